@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/llm"
+	"github.com/kamusis/axon-cli/internal/search"
+	"github.com/spf13/cobra"
+)
+
+var askCmd = &cobra.Command{
+	Use:   "ask <question>",
+	Short: "Answer a question from the skills in your Hub",
+	Long: `Find the skills, workflows, and commands most relevant to a question and
+have a chat model answer it from them, citing the documents it used.
+
+Retrieval uses the semantic index when available and keyword search
+otherwise, like 'axon search'. Asking is opt-in: it needs its own chat
+provider in the environment or ~/.axon/.env:
+
+  AXON_ASK_PROVIDER=openai
+  AXON_ASK_API_KEY=...
+  AXON_ASK_MODEL=gpt-4o-mini          # optional
+  AXON_ASK_BASE_URL=...               # optional, any OpenAI-compatible API
+
+The retrieved documents are sent to the provider. Use --dry-run to see
+exactly what would be sent.
+
+Examples:
+  axon ask "how do I rotate oracle passwords"
+  axon ask --k 3 "release checklist for the mobile app"
+  axon ask --dry-run "which skill formats commit messages"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAsk,
+}
+
+var (
+	flagAskK      int
+	flagAskDryRun bool
+)
+
+func init() {
+	askCmd.Flags().IntVar(&flagAskK, "k", 5, "Number of documents to retrieve")
+	askCmd.Flags().BoolVar(&flagAskDryRun, "dry-run", false, "Print the prompt instead of calling the provider")
+	rootCmd.AddCommand(askCmd)
+}
+
+// Context budget: each document is cut to askDocChars and the whole context
+// to askContextChars, which keeps prompts within small models' windows.
+const (
+	askDocChars     = 6000
+	askContextChars = 24000
+)
+
+const askSystemPrompt = `You answer questions about the user's AI agent skills, workflows, and commands.
+Answer only from the numbered documents provided. Cite the documents you use
+inline by number and path, for example [1] skills/git-release/SKILL.md.
+If the documents do not answer the question, say so instead of guessing.`
+
+// askSource is one retrieved document in the context.
+type askSource struct {
+	Path    string // Hub-relative, slash-separated
+	Content string
+}
+
+func runAsk(_ *cobra.Command, args []string) error {
+	question := strings.TrimSpace(strings.Join(args, " "))
+	if question == "" {
+		return errors.New("question is empty")
+	}
+	if flagAskK < 1 {
+		return errors.New("--k must be at least 1")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+
+	var provider llm.Provider
+	if !flagAskDryRun {
+		provider, err = llm.LoadAskProvider()
+		if err != nil {
+			return fmt.Errorf("failed to load ask provider: %w", err)
+		}
+		if provider == nil {
+			return errors.New("axon ask is not configured. Set AXON_ASK_PROVIDER, AXON_ASK_API_KEY, and optionally AXON_ASK_MODEL in ~/.axon/.env")
+		}
+	}
+
+	results, err := askRetrieve(cfg, question, flagAskK)
+	if err != nil {
+		return err
+	}
+	sources := askSources(cfg, results)
+	if len(sources) == 0 {
+		return fmt.Errorf("no skills in the Hub match %q", question)
+	}
+	messages := askMessages(question, sources)
+
+	if flagAskDryRun {
+		for _, m := range messages {
+			fmt.Printf("--- %s ---\n%s\n\n", m.Role, m.Content)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	resp, err := provider.Chat(ctx, messages)
+	if err != nil {
+		return fmt.Errorf("ask failed: %w", err)
+	}
+
+	fmt.Println(strings.TrimSpace(resp.Content))
+	printSection("Sources")
+	for i, s := range sources {
+		fmt.Printf("  [%d] %s\n", i+1, s.Path)
+	}
+	return nil
+}
+
+// askRetrieve returns the k documents most relevant to question, from
+// semantic search when it is available and keyword search otherwise.
+func askRetrieve(cfg *config.Config, question string, k int) ([]search.SearchResult, error) {
+	if res, err := semanticSearch(cfg, question, 0, k, search.Filter{}); err == nil && len(res) > 0 {
+		return res, nil
+	}
+	return keywordSearch(cfg, question, k, search.Filter{})
+}
+
+// askSources reads the documents behind results, trimmed to the context
+// budget. Documents that cannot be read are skipped.
+func askSources(cfg *config.Config, results []search.SearchResult) []askSource {
+	var (
+		out    []askSource
+		budget = askContextChars
+	)
+	for _, r := range results {
+		if budget <= 0 {
+			break
+		}
+		file, err := searchResultFile(cfg, r)
+		if err != nil {
+			continue
+		}
+		b, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		content := truncateRunes(strings.TrimSpace(string(b)), min(askDocChars, budget))
+		budget -= len([]rune(content))
+		out = append(out, askSource{Path: askSourcePath(r, file), Content: content})
+	}
+	return out
+}
+
+// askSourcePath is the citation for a document: its path in the Hub,
+// prefixed with the repo name when several repos are configured.
+func askSourcePath(r search.SearchResult, file string) string {
+	p := r.Skill.Path + "/" + filepath.Base(file)
+	if r.Skill.Repo != "" {
+		p = r.Skill.Repo + ":" + p
+	}
+	return p
+}
+
+func askMessages(question string, sources []askSource) []llm.Message {
+	var b strings.Builder
+	for i, s := range sources {
+		fmt.Fprintf(&b, "[%d] %s\n%s\n\n", i+1, s.Path, s.Content)
+	}
+	fmt.Fprintf(&b, "Question: %s", question)
+	return []llm.Message{
+		{Role: "system", Content: askSystemPrompt},
+		{Role: "user", Content: b.String()},
+	}
+}
+
+// truncateRunes cuts s to at most n runes, marking the cut.
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "\n[…truncated]"
+}
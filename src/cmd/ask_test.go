@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAsk_RetrievesAndCitesDocuments(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	t.Setenv("AXON_EMBEDDINGS_PROVIDER", "")
+	skill := filepath.Join(cfg.RepoPath, "skills", "oracle-admin", "SKILL.md")
+	if err := os.MkdirAll(filepath.Dir(skill), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	body := "---\nname: oracle-admin\ndescription: rotate oracle passwords\n---\nRun ALTER USER ... IDENTIFIED BY.\n" + strings.Repeat("x", askDocChars)
+	if err := os.WriteFile(skill, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a semantic index, retrieval falls back to keywords.
+	results, err := askRetrieve(cfg, "rotate oracle passwords", 3)
+	if err != nil || len(results) == 0 || results[0].Skill.ID != "oracle-admin" {
+		t.Fatalf("askRetrieve = %+v, %v", results, err)
+	}
+
+	sources := askSources(cfg, results)
+	if len(sources) != 1 || sources[0].Path != "skills/oracle-admin/SKILL.md" {
+		t.Fatalf("sources = %+v", sources)
+	}
+	if !strings.Contains(sources[0].Content, "ALTER USER") || !strings.HasSuffix(sources[0].Content, "[…truncated]") {
+		t.Errorf("source content not read or not truncated: %d runes", len([]rune(sources[0].Content)))
+	}
+
+	msgs := askMessages("how do I rotate oracle passwords", sources)
+	if len(msgs) != 2 || msgs[0].Role != "system" {
+		t.Fatalf("messages = %+v", msgs)
+	}
+	if !strings.HasPrefix(msgs[1].Content, "[1] skills/oracle-admin/SKILL.md\n") || !strings.HasSuffix(msgs[1].Content, "Question: how do I rotate oracle passwords") {
+		t.Errorf("user message = %q", msgs[1].Content)
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	searchindex "github.com/kamusis/axon-cli/internal/search/index"
+	"github.com/spf13/cobra"
+)
+
+var bootstrapCmd = &cobra.Command{
+	Use:     "bootstrap <hub-remote-url>",
+	Aliases: []string{"clone"},
+	Short:   "Set up a new machine from an existing Hub in one step",
+	Long: `Set up a new machine from the Hub at <hub-remote-url>:
+
+  1. axon init <hub-remote-url>   clone the Hub (or start it, if the remote is empty)
+  2. axon link                     link every installed tool
+  3. search index                  build the semantic index when an embeddings
+                                   provider is configured, else use the Hub's
+                                   published one; the keyword index is always built
+  4. axon doctor                   check the result
+
+A readiness checklist is printed at the end. Bootstrap stops if init fails;
+otherwise every step runs, so the checklist shows everything left to fix.
+A Hub that already exists is reused only when its origin is the same URL.
+
+  axon bootstrap git@github.com:me/axon-hub.git`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBootstrap,
+}
+
+var flagBootstrapNoIndex bool
+
+func init() {
+	bootstrapCmd.Flags().BoolVar(&flagBootstrapNoIndex, "no-index", false, "Skip building the search index")
+	rootCmd.AddCommand(bootstrapCmd)
+}
+
+// bootstrapStep is one line of the readiness checklist.
+type bootstrapStep struct {
+	name   string
+	state  string // "ok", "warn", "error", "skip"
+	detail string
+}
+
+func runBootstrap(cmd *cobra.Command, args []string) error {
+	var steps []bootstrapStep
+	add := func(name, state, detail string) {
+		steps = append(steps, bootstrapStep{name, state, detail})
+	}
+
+	// ── 1. Init ───────────────────────────────────────────────────────────────
+	printSection("Bootstrap 1/4: init")
+	if err := checkBootstrapHub(args[0]); err != nil {
+		return err
+	}
+	if err := runInit(cmd, args); err != nil {
+		add("Hub", "error", err.Error())
+		_ = printBootstrapChecklist(steps)
+		return fmt.Errorf("bootstrap stopped: %w", err)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w", err)
+	}
+	add("Hub", "ok", fmt.Sprintf("%s (origin %s)", cfg.RepoPath, args[0]))
+
+	// ── 2. Link ───────────────────────────────────────────────────────────────
+	printSection("Bootstrap 2/4: link")
+	if err := runLink(cmd, nil); err != nil {
+		add("Links", "error", err.Error())
+	} else {
+		add("Links", "ok", "every installed tool is linked")
+	}
+
+	// ── 3. Search index ───────────────────────────────────────────────────────
+	printSection("Bootstrap 3/4: search index")
+	if flagBootstrapNoIndex {
+		add("Search", "skip", "--no-index")
+	} else {
+		state, detail := bootstrapSearchIndex(cmd, cfg)
+		add("Search", state, detail)
+	}
+
+	// ── 4. Doctor ─────────────────────────────────────────────────────────────
+	printSection("Bootstrap 4/4: doctor")
+	fmt.Println()
+	results := gatherDiagnostics()
+	printDiagnostics(results)
+	switch errs, warns := countIssues(results); {
+	case errs > 0:
+		add("Doctor", "error", fmt.Sprintf("%d error(s), %d warning(s); run 'axon doctor --fix'", errs, warns))
+	case warns > 0:
+		add("Doctor", "warn", fmt.Sprintf("%d warning(s); see 'axon doctor'", warns))
+	default:
+		add("Doctor", "ok", "all checks passed")
+	}
+
+	return printBootstrapChecklist(steps)
+}
+
+// checkBootstrapHub refuses to bootstrap over an existing Hub whose origin
+// is not remote, which init would otherwise silently keep.
+func checkBootstrapHub(remote string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(cfg.RepoPath, ".git")); err != nil {
+		return nil
+	}
+	if existing := originURL(cfg.RepoPath); existing != "" && existing != remote {
+		return fmt.Errorf("a Hub already exists at %s with origin %s\n"+
+			"Run 'axon remote set %s' to switch it, then 'axon sync' and 'axon link'.", cfg.RepoPath, existing, remote)
+	}
+	return nil
+}
+
+// bootstrapSearchIndex makes search work on the new machine: a semantic
+// index when an embeddings provider is configured, the Hub's published
+// index otherwise, and a keyword index either way.
+func bootstrapSearchIndex(cmd *cobra.Command, cfg *config.Config) (state, detail string) {
+	if dir, err := keywordIndexDir(); err == nil {
+		if _, err := refreshKeywordIndex(cfg, dir, false); err != nil {
+			return "warn", fmt.Sprintf("keyword index not built: %v", err)
+		}
+	}
+
+	prov, err := searchEmbeddingsProvider()
+	if err == nil && prov.ModelID() != "" {
+		if err := runSearchIndex(cmd, cfg); err != nil {
+			return "warn", fmt.Sprintf("semantic index not built: %v; keyword search works", err)
+		}
+		return "ok", "semantic and keyword indexes built"
+	}
+	if _, err := searchindex.Load(filepath.Join(cfg.RepoPath, "search")); err == nil {
+		printOK("", "using the semantic index published in the Hub")
+		return "ok", "keyword index built; semantic search uses the Hub's published index"
+	}
+	printSkip("", "no embeddings provider configured; semantic search is not available")
+	return "warn", "keyword index only; configure an embeddings provider in ~/.axon/.env and run 'axon search --index'"
+}
+
+// printBootstrapChecklist prints the readiness checklist and returns an
+// error when any step failed.
+func printBootstrapChecklist(steps []bootstrapStep) error {
+	printSection("Readiness")
+	failed := 0
+	for _, s := range steps {
+		switch s.state {
+		case "ok":
+			printOK(s.name, s.detail)
+		case "warn":
+			printWarn(s.name, s.detail)
+		case "skip":
+			printSkip(s.name, s.detail)
+		default:
+			printErr(s.name, s.detail)
+			failed++
+		}
+	}
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("bootstrap finished with %d failed step(s)", failed)
+	}
+	printOK("", "This machine is ready. Run 'axon sync' to pull future changes.")
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newBootstrapRemote returns a bare repo holding one skill, pushed from a
+// scratch clone under tmp.
+func newBootstrapRemote(t *testing.T, tmp string) string {
+	t.Helper()
+	origin := filepath.Join(tmp, "origin.git")
+	seed := filepath.Join(tmp, "seed")
+	if err := os.MkdirAll(filepath.Join(seed, "skills", "demo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(seed, "skills", "demo", "SKILL.md"), []byte("---\nname: demo\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"-C", tmp, "init", "-q", "--bare", origin},
+		{"-C", seed, "init", "-q", "-b", "master"},
+		{"-C", seed, "-c", "user.email=test@axon.local", "-c", "user.name=Axon Test", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"-C", seed, "add", "."},
+		{"-C", seed, "-c", "user.email=test@axon.local", "-c", "user.name=Axon Test", "commit", "-q", "-m", "demo skill"},
+		{"-C", seed, "push", "-q", origin, "master"},
+	} {
+		if err := gitRun(args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	return origin
+}
+
+func TestBootstrap(t *testing.T) {
+	tmp := t.TempDir()
+	// init clones without -C; an earlier test may have removed the cwd.
+	t.Chdir(tmp)
+	home := filepath.Join(tmp, "home")
+	t.Setenv("HOME", home)
+	t.Setenv("AXON_EMBEDDINGS_PROVIDER", "")
+	if err := os.MkdirAll(filepath.Join(home, ".claude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	origin := newBootstrapRemote(t, tmp)
+
+	// Doctor may report environment-specific problems; the steps before it
+	// are what matter here.
+	_ = runBootstrap(bootstrapCmd, []string{origin})
+
+	hub := filepath.Join(home, ".axon", "repo")
+	if _, err := os.Stat(filepath.Join(hub, "skills", "demo", "SKILL.md")); err != nil {
+		t.Fatalf("Hub not cloned: %v", err)
+	}
+	dest := filepath.Join(home, ".claude", "skills")
+	if target, err := os.Readlink(dest); err != nil || target != filepath.Join(hub, "skills") {
+		t.Errorf("%s → %q, %v; want a link to the Hub's skills", dest, target, err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".axon", "search")); err != nil {
+		t.Errorf("keyword index not built: %v", err)
+	}
+
+	err := runBootstrap(bootstrapCmd, []string{filepath.Join(tmp, "other.git")})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("bootstrap over a Hub with another origin = %v, want refused", err)
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// clipboardCommands lists the clipboard writers to try on this platform, in
+// order of preference.
+func clipboardCommands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	}
+	var cmds [][]string
+	if config.IsWSL() {
+		cmds = append(cmds, []string{"clip.exe"})
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		cmds = append(cmds, []string{"wl-copy"})
+	}
+	return append(cmds,
+		[]string{"xclip", "-selection", "clipboard"},
+		[]string{"xsel", "--clipboard", "--input"},
+	)
+}
+
+// copyToClipboard pipes data into the first available clipboard writer.
+func copyToClipboard(data []byte) error {
+	for _, args := range clipboardCommands() {
+		bin, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+		c := exec.Command(bin, args[1:]...)
+		c.Stdin = bytes.NewReader(data)
+		if out, err := c.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s failed: %v: %s", args[0], err, bytes.TrimSpace(out))
+		}
+		return nil
+	}
+	return errors.New("no clipboard tool found (install xclip, xsel, or wl-clipboard)")
+}
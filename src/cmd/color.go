@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// ── Colored output ────────────────────────────────────────────────────────────
+// The output helpers color their icons and headers when the stream they
+// write to is a terminal. NO_COLOR (https://no-color.org) and TERM=dumb turn
+// colors off; --color always|never overrides both.
+
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// ANSI SGR codes.
+const (
+	sgrBold   = "1"
+	sgrDim    = "2"
+	sgrRed    = "31"
+	sgrGreen  = "32"
+	sgrYellow = "33"
+	sgrCyan   = "36"
+)
+
+var flagColor = colorAuto
+
+// colorStdout and colorStderr are set once by setupColor, before a command
+// runs; both stay off in tests.
+var colorStdout, colorStderr bool
+
+// iconColors maps the icons of the output helpers to their color.
+var iconColors = map[string]string{
+	iconOK:      sgrGreen,
+	iconError:   sgrRed,
+	iconWarn:    sgrYellow,
+	iconSkip:    sgrDim,
+	iconMiss:    sgrDim,
+	iconInfo:    sgrCyan,
+	iconBackup:  sgrCyan,
+	iconRestore: sgrCyan,
+}
+
+// setupColor decides from mode (--color) whether stdout and stderr get
+// colors.
+func setupColor(mode string) error {
+	switch mode {
+	case colorAuto, colorAlways, colorNever:
+	default:
+		return fmt.Errorf("invalid --color %q (want auto, always, or never)", mode)
+	}
+	colorStdout = wantColor(mode, os.Stdout.Fd())
+	colorStderr = wantColor(mode, os.Stderr.Fd())
+	return nil
+}
+
+// wantColor reports whether output on fd should be colored under mode.
+func wantColor(mode string, fd uintptr) bool {
+	switch mode {
+	case colorNever:
+		return false
+	case colorAlways:
+		// Still switch a Windows console to escape sequences if it can be.
+		if isTerminal(fd) {
+			enableEscapes(fd)
+		}
+		return true
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(fd) && enableEscapes(fd)
+}
+
+// paint wraps s in the SGR code when on is set.
+func paint(on bool, code, s string) string {
+	if !on || code == "" {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
+
+// tint colors an icon for stdout; tintErr for stderr.
+func tint(icon string) string    { return paint(colorStdout, iconColors[icon], icon) }
+func tintErr(icon string) string { return paint(colorStderr, iconColors[icon], icon) }
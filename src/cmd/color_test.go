@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestWantColor(t *testing.T) {
+	// A pipe is never a terminal.
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+	if wantColor(colorAuto, w.Fd()) {
+		t.Error("auto must not color a pipe")
+	}
+	if !wantColor(colorAlways, w.Fd()) {
+		t.Error("always must color a pipe")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if !wantColor(colorAlways, w.Fd()) {
+		t.Error("--color always must override NO_COLOR")
+	}
+	if wantColor(colorNever, w.Fd()) {
+		t.Error("never must not color")
+	}
+}
+
+func TestSetupColor_RejectsUnknownMode(t *testing.T) {
+	t.Cleanup(func() { colorStdout, colorStderr = false, false })
+	if err := setupColor("sometimes"); err == nil {
+		t.Error("expected an error for --color sometimes")
+	}
+	if err := setupColor(colorAlways); err != nil || !colorStdout || !colorStderr {
+		t.Errorf("always: err=%v stdout=%v stderr=%v", err, colorStdout, colorStderr)
+	}
+	if got := tint(iconOK); got != "\x1b[32m✓\x1b[0m" {
+		t.Errorf("tint(iconOK) = %q", got)
+	}
+	if got := tint(iconItem); got != iconItem {
+		t.Errorf("uncolored icons must be left alone, got %q", got)
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var completionInstallCmd = &cobra.Command{
+	Use:   "install [bash|zsh|fish|powershell]",
+	Short: "Install shell completion for axon",
+	Long: `Write the completion script for your shell where the shell finds it, and
+hook it into the shell profile if the shell does not load it by itself.
+
+The shell is detected from $SHELL (PowerShell on Windows) unless given:
+
+  shell        script                                            profile
+  bash         ~/.local/share/bash-completion/completions/axon   ~/.bashrc
+  zsh          ~/.zsh/completions/_axon                          ~/.zshrc
+  fish         ~/.config/fish/completions/axon.fish              (loaded automatically)
+  powershell   ~/.axon/completion/axon.ps1                       (add it to $PROFILE)
+
+Adding the 'source' line to the profile asks first; --yes adds it without
+asking, --no-profile only writes the script. Running the command again
+refreshes the script and leaves a profile that already loads it alone.
+'axon doctor' reports when completion is not active.
+
+Examples:
+  axon completion install
+  axon completion install zsh --yes`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: completionShells,
+	RunE:      runCompletionInstall,
+}
+
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+var (
+	flagCompletionYes       bool
+	flagCompletionNoProfile bool
+)
+
+func init() {
+	completionInstallCmd.Flags().BoolVarP(&flagCompletionYes, "yes", "y", false, "Add the completion to the shell profile without asking")
+	completionInstallCmd.Flags().BoolVar(&flagCompletionNoProfile, "no-profile", false, "Only write the completion script; leave the shell profile alone")
+	// Install below cobra's generated 'completion' command, next to the
+	// per-shell script generators.
+	rootCmd.InitDefaultCompletionCmd()
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == "completion" {
+			c.AddCommand(completionInstallCmd)
+		}
+	}
+}
+
+// completionTarget is where the completion of one shell is installed.
+type completionTarget struct {
+	Shell   string
+	Script  string // the completion script file
+	Profile string // the startup file that must source Script; "" when the shell loads it by itself
+}
+
+// detectShell returns the user's shell: the base name of $SHELL, or
+// powershell on Windows when $SHELL is unset. It returns "" when the shell
+// is unknown or not supported.
+func detectShell() string {
+	sh := os.Getenv("SHELL")
+	if sh == "" {
+		if runtime.GOOS == "windows" {
+			return "powershell"
+		}
+		return ""
+	}
+	name := strings.TrimSuffix(filepath.Base(sh), ".exe")
+	if name == "pwsh" {
+		name = "powershell"
+	}
+	for _, s := range completionShells {
+		if s == name {
+			return name
+		}
+	}
+	return ""
+}
+
+// completionTargetFor returns the install locations for shell.
+func completionTargetFor(shell string) (completionTarget, error) {
+	home, err := config.UserHome()
+	if err != nil {
+		return completionTarget{}, err
+	}
+	xdg := func(env string, def ...string) string {
+		if v := os.Getenv(env); filepath.IsAbs(v) {
+			return v
+		}
+		return filepath.Join(append([]string{home}, def...)...)
+	}
+	switch shell {
+	case "bash":
+		return completionTarget{
+			Shell:   shell,
+			Script:  filepath.Join(xdg("XDG_DATA_HOME", ".local", "share"), "bash-completion", "completions", "axon"),
+			Profile: filepath.Join(home, ".bashrc"),
+		}, nil
+	case "zsh":
+		zdot := home
+		if v := os.Getenv("ZDOTDIR"); filepath.IsAbs(v) {
+			zdot = v
+		}
+		return completionTarget{
+			Shell:   shell,
+			Script:  filepath.Join(home, ".zsh", "completions", "_axon"),
+			Profile: filepath.Join(zdot, ".zshrc"),
+		}, nil
+	case "fish":
+		return completionTarget{
+			Shell:  shell,
+			Script: filepath.Join(xdg("XDG_CONFIG_HOME", ".config"), "fish", "completions", "axon.fish"),
+		}, nil
+	case "powershell":
+		axonDir, err := config.AxonDir()
+		if err != nil {
+			return completionTarget{}, err
+		}
+		return completionTarget{Shell: shell, Script: filepath.Join(axonDir, "completion", "axon.ps1")}, nil
+	}
+	return completionTarget{}, fmt.Errorf("unsupported shell %q (want one of %s)", shell, strings.Join(completionShells, ", "))
+}
+
+// generateCompletion returns the completion script of axon for shell.
+func generateCompletion(shell string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch shell {
+	case "bash":
+		err = rootCmd.GenBashCompletionV2(&buf, true)
+	case "zsh":
+		err = rootCmd.GenZshCompletion(&buf)
+	case "fish":
+		err = rootCmd.GenFishCompletion(&buf, true)
+	case "powershell":
+		err = rootCmd.GenPowerShellCompletionWithDesc(&buf)
+	default:
+		err = fmt.Errorf("unsupported shell %q", shell)
+	}
+	return buf.Bytes(), err
+}
+
+// completionSourceLine is the profile line that loads script.
+func completionSourceLine(script string) string {
+	return fmt.Sprintf("[ -f %q ] && source %q", script, script)
+}
+
+// profileSourcesCompletion reports whether the profile at path mentions
+// script, i.e. already loads the completion.
+func profileSourcesCompletion(path, script string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && bytes.Contains(data, []byte(script))
+}
+
+// installCompletion writes the completion script of t and, when addProfile
+// is set and the profile does not load it yet, appends the source line to
+// the profile. It reports whether the profile was changed.
+func installCompletion(t completionTarget, addProfile bool) (bool, error) {
+	script, err := generateCompletion(t.Shell)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.Script), 0o755); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(t.Script, script, 0o644); err != nil {
+		return false, err
+	}
+	if t.Profile == "" || !addProfile || profileSourcesCompletion(t.Profile, t.Script) {
+		return false, nil
+	}
+	f, err := os.OpenFile(t.Profile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return false, err
+	}
+	_, err = fmt.Fprintf(f, "\n# axon shell completion\n%s\n", completionSourceLine(t.Script))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err == nil, err
+}
+
+func runCompletionInstall(_ *cobra.Command, args []string) error {
+	shell := detectShell()
+	if len(args) == 1 {
+		shell = args[0]
+	}
+	if shell == "" {
+		return fmt.Errorf("cannot detect your shell from $SHELL; name it, e.g. 'axon completion install bash'")
+	}
+	t, err := completionTargetFor(shell)
+	if err != nil {
+		return err
+	}
+
+	printSection("Shell Completion")
+	needsProfile := t.Profile != "" && !profileSourcesCompletion(t.Profile, t.Script)
+	addProfile := needsProfile && !flagCompletionNoProfile &&
+		(flagCompletionYes || confirm(os.Stdin, fmt.Sprintf("Add a line loading the completion to %s?", t.Profile)))
+	changed, err := installCompletion(t, addProfile)
+	if err != nil {
+		return err
+	}
+	printOK(shell, "completion script written: "+t.Script)
+	switch {
+	case changed:
+		printOK(shell, "loaded from "+t.Profile+"; open a new shell to use it")
+	case needsProfile:
+		printInfo(shell, "to load it, add this line to "+t.Profile+":")
+		outf("     %s\n", completionSourceLine(t.Script))
+	case t.Shell == "powershell":
+		printInfo(shell, "to load it, add this line to your $PROFILE:")
+		outf("     . '%s'\n", t.Script)
+	default:
+		printOK(shell, "open a new shell to use it")
+	}
+	return nil
+}
+
+// checkCompletion reports whether completion is installed and loaded for
+// the user's shell. Unknown shells pass, since there is nothing to check.
+func checkCompletion() []DiagnosticResult {
+	cat := "Shell Completion"
+	shell := detectShell()
+	if shell == "" {
+		return []DiagnosticResult{{Category: cat, Passed: true, Message: "shell not detected from $SHELL — skipped"}}
+	}
+	t, err := completionTargetFor(shell)
+	if err != nil {
+		return []DiagnosticResult{{Category: cat, Item: shell, Passed: true, Message: err.Error()}}
+	}
+	fix := func() error {
+		_, err := installCompletion(t, true)
+		return err
+	}
+	if _, err := os.Stat(t.Script); err != nil {
+		return []DiagnosticResult{{
+			Category: cat, Item: shell, Passed: false, Severity: DiagnosticSeverityWarn,
+			Message:     "completion is not installed",
+			Remediation: "run 'axon completion install', or 'axon doctor --fix'",
+			CanFix:      true, FixAction: fix,
+		}}
+	}
+	if t.Profile != "" && !profileSourcesCompletion(t.Profile, t.Script) {
+		return []DiagnosticResult{{
+			Category: cat, Item: shell, Passed: false, Severity: DiagnosticSeverityWarn,
+			Message:     fmt.Sprintf("%s does not load %s", t.Profile, t.Script),
+			Remediation: "run 'axon completion install', or 'axon doctor --fix'",
+			CanFix:      true, FixAction: fix,
+		}}
+	}
+	return []DiagnosticResult{{Category: cat, Item: shell, Passed: true, Message: "completion installed: " + t.Script}}
+}
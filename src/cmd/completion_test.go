@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectShell(t *testing.T) {
+	for sh, want := range map[string]string{
+		"/bin/bash":          "bash",
+		"/usr/local/bin/zsh": "zsh",
+		"/usr/bin/fish":      "fish",
+		"/usr/bin/pwsh":      "powershell",
+		"/bin/tcsh":          "",
+	} {
+		t.Setenv("SHELL", sh)
+		if got := detectShell(); got != want {
+			t.Errorf("SHELL=%s: detectShell = %q, want %q", sh, got, want)
+		}
+	}
+}
+
+func TestInstallCompletion_Zsh(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("ZDOTDIR", "")
+	t.Setenv("SHELL", "/bin/zsh")
+
+	if res := checkCompletion(); len(res) != 1 || res[0].Passed || !res[0].CanFix {
+		t.Fatalf("before install: checkCompletion = %+v, want a fixable warning", res)
+	}
+
+	tgt, err := completionTargetFor("zsh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed, err := installCompletion(tgt, false); err != nil || changed {
+		t.Fatalf("installCompletion without profile = %v, %v", changed, err)
+	}
+	if res := checkCompletion(); res[0].Passed || !strings.Contains(res[0].Message, "does not load") {
+		t.Errorf("script only: checkCompletion = %+v, want the missing profile line", res)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := installCompletion(tgt, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rc, err := os.ReadFile(filepath.Join(home, ".zshrc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(rc), completionSourceLine(tgt.Script)); n != 1 {
+		t.Errorf(".zshrc loads the completion %d times, want once:\n%s", n, rc)
+	}
+	script, _ := os.ReadFile(tgt.Script)
+	if !strings.HasPrefix(string(script), "#compdef axon") {
+		t.Errorf("the zsh script starts with %.40q", script)
+	}
+	if res := checkCompletion(); !res[0].Passed {
+		t.Errorf("after install: checkCompletion = %+v", res)
+	}
+}
+
+func TestInstallCompletion_FishNeedsNoProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	tgt, err := completionTargetFor("fish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tgt.Profile != "" || tgt.Script != filepath.Join(home, ".config", "fish", "completions", "axon.fish") {
+		t.Errorf("fish target = %+v", tgt)
+	}
+	if _, err := completionTargetFor("tcsh"); err == nil {
+		t.Error("an unsupported shell should be an error")
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Check the axon.yaml configuration",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Validate axon.yaml and report problems with line numbers",
+	Long: `Check ~/.axon/axon.yaml (or the given file) against the config schema and
+list every problem with its line number:
+
+  - unknown keys, such as a misspelled 'destination'
+  - targets without a name, source, or destination
+  - duplicate target names, and targets of one tool sharing a destination
+  - targets linking different content to one path, or nested destinations
+  - a sync_mode other than read-only or read-write
+  - paths whose ~ or {{template}} cannot be expanded
+
+The checks every command runs when loading the config are reported too.
+Other commands only warn about unknown keys and the like, so a typo does not
+stop them; this command exits with an error when it finds any problem.
+
+Examples:
+  axon config validate
+  axon config validate ./axon.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigValidate,
+}
+
+func init() {
+	config.Warn = warnConfigProblem
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+var (
+	configWarnMu      sync.Mutex
+	configWarnSeen    = make(map[string]bool)
+	configWarnPending []string
+	configWarnReady   bool
+)
+
+// warnConfigProblem prints a problem config.Load tolerated, once per run
+// even though commands load the config several times. Warnings from before
+// the command is known (the language setup loads the config) wait for
+// flushConfigWarnings.
+func warnConfigProblem(path string, p config.Problem) {
+	msg := fmt.Sprintf("%s: %s (run 'axon config validate')", path, p)
+	configWarnMu.Lock()
+	defer configWarnMu.Unlock()
+	if configWarnSeen[msg] {
+		return
+	}
+	configWarnSeen[msg] = true
+	if !configWarnReady {
+		configWarnPending = append(configWarnPending, msg)
+		return
+	}
+	errf("  %s  %s\n", tintErr(iconWarn), msg)
+}
+
+// flushConfigWarnings prints the warnings held back until cmd was known,
+// except for 'axon config validate', which reports them itself.
+func flushConfigWarnings(cmd *cobra.Command) {
+	configWarnMu.Lock()
+	pending := configWarnPending
+	configWarnPending, configWarnReady = nil, true
+	configWarnMu.Unlock()
+	if cmd == configValidateCmd {
+		return
+	}
+	for _, msg := range pending {
+		errf("  %s  %s\n", tintErr(iconWarn), msg)
+	}
+}
+
+func runConfigValidate(_ *cobra.Command, args []string) error {
+	path, err := config.ConfigPath()
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		path = args[0]
+	}
+	problems, err := config.ValidateFile(path)
+	if err != nil {
+		return err
+	}
+
+	printSection("Config Validate")
+	if len(problems) == 0 {
+		printOK("", path+" is valid")
+		return nil
+	}
+	for _, p := range problems {
+		printErr("", p.String())
+	}
+	return fmt.Errorf("%s has %d problem(s)", path, len(problems))
+}
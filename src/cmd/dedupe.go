@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/dedupe"
+	"github.com/kamusis/axon-cli/internal/frontmatter"
+	searchindex "github.com/kamusis/axon-cli/internal/search/index"
+	"github.com/spf13/cobra"
+)
+
+var dedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Find duplicate and near-duplicate skills in the Hub",
+	Long: `Group skills that are copies or near-copies of each other, across every
+search root and Hub repo:
+
+  identical   the same files with the same contents
+  similar     SKILL.md bodies that share at least --threshold of their
+              three-word phrases, or, when the semantic index loads,
+              embeddings with a cosine similarity of at least 0.95
+
+With --interactive, axon asks what to do with each group:
+
+  k<N>   keep skill N and move the others to the trash
+  m<N>   merge: copy the files skill N lacks from the others, then move
+         the others to the trash
+  s      skip this group
+  q      stop
+
+Skills in read-only repos are never removed or merged into; keep one of
+them, or fix the duplicate upstream.
+
+Removed skills can be brought back with 'axon trash restore'. Run
+'axon sync' afterwards to commit the changes.
+
+Examples:
+  axon dedupe
+  axon dedupe --threshold 0.6
+  axon dedupe --interactive`,
+	Args: cobra.NoArgs,
+	RunE: runDedupe,
+}
+
+var (
+	flagDedupeThreshold   float64
+	flagDedupeInteractive bool
+	flagDedupeNoIndex     bool
+)
+
+// dedupeVectorThreshold is the cosine similarity at which two skills'
+// embeddings count as duplicates.
+const dedupeVectorThreshold = 0.95
+
+func init() {
+	dedupeCmd.Flags().Float64Var(&flagDedupeThreshold, "threshold", 0.8, "Share of SKILL.md phrases two skills must have in common (0-1)")
+	dedupeCmd.Flags().BoolVarP(&flagDedupeInteractive, "interactive", "i", false, "Ask to keep, merge, or skip each duplicate group")
+	dedupeCmd.Flags().BoolVar(&flagDedupeNoIndex, "no-index", false, "Compare text only, without semantic index embeddings")
+	rootCmd.AddCommand(dedupeCmd)
+}
+
+func runDedupe(cmd *cobra.Command, _ []string) error {
+	if flagDedupeThreshold <= 0 || flagDedupeThreshold > 1 {
+		return fmt.Errorf("--threshold must be in (0, 1]")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+
+	var vectors map[string][]float32
+	if !flagDedupeNoIndex {
+		vectors = indexSkillVectors(cfg)
+	}
+	items, err := dedupeItems(cfg, vectors)
+	if err != nil {
+		return err
+	}
+	opts := dedupe.Options{TextThreshold: flagDedupeThreshold}
+	if len(vectors) > 0 {
+		opts.VectorThreshold = dedupeVectorThreshold
+	}
+	groups := dedupe.Cluster(items, opts)
+
+	printSection("Duplicate skills")
+	if len(groups) == 0 {
+		printOK("", fmt.Sprintf("no duplicates among %d skill(s)", len(items)))
+		return nil
+	}
+	in, out := bufio.NewReader(cmd.InOrStdin()), cmd.OutOrStdout()
+	readOnly := readOnlyRepoPaths(cfg)
+	changed := 0
+	for gi, g := range groups {
+		printDedupeGroup(gi+1, g)
+		if !flagDedupeInteractive {
+			continue
+		}
+		action, n, quit := askDedupeAction(in, out, len(g.Items))
+		if quit {
+			break
+		}
+		if action == "" {
+			continue
+		}
+		if err := resolveDedupeGroup(g, action, n, readOnly); err != nil {
+			printErr("", err.Error())
+			continue
+		}
+		changed++
+	}
+
+	fmt.Println()
+	if changed > 0 {
+		printOK("", fmt.Sprintf("%d group(s) resolved; removed skills are in the trash", changed))
+		fmt.Println("  Run 'axon sync' to commit the changes.")
+	} else if !flagDedupeInteractive {
+		printInfo("", fmt.Sprintf("%d duplicate group(s). Run 'axon dedupe --interactive' to resolve them.", len(groups)))
+	}
+	return nil
+}
+
+// dedupeItems returns every skill directory in every Hub repo, with its
+// tree digest, SKILL.md shingles, and embedding from vectors (see
+// indexSkillVectors). Unlike loadSkillGraph it keeps skills that share a
+// name across repos, since those are duplicates too.
+func dedupeItems(cfg *config.Config, vectors map[string][]float32) ([]dedupe.Item, error) {
+	repos := cfg.HubRepos()
+	var items []dedupe.Item
+	for _, repo := range repos {
+		for _, root := range cfg.EffectiveSearchRoots() {
+			entries, err := os.ReadDir(filepath.Join(repo.Path, root))
+			if err != nil {
+				continue
+			}
+			for _, e := range entries {
+				if !e.IsDir() {
+					continue
+				}
+				dir := filepath.Join(repo.Path, root, e.Name())
+				data, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+				if err != nil {
+					continue
+				}
+				digest, err := dedupe.TreeDigest(dir)
+				if err != nil {
+					return nil, fmt.Errorf("cannot hash %s: %w", dir, err)
+				}
+				body := data
+				if doc, err := frontmatter.Parse(data); err == nil {
+					body = doc.Body()
+				}
+				id := root + "/" + e.Name()
+				if len(repos) > 1 {
+					id = repo.Name + ":" + id
+				}
+				v := vectors[repo.Name+"\x00"+e.Name()]
+				if v == nil {
+					v = vectors["\x00"+e.Name()]
+				}
+				items = append(items, dedupe.Item{ID: id, Dir: dir, Digest: digest, Words: dedupe.Shingles(string(body)), Vector: v})
+			}
+		}
+	}
+	return items, nil
+}
+
+// indexSkillVectors returns the normalized name-and-description embedding
+// of each document in the semantic index, keyed by repo and ID separated by
+// a NUL. It returns nil when no index loads.
+func indexSkillVectors(cfg *config.Config) map[string][]float32 {
+	idx, _, err := selectSemanticIndex(cfg)
+	if err != nil {
+		return nil
+	}
+	dim := idx.Manifest.Dim
+	vectors := make(map[string][]float32)
+	for i, e := range idx.Skills {
+		if e.Chunk != 0 || (i+1)*dim > len(idx.Vectors) {
+			continue
+		}
+		vectors[e.Repo+"\x00"+e.ID] = searchindex.NormalizeL2(idx.Vectors[i*dim : (i+1)*dim])
+	}
+	return vectors
+}
+
+// printDedupeGroup lists one duplicate group, numbering its skills.
+func printDedupeGroup(n int, g dedupe.Group) {
+	kind := "identical"
+	if !g.Identical {
+		kind = fmt.Sprintf("similar, %.0f%%", g.Score*100)
+	}
+	printBullet(fmt.Sprintf("Group %d (%s):", n, kind))
+	for i, it := range g.Items {
+		files, size := skillTreeStats(it.Dir)
+		printListItem(iconItem, fmt.Sprintf("[%d] %-40s %d file(s), %s", i+1, it.ID, files, humanBytes(size)))
+	}
+}
+
+// skillTreeStats counts the files under dir and their total size.
+func skillTreeStats(dir string) (int, int64) {
+	var files int
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.Mode().IsRegular() {
+			files++
+			size += info.Size()
+		}
+		return nil
+	})
+	return files, size
+}
+
+// askDedupeAction reads what to do with a group of n skills: "keep" or
+// "merge" with the 1-based skill number, "" to skip, or quit.
+func askDedupeAction(in *bufio.Reader, out io.Writer, n int) (action string, pick int, quit bool) {
+	for {
+		fmt.Fprintf(out, "  Keep [k1-k%d], merge into [m1-m%d], skip [s], quit [q]: ", n, n)
+		line, err := in.ReadString('\n')
+		answer := strings.ToLower(strings.TrimSpace(line))
+		switch {
+		case answer == "q" || (err == io.EOF && answer == ""):
+			return "", 0, true
+		case answer == "s" || answer == "":
+			return "", 0, false
+		case len(answer) > 1 && (answer[0] == 'k' || answer[0] == 'm'):
+			i, convErr := strconv.Atoi(answer[1:])
+			if convErr == nil && i >= 1 && i <= n {
+				if answer[0] == 'k' {
+					return "keep", i, false
+				}
+				return "merge", i, false
+			}
+		}
+		if err != nil {
+			return "", 0, true
+		}
+		fmt.Fprintf(out, "  Answer k or m followed by 1-%d, s, or q.\n", n)
+	}
+}
+
+// readOnlyRepoPaths returns the paths of the Hub repos that are not synced
+// read-write. Local changes there would break their next pull.
+func readOnlyRepoPaths(cfg *config.Config) []string {
+	var out []string
+	for _, r := range cfg.HubRepos() {
+		if r.SyncMode != "read-write" {
+			out = append(out, r.Path)
+		}
+	}
+	return out
+}
+
+// resolveDedupeGroup keeps skill n (1-based) of g and moves the others to
+// the trash, first copying the files it lacks from them when merging. It
+// changes nothing when that would touch a skill under one of readOnly.
+func resolveDedupeGroup(g dedupe.Group, action string, n int, readOnly []string) error {
+	keep := g.Items[n-1]
+	inReadOnly := func(dir string) bool {
+		return slices.ContainsFunc(readOnly, func(root string) bool { return pathWithin(root, dir) })
+	}
+	if action == "merge" && inReadOnly(keep.Dir) {
+		return fmt.Errorf("cannot merge into %s: it is in a read-only repo", keep.ID)
+	}
+	for i, it := range g.Items {
+		if i != n-1 && inReadOnly(it.Dir) {
+			return fmt.Errorf("cannot remove %s: it is in a read-only repo; keep it instead", it.ID)
+		}
+	}
+	for i, it := range g.Items {
+		if i == n-1 {
+			continue
+		}
+		if action == "merge" {
+			added, err := mergeSkillFiles(it.Dir, keep.Dir)
+			if err != nil {
+				return fmt.Errorf("cannot merge %s into %s: %w", it.ID, keep.ID, err)
+			}
+			if added > 0 {
+				printInfo(keep.ID, fmt.Sprintf("%d file(s) copied from %s", added, it.ID))
+			}
+		}
+		if err := moveToTrash(it.Dir, "dedupe: duplicate of "+keep.ID); err != nil {
+			return fmt.Errorf("cannot remove %s: %w", it.ID, err)
+		}
+		printOK(it.ID, "moved to the trash")
+	}
+	return nil
+}
+
+// mergeSkillFiles copies the files under src that dst does not have and
+// returns how many it copied. Files present in both are left as in dst.
+func mergeSkillFiles(src, dst string) (int, error) {
+	added := 0
+	err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		info, err := d.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if _, err := os.Lstat(target); err == nil {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		added++
+		return copyRegularFile(p, target, info.Mode().Perm())
+	})
+	return added, err
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/dedupe"
+)
+
+func TestDedupeMerge(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	skills := filepath.Join(cfg.RepoPath, "skills")
+	body := "---\nname: pdf\n---\nExtract tables and text from PDF files and save them as CSV for later analysis.\n"
+	for name, extra := range map[string]string{"pdf": "", "pdf-tools": "scripts/ocr.py", "sql": ""} {
+		dir := filepath.Join(skills, name)
+		if err := os.MkdirAll(filepath.Join(dir, "scripts"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		content := []byte("---\nname: sql\n---\nReview SQL queries.\n")
+		if name != "sql" {
+			content = []byte(body)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if extra != "" {
+			if err := os.WriteFile(filepath.Join(dir, extra), []byte("print()"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	items, err := dedupeItems(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := dedupe.Cluster(items, dedupe.Options{TextThreshold: 0.8})
+	if len(groups) != 1 || len(groups[0].Items) != 2 || groups[0].Identical {
+		t.Fatalf("groups = %+v, want pdf and pdf-tools", groups)
+	}
+
+	if err := resolveDedupeGroup(groups[0], "merge", 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(skills, "pdf", "scripts", "ocr.py")); err != nil {
+		t.Errorf("merged file missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(skills, "pdf-tools")); !os.IsNotExist(err) {
+		t.Errorf("pdf-tools should be in the trash, stat err = %v", err)
+	}
+	entries, _ := loadTrash()
+	if len(entries) != 1 || entries[0].Reason != "dedupe: duplicate of skills/pdf" {
+		t.Errorf("trash = %+v", entries)
+	}
+}
+
+func TestResolveDedupeGroup_ReadOnly(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	mine, upstream := filepath.Join(tmp, "hub", "skills", "pdf"), filepath.Join(tmp, "upstream", "skills", "pdf")
+	for _, d := range []string{mine, upstream} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g := dedupe.Group{Items: []dedupe.Item{{ID: "hub:skills/pdf", Dir: mine}, {ID: "upstream:skills/pdf", Dir: upstream}}}
+	readOnly := []string{filepath.Join(tmp, "upstream")}
+
+	for _, c := range []struct {
+		action string
+		n      int
+	}{{"keep", 1}, {"merge", 2}} {
+		if err := resolveDedupeGroup(g, c.action, c.n, readOnly); err == nil || !strings.Contains(err.Error(), "read-only") {
+			t.Errorf("%s %d: expected read-only refusal, got %v", c.action, c.n, err)
+		}
+	}
+	if _, err := os.Stat(upstream); err != nil {
+		t.Fatalf("read-only skill was removed: %v", err)
+	}
+
+	if err := resolveDedupeGroup(g, "keep", 2, readOnly); err != nil {
+		t.Fatalf("keeping the read-only copy: %v", err)
+	}
+	if _, err := os.Stat(mine); !os.IsNotExist(err) {
+		t.Errorf("writable duplicate should be in the trash, stat err = %v", err)
+	}
+}
+
+func TestAskDedupeAction(t *testing.T) {
+	var out bytes.Buffer
+	in := bufio.NewReader(strings.NewReader("x\nm2\n"))
+	action, n, quit := askDedupeAction(in, &out, 2)
+	if action != "merge" || n != 2 || quit {
+		t.Errorf("got %q %d %v, want merge 2", action, n, quit)
+	}
+	if !strings.Contains(out.String(), "Answer k or m followed by 1-2") {
+		t.Errorf("prompt output = %q", out.String())
+	}
+}
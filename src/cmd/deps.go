@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var depsCmd = &cobra.Command{
+	Use:   "deps <skill>",
+	Short: "Show the skill dependency tree of a skill",
+	Long: `Print the skills a skill depends on, recursively.
+
+Skills declare dependencies on other skills in their SKILL.md frontmatter:
+
+  requires:
+    skills: [git-helpers, markdown-style]
+
+Missing skills and dependency cycles are marked in the tree. 'axon link'
+and 'axon doctor' report them for the whole Hub.
+
+Example:
+  axon deps release-notes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeps,
+}
+
+func init() {
+	rootCmd.AddCommand(depsCmd)
+}
+
+// skillNode is one skill in the dependency graph.
+type skillNode struct {
+	Name     string
+	Dir      string
+	Requires []string
+}
+
+// skillDepProblem is a missing dependency or a dependency cycle.
+type skillDepProblem struct {
+	Skill  string
+	Kind   string // "missing" or "cycle"
+	Detail string
+}
+
+func runDeps(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	graph := loadSkillGraph(cfg)
+	name := args[0]
+	if _, ok := graph[name]; !ok {
+		return fmt.Errorf("skill %q not found in Hub.\nTip: run 'axon list' to see available items.", name)
+	}
+
+	fmt.Println(name)
+	printDepTree(graph, name, "", []string{name})
+
+	for _, p := range skillDepProblems(graph) {
+		if p.Skill == name || reachable(graph, name, p.Skill) {
+			fmt.Println()
+			printWarn("", "dependency problems found; run 'axon doctor' for details")
+			break
+		}
+	}
+	return nil
+}
+
+// printDepTree prints the dependencies of name below it. path holds the
+// skills from the root down to name and is used to cut cycles.
+func printDepTree(graph map[string]*skillNode, name, prefix string, path []string) {
+	reqs := graph[name].Requires
+	for i, dep := range reqs {
+		branch, indent := "├── ", "│   "
+		if i == len(reqs)-1 {
+			branch, indent = "└── ", "    "
+		}
+		switch {
+		case graph[dep] == nil:
+			fmt.Printf("%s%s%s (missing)\n", prefix, branch, dep)
+		case containsString(path, dep):
+			fmt.Printf("%s%s%s (cycle)\n", prefix, branch, dep)
+		default:
+			fmt.Printf("%s%s%s\n", prefix, branch, dep)
+			printDepTree(graph, dep, prefix+indent, append(path, dep))
+		}
+	}
+}
+
+// loadSkillGraph collects every skill directory (one containing SKILL.md)
+// under the source roots of all Hub repos. When two repos provide the same
+// skill, the higher-priority repo wins, as in merged views.
+func loadSkillGraph(cfg *config.Config) map[string]*skillNode {
+	graph := make(map[string]*skillNode)
+	for _, repo := range cfg.HubRepos() {
+		for _, root := range cfg.EffectiveSearchRoots() {
+			entries, err := os.ReadDir(filepath.Join(repo.Path, root))
+			if err != nil {
+				continue
+			}
+			for _, e := range entries {
+				if !e.IsDir() || graph[e.Name()] != nil {
+					continue
+				}
+				dir := filepath.Join(repo.Path, root, e.Name())
+				skillMD := filepath.Join(dir, "SKILL.md")
+				if _, err := os.Stat(skillMD); err != nil {
+					continue
+				}
+				meta, _ := parseSkillMeta(skillMD)
+				graph[e.Name()] = &skillNode{Name: e.Name(), Dir: dir, Requires: meta.GetRequiresSkills()}
+			}
+		}
+	}
+	return graph
+}
+
+// skillDepProblems returns every missing dependency and every cycle in
+// graph, sorted by skill. Each cycle is reported once.
+func skillDepProblems(graph map[string]*skillNode) []skillDepProblem {
+	var out []skillDepProblem
+	names := make([]string, 0, len(graph))
+	for n := range graph {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		for _, dep := range graph[n].Requires {
+			if graph[dep] == nil {
+				out = append(out, skillDepProblem{Skill: n, Kind: "missing", Detail: fmt.Sprintf("requires skill %q, which is not in the Hub", dep)})
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(graph))
+	seenCycles := make(map[string]bool)
+	var stack []string
+	var visit func(n string)
+	visit = func(n string) {
+		state[n] = visiting
+		stack = append(stack, n)
+		for _, dep := range graph[n].Requires {
+			if graph[dep] == nil {
+				continue
+			}
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				i := indexOfString(stack, dep)
+				cycle := append(append([]string(nil), stack[i:]...), dep)
+				members := append([]string(nil), stack[i:]...)
+				sort.Strings(members)
+				key := strings.Join(members, "\x00")
+				if !seenCycles[key] {
+					seenCycles[key] = true
+					out = append(out, skillDepProblem{Skill: dep, Kind: "cycle", Detail: "dependency cycle: " + strings.Join(cycle, " → ")})
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n] = done
+	}
+	for _, n := range names {
+		if state[n] == unvisited {
+			visit(n)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Skill < out[j].Skill })
+	return out
+}
+
+// reachable reports whether to is a transitive dependency of from.
+func reachable(graph map[string]*skillNode, from, to string) bool {
+	seen := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		node := graph[n]
+		if node == nil {
+			continue
+		}
+		for _, dep := range node.Requires {
+			if dep == to {
+				return true
+			}
+			if !seen[dep] {
+				seen[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return false
+}
+
+// warnSkillDependencyProblems prints missing and cyclic skill dependencies.
+// It never fails the calling command.
+func warnSkillDependencyProblems(cfg *config.Config) {
+	problems := skillDepProblems(loadSkillGraph(cfg))
+	if len(problems) == 0 {
+		return
+	}
+	fmt.Println()
+	printWarn("", fmt.Sprintf("%d skill dependency problem(s):", len(problems)))
+	for _, p := range problems {
+		printWarn(p.Skill, p.Detail)
+	}
+	fmt.Println("   Run 'axon deps <skill>' for the full tree.")
+}
+
+// checkSkillDeps reports missing and cyclic skill dependencies.
+func checkSkillDeps(cfg *config.Config) []DiagnosticResult {
+	cat := "Skill Dependencies"
+	graph := loadSkillGraph(cfg)
+	problems := skillDepProblems(graph)
+	if len(problems) == 0 {
+		declared := 0
+		for _, n := range graph {
+			declared += len(n.Requires)
+		}
+		if declared == 0 {
+			return []DiagnosticResult{{Category: cat, Passed: true, Message: "no skill dependencies declared"}}
+		}
+		return []DiagnosticResult{{Category: cat, Passed: true, Message: fmt.Sprintf("%d declared dependency(ies) resolved, no cycles", declared)}}
+	}
+	var res []DiagnosticResult
+	for _, p := range problems {
+		r := DiagnosticResult{Category: cat, Item: p.Skill, Passed: false, Severity: DiagnosticSeverityError, Message: p.Detail}
+		if p.Kind == "missing" {
+			r.Remediation = "add the skill to the Hub (e.g. 'axon vendor sync') or remove it from requires.skills"
+		} else {
+			r.Remediation = "remove one of the requires.skills entries to break the cycle"
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func containsString(list []string, s string) bool {
+	return indexOfString(list, s) >= 0
+}
+
+func indexOfString(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSkillWithDeps(t *testing.T, root, name string, deps ...string) {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	body := "---\nname: " + name + "\n"
+	if len(deps) > 0 {
+		body += "requires:\n  skills: [" + strings.Join(deps, ", ") + "]\n"
+	}
+	body += "---\n# " + name + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSkillDepProblems(t *testing.T) {
+	cfg, _ := setupLinkTest(t)
+	skills := filepath.Join(cfg.RepoPath, "skills")
+	writeSkillWithDeps(t, skills, "app", "lib", "ghost")
+	writeSkillWithDeps(t, skills, "lib", "util")
+	writeSkillWithDeps(t, skills, "util", "lib")
+	writeSkillWithDeps(t, skills, "solo")
+
+	graph := loadSkillGraph(cfg)
+	if len(graph) != 4 {
+		t.Fatalf("graph has %d skills, want 4", len(graph))
+	}
+	if got := graph["app"].Requires; len(got) != 2 || got[0] != "lib" {
+		t.Errorf("app requires %v", got)
+	}
+
+	problems := skillDepProblems(graph)
+	var missing, cycles int
+	for _, p := range problems {
+		switch p.Kind {
+		case "missing":
+			missing++
+			if p.Skill != "app" || !strings.Contains(p.Detail, "ghost") {
+				t.Errorf("unexpected missing problem %+v", p)
+			}
+		case "cycle":
+			cycles++
+			if !strings.Contains(p.Detail, "lib → util → lib") && !strings.Contains(p.Detail, "util → lib → util") {
+				t.Errorf("unexpected cycle detail %q", p.Detail)
+			}
+		}
+	}
+	if missing != 1 || cycles != 1 {
+		t.Errorf("problems = %+v, want 1 missing and 1 cycle", problems)
+	}
+
+	if !reachable(graph, "app", "util") || reachable(graph, "solo", "lib") {
+		t.Error("reachable returned wrong result")
+	}
+}
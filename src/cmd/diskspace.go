@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// tempLeftoverWarnBytes is how much abandoned temp data doctor tolerates
+// before suggesting a purge.
+const tempLeftoverWarnBytes = 1 << 30
+
+// existingAncestor returns path or its closest existing parent, so free space
+// can be measured before a directory is created.
+func existingAncestor(path string) string {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
+
+// ensureFreeSpace refuses to start op when the filesystem holding path has
+// less than the configured min_free_space. If free space cannot be measured
+// the operation proceeds.
+func ensureFreeSpace(cfg *config.Config, path, op string) error {
+	need := cfg.MinFreeBytes()
+	if need <= 0 {
+		return nil
+	}
+	free, err := diskFree(existingAncestor(path))
+	if err != nil || free >= uint64(need) {
+		return nil
+	}
+	return fmt.Errorf("only %s free on the filesystem holding %s; %s needs at least %s\nRun 'axon gc' to reclaim space, or lower min_free_space in axon.yaml.",
+		humanBytes(int64(free)), path, op, humanBytes(need))
+}
+
+// checkDiskSpace reports free space where axon writes and flags large
+// amounts of abandoned temp data.
+func checkDiskSpace(cfg *config.Config) []DiagnosticResult {
+	cat := "Disk space"
+	var res []DiagnosticResult
+
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return []DiagnosticResult{{Category: cat, Passed: false, Severity: DiagnosticSeverityWarn, Message: err.Error()}}
+	}
+
+	need := cfg.MinFreeBytes()
+	paths := []string{axonDir}
+	// The Hub usually lives under ~/.axon; measure it separately when not.
+	if rel, err := filepath.Rel(axonDir, cfg.RepoPath); err != nil || strings.HasPrefix(rel, "..") {
+		paths = append(paths, cfg.RepoPath)
+	}
+	for _, p := range paths {
+		free, err := diskFree(existingAncestor(p))
+		switch {
+		case err != nil:
+			res = append(res, DiagnosticResult{Category: cat, Item: p, Passed: false, Severity: DiagnosticSeverityWarn, Message: fmt.Sprintf("cannot measure free space: %v", err)})
+		case need > 0 && free < uint64(need):
+			res = append(res, DiagnosticResult{
+				Category:    cat,
+				Item:        p,
+				Passed:      false,
+				Severity:    DiagnosticSeverityError,
+				Message:     fmt.Sprintf("%s free, below min_free_space %s; sync, import, and update will refuse to run", humanBytes(int64(free)), humanBytes(need)),
+				Remediation: "free up disk space or run 'axon gc'",
+			})
+		default:
+			res = append(res, DiagnosticResult{Category: cat, Item: p, Passed: true, Message: fmt.Sprintf("%s free", humanBytes(int64(free)))})
+		}
+	}
+
+	bases, owned := gcTempBases(axonDir), filepath.Join(axonDir, "tmp")
+	cutoff := time.Now().Add(-gcStaleTempAge)
+	leftover := gcTempDirs(bases, owned, cutoff, true)
+	if leftover.Bytes >= tempLeftoverWarnBytes {
+		res = append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        "temp",
+			Passed:      false,
+			Severity:    DiagnosticSeverityWarn,
+			Message:     fmt.Sprintf("%d abandoned temp dir(s) use %s", leftover.Items, humanBytes(leftover.Bytes)),
+			Remediation: "run 'axon gc' or 'axon doctor --fix' to purge them",
+			CanFix:      true,
+			FixAction: func() error {
+				return gcTempDirs(bases, owned, cutoff, false).Err
+			},
+		})
+	} else {
+		res = append(res, DiagnosticResult{Category: cat, Item: "temp", Passed: true, Message: fmt.Sprintf("%s of abandoned temp data", humanBytes(leftover.Bytes))})
+	}
+	return res
+}
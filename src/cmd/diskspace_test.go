@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestEnsureFreeSpace(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "not", "created", "yet")
+
+	if err := ensureFreeSpace(&config.Config{MinFreeSpace: "1KB"}, dir, "sync"); err != nil {
+		t.Errorf("1KB threshold: %v", err)
+	}
+	if err := ensureFreeSpace(&config.Config{MinFreeSpace: "0"}, dir, "sync"); err != nil {
+		t.Errorf("disabled threshold: %v", err)
+	}
+	err := ensureFreeSpace(&config.Config{MinFreeSpace: "1000000TB"}, dir, "sync")
+	if err == nil || !strings.Contains(err.Error(), "sync needs at least") {
+		t.Errorf("huge threshold err = %v, want refusal", err)
+	}
+}
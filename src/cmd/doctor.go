@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -8,9 +9,11 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/kamusis/axon-cli/internal/config"
 	"github.com/kamusis/axon-cli/internal/gitutil"
+	"github.com/kamusis/axon-cli/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -18,14 +21,32 @@ var doctorCmd = &cobra.Command{
 	Use:   "doctor",
 	Short: "Run pre-flight environment checks",
 	Long: `Check that Axon's dependencies and environment are correctly configured.
-Run this command when something seems wrong, or before filing a bug report.`,
+Run this command when something seems wrong, or before filing a bug report.
+
+With --fix, every fixable issue is remediated and the checks are run again
+to show what is left. --fix-only limits remediation to the named categories
+(case-insensitive substring match, e.g. --fix-only symlinks).
+
+With --skills, doctor also runs the healthcheck each skill declares in its
+SKILL.md frontmatter: a script in the skill, or an http(s) URL.
+
+  healthcheck: scripts/check.sh
+  healthcheck: https://${INTERNAL_API}/health`,
 	RunE: runDoctor,
 }
 
-var doctorFix bool
+var (
+	doctorFix           bool
+	doctorFixOnly       []string
+	doctorSkills        bool
+	doctorSkillsTimeout time.Duration
+)
 
 func init() {
 	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Automatically fix detected issues where possible")
+	doctorCmd.Flags().StringSliceVar(&doctorFixOnly, "fix-only", nil, "Only fix issues in these categories (implies --fix)")
+	doctorCmd.Flags().BoolVar(&doctorSkills, "skills", false, "Also run the healthcheck of every skill that declares one")
+	doctorCmd.Flags().DurationVar(&doctorSkillsTimeout, "skills-timeout", 10*time.Second, "Time limit for each skill healthcheck")
 	rootCmd.AddCommand(doctorCmd)
 }
 
@@ -48,17 +69,36 @@ const (
 )
 
 func runDoctor(_ *cobra.Command, _ []string) error {
+	if doctorSkillsTimeout <= 0 {
+		return fmt.Errorf("--skills-timeout must be positive")
+	}
 	printSection("axon doctor")
 	fmt.Println()
 
 	results := gatherDiagnostics()
 
-	if doctorFix {
-		return runFixes(results)
+	if doctorFix || len(doctorFixOnly) > 0 {
+		return runFixes(results, doctorFixOnly)
 	}
 
-	hasErrors := false
-	hasWarnings := false
+	hasErrors, hasWarnings := printDiagnostics(results)
+
+	fmt.Println("===================")
+	if hasErrors {
+		printErr("", "One or more checks failed. See details above.")
+		return fmt.Errorf("doctor found issues")
+	}
+	if hasWarnings {
+		printWarn("", "Doctor found no issues, but some warnings were detected.")
+		return nil
+	}
+	printOK("", "All checks passed. Axon is ready to use.")
+	return nil
+}
+
+// printDiagnostics prints results grouped by category and reports whether
+// any errors or warnings were among them.
+func printDiagnostics(results []DiagnosticResult) (hasErrors, hasWarnings bool) {
 	var currentCategory string
 
 	for _, r := range results {
@@ -66,7 +106,7 @@ func runDoctor(_ *cobra.Command, _ []string) error {
 			if currentCategory != "" {
 				fmt.Println()
 			}
-			fmt.Printf("[ %s ]\n", r.Category)
+			fmt.Println(paint(colorStdout, sgrBold, "[ "+r.Category+" ]"))
 			currentCategory = r.Category
 		}
 
@@ -83,34 +123,68 @@ func runDoctor(_ *cobra.Command, _ []string) error {
 			printErr(r.Item, r.Message)
 		}
 		if r.Remediation != "" {
-			fmt.Printf("      Fix: %s\n", r.Remediation)
+			fmt.Printf("      %s: %s\n", i18n.T("Fix"), r.Remediation)
 		}
 	}
 	fmt.Println()
+	return hasErrors, hasWarnings
+}
 
-	fmt.Println("===================")
-	if hasErrors {
-		printErr("", "One or more checks failed. See details above.")
-		return fmt.Errorf("doctor found issues")
+// countIssues returns the number of failed checks by severity.
+func countIssues(results []DiagnosticResult) (errs, warns int) {
+	for _, r := range results {
+		switch {
+		case r.Passed:
+		case r.Severity == DiagnosticSeverityWarn:
+			warns++
+		default:
+			errs++
+		}
 	}
-	if hasWarnings {
-		printWarn("", "Doctor found no issues, but some warnings were detected.")
-		return nil
+	return errs, warns
+}
+
+// inFixScope reports whether category is selected by --fix-only; an empty
+// scope selects everything.
+func inFixScope(category string, scope []string) bool {
+	if len(scope) == 0 {
+		return true
 	}
-	printOK("", "All checks passed. Axon is ready to use.")
-	return nil
+	for _, s := range scope {
+		if s = strings.TrimSpace(s); s != "" && strings.Contains(strings.ToLower(category), strings.ToLower(s)) {
+			return true
+		}
+	}
+	return false
 }
 
-func runFixes(results []DiagnosticResult) error {
+func runFixes(results []DiagnosticResult, scope []string) error {
 	if err := checkGitAvailable(); err != nil {
 		return err
 	}
 
+	if len(scope) > 0 {
+		seen := make(map[string]bool)
+		var categories []string
+		matched := false
+		for _, r := range results {
+			if !seen[r.Category] {
+				seen[r.Category] = true
+				categories = append(categories, r.Category)
+			}
+			matched = matched || inFixScope(r.Category, scope)
+		}
+		if !matched {
+			return fmt.Errorf("--fix-only %s matches no category; available: %s", strings.Join(scope, ","), strings.Join(categories, ", "))
+		}
+	}
+
 	var fixedCount int
 	var failedCount int
+	fixed := make(map[string]bool)
 
 	for _, r := range results {
-		if !r.Passed && r.CanFix && r.FixAction != nil {
+		if !r.Passed && r.CanFix && r.FixAction != nil && inFixScope(r.Category, scope) {
 			fmt.Printf("Fixing %s", r.Category)
 			if r.Item != "" {
 				fmt.Printf(" > %s", r.Item)
@@ -123,6 +197,7 @@ func runFixes(results []DiagnosticResult) error {
 			} else {
 				fmt.Println("OK")
 				fixedCount++
+				fixed[r.Category+"\x00"+r.Item] = true
 			}
 		}
 	}
@@ -133,11 +208,31 @@ func runFixes(results []DiagnosticResult) error {
 		return nil
 	}
 
+	// Re-run every check: fixes can resolve (or reveal) issues elsewhere.
+	printSection("Re-checking")
+	fmt.Println()
+	after := gatherDiagnostics()
+	hasErrors, _ := printDiagnostics(after)
+
+	for _, r := range after {
+		if !r.Passed && fixed[r.Category+"\x00"+r.Item] {
+			printWarn(r.Item, fmt.Sprintf("%s: still failing after the fix", r.Category))
+		}
+	}
+
+	beforeErrs, beforeWarns := countIssues(results)
+	afterErrs, afterWarns := countIssues(after)
+	fmt.Println("===================")
+	printInfo("", fmt.Sprintf("before: %d error(s), %d warning(s)", beforeErrs, beforeWarns))
+	printInfo("", fmt.Sprintf("after:  %d error(s), %d warning(s)", afterErrs, afterWarns))
+
 	if failedCount > 0 {
 		return fmt.Errorf("%d issue(s) could not be fixed", failedCount)
 	}
-
 	printOK("", fmt.Sprintf("%d issue(s) fixed successfully.", fixedCount))
+	if hasErrors {
+		return fmt.Errorf("doctor found issues")
+	}
 	return nil
 }
 
@@ -158,29 +253,68 @@ func gatherDiagnostics() []DiagnosticResult {
 		// 4. Git Health
 		results = append(results, checkGitHealth(cfg)...)
 
+		// 4b. Remote access (SSH keys, host keys, tokens)
+		results = append(results, checkRemoteAccess(cfg)...)
+
 		// 5. Symlinks
+		results = append(results, checkTargetSources(cfg)...)
 		results = append(results, checkSymlinks(cfg)...)
 
+		// 5b. WSL / Windows-side targets
+		results = append(results, checkWSL(cfg)...)
+
+		// 5c. Overlapping target destinations
+		results = append(results, checkTargetOverlaps(cfg)...)
+
 		// 6. Conflicts
 		results = append(results, checkConflicts(cfg)...)
 
-		// 7. Permission Sentinel
+		// 6b. Cross-platform path hazards
+		results = append(results, checkPortability(cfg)...)
+
+		// 6c. Symlinks inside the Hub
+		results = append(results, checkHubSymlinks(cfg)...)
+
+		// 6d. Files written through read-only targets
+		results = append(results, checkHubWrites(cfg)...)
+
+		// 7. Signatures
+		results = append(results, checkSignatures(cfg)...)
+
+		// 8. Permission Sentinel
 		results = append(results, checkPermissions(cfg)...)
 
-		// 8. Binary Dependencies
+		// 9. Binary Dependencies
 		results = append(results, checkBinaryDeps(cfg)...)
 
-		// 9. NPM Dependencies
+		// 10. NPM Dependencies
 		results = append(results, checkNPMDeps(cfg)...)
 
-		// 10. Python Dependencies
+		// 11. Python Dependencies
 		results = append(results, checkPythonDeps(cfg)...)
 
-		// 11. Environment Variables
+		// 12. Environment Variables
 		results = append(results, checkEnvDeps(cfg)...)
+
+		// 12b. Skill-to-skill dependencies
+		results = append(results, checkSkillDeps(cfg)...)
+
+		// 12c. Skill healthchecks
+		if doctorSkills {
+			results = append(results, checkSkillHealth(cfg, doctorSkillsTimeout)...)
+		}
+
+		// 13. Semantic index & embeddings provider
+		results = append(results, checkSemanticSearch(cfg)...)
+
+		// 13b. Free space & leftover temp data
+		results = append(results, checkDiskSpace(cfg)...)
 	}
 
-	// 12. Windows symlink permission
+	// 13c. Shell completion
+	results = append(results, checkCompletion()...)
+
+	// 14. Windows symlink permission
 	if runtime.GOOS == "windows" {
 		results = append(results, checkWindowsSymlink()...)
 	}
@@ -249,11 +383,17 @@ func checkHubAndConfig() ([]DiagnosticResult, *config.Config, error) {
 	cfgPath, _ := config.ConfigPath()
 	if _, err := os.Stat(cfgPath); os.IsNotExist(err) {
 		res = append(res, DiagnosticResult{
-			Category: catDir, Passed: false, Message: "~/.axon/axon.yaml not found", Remediation: "run 'axon init'",
+			Category: catDir, Passed: false, Message: fmt.Sprintf("%s not found", cfgPath), Remediation: "run 'axon init'",
 		})
 		return res, nil, err
 	}
-	res = append(res, DiagnosticResult{Category: catDir, Passed: true, Message: fmt.Sprintf("~/.axon/ exists: %s", axonDir)})
+	msg := fmt.Sprintf("axon home exists: %s", axonDir)
+	if config.PortableDir() != "" {
+		msg += " (portable mode)"
+	} else if os.Getenv(config.HomeEnv) != "" {
+		msg += " (set by " + config.HomeEnv + ")"
+	}
+	res = append(res, DiagnosticResult{Category: catDir, Passed: true, Message: msg})
 
 	cfg, loadErr := config.Load()
 	if loadErr != nil {
@@ -264,6 +404,14 @@ func checkHubAndConfig() ([]DiagnosticResult, *config.Config, error) {
 	}
 
 	res = append(res, DiagnosticResult{Category: catCfg, Passed: true, Message: fmt.Sprintf("valid YAML — %d target(s) defined", len(cfg.Targets))})
+	if cfg.Machine != "" {
+		res = append(res, DiagnosticResult{Category: catCfg, Item: "machine", Passed: true, Message: machineOverrideSummary(cfg)})
+	} else if len(cfg.Overrides) > 0 {
+		res = append(res, DiagnosticResult{Category: catCfg, Item: "machine", Passed: true, Message: fmt.Sprintf("no override matches %q (set %s to pick one)", config.MachineLabel(), config.MachineEnv)})
+	}
+	if cfg.ProjectRoot != "" {
+		res = append(res, DiagnosticResult{Category: catCfg, Item: "project", Passed: true, Message: fmt.Sprintf("%s found in %s", config.ProjectFileName, cfg.ProjectRoot)})
+	}
 
 	if cfg.RepoPath == "" {
 		res = append(res, DiagnosticResult{Category: catCfg, Passed: false, Message: "repo_path is empty", Remediation: "add repo_path to axon.yaml"})
@@ -275,14 +423,46 @@ func checkHubAndConfig() ([]DiagnosticResult, *config.Config, error) {
 func checkHubRepo(cfg *config.Config) []DiagnosticResult {
 	cat := "Hub repo"
 	gitDir := filepath.Join(cfg.RepoPath, ".git")
+	var res []DiagnosticResult
 	if _, err := os.Stat(gitDir); os.IsNotExist(err) {
-		return []DiagnosticResult{{
+		res = append(res, DiagnosticResult{
 			Category: cat, Passed: false, Message: fmt.Sprintf("Hub repo not initialised at %s", cfg.RepoPath), Remediation: "run 'axon init'",
-		}}
+		})
+	} else {
+		res = append(res, DiagnosticResult{
+			Category: cat, Passed: true, Message: fmt.Sprintf("Git repo ready: %s", cfg.RepoPath),
+		})
 	}
-	return []DiagnosticResult{{
-		Category: cat, Passed: true, Message: fmt.Sprintf("Git repo ready: %s", cfg.RepoPath),
-	}}
+
+	// Additional repos from the 'repos' block, in priority order.
+	for _, r := range cfg.HubRepos() {
+		if r.Name == config.PrimaryRepoName {
+			continue
+		}
+		if r.Project != "" {
+			msg := fmt.Sprintf("project items at %s", r.Path)
+			if _, err := os.Stat(r.Path); err != nil {
+				msg += " (not created yet)"
+			}
+			res = append(res, DiagnosticResult{Category: cat, Item: r.Name, Passed: true, Message: msg})
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(r.Path, ".git")); err != nil {
+			msg, fix := fmt.Sprintf("not cloned at %s", r.Path), "add a 'remote' for this repo and run 'axon sync'"
+			if r.Remote != "" {
+				fix = fmt.Sprintf("run 'axon sync --repo %s'", r.Name)
+			}
+			res = append(res, DiagnosticResult{
+				Category: cat, Item: r.Name, Passed: false, Severity: DiagnosticSeverityWarn, Message: msg, Remediation: fix,
+			})
+			continue
+		}
+		res = append(res, DiagnosticResult{
+			Category: cat, Item: r.Name, Passed: true,
+			Message: fmt.Sprintf("Git repo ready: %s (priority %d, %s)", r.Path, r.Priority, r.SyncMode),
+		})
+	}
+	return res
 }
 
 func checkGitHealth(cfg *config.Config) []DiagnosticResult {
@@ -325,6 +505,60 @@ func checkGitHealth(cfg *config.Config) []DiagnosticResult {
 	return res
 }
 
+// checkTargetSources reports nested target sources (e.g. skills/db-only)
+// and file target sources that the Hub lacks. Targets of tools that are not
+// installed are skipped.
+func checkTargetSources(cfg *config.Config) []DiagnosticResult {
+	cat := "Sources"
+	var res []DiagnosticResult
+	nested := 0
+	for _, t := range cfg.Targets {
+		if !nestedSource(t.Source) && !t.IsFile() {
+			continue
+		}
+		dest, err := config.ExpandPath(t.Destination)
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Dir(dest)); os.IsNotExist(err) {
+			continue
+		}
+		nested++
+		if t.IsFile() {
+			if _, _, err := fileTargetSource(cfg, t); err != nil {
+				res = append(res, DiagnosticResult{
+					Category:    cat,
+					Item:        t.Name,
+					Passed:      false,
+					Severity:    DiagnosticSeverityError,
+					Message:     err.Error(),
+					Remediation: fmt.Sprintf("add %s to the Hub, or fix the target's source in axon.yaml", t.Source),
+				})
+			}
+			continue
+		}
+		if err := missingTargetSource(cfg, t); err != nil {
+			target := t // capture
+			res = append(res, DiagnosticResult{
+				Category:    cat,
+				Item:        t.Name,
+				Passed:      false,
+				Severity:    DiagnosticSeverityError,
+				Message:     err.Error(),
+				Remediation: fmt.Sprintf("create %s in the Hub, or fix the target's source in axon.yaml", t.Source),
+				CanFix:      true,
+				FixAction: func() error {
+					return createTargetSource(cfg, target)
+				},
+			})
+		}
+	}
+	if nested > 0 && len(res) == 0 {
+		res = append(res, DiagnosticResult{Category: cat, Item: "subpaths", Passed: true, Message: fmt.Sprintf("all %d nested or file source(s) exist in the Hub", nested)})
+	}
+	return res
+}
+
 func checkSymlinks(cfg *config.Config) []DiagnosticResult {
 	cat := "Symlinks"
 	var res []DiagnosticResult
@@ -337,6 +571,9 @@ func checkSymlinks(cfg *config.Config) []DiagnosticResult {
 
 	for _, t := range targets {
 		dest, err := config.ExpandPath(t.Destination)
+		if errors.Is(err, config.ErrNoWindowsHome) {
+			continue // No Windows side on this machine; see the WSL checks
+		}
 		if err != nil {
 			res = append(res, DiagnosticResult{Category: cat, Item: t.Name, Passed: false, Severity: DiagnosticSeverityError, Message: fmt.Sprintf("cannot expand path: %v", err)})
 			continue
@@ -346,6 +583,10 @@ func checkSymlinks(cfg *config.Config) []DiagnosticResult {
 		if _, parentErr := os.Stat(parent); os.IsNotExist(parentErr) {
 			continue // Skip silently in doctor, target not installed
 		}
+		if real, inHub := destParentInHub(cfg, dest); inHub {
+			res = append(res, DiagnosticResult{Category: cat, Item: t.Name, Passed: false, Severity: DiagnosticSeverityError, Message: destInHubRefusal(dest, real)})
+			continue
+		}
 
 		info, err := os.Lstat(dest)
 		if os.IsNotExist(err) {
@@ -368,20 +609,56 @@ func checkSymlinks(cfg *config.Config) []DiagnosticResult {
 			res = append(res, DiagnosticResult{Category: cat, Item: t.Name, Passed: false, Severity: DiagnosticSeverityError, Message: fmt.Sprintf("stat error: %v", err)})
 			continue
 		}
+		if marked, ok := copyMarkerSource(dest); ok && (info.IsDir() || t.IsFile() || t.IsRules()) {
+			res = append(res, checkCopyTarget(cfg, t, dest, marked))
+			continue
+		}
 		if info.Mode()&os.ModeSymlink == 0 {
+			kind := "directory"
+			if !info.IsDir() {
+				kind = "file"
+			}
 			res = append(res, DiagnosticResult{
 				Category:    cat,
 				Item:        t.Name,
 				Passed:      false,
 				Severity:    DiagnosticSeverityWarn,
-				Message:     fmt.Sprintf("real directory present at %s", dest),
-				Remediation: fmt.Sprintf("delete the folder and run 'axon link %s'", t.Name),
+				Message:     fmt.Sprintf("real %s present at %s", kind, dest),
+				Remediation: fmt.Sprintf("delete the %s and run 'axon link %s'", kind, t.Name),
 			})
 			continue
 		}
-		expected := filepath.Join(cfg.RepoPath, t.Source)
+		expected, repos, err := targetLinkSource(cfg, t)
+		if err != nil {
+			res = append(res, DiagnosticResult{Category: cat, Item: t.Name, Passed: false, Severity: DiagnosticSeverityError, Message: err.Error()})
+			continue
+		}
 		actual, _ := os.Readlink(dest)
-		if actual != expected {
+		if problem, broken := linkChainProblem(dest); problem != "" {
+			d := DiagnosticResult{Category: cat, Item: t.Name, Passed: false, Severity: DiagnosticSeverityWarn, Message: problem}
+			if broken {
+				d.Severity = DiagnosticSeverityError
+			}
+			res = append(res, d)
+			continue
+		}
+		if root, ok := missingHubRoot(cfg, t, dest, actual); ok {
+			res = append(res, DiagnosticResult{
+				Category:    cat,
+				Item:        t.Name,
+				Passed:      false,
+				Severity:    DiagnosticSeverityError,
+				Message:     fmt.Sprintf("points into a missing Hub at %s (moved or deleted?)", root),
+				Remediation: fmt.Sprintf("run 'axon relink --from %s'", root),
+				CanFix:      true,
+				FixAction: func() error {
+					flagRelinkFrom = root
+					return runRelink(nil, nil)
+				},
+			})
+			continue
+		}
+		if !linkResolvesTo(dest, actual, expected) {
 			targetName := t.Name // capture
 			res = append(res, DiagnosticResult{
 				Category:    cat,
@@ -397,6 +674,57 @@ func checkSymlinks(cfg *config.Config) []DiagnosticResult {
 			})
 			continue
 		}
+		style := cfg.TargetLinkStyle(t)
+		if want, err := symlinkValue(style, dest, expected); err == nil && actual != want {
+			targetName := t.Name // capture
+			res = append(res, DiagnosticResult{
+				Category:    cat,
+				Item:        t.Name,
+				Passed:      false,
+				Severity:    DiagnosticSeverityWarn,
+				Message:     fmt.Sprintf("link works but is not a %s link (link_style): %s", style, actual),
+				Remediation: fmt.Sprintf("run 'axon link %s'", targetName),
+				CanFix:      true,
+				FixAction: func() error {
+					return runLink(nil, []string{targetName})
+				},
+			})
+			continue
+		}
+		if usesMergedView(t, repos) {
+			if plan, err := planMergedView(t.Source, repos, style, t.Tool()); err == nil {
+				changed, stale, stray := mergedViewDrift(expected, plan)
+				if len(changed)+len(stale) > 0 {
+					targetName := t.Name // capture
+					res = append(res, DiagnosticResult{
+						Category:    cat,
+						Item:        t.Name,
+						Passed:      false,
+						Severity:    DiagnosticSeverityWarn,
+						Message:     fmt.Sprintf("merged view out of date: %d item(s) changed in the repos", len(changed)+len(stale)),
+						Remediation: fmt.Sprintf("run 'axon link %s'", targetName),
+						CanFix:      true,
+						FixAction: func() error {
+							return runLink(nil, []string{targetName})
+						},
+					})
+					continue
+				}
+				if len(stray) > 0 {
+					res = append(res, DiagnosticResult{
+						Category:    cat,
+						Item:        t.Name,
+						Passed:      false,
+						Severity:    DiagnosticSeverityWarn,
+						Message:     fmt.Sprintf("%d item(s) written directly into the merged view %s: %s", len(stray), expected, strings.Join(stray, ", ")),
+						Remediation: "move them into one of the Hub repos, then run 'axon link'",
+					})
+					continue
+				}
+				res = append(res, DiagnosticResult{Category: cat, Item: t.Name, Passed: true, Message: fmt.Sprintf("OK (merged from %d repos, %d shadowed item(s))", len(repos), len(plan.Shadowed))})
+				continue
+			}
+		}
 		res = append(res, DiagnosticResult{Category: cat, Item: t.Name, Passed: true, Message: "OK"})
 	}
 
@@ -406,6 +734,121 @@ func checkSymlinks(cfg *config.Config) []DiagnosticResult {
 	return res
 }
 
+// checkCopyTarget diagnoses a destination provisioned by copy mode.
+func checkCopyTarget(cfg *config.Config, t config.Target, dest, marked string) DiagnosticResult {
+	cat := "Symlinks"
+	targetName := t.Name // capture
+	fix := func() error { return runLink(nil, []string{targetName}) }
+	expected, _, err := targetLinkSource(cfg, t)
+	if err != nil {
+		return DiagnosticResult{Category: cat, Item: t.Name, Passed: false, Severity: DiagnosticSeverityError, Message: err.Error()}
+	}
+	if marked != expected {
+		return DiagnosticResult{
+			Category:    cat,
+			Item:        t.Name,
+			Passed:      false,
+			Severity:    DiagnosticSeverityWarn,
+			Message:     fmt.Sprintf("copy of the wrong source:\n      got:  %s\n      want: %s", marked, expected),
+			Remediation: fmt.Sprintf("run 'axon link %s'", targetName),
+			CanFix:      true,
+			FixAction:   fix,
+		}
+	}
+	n, err := copyDrift(cfg, t, dest, expected)
+	if err != nil {
+		return DiagnosticResult{Category: cat, Item: t.Name, Passed: false, Severity: DiagnosticSeverityError, Message: fmt.Sprintf("cannot compare copy: %v", err)}
+	}
+	if n > 0 {
+		return DiagnosticResult{
+			Category:    cat,
+			Item:        t.Name,
+			Passed:      false,
+			Severity:    DiagnosticSeverityWarn,
+			Message:     fmt.Sprintf("copy out of date: %d change(s) in the Hub", n),
+			Remediation: fmt.Sprintf("run 'axon link %s'", targetName),
+			CanFix:      true,
+			FixAction:   fix,
+		}
+	}
+	return DiagnosticResult{Category: cat, Item: t.Name, Passed: true, Message: "OK (copy)"}
+}
+
+// checkWSL covers running axon in WSL for tools installed on Windows:
+// {windows_home} must resolve, and destinations on Windows drives need a
+// copy or a symlink Windows can follow.
+func checkWSL(cfg *config.Config) []DiagnosticResult {
+	cat := "WSL"
+	usesWindowsHome := false
+	for _, t := range cfg.Targets {
+		if strings.Contains(t.Destination, config.TemplateWindowsHome) {
+			usesWindowsHome = true
+			break
+		}
+	}
+	if !config.IsWSL() && !usesWindowsHome {
+		return nil
+	}
+
+	var res []DiagnosticResult
+	if home, err := config.WindowsHome(); err != nil {
+		sev := DiagnosticSeverityError
+		if !config.IsWSL() {
+			// Synced config on a machine without Windows: targets are skipped.
+			sev = DiagnosticSeverityWarn
+		}
+		res = append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        config.TemplateWindowsHome,
+			Passed:      false,
+			Severity:    sev,
+			Message:     err.Error(),
+			Remediation: fmt.Sprintf("export %s=/mnt/c/Users/<you> (or enable WSL interop)", config.WindowsHomeEnv),
+		})
+	} else {
+		res = append(res, DiagnosticResult{Category: cat, Item: config.TemplateWindowsHome, Passed: true, Message: home})
+	}
+	if !config.IsWSL() {
+		return res
+	}
+
+	for _, t := range cfg.Targets {
+		dest, err := config.ExpandPath(t.Destination)
+		if err != nil || !config.OnWindowsDrive(dest) {
+			continue
+		}
+		source, _, err := targetLinkSource(cfg, t)
+		if err != nil {
+			continue
+		}
+		switch {
+		case targetLinkMode(t, dest, source) == config.LinkModeCopy:
+			res = append(res, DiagnosticResult{Category: cat, Item: t.Name, Passed: true, Message: "Windows destination, provisioned as a copy"})
+		case !config.OnWindowsDrive(source):
+			res = append(res, DiagnosticResult{
+				Category:    cat,
+				Item:        t.Name,
+				Passed:      false,
+				Severity:    DiagnosticSeverityError,
+				Message:     fmt.Sprintf("mode: symlink on a Windows drive points into the WSL filesystem (%s); Windows programs cannot follow it", source),
+				Remediation: "remove 'mode: symlink' (auto copies) or set 'mode: copy'",
+			})
+		case cfg.TargetLinkStyle(t) != config.LinkStyleRelative:
+			res = append(res, DiagnosticResult{
+				Category:    cat,
+				Item:        t.Name,
+				Passed:      false,
+				Severity:    DiagnosticSeverityWarn,
+				Message:     "absolute /mnt/... symlink targets are not valid paths on the Windows side",
+				Remediation: "set 'link_style: relative' so WSL creates a Windows-readable symlink, then run 'axon link'",
+			})
+		default:
+			res = append(res, DiagnosticResult{Category: cat, Item: t.Name, Passed: true, Message: "Windows destination, relative symlink within the Windows drive"})
+		}
+	}
+	return res
+}
+
 func checkConflicts(cfg *config.Config) []DiagnosticResult {
 	cat := "Unresolved conflicts"
 	var res []DiagnosticResult
@@ -422,16 +865,67 @@ func checkConflicts(cfg *config.Config) []DiagnosticResult {
 			Category:    cat,
 			Passed:      false,
 			Message:     fmt.Sprintf("unresolved conflict: %s", relPath),
-			Remediation: "run 'axon doctor --fix' to delete",
+			Remediation: "merge it by hand, or run 'axon doctor --fix' to move it to the trash",
 			CanFix:      true,
 			FixAction: func() error {
-				return os.Remove(fullPath)
+				return moveToTrash(fullPath, "doctor --fix: unresolved conflict")
 			},
 		})
 	}
 	return res
 }
 
+// checkTargetOverlaps reports targets whose destinations are the same path
+// with different content, or nested inside each other. 'axon link' refuses
+// them, since linking one replaces or writes into the other.
+func checkTargetOverlaps(cfg *config.Config) []DiagnosticResult {
+	cat := "Target Destinations"
+	overlaps := cfg.Overlaps()
+	if len(overlaps) == 0 {
+		return []DiagnosticResult{{Category: cat, Passed: true, Message: "no overlapping destinations"}}
+	}
+	var res []DiagnosticResult
+	for _, o := range overlaps {
+		res = append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        o.B.Name,
+			Passed:      false,
+			Message:     o.String(),
+			Remediation: fmt.Sprintf("change the destination of %q or %q in axon.yaml, or remove one of them", o.A.Name, o.B.Name),
+		})
+	}
+	return res
+}
+
+func checkSignatures(cfg *config.Config) []DiagnosticResult {
+	cat := "Signatures"
+	statuses := verifySignedSkills(cfg)
+	if len(statuses) == 0 {
+		return []DiagnosticResult{{Category: cat, Passed: true, Message: "no skills marked 'signed: true'"}}
+	}
+
+	var res []DiagnosticResult
+	for _, st := range statuses {
+		if st.Err == nil {
+			msg := "signature valid"
+			if st.Signer != "" {
+				msg += " (signed by " + st.Signer + ")"
+			}
+			res = append(res, DiagnosticResult{Category: cat, Item: st.Skill, Passed: true, Message: msg})
+			continue
+		}
+		res = append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        st.Skill,
+			Passed:      false,
+			Severity:    DiagnosticSeverityWarn,
+			Message:     signatureErrorMessage(st.Err),
+			Remediation: fmt.Sprintf("review the changes; if they are trusted, re-sign with 'axon sign %s'", st.Skill),
+		})
+	}
+	return res
+}
+
 func checkPermissions(cfg *config.Config) []DiagnosticResult {
 	cat := "Permission Sentinel"
 	var res []DiagnosticResult
@@ -604,60 +1098,52 @@ func checkNPMDeps(cfg *config.Config) []DiagnosticResult {
 	return res
 }
 
+// checkEnvDeps checks the requires.envs of the skills that are linked, that
+// is the skills under the search roots of every Hub repo. A variable may be
+// set in the environment or in ~/.axon/.env.
 func checkEnvDeps(cfg *config.Config) []DiagnosticResult {
 	cat := "Environment Variables"
 	var res []DiagnosticResult
 
-	foundAny := false
-	seenEnvs := make(map[string]bool)
+	dotenv, err := config.LoadDotEnv()
+	if err != nil {
+		res = append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        ".env",
+			Passed:      false,
+			Severity:    DiagnosticSeverityWarn,
+			Message:     err.Error(),
+			Remediation: "fix the permissions of ~/.axon/.env",
+		})
+	}
 
-	_ = filepath.WalkDir(cfg.RepoPath, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return nil
-		}
-		if d.IsDir() && d.Name() == ".git" {
-			return filepath.SkipDir
+	foundAny := false
+	for name, node := range loadSkillGraph(cfg) {
+		meta, hasMeta := parseSkillMeta(filepath.Join(node.Dir, "SKILL.md"))
+		if !hasMeta {
+			continue
 		}
-		if !d.IsDir() && d.Name() == "SKILL.md" {
-			meta, hasMeta := parseSkillMeta(path)
-			if !hasMeta {
-				return nil
+		for _, env := range meta.GetRequiresEnvs() {
+			foundAny = true
+			item := fmt.Sprintf("%s (%s)", env, name)
+			if _, ok := os.LookupEnv(env); ok {
+				res = append(res, DiagnosticResult{Category: cat, Item: item, Passed: true, Message: "set in environment"})
+				continue
 			}
-
-			skillName := filepath.Base(filepath.Dir(path))
-			if meta.Name != "" {
-				skillName = meta.Name
-			}
-
-			for _, env := range meta.GetRequiresEnvs() {
-				foundAny = true
-				key := env + "|" + skillName
-				if seenEnvs[key] {
-					continue
-				}
-				seenEnvs[key] = true
-
-				if _, ok := os.LookupEnv(env); !ok {
-					res = append(res, DiagnosticResult{
-						Category:    cat,
-						Item:        fmt.Sprintf("%s (%s)", env, skillName),
-						Passed:      false,
-						Severity:    DiagnosticSeverityWarn,
-						Message:     fmt.Sprintf("environment variable '%s' is not set", env),
-						Remediation: fmt.Sprintf("set %s in your shell profile or .env file", env),
-					})
-				} else {
-					res = append(res, DiagnosticResult{
-						Category: cat,
-						Item:     fmt.Sprintf("%s (%s)", env, skillName),
-						Passed:   true,
-						Message:  "set in environment",
-					})
-				}
+			if dotenv[env] != "" {
+				res = append(res, DiagnosticResult{Category: cat, Item: item, Passed: true, Message: "set in ~/.axon/.env"})
+				continue
 			}
+			res = append(res, DiagnosticResult{
+				Category:    cat,
+				Item:        item,
+				Passed:      false,
+				Severity:    DiagnosticSeverityWarn,
+				Message:     fmt.Sprintf("environment variable '%s' is not set", env),
+				Remediation: fmt.Sprintf("run 'axon env template %s' and fill in %s in ~/.axon/.env, or set it in your shell profile", name, env),
+			})
 		}
-		return nil
-	})
+	}
 
 	if !foundAny {
 		res = append(res, DiagnosticResult{Category: cat, Passed: true, Message: "no environment variable dependencies declared"})
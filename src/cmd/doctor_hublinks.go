@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/hublinks"
+)
+
+// maxHubLinkProblems caps how many broken Hub symlinks doctor and status
+// list per repo.
+const maxHubLinkProblems = 20
+
+// checkHubSymlinks scans every Hub repo for symlinks that are dangling,
+// leave the repo, or store an absolute path. They may work here but arrive
+// broken on other machines after 'axon sync'.
+func checkHubSymlinks(cfg *config.Config) []DiagnosticResult {
+	cat := "Hub Symlinks"
+	var res []DiagnosticResult
+	for _, repo := range cfg.HubRepos() {
+		links, err := hublinks.Scan(repo.Path)
+		if err != nil {
+			res = append(res, DiagnosticResult{Category: cat, Item: repo.Name, Passed: false, Severity: DiagnosticSeverityWarn, Message: fmt.Sprintf("cannot scan %s: %v", repo.Path, err)})
+			continue
+		}
+		if len(links) == 0 {
+			res = append(res, DiagnosticResult{Category: cat, Item: repo.Name, Passed: true, Message: "no broken or non-portable symlinks"})
+			continue
+		}
+		for i, l := range links {
+			if i == maxHubLinkProblems {
+				res = append(res, DiagnosticResult{Category: cat, Item: repo.Name, Passed: false, Severity: DiagnosticSeverityWarn, Message: fmt.Sprintf("… and %d more", len(links)-i)})
+				break
+			}
+			severity := DiagnosticSeverityWarn
+			if l.Kind == hublinks.KindDangling {
+				severity = DiagnosticSeverityError
+			}
+			res = append(res, DiagnosticResult{
+				Category:    cat,
+				Item:        l.Skill,
+				Passed:      false,
+				Severity:    severity,
+				Message:     fmt.Sprintf("%s %s", l.Path, l.Detail),
+				Remediation: fmt.Sprintf("fix or remove the link in %s and run 'axon sync'", repo.Path),
+			})
+		}
+	}
+	return res
+}
+
+// printHubSymlinkProblems lists the broken Hub symlinks for 'axon status'.
+func printHubSymlinkProblems(cfg *config.Config) {
+	var header bool
+	for _, repo := range cfg.HubRepos() {
+		links, err := hublinks.Scan(repo.Path)
+		if err != nil || len(links) == 0 {
+			continue
+		}
+		if !header {
+			printBullet("Broken symlinks inside the Hub:")
+			header = true
+		}
+		for i, l := range links {
+			if i == maxHubLinkProblems {
+				printInfo(repo.Name, fmt.Sprintf("… and %d more (run 'axon doctor')", len(links)-i))
+				break
+			}
+			msg := fmt.Sprintf("%s %s", l.Path, l.Detail)
+			if l.Kind == hublinks.KindDangling {
+				printErr(l.Skill, msg)
+			} else {
+				printWarn(l.Skill, msg)
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"unicode/utf16"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/portable"
+)
+
+// maxPortabilityHazards caps how many hazards doctor and sync list per repo.
+const maxPortabilityHazards = 20
+
+// windowsPrefixEstimate is the destination length assumed for Windows
+// machines sharing the Hub when this one is not Windows, about as long as
+// C:\Users\<name>\.axon\backups\<target>_<timestamp>.
+const windowsPrefixEstimate = 64
+
+// longPathPrefix returns the length of the longest directory Hub content is
+// placed under: a target destination or its backup directory on Windows,
+// or windowsPrefixEstimate elsewhere.
+func longPathPrefix(cfg *config.Config) int {
+	if runtime.GOOS != "windows" {
+		return windowsPrefixEstimate
+	}
+	n := windowsPrefixEstimate
+	axonDir, _ := config.AxonDir()
+	for _, t := range cfg.Targets {
+		if dest, err := config.ExpandPath(t.Destination); err == nil {
+			n = max(n, len(utf16.Encode([]rune(dest))))
+		}
+		if axonDir != "" {
+			bkp := filepath.Join(axonDir, "backups", t.Name+"_20060102150405")
+			n = max(n, len(utf16.Encode([]rune(bkp))))
+		}
+	}
+	return n
+}
+
+// hubHazards returns the portability hazards of a Hub repo: Scan's name
+// hazards plus paths below its top-level directories that would exceed the
+// Windows path limit under a prefix-long destination.
+func hubHazards(repo string, prefix int) ([]portable.Hazard, error) {
+	hazards, err := portable.Scan(repo)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() || e.Name() == ".git" {
+			continue
+		}
+		long, err := portable.LongPaths(filepath.Join(repo, e.Name()), prefix)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range long {
+			h.Path = e.Name() + "/" + h.Path
+			hazards = append(hazards, h)
+		}
+	}
+	sortHazards(hazards)
+	return hazards, nil
+}
+
+// checkPortability scans every Hub repo for paths that will not check out on
+// another platform: case-only name collisions, Windows reserved names,
+// characters Windows rejects, and paths too long for Windows once copied to
+// a destination or backup.
+func checkPortability(cfg *config.Config) []DiagnosticResult {
+	cat := "Portability"
+	var res []DiagnosticResult
+	prefix := longPathPrefix(cfg)
+	for _, repo := range cfg.HubRepos() {
+		hazards, err := hubHazards(repo.Path, prefix)
+		if err != nil {
+			res = append(res, DiagnosticResult{Category: cat, Item: repo.Name, Passed: false, Severity: DiagnosticSeverityWarn, Message: fmt.Sprintf("cannot scan %s: %v", repo.Path, err)})
+			continue
+		}
+		if len(hazards) == 0 {
+			res = append(res, DiagnosticResult{Category: cat, Item: repo.Name, Passed: true, Message: "all paths are portable across Linux, macOS, and Windows"})
+			continue
+		}
+		for i, h := range hazards {
+			if i == maxPortabilityHazards {
+				res = append(res, DiagnosticResult{Category: cat, Item: repo.Name, Passed: false, Severity: DiagnosticSeverityWarn, Message: fmt.Sprintf("… and %d more", len(hazards)-i)})
+				break
+			}
+			fix := fmt.Sprintf("rename it in %s and run 'axon sync'", repo.Path)
+			if h.Kind == portable.KindLongPath {
+				fix = fmt.Sprintf("shorten or flatten it in %s, or enable long paths on Windows (LongPathsEnabled, git config core.longpaths true)", repo.Path)
+			}
+			res = append(res, DiagnosticResult{
+				Category:    cat,
+				Item:        h.Path,
+				Passed:      false,
+				Severity:    DiagnosticSeverityWarn,
+				Message:     h.Detail,
+				Remediation: fix,
+			})
+		}
+	}
+	return res
+}
+
+// warnPortabilityHazards prints the hazards in repo before sync commits them.
+func warnPortabilityHazards(repo string, prefix int) {
+	hazards, err := hubHazards(repo, prefix)
+	if err != nil {
+		return
+	}
+	printPortabilityHazards(hazards)
+}
+
+// importHazards returns the hazards of content about to be imported from
+// dir into the Hub directory hubDir (slash-separated, relative to the Hub).
+// Unreadable content is left to the import itself to report.
+func importHazards(dir, hubDir string, prefix int) []portable.Hazard {
+	hazards, err := portable.Scan(dir)
+	if err != nil {
+		return nil
+	}
+	long, err := portable.LongPaths(dir, prefix)
+	if err != nil {
+		return nil
+	}
+	hazards = append(hazards, long...)
+	for i := range hazards {
+		hazards[i].Path = path.Join(hubDir, hazards[i].Path)
+	}
+	sortHazards(hazards)
+	return hazards
+}
+
+func sortHazards(hazards []portable.Hazard) {
+	sort.SliceStable(hazards, func(i, j int) bool { return hazards[i].Path < hazards[j].Path })
+}
+
+// printPortabilityHazards lists hazards, capped at maxPortabilityHazards.
+func printPortabilityHazards(hazards []portable.Hazard) {
+	if len(hazards) == 0 {
+		return
+	}
+	fmt.Println()
+	printWarn("", fmt.Sprintf("%d path(s) will break on other platforms:", len(hazards)))
+	for i, h := range hazards {
+		if i == maxPortabilityHazards {
+			printInfo("", fmt.Sprintf("… and %d more", len(hazards)-i))
+			break
+		}
+		printWarn(h.Path, h.Detail)
+	}
+	fmt.Println("   Run 'axon doctor' for details.")
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// remoteProbeTimeout bounds each 'git ls-remote' doctor runs.
+const remoteProbeTimeout = 20 * time.Second
+
+// checkRemoteAccess probes the origin of every cloned Hub repo with
+// 'git ls-remote', so SSH key, host key, and token problems show up before
+// 'axon sync' trips over them.
+func checkRemoteAccess(cfg *config.Config) []DiagnosticResult {
+	cat := "Remote Access"
+	var res []DiagnosticResult
+	for _, r := range syncableRepos(cfg) {
+		if _, err := os.Stat(filepath.Join(r.Path, ".git")); err != nil {
+			continue
+		}
+		remote := originURL(r.Path)
+		if remote == "" {
+			continue
+		}
+		out, err := probeRemote(r.Path)
+		if err == nil {
+			res = append(res, DiagnosticResult{Category: cat, Item: r.Name, Passed: true, Message: "origin is reachable: " + remote})
+			continue
+		}
+		d := DiagnosticResult{Category: cat, Item: r.Name, Passed: false, Severity: DiagnosticSeverityError}
+		if p, ok := diagnoseGitRemote(out, remote); ok {
+			d.Message = p.Summary
+			d.Remediation = p.Remediation
+			if p.Network {
+				d.Severity = DiagnosticSeverityWarn
+			}
+		} else {
+			d.Message = fmt.Sprintf("cannot reach %s: %s", remote, firstLine(strings.TrimSpace(out), err))
+			d.Remediation = fmt.Sprintf("run 'git -C %s ls-remote origin' to see the full error", r.Path)
+		}
+		res = append(res, d)
+	}
+	return res
+}
+
+// probeRemote runs 'git ls-remote origin HEAD' in repo without letting git
+// or ssh prompt, so an untrusted host key or a missing token fails instead
+// of hanging doctor.
+func probeRemote(repo string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteProbeTimeout)
+	defer cancel()
+	c := exec.CommandContext(ctx, "git", "-C", repo, "ls-remote", "origin", "HEAD")
+	c.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if os.Getenv("GIT_SSH_COMMAND") == "" {
+		c.Env = append(c.Env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	}
+	out, err := c.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return string(out) + "\nconnection timed out", ctx.Err()
+	}
+	return string(out), err
+}
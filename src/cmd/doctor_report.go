@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
+	"github.com/kamusis/axon-cli/internal/secrets"
+	"github.com/spf13/cobra"
+)
+
+var doctorReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Write a redacted diagnostics report to attach to a bug report",
+	Long: `Collect what is needed to investigate a problem into one Markdown file:
+version and build details, axon.yaml, the doctor results, recent journal
+entries, and the git status of each Hub repo.
+
+Before it is written, the report is redacted: API keys, tokens, passwords,
+credentials in remote URLs, and private keys are masked, the home directory
+is shown as ~, and the user and host names are replaced. --redact-paths
+also hides every absolute path in axon.yaml. Review the file before
+attaching it anyway.
+
+Examples:
+  axon doctor report
+  axon doctor report --redact-paths -o report.md
+  axon doctor report -o -        # print to stdout`,
+	Args: cobra.NoArgs,
+	RunE: runDoctorReport,
+}
+
+var (
+	flagReportOutput      string
+	flagReportRedactPaths bool
+	flagReportHistory     int
+)
+
+func init() {
+	doctorReportCmd.Flags().StringVarP(&flagReportOutput, "output", "o", "", "File to write (default axon-report-<time>.md; - for stdout)")
+	doctorReportCmd.Flags().BoolVar(&flagReportRedactPaths, "redact-paths", false, "Hide absolute paths in axon.yaml")
+	doctorReportCmd.Flags().IntVar(&flagReportHistory, "history", 20, "Number of recent journal entries to include")
+	doctorCmd.AddCommand(doctorReportCmd)
+}
+
+func runDoctorReport(_ *cobra.Command, _ []string) error {
+	if flagReportHistory < 0 {
+		return fmt.Errorf("--history must be >= 0")
+	}
+	report := redactReport(buildDoctorReport(time.Now()), reportIdentity())
+
+	if flagReportOutput == "-" {
+		fmt.Print(report)
+		return nil
+	}
+	out := flagReportOutput
+	if out == "" {
+		out = fmt.Sprintf("axon-report-%s.md", time.Now().Format("20060102-150405"))
+	}
+	if err := os.WriteFile(out, []byte(report), 0o600); err != nil {
+		return fmt.Errorf("cannot write report: %w", err)
+	}
+	printOK("", fmt.Sprintf("Report written to %s", out))
+	printInfo("", "Review it for anything private before attaching it to an issue.")
+	return nil
+}
+
+// buildDoctorReport assembles the unredacted report.
+func buildDoctorReport(now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Axon diagnostics report\n\nGenerated: %s\n", now.Format(time.RFC3339))
+
+	reportSection(&b, "Version")
+	b.WriteString(fence("", versionInfo()))
+
+	reportSection(&b, "Environment")
+	b.WriteString(fence("", axonEnv()))
+
+	reportSection(&b, "axon.yaml")
+	cfgText := "(not found)\n"
+	if path, err := config.ConfigPath(); err == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			cfgText = string(data)
+			if flagReportRedactPaths {
+				cfgText = redactAbsPaths(cfgText)
+			}
+		}
+	}
+	b.WriteString(fence("yaml", cfgText))
+
+	reportSection(&b, "Doctor")
+	b.WriteString(fence("", formatDiagnostics(gatherDiagnostics())))
+
+	reportSection(&b, "Recent operations")
+	b.WriteString(fence("", recentJournal(flagReportHistory)))
+
+	reportSection(&b, "Hub git status")
+	b.WriteString(hubGitReport())
+	return b.String()
+}
+
+func reportSection(b *strings.Builder, title string) {
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+}
+
+// fence wraps text in a Markdown code block.
+func fence(lang, text string) string {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return "```" + lang + "\n" + text + "```\n"
+}
+
+// axonEnv lists the AXON_* environment variables that are set.
+func axonEnv() string {
+	var vars []string
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, "AXON_") {
+			vars = append(vars, kv)
+		}
+	}
+	if len(vars) == 0 {
+		return "(no AXON_* variables set)\n"
+	}
+	sort.Strings(vars)
+	return strings.Join(vars, "\n") + "\n"
+}
+
+// formatDiagnostics renders doctor results as plain text, one per line.
+func formatDiagnostics(results []DiagnosticResult) string {
+	var b strings.Builder
+	var category string
+	for _, r := range results {
+		if r.Category != category {
+			if category != "" {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "[ %s ]\n", r.Category)
+			category = r.Category
+		}
+		icon := iconOK
+		switch {
+		case r.Passed:
+		case r.Severity == DiagnosticSeverityWarn:
+			icon = iconWarn
+		default:
+			icon = iconError
+		}
+		if r.Item != "" {
+			fmt.Fprintf(&b, "  %s  [%s] %s\n", icon, r.Item, r.Message)
+		} else {
+			fmt.Fprintf(&b, "  %s  %s\n", icon, r.Message)
+		}
+		if !r.Passed && r.Remediation != "" {
+			fmt.Fprintf(&b, "      Fix: %s\n", r.Remediation)
+		}
+	}
+	return b.String()
+}
+
+// recentJournal renders the last n journal entries.
+func recentJournal(n int) string {
+	path, err := journal.Path()
+	if err != nil {
+		return err.Error()
+	}
+	entries, err := journal.Read(path, time.Time{})
+	if err != nil {
+		return fmt.Sprintf("cannot read history: %v", err)
+	}
+	if len(entries) == 0 {
+		return "(no recorded operations)\n"
+	}
+	if len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(formatHistoryEntry(e) + "\n")
+	}
+	return b.String()
+}
+
+// hubGitReport shows the branch, working tree, recent commits, and remotes
+// of every Hub repo.
+func hubGitReport() string {
+	if err := checkGitAvailable(); err != nil {
+		return fence("", err.Error())
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fence("", fmt.Sprintf("cannot load config: %v", err))
+	}
+	var b strings.Builder
+	for _, r := range cfg.HubRepos() {
+		fmt.Fprintf(&b, "### %s\n\n", r.Name)
+		var out strings.Builder
+		for _, args := range [][]string{
+			{"status", "--short", "--branch"},
+			{"log", "--oneline", "-n", "5"},
+			{"remote", "-v"},
+		} {
+			text, _ := gitOutput(r.Path, args...)
+			fmt.Fprintf(&out, "$ git %s\n%s", strings.Join(args, " "), text)
+			if text != "" && !strings.HasSuffix(text, "\n") {
+				out.WriteString("\n")
+			}
+		}
+		b.WriteString(fence("", out.String()) + "\n")
+	}
+	return b.String()
+}
+
+// reportIdent is what redactReport replaces besides secrets.
+type reportIdent struct {
+	Home, User, Host string
+}
+
+func reportIdentity() reportIdent {
+	var id reportIdent
+	id.Home, _ = os.UserHomeDir()
+	if u, err := user.Current(); err == nil {
+		id.User = u.Username
+		// Windows reports DOMAIN\user.
+		if i := strings.LastIndex(id.User, `\`); i >= 0 {
+			id.User = id.User[i+1:]
+		}
+	}
+	id.Host, _ = os.Hostname()
+	return id
+}
+
+// redactReport masks secrets, then hides the home directory, user name, and
+// host name.
+func redactReport(report string, id reportIdent) string {
+	report = secrets.Redact(report)
+	if id.Home != "" && id.Home != string(filepath.Separator) {
+		report = strings.ReplaceAll(report, id.Home, "~")
+		report = strings.ReplaceAll(report, filepath.ToSlash(id.Home), "~")
+	}
+	// The longer name first, as host names often contain the user name.
+	names := [][2]string{{id.Host, "<host>"}, {id.User, "<user>"}}
+	if len(id.User) > len(id.Host) {
+		names[0], names[1] = names[1], names[0]
+	}
+	for _, n := range names {
+		name, mask := n[0], n[1]
+		// Very short names would match inside unrelated words.
+		if len(name) < 3 {
+			continue
+		}
+		re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(name) + `\b`)
+		report = re.ReplaceAllString(report, mask)
+	}
+	return report
+}
+
+var absPath = regexp.MustCompile(`(^|[\s"'=])((?:~|[A-Za-z]:)?[\\/][^\s"'#,]*)`)
+
+// redactAbsPaths replaces absolute and home-relative paths with <path>.
+func redactAbsPaths(text string) string {
+	return absPath.ReplaceAllString(text, "${1}<path>")
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactReport(t *testing.T) {
+	id := reportIdent{Home: "/home/alice", User: "alice", Host: "alice-laptop"}
+	in := strings.Join([]string{
+		"repo_path: /home/alice/.axon/repo",
+		"origin https://alice:ghp_" + strings.Repeat("A1b2", 9) + "@github.com/alice/hub.git (fetch)",
+		"host: alice-laptop",
+		"  ✓  [remote] reachable as Alice",
+		"palice is not a user name",
+	}, "\n")
+
+	got := redactReport(in, id)
+	for _, want := range []string{
+		"repo_path: ~/.axon/repo",
+		"https://***@github.com/<user>/hub.git",
+		"host: <host>",
+		"reachable as <user>",
+		"palice is not",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("redacted report lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "ghp_") {
+		t.Errorf("token leaked:\n%s", got)
+	}
+}
+
+func TestRedactAbsPaths(t *testing.T) {
+	in := "repo_path: ~/.axon/repo\n  destination: \"C:\\Users\\me\\.cursor\"\n  source: skills\nupstream: https://github.com/kamusis/axon-hub.git\n  - /opt/skills\n"
+	want := "repo_path: <path>\n  destination: \"<path>\"\n  source: skills\nupstream: https://github.com/kamusis/axon-hub.git\n  - <path>\n"
+	if got := redactAbsPaths(in); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatDiagnostics(t *testing.T) {
+	got := formatDiagnostics([]DiagnosticResult{
+		{Category: "git", Item: "installed", Passed: true, Message: "git version 2.43"},
+		{Category: "Symlinks", Item: "cursor-skills", Severity: DiagnosticSeverityWarn, Message: "broken", Remediation: "run 'axon link'"},
+		{Category: "Symlinks", Severity: DiagnosticSeverityError, Message: "cannot stat"},
+	})
+	want := "[ git ]\n  ✓  [installed] git version 2.43\n\n[ Symlinks ]\n  ⚠  [cursor-skills] broken\n      Fix: run 'axon link'\n  ✗  cannot stat\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
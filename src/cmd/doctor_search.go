@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/embeddings"
+	searchindex "github.com/kamusis/axon-cli/internal/search/index"
+)
+
+// embeddingsProbeText is embedded once by doctor to check the provider.
+const embeddingsProbeText = "axon doctor probe"
+
+// checkSemanticSearch validates the semantic index 'axon search' would use
+// and the embeddings provider that has to match it.
+func checkSemanticSearch(cfg *config.Config) []DiagnosticResult {
+	cat := "Semantic search"
+	var res []DiagnosticResult
+
+	rebuild := func(force bool) func() error {
+		return func() error {
+			prev := flagSearchForce
+			flagSearchForce = force
+			defer func() { flagSearchForce = prev }()
+			return runSearchIndex(nil, cfg)
+		}
+	}
+
+	// ── Provider ───────────────────────────────────────────────────────────────
+	var prov embeddings.Provider
+	embCfg, err := embeddings.LoadConfig()
+	switch {
+	case err != nil:
+		res = append(res, DiagnosticResult{Category: cat, Item: "provider", Passed: false, Severity: DiagnosticSeverityError, Message: err.Error()})
+	case embCfg.Provider == "":
+		res = append(res, DiagnosticResult{Category: cat, Item: "provider", Passed: true, Message: "not configured — keyword search only (set AXON_EMBEDDINGS_PROVIDER to enable semantic search)"})
+	default:
+		prov, err = embeddings.NewFromConfig(embCfg)
+		if err != nil {
+			res = append(res, DiagnosticResult{Category: cat, Item: "provider", Passed: false, Severity: DiagnosticSeverityError, Message: err.Error(), Remediation: "fix AXON_EMBEDDINGS_PROVIDER in the environment or ~/.axon/.env"})
+			break
+		}
+		res = append(res, checkEmbeddingsProvider(cat, embCfg, prov)...)
+	}
+
+	// ── Index ──────────────────────────────────────────────────────────────────
+	dir, manifest := findSemanticIndex(cfg)
+	if manifest == nil {
+		r := DiagnosticResult{Category: cat, Item: "index", Passed: true, Message: "no semantic index built"}
+		if prov != nil {
+			r = DiagnosticResult{
+				Category:    cat,
+				Item:        "index",
+				Passed:      false,
+				Severity:    DiagnosticSeverityWarn,
+				Message:     "an embeddings provider is configured but no semantic index exists",
+				Remediation: "run 'axon search --index'",
+				CanFix:      true,
+				FixAction:   rebuild(false),
+			}
+		}
+		return append(res, r)
+	}
+
+	if _, err := searchindex.Load(dir); err != nil {
+		return append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        "index",
+			Passed:      false,
+			Severity:    DiagnosticSeverityError,
+			Message:     fmt.Sprintf("index at %s is unreadable: %v", dir, err),
+			Remediation: "run 'axon search --index --force'",
+			CanFix:      true,
+			FixAction:   rebuild(true),
+		})
+	}
+	res = append(res, DiagnosticResult{Category: cat, Item: "index", Passed: true, Message: fmt.Sprintf("%s (%s, dim %d)", dir, manifest.ModelID, manifest.Dim)})
+
+	if prov != nil && prov.ModelID() != manifest.ModelID {
+		res = append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        "model",
+			Passed:      false,
+			Severity:    DiagnosticSeverityError,
+			Message:     fmt.Sprintf("index was built with %s but the provider is %s; semantic search will refuse to run", manifest.ModelID, prov.ModelID()),
+			Remediation: "run 'axon search --index --force'",
+			CanFix:      true,
+			FixAction:   rebuild(true),
+		})
+	}
+
+	if want, err := searchTextNormalization(); err != nil {
+		res = append(res, DiagnosticResult{Category: cat, Item: "normalization", Passed: false, Severity: DiagnosticSeverityError, Message: err.Error(), Remediation: "set " + searchTextNormalizationKey + " to none or nfkc"})
+	} else if have, _ := searchindex.ParseTextNormalization(manifest.TextNorm); have != want {
+		res = append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        "normalization",
+			Passed:      false,
+			Severity:    DiagnosticSeverityWarn,
+			Message:     fmt.Sprintf("index texts were normalized with %s but %s is %s", have, searchTextNormalizationKey, want),
+			Remediation: "run 'axon search --index'",
+			CanFix:      prov != nil,
+			FixAction:   rebuild(false),
+		})
+	}
+
+	current := searchHubRevision(cfg)
+	switch {
+	case manifest.HubRevision == "":
+		res = append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        "freshness",
+			Passed:      false,
+			Severity:    DiagnosticSeverityWarn,
+			Message:     fmt.Sprintf("index built %s does not record a Hub revision; it may be stale", manifest.CreatedAt),
+			Remediation: "run 'axon search --index'",
+			CanFix:      prov != nil,
+			FixAction:   rebuild(false),
+		})
+	case manifest.HubRevision != current:
+		res = append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        "freshness",
+			Passed:      false,
+			Severity:    DiagnosticSeverityWarn,
+			Message:     fmt.Sprintf("Hub changed since the index was built %s (indexed %s, now %s)", manifest.CreatedAt, abbrevRevision(manifest.HubRevision), abbrevRevision(current)),
+			Remediation: "run 'axon search --index'",
+			CanFix:      prov != nil,
+			FixAction:   rebuild(false),
+		})
+	default:
+		res = append(res, DiagnosticResult{Category: cat, Item: "freshness", Passed: true, Message: "index matches the Hub HEAD"})
+	}
+	return res
+}
+
+// checkEmbeddingsProvider checks credentials and embeds a tiny probe text.
+func checkEmbeddingsProvider(cat string, embCfg *embeddings.Config, prov embeddings.Provider) []DiagnosticResult {
+	var missing []string
+	switch {
+	case embCfg.Provider == "azure-openai":
+		// Azure addresses a deployment at the resource's own endpoint.
+		if embCfg.Deployment == "" {
+			missing = append(missing, "AXON_EMBEDDINGS_DEPLOYMENT")
+		}
+		if embCfg.BaseURL == "" {
+			missing = append(missing, "AXON_EMBEDDINGS_BASE_URL")
+		}
+	case embCfg.Model == "":
+		missing = append(missing, "AXON_EMBEDDINGS_MODEL")
+	}
+	if embCfg.APIKey == "" {
+		missing = append(missing, "AXON_EMBEDDINGS_API_KEY")
+	}
+	if len(missing) > 0 {
+		return []DiagnosticResult{{
+			Category:    cat,
+			Item:        "provider",
+			Passed:      false,
+			Severity:    DiagnosticSeverityError,
+			Message:     fmt.Sprintf("%s is configured but %v is not set", embCfg.Provider, missing),
+			Remediation: "set them in the environment or ~/.axon/.env",
+		}}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	start := time.Now()
+	v, err := prov.Embed(ctx, embeddingsProbeText)
+	if err != nil {
+		return []DiagnosticResult{{
+			Category:    cat,
+			Item:        "provider",
+			Passed:      false,
+			Severity:    DiagnosticSeverityError,
+			Message:     fmt.Sprintf("probe request to %s failed: %v", embCfg.BaseURL, err),
+			Remediation: "check AXON_EMBEDDINGS_BASE_URL, the API key, and network access",
+		}}
+	}
+	return []DiagnosticResult{{
+		Category: cat,
+		Item:     "provider",
+		Passed:   true,
+		Message:  fmt.Sprintf("%s reachable (dim %d, %s)", prov.ModelID(), len(v), time.Since(start).Round(time.Millisecond)),
+	}}
+}
+
+// findSemanticIndex returns the directory and manifest of the index 'axon
+// search' prefers (user index first, then the Hub's), or a nil manifest.
+func findSemanticIndex(cfg *config.Config) (string, *searchindex.Manifest) {
+	var dirs []string
+	if axonDir, err := config.AxonDir(); err == nil {
+		dirs = append(dirs, filepath.Join(axonDir, "search"))
+	}
+	dirs = append(dirs, filepath.Join(cfg.RepoPath, "search"))
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, searchindex.ManifestFile)); err != nil {
+			continue
+		}
+		m, err := searchindex.ReadManifest(dir)
+		if err != nil {
+			// Report the broken manifest rather than silently falling back.
+			return dir, &searchindex.Manifest{}
+		}
+		return dir, m
+	}
+	return "", nil
+}
+
+// abbrevRevision shortens a single commit SHA for display; multi-repo
+// revisions are shown as is.
+func abbrevRevision(rev string) string {
+	if len(rev) == 40 {
+		return abbrevSHA(rev)
+	}
+	return rev
+}
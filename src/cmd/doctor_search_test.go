@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	searchindex "github.com/kamusis/axon-cli/internal/search/index"
+)
+
+func TestCheckSemanticSearch_IndexProblems(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	t.Setenv("AXON_EMBEDDINGS_PROVIDER", "")
+	cfg := &config.Config{RepoPath: filepath.Join(tmp, "hub")}
+	dir := filepath.Join(tmp, ".axon", "search")
+
+	byItem := func(res []DiagnosticResult) map[string]DiagnosticResult {
+		m := make(map[string]DiagnosticResult)
+		for _, r := range res {
+			m[r.Item] = r
+		}
+		return m
+	}
+
+	// No provider and no index: nothing to complain about.
+	for _, r := range checkSemanticSearch(cfg) {
+		if !r.Passed {
+			t.Errorf("unexpected failure without index: %+v", r)
+		}
+	}
+
+	m := searchindex.Manifest{IndexVersion: 1, ModelID: "openai:test", Dim: 2, HubRevision: "0123abcd"}
+	skills := []searchindex.SkillEntry{{ID: "a"}, {ID: "b"}}
+	if err := searchindex.Write(dir, m, skills, []float32{1, 0, 0, 1}); err != nil {
+		t.Fatal(err)
+	}
+	got := byItem(checkSemanticSearch(cfg))
+	if !got["index"].Passed {
+		t.Errorf("index should load: %+v", got["index"])
+	}
+	if f := got["freshness"]; f.Passed || f.Severity != DiagnosticSeverityWarn {
+		t.Errorf("revision mismatch should warn: %+v", f)
+	}
+
+	// Vectors that do not match dim × skills make the index unreadable.
+	if err := os.WriteFile(filepath.Join(dir, "vectors.f32"), make([]byte, 12), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got = byItem(checkSemanticSearch(cfg))
+	if r := got["index"]; r.Passed || r.Severity != DiagnosticSeverityError || !r.CanFix {
+		t.Errorf("size mismatch should be a fixable error: %+v", r)
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// healthcheckParallel bounds how many skill health checks run at once.
+const healthcheckParallel = 4
+
+// checkSkillHealth runs the healthcheck every skill declares in SKILL.md,
+// each bounded by timeout. Skills of a project repo are skipped: its
+// .axon.yaml applies on its own when axon runs inside a checkout, which
+// must not be enough to run that checkout's scripts.
+func checkSkillHealth(cfg *config.Config, timeout time.Duration) []DiagnosticResult {
+	cat := "Skill Health"
+	type probe struct {
+		name, dir, check string
+		envs             []string
+	}
+	var projectRoots []string
+	for _, r := range cfg.HubRepos() {
+		if r.Project != "" {
+			projectRoots = append(projectRoots, r.Path)
+		}
+	}
+	var probes []probe
+	skipped := 0
+	for name, node := range loadSkillGraph(cfg) {
+		meta, _ := parseSkillMeta(filepath.Join(node.Dir, "SKILL.md"))
+		check := strings.TrimSpace(meta.Healthcheck)
+		if check == "" {
+			continue
+		}
+		if slices.ContainsFunc(projectRoots, func(root string) bool { return pathWithin(root, node.Dir) }) {
+			skipped++
+			continue
+		}
+		probes = append(probes, probe{name, node.Dir, check, meta.GetRequiresEnvs()})
+	}
+	var notes []DiagnosticResult
+	if skipped > 0 {
+		notes = append(notes, DiagnosticResult{Category: cat, Passed: true,
+			Message: fmt.Sprintf("%d healthcheck(s) of project skills not run (%s)", skipped, config.ProjectFileName)})
+	}
+	if len(probes) == 0 {
+		return append(notes, DiagnosticResult{Category: cat, Passed: true, Message: "no skill declares a healthcheck"})
+	}
+	sort.Slice(probes, func(i, j int) bool { return probes[i].name < probes[j].name })
+
+	progress := startProgress("Checking skills", int64(len(probes)))
+	defer progress.Done()
+	res := make([]DiagnosticResult, len(probes))
+	sem := make(chan struct{}, healthcheckParallel)
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			start := time.Now()
+			err := runHealthcheck(cfg, p.name, p.dir, p.check, p.envs, timeout)
+			d := DiagnosticResult{Category: cat, Item: p.name}
+			if err == nil {
+				d.Passed = true
+				d.Message = fmt.Sprintf("healthy (%s)", time.Since(start).Round(time.Millisecond))
+			} else {
+				d.Severity = DiagnosticSeverityWarn
+				d.Message = err.Error()
+				d.Remediation = fmt.Sprintf("check the service behind the skill, or run its healthcheck (%s) by hand", p.check)
+			}
+			res[i] = d
+			progress.Add(1)
+		}()
+	}
+	wg.Wait()
+	return append(notes, res...)
+}
+
+// runHealthcheck probes one skill: an http(s) URL must answer with a 2xx
+// or 3xx status, a script must exit 0. $VARS in a URL are expanded from the
+// environment and ~/.axon/.env, but only those listed in envs (the skill's
+// requires.envs), so a skill cannot send arbitrary secrets to a URL it picks.
+// The same envs are provided to a script.
+func runHealthcheck(cfg *config.Config, skill, dir, check string, envs []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var undeclared []string
+	url := os.Expand(check, func(key string) string {
+		if !slices.Contains(envs, key) {
+			undeclared = append(undeclared, key)
+			return ""
+		}
+		v, _ := config.GetConfigValue(key)
+		return v
+	})
+	if len(undeclared) > 0 && strings.Contains(check, "://") {
+		return fmt.Errorf("healthcheck URL uses %s, not declared in requires.envs", strings.Join(undeclared, ", "))
+	}
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out after %s", timeout)
+			}
+			return fmt.Errorf("request failed: %v", errors.Unwrap(err))
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("answered %s", resp.Status)
+		}
+		return nil
+	}
+
+	path, err := resolveSkillScript(dir, check)
+	if err != nil {
+		return err
+	}
+	name, args, err := scriptCommand(path, nil)
+	if err != nil {
+		return err
+	}
+	extra, _ := skillRunEnv(envs)
+	c := exec.CommandContext(ctx, name, args...)
+	c.Dir = dir
+	if c.Env, err = skillScriptEnv(cfg, skill, dir, extra); err != nil {
+		return err
+	}
+	// Children that keep the output open must not hold doctor past the timeout.
+	c.WaitDelay = 2 * time.Second
+	var out bytes.Buffer
+	c.Stdout, c.Stderr = &out, &out
+	err = c.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("%s failed: %s", check, firstLine(strings.TrimSpace(out.String()), err))
+	}
+	return nil
+}
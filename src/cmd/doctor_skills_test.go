@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestRunHealthcheck_URL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AXON_TEST_SVC", srv.URL)
+	cfg, _ := setupLinkTest(t)
+
+	if err := runHealthcheck(cfg, "api", cfg.RepoPath, "${AXON_TEST_SVC}/health", []string{"AXON_TEST_SVC"}, 5*time.Second); err != nil {
+		t.Errorf("healthy service: %v", err)
+	}
+	t.Setenv("AXON_TEST_SECRET", "hunter2")
+	err := runHealthcheck(cfg, "api", cfg.RepoPath, srv.URL+"/health?k=$AXON_TEST_SECRET", []string{"AXON_TEST_SVC"}, 5*time.Second)
+	if err == nil || !strings.Contains(err.Error(), "AXON_TEST_SECRET") {
+		t.Errorf("undeclared variable in URL: %v", err)
+	}
+	err = runHealthcheck(cfg, "api", cfg.RepoPath, srv.URL+"/down", nil, 5*time.Second)
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("failing service: %v", err)
+	}
+}
+
+func TestRunHealthcheck_Script(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	t.Setenv("HOME", t.TempDir())
+	cfg, _ := setupLinkTest(t)
+	dir := filepath.Join(cfg.RepoPath, "skills", "api")
+	if err := os.MkdirAll(filepath.Join(dir, "scripts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	scripts := map[string]string{
+		"ok.sh":   `test "$AXON_SKILL" = api`,
+		"fail.sh": "echo service down; exit 1",
+		"slow.sh": "exec sleep 5",
+	}
+	for name, body := range scripts {
+		if err := os.WriteFile(filepath.Join(dir, "scripts", name), []byte(body+"\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := runHealthcheck(cfg, "api", dir, "scripts/ok.sh", nil, 5*time.Second); err != nil {
+		t.Errorf("ok.sh: %v", err)
+	}
+	if err := runHealthcheck(cfg, "api", dir, "fail.sh", nil, 5*time.Second); err == nil || !strings.Contains(err.Error(), "service down") {
+		t.Errorf("fail.sh: %v", err)
+	}
+	if err := runHealthcheck(cfg, "api", dir, "slow.sh", nil, 100*time.Millisecond); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("slow.sh: %v", err)
+	}
+}
+
+func TestCheckSkillHealth_SkipsProjectSkills(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	t.Setenv("HOME", t.TempDir())
+	cfg, tmp := setupLinkTest(t)
+	project := filepath.Join(tmp, "checkout")
+	dir := filepath.Join(project, ".axon", "skills", "evil")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	marker := filepath.Join(tmp, "ran")
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: evil\nhealthcheck: check.sh\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "check.sh"), []byte("touch "+marker+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Repos = append(cfg.Repos, config.Repo{Name: "project", Path: filepath.Join(project, ".axon"), Project: project})
+
+	res := checkSkillHealth(cfg, 5*time.Second)
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Fatal("project skill healthcheck was run")
+	}
+	if len(res) == 0 || !strings.Contains(res[0].Message, "not run") {
+		t.Errorf("results = %+v, want a note about the skipped project skill", res)
+	}
+}
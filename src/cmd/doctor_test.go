@@ -0,0 +1,34 @@
+package cmd
+
+import "testing"
+
+func TestInFixScope(t *testing.T) {
+	cases := []struct {
+		category string
+		scope    []string
+		want     bool
+	}{
+		{"Symlinks", nil, true},
+		{"Symlinks", []string{"symlinks"}, true},
+		{"Unresolved conflicts", []string{"Conflicts"}, true},
+		{"Unresolved conflicts", []string{"symlinks"}, false},
+		{"Symlinks", []string{" ", "wsl"}, false},
+	}
+	for _, c := range cases {
+		if got := inFixScope(c.category, c.scope); got != c.want {
+			t.Errorf("inFixScope(%q, %q) = %v, want %v", c.category, c.scope, got, c.want)
+		}
+	}
+}
+
+func TestCountIssues(t *testing.T) {
+	results := []DiagnosticResult{
+		{Passed: true},
+		{Severity: DiagnosticSeverityWarn},
+		{Severity: DiagnosticSeverityError},
+		{}, // unset severity is reported as an error
+	}
+	if errs, warns := countIssues(results); errs != 2 || warns != 1 {
+		t.Errorf("countIssues = %d, %d; want 2, 1", errs, warns)
+	}
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/bundle"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// e2eEnv is a throwaway machine for end-to-end tests: a temp HOME holding
+// the fake tool directories, a bare git remote, and the real cobra commands
+// run in-process against them.
+type e2eEnv struct {
+	t      *testing.T
+	home   string // the fake $HOME
+	remote string // a bare repository usable as the Hub remote
+}
+
+// newE2EEnv creates the fake home and the empty remote and points axon and
+// git at them. Tests using it cannot run in parallel, since the commands
+// share the process environment and flag variables.
+func newE2EEnv(t *testing.T) *e2eEnv {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("end-to-end test")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	tmp := t.TempDir()
+	e := &e2eEnv{t: t, home: filepath.Join(tmp, "home"), remote: filepath.Join(tmp, "remote.git")}
+	if err := os.MkdirAll(e.home, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]string{
+		"HOME":                 e.home,
+		"USERPROFILE":          e.home,
+		"XDG_CONFIG_HOME":      "",
+		"XDG_DATA_HOME":        "",
+		"SHELL":                "",
+		"AXON_HOME":            "",
+		"AXON_PORTABLE":        "",
+		"AXON_MACHINE":         "e2e",
+		"AXON_LANG":            "",
+		"AXON_NO_UPDATE_CHECK": "1",
+		"GIT_CONFIG_NOSYSTEM":  "1",
+		"GIT_CONFIG_GLOBAL":    filepath.Join(e.home, ".gitconfig"),
+	} {
+		t.Setenv(k, v)
+	}
+	t.Chdir(tmp)
+	// sync looks for the identity in git config, not in GIT_AUTHOR_NAME.
+	// The Hub syncs the master branch, so init.defaultBranch stays unset.
+	e.write(".gitconfig", "[user]\n\tname = Axon E2E\n\temail = e2e@axon.local\n")
+	e.git("init", "--bare", "--initial-branch=master", e.remote)
+	return e
+}
+
+// path returns rel (slash-separated) inside the fake home.
+func (e *e2eEnv) path(rel string) string {
+	return filepath.Join(e.home, filepath.FromSlash(rel))
+}
+
+// write creates the file rel inside the fake home.
+func (e *e2eEnv) write(rel, content string) {
+	e.t.Helper()
+	p := e.path(rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		e.t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		e.t.Fatal(err)
+	}
+}
+
+// read returns the content of rel inside the fake home.
+func (e *e2eEnv) read(rel string) string {
+	e.t.Helper()
+	data, err := os.ReadFile(e.path(rel))
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	return string(data)
+}
+
+// git runs git with args and returns its trimmed output.
+func (e *e2eEnv) git(args ...string) string {
+	e.t.Helper()
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		e.t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// bundle writes a bundle of files (Hub-relative path → content) as
+// 'axon export' would and returns its path.
+func (e *e2eEnv) bundle(files map[string]string) string {
+	e.t.Helper()
+	root := e.t.TempDir()
+	items := make(map[string]bool)
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			e.t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			e.t.Fatal(err)
+		}
+		items[strings.Join(strings.SplitN(rel, "/", 3)[:2], "/")] = true
+	}
+	var m bundle.Manifest
+	for item := range items {
+		m.Items = append(m.Items, bundle.Item{Path: item})
+	}
+	out := filepath.Join(e.t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(out)
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := bundle.Create(f, root, m); err != nil {
+		e.t.Fatal(err)
+	}
+	return out
+}
+
+// run runs 'axon args...' and returns its stdout and stderr, failing the
+// test when the command fails.
+func (e *e2eEnv) run(args ...string) string {
+	e.t.Helper()
+	out, err := e.runErr(args...)
+	if err != nil {
+		e.t.Fatalf("axon %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return out
+}
+
+// runErr runs 'axon args...' like Execute does and returns its combined
+// stdout and stderr along with the command's error.
+func (e *e2eEnv) runErr(args ...string) (string, error) {
+	e.t.Helper()
+	resetCommandFlags(rootCmd)
+	r, w, err := os.Pipe()
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	oldOut, oldErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, w
+	var buf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(&buf, r)
+	}()
+
+	rootCmd.SetArgs(args)
+	_, runErr := rootCmd.ExecuteC()
+	releaseHubLock()
+
+	os.Stdout, os.Stderr = oldOut, oldErr
+	w.Close()
+	wg.Wait()
+	r.Close()
+	return buf.String(), runErr
+}
+
+// resetCommandFlags puts every flag of c and its subcommands back to its
+// default, so one run's flags do not leak into the next.
+func resetCommandFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetCommandFlags(sub)
+	}
+}
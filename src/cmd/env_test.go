@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckEnvDeps_DotEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("AXON_TEST_SHELL", "1")
+	os.Unsetenv("AXON_TEST_DOTENV")
+	os.Unsetenv("AXON_TEST_MISSING")
+	if err := os.MkdirAll(filepath.Join(home, ".axon"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".axon", ".env"), []byte("AXON_TEST_DOTENV=x\n# AXON_TEST_MISSING=\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, _ := setupLinkTest(t)
+	dir := filepath.Join(cfg.RepoPath, "skills", "api")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	skill := "---\nname: api\nrequires:\n  envs: [AXON_TEST_SHELL, AXON_TEST_DOTENV, AXON_TEST_MISSING]\n---\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skill), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]DiagnosticResult)
+	for _, r := range checkEnvDeps(cfg) {
+		got[r.Item] = r
+	}
+	if r := got["AXON_TEST_SHELL (api)"]; !r.Passed || r.Message != "set in environment" {
+		t.Errorf("shell variable: %+v", r)
+	}
+	if r := got["AXON_TEST_DOTENV (api)"]; !r.Passed || r.Message != "set in ~/.axon/.env" {
+		t.Errorf("dotenv variable: %+v", r)
+	}
+	if r := got["AXON_TEST_MISSING (api)"]; r.Passed || r.Severity != DiagnosticSeverityWarn {
+		t.Errorf("placeholder counted as set: %+v", r)
+	}
+}
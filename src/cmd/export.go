@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/bundle"
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export Hub content to a portable tar.gz bundle",
+	Long: `Write selected Hub content to a gzip-compressed tarball with a manifest
+recording item versions, per-file SHA-256 digests, and the source Hub revision.
+Use 'axon import <bundle>' on another machine (e.g. an air-gapped one) to merge it.
+
+Without --skills, every configured source directory (skills/, workflows/, ...)
+is exported.
+
+Examples:
+  axon export -o hub.tar.gz
+  axon export --skills git-release,workflows/deploy -o subset.tar.gz`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+var (
+	flagExportSkills []string
+	flagExportOutput string
+)
+
+func init() {
+	exportCmd.Flags().StringSliceVar(&flagExportSkills, "skills", nil, "Comma-separated skills/workflows/commands to export (default: all)")
+	exportCmd.Flags().StringVarP(&flagExportOutput, "output", "o", "", "Output archive path (default: axon-export-<timestamp>.tar.gz)")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(_ *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+
+	items, err := exportItems(cfg, flagExportSkills)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("nothing to export: Hub source directories are empty")
+	}
+
+	out := flagExportOutput
+	if out == "" {
+		out = fmt.Sprintf("axon-export-%s.tar.gz", time.Now().Format("20060102150405"))
+	}
+
+	m := bundle.Manifest{
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+		AxonVersion: version,
+		Source:      exportSource(cfg.RepoPath),
+		Items:       items,
+	}
+
+	// Write to a temp file beside the target so a failed export never leaves
+	// a truncated archive behind.
+	tmp, err := os.CreateTemp(filepath.Dir(out), ".axon-export-*")
+	if err != nil {
+		return fmt.Errorf("cannot create output file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	written, err := bundle.Create(tmp, cfg.RepoPath, m)
+	if cerr := tmp.Close(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+	if err := os.Rename(tmpName, out); err != nil {
+		return fmt.Errorf("cannot write %s: %w", out, err)
+	}
+
+	var size int64
+	if info, err := os.Stat(out); err == nil {
+		size = info.Size()
+	}
+
+	printSection("Export")
+	for _, it := range written.Items {
+		msg := ""
+		if it.Version != "" {
+			msg = "v" + strings.TrimPrefix(it.Version, "v")
+		}
+		printOK(it.Path, msg)
+	}
+	fmt.Printf("\n  %d item(s), %d file(s), %s → %s\n", len(written.Items), len(written.Files), humanBytes(size), out)
+	if written.Source.Revision != "" {
+		fmt.Printf("  Hub revision: %s\n", written.Source.Revision)
+	}
+	return nil
+}
+
+// exportItems resolves the --skills selection (or every entry of each source
+// root when empty) to Hub-relative bundle items with their declared versions.
+func exportItems(cfg *config.Config, names []string) ([]bundle.Item, error) {
+	var paths []string
+	if len(names) > 0 {
+		seen := make(map[string]bool)
+		for _, n := range names {
+			n = strings.TrimSpace(n)
+			if n == "" {
+				continue
+			}
+			rel, err := resolveSkillPath(cfg.RepoPath, n)
+			if err != nil {
+				return nil, err
+			}
+			rel = filepath.ToSlash(filepath.Clean(rel))
+			if !seen[rel] {
+				seen[rel] = true
+				paths = append(paths, rel)
+			}
+		}
+	} else {
+		for _, root := range cfg.EffectiveSearchRoots() {
+			entries, err := os.ReadDir(filepath.Join(cfg.RepoPath, root))
+			if err != nil {
+				continue
+			}
+			for _, e := range entries {
+				if e.Name() == ".git" {
+					continue
+				}
+				paths = append(paths, filepath.ToSlash(filepath.Join(root, e.Name())))
+			}
+		}
+	}
+
+	items := make([]bundle.Item, 0, len(paths))
+	for _, p := range paths {
+		it := bundle.Item{Path: p}
+		if meta, ok := parseSkillMeta(filepath.Join(cfg.RepoPath, filepath.FromSlash(p), "SKILL.md")); ok {
+			it.Version = meta.Version
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// exportSource records the host and Hub revision the bundle was built from.
+// Missing git information is not an error: a local-only Hub still exports.
+func exportSource(repoPath string) bundle.Source {
+	var src bundle.Source
+	src.Host, _ = os.Hostname()
+	if checkGitAvailable() != nil {
+		return src
+	}
+	if out, err := gitOutput(repoPath, "rev-parse", "HEAD"); err == nil {
+		src.Revision = strings.TrimSpace(out)
+	}
+	if out, err := gitOutput(repoPath, "remote", "get-url", "origin"); err == nil {
+		src.Remote = strings.TrimSpace(out)
+	}
+	return src
+}
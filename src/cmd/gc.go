@@ -0,0 +1,649 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/trash"
+	"github.com/kamusis/axon-cli/internal/vendor"
+	"github.com/spf13/cobra"
+)
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Reclaim disk space used by backups, temp dirs, and caches",
+	Long: `Clean up artifacts that axon accumulates over time:
+
+  backups      keep only the newest --keep-backups backups per target,
+               moving older ones to the trash
+  temp         remove stale axon temp dirs, and anything stale in ~/.axon/tmp
+  vendors      remove vendor caches no longer referenced in axon.yaml
+  embeddings   expire embeddings cache entries older than --cache-ttl
+  empty dirs   remove empty directories under the Hub's search roots
+  conflicts    move *.conflict-* files older than --conflict-age to the
+               trash, after listing them and asking for confirmation
+               (--yes skips it)
+  trash        delete trashed items older than trash_retention (30d)
+  hub          run 'git gc' on the Hub repository
+
+Trashed items can be brought back with 'axon trash restore' until they
+expire; 'axon trash empty' deletes them right away.
+
+--suggest removes nothing. With usage_tracking on in axon.yaml, it lists
+the skills no tool has read for --unused-age (90 days) as candidates for
+removal.
+
+Examples:
+  axon gc
+  axon gc --dry-run
+  axon gc --suggest
+  axon gc --keep-backups 1 --cache-ttl 168h
+  axon gc --conflict-age 168h --yes`,
+	Args: cobra.NoArgs,
+	RunE: runGC,
+}
+
+var (
+	flagGCDryRun      bool
+	flagGCKeepBackups int
+	flagGCCacheTTL    time.Duration
+	flagGCConflictAge time.Duration
+	flagGCYes         bool
+	flagGCSuggest     bool
+	flagGCUnusedAge   time.Duration
+)
+
+// gcStaleTempAge is how old a temp dir must be before gc considers it
+// abandoned. Anything younger may belong to a concurrently running command.
+const gcStaleTempAge = 24 * time.Hour
+
+func init() {
+	gcCmd.Flags().BoolVar(&flagGCDryRun, "dry-run", false, "Show what would be removed without deleting anything")
+	gcCmd.Flags().IntVar(&flagGCKeepBackups, "keep-backups", 3, "Number of most recent backups to keep per target")
+	gcCmd.Flags().DurationVar(&flagGCCacheTTL, "cache-ttl", 30*24*time.Hour, "Expire embeddings cache entries older than this")
+	gcCmd.Flags().DurationVar(&flagGCConflictAge, "conflict-age", 30*24*time.Hour, "Delete conflict files last modified longer ago than this")
+	gcCmd.Flags().BoolVarP(&flagGCYes, "yes", "y", false, "Delete old conflict files without asking for confirmation")
+	gcCmd.Flags().BoolVar(&flagGCSuggest, "suggest", false, "Only list skills unused for --unused-age as removal candidates")
+	gcCmd.Flags().DurationVar(&flagGCUnusedAge, "unused-age", defaultUnusedAge, "With --suggest, how long a skill must go unread")
+	rootCmd.AddCommand(gcCmd)
+}
+
+// gcReport summarises what one gc category removed (or would remove).
+type gcReport struct {
+	Category string
+	Items    int
+	Bytes    int64
+	Note     string
+	Err      error
+
+	// Trashed marks items moved to the trash rather than deleted; their
+	// space is reclaimed only when the trash is emptied.
+	Trashed bool
+}
+
+func runGC(cmd *cobra.Command, _ []string) error {
+	if flagGCKeepBackups < 0 {
+		return fmt.Errorf("--keep-backups must be >= 0")
+	}
+	if flagGCSuggest {
+		return runGCSuggest()
+	}
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return err
+	}
+
+	// Config is optional: without it we can still clean backups and temp dirs.
+	cfg, cfgErr := config.Load()
+
+	title := "Garbage Collection"
+	if flagGCDryRun {
+		title += " (dry run)"
+	}
+	printSection(title)
+
+	// Conflict files hold edits nobody has merged yet: list them and ask
+	// before anything is deleted.
+	var conflicts []string
+	conflictsNote := ""
+	if cfgErr == nil {
+		conflicts = staleConflictFiles(cfg, time.Now().Add(-flagGCConflictAge))
+		if len(conflicts) > 0 && !flagGCDryRun && !flagGCYes {
+			printBullet(fmt.Sprintf("Conflict files not modified for %s:", gcAge(flagGCConflictAge)))
+			for _, p := range conflicts {
+				printItem(p)
+			}
+			if !confirm(cmd.InOrStdin(), fmt.Sprintf("Delete %d conflict file(s)?", len(conflicts))) {
+				conflicts, conflictsNote = nil, "kept (not confirmed)"
+			}
+		}
+	} else {
+		conflictsNote = "skipped (axon.yaml not loaded)"
+	}
+
+	reports := []gcReport{
+		gcBackups(filepath.Join(axonDir, "backups"), flagGCKeepBackups, flagGCDryRun),
+		gcTempDirs(gcTempBases(axonDir), filepath.Join(axonDir, "tmp"), time.Now().Add(-gcStaleTempAge), flagGCDryRun),
+	}
+	if cfgErr == nil {
+		reports = append(reports, gcVendorCaches(cfg.Vendors, flagGCDryRun))
+	} else {
+		reports = append(reports, gcReport{Category: "vendors", Note: "skipped (axon.yaml not loaded)"})
+	}
+	reports = append(reports, gcEmbeddingsCache(filepath.Join(axonDir, "cache", "embeddings"), time.Now().Add(-flagGCCacheTTL), flagGCDryRun))
+	retention := config.DefaultTrashRetention
+	if cfgErr == nil {
+		retention = cfg.TrashRetentionPeriod()
+	}
+	reports = append(reports, gcExpiredTrash(retention, flagGCDryRun))
+	if cfgErr == nil {
+		empty := gcEmptyDirs(cfg, flagGCDryRun)
+		reports = append(reports, empty)
+		if empty.Items > 0 && !flagGCDryRun {
+			refreshMergedViews(cfg)
+		}
+		conflictsRep := gcFiles("conflicts", conflicts, flagGCDryRun)
+		conflictsRep.Note = conflictsNote
+		reports = append(reports, conflictsRep, gcHubGit(cfg.RepoPath, flagGCDryRun))
+	} else {
+		reports = append(reports,
+			gcReport{Category: "empty dirs", Note: "skipped (axon.yaml not loaded)"},
+			gcReport{Category: "conflicts", Note: conflictsNote},
+			gcReport{Category: "hub", Note: "skipped (axon.yaml not loaded)"})
+	}
+
+	verb := "reclaimed"
+	if flagGCDryRun {
+		verb = "reclaimable"
+	}
+
+	trashVerb := "moved to the trash"
+	if flagGCDryRun {
+		trashVerb = "to move to the trash"
+	}
+
+	var total int64
+	var failed int
+	trashed := false
+	fmt.Println()
+	for _, r := range reports {
+		switch {
+		case r.Err != nil:
+			failed++
+			printErr(r.Category, r.Err.Error())
+		case r.Note != "":
+			printSkip(r.Category, r.Note)
+		case r.Items == 0 && r.Bytes == 0:
+			printSkip(r.Category, "nothing to clean")
+		case r.Trashed:
+			trashed = true
+			printOK(r.Category, fmt.Sprintf("%d item(s), %s %s", r.Items, humanBytes(r.Bytes), trashVerb))
+		default:
+			total += r.Bytes
+			printOK(r.Category, fmt.Sprintf("%d item(s), %s %s", r.Items, humanBytes(r.Bytes), verb))
+		}
+	}
+
+	fmt.Printf("\n  Total %s: %s\n", verb, humanBytes(total))
+	if trashed && !flagGCDryRun {
+		printInfo("", "Restore trashed items with 'axon trash restore', or free their space now with 'axon trash empty'.")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d gc step(s) failed", failed)
+	}
+	return nil
+}
+
+// gcBackups keeps the newest keep backups per target in backupsDir and moves
+// the rest to the trash. Backups are named <target>_<YYYYMMDDHHMMSS> by axon
+// link; most are directories, but link --force also moves files, sockets,
+// and other non-directory destinations here.
+func gcBackups(backupsDir string, keep int, dryRun bool) gcReport {
+	rep := gcReport{Category: "backups", Trashed: true}
+	victims, err := selectExpiredBackups(backupsDir, keep)
+	if err != nil {
+		rep.Err = err
+		return rep
+	}
+	for _, p := range victims {
+		size := backupSize(p)
+		if !dryRun {
+			if err := moveToTrash(p, "gc: backups"); err != nil {
+				rep.Err = err
+				return rep
+			}
+		}
+		rep.Items++
+		rep.Bytes += size
+	}
+	return rep
+}
+
+// backupSize returns the bytes held by a backup: the regular files below a
+// directory, or the Lstat size of anything else.
+func backupSize(path string) int64 {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0
+	}
+	if info.IsDir() {
+		return dirSize(path)
+	}
+	return info.Size()
+}
+
+// selectExpiredBackups returns the backup paths beyond the newest keep entries
+// for each target, whatever their type. Entries whose name does not parse as
+// a backup are ignored.
+func selectExpiredBackups(backupsDir string, keep int) ([]string, error) {
+	entries, err := os.ReadDir(backupsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type backup struct {
+		path string
+		t    time.Time
+	}
+	byTarget := make(map[string][]backup)
+	for _, e := range entries {
+		idx := strings.LastIndex(e.Name(), "_")
+		if idx <= 0 {
+			continue
+		}
+		t, err := time.Parse(backupLayout, e.Name()[idx+1:])
+		if err != nil {
+			continue
+		}
+		target := e.Name()[:idx]
+		byTarget[target] = append(byTarget[target], backup{filepath.Join(backupsDir, e.Name()), t})
+	}
+
+	var out []string
+	for _, list := range byTarget {
+		sort.Slice(list, func(i, j int) bool { return list[i].t.After(list[j].t) })
+		for i := keep; i < len(list); i++ {
+			out = append(out, list[i].path)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// gcTempBases returns every directory axon may have used as a temp base.
+// It mirrors the candidates of chooseWritableTempBase plus ~/.axon/tmp used by
+// the search indexer, imports, and publish.
+func gcTempBases(axonDir string) []string {
+	bases := []string{os.TempDir(), filepath.Join(axonDir, "tmp")}
+	if cacheDir, err := os.UserCacheDir(); err == nil && cacheDir != "" && config.PortableDir() == "" {
+		bases = append(bases, filepath.Join(cacheDir, "axon", "tmp"))
+	}
+	return bases
+}
+
+// gcTempPrefixes are the name prefixes of temp dirs created by update,
+// import, publish, and the search indexer.
+var gcTempPrefixes = []string{"axon-update-", "axon-import-", "axon-publish-", "search-index-"}
+
+// gcTempDirs removes axon-update-*, axon-import-*, axon-publish-*, and
+// search-index-* dirs last modified before cutoff from each base directory.
+// owned is axon's own temp base: every entry in it older than cutoff goes,
+// whatever its name.
+func gcTempDirs(bases []string, owned string, cutoff time.Time, dryRun bool) gcReport {
+	rep := gcReport{Category: "temp"}
+	seen := make(map[string]bool)
+	for _, base := range bases {
+		if seen[base] {
+			continue
+		}
+		seen[base] = true
+
+		entries, err := os.ReadDir(base)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if base != owned && (!e.IsDir() || !hasTempPrefix(name)) {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil || info.ModTime().After(cutoff) {
+				continue
+			}
+			p := filepath.Join(base, name)
+			size := dirSize(p)
+			if !dryRun {
+				if err := os.RemoveAll(p); err != nil {
+					rep.Err = fmt.Errorf("cannot remove %s: %w", p, err)
+					return rep
+				}
+			}
+			rep.Items++
+			rep.Bytes += size
+		}
+	}
+	return rep
+}
+
+func hasTempPrefix(name string) bool {
+	for _, p := range gcTempPrefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// gcVendorCaches removes cached vendor clones and <name>.sha state files that
+// no configured vendor entry refers to anymore.
+func gcVendorCaches(vendors []config.Vendor, dryRun bool) gcReport {
+	rep := gcReport{Category: "vendors"}
+	root, err := vendor.CacheRoot()
+	if err != nil {
+		rep.Err = err
+		return rep
+	}
+
+	keepRepos := make(map[string]bool)
+	keepSHAs := make(map[string]bool)
+	for _, v := range vendors {
+		if p, err := vendor.CachePath(v.Repo); err == nil {
+			keepRepos[p] = true
+		}
+		keepSHAs[v.Name+".sha"] = true
+	}
+
+	owners, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return rep
+	}
+	if err != nil {
+		rep.Err = err
+		return rep
+	}
+
+	remove := func(p string) error {
+		size := dirSize(p)
+		if !dryRun {
+			if err := os.RemoveAll(p); err != nil {
+				return fmt.Errorf("cannot remove %s: %w", p, err)
+			}
+		}
+		rep.Items++
+		rep.Bytes += size
+		return nil
+	}
+
+	for _, o := range owners {
+		ownerPath := filepath.Join(root, o.Name())
+		if !o.IsDir() {
+			if strings.HasSuffix(o.Name(), ".sha") && !keepSHAs[o.Name()] {
+				if err := remove(ownerPath); err != nil {
+					rep.Err = err
+					return rep
+				}
+			}
+			continue
+		}
+		repos, err := os.ReadDir(ownerPath)
+		if err != nil {
+			continue
+		}
+		kept := 0
+		for _, r := range repos {
+			repoPath := filepath.Join(ownerPath, r.Name())
+			if keepRepos[repoPath] {
+				kept++
+				continue
+			}
+			if err := remove(repoPath); err != nil {
+				rep.Err = err
+				return rep
+			}
+		}
+		if kept == 0 && !dryRun {
+			_ = os.Remove(ownerPath)
+		}
+	}
+	return rep
+}
+
+// gcEmptyDirs removes directories under the search roots of every Hub repo
+// that contain no files, e.g. a skill whose files were all deleted. Git does
+// not track them, so they linger after a sync and show up as empty skills.
+func gcEmptyDirs(cfg *config.Config, dryRun bool) gcReport {
+	rep := gcReport{Category: "empty dirs"}
+	for _, repo := range cfg.HubRepos() {
+		for _, root := range cfg.EffectiveSearchRoots() {
+			dirs, err := emptyDirs(filepath.Join(repo.Path, root))
+			if err != nil {
+				rep.Err = err
+				return rep
+			}
+			for _, d := range dirs {
+				if !dryRun {
+					if err := os.RemoveAll(d); err != nil {
+						rep.Err = fmt.Errorf("cannot remove %s: %w", d, err)
+						return rep
+					}
+				}
+				rep.Items++
+			}
+		}
+	}
+	return rep
+}
+
+// emptyDirs returns the outermost directories below root (root excluded)
+// that hold no files, only other empty directories. Hidden entries count as
+// files, and symlinks are not followed.
+func emptyDirs(root string) ([]string, error) {
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil, nil
+	}
+	var out []string
+	var isEmpty func(dir string) (bool, error)
+	isEmpty = func(dir string) (bool, error) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return false, err
+		}
+		empty := true
+		var emptyChildren []string
+		for _, e := range entries {
+			p := filepath.Join(dir, e.Name())
+			if !e.IsDir() {
+				empty = false
+				continue
+			}
+			sub, err := isEmpty(p)
+			if err != nil {
+				return false, err
+			}
+			if sub {
+				emptyChildren = append(emptyChildren, p)
+			} else {
+				empty = false
+			}
+		}
+		if !empty || dir == root {
+			out = append(out, emptyChildren...)
+		}
+		return empty, nil
+	}
+	if _, err := isEmpty(root); err != nil {
+		return nil, err
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// staleConflictFiles returns the conflict copies (<name>.conflict-<source>)
+// in the Hub last modified before cutoff, as absolute paths.
+func staleConflictFiles(cfg *config.Config, cutoff time.Time) []string {
+	var out []string
+	for _, rel := range findConflictFiles(cfg.RepoPath) {
+		p := filepath.Join(cfg.RepoPath, rel)
+		if info, err := os.Stat(p); err == nil && info.ModTime().Before(cutoff) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// gcFiles moves paths to the trash and reports them under category.
+func gcFiles(category string, paths []string, dryRun bool) gcReport {
+	rep := gcReport{Category: category, Trashed: true}
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		if !dryRun {
+			if err := moveToTrash(p, "gc: "+category); err != nil {
+				rep.Err = err
+				return rep
+			}
+		}
+		rep.Items++
+		rep.Bytes += info.Size()
+	}
+	return rep
+}
+
+// runGCSuggest lists removal candidates without removing anything.
+func runGCSuggest() error {
+	if flagGCUnusedAge <= 0 {
+		return fmt.Errorf("--unused-age must be positive")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	printSection("Suggestions")
+	printBullet(fmt.Sprintf("Skills not read for %s:", gcAge(flagGCUnusedAge)))
+	printUnusedSkills(cfg, flagGCUnusedAge)
+	fmt.Println("\n  Nothing was removed. Delete skills you no longer need from the Hub and run 'axon sync'.")
+	return nil
+}
+
+// gcAge renders an age flag in days when it is a whole number of them.
+func gcAge(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%d days", int(d/(24*time.Hour)))
+	}
+	return d.String()
+}
+
+// gcEmbeddingsCache removes embeddings cache files last modified before cutoff.
+func gcEmbeddingsCache(cacheDir string, cutoff time.Time, dryRun bool) gcReport {
+	rep := gcReport{Category: "embeddings"}
+	if _, err := os.Stat(cacheDir); os.IsNotExist(err) {
+		return rep
+	}
+	err := filepath.WalkDir(cacheDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			return nil
+		}
+		if !dryRun {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("cannot remove %s: %w", path, err)
+			}
+		}
+		rep.Items++
+		rep.Bytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		rep.Err = err
+	}
+	return rep
+}
+
+// gcHubGit runs 'git gc' on the Hub and reports how much .git shrank.
+func gcHubGit(repoPath string, dryRun bool) gcReport {
+	rep := gcReport{Category: "hub"}
+	gitDir := filepath.Join(repoPath, ".git")
+	if _, err := os.Stat(gitDir); err != nil {
+		rep.Note = "skipped (Hub repo not initialised)"
+		return rep
+	}
+	if err := checkGitAvailable(); err != nil {
+		rep.Note = "skipped (git not available)"
+		return rep
+	}
+	if dryRun {
+		rep.Note = fmt.Sprintf("would run 'git gc' (.git is %s)", humanBytes(dirSize(gitDir)))
+		return rep
+	}
+
+	before := dirSize(gitDir)
+	if out, err := gitOutput(repoPath, "gc", "--quiet"); err != nil {
+		rep.Err = fmt.Errorf("git gc failed: %w\n%s", err, strings.TrimSpace(out))
+		return rep
+	}
+	after := dirSize(gitDir)
+	rep.Items = 1
+	if before > after {
+		rep.Bytes = before - after
+	}
+	return rep
+}
+
+// dirSize returns the total size in bytes of regular files under path.
+// Unreadable entries are skipped; a missing path has size 0.
+func dirSize(path string) int64 {
+	var total int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total
+}
+
+// gcExpiredTrash deletes the trash entries older than retention.
+func gcExpiredTrash(retention time.Duration, dryRun bool) gcReport {
+	rep := gcReport{Category: "trash"}
+	root, err := trash.Dir()
+	if err != nil {
+		rep.Err = err
+		return rep
+	}
+	entries, err := trash.List(root)
+	if err != nil {
+		rep.Err = err
+		return rep
+	}
+	for _, e := range trash.Expired(entries, retention, time.Now()) {
+		if !dryRun {
+			if err := trash.Delete(e); err != nil {
+				rep.Err = fmt.Errorf("cannot delete trash entry %s: %w", e.ID, err)
+				return rep
+			}
+		}
+		rep.Items++
+		rep.Bytes += e.Size
+	}
+	return rep
+}
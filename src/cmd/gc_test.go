@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/trash"
+)
+
+func TestSelectExpiredBackups_KeepsNewestPerTarget(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"claude-code-skills_20260101000000",
+		"claude-code-skills_20260102000000",
+		"claude-code-skills_20260103000000",
+		"windsurf-skills_20260101000000",
+		"not-a-backup",
+	} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := selectExpiredBackups(dir, 1)
+	if err != nil {
+		t.Fatalf("selectExpiredBackups: %v", err)
+	}
+	want := []string{
+		filepath.Join(dir, "claude-code-skills_20260101000000"),
+		filepath.Join(dir, "claude-code-skills_20260102000000"),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGCBackups_PrunesFileBackups(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "t_20260103000000"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"t_20260101000000", "t_20260102000000"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("12345"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rep := gcBackups(dir, 1, false)
+	if rep.Err != nil || rep.Items != 2 || rep.Bytes != 10 {
+		t.Fatalf("report = %+v, want 2 items / 10 bytes", rep)
+	}
+	for _, name := range []string{"t_20260101000000", "t_20260102000000"} {
+		if _, err := os.Lstat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("file backup %s should be pruned", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "t_20260103000000")); err != nil {
+		t.Error("newest backup should be kept")
+	}
+}
+
+func TestSelectExpiredBackups_MissingDir(t *testing.T) {
+	got, err := selectExpiredBackups(filepath.Join(t.TempDir(), "nope"), 3)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("expected no backups and no error, got %v / %v", got, err)
+	}
+}
+
+func TestGCTempDirs_OnlyRemovesStaleAxonDirs(t *testing.T) {
+	base := t.TempDir()
+	stale := filepath.Join(base, "axon-update-123")
+	fresh := filepath.Join(base, "search-index-456")
+	other := filepath.Join(base, "unrelated")
+	for _, d := range []string{stale, fresh, other} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(stale, "axon.tar.gz"), []byte("12345"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+	oldOther := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(other, oldOther, oldOther); err != nil {
+		t.Fatal(err)
+	}
+
+	rep := gcTempDirs([]string{base}, "", time.Now().Add(-gcStaleTempAge), false)
+	if rep.Err != nil {
+		t.Fatalf("gcTempDirs: %v", rep.Err)
+	}
+	if rep.Items != 1 || rep.Bytes != 5 {
+		t.Errorf("report = %+v, want 1 item / 5 bytes", rep)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("stale axon-update dir should have been removed")
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Error("fresh search-index dir should be kept")
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Error("unrelated dir should be kept")
+	}
+}
+
+func TestGCBackups_DryRunKeepsFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"t_20260101000000", "t_20260102000000"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rep := gcBackups(dir, 1, true)
+	if rep.Items != 1 {
+		t.Fatalf("dry run should report 1 item, got %d", rep.Items)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "t_20260101000000")); err != nil {
+		t.Error("dry run must not delete backups")
+	}
+}
+
+func TestGCTempDirs_OwnedBaseRemovesAnyStaleEntry(t *testing.T) {
+	owned := t.TempDir()
+	staleDir := filepath.Join(owned, "stage-1")
+	staleFile := filepath.Join(owned, "partial.zip")
+	fresh := filepath.Join(owned, "axon-publish-1")
+	for _, d := range []string{staleDir, fresh} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(staleFile, []byte("123"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	for _, p := range []string{staleDir, staleFile} {
+		if err := os.Chtimes(p, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rep := gcTempDirs([]string{owned}, owned, time.Now().Add(-gcStaleTempAge), false)
+	if rep.Err != nil || rep.Items != 2 || rep.Bytes != 3 {
+		t.Errorf("report = %+v, want 2 items / 3 bytes", rep)
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Error("fresh temp dir should be kept")
+	}
+}
+
+func TestEmptyDirs(t *testing.T) {
+	root := t.TempDir()
+	for _, d := range []string{"gone/nested/deeper", "pdf/scripts", "pdf/empty", "kept"} {
+		if err := os.MkdirAll(filepath.Join(root, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{"pdf/scripts/fill.py", "kept/.keep"} {
+		if err := os.WriteFile(filepath.Join(root, f), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := emptyDirs(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(root, "gone"), filepath.Join(root, "pdf", "empty")}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("emptyDirs = %v, want %v", got, want)
+	}
+}
+
+func TestStaleConflictFiles(t *testing.T) {
+	hub := t.TempDir()
+	skill := filepath.Join(hub, "skills", "pdf")
+	if err := os.MkdirAll(skill, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	old := filepath.Join(skill, "SKILL.conflict-laptop.md")
+	recent := filepath.Join(skill, "notes.conflict-import.md")
+	for _, p := range []string{old, recent} {
+		if err := os.WriteFile(p, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().Add(-40 * 24 * time.Hour)
+	if err := os.Chtimes(old, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	cfg := &config.Config{RepoPath: hub}
+	got := staleConflictFiles(cfg, time.Now().Add(-30*24*time.Hour))
+	if len(got) != 1 || got[0] != old {
+		t.Fatalf("staleConflictFiles = %v, want [%s]", got, old)
+	}
+	if rep := gcFiles("conflicts", got, false); rep.Items != 1 || rep.Bytes != 1 {
+		t.Errorf("gcFiles = %+v", rep)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Error("old conflict file should be removed")
+	}
+
+	root, _ := trash.Dir()
+	entries, err := trash.List(root)
+	if err != nil || len(entries) != 1 || entries[0].Path != old {
+		t.Fatalf("trash = %+v, %v; want the conflict file", entries, err)
+	}
+	if rep := gcExpiredTrash(time.Hour, false); rep.Items != 0 {
+		t.Errorf("fresh entry expired: %+v", rep)
+	}
+	if rep := gcExpiredTrash(time.Nanosecond, false); rep.Items != 1 || rep.Bytes != 1 {
+		t.Errorf("gcExpiredTrash = %+v", rep)
+	}
+}
+
+func TestRunGC_ConflictPrompt(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("TMPDIR", filepath.Join(tmp, "tmp"))
+	hub := filepath.Join(tmp, "hub")
+	conflict := filepath.Join(hub, "skills", "pdf", "SKILL.conflict-laptop.md")
+	for _, d := range []string{filepath.Dir(conflict), filepath.Join(tmp, "home", ".axon"), filepath.Join(tmp, "tmp")} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(conflict, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-40 * 24 * time.Hour)
+	if err := os.Chtimes(conflict, past, past); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Save(&config.Config{RepoPath: hub, Targets: []config.Target{{Name: "s", Source: "skills"}}}); err != nil {
+		t.Fatal(err)
+	}
+	flagGCConflictAge = 30 * 24 * time.Hour
+	t.Cleanup(func() {
+		gcCmd.SetIn(nil)
+		flagGCConflictAge = 30 * 24 * time.Hour
+	})
+
+	gcCmd.SetIn(strings.NewReader("n\n"))
+	if err := runGC(gcCmd, nil); err != nil {
+		t.Fatalf("runGC (declined): %v", err)
+	}
+	if _, err := os.Stat(conflict); err != nil {
+		t.Fatalf("declined conflict file should be kept: %v", err)
+	}
+
+	gcCmd.SetIn(strings.NewReader("y\n"))
+	if err := runGC(gcCmd, nil); err != nil {
+		t.Fatalf("runGC (accepted): %v", err)
+	}
+	if _, err := os.Stat(conflict); !os.IsNotExist(err) {
+		t.Errorf("accepted conflict file should be moved to the trash, stat err = %v", err)
+	}
+}
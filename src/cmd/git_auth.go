@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// gitRemoteProblem is a recognised failure to reach or authenticate with a
+// git remote, and what to do about it.
+type gitRemoteProblem struct {
+	Summary     string
+	Remediation string
+	// Network is set for connectivity problems, as opposed to credentials.
+	Network bool
+}
+
+// diagnoseGitRemote matches git's output against common SSH, HTTPS, and
+// network failures. remoteURL, when known, names the host in the advice.
+func diagnoseGitRemote(output, remoteURL string) (gitRemoteProblem, bool) {
+	out := strings.ToLower(output)
+	host := remoteHost(remoteURL)
+	if host == "" {
+		host = "the git host"
+	}
+	has := func(subs ...string) bool {
+		for _, s := range subs {
+			if strings.Contains(out, s) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case has("remote host identification has changed"):
+		return gitRemoteProblem{
+			Summary:     fmt.Sprintf("the SSH host key of %s changed since you last connected", host),
+			Remediation: fmt.Sprintf("if %s really rotated its key, remove the old one with 'ssh-keygen -R %s' and connect again", host, host),
+		}, true
+	case has("host key verification failed", "authenticity of host", "no matching host key"):
+		return gitRemoteProblem{
+			Summary:     fmt.Sprintf("the SSH host key of %s is not trusted yet", host),
+			Remediation: fmt.Sprintf("run 'ssh -T git@%s' once and accept the key, then retry", host),
+		}, true
+	case has("permission denied (publickey"):
+		fix := fmt.Sprintf("run 'ssh-add -l' to check that the agent holds your key and that its public key is added to your account on %s ('ssh-keygen -t ed25519' creates one)", host)
+		if os.Getenv("SSH_AUTH_SOCK") == "" {
+			fix = "no ssh-agent is running: start one with 'eval \"$(ssh-agent)\"' and load your key with 'ssh-add'"
+		}
+		return gitRemoteProblem{
+			Summary:     fmt.Sprintf("%s rejected your SSH key", host),
+			Remediation: fix,
+		}, true
+	case has("password authentication is not supported", "support for password authentication was removed"):
+		return gitRemoteProblem{
+			Summary:     fmt.Sprintf("%s no longer accepts account passwords over HTTPS", host),
+			Remediation: "use a personal access token as the password, or switch origin to an SSH URL with 'axon remote set git@<host>:<owner>/<repo>.git'",
+		}, true
+	case has("could not read username", "could not read password", "terminal prompts disabled"):
+		return gitRemoteProblem{
+			Summary:     "git needs HTTPS credentials but cannot prompt for them",
+			Remediation: "set up a credential helper ('git config --global credential.helper' with your OS keychain) or switch origin to an SSH URL",
+		}, true
+	case has("authentication failed", "invalid username or password", "http basic: access denied", "invalid credentials"):
+		return gitRemoteProblem{
+			Summary:     fmt.Sprintf("%s rejected your HTTPS credentials (expired or revoked token?)", host),
+			Remediation: fmt.Sprintf("create a new personal access token, drop the stored one with \"printf 'protocol=https\\nhost=%s\\n' | git credential reject\", and retry", host),
+		}, true
+	case has("repository not found", "does not appear to be a git repository", "the requested url returned error: 404"):
+		return gitRemoteProblem{
+			Summary:     "the remote repository was not found, or your account cannot access it",
+			Remediation: "check the URL with 'git remote get-url origin' in the Hub and your access to it; 'axon remote set <url>' changes it",
+		}, true
+	case has("could not resolve hostname", "could not resolve host"):
+		return gitRemoteProblem{
+			Summary:     fmt.Sprintf("cannot resolve %s", host),
+			Remediation: "check your network connection, VPN, or proxy settings",
+			Network:     true,
+		}, true
+	case has("connection timed out", "operation timed out", "connection refused", "network is unreachable"):
+		return gitRemoteProblem{
+			Summary:     fmt.Sprintf("cannot connect to %s", host),
+			Remediation: "check your network or firewall; if port 22 is blocked, try SSH over port 443 or an HTTPS URL",
+			Network:     true,
+		}, true
+	}
+	return gitRemoteProblem{}, false
+}
+
+// gitRemoteHint returns the diagnosis of output as extra error lines, or ""
+// when the failure is not recognised.
+func gitRemoteHint(output, remoteURL string) string {
+	p, ok := diagnoseGitRemote(output, remoteURL)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("\n   %s\n   → %s", p.Summary, p.Remediation)
+}
+
+// remoteHost extracts the host from an SSH (git@host:path, ssh://…) or
+// HTTPS remote URL, or "" when there is none.
+func remoteHost(remoteURL string) string {
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return ""
+		}
+		return u.Hostname()
+	}
+	// scp-like syntax: [user@]host:path
+	hostPart, _, ok := strings.Cut(remoteURL, ":")
+	if !ok {
+		return ""
+	}
+	if _, h, found := strings.Cut(hostPart, "@"); found {
+		return h
+	}
+	return hostPart
+}
+
+// originURL returns the URL of repo's origin remote, or "".
+func originURL(repo string) string {
+	out, err := gitOutput(repo, "remote", "get-url", "origin")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// gitRunRemote is gitRun for commands that talk to remoteURL (clone, fetch,
+// pull, push). Output still streams, and a recognised auth or network
+// failure adds its remediation to the error.
+func gitRunRemote(remoteURL string, args ...string) error {
+	_, err := gitRunRemoteStderr(remoteURL, args...)
+	return err
+}
+
+// gitRunRemoteStderr is gitRunRemote that also returns what git wrote to
+// stderr, for callers that explain other failures themselves.
+func gitRunRemoteStderr(remoteURL string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	c := exec.Command("git", args...)
+	c.Stdout = os.Stdout
+	c.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	resume := pauseProgress()
+	err := runner.Run(c)
+	resume()
+	if err != nil {
+		if hint := gitRemoteHint(stderr.String(), remoteURL); hint != "" {
+			return stderr.String(), fmt.Errorf("%w%s", err, hint)
+		}
+	}
+	return stderr.String(), err
+}
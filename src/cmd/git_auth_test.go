@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiagnoseGitRemote(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "/tmp/agent.sock")
+	cases := []struct {
+		output, url, want string
+	}{
+		{"git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.", "git@github.com:me/hub.git", "rejected your SSH key"},
+		{"Host key verification failed.\nfatal: Could not read from remote repository.", "git@gitlab.com:me/hub.git", "not trusted yet"},
+		{"@@@ WARNING: REMOTE HOST IDENTIFICATION HAS CHANGED! @@@", "ssh://git@example.com:2222/hub.git", "changed since"},
+		{"remote: Support for password authentication was removed on August 13, 2021.", "https://github.com/me/hub.git", "no longer accepts account passwords"},
+		{"fatal: Authentication failed for 'https://github.com/me/hub.git/'", "https://github.com/me/hub.git", "expired or revoked token"},
+		{"fatal: could not read Username for 'https://github.com': terminal prompts disabled", "https://github.com/me/hub.git", "cannot prompt"},
+		{"ssh: Could not resolve hostname github.com: Name or service not known", "git@github.com:me/hub.git", "cannot resolve github.com"},
+		{"ERROR: Repository not found.", "git@github.com:me/gone.git", "not found"},
+	}
+	for _, c := range cases {
+		p, ok := diagnoseGitRemote(c.output, c.url)
+		if !ok || !strings.Contains(p.Summary, c.want) {
+			t.Errorf("diagnoseGitRemote(%q) = %+v, %v; want summary containing %q", c.output, p, ok, c.want)
+		}
+	}
+	if _, ok := diagnoseGitRemote("error: failed to push some refs", ""); ok {
+		t.Error("an unrelated failure should not be diagnosed")
+	}
+}
+
+func TestDiagnoseGitRemote_NoAgent(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	p, _ := diagnoseGitRemote("Permission denied (publickey).", "git@github.com:me/hub.git")
+	if !strings.Contains(p.Remediation, "ssh-agent") {
+		t.Errorf("remediation = %q, want advice to start ssh-agent", p.Remediation)
+	}
+}
+
+func TestRemoteHost(t *testing.T) {
+	for in, want := range map[string]string{
+		"git@github.com:me/hub.git":          "github.com",
+		"ssh://git@example.com:2222/hub.git": "example.com",
+		"https://gitlab.com/me/hub.git":      "gitlab.com",
+		"/srv/git/hub.git":                   "",
+	} {
+		if got := remoteHost(in); got != want {
+			t.Errorf("remoteHost(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCheckRemoteAccess(t *testing.T) {
+	cfg, tmp := initTestRepo(t)
+	if res := checkRemoteAccess(cfg); len(res) != 0 {
+		t.Fatalf("repo without origin: %+v, want no results", res)
+	}
+
+	origin := filepath.Join(tmp, "origin.git")
+	if err := gitRun("-C", tmp, "init", "-q", "--bare", origin); err != nil {
+		t.Fatal(err)
+	}
+	if err := gitRun("-C", cfg.RepoPath, "remote", "add", "origin", origin); err != nil {
+		t.Fatal(err)
+	}
+	if res := checkRemoteAccess(cfg); len(res) != 1 || !res[0].Passed {
+		t.Fatalf("reachable origin: %+v", res)
+	}
+
+	if err := gitRun("-C", cfg.RepoPath, "remote", "set-url", "origin", filepath.Join(tmp, "missing.git")); err != nil {
+		t.Fatal(err)
+	}
+	res := checkRemoteAccess(cfg)
+	if len(res) != 1 || res[0].Passed || res[0].Remediation == "" {
+		t.Fatalf("missing origin: %+v, want a failure with remediation", res)
+	}
+}
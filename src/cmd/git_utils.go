@@ -7,9 +7,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
 )
 
-// resolveSkillPath finds a skill/workflow/command by its shorthand name.
+// resolveSkillPath finds a skill/workflow/command/rule/memory by its shorthand name.
 // Examples: "humanizer" -> "skills/humanizer", "git-release" -> "workflows/git-release".
 // If multiple matches exist, it returns an error.
 func resolveSkillPath(repoPath, name string) (string, error) {
@@ -20,7 +22,7 @@ func resolveSkillPath(repoPath, name string) (string, error) {
 	}
 
 	// 2. Search in common directories.
-	prefixes := []string{"skills", "workflows", "commands"}
+	prefixes := config.DefaultContentRoots()
 	var matches []string
 	for _, p := range prefixes {
 		candidate := filepath.Join(p, name)
@@ -31,7 +33,7 @@ func resolveSkillPath(repoPath, name string) (string, error) {
 	}
 
 	if len(matches) == 0 {
-		return "", fmt.Errorf("cannot find skill, workflow, or command %q in Hub", name)
+		return "", fmt.Errorf("cannot find skill, workflow, command, rule, or memory %q in Hub", name)
 	}
 	if len(matches) > 1 {
 		return "", fmt.Errorf("ambiguous name %q matches multiple paths:\n  - %s\nPlease specify the full relative path.",
@@ -58,7 +60,8 @@ func gitRun(args ...string) error {
 	c := exec.Command("git", args...)
 	c.Stdout = os.Stdout
 	c.Stderr = os.Stderr
-	return c.Run()
+	defer pauseProgress()()
+	return runner.Run(c)
 }
 
 // gitOutput runs a git sub-command and returns its combined stdout output.
@@ -68,7 +71,7 @@ func gitOutput(repoPath string, args ...string) (string, error) {
 	var buf bytes.Buffer
 	cmd.Stdout = &buf
 	cmd.Stderr = &buf
-	err := cmd.Run()
+	err := runner.Run(cmd)
 	return buf.String(), err
 }
 
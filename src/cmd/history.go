@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the journal of link, unlink, sync, import, vendor, and update operations",
+	Long: `Print the operation journal kept in ~/.axon/history.log.
+
+Every mutating command (link, unlink, sync, init/import, vendor sync, update)
+appends one JSON line describing what it changed: the target, destination,
+backup paths, commit SHAs, or versions involved.
+
+--since accepts a duration (90m, 24h, 7d) or a date (2006-01-02 or RFC 3339).
+
+Examples:
+  axon history
+  axon history --since 7d
+  axon history --since 2026-10-01 --limit 0`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+var (
+	flagHistorySince string
+	flagHistoryLimit int
+)
+
+func init() {
+	historyCmd.Flags().StringVar(&flagHistorySince, "since", "", "Only show entries newer than a duration (24h, 7d) or date (2006-01-02)")
+	historyCmd.Flags().IntVarP(&flagHistoryLimit, "limit", "n", 50, "Show at most this many of the most recent entries (0 = all)")
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(_ *cobra.Command, _ []string) error {
+	if flagHistoryLimit < 0 {
+		return fmt.Errorf("--limit must be >= 0")
+	}
+	var since time.Time
+	if flagHistorySince != "" {
+		var err error
+		if since, err = parseSince(flagHistorySince, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	path, err := journal.Path()
+	if err != nil {
+		return err
+	}
+	entries, err := journal.Read(path, since)
+	if err != nil {
+		return fmt.Errorf("cannot read history: %w", err)
+	}
+
+	printSection("History")
+	if len(entries) == 0 {
+		printSkip("", "no recorded operations")
+		return nil
+	}
+	if flagHistoryLimit > 0 && len(entries) > flagHistoryLimit {
+		printInfo("", fmt.Sprintf("showing the last %d of %d entries (use --limit 0 for all)", flagHistoryLimit, len(entries)))
+		entries = entries[len(entries)-flagHistoryLimit:]
+	}
+	for _, e := range entries {
+		fmt.Println(formatHistoryEntry(e))
+	}
+	return nil
+}
+
+// formatHistoryEntry renders one journal entry as a single aligned line.
+func formatHistoryEntry(e journal.Entry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "  %s  %-15s", e.Time.Local().Format("2006-01-02 15:04:05"), e.Op)
+	if e.Target != "" {
+		fmt.Fprintf(&b, " [%s]", e.Target)
+	}
+	if e.Detail != "" {
+		b.WriteString(" " + e.Detail)
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// parseSince converts a --since value into an absolute time. It accepts Go
+// durations (90m, 24h), whole days (7d), dates (2006-01-02), and RFC 3339
+// timestamps.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "d") {
+		if n, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a duration (24h, 7d) or a date (2006-01-02)", s)
+}
+
+// recordHistory appends e to the operation journal. Journaling never fails
+// the operation itself; a write error is reported as a warning.
+func recordHistory(e journal.Entry) {
+	if err := journal.Record(e); err != nil {
+		printWarn("history", fmt.Sprintf("could not record operation: %v", err))
+	}
+}
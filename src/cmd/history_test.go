@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		in   string
+		want time.Time
+	}{
+		{"24h", now.Add(-24 * time.Hour)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"2026-10-15T08:00:00Z", time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		got, err := parseSince(c.in, now)
+		if err != nil {
+			t.Errorf("parseSince(%q): %v", c.in, err)
+			continue
+		}
+		if !got.Equal(c.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+
+	got, err := parseSince("2026-10-01", now)
+	if err != nil || got.Format("2006-01-02") != "2026-10-01" {
+		t.Errorf("parseSince(date) = %v, %v", got, err)
+	}
+
+	for _, bad := range []string{"", "yesterday", "-3h", "7x"} {
+		if _, err := parseSince(bad, now); err == nil {
+			t.Errorf("parseSince(%q): expected error", bad)
+		}
+	}
+}
+
+func TestFormatHistoryEntry(t *testing.T) {
+	e := journal.Entry{
+		Time:   time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local),
+		Op:     journal.OpLink,
+		Target: "claude-skills",
+		Detail: "/a → /b",
+	}
+	got := formatHistoryEntry(e)
+	for _, want := range []string{"2026-10-16 12:00:00", "link", "[claude-skills]", "/a → /b"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatHistoryEntry = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestRecordLinkJournalsBackup(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	target := cfg.Targets[0]
+
+	// A non-empty real directory is backed up before linking.
+	dest, _ := config.ExpandPath(target.Destination)
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dest, "local.md"), []byte("local"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, _, err := linkTargets(cfg, cfg.Targets, linkOptions{})
+	if err != nil || len(results) != 1 || results[0].state != "backed_up" {
+		t.Fatalf("linkTargets = %+v, %v; want one backed_up", results, err)
+	}
+
+	path, _ := journal.Path()
+	entries, err := journal.Read(path, time.Time{})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("journal entries = %v, %v; want one", entries, err)
+	}
+	e := entries[0]
+	if e.Op != journal.OpLink || e.Target != target.Name || e.Data["dest"] != dest {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if e.Data["backup"] == "" || !strings.HasPrefix(e.Data["backup"], filepath.Join(tmp, ".axon", "backups")) {
+		t.Errorf("backup path not recorded: %+v", e.Data)
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// maxHubWrites caps how many files written through read-only targets doctor
+// and status list.
+const maxHubWrites = 20
+
+// hubWrite is a new, untracked file in a Hub repo under the source of one
+// or more read-only targets: most likely written by the tool through its
+// symlinked destination.
+type hubWrite struct {
+	Repo    string   // repo name
+	Path    string   // slash-separated, relative to the repo
+	Targets []string // read-only targets whose source holds Path
+}
+
+// via names the targets a write may have come through.
+func (w hubWrite) via() string {
+	return strings.Join(w.Targets, " or ")
+}
+
+// findHubWrites lists the untracked files under the sources of cfg's
+// read-only targets, sorted by repo and path. Files matched by excludes
+// (written to .git/info/exclude by 'axon sync') are not listed.
+func findHubWrites(cfg *config.Config) ([]hubWrite, error) {
+	if checkGitAvailable() != nil {
+		return nil, nil
+	}
+	byKey := make(map[string]*hubWrite)
+	scanned := make(map[string][]string)
+	for _, t := range cfg.Targets {
+		if !t.ReadOnly || t.IsFile() || t.IsRules() {
+			continue
+		}
+		repos, err := cfg.TargetRepos(t)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range repos {
+			key := r.Path + "\x00" + t.Source
+			files, ok := scanned[key]
+			if !ok {
+				if files, err = untrackedFiles(r.Path, t.Source); err != nil {
+					return nil, fmt.Errorf("repo %s: %w", r.Name, err)
+				}
+				scanned[key] = files
+			}
+			for _, f := range files {
+				k := r.Name + "\x00" + f
+				w := byKey[k]
+				if w == nil {
+					w = &hubWrite{Repo: r.Name, Path: f}
+					byKey[k] = w
+				}
+				w.Targets = append(w.Targets, t.Name)
+			}
+		}
+	}
+	out := make([]hubWrite, 0, len(byKey))
+	for _, w := range byKey {
+		out = append(out, *w)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Repo != out[j].Repo {
+			return out[i].Repo < out[j].Repo
+		}
+		return out[i].Path < out[j].Path
+	})
+	return out, nil
+}
+
+// untrackedFiles returns the untracked, non-ignored files under source in
+// the repo at repoPath. A repo that is not cloned yet has none.
+func untrackedFiles(repoPath, source string) ([]string, error) {
+	spec := source
+	if spec == "" {
+		spec = "."
+	}
+	out, err := gitOutput(repoPath, "status", "--porcelain", "-z", "--untracked-files=all", "--", spec)
+	if err != nil {
+		if strings.Contains(out, "not a git repository") || strings.Contains(out, "cannot change to") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("git status: %s", strings.TrimSpace(out))
+	}
+	var files []string
+	for _, entry := range strings.Split(out, "\x00") {
+		if p, ok := strings.CutPrefix(entry, "?? "); ok {
+			files = append(files, p)
+		}
+	}
+	return files, nil
+}
+
+// checkHubWrites reports files written into the Hub through read-only
+// targets.
+func checkHubWrites(cfg *config.Config) []DiagnosticResult {
+	cat := "Read-only Targets"
+	var readOnly int
+	for _, t := range cfg.Targets {
+		if t.ReadOnly {
+			readOnly++
+		}
+	}
+	if readOnly == 0 {
+		return nil
+	}
+	writes, err := findHubWrites(cfg)
+	if err != nil {
+		return []DiagnosticResult{{Category: cat, Passed: false, Severity: DiagnosticSeverityWarn, Message: err.Error()}}
+	}
+	if len(writes) == 0 {
+		return []DiagnosticResult{{Category: cat, Passed: true, Message: fmt.Sprintf("no files written into the Hub through %d read-only target(s)", readOnly)}}
+	}
+	var res []DiagnosticResult
+	for i, w := range writes {
+		if i == maxHubWrites {
+			res = append(res, DiagnosticResult{Category: cat, Passed: false, Severity: DiagnosticSeverityWarn, Message: fmt.Sprintf("… and %d more", len(writes)-i)})
+			break
+		}
+		res = append(res, DiagnosticResult{
+			Category:    cat,
+			Item:        w.via(),
+			Passed:      false,
+			Severity:    DiagnosticSeverityWarn,
+			Message:     fmt.Sprintf("new file %s in repo %s", w.Path, w.Repo),
+			Remediation: "remove it, or keep it and run 'axon sync' to commit it",
+		})
+	}
+	return res
+}
+
+// printHubWrites lists files written through read-only targets for
+// 'axon status'.
+func printHubWrites(cfg *config.Config) {
+	writes, err := findHubWrites(cfg)
+	if err != nil || len(writes) == 0 {
+		return
+	}
+	printBullet("Written into the Hub through read-only targets:")
+	for i, w := range writes {
+		if i == maxHubWrites {
+			printInfo("", fmt.Sprintf("… and %d more (run 'axon doctor')", len(writes)-i))
+			break
+		}
+		printWarn(w.via(), fmt.Sprintf("%s (repo %s)", w.Path, w.Repo))
+	}
+}
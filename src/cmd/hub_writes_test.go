@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestFindHubWrites(t *testing.T) {
+	hub := t.TempDir()
+	for _, dir := range []string{"skills/pdf", "workflows"} {
+		if err := os.MkdirAll(filepath.Join(hub, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(hub, "skills", "pdf", "SKILL.md"), []byte("# pdf\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"-C", hub, "init", "-q"},
+		{"-C", hub, "add", "."},
+		{"-C", hub, "-c", "user.email=test@axon.local", "-c", "user.name=Axon Test", "commit", "-q", "-m", "initial"},
+	} {
+		if err := gitRun(args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	for _, f := range []string{"skills/pdf/notes.md", "skills/new/SKILL.md", "workflows/plan.md"} {
+		p := filepath.Join(hub, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{
+		RepoPath: hub,
+		Targets: []config.Target{
+			{Name: "claude-skills", Source: "skills", ReadOnly: true},
+			{Name: "cursor-skills", Source: "skills", ReadOnly: true},
+			{Name: "windsurf-skills", Source: "skills"},
+			{Name: "claude-workflows", Source: "workflows", ReadOnly: true},
+		},
+	}
+	got, err := findHubWrites(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []hubWrite{
+		{Repo: config.PrimaryRepoName, Path: "skills/new/SKILL.md", Targets: []string{"claude-skills", "cursor-skills"}},
+		{Repo: config.PrimaryRepoName, Path: "skills/pdf/notes.md", Targets: []string{"claude-skills", "cursor-skills"}},
+		{Repo: config.PrimaryRepoName, Path: "workflows/plan.md", Targets: []string{"claude-workflows"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findHubWrites =\n%+v\nwant\n%+v", got, want)
+	}
+	if via := got[0].via(); via != "claude-skills or cursor-skills" {
+		t.Errorf("via = %q", via)
+	}
+
+	cfg.Targets[3].ReadOnly = false
+	res := checkHubWrites(cfg)
+	if len(res) != 2 || res[0].Passed {
+		t.Errorf("checkHubWrites = %+v", res)
+	}
+}
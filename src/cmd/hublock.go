@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// hubLockAnnotation marks commands that take the Hub lock before running.
+const hubLockAnnotation = "axon/hub-lock"
+
+// defaultHubLockWait is how long a bare --wait waits for the Hub lock.
+const defaultHubLockWait = 10 * time.Minute
+
+// flagHubLockWait is the --wait value of whichever mutating command runs.
+var flagHubLockWait time.Duration
+
+// releaseHubLock releases the lock taken for the running command. Execute
+// calls it once the command returns.
+var releaseHubLock = func() {}
+
+// errHubBusy is returned when another axon process holds the Hub lock.
+type errHubBusy struct {
+	owner string
+}
+
+func (e *errHubBusy) Error() string {
+	msg := "another axon operation is in progress on the Hub"
+	if e.owner != "" {
+		msg += " (" + e.owner + ")"
+	}
+	return msg + "\nWait for it to finish, or retry with --wait."
+}
+
+func init() {
+	markHubMutating(
+		linkCmd, unlinkCmd, importCmd, rollbackCmd, undoCmd, remoteSetCmd,
+		gcCmd, vendorSyncCmd, updateSkillCmd, metaSetCmd, signCmd, syncCmd,
+		bootstrapCmd, purgeCmd, relinkCmd, moveHubCmd, importBundleCmd,
+		installCmd, trashRestoreCmd, trashEmptyCmd, dedupeCmd, renameSkillCmd,
+		initCmd, vendorCachePruneCmd,
+	)
+	// The sync daemon locks around each run instead of for its lifetime.
+	markHubMutatingWhen(syncCmd, func() bool { return !flagSyncDaemon && !flagSyncStatus })
+	markHubMutatingWhen(searchCmd, func() bool { return flagSearchIndex && flagSearchPublish })
+	markHubMutatingWhen(doctorCmd, func() bool { return doctorFix || len(doctorFixOnly) > 0 })
+}
+
+// hubLockWhen holds the commands that mutate the Hub only with some flags,
+// mapped to whether the current invocation does.
+var hubLockWhen = map[*cobra.Command]func() bool{}
+
+// markHubMutating makes cmds take the Hub lock and gives them --wait. Two
+// such commands never run against the same Hub at once, so a cron 'axon
+// sync' cannot interleave its git operations with a manual 'axon link'.
+func markHubMutating(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		if c.Annotations == nil {
+			c.Annotations = map[string]string{}
+		}
+		c.Annotations[hubLockAnnotation] = "true"
+		c.Flags().DurationVar(&flagHubLockWait, "wait", 0, "Wait up to this long for another axon operation on the Hub to finish")
+		c.Flags().Lookup("wait").NoOptDefVal = defaultHubLockWait.String()
+	}
+}
+
+// markHubMutatingWhen is markHubMutating for a command that mutates the Hub
+// only when mutates reports true, e.g. with a --fix or --publish flag set.
+func markHubMutatingWhen(c *cobra.Command, mutates func() bool) {
+	if c.Annotations[hubLockAnnotation] == "" {
+		markHubMutating(c)
+	}
+	hubLockWhen[c] = mutates
+}
+
+// lockHubForCommand takes the Hub lock when cmd mutates the Hub. Without a
+// loadable config there is nothing to lock; the command reports that itself.
+func lockHubForCommand(cmd *cobra.Command) error {
+	if cmd.Annotations[hubLockAnnotation] == "" {
+		return nil
+	}
+	if mutates := hubLockWhen[cmd]; mutates != nil && !mutates() {
+		return nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	unlock, err := acquireHubLock(cfg.RepoPath, cmd.CommandPath(), flagHubLockWait)
+	if err != nil {
+		return err
+	}
+	releaseHubLock = unlock
+	return nil
+}
+
+// acquireHubLock takes the exclusive lock of the Hub at repoPath, retrying
+// for up to wait. The returned function releases it.
+func acquireHubLock(repoPath, op string, wait time.Duration) (func(), error) {
+	lockPath, err := hubLockPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	l := flock.New(lockPath)
+	deadline := time.Now().Add(wait)
+	announced := false
+	for {
+		locked, err := l.TryLock()
+		if err != nil {
+			return nil, fmt.Errorf("cannot acquire Hub lock: %w", err)
+		}
+		if locked {
+			break
+		}
+		if !time.Now().Before(deadline) {
+			return nil, &errHubBusy{owner: readHubLockOwner(lockPath)}
+		}
+		if !announced {
+			owner := readHubLockOwner(lockPath)
+			if owner == "" {
+				owner = "another axon operation"
+			}
+			printInfo("", fmt.Sprintf("waiting for %s to finish…", owner))
+			announced = true
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	info := lockPath + ".owner"
+	_ = os.WriteFile(info, []byte(fmt.Sprintf("%d %s\n", os.Getpid(), op)), 0o644)
+	return func() {
+		_ = os.Remove(info)
+		_ = l.Unlock()
+	}, nil
+}
+
+// hubLockPath returns ~/.axon/locks/hub-<hash>.lock for the Hub at repoPath.
+// The lock lives outside the Hub so it never shows up in git status.
+func hubLockPath(repoPath string) (string, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(repoPath)
+	if err != nil {
+		abs = repoPath
+	}
+	sum := sha256.Sum256([]byte(filepath.Clean(abs)))
+	dir := filepath.Join(axonDir, "locks")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "hub-"+hex.EncodeToString(sum[:6])+".lock"), nil
+}
+
+// readHubLockOwner describes the process holding the lock at lockPath, e.g.
+// "axon sync, pid 4242", or "" when unknown.
+func readHubLockOwner(lockPath string) string {
+	b, err := os.ReadFile(lockPath + ".owner")
+	if err != nil {
+		return ""
+	}
+	pid, op, ok := strings.Cut(strings.TrimSpace(string(b)), " ")
+	if !ok {
+		return ""
+	}
+	if _, err := strconv.Atoi(pid); err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s, pid %s", op, pid)
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestAcquireHubLock_RejectsSecondHolder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+
+	unlock, err := acquireHubLock(repo, "axon sync", 0)
+	if err != nil {
+		t.Fatalf("first lock: %v", err)
+	}
+	_, err = acquireHubLock(repo, "axon link", 0)
+	var busy *errHubBusy
+	if !errors.As(err, &busy) {
+		t.Fatalf("second lock: err = %v, want errHubBusy", err)
+	}
+	if !strings.Contains(err.Error(), "axon sync, pid") || !strings.Contains(err.Error(), "--wait") {
+		t.Errorf("busy error = %q, want owner and --wait hint", err)
+	}
+
+	// Another Hub is not affected.
+	other, err := acquireHubLock(t.TempDir(), "axon link", 0)
+	if err != nil {
+		t.Fatalf("lock on another Hub: %v", err)
+	}
+	other()
+
+	unlock()
+	again, err := acquireHubLock(repo, "axon link", 0)
+	if err != nil {
+		t.Fatalf("lock after release: %v", err)
+	}
+	again()
+}
+
+func TestAcquireHubLock_WaitsForRelease(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+
+	unlock, err := acquireHubLock(repo, "axon sync", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		unlock()
+	}()
+	second, err := acquireHubLock(repo, "axon link", 5*time.Second)
+	if err != nil {
+		t.Fatalf("waiting lock: %v", err)
+	}
+	second()
+}
+
+func TestLockHubForCommand_OnlyMutatingCommands(t *testing.T) {
+	if listCmd.Annotations[hubLockAnnotation] != "" {
+		t.Error("list should not take the Hub lock")
+	}
+	for _, c := range []string{"link", "sync", "import", "init", "search", "doctor", "trash empty", "vendor cache prune"} {
+		cmd, _, err := rootCmd.Find(strings.Fields(c))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd.Annotations[hubLockAnnotation] == "" || cmd.Flags().Lookup("wait") == nil {
+			t.Errorf("%s should take the Hub lock and accept --wait", c)
+		}
+	}
+}
+
+func TestLockHubForCommand_FlagGatedCommands(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(config.PortableEnv, "")
+	t.Setenv(config.HomeEnv, home)
+	t.Chdir(t.TempDir())
+	repo := filepath.Join(home, "repo")
+	if err := os.WriteFile(filepath.Join(home, "axon.yaml"), []byte("repo_path: "+repo+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		flagSearchIndex, flagSearchPublish, doctorFix, doctorFixOnly = false, false, false, nil
+	})
+
+	cases := []struct {
+		name string
+		cmd  *cobra.Command
+		set  func(on bool)
+	}{
+		{"search --index --publish", searchCmd, func(on bool) { flagSearchIndex, flagSearchPublish = true, on }},
+		{"doctor --fix", doctorCmd, func(on bool) { doctorFix = on }},
+		{"doctor --fix-only", doctorCmd, func(on bool) {
+			doctorFix, doctorFixOnly = false, nil
+			if on {
+				doctorFixOnly = []string{"Hub"}
+			}
+		}},
+	}
+	for _, c := range cases {
+		for _, on := range []bool{false, true} {
+			c.set(on)
+			if err := lockHubForCommand(c.cmd); err != nil {
+				t.Fatalf("%s: %v", c.name, err)
+			}
+			probe, err := acquireHubLock(repo, "axon link", 0)
+			var busy *errHubBusy
+			if locked := errors.As(err, &busy); locked != on {
+				t.Errorf("%s (flag set: %v): Hub locked = %v", c.name, on, locked)
+			}
+			if err == nil {
+				probe()
+			}
+			releaseHubLock()
+			releaseHubLock = func() {}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/bundle"
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/importer"
+	"github.com/kamusis/axon-cli/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <bundle.tar.gz>",
+	Short: "Merge a bundle created by 'axon export' into the Hub",
+	Long: `Extract a bundle produced by 'axon export', verify every file against the
+manifest's SHA-256 digests, and merge it into the Hub.
+
+Merging uses the same rules as 'axon init' imports: identical files are
+skipped, new files are copied, and differing files are kept side by side as
+<name>.conflict-import<ext> for manual review. Nothing in the Hub is overwritten.
+
+Examples:
+  axon import hub.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return err
+	}
+	tmpBase := filepath.Join(axonDir, "tmp")
+	if err := ensureFreeSpace(cfg, tmpBase, "import"); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpBase, 0o755); err != nil {
+		return fmt.Errorf("cannot create temp dir: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(tmpBase, "axon-import-*")
+	if err != nil {
+		return fmt.Errorf("cannot create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	m, err := bundle.Extract(args[0], tmpDir)
+	if err != nil {
+		return fmt.Errorf("cannot read bundle: %w", err)
+	}
+
+	printSection("Import Bundle")
+	fmt.Printf("  Created:  %s", m.CreatedAt)
+	if m.AxonVersion != "" {
+		fmt.Printf(" (axon %s)", m.AxonVersion)
+	}
+	fmt.Println()
+	if m.Source.Host != "" || m.Source.Revision != "" {
+		rev := m.Source.Revision
+		if len(rev) > 12 {
+			rev = rev[:12]
+		}
+		fmt.Printf("  Source:   %s\n", strings.TrimSpace(m.Source.Host+" "+rev))
+	}
+	printOK("verify", fmt.Sprintf("%d file(s) match the manifest", len(m.Files)))
+
+	// Merge each top-level directory separately so skill-level counts refer to
+	// skills/<name>, workflows/<name>, ... rather than the source roots.
+	contentRoot := filepath.Join(tmpDir, bundle.ContentDir)
+	roots, err := os.ReadDir(contentRoot)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].Name() < roots[j].Name() })
+	if hazards, err := hubHazards(contentRoot, longPathPrefix(cfg)); err == nil {
+		printPortabilityHazards(hazards)
+	}
+
+	// Only the Hub's content roots are merged; anything else in the bundle,
+	// such as a .git directory, must not reach the Hub.
+	contentRoots := make(map[string]bool)
+	for _, root := range cfg.EffectiveSearchRoots() {
+		top, _, _ := strings.Cut(filepath.ToSlash(filepath.Clean(root)), "/")
+		contentRoots[top] = true
+	}
+
+	var conflicts []importer.ConflictPair
+	progress := startProgress("Importing", int64(len(roots)))
+	defer progress.Done()
+	for i, r := range roots {
+		progress.Set(int64(i), int64(len(roots)))
+		if !r.IsDir() {
+			continue
+		}
+		if !contentRoots[r.Name()] {
+			printSkip(r.Name(), "not a content root in axon.yaml; not imported")
+			continue
+		}
+		progress.Item(r.Name())
+		res, err := importer.ImportDir(filepath.Join(contentRoot, r.Name()), filepath.Join(cfg.RepoPath, r.Name()), "import", cfg.Excludes)
+		if err != nil {
+			return fmt.Errorf("import [%s]: %w", r.Name(), err)
+		}
+		recordImport(r.Name(), args[0], res)
+		label := strings.TrimSuffix(r.Name(), "s")
+		printOK(r.Name(), fmt.Sprintf(
+			"%d %s(s) imported, %d skipped, %d conflict(s)  (%d file(s))",
+			res.SkillsImported, label, res.SkillsSkipped, res.SkillsConflicts, res.Imported+res.Skipped,
+		))
+		conflicts = append(conflicts, res.Conflicts...)
+	}
+
+	if len(conflicts) > 0 {
+		printWarn("", fmt.Sprintf("%d conflict(s) detected during import.", len(conflicts)))
+		fmt.Printf("   All versions have been preserved in %s.\n", cfg.RepoPath)
+		fmt.Println("   Please review and resolve the following files manually:")
+		for _, c := range conflicts {
+			fmt.Printf("     - %s  ← conflicts with %s\n", c.Conflict, c.Original)
+		}
+	}
+	fmt.Println("\n  Run 'axon sync' to commit the imported content.")
+	return nil
+}
+
+// recordImport journals the Hub paths an import created so 'axon undo' can
+// remove them again, along with the digest of every created file so undo can
+// leave alone the ones edited since. Imports that only skipped duplicates are
+// not recorded.
+func recordImport(name, from string, res *importer.Result) {
+	if res == nil || len(res.Created) == 0 {
+		return
+	}
+	digests := make(map[string]string)
+	for _, p := range res.Created {
+		if d := pathDigest(p); d != "" {
+			digests[p] = d
+		}
+	}
+	recordHistory(journal.Entry{
+		Op:      journal.OpImport,
+		Target:  name,
+		Detail:  fmt.Sprintf("%d file(s) from %s, %d conflict(s)", res.Imported, from, len(res.Conflicts)),
+		Data:    map[string]string{"from": from},
+		Paths:   res.Created,
+		Digests: digests,
+	})
+}
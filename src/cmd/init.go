@@ -9,6 +9,7 @@ import (
 
 	"github.com/kamusis/axon-cli/internal/config"
 	"github.com/kamusis/axon-cli/internal/importer"
+	"github.com/kamusis/axon-cli/internal/portable"
 	"github.com/spf13/cobra"
 )
 
@@ -122,7 +123,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("no upstream URL configured in axon.yaml")
 		}
 		fmt.Printf("  Cloning upstream %s → %s\n", upstream, repoPath)
-		if err := gitRun("clone", upstream, repoPath); err != nil {
+		if err := gitRunRemote(upstream, "clone", upstream, repoPath); err != nil {
 			return fmt.Errorf("git clone failed: %w", err)
 		}
 		printOK("", "Upstream cloned (read-only mode).")
@@ -174,7 +175,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 }
 
 // importExistingSkills scans each target destination and copies real directories
-// into the Hub, applying exclude filtering and MD5 conflict resolution.
+// into the Hub, applying exclude filtering and SHA-256 conflict resolution.
 func importExistingSkills(cfg *config.Config) error {
 	// Sort targets alphabetically — mirrors status output ordering.
 	targets := make([]config.Target, len(cfg.Targets))
@@ -194,11 +195,19 @@ func importExistingSkills(cfg *config.Config) error {
 		alreadyLinked  []string
 		notFound       []string
 		totalConflicts []importer.ConflictPair
+		hazards        []portable.Hazard
 	)
 	notInstalledMap := make(map[string]bool)
 	var notInstalled []string
 
-	for _, t := range targets {
+	prefix := longPathPrefix(cfg)
+	progress := startProgress("Importing", int64(len(targets)))
+	defer progress.Done()
+	for i, t := range targets {
+		progress.Set(int64(i), int64(len(targets)))
+		if t.IsFile() {
+			continue // only directories are imported
+		}
 		dest, err := config.ExpandPath(t.Destination)
 		if err != nil {
 			return err
@@ -239,13 +248,17 @@ func importExistingSkills(cfg *config.Config) error {
 		// Hub target directory.
 		hubDest := filepath.Join(cfg.RepoPath, t.Source)
 
+		progress.Item(t.Name)
+		hazards = append(hazards, importHazards(dest, t.Source, prefix)...)
 		result, err := importer.ImportDir(dest, hubDest, t.Name, cfg.Excludes)
 		if err != nil {
 			return fmt.Errorf("import [%s]: %w", t.Name, err)
 		}
+		recordImport(t.Name, dest, result)
 		imported = append(imported, importedEntry{name: t.Name, source: t.Source, result: result})
 		totalConflicts = append(totalConflicts, result.Conflicts...)
 	}
+	progress.Done()
 
 	// ── Print grouped output ───────────────────────────────────────────────────
 	printSection("Import Existing Skills")
@@ -301,6 +314,7 @@ func importExistingSkills(cfg *config.Config) error {
 			fmt.Printf("     - %s  ← conflicts with %s\n", c.Conflict, c.Original)
 		}
 	}
+	printPortabilityHazards(hazards)
 
 	return nil
 }
@@ -361,7 +375,7 @@ func setupHubWithRemote(repoPath, remote string) (clonedFromRemote bool, err err
 	// Best-effort: fetch origin and set origin/HEAD to the remote's default branch.
 	// This helps commands like `axon status --fetch` rely on origin/HEAD without guesswork.
 	if out, err := gitOutput(repoPath, "fetch", "--prune", "origin"); err != nil {
-		printWarn("", fmt.Sprintf("git fetch origin failed; remote default branch may be unknown:\n%s", strings.TrimSpace(out)))
+		printWarn("", fmt.Sprintf("git fetch origin failed; remote default branch may be unknown:\n%s%s", strings.TrimSpace(out), gitRemoteHint(out, remote)))
 	}
 	if err := gitRun("-C", repoPath, "remote", "set-head", "origin", "-a"); err != nil {
 		printWarn("", "could not set origin/HEAD automatically; remote default branch may be unknown")
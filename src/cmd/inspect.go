@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -23,30 +24,46 @@ const (
 	inspectIconWorkflow = "≡" // Workflow          - Standard .md file in the workflows/ directory
 	inspectIconCommand  = "$" // Command           - Standard .md file in commands/
 	inspectIconRule     = "‡" // Rule              - Standard .md file in the rules/ directory
+	inspectIconMemory   = "※" // Memory            - Standard .md file in the memories/ directory
 	inspectIconFolder   = "◇" // User-defined category folder (directory)
 	inspectIconFile     = "⬦" // User-defined category standalone .md file
 )
 
 var inspectCmd = &cobra.Command{
 	Use:   "inspect <name>",
-	Short: "Show metadata and structure of a skill, workflow or target",
+	Short: "Show metadata and structure of a skill, workflow, rule, or target",
 	Long: `Display a formatted summary of an item in the Hub, including its
 description, triggers, scripts, and declared dependencies.
 
 The argument can be either:
   - A skill folder name inside the Hub (e.g. humanizer)
-  - A workflow or rule file name (e.g. codebase-review.md)
+  - A workflow, command, rule, or memory file name (e.g. codebase-review.md)
+  - A document ID as shown by 'axon search' (e.g. rules:pnpm)
   - A target name from axon.yaml (e.g. windsurf-skills)
 
 Example:
   axon inspect humanizer
   axon inspect codebase-review.md
-  axon inspect windsurf-skills`,
+  axon inspect rules:pnpm
+  axon inspect windsurf-skills
+  axon inspect humanizer --tree --deps
+
+--tree shows every file of the item with its size and flags files larger
+than the context limit of a tool the item is linked for (context_limits in
+axon.yaml, 100KB by default). --deps resolves the requires.skills of a
+skill recursively.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runInspect,
 }
 
+var (
+	flagInspectTree bool
+	flagInspectDeps bool
+)
+
 func init() {
+	inspectCmd.Flags().BoolVar(&flagInspectTree, "tree", false, "Show the full file tree with sizes and flag files over tool context limits")
+	inspectCmd.Flags().BoolVar(&flagInspectDeps, "deps", false, "Show the skill dependency tree")
 	rootCmd.AddCommand(inspectCmd)
 }
 
@@ -59,21 +76,32 @@ type skillMeta struct {
 	License      string   `yaml:"license"`
 	AllowedTools []string `yaml:"allowed-tools"`
 	AutoInvoke   bool     `yaml:"auto_invoke"`
+	Signed       bool     `yaml:"signed"`
+
+	// Tools limits the targets the skill is linked into to these tools
+	// (e.g. [claude, windsurf]); empty means every tool.
+	Tools []string `yaml:"tools"`
+
+	// Healthcheck is a script in the skill (run like 'axon run') or an
+	// http(s) URL that 'axon doctor --skills' probes.
+	Healthcheck string `yaml:"healthcheck"`
 
 	// Triggers: list of {pattern, description} maps OR bare strings.
 	// We unmarshal as []yaml.Node for maximum flexibility.
 	Triggers yaml.Node `yaml:"triggers"`
 
-	// Requires: {bins: [...], envs: [...], npm: [...], python: [...]} dependency block.
+	// Requires: {bins: [...], envs: [...], npm: [...], python: [...], skills: [...]} dependency block.
 	Requires struct {
 		Bins   []string `yaml:"bins"`
 		Envs   []string `yaml:"envs"`
 		NPM    []string `yaml:"npm"`
 		Python []string `yaml:"python"`
+		Skills []string `yaml:"skills"`
 	} `yaml:"requires"`
 
 	// OpenClaw Metadata standard nested fields
 	Metadata struct {
+		Tools    []string `yaml:"tools"`
 		Requires struct {
 			Bins   []string `yaml:"bins"`
 			NPM    []string `yaml:"npm"`
@@ -158,6 +186,36 @@ func (m *skillMeta) GetRequiresPython() []string {
 	return unique
 }
 
+// GetTools merges the tools the skill is limited to from the top level and
+// from metadata.tools, lower-cased.
+func (m *skillMeta) GetTools() []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, t := range append(append([]string{}, m.Tools...), m.Metadata.Tools...) {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if !seen[t] && t != "" {
+			seen[t] = true
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+// GetRequiresSkills returns the names of other Hub skills declared under
+// requires.skills.
+func (m *skillMeta) GetRequiresSkills() []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, s := range m.Requires.Skills {
+		s = strings.TrimSpace(s)
+		if !seen[s] && s != "" {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+	return unique
+}
+
 func runInspect(_ *cobra.Command, args []string) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -175,13 +233,17 @@ func runInspect(_ *cobra.Command, args []string) error {
 		if i > 0 {
 			fmt.Println(strings.Repeat("─", 50))
 		}
-		printInspect(p)
+		printInspect(cfg, p)
 	}
 	return nil
 }
 
 func resolveInspectPaths(cfg *config.Config, arg string) ([]string, error) {
-	sourceRoots := uniqueSourceRoots(cfg)
+	if p, ok := documentIDPath(cfg.RepoPath, arg); ok {
+		return []string{p}, nil
+	}
+
+	sourceRoots := inspectRoots(cfg)
 	isMD := strings.HasSuffix(strings.ToLower(arg), ".md")
 
 	// 1. Exact match.
@@ -251,6 +313,41 @@ func resolveInspectPaths(cfg *config.Config, arg string) ([]string, error) {
 	return nil, fmt.Errorf("item %q not found in Hub.\nTip: run 'axon list' to see available items.", arg)
 }
 
+// documentIDPath resolves a per-file document ID such as "rules:pnpm" or
+// "workflows:release:deploy" to its Markdown file in the Hub.
+func documentIDPath(repoPath, id string) (string, bool) {
+	if !strings.Contains(id, ":") {
+		return "", false
+	}
+	rel := filepath.FromSlash(strings.ReplaceAll(id, ":", "/") + ".md")
+	if !filepath.IsLocal(rel) {
+		return "", false
+	}
+	p := filepath.Join(repoPath, rel)
+	if info, err := os.Stat(p); err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	return p, true
+}
+
+// inspectRoots returns uniqueSourceRoots followed by the Hub's standard
+// content roots no target draws on, so rules and memories can be inspected
+// before any target links them.
+func inspectRoots(cfg *config.Config) []string {
+	roots := uniqueSourceRoots(cfg)
+	seen := make(map[string]bool, len(roots))
+	for _, r := range roots {
+		seen[r] = true
+	}
+	for _, name := range config.DefaultContentRoots() {
+		if r := filepath.Join(cfg.RepoPath, name); !seen[r] {
+			seen[r] = true
+			roots = append(roots, r)
+		}
+	}
+	return roots
+}
+
 // uniqueSourceRoots returns the unique parent directories of all target sources.
 func uniqueSourceRoots(cfg *config.Config) []string {
 	seen := map[string]bool{}
@@ -272,7 +369,7 @@ func uniqueSourceRoots(cfg *config.Config) []string {
 }
 
 // printInspect displays the formatted inspection output for one path.
-func printInspect(itemPath string) {
+func printInspect(cfg *config.Config, itemPath string) {
 	info, err := os.Stat(itemPath)
 	if err != nil {
 		printErr("", fmt.Sprintf("Error accessing path: %v", err))
@@ -323,6 +420,9 @@ func printInspect(itemPath string) {
 		case "rules":
 			icon = inspectIconRule
 			label = "Rule"
+		case "memories":
+			icon = inspectIconMemory
+			label = "Memory"
 		}
 	}
 	fmt.Printf("%s %s: %s\n", icon, label, name)
@@ -351,9 +451,14 @@ func printInspect(itemPath string) {
 	if len(meta.AllowedTools) > 0 {
 		fmt.Printf("\nAllowed Tools: %s\n", strings.Join(meta.AllowedTools, ", "))
 	}
+	if tools := meta.GetTools(); len(tools) > 0 {
+		fmt.Printf("\nLinked For:    %s\n", strings.Join(tools, ", "))
+	}
 
-	// For directories, show files and scripts.
-	if isDir {
+	// For directories, show files and scripts; --tree shows every file.
+	if flagInspectTree {
+		printInspectTree(cfg, itemPath, isDir)
+	} else if isDir {
 		files := listSkillFiles(itemPath)
 		scripts := listExecutables(filepath.Join(itemPath, "scripts"))
 
@@ -371,7 +476,7 @@ func printInspect(itemPath string) {
 		}
 	}
 
-	if len(meta.Requires.Bins) > 0 || len(meta.Requires.Envs) > 0 {
+	if len(meta.Requires.Bins) > 0 || len(meta.Requires.Envs) > 0 || len(meta.Requires.Skills) > 0 {
 		fmt.Println("\nDependencies (declared):")
 		for _, b := range meta.Requires.Bins {
 			status := "Found"
@@ -387,6 +492,16 @@ func printInspect(itemPath string) {
 			}
 			fmt.Printf("  env: %-20s %s\n", e, status)
 		}
+		for _, s := range meta.GetRequiresSkills() {
+			status := "Found"
+			if _, err := os.Stat(filepath.Join(filepath.Dir(itemPath), s, "SKILL.md")); err != nil {
+				status = "Not found"
+			}
+			fmt.Printf("  skill: %-18s %s\n", s, status)
+		}
+	}
+	if flagInspectDeps && isDir {
+		printInspectDeps(cfg, itemPath)
 	}
 	fmt.Printf("\nPath: %s\n", itemPath)
 }
@@ -400,9 +515,14 @@ func parseSkillMeta(skillMDPath string) (skillMeta, bool) {
 		return skillMeta{}, false
 	}
 	defer f.Close()
+	return parseSkillMetaFrom(f)
+}
 
+// parseSkillMetaFrom parses SKILL.md frontmatter from r, e.g. a file read
+// from git rather than the working tree.
+func parseSkillMetaFrom(r io.Reader) (skillMeta, bool) {
 	// Frontmatter is delimited by --- lines.
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	var inFrontmatter bool
 	var yamlLines []string
 
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/kamusis/axon-cli/internal/config"
@@ -183,6 +185,37 @@ func TestResolveInspectPaths(t *testing.T) {
 		}
 	})
 
+	os.MkdirAll(filepath.Join(repo, "rules/db"), 0o755)
+	os.WriteFile(filepath.Join(repo, "rules/db/postgres.md"), []byte(""), 0o644)
+	os.MkdirAll(filepath.Join(repo, "memories"), 0o755)
+	os.WriteFile(filepath.Join(repo, "memories/notes.md"), []byte(""), 0o644)
+
+	t.Run("document id", func(t *testing.T) {
+		paths, err := resolveInspectPaths(cfg, "rules:db:postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(repo, "rules", "db", "postgres.md"); len(paths) != 1 || paths[0] != want {
+			t.Errorf("unexpected paths: %v, want %s", paths, want)
+		}
+	})
+
+	t.Run("memory without a target", func(t *testing.T) {
+		paths, err := resolveInspectPaths(cfg, "notes.md")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(repo, "memories", "notes.md"); len(paths) != 1 || paths[0] != want {
+			t.Errorf("unexpected paths: %v, want %s", paths, want)
+		}
+	})
+
+	t.Run("document id outside the Hub", func(t *testing.T) {
+		if _, err := resolveInspectPaths(cfg, "..:secret"); err == nil {
+			t.Error("expected error for an ID escaping the Hub")
+		}
+	})
+
 	t.Run("not found", func(t *testing.T) {
 		_, err := resolveInspectPaths(cfg, "nonexistent")
 		if err == nil {
@@ -190,3 +223,50 @@ func TestResolveInspectPaths(t *testing.T) {
 		}
 	})
 }
+
+func TestInspectContextLimits(t *testing.T) {
+	repo := t.TempDir()
+	skill := filepath.Join(repo, "skills", "big")
+	os.MkdirAll(filepath.Join(skill, "references"), 0o755)
+	os.WriteFile(filepath.Join(skill, "SKILL.md"), []byte("---\nname: big\ntools: [claude-code, cursor]\n---\n"), 0o644)
+	os.WriteFile(filepath.Join(skill, "references", "api.md"), make([]byte, 80<<10), 0o644)
+	cfg := &config.Config{
+		RepoPath: repo,
+		Targets: []config.Target{
+			{Name: "claude-code-skills", Source: "skills"},
+			{Name: "cursor-skills", Source: "skills"},
+			{Name: "windsurf-skills", Source: "skills"},
+		},
+		ContextLimits: map[string]string{"cursor": "64KB", "default": "100KB"},
+	}
+
+	limits := inspectContextLimits(cfg, skill)
+	want := []toolLimit{{Tool: "cursor", Bytes: 64 << 10}, {Tool: "claude-code", Bytes: 100 << 10}}
+	if !reflect.DeepEqual(limits, want) {
+		t.Fatalf("limits = %+v, want %+v (windsurf is not linked)", limits, want)
+	}
+	if got := exceededLimits(limits, 80<<10); got != "too large for cursor (64.0 KiB)" {
+		t.Errorf("exceededLimits = %q", got)
+	}
+	if got := exceededLimits(limits, 1<<10); got != "" {
+		t.Errorf("exceededLimits of a small file = %q, want none", got)
+	}
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	n := printFileTree(skill, "", limits)
+	w.Close()
+	os.Stdout = old
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	out := buf.String()
+	if n != 1 {
+		t.Errorf("oversized = %d, want 1", n)
+	}
+	for _, line := range []string{"├── SKILL.md", "└── references/", "    └── api.md  80.0 KiB  ⚠ too large for cursor"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("tree missing %q:\n%s", line, out)
+		}
+	}
+}
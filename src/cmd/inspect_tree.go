@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// toolLimit is the context limit of one tool an item is linked for.
+type toolLimit struct {
+	Tool  string
+	Bytes int64
+}
+
+// inspectContextLimits returns the context limits of the tools the item at
+// itemPath is linked for by the configured targets, smallest first. Tools
+// without a limit are left out.
+func inspectContextLimits(cfg *config.Config, itemPath string) []toolLimit {
+	seen := make(map[string]bool)
+	var limits []toolLimit
+	for _, t := range cfg.Targets {
+		tool := t.Tool()
+		if t.IsFile() || t.IsRules() || seen[tool] {
+			continue
+		}
+		seen[tool] = true
+		if !skillAllowsTool(itemPath, tool) {
+			continue
+		}
+		if n := cfg.ContextLimitBytes(tool); n > 0 {
+			limits = append(limits, toolLimit{Tool: tool, Bytes: n})
+		}
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		if limits[i].Bytes != limits[j].Bytes {
+			return limits[i].Bytes < limits[j].Bytes
+		}
+		return limits[i].Tool < limits[j].Tool
+	})
+	return limits
+}
+
+// exceededLimits describes the limits size is over, e.g.
+// "too large for cursor (64.0 KiB), windsurf (100.0 KiB)"; "" if none.
+func exceededLimits(limits []toolLimit, size int64) string {
+	var over []string
+	for _, l := range limits {
+		if size > l.Bytes {
+			over = append(over, fmt.Sprintf("%s (%s)", l.Tool, humanBytes(l.Bytes)))
+		}
+	}
+	if len(over) == 0 {
+		return ""
+	}
+	return "too large for " + strings.Join(over, ", ")
+}
+
+// printFileTree prints the entries under dir as a tree with their sizes,
+// marking files over the context limit of a tool. It returns the number of
+// marked files.
+func printFileTree(dir, prefix string, limits []toolLimit) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		fmt.Printf("%s(cannot read: %v)\n", prefix, err)
+		return 0
+	}
+	entries = withoutGitDir(entries)
+	oversized := 0
+	for i, e := range entries {
+		branch, indent := "├── ", "│   "
+		if i == len(entries)-1 {
+			branch, indent = "└── ", "    "
+		}
+		p := filepath.Join(dir, e.Name())
+		switch {
+		case e.Type()&fs.ModeSymlink != 0:
+			target, _ := os.Readlink(p)
+			fmt.Printf("%s%s%s → %s\n", prefix, branch, e.Name(), target)
+		case e.IsDir():
+			fmt.Printf("%s%s%s/  %s\n", prefix, branch, e.Name(), humanBytes(dirSize(p)))
+			oversized += printFileTree(p, prefix+indent, limits)
+		default:
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			line := fmt.Sprintf("%s%s%s  %s", prefix, branch, e.Name(), humanBytes(info.Size()))
+			if over := exceededLimits(limits, info.Size()); over != "" {
+				line += "  ⚠ " + over
+				oversized++
+			}
+			fmt.Println(line)
+		}
+	}
+	return oversized
+}
+
+// withoutGitDir drops the .git entry, which is never part of an item.
+func withoutGitDir(entries []os.DirEntry) []os.DirEntry {
+	out := entries[:0]
+	for _, e := range entries {
+		if e.Name() != ".git" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// printInspectTree prints the file tree of the item at itemPath, or the
+// size of a single file, for 'axon inspect --tree'.
+func printInspectTree(cfg *config.Config, itemPath string, isDir bool) {
+	limits := inspectContextLimits(cfg, itemPath)
+	fmt.Println("\nTree:")
+	oversized := 0
+	if isDir {
+		fmt.Printf("  %s/  %s\n", filepath.Base(itemPath), humanBytes(dirSize(itemPath)))
+		oversized = printFileTree(itemPath, "  ", limits)
+	} else if info, err := os.Stat(itemPath); err == nil {
+		line := fmt.Sprintf("  %s  %s", filepath.Base(itemPath), humanBytes(info.Size()))
+		if over := exceededLimits(limits, info.Size()); over != "" {
+			line += "  ⚠ " + over
+			oversized++
+		}
+		fmt.Println(line)
+	}
+	if oversized > 0 {
+		fmt.Println()
+		printWarn("", fmt.Sprintf("%d file(s) exceed a tool's context limit; set context_limits in axon.yaml to change the limits", oversized))
+	}
+}
+
+// printInspectDeps prints the requires.skills dependency tree of the skill
+// at itemPath, for 'axon inspect --deps'.
+func printInspectDeps(cfg *config.Config, itemPath string) {
+	fmt.Println("\nDependency tree:")
+	graph := loadSkillGraph(cfg)
+	name := filepath.Base(itemPath)
+	if graph[name] == nil {
+		fmt.Println("  (not a skill)")
+		return
+	}
+	if len(graph[name].Requires) == 0 {
+		fmt.Printf("  %s (no dependencies)\n", name)
+		return
+	}
+	fmt.Printf("  %s\n", name)
+	printDepTree(graph, name, "  ", []string{name})
+}
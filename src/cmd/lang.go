@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/i18n"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// usageHeadings are the English pieces of cobra's usage template that are
+// translated.
+var usageHeadings = []string{
+	"Usage:",
+	"Aliases:",
+	"Examples:",
+	"Available Commands:",
+	"Additional Commands:",
+	"Global Flags:",
+	"Flags:",
+	"Additional help topics:",
+	`Use "{{.CommandPath}} [command] --help" for more information about a command.`,
+}
+
+// setupLanguage selects the message language from AXON_LANG, or from
+// 'language' in axon.yaml, and translates the command summaries, flag
+// usages, and usage headings of every command.
+func setupLanguage() {
+	lang := os.Getenv(i18n.EnvLang)
+	if lang == "" {
+		if cfg, err := config.Load(); err == nil {
+			lang = cfg.Language
+		}
+	}
+	if lang == "" {
+		return
+	}
+	if !i18n.SetLanguage(lang) {
+		fmt.Fprintf(os.Stderr, "axon: unsupported language %q (want one of %s); using English\n",
+			lang, strings.Join(i18n.Supported(), ", "))
+		return
+	}
+	if i18n.Language() != i18n.English {
+		// cobra adds these on Execute; add them now so they are translated too.
+		rootCmd.InitDefaultHelpCmd()
+		rootCmd.InitDefaultCompletionCmd()
+		localizeCommand(rootCmd)
+		rootCmd.SetUsageTemplate(localizeUsage(rootCmd.UsageTemplate()))
+	}
+}
+
+// localizeCommand translates the descriptions and flag usages of c and its
+// subcommands. Long descriptions are catalogued for the root command only;
+// the others stay in English.
+func localizeCommand(c *cobra.Command) {
+	c.Short = i18n.T(c.Short)
+	c.Long = i18n.T(c.Long)
+	translate := func(f *pflag.Flag) { f.Usage = i18n.T(f.Usage) }
+	c.Flags().VisitAll(translate)
+	c.PersistentFlags().VisitAll(translate)
+	c.InitDefaultHelpFlag()
+	if f := c.Flags().Lookup("help"); f != nil {
+		f.Usage = fmt.Sprintf(i18n.T("help for %s"), c.Name())
+	}
+	for _, sub := range c.Commands() {
+		localizeCommand(sub)
+	}
+}
+
+// localizeUsage translates the headings of a cobra usage template.
+func localizeUsage(tmpl string) string {
+	// Matches never overlap, so "Flags:" is not replaced inside
+	// "Global Flags:".
+	pairs := make([]string, 0, 2*len(usageHeadings))
+	for _, h := range usageHeadings {
+		pairs = append(pairs, h, i18n.T(h))
+	}
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func TestLocalizeUsage(t *testing.T) {
+	i18n.SetLanguage("zh")
+	t.Cleanup(func() { i18n.SetLanguage(i18n.English) })
+
+	got := localizeUsage((&cobra.Command{}).UsageTemplate())
+	for _, want := range []string{"用法：", "\n\n选项：\n", "\n\n全局选项：\n", "查看命令的更多信息。"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("localized template lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Global Flags:") || strings.Contains(got, "全局选项：\n{{.LocalFlags") {
+		t.Errorf("headings mixed up:\n%s", got)
+	}
+}
+
+func TestLocalizeCommand(t *testing.T) {
+	i18n.SetLanguage("zh")
+	t.Cleanup(func() { i18n.SetLanguage(i18n.English) })
+
+	root := &cobra.Command{Use: "axon"}
+	child := &cobra.Command{Use: "link", Short: "Create symlinks from tool destinations to the Hub"}
+	child.Flags().Bool("x", false, "Print axon version and exit")
+	root.AddCommand(child)
+
+	localizeCommand(root)
+	if child.Short != "为各工具的目标目录创建指向 Hub 的符号链接" {
+		t.Errorf("Short = %q", child.Short)
+	}
+	if u := child.Flags().Lookup("x").Usage; u != "打印 axon 版本并退出" {
+		t.Errorf("flag usage = %q", u)
+	}
+}
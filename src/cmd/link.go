@@ -1,35 +1,84 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
 	"github.com/spf13/cobra"
 )
 
 var linkCmd = &cobra.Command{
-	Use:   "link [target-name | all]",
+	Use:   "link [target-name | @group | all]",
 	Short: "Create symlinks from tool destinations to the Hub",
 	Long: `Create symbolic links so each AI tool's skill/workflow/command directory
 points to the central Hub at ~/.axon/repo/.
 
   axon link              Link all targets defined in axon.yaml (default)
   axon link all          Same as above
-  axon link windsurf-skills  Link a single target by name`,
+  axon link windsurf-skills  Link a single target by name
+  axon link @jetbrains   Link the targets with 'group: jetbrains'
+  axon link all --exclude cursor-skills,@jetbrains
+
+What happens to an existing destination:
+
+  destination            default   --no-backup   --force   --force --no-backup
+  empty dir / missing    link      link          link      link
+  wrong symlink          relink    relink        relink    relink
+  non-empty directory    backup    delete        backup    delete
+  file, socket, ...      refuse    refuse        backup    delete
+  mount point            refuse    refuse        refuse    refuse
+
+Backups go to ~/.axon/backups/<target>_<timestamp> and are restored by
+'axon unlink'. --no-backup is meant for throwaway environments such as CI.
+
+Linking is all-or-nothing: every target is checked before anything changes,
+and if one still fails, the targets already linked in this run are rolled
+back (recorded as 'link-rollback' in 'axon history').
+
+Targets whose destinations collide — one path linked to different content,
+or one destination inside another such as ~/.claude and ~/.claude/skills —
+are refused, since each link would undo the other. Fix the destinations, or
+link anyway with --force.
+
+A source may be a subpath such as skills/db-only, so one tool can have
+several targets. A nested source must already exist in the Hub unless
+--create-sources is given; top-level sources are created as needed.
+
+Targets with 'mode: copy' get a mirrored copy of the Hub instead of a symlink,
+refreshed on every run. Under WSL this is automatic for destinations on a
+Windows drive (e.g. destination: {windows_home}/.codeium/windsurf/skills).`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runLink,
 }
 
+var (
+	flagLinkForce         bool
+	flagLinkNoBackup      bool
+	flagLinkCreateSources bool
+	flagLinkExclude       []string
+)
+
 func init() {
+	linkCmd.Flags().BoolVar(&flagLinkForce, "force", false, "Also replace files and other non-directory destinations (backed up unless --no-backup), and link overlapping targets")
+	linkCmd.Flags().BoolVar(&flagLinkNoBackup, "no-backup", false, "Delete replaced destinations instead of backing them up")
+	linkCmd.Flags().StringSliceVar(&flagLinkExclude, "exclude", nil, "Skip these targets or @groups when linking all or a group")
+	linkCmd.Flags().BoolVar(&flagLinkCreateSources, "create-sources", false, "Create nested sources (e.g. skills/db-only) missing from the Hub")
 	rootCmd.AddCommand(linkCmd)
 }
 
+// linkOptions controls how linkTarget treats an existing destination.
+type linkOptions struct {
+	force         bool // replace files and other non-directory destinations
+	noBackup      bool // delete replaced content instead of backing it up
+	createSources bool // create nested sources missing from the Hub
+}
+
 func runLink(cmd *cobra.Command, args []string) error {
 	if err := checkGitAvailable(); err != nil {
 		return err
@@ -39,46 +88,26 @@ func runLink(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
 	}
 
-	// Determine which targets to process.
-	var targets []config.Target
-	singleTarget := false
-	if len(args) == 0 || args[0] == "all" {
-		targets = make([]config.Target, len(cfg.Targets))
-		copy(targets, cfg.Targets)
-		sort.Slice(targets, func(i, j int) bool {
-			return targets[i].Name < targets[j].Name
-		})
-	} else {
-		name := args[0]
-		for _, t := range cfg.Targets {
-			if t.Name == name {
-				targets = append(targets, t)
-				break
-			}
-		}
-		if len(targets) == 0 {
-			return fmt.Errorf("target %q not found in axon.yaml", name)
-		}
-		singleTarget = true
+	targets, singleTarget, err := selectTargets(cfg, args, flagLinkExclude)
+	if err != nil {
+		return err
 	}
 
-	// ── Collect results ────────────────────────────────────────────────────────
-	type linkResult struct {
-		name   string
-		state  string // "linked","already","relinked","backed_up","error"
-		detail string
-	}
-	var results []linkResult
-	notInstalledMap := make(map[string]bool)
+	// Signed skills are verified after linking; failures only warn.
+	defer warnSignatureFailures(cfg)
+	// So are missing or cyclic skill dependencies.
+	defer warnSkillDependencyProblems(cfg)
 
-	for _, t := range targets {
-		state, detail, notInstalled := linkTarget(cfg, t)
-		if notInstalled != "" {
-			notInstalledMap[notInstalled] = true
-			continue
-		}
-		results = append(results, linkResult{t.Name, state, detail})
+	opts := linkOptions{force: flagLinkForce, noBackup: flagLinkNoBackup, createSources: flagLinkCreateSources}
+	results, notInstalledMap, err := linkTargets(cfg, targets, opts)
+	if err != nil {
+		return err
+	}
+	changed := make(map[string]bool)
+	for _, r := range results {
+		changed[r.name] = r.state != "already"
 	}
+	defer runTargetHooks(cfg, targets, changed, hookPostLink)
 
 	// ── Print results ──────────────────────────────────────────────────────────
 	if singleTarget {
@@ -93,9 +122,10 @@ func runLink(cmd *cobra.Command, args []string) error {
 				printInfo(r.name, "re-linked ("+r.detail+")")
 			case "backed_up":
 				printBackup(r.name, r.detail)
-			case "error":
-				printErr(r.name, r.detail)
-				return fmt.Errorf("link failed")
+			case "replaced":
+				printWarn(r.name, r.detail)
+			case "refreshed":
+				printOK(r.name, r.detail)
 			}
 		}
 		return nil
@@ -104,7 +134,7 @@ func runLink(cmd *cobra.Command, args []string) error {
 	// Multi-target: grouped sections.
 	printSection("Link")
 
-	var linked, already, relinked, backedUp, errors []linkResult
+	var linked, already, relinked, backedUp, replaced, refreshed []linkResult
 	for _, r := range results {
 		switch r.state {
 		case "linked":
@@ -115,8 +145,10 @@ func runLink(cmd *cobra.Command, args []string) error {
 			relinked = append(relinked, r)
 		case "backed_up":
 			backedUp = append(backedUp, r)
-		case "error":
-			errors = append(errors, r)
+		case "replaced":
+			replaced = append(replaced, r)
+		case "refreshed":
+			refreshed = append(refreshed, r)
 		}
 	}
 
@@ -132,12 +164,24 @@ func runLink(cmd *cobra.Command, args []string) error {
 			printBackup(r.name, r.detail)
 		}
 	}
+	if len(replaced) > 0 {
+		printBullet("Linked (original deleted, --no-backup):")
+		for _, r := range replaced {
+			printWarn(r.name, r.detail)
+		}
+	}
 	if len(relinked) > 0 {
 		printBullet("Re-linked (wrong target corrected):")
 		for _, r := range relinked {
 			printInfo(r.name, r.detail)
 		}
 	}
+	if len(refreshed) > 0 {
+		printBullet("Copies refreshed:")
+		for _, r := range refreshed {
+			printOK(r.name, r.detail)
+		}
+	}
 	if len(already) > 0 {
 		printBullet("Already linked:")
 		for _, r := range already {
@@ -155,49 +199,86 @@ func runLink(cmd *cobra.Command, args []string) error {
 			printSkip("", name)
 		}
 	}
-	if len(errors) > 0 {
-		printBullet("Errors:")
-		for _, r := range errors {
-			printErr(r.name, r.detail)
-		}
-		return fmt.Errorf("%d target(s) failed to link", len(errors))
-	}
-
 	return nil
 }
 
-// linkTarget applies the 5-case linking logic for a single target.
+// linkTarget applies the linking policy for a single target (see the link
+// command help for the destination matrix).
 // Returns (state, detail, notInstalledToolName).
 // If notInstalledToolName is non-empty, the tool is not installed and the
 // caller should group it separately; state/detail are meaningless in that case.
-func linkTarget(cfg *config.Config, t config.Target) (state, detail, notInstalled string) {
+func linkTarget(cfg *config.Config, t config.Target, opts linkOptions) (state, detail, notInstalled string) {
 	dest, err := config.ExpandPath(t.Destination)
+	if errors.Is(err, config.ErrNoWindowsHome) {
+		// {windows_home} target on a machine without a Windows side.
+		return "", "", toolBaseName(t.Name)
+	}
+	if err != nil {
+		return "error", err.Error(), ""
+	}
+	if real, inHub := destParentInHub(cfg, dest); inHub {
+		return "error", destInHubRefusal(dest, real), ""
+	}
+	if t.IsFile() {
+		return linkFileTarget(cfg, t, dest, opts)
+	}
+	if t.IsRules() {
+		return linkRulesTarget(cfg, t, dest, opts)
+	}
+	hubPath, repos, err := targetLinkSource(cfg, t)
 	if err != nil {
 		return "error", err.Error(), ""
 	}
-	hubPath := filepath.Join(cfg.RepoPath, t.Source)
 
-	// Ensure Hub source directory exists.
-	if err := os.MkdirAll(hubPath, 0o755); err != nil {
+	if err := missingTargetSource(cfg, t); err != nil {
+		if _, perr := fsys.Stat(filepath.Dir(dest)); os.IsNotExist(perr) {
+			return "", "", toolBaseName(t.Name) // not installed: create nothing
+		}
+		if !opts.createSources {
+			return "error", err.Error(), ""
+		}
+		if err := createTargetSource(cfg, t); err != nil {
+			return "error", err.Error(), ""
+		}
+	}
+
+	style := cfg.TargetLinkStyle(t)
+	if usesMergedView(t, repos) {
+		// Several repos or tool-limited skills: (re)build the merged view the
+		// destination points to.
+		if _, _, err := refreshMergedView(hubPath, t.Source, repos, style, t.Tool()); err != nil {
+			return "error", err.Error(), ""
+		}
+	} else if err := fsys.MkdirAll(hubPath, 0o755); err != nil {
+		// Ensure Hub source directory exists.
 		return "error", fmt.Sprintf("cannot create hub path: %v", err), ""
 	}
 
-	info, lstatErr := os.Lstat(dest)
+	if targetLinkMode(t, dest, hubPath) == config.LinkModeCopy {
+		return copyTarget(cfg, t, dest, hubPath, opts)
+	}
+
+	link, err := symlinkValue(style, dest, hubPath)
+	if err != nil {
+		return "error", err.Error(), ""
+	}
+
+	info, lstatErr := fsys.Lstat(dest)
 
 	// ── Case: Does not exist ───────────────────────────────────────────────────
 	if os.IsNotExist(lstatErr) {
 		parent := filepath.Dir(dest)
-		if _, parentErr := os.Stat(parent); os.IsNotExist(parentErr) {
+		if _, parentErr := fsys.Stat(parent); os.IsNotExist(parentErr) {
 			baseName := t.Name
 			if idx := strings.LastIndex(t.Name, "-"); idx != -1 {
 				baseName = t.Name[:idx]
 			}
 			return "", "", baseName
 		}
-		if err := createSymlink(hubPath, dest, t.Name); err != nil {
+		if err := createSymlink(link, dest, t.Name); err != nil {
 			return "error", err.Error(), ""
 		}
-		return "linked", fmt.Sprintf("%s → %s", dest, hubPath), ""
+		return "linked", fmt.Sprintf("%s → %s", dest, link), ""
 	}
 	if lstatErr != nil {
 		return "error", fmt.Sprintf("stat: %v", lstatErr), ""
@@ -205,70 +286,221 @@ func linkTarget(cfg *config.Config, t config.Target) (state, detail, notInstalle
 
 	// ── Symlink cases ──────────────────────────────────────────────────────────
 	if info.Mode()&os.ModeSymlink != 0 {
-		current, err := os.Readlink(dest)
+		current, err := fsys.Readlink(dest)
 		if err != nil {
 			return "error", fmt.Sprintf("readlink: %v", err), ""
 		}
-		if current == hubPath {
+		if current == link {
 			return "already", "", ""
 		}
-		// Wrong symlink — remove and re-create.
-		if err := os.Remove(dest); err != nil {
+		// Wrong symlink, or the right one in the other link style — re-create.
+		if err := fsys.Remove(dest); err != nil {
 			return "error", fmt.Sprintf("cannot remove old symlink: %v", err), ""
 		}
-		if err := createSymlink(hubPath, dest, t.Name); err != nil {
+		if err := createSymlink(link, dest, t.Name); err != nil {
 			return "error", err.Error(), ""
 		}
+		if linkResolvesTo(dest, current, hubPath) {
+			return "relinked", fmt.Sprintf("switched to %s link %s", style, link), ""
+		}
 		return "relinked", fmt.Sprintf("was → %s", current), ""
 	}
 
-	// ── Real directory ─────────────────────────────────────────────────────────
+	// ── Mount points are never moved or deleted ────────────────────────────────
+	if isMountPoint(dest, info) {
+		return "error", mountPointRefusal(dest), ""
+	}
+
+	// ── Files, sockets, devices: only with --force ─────────────────────────────
 	if !info.IsDir() {
-		return "error", fmt.Sprintf("%s is not a directory or symlink", dest), ""
+		if !opts.force {
+			return "error", nonDirectoryRefusal(dest, info), ""
+		}
+		return replaceDestination(cfg, t, dest, opts, func() error { return createSymlink(link, dest, t.Name) }, fmt.Sprintf("%s → %s", dest, link))
 	}
 
-	entries, err := os.ReadDir(dest)
+	entries, err := fsys.ReadDir(dest)
 	if err != nil {
 		return "error", fmt.Sprintf("readdir: %v", err), ""
 	}
 
 	// Empty directory — remove and link.
 	if len(entries) == 0 {
-		if err := os.Remove(dest); err != nil {
+		if err := fsys.Remove(dest); err != nil {
 			return "error", fmt.Sprintf("cannot remove empty dir: %v", err), ""
 		}
-		if err := createSymlink(hubPath, dest, t.Name); err != nil {
+		if err := createSymlink(link, dest, t.Name); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "linked", fmt.Sprintf("%s → %s", dest, link), ""
+	}
+
+	// Non-empty directory — backup (or delete with --no-backup) then link.
+	return replaceDestination(cfg, t, dest, opts, func() error { return createSymlink(link, dest, t.Name) }, fmt.Sprintf("%s → %s", dest, link))
+}
+
+// replaceDestination moves dest into a timestamped backup (or deletes it when
+// opts.noBackup is set) and then runs install to put the link or copy
+// described by desc in its place.
+func replaceDestination(cfg *config.Config, t config.Target, dest string, opts linkOptions, install func() error, desc string) (state, detail, notInstalled string) {
+	if opts.noBackup {
+		if err := fsys.RemoveAll(dest); err != nil {
+			return "error", fmt.Sprintf("cannot delete %s: %v", dest, err), ""
+		}
+		if err := install(); err != nil {
 			return "error", err.Error(), ""
 		}
-		return "linked", fmt.Sprintf("%s → %s", dest, hubPath), ""
+		return "replaced", "deleted original, " + desc, ""
 	}
 
-	// Non-empty directory — backup then link.
 	bkp, err := backupDir(cfg, t.Name)
 	if err != nil {
 		return "error", err.Error(), ""
 	}
-	if err := os.Rename(dest, bkp); err != nil {
+	if err := moveAside(dest, bkp); err != nil {
 		return "error", fmt.Sprintf("backup failed: %v", err), ""
 	}
-	if err := createSymlink(hubPath, dest, t.Name); err != nil {
+	if err := install(); err != nil {
+		// Put the original back rather than leave the destination empty.
+		_ = fsys.RemoveAll(dest)
+		if rerr := moveAside(bkp, dest); rerr != nil {
+			return "error", fmt.Sprintf("%v (original left in backup %s: %v)", err, bkp, rerr), ""
+		}
 		return "error", err.Error(), ""
 	}
 	return "backed_up", fmt.Sprintf("backed up → %s", bkp), ""
 }
 
+// mountPointRefusal explains why a mount point destination is left alone.
+func mountPointRefusal(dest string) string {
+	return fmt.Sprintf("%s is a mount point — refusing to replace it; unmount it or change the destination", dest)
+}
+
+// nonDirectoryRefusal explains why a file destination needs --force.
+func nonDirectoryRefusal(dest string, info os.FileInfo) string {
+	return fmt.Sprintf("%s is a %s, not a directory — re-run with --force to replace it (backed up unless --no-backup)", dest, fileKind(info))
+}
+
+// fileKind names the type of a non-directory destination for error messages.
+func fileKind(info os.FileInfo) string {
+	m := info.Mode()
+	switch {
+	case m.IsRegular():
+		return "regular file"
+	case m&os.ModeNamedPipe != 0:
+		return "named pipe"
+	case m&os.ModeSocket != 0:
+		return "socket"
+	case m&os.ModeDevice != 0:
+		return "device"
+	}
+	return "special file"
+}
+
+// currentLinkTarget returns where t's destination symlink points now, or ""
+// when it is not a symlink.
+func currentLinkTarget(t config.Target) string {
+	dest, err := config.ExpandPath(t.Destination)
+	if err != nil {
+		return ""
+	}
+	current, _ := fsys.Readlink(dest)
+	return current
+}
+
+// linkJournalEntry describes a link that changed the filesystem, keeping
+// enough detail (previous symlink, backup path) for 'axon undo' to revert
+// it. It reports false for states that changed nothing worth journaling.
+func linkJournalEntry(cfg *config.Config, t config.Target, state, previous string) (journal.Entry, bool) {
+	if state != "linked" && state != "relinked" && state != "backed_up" && state != "replaced" {
+		return journal.Entry{}, false
+	}
+	dest, err := config.ExpandPath(t.Destination)
+	if err != nil {
+		return journal.Entry{}, false
+	}
+	hubPath, _, err := targetLinkSource(cfg, t)
+	if err != nil {
+		return journal.Entry{}, false
+	}
+	data := map[string]string{"state": state, "dest": dest, "source": hubPath}
+	detail := fmt.Sprintf("%s → %s", dest, hubPath)
+	if _, ok := copyMarkerSource(dest); ok {
+		data["mode"] = config.LinkModeCopy
+		detail = fmt.Sprintf("%s copied from %s", dest, hubPath)
+		if t.IsFile() || t.IsRules() {
+			data["mode"] = modeRender
+			detail = fmt.Sprintf("%s rendered from %s", dest, hubPath)
+		}
+	}
+	switch state {
+	case "relinked":
+		data["previous"] = previous
+		detail += fmt.Sprintf(" (was → %s)", previous)
+	case "backed_up":
+		if bkp, err := latestBackup(cfg, t.Name); err == nil && bkp != "" {
+			data["backup"] = bkp
+			detail += fmt.Sprintf(" (backed up → %s)", bkp)
+		}
+	case "replaced":
+		detail += " (original deleted, --no-backup)"
+	}
+	return journal.Entry{Op: journal.OpLink, Target: t.Name, Detail: detail, Data: data}, true
+}
+
+// symlinkValue returns what the symlink at dest should contain to reach
+// target: target itself for absolute links, or a path relative to dest's
+// directory (e.g. ../../.axon/repo/skills) for relative links. Symlinks in
+// both directories are resolved first, because the OS resolves a relative
+// link from the real directory holding it.
+func symlinkValue(style, dest, target string) (string, error) {
+	if style != config.LinkStyleRelative {
+		return target, nil
+	}
+	from := filepath.Dir(dest)
+	if real, err := filepath.EvalSymlinks(from); err == nil {
+		from = real
+	}
+	to := target
+	if real, err := filepath.EvalSymlinks(filepath.Dir(target)); err == nil {
+		to = filepath.Join(real, filepath.Base(target))
+	}
+	rel, err := filepath.Rel(from, to)
+	if err != nil {
+		return "", fmt.Errorf("cannot make a relative link from %s to %s: %w", dest, target, err)
+	}
+	return rel, nil
+}
+
+// linkResolvesTo reports whether a symlink at dest containing raw reaches
+// target, whichever link style was used to create it.
+func linkResolvesTo(dest, raw, target string) bool {
+	if raw == target {
+		return true
+	}
+	if filepath.IsAbs(raw) {
+		return false
+	}
+	if filepath.Join(filepath.Dir(dest), raw) == filepath.Clean(target) {
+		return true
+	}
+	a, errA := fsys.Stat(dest)
+	b, errB := fsys.Stat(target)
+	return errA == nil && errB == nil && os.SameFile(a, b)
+}
+
 // createSymlink creates dest → hub, handling platform differences.
 func createSymlink(hub, dest, name string) error {
 	_ = name
 	var err error
-	if runtime.GOOS == "windows" {
-		err = os.Symlink(hub, dest)
+	if hostOS == "windows" {
+		err = fsys.Symlink(hub, dest)
 		if err != nil {
 			return fmt.Errorf(
 				"symlink failed on Windows — run 'axon doctor' for remediation.\n  Underlying error: %w", err)
 		}
 	} else {
-		err = os.Symlink(hub, dest)
+		err = fsys.Symlink(hub, dest)
 	}
 	if err != nil {
 		return fmt.Errorf("symlink %s → %s: %w", dest, hub, err)
@@ -282,9 +514,9 @@ func backupDir(_ *config.Config, targetName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	ts := time.Now().Format("20060102150405")
+	ts := clock.Now().Format(backupLayout)
 	dir := filepath.Join(axonDir, "backups", targetName+"_"+ts)
-	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+	if err := fsys.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
 		return "", fmt.Errorf("cannot create backups dir: %w", err)
 	}
 	return dir, nil
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/portable"
+)
+
+// ── Copy mode ─────────────────────────────────────────────────────────────────
+// Some destinations cannot use a symlink into the Hub: under WSL, Windows
+// programs reading C:\Users\me\.codeium cannot follow a Linux symlink into
+// the WSL filesystem. For those targets 'axon link' mirrors the Hub source
+// into the destination instead and re-mirrors it on every run.
+
+// copyMarkerName marks a destination provisioned by copy mode. It holds the
+// Hub directory the copy mirrors and is what lets status, unlink, and undo
+// tell an axon copy apart from a user's real directory.
+const copyMarkerName = ".axon-copy"
+
+// targetLinkMode resolves t's mode for dest and its Hub source. Auto copies
+// when, under WSL, dest is on a Windows drive but the source is not.
+func targetLinkMode(t config.Target, dest, source string) string {
+	switch t.Mode {
+	case config.LinkModeSymlink, config.LinkModeCopy:
+		return t.Mode
+	}
+	if config.IsWSL() && config.OnWindowsDrive(dest) && !config.OnWindowsDrive(source) {
+		return config.LinkModeCopy
+	}
+	return config.LinkModeSymlink
+}
+
+// copyMarkerSource returns the Hub directory recorded in dest's copy marker,
+// and whether dest is an axon copy at all. A rendered file target counts as
+// a copy of its Hub file.
+func copyMarkerSource(dest string) (string, bool) {
+	data, err := fsys.ReadFile(filepath.Join(dest, copyMarkerName))
+	if err != nil {
+		return renderedFileSource(dest)
+	}
+	return strings.TrimSpace(string(data)), true
+}
+
+// installCopy creates dest as a fresh copy of source.
+func installCopy(source, dest string) error {
+	if err := fsys.MkdirAll(dest, 0o755); err != nil {
+		return fmt.Errorf("cannot create %s: %w", dest, err)
+	}
+	if _, err := mirrorCopy(source, dest, false); err != nil {
+		return err
+	}
+	return writeCopyMarker(source, dest)
+}
+
+func writeCopyMarker(source, dest string) error {
+	if err := fsys.WriteFile(filepath.Join(dest, copyMarkerName), []byte(source+"\n"), 0o644); err != nil {
+		return fmt.Errorf("cannot write copy marker: %w", err)
+	}
+	return nil
+}
+
+// copyTarget is linkTarget for copy mode. It follows the same policy for
+// whatever already sits at dest; an existing axon copy is refreshed in place.
+func copyTarget(cfg *config.Config, t config.Target, dest, source string, opts linkOptions) (state, detail, notInstalled string) {
+	info, lstatErr := fsys.Lstat(dest)
+	if os.IsNotExist(lstatErr) {
+		if _, err := fsys.Stat(filepath.Dir(dest)); os.IsNotExist(err) {
+			return "", "", toolBaseName(t.Name)
+		}
+		if err := installCopy(source, dest); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "linked", fmt.Sprintf("%s copied from %s", dest, source), ""
+	}
+	if lstatErr != nil {
+		return "error", fmt.Sprintf("stat: %v", lstatErr), ""
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		current, _ := fsys.Readlink(dest)
+		if err := fsys.Remove(dest); err != nil {
+			return "error", fmt.Sprintf("cannot remove old symlink: %v", err), ""
+		}
+		if err := installCopy(source, dest); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "relinked", fmt.Sprintf("was → %s, now a copy", current), ""
+	}
+
+	if isMountPoint(dest, info) {
+		return "error", mountPointRefusal(dest), ""
+	}
+
+	install := func() error { return installCopy(source, dest) }
+	desc := fmt.Sprintf("%s copied from %s", dest, source)
+	if !info.IsDir() {
+		if !opts.force {
+			return "error", nonDirectoryRefusal(dest, info), ""
+		}
+		return replaceDestination(cfg, t, dest, opts, install, desc)
+	}
+
+	// Existing axon copy: bring it up to date.
+	if marked, ok := copyMarkerSource(dest); ok {
+		n, err := mirrorCopy(source, dest, false)
+		if err != nil {
+			return "error", err.Error(), ""
+		}
+		if n == 0 && marked == source {
+			return "already", "", ""
+		}
+		if err := writeCopyMarker(source, dest); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "refreshed", fmt.Sprintf("copy updated (%d change(s))", n), ""
+	}
+
+	entries, err := fsys.ReadDir(dest)
+	if err != nil {
+		return "error", fmt.Sprintf("readdir: %v", err), ""
+	}
+	if len(entries) == 0 {
+		if err := installCopy(source, dest); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "linked", desc, ""
+	}
+	return replaceDestination(cfg, t, dest, opts, install, desc)
+}
+
+// copyDrift counts the changes 'axon link' would make to the copy of
+// expected at dest; a rendered file target counts as one change when stale.
+func copyDrift(cfg *config.Config, t config.Target, dest, expected string) (int, error) {
+	if t.IsRules() {
+		return rulesDrift(cfg, t, dest, expected)
+	}
+	if !t.IsFile() {
+		return mirrorCopy(expected, dest, true)
+	}
+	stale, err := renderedDrift(cfg, t, dest, expected)
+	if err != nil || !stale {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// mirrorCopy makes dest an exact copy of src and returns the number of
+// entries it added, updated, or removed; with dryRun it only counts them.
+// Symlinks in src (e.g. merged-view items) are followed, .git directories
+// and the copy marker are left alone.
+func mirrorCopy(src, dest string, dryRun bool) (int, error) {
+	entries, err := fsys.ReadDir(src)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read %s: %w", src, err)
+	}
+	changes := 0
+	want := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if name == copyMarkerName || name == ".git" {
+			continue
+		}
+		sp, dp := filepath.Join(src, name), filepath.Join(dest, name)
+		sInfo, err := fsys.Stat(sp)
+		if err != nil {
+			continue // dangling symlink in the Hub
+		}
+		want[name] = true
+		dInfo, dErr := fsys.Lstat(dp)
+
+		if sInfo.IsDir() {
+			if dErr == nil && !dInfo.IsDir() {
+				changes++
+				if !dryRun {
+					if err := fsys.RemoveAll(dp); err != nil {
+						return changes, err
+					}
+				}
+				dErr = os.ErrNotExist
+			}
+			if dErr != nil {
+				changes++
+				if !dryRun {
+					if err := fsys.MkdirAll(portable.LongPath(dp), 0o755); err != nil {
+						return changes, err
+					}
+				}
+			}
+			n, err := mirrorCopy(sp, dp, dryRun)
+			changes += n
+			if err != nil {
+				return changes, err
+			}
+			continue
+		}
+
+		if dErr == nil && dInfo.Mode().IsRegular() && sameFileContent(sp, dp, sInfo, dInfo) {
+			continue
+		}
+		changes++
+		if dryRun {
+			continue
+		}
+		if dErr == nil && !dInfo.Mode().IsRegular() {
+			if err := fsys.RemoveAll(dp); err != nil {
+				return changes, err
+			}
+		}
+		if err := copyMirroredFile(sp, dp, sInfo); err != nil {
+			return changes, err
+		}
+	}
+
+	existing, err := fsys.ReadDir(dest)
+	if err != nil && !os.IsNotExist(err) {
+		return changes, err
+	}
+	for _, e := range existing {
+		if want[e.Name()] || e.Name() == copyMarkerName {
+			continue
+		}
+		changes++
+		if !dryRun {
+			if err := fsys.RemoveAll(filepath.Join(dest, e.Name())); err != nil {
+				return changes, err
+			}
+		}
+	}
+	return changes, nil
+}
+
+// sameFileContent treats files with equal size and mtime as identical and
+// compares bytes otherwise.
+func sameFileContent(a, b string, ai, bi os.FileInfo) bool {
+	if ai.Size() != bi.Size() {
+		return false
+	}
+	if ai.ModTime().Equal(bi.ModTime()) {
+		return true
+	}
+	ad, err := fsys.ReadFile(a)
+	if err != nil {
+		return false
+	}
+	bd, err := fsys.ReadFile(b)
+	return err == nil && bytes.Equal(ad, bd)
+}
+
+// copyMirroredFile copies src to dest, keeping its permissions and mtime so
+// the next mirror run can skip it cheaply.
+func copyMirroredFile(src, dest string, info os.FileInfo) error {
+	data, err := fsys.ReadFile(portable.LongPath(src))
+	if err != nil {
+		return err
+	}
+	dest = portable.LongPath(dest)
+	if err := fsys.WriteFile(dest, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("cannot copy %s: %w", src, err)
+	}
+	return os.Chtimes(dest, info.ModTime(), info.ModTime())
+}
+
+// toolBaseName strips the last "-suffix" from a target name
+// ("windsurf-skills" → "windsurf") to group targets by tool.
+func toolBaseName(name string) string {
+	if idx := strings.LastIndex(name, "-"); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
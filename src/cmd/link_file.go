@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// ── File targets ──────────────────────────────────────────────────────────────
+// A 'type: file' target links one Hub file, e.g. a shared AGENTS.md, to a
+// tool-specific path such as ~/.claude/CLAUDE.md. When a tool needs its own
+// variation (vars, append) or cannot follow a symlink (copy mode), the
+// destination is rendered instead. The first line of a rendered file names
+// its Hub source; like the copy marker of a copied directory, it is what lets
+// status, unlink, and undo tell it apart from a file the user wrote.
+
+const (
+	renderedMarkerPrefix = "<!-- axon: rendered from "
+	renderedMarkerSuffix = "; edit that file, 'axon link' overwrites this one -->"
+)
+
+// modeRender marks journal entries for rendered file targets.
+const modeRender = "render"
+
+// fileTargetSource returns the Hub file t links: t.Source in the first of
+// its repos that has it. repos holds only that repo, so file targets never
+// get a merged view.
+func fileTargetSource(cfg *config.Config, t config.Target) (string, []config.Repo, error) {
+	repos, err := cfg.TargetRepos(t)
+	if err != nil {
+		return "", nil, err
+	}
+	p, r, err := firstRepoFile(repos, t.Source)
+	if err != nil {
+		return "", nil, err
+	}
+	return p, []config.Repo{r}, nil
+}
+
+// firstRepoFile finds the regular file rel in the highest-priority repo.
+func firstRepoFile(repos []config.Repo, rel string) (string, config.Repo, error) {
+	for _, r := range repos {
+		p := filepath.Join(r.Path, filepath.FromSlash(rel))
+		info, err := fsys.Stat(p)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			return "", r, fmt.Errorf("%s in repo %s is a directory; use type: %s", rel, r.Name, config.TargetTypeDirectory)
+		}
+		return p, r, nil
+	}
+	return "", config.Repo{}, fmt.Errorf("source file %s does not exist in the Hub", rel)
+}
+
+// renderedTarget reports whether t's destination is rendered rather than
+// symlinked to source.
+func renderedTarget(t config.Target, dest, source string) bool {
+	return len(t.Vars) > 0 || len(t.Append) > 0 || targetLinkMode(t, dest, source) == config.LinkModeCopy
+}
+
+// renderFileTarget returns the content of t's rendered destination: the
+// marker line, then source followed by the append files, expanded as a
+// template when t has vars.
+func renderFileTarget(cfg *config.Config, t config.Target, source string) ([]byte, error) {
+	body, err := fsys.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := cfg.TargetRepos(t)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range t.Append {
+		p, _, err := firstRepoFile(repos, rel)
+		if err != nil {
+			return nil, fmt.Errorf("append: %w", err)
+		}
+		extra, err := fsys.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		if len(body) > 0 && !bytes.HasSuffix(body, []byte("\n")) {
+			body = append(body, '\n')
+		}
+		body = append(append(body, '\n'), extra...)
+	}
+	if len(t.Vars) > 0 {
+		tmpl, err := template.New(t.Source).Option("missingkey=error").Parse(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", t.Source, err)
+		}
+		data := map[string]string{"target": t.Name, "tool": t.Tool()}
+		for k, v := range t.Vars {
+			data[k] = v
+		}
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, data); err != nil {
+			return nil, fmt.Errorf("template %s: %w", t.Source, err)
+		}
+		body = out.Bytes()
+	}
+	return append([]byte(renderedMarkerPrefix+source+renderedMarkerSuffix+"\n"), body...), nil
+}
+
+// renderedFileSource returns the Hub file named on the marker line of the
+// rendered file dest, and whether dest is a rendered file at all.
+func renderedFileSource(dest string) (string, bool) {
+	info, err := fsys.Lstat(dest)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	f, err := os.Open(dest)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil {
+		return "", false
+	}
+	rest, ok := strings.CutPrefix(strings.TrimRight(line, "\r\n"), renderedMarkerPrefix)
+	if !ok {
+		return "", false
+	}
+	return strings.CutSuffix(rest, renderedMarkerSuffix)
+}
+
+// renderedDrift reports whether the rendered file dest differs from what
+// 'axon link' would write now.
+func renderedDrift(cfg *config.Config, t config.Target, dest, source string) (bool, error) {
+	want, err := renderFileTarget(cfg, t, source)
+	if err != nil {
+		return false, err
+	}
+	got, err := fsys.ReadFile(dest)
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(got, want), nil
+}
+
+// linkFileTarget is linkTarget for file targets. It follows the directory
+// policy with files and directories swapped: a real file at dest is backed
+// up and replaced, a directory needs --force.
+func linkFileTarget(cfg *config.Config, t config.Target, dest string, opts linkOptions) (state, detail, notInstalled string) {
+	if _, err := fsys.Stat(filepath.Dir(dest)); os.IsNotExist(err) {
+		return "", "", toolBaseName(t.Name)
+	}
+	source, _, err := fileTargetSource(cfg, t)
+	if err != nil {
+		return "error", err.Error(), ""
+	}
+	if renderedTarget(t, dest, source) {
+		content, err := renderFileTarget(cfg, t, source)
+		if err != nil {
+			return "error", err.Error(), ""
+		}
+		return writeRenderedFile(cfg, t, dest, content, fmt.Sprintf("%s rendered from %s", dest, source), opts)
+	}
+
+	link, err := symlinkValue(cfg.TargetLinkStyle(t), dest, source)
+	if err != nil {
+		return "error", err.Error(), ""
+	}
+	install := func() error { return createSymlink(link, dest, t.Name) }
+	desc := fmt.Sprintf("%s → %s", dest, link)
+	if current, err := fsys.Readlink(dest); err == nil && current == link {
+		return "already", "", ""
+	}
+	if _, ok := renderedFileSource(dest); ok {
+		if err := fsys.Remove(dest); err != nil {
+			return "error", err.Error(), ""
+		}
+		if err := install(); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "relinked", "was a rendered file, now " + desc, ""
+	}
+	return placeFile(cfg, t, dest, install, desc, opts)
+}
+
+// writeRenderedFile makes dest the rendered file content, refreshing an
+// earlier rendering in place.
+func writeRenderedFile(cfg *config.Config, t config.Target, dest string, content []byte, desc string, opts linkOptions) (state, detail, notInstalled string) {
+	if _, ok := renderedFileSource(dest); ok {
+		if current, err := fsys.ReadFile(dest); err == nil && bytes.Equal(current, content) {
+			return "already", "", ""
+		}
+		if err := fsys.WriteFile(dest, content, 0o644); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "refreshed", "rendered file updated", ""
+	}
+	return placeFile(cfg, t, dest, func() error { return fsys.WriteFile(dest, content, 0o644) }, desc, opts)
+}
+
+// placeFile runs install to put a file (symlink or rendered) at dest, after
+// dealing with whatever is there per the file target policy.
+func placeFile(cfg *config.Config, t config.Target, dest string, install func() error, desc string, opts linkOptions) (state, detail, notInstalled string) {
+	info, lstatErr := fsys.Lstat(dest)
+	switch {
+	case os.IsNotExist(lstatErr):
+		if err := install(); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "linked", desc, ""
+	case lstatErr != nil:
+		return "error", fmt.Sprintf("stat: %v", lstatErr), ""
+	case info.Mode()&os.ModeSymlink != 0:
+		current, _ := fsys.Readlink(dest)
+		if err := fsys.Remove(dest); err != nil {
+			return "error", fmt.Sprintf("cannot remove old symlink: %v", err), ""
+		}
+		if err := install(); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "relinked", fmt.Sprintf("was → %s, now %s", current, desc), ""
+	case isMountPoint(dest, info):
+		return "error", mountPointRefusal(dest), ""
+	case !info.Mode().IsRegular() && !opts.force:
+		return "error", nonFileRefusal(dest, info), ""
+	}
+	return replaceDestination(cfg, t, dest, opts, install, desc)
+}
+
+// planFileTarget is planLinkTarget for file targets.
+func planFileTarget(cfg *config.Config, t config.Target, dest string, opts linkOptions) string {
+	if _, err := fsys.Stat(filepath.Dir(dest)); os.IsNotExist(err) {
+		return ""
+	}
+	source, _, err := fileTargetSource(cfg, t)
+	if err != nil {
+		return err.Error()
+	}
+	if renderedTarget(t, dest, source) {
+		if _, err := renderFileTarget(cfg, t, source); err != nil {
+			return err.Error()
+		}
+	}
+	info, err := fsys.Lstat(dest)
+	switch {
+	case os.IsNotExist(err):
+		return ""
+	case err != nil:
+		return fmt.Sprintf("stat: %v", err)
+	case info.Mode()&os.ModeSymlink != 0:
+		return ""
+	case isMountPoint(dest, info):
+		return mountPointRefusal(dest)
+	case !info.Mode().IsRegular() && !opts.force:
+		return nonFileRefusal(dest, info)
+	}
+	return ""
+}
+
+// nonFileRefusal explains why a file target's non-file destination needs
+// --force.
+func nonFileRefusal(dest string, info os.FileInfo) string {
+	kind := "directory"
+	if !info.IsDir() {
+		kind = fileKind(info)
+	}
+	return fmt.Sprintf("%s is a %s, not a file — re-run with --force to replace it (backed up unless --no-backup)", dest, kind)
+}
+
+// reRenderTarget renders the file or rules target named name at dest
+// again, for 'axon undo' of an unlink.
+func reRenderTarget(name, dest string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	t, ok := findTarget(cfg, name)
+	if !ok {
+		return fmt.Errorf("target %q is no longer in axon.yaml", name)
+	}
+	if expanded, err := config.ExpandPath(t.Destination); err != nil || expanded != dest {
+		return fmt.Errorf("target %q no longer renders to %s", name, dest)
+	}
+	if state, detail, _ := linkTarget(cfg, t, linkOptions{}); state == "error" {
+		return errors.New(detail)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// setupFileTarget adds instructions/AGENTS.md and instructions/claude.md to
+// a link test Hub and returns a file target linking AGENTS.md to
+// dest/CLAUDE.md.
+func setupFileTarget(t *testing.T) (*config.Config, config.Target) {
+	t.Helper()
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	dir := filepath.Join(cfg.RepoPath, "instructions")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "AGENTS.md"), []byte("You are working with {{.tool}} on {{.project}}.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "claude.md"), []byte("Claude only.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmp, "dest"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ft := config.Target{
+		Name:        "claude-code-instructions",
+		Source:      "instructions/AGENTS.md",
+		Destination: filepath.Join(tmp, "dest", "CLAUDE.md"),
+		Type:        config.TargetTypeFile,
+	}
+	cfg.Targets = []config.Target{ft}
+	return cfg, ft
+}
+
+func TestLinkFileTarget_Symlink(t *testing.T) {
+	cfg, ft := setupFileTarget(t)
+	if err := os.WriteFile(ft.Destination, []byte("my own notes"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, _, err := linkTargets(cfg, cfg.Targets, linkOptions{})
+	if err != nil {
+		t.Fatalf("linkTargets: %v", err)
+	}
+	if results[0].state != "backed_up" {
+		t.Errorf("state = %s, want backed_up (the user's file is kept)", results[0].state)
+	}
+	src := filepath.Join(cfg.RepoPath, "instructions", "AGENTS.md")
+	if raw, _ := os.Readlink(ft.Destination); raw != src {
+		t.Errorf("%s → %s, want %s", ft.Destination, raw, src)
+	}
+	if state, _, _ := linkTarget(cfg, ft, linkOptions{}); state != "already" {
+		t.Errorf("second link: state = %s, want already", state)
+	}
+}
+
+func TestLinkFileTarget_Rendered(t *testing.T) {
+	cfg, ft := setupFileTarget(t)
+	ft.Vars = map[string]string{"project": "axon"}
+	ft.Append = []string{"instructions/claude.md"}
+	cfg.Targets = []config.Target{ft}
+
+	if _, _, err := linkTargets(cfg, cfg.Targets, linkOptions{}); err != nil {
+		t.Fatalf("linkTargets: %v", err)
+	}
+	data, err := os.ReadFile(ft.Destination)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{"You are working with claude-code on axon.", "Claude only."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rendered file lacks %q:\n%s", want, got)
+		}
+	}
+	src := filepath.Join(cfg.RepoPath, "instructions", "AGENTS.md")
+	if marked, ok := copyMarkerSource(ft.Destination); !ok || marked != src {
+		t.Errorf("copyMarkerSource = %q, %v; want %s", marked, ok, src)
+	}
+	if state, _, _ := linkTarget(cfg, ft, linkOptions{}); state != "already" {
+		t.Errorf("second link: state = %s, want already", state)
+	}
+
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "instructions", "claude.md"), []byte("Changed.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if h := collectLinkHealth(cfg); len(h.drift) != 1 {
+		t.Errorf("status should report the stale rendered file, drift = %v", h.drift)
+	}
+	if state, _, _ := linkTarget(cfg, ft, linkOptions{}); state != "refreshed" {
+		t.Errorf("link after a Hub change: state = %s, want refreshed", state)
+	}
+
+	ft.Vars = map[string]string{"project": "axon"}
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "instructions", "claude.md"), []byte("{{.nope}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if reason := planLinkTarget(cfg, ft, linkOptions{}); !strings.Contains(reason, "nope") {
+		t.Errorf("an unknown template key should be refused, got %q", reason)
+	}
+}
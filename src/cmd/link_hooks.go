@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// Target hooks, run after 'axon link' or 'axon unlink' changed a target's
+// destination.
+const (
+	hookPostLink   = "post_link"
+	hookPostUnlink = "post_unlink"
+)
+
+// hookTimeout bounds a single hook command.
+const hookTimeout = time.Minute
+
+// targetHook returns t's command for hook, or "".
+func targetHook(t config.Target, hook string) string {
+	if hook == hookPostLink {
+		return t.PostLink
+	}
+	return t.PostUnlink
+}
+
+// runTargetHooks runs hook for each target whose name is in changed and
+// prints the outcome. A failing hook is reported but does not undo the link
+// or unlink. Hooks from a project's .axon.yaml are not run, so cloning a
+// repository cannot make axon execute its commands.
+func runTargetHooks(cfg *config.Config, targets []config.Target, changed map[string]bool, hook string) {
+	for _, t := range targets {
+		command := strings.TrimSpace(targetHook(t, hook))
+		if command == "" || !changed[t.Name] {
+			continue
+		}
+		if t.Project != "" {
+			printWarn(t.Name, fmt.Sprintf("%s ignored: hooks in %s are not run", hook, config.ProjectFileName))
+			continue
+		}
+		out, err := runTargetHook(cfg, t, hook, command)
+		if err != nil {
+			printWarn(t.Name, fmt.Sprintf("%s failed: %s", hook, firstLine(out, err)))
+			continue
+		}
+		printOK(t.Name, hook+" ran")
+	}
+}
+
+// runTargetHook runs command through the shell with the target described
+// in the environment, from the destination's parent directory, and returns
+// its combined output.
+func runTargetHook(cfg *config.Config, t config.Target, hook, command string) (string, error) {
+	dest, err := config.ExpandPath(t.Destination)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	c.WaitDelay = 2 * time.Second
+	c.Dir = filepath.Dir(dest)
+	c.Env = append(os.Environ(),
+		"AXON_HOOK="+hook,
+		"AXON_TARGET="+t.Name,
+		"AXON_DEST="+dest,
+		"AXON_SOURCE="+filepath.Join(cfg.RepoPath, t.Source),
+		"AXON_HUB="+cfg.RepoPath,
+	)
+	out, err := c.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("timed out after %s", hookTimeout)
+	}
+	return strings.TrimSpace(string(out)), err
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestRunTargetHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	cfg, tmp := setupLinkTest(t)
+	out := filepath.Join(tmp, "hook.out")
+	cfg.Targets[0].PostLink = `echo "$AXON_HOOK $AXON_TARGET $AXON_DEST" > ` + out
+	if err := os.MkdirAll(filepath.Join(tmp, "dest"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	runTargetHooks(cfg, cfg.Targets, map[string]bool{}, hookPostLink)
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Fatal("hook ran for an unchanged target")
+	}
+
+	changed := map[string]bool{"test-skills": true}
+	runTargetHooks(cfg, cfg.Targets, changed, hookPostLink)
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	want := "post_link test-skills " + cfg.Targets[0].Destination
+	if got := strings.TrimSpace(string(data)); got != want {
+		t.Errorf("hook env = %q, want %q", got, want)
+	}
+
+	// Hooks from a project's .axon.yaml never run.
+	os.Remove(out)
+	project := cfg.Targets[0]
+	project.Project = tmp
+	runTargetHooks(cfg, []config.Target{project}, changed, hookPostLink)
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Error("project hook ran")
+	}
+
+	if _, err := runTargetHook(cfg, cfg.Targets[0], hookPostUnlink, "echo broken >&2; exit 3"); err == nil {
+		t.Error("failing hook: err = nil")
+	}
+}
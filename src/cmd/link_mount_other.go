@@ -0,0 +1,27 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// isMountPoint reports whether dest sits on a different device than its
+// parent directory, i.e. something is mounted there.
+func isMountPoint(dest string, info os.FileInfo) bool {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false
+	}
+	parent, err := os.Stat(filepath.Dir(dest))
+	if err != nil {
+		return false
+	}
+	pst, ok := parent.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false
+	}
+	return st.Dev != pst.Dev
+}
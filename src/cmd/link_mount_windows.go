@@ -0,0 +1,11 @@
+//go:build windows
+
+package cmd
+
+import "os"
+
+// isMountPoint is not detected on Windows; mounted folders show up as
+// reparse points, which link already treats as symlinks.
+func isMountPoint(_ string, _ os.FileInfo) bool {
+	return false
+}
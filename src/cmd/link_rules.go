@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/rules"
+)
+
+// ── Rules targets ─────────────────────────────────────────────────────────────
+// A 'type: rules' target keeps canonical rules (rules/*.md) in the Hub and
+// renders them into one tool's format instead of symlinking: Cursor .mdc
+// files, Windsurf rules, or a single Claude memory file. A rendered
+// directory carries the copy marker and a rendered memory file the marker
+// line of file targets, so status, unlink, and undo handle both as copies.
+
+// renderRules renders t's rules: file name → content, or the single file
+// under "" for single-file formats. Rules come from every repo t draws from;
+// for each name the highest-priority repo wins, as in a merged view.
+func renderRules(cfg *config.Config, t config.Target) (map[string][]byte, error) {
+	repos, err := cfg.TargetRepos(t)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planMergedView(t.Source, repos, "", "")
+	if err != nil {
+		return nil, err
+	}
+	var list []rules.Rule
+	for _, it := range plan.Items {
+		if !strings.EqualFold(filepath.Ext(it.Name), ".md") {
+			continue
+		}
+		info, err := fsys.Stat(it.Path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		data, err := fsys.ReadFile(it.Path)
+		if err != nil {
+			return nil, err
+		}
+		r, err := rules.Parse(it.Name, data)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+	return rules.Render(t.Format, list)
+}
+
+// renderedRulesFile returns the content of a single-file rules target whose
+// Hub source is source.
+func renderedRulesFile(files map[string][]byte, source string) []byte {
+	return append([]byte(renderedMarkerPrefix+source+renderedMarkerSuffix+"\n\n"), files[""]...)
+}
+
+// linkRulesTarget is linkTarget for rules targets. A directory format
+// follows the copy mode policy, a single-file format the file target one.
+func linkRulesTarget(cfg *config.Config, t config.Target, dest string, opts linkOptions) (state, detail, notInstalled string) {
+	if _, err := fsys.Stat(filepath.Dir(dest)); os.IsNotExist(err) {
+		return "", "", toolBaseName(t.Name)
+	}
+	source, _, err := targetLinkSource(cfg, t)
+	if err != nil {
+		return "error", err.Error(), ""
+	}
+	files, err := renderRules(cfg, t)
+	if err != nil {
+		return "error", err.Error(), ""
+	}
+	desc := fmt.Sprintf("%s rendered from %s (%s)", dest, source, t.Format)
+	if rules.SingleFile(t.Format) {
+		return writeRenderedFile(cfg, t, dest, renderedRulesFile(files, source), desc, opts)
+	}
+
+	install := func() error {
+		if err := fsys.MkdirAll(dest, 0o755); err != nil {
+			return fmt.Errorf("cannot create %s: %w", dest, err)
+		}
+		if _, err := syncRenderedDir(dest, files, false); err != nil {
+			return err
+		}
+		return writeCopyMarker(source, dest)
+	}
+	info, lstatErr := fsys.Lstat(dest)
+	switch {
+	case os.IsNotExist(lstatErr):
+		if err := install(); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "linked", desc, ""
+	case lstatErr != nil:
+		return "error", fmt.Sprintf("stat: %v", lstatErr), ""
+	case info.Mode()&os.ModeSymlink != 0:
+		current, _ := fsys.Readlink(dest)
+		if err := fsys.Remove(dest); err != nil {
+			return "error", fmt.Sprintf("cannot remove old symlink: %v", err), ""
+		}
+		if err := install(); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "relinked", fmt.Sprintf("was → %s, now rendered %s rules", current, t.Format), ""
+	case isMountPoint(dest, info):
+		return "error", mountPointRefusal(dest), ""
+	case !info.IsDir():
+		if !opts.force {
+			return "error", nonDirectoryRefusal(dest, info), ""
+		}
+		return replaceDestination(cfg, t, dest, opts, install, desc)
+	}
+
+	if marked, ok := copyMarkerSource(dest); ok {
+		n, err := syncRenderedDir(dest, files, false)
+		if err != nil {
+			return "error", err.Error(), ""
+		}
+		if n == 0 && marked == source {
+			return "already", "", ""
+		}
+		if err := writeCopyMarker(source, dest); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "refreshed", fmt.Sprintf("rules updated (%d change(s))", n), ""
+	}
+	if entries, err := fsys.ReadDir(dest); err == nil && len(entries) == 0 {
+		if err := install(); err != nil {
+			return "error", err.Error(), ""
+		}
+		return "linked", desc, ""
+	}
+	return replaceDestination(cfg, t, dest, opts, install, desc)
+}
+
+// planRulesTarget is planLinkTarget for rules targets.
+func planRulesTarget(cfg *config.Config, t config.Target, dest string, opts linkOptions) string {
+	if _, err := fsys.Stat(filepath.Dir(dest)); os.IsNotExist(err) {
+		return ""
+	}
+	if _, err := renderRules(cfg, t); err != nil {
+		return err.Error()
+	}
+	info, err := fsys.Lstat(dest)
+	switch {
+	case os.IsNotExist(err):
+		return ""
+	case err != nil:
+		return fmt.Sprintf("stat: %v", err)
+	case info.Mode()&os.ModeSymlink != 0:
+		return ""
+	case isMountPoint(dest, info):
+		return mountPointRefusal(dest)
+	case opts.force:
+		return ""
+	case rules.SingleFile(t.Format) && !info.Mode().IsRegular():
+		return nonFileRefusal(dest, info)
+	case !rules.SingleFile(t.Format) && !info.IsDir():
+		return nonDirectoryRefusal(dest, info)
+	}
+	return ""
+}
+
+// syncRenderedDir makes the directory dest hold exactly files (plus the copy
+// marker) and returns the number of files it wrote or removed; with dryRun
+// it only counts them.
+func syncRenderedDir(dest string, files map[string][]byte, dryRun bool) (int, error) {
+	changes := 0
+	for name, data := range files {
+		p := filepath.Join(dest, name)
+		if current, err := fsys.ReadFile(p); err == nil && bytes.Equal(current, data) {
+			continue
+		}
+		changes++
+		if dryRun {
+			continue
+		}
+		if err := fsys.WriteFile(p, data, 0o644); err != nil {
+			return changes, err
+		}
+	}
+	entries, err := fsys.ReadDir(dest)
+	if err != nil && !os.IsNotExist(err) {
+		return changes, err
+	}
+	for _, e := range entries {
+		if _, ok := files[e.Name()]; ok || e.Name() == copyMarkerName {
+			continue
+		}
+		changes++
+		if !dryRun {
+			if err := fsys.RemoveAll(filepath.Join(dest, e.Name())); err != nil {
+				return changes, err
+			}
+		}
+	}
+	return changes, nil
+}
+
+// rulesDrift counts the changes 'axon link' would make to the rendered
+// rules at dest.
+func rulesDrift(cfg *config.Config, t config.Target, dest, source string) (int, error) {
+	files, err := renderRules(cfg, t)
+	if err != nil {
+		return 0, err
+	}
+	if !rules.SingleFile(t.Format) {
+		return syncRenderedDir(dest, files, true)
+	}
+	got, err := fsys.ReadFile(dest)
+	if err != nil {
+		return 0, err
+	}
+	if bytes.Equal(got, renderedRulesFile(files, source)) {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// refreshRenderedTargets re-renders the rules and file targets already
+// rendered on this machine, e.g. after 'axon sync' pulled Hub changes.
+// Destinations that are not axon renderings are left for 'axon link'.
+func refreshRenderedTargets(cfg *config.Config) {
+	for _, t := range cfg.Targets {
+		if !t.IsRules() && !t.IsFile() {
+			continue
+		}
+		dest, err := config.ExpandPath(t.Destination)
+		if err != nil {
+			continue
+		}
+		if _, ok := copyMarkerSource(dest); !ok {
+			continue
+		}
+		switch state, detail, _ := linkTarget(cfg, t, linkOptions{}); state {
+		case "refreshed":
+			printOK(t.Name, detail)
+		case "error":
+			printWarn(t.Name, "cannot refresh: "+detail)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestLinkRulesTarget(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	rulesDir := filepath.Join(cfg.RepoPath, "rules")
+	if err := os.MkdirAll(rulesDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, "pnpm.md"), []byte("---\ndescription: Use pnpm\nglobs: [\"**/*.ts\"]\n---\nAlways run pnpm.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []string{"cursor", "claude"} {
+		if err := os.MkdirAll(filepath.Join(tmp, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cursor := config.Target{Name: "cursor-rules", Source: "rules", Destination: filepath.Join(tmp, "cursor", "rules"), Type: config.TargetTypeRules, Format: "cursor"}
+	claude := config.Target{Name: "claude-code-rules", Source: "rules", Destination: filepath.Join(tmp, "claude", "CLAUDE.md"), Type: config.TargetTypeRules, Format: "claude"}
+	cfg.Targets = []config.Target{cursor, claude}
+
+	if _, _, err := linkTargets(cfg, cfg.Targets, linkOptions{}); err != nil {
+		t.Fatalf("linkTargets: %v", err)
+	}
+	mdc, err := os.ReadFile(filepath.Join(cursor.Destination, "pnpm.mdc"))
+	if err != nil || !strings.Contains(string(mdc), "globs: **/*.ts\n") {
+		t.Errorf("cursor rule = %q, %v", mdc, err)
+	}
+	memory, err := os.ReadFile(claude.Destination)
+	if err != nil || !strings.Contains(string(memory), "## pnpm") {
+		t.Errorf("claude memory = %q, %v", memory, err)
+	}
+	for _, tg := range cfg.Targets {
+		if state, _, _ := linkTarget(cfg, tg, linkOptions{}); state != "already" {
+			t.Errorf("%s: second link state = %s, want already", tg.Name, state)
+		}
+	}
+
+	// A rule removed from the Hub disappears from the tool on the next refresh.
+	if err := os.Rename(filepath.Join(rulesDir, "pnpm.md"), filepath.Join(rulesDir, "yarn.md")); err != nil {
+		t.Fatal(err)
+	}
+	if h := collectLinkHealth(cfg); len(h.drift) != 2 {
+		t.Errorf("status should report both renderings as stale, drift = %v", h.drift)
+	}
+	refreshRenderedTargets(cfg)
+	if _, err := os.Stat(filepath.Join(cursor.Destination, "pnpm.mdc")); !os.IsNotExist(err) {
+		t.Errorf("pnpm.mdc should be gone after the refresh, stat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cursor.Destination, "yarn.mdc")); err != nil {
+		t.Errorf("yarn.mdc should be rendered: %v", err)
+	}
+}
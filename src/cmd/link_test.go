@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kamusis/axon-cli/internal/config"
@@ -36,7 +37,7 @@ func setupLinkTest(t *testing.T) (*config.Config, string) {
 // callLinkTarget wraps the new linkTarget signature into an error return
 // for test readability.
 func callLinkTarget(cfg *config.Config, t config.Target) error {
-	state, detail, _ := linkTarget(cfg, t)
+	state, detail, _ := linkTarget(cfg, t, linkOptions{})
 	if state == "error" {
 		return &linkErr{detail}
 	}
@@ -78,7 +79,7 @@ func TestLinkTarget_ParentMissing(t *testing.T) {
 	cfg, _ := setupLinkTest(t)
 	// dest parent (~/.cursor/) does not exist — tool not installed.
 	// linkTarget should skip gracefully without creating any directories.
-	state, _, notInstalled := linkTarget(cfg, cfg.Targets[0])
+	state, _, notInstalled := linkTarget(cfg, cfg.Targets[0], linkOptions{})
 	if state == "error" {
 		t.Fatalf("unexpected error state")
 	}
@@ -104,7 +105,7 @@ func TestLinkTarget_AlreadyCorrect(t *testing.T) {
 	}
 
 	// Should be a no-op; symlink must remain unchanged.
-	state, _, _ := linkTarget(cfg, cfg.Targets[0])
+	state, _, _ := linkTarget(cfg, cfg.Targets[0], linkOptions{})
 	if state != "already" {
 		t.Errorf("expected state 'already', got %q", state)
 	}
@@ -182,3 +183,168 @@ func TestLinkTarget_EmptyDir(t *testing.T) {
 		t.Error("dest should be a symlink after empty-dir removal")
 	}
 }
+
+func TestLinkTarget_FileRequiresForce(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	dest := cfg.Targets[0].Destination
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dest, []byte("stray file"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	state, detail, _ := linkTarget(cfg, cfg.Targets[0], linkOptions{})
+	if state != "error" || !strings.Contains(detail, "--force") {
+		t.Fatalf("state = %q (%s), want a refusal mentioning --force", state, detail)
+	}
+	if info, err := os.Lstat(dest); err != nil || !info.Mode().IsRegular() {
+		t.Fatalf("file should be untouched, got %v, %v", info, err)
+	}
+
+	state, _, _ = linkTarget(cfg, cfg.Targets[0], linkOptions{force: true})
+	if state != "backed_up" {
+		t.Fatalf("state = %q, want backed_up", state)
+	}
+	bkp, err := latestBackup(cfg, cfg.Targets[0].Name)
+	if err != nil || bkp == "" {
+		t.Fatalf("latestBackup = %q, %v", bkp, err)
+	}
+	if data, _ := os.ReadFile(bkp); string(data) != "stray file" {
+		t.Errorf("backup content = %q", data)
+	}
+	if target, _ := os.Readlink(dest); target != filepath.Join(cfg.RepoPath, "skills") {
+		t.Errorf("symlink → %s", target)
+	}
+}
+
+func TestLinkTarget_NoBackupDeletes(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	dest := cfg.Targets[0].Destination
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dest, "old.md"), []byte("throwaway"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	state, _, _ := linkTarget(cfg, cfg.Targets[0], linkOptions{noBackup: true})
+	if state != "replaced" {
+		t.Fatalf("state = %q, want replaced", state)
+	}
+	if bkp, _ := latestBackup(cfg, cfg.Targets[0].Name); bkp != "" {
+		t.Errorf("no backup expected, found %s", bkp)
+	}
+	if info, err := os.Lstat(dest); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("dest should be a symlink, got %v, %v", info, err)
+	}
+}
+
+func TestLinkTarget_RelativeStyle(t *testing.T) {
+	cfg, _ := setupLinkTest(t)
+	cfg.LinkStyle = config.LinkStyleRelative
+	dest := cfg.Targets[0].Destination
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if state, detail, _ := linkTarget(cfg, cfg.Targets[0], linkOptions{}); state != "linked" {
+		t.Fatalf("state = %q (%s), want linked", state, detail)
+	}
+	raw, _ := os.Readlink(dest)
+	if want := filepath.Join("..", "hub", "skills"); raw != want {
+		t.Errorf("symlink → %q, want %q", raw, want)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "sentinel.md")); err != nil {
+		t.Errorf("relative link does not resolve: %v", err)
+	}
+	if state, _, _ := linkTarget(cfg, cfg.Targets[0], linkOptions{}); state != "already" {
+		t.Errorf("second run state = %q, want already", state)
+	}
+
+	// Switching back to absolute re-creates the link in the new style.
+	cfg.LinkStyle = ""
+	if state, _, _ := linkTarget(cfg, cfg.Targets[0], linkOptions{}); state != "relinked" {
+		t.Errorf("state after style change = %q, want relinked", state)
+	}
+	if raw, _ := os.Readlink(dest); raw != filepath.Join(cfg.RepoPath, "skills") {
+		t.Errorf("symlink → %q, want absolute", raw)
+	}
+}
+
+func TestLinkTarget_CopyMode(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	cfg.Targets[0].Mode = config.LinkModeCopy
+	target := cfg.Targets[0]
+	dest := target.Destination
+	hubPath := filepath.Join(cfg.RepoPath, "skills")
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if state, detail, _ := linkTarget(cfg, target, linkOptions{}); state != "linked" {
+		t.Fatalf("state = %q (%s), want linked", state, detail)
+	}
+	info, err := os.Lstat(dest)
+	if err != nil || !info.IsDir() {
+		t.Fatalf("dest should be a real directory, got %v, %v", info, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dest, "sentinel.md")); string(data) != "hub content" {
+		t.Errorf("copied content = %q", data)
+	}
+	if src, ok := copyMarkerSource(dest); !ok || src != hubPath {
+		t.Errorf("marker = %q, %v", src, ok)
+	}
+	if state, _, _ := linkTarget(cfg, target, linkOptions{}); state != "already" {
+		t.Errorf("second run state = %q, want already", state)
+	}
+
+	// Hub changes show up as drift and are mirrored on the next link.
+	if err := os.WriteFile(filepath.Join(hubPath, "new.md"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(hubPath, "sentinel.md")); err != nil {
+		t.Fatal(err)
+	}
+	h := collectLinkHealth(cfg)
+	if len(h.linked) != 1 || len(h.drift) != 1 {
+		t.Errorf("health = %+v, want linked with drift", h)
+	}
+	if state, _, _ := linkTarget(cfg, target, linkOptions{}); state != "refreshed" {
+		t.Errorf("state = %q, want refreshed", state)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "sentinel.md")); !os.IsNotExist(err) {
+		t.Errorf("removed Hub file should be gone from the copy, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "new.md")); err != nil {
+		t.Errorf("new Hub file missing from the copy: %v", err)
+	}
+}
+
+func TestLinkTargets_NestedSource(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	if err := os.MkdirAll(filepath.Join(tmp, "dest"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	nested := config.Target{Name: "test-db", Source: "skills/db-only", Destination: filepath.Join(tmp, "dest", "db"), Type: "directory"}
+	cfg.Targets = append(cfg.Targets, nested)
+
+	if _, _, err := linkTargets(cfg, cfg.Targets, linkOptions{}); err == nil {
+		t.Fatal("linking a missing nested source should be refused")
+	}
+	if _, err := os.Lstat(cfg.Targets[0].Destination); !os.IsNotExist(err) {
+		t.Errorf("nothing should be linked after the refusal, lstat err = %v", err)
+	}
+
+	if _, _, err := linkTargets(cfg, cfg.Targets, linkOptions{createSources: true}); err != nil {
+		t.Fatalf("linkTargets --create-sources: %v", err)
+	}
+	src := filepath.Join(cfg.RepoPath, "skills", "db-only")
+	if raw, _ := os.Readlink(nested.Destination); raw != src {
+		t.Errorf("%s → %s, want %s", nested.Destination, raw, src)
+	}
+}
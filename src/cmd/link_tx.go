@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
+)
+
+// linkResult is the outcome of linking one target.
+type linkResult struct {
+	name   string
+	state  string // "linked","already","relinked","backed_up","replaced","refreshed"
+	detail string
+}
+
+// linkTargets links targets as one transaction. Every target is checked
+// first and nothing is touched if any of them would be refused. If a target
+// then fails anyway, the changes already made for the other targets are
+// rolled back and the rollback is journaled. On success the changes are
+// journaled together, so 'axon undo' reverts them as a unit.
+//
+// Tools whose parent directory does not exist are skipped and returned in
+// notInstalled.
+func linkTargets(cfg *config.Config, targets []config.Target, opts linkOptions) (results []linkResult, notInstalled map[string]bool, err error) {
+	// ── Plan ──────────────────────────────────────────────────────────────────
+	var refused int
+	for _, t := range targets {
+		if reason := planLinkTarget(cfg, t, opts); reason != "" {
+			printErr(t.Name, reason)
+			refused++
+		}
+	}
+	if refused > 0 {
+		return nil, nil, fmt.Errorf("%d target(s) cannot be linked; nothing was changed", refused)
+	}
+
+	// ── Apply ─────────────────────────────────────────────────────────────────
+	notInstalled = make(map[string]bool)
+	var tx linkTransaction
+	for _, t := range targets {
+		previous := currentLinkTarget(t)
+		state, detail, tool := linkTarget(cfg, t, opts)
+		if tool != "" {
+			notInstalled[tool] = true
+			continue
+		}
+		if state == "error" {
+			printErr(t.Name, detail)
+			if len(tx.entries) == 0 {
+				return nil, nil, fmt.Errorf("link failed")
+			}
+			if stuck := tx.rollback(t.Name, detail); len(stuck) > 0 {
+				return nil, nil, fmt.Errorf("link failed for %s and rollback failed for %s; see 'axon history'",
+					t.Name, strings.Join(stuck, ", "))
+			}
+			return nil, nil, fmt.Errorf("link failed for %s; rolled back %d other target(s)", t.Name, len(tx.entries))
+		}
+		results = append(results, linkResult{t.Name, state, detail})
+		tx.add(cfg, t, state, previous)
+	}
+	tx.commit()
+	return results, notInstalled, nil
+}
+
+// planLinkTarget checks, without changing anything, whether linkTarget
+// would refuse t. It returns the reason, or "" when t can be linked (or is
+// skipped as not installed).
+func planLinkTarget(cfg *config.Config, t config.Target, opts linkOptions) string {
+	dest, err := config.ExpandPath(t.Destination)
+	if errors.Is(err, config.ErrNoWindowsHome) {
+		return ""
+	}
+	if err != nil {
+		return err.Error()
+	}
+	if real, inHub := destParentInHub(cfg, dest); inHub {
+		return destInHubRefusal(dest, real)
+	}
+	if !opts.force {
+		if reason := targetOverlapRefusal(cfg, t); reason != "" {
+			return reason
+		}
+	}
+	if t.IsFile() {
+		return planFileTarget(cfg, t, dest, opts)
+	}
+	if t.IsRules() {
+		return planRulesTarget(cfg, t, dest, opts)
+	}
+	if _, _, err := targetLinkSource(cfg, t); err != nil {
+		return err.Error()
+	}
+	if err := missingTargetSource(cfg, t); err != nil && !opts.createSources {
+		if _, perr := fsys.Stat(filepath.Dir(dest)); !os.IsNotExist(perr) {
+			return err.Error()
+		}
+	}
+	info, err := fsys.Lstat(dest)
+	if os.IsNotExist(err) {
+		return ""
+	}
+	if err != nil {
+		return fmt.Sprintf("stat: %v", err)
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return ""
+	}
+	if isMountPoint(dest, info) {
+		return mountPointRefusal(dest)
+	}
+	if !info.IsDir() && !opts.force {
+		return nonDirectoryRefusal(dest, info)
+	}
+	return ""
+}
+
+// linkTransaction holds the journal entries of the changes one 'axon link'
+// run has made so far. The entries carry what 'axon undo' needs, so the
+// same reversal rolls back a failed run.
+type linkTransaction struct {
+	entries []journal.Entry
+}
+
+func (tx *linkTransaction) add(cfg *config.Config, t config.Target, state, previous string) {
+	if e, ok := linkJournalEntry(cfg, t, state, previous); ok {
+		tx.entries = append(tx.entries, e)
+	}
+}
+
+// commit journals the changes.
+func (tx *linkTransaction) commit() {
+	for _, e := range tx.entries {
+		recordHistory(e)
+	}
+}
+
+// rollback reverts the changes newest first once the target named failed
+// could not be linked, journals the rollback with reason, and returns the
+// targets it could not revert.
+func (tx *linkTransaction) rollback(failed, reason string) (stuck []string) {
+	printBullet("Rolling back:")
+	var reverted []string
+	for i := len(tx.entries) - 1; i >= 0; i-- {
+		e := tx.entries[i]
+		step, err := planUndoLink(e)
+		if err == nil {
+			err = step.apply()
+		}
+		if err != nil {
+			printErr(e.Target, "rollback failed: "+err.Error())
+			stuck = append(stuck, e.Target)
+			continue
+		}
+		printRestore(e.Target, "restored "+e.Data["dest"])
+		reverted = append(reverted, e.Target)
+	}
+
+	data := map[string]string{"error": reason, "reverted": strings.Join(reverted, ",")}
+	if len(stuck) > 0 {
+		data["stuck"] = strings.Join(stuck, ",")
+	}
+	recordHistory(journal.Entry{
+		Op:     journal.OpLinkRollback,
+		Target: failed,
+		Detail: fmt.Sprintf("%s failed; rolled back %d of %d target(s)", failed, len(reverted), len(tx.entries)),
+		Data:   data,
+	})
+	return stuck
+}
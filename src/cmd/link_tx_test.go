@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
+)
+
+func TestLinkTargets_RefusalChangesNothing(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	first := cfg.Targets[0]
+	if err := os.MkdirAll(filepath.Dir(first.Destination), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(tmp, "dest", "file-target")
+	if err := os.WriteFile(file, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Targets = append(cfg.Targets, config.Target{Name: "z-file", Source: "skills", Destination: file})
+
+	_, _, err := linkTargets(cfg, cfg.Targets, linkOptions{})
+	if err == nil || !strings.Contains(err.Error(), "nothing was changed") {
+		t.Fatalf("err = %v, want refusal before any change", err)
+	}
+	if _, err := os.Lstat(first.Destination); !os.IsNotExist(err) {
+		t.Errorf("first target was linked despite the refusal: %v", err)
+	}
+}
+
+func TestLinkTargets_RollsBackOnFailure(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	first := cfg.Targets[0]
+	if err := os.MkdirAll(first.Destination, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(first.Destination, "local.md"), []byte("local"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// The second target's Hub source is a file, so creating it fails only
+	// once linking is under way.
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "broken"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Targets = append(cfg.Targets, config.Target{Name: "z-broken", Source: "broken", Destination: filepath.Join(tmp, "dest", "broken")})
+
+	_, _, err := linkTargets(cfg, cfg.Targets, linkOptions{})
+	if err == nil || !strings.Contains(err.Error(), "rolled back 1") {
+		t.Fatalf("err = %v, want rollback of the first target", err)
+	}
+
+	info, err := os.Lstat(first.Destination)
+	if err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Fatalf("first destination should be the original directory again: %v, %v", info, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(first.Destination, "local.md")); string(data) != "local" {
+		t.Errorf("original content not restored: %q", data)
+	}
+
+	path, _ := journal.Path()
+	entries, _ := journal.Read(path, time.Time{})
+	if len(entries) != 1 || entries[0].Op != journal.OpLinkRollback || entries[0].Target != "z-broken" {
+		t.Fatalf("journal = %+v, want a single link-rollback entry", entries)
+	}
+	if entries[0].Data["reverted"] != first.Name {
+		t.Errorf("reverted = %q, want %q", entries[0].Data["reverted"], first.Name)
+	}
+}
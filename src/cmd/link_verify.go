@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// maxLinkHops is how many symlinks a destination may resolve through before
+// status warns. The OS gives up at 40 (Linux) or 32 (macOS), and every
+// program reading the tool's directory pays for each hop.
+const maxLinkHops = 8
+
+// errLinkLoop is returned by linkChain for a symlink that leads back to
+// itself.
+var errLinkLoop = errors.New("symlink loop")
+
+// destParentInHub reports whether the directory holding dest resolves into a
+// Hub repo, typically because a parent of dest is itself a symlink into the
+// Hub. A link made there would be written into the Hub and could point at
+// itself. real is the resolved directory.
+func destParentInHub(cfg *config.Config, dest string) (real string, ok bool) {
+	real, err := filepath.EvalSymlinks(filepath.Dir(dest))
+	if err != nil {
+		return "", false
+	}
+	for _, r := range cfg.HubRepos() {
+		root, err := filepath.EvalSymlinks(r.Path)
+		if err != nil {
+			root = r.Path
+		}
+		if pathWithin(root, real) {
+			return real, true
+		}
+	}
+	return "", false
+}
+
+// destInHubRefusal explains why a destination inside the Hub is not linked.
+func destInHubRefusal(dest, real string) string {
+	return fmt.Sprintf("%s is inside the Hub (its directory resolves to %s), so linking it would write into the Hub and can create a symlink loop — point the destination outside the Hub, or replace the symlinked parent directory with a real one", dest, real)
+}
+
+// targetOverlapRefusal explains why t is not linked when its destination
+// overlaps another target's: linking both would make each run undo the
+// other. It returns "" when t overlaps no other target.
+func targetOverlapRefusal(cfg *config.Config, t config.Target) string {
+	for _, o := range cfg.Overlaps() {
+		if o.Involves(t.Name) {
+			return o.String() + ", so each link would undo the other — fix the destinations in axon.yaml, or use --force to link anyway"
+		}
+	}
+	return ""
+}
+
+// linkChain follows the symlink at path one hop at a time and returns every
+// link on the way, path first. It fails with errLinkLoop when a link is
+// reached twice.
+func linkChain(path string) ([]string, error) {
+	var chain []string
+	seen := make(map[string]bool)
+	p := filepath.Clean(path)
+	for {
+		info, err := fsys.Lstat(p)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			return chain, nil
+		}
+		if seen[p] {
+			return chain, errLinkLoop
+		}
+		seen[p] = true
+		chain = append(chain, p)
+		raw, err := fsys.Readlink(p)
+		if err != nil {
+			return chain, err
+		}
+		if !filepath.IsAbs(raw) {
+			dir := filepath.Dir(p)
+			if real, err := filepath.EvalSymlinks(dir); err == nil {
+				dir = real
+			}
+			raw = filepath.Join(dir, raw)
+		}
+		p = filepath.Clean(raw)
+	}
+}
+
+// linkChainProblem checks the symlink chain starting at dest. A loop is
+// broken; a chain longer than maxLinkHops still works and is only a warning.
+func linkChainProblem(dest string) (problem string, broken bool) {
+	chain, err := linkChain(dest)
+	if errors.Is(err, errLinkLoop) {
+		return fmt.Sprintf("symlink loop: %s leads back to itself — remove it and run 'axon link'", dest), true
+	}
+	if len(chain) > maxLinkHops {
+		return fmt.Sprintf("resolves through %d symlinks (more than %d) — shorten the chain, e.g. set repo_path to the real Hub directory, and run 'axon link'", len(chain), maxLinkHops), false
+	}
+	return "", false
+}
+
+// moveAside renames src to dst. When they are on different filesystems,
+// where rename cannot work, src is copied and then removed.
+func moveAside(src, dst string) error {
+	err := fsys.Rename(src, dst)
+	if err == nil || !isCrossDevice(err) {
+		return err
+	}
+	if err := copyTree(src, dst); err != nil {
+		_ = fsys.RemoveAll(dst)
+		return fmt.Errorf("copy across filesystems: %w", err)
+	}
+	if err := fsys.RemoveAll(src); err != nil {
+		return fmt.Errorf("copied to %s, but cannot remove the original: %w", dst, err)
+	}
+	return nil
+}
+
+// isCrossDevice reports whether err is a rename failing because source and
+// target are on different filesystems.
+func isCrossDevice(err error) bool {
+	if errors.Is(err, syscall.EXDEV) {
+		return true
+	}
+	// ERROR_NOT_SAME_DEVICE
+	return hostOS == "windows" && errors.Is(err, syscall.Errno(17))
+}
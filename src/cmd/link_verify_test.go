@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestLinkTarget_RefusesDestinationInsideHub(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	// The tool's directory is a symlink into the Hub, so the destination
+	// would land in skills/skills and point at its own parent.
+	tool := filepath.Join(tmp, "tool")
+	if err := os.Symlink(filepath.Join(cfg.RepoPath, "skills"), tool); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Targets[0].Destination = filepath.Join(tool, "skills")
+
+	err := callLinkTarget(cfg, cfg.Targets[0])
+	if err == nil || !strings.Contains(err.Error(), "inside the Hub") {
+		t.Fatalf("linkTarget = %v, want a refusal", err)
+	}
+	if _, err := os.Lstat(filepath.Join(cfg.RepoPath, "skills", "skills")); !os.IsNotExist(err) {
+		t.Error("a link was written into the Hub")
+	}
+	h := collectLinkHealth(cfg)
+	if len(h.broken) != 1 || !strings.Contains(h.broken[0].msg, "inside the Hub") {
+		t.Errorf("status broken = %+v", h.broken)
+	}
+}
+
+func TestLinkChainProblem(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	if err := os.Symlink(b, a); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("a", b); err != nil {
+		t.Fatal(err)
+	}
+	if msg, broken := linkChainProblem(a); !broken || !strings.Contains(msg, "loop") {
+		t.Errorf("loop: %q, %v", msg, broken)
+	}
+
+	real := filepath.Join(dir, "real")
+	if err := os.Mkdir(real, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	prev := real
+	for i := 0; i <= maxLinkHops; i++ {
+		p := filepath.Join(dir, fmt.Sprintf("hop%d", i))
+		if err := os.Symlink(prev, p); err != nil {
+			t.Fatal(err)
+		}
+		prev = p
+	}
+	if msg, broken := linkChainProblem(prev); broken || !strings.Contains(msg, "symlinks") {
+		t.Errorf("deep chain: %q, %v", msg, broken)
+	}
+	if msg, _ := linkChainProblem(filepath.Join(dir, "hop0")); msg != "" {
+		t.Errorf("single link reported: %q", msg)
+	}
+}
+
+func TestMoveAside(t *testing.T) {
+	dir := t.TempDir()
+	src, dst := filepath.Join(dir, "src"), filepath.Join(dir, "dst")
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "sub", "f"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := moveAside(src, dst); err != nil {
+		t.Fatalf("moveAside: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "sub", "f")); err != nil {
+		t.Errorf("moved file: %v", err)
+	}
+	if err := moveAside(src, dst); err == nil {
+		t.Error("moving a missing directory succeeded")
+	}
+
+	xdev := &os.LinkError{Op: "rename", Old: src, New: dst, Err: syscall.EXDEV}
+	if !isCrossDevice(xdev) || isCrossDevice(errors.New("other")) {
+		t.Error("isCrossDevice misclassified")
+	}
+}
+
+func TestPlanLinkTarget_RefusesOverlappingTargets(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	outer := config.Target{
+		Name:        "claude-home",
+		Source:      "",
+		Destination: filepath.Join(tmp, "dest"),
+		Type:        "directory",
+	}
+	cfg.Targets = append(cfg.Targets, outer)
+	inner := cfg.Targets[0]
+
+	for _, tgt := range []config.Target{inner, outer} {
+		if reason := planLinkTarget(cfg, tgt, linkOptions{}); !strings.Contains(reason, "inside") {
+			t.Errorf("%s: reason = %q, want the nested destination refused", tgt.Name, reason)
+		}
+	}
+	if reason := planLinkTarget(cfg, inner, linkOptions{force: true}); reason != "" {
+		t.Errorf("--force should link anyway, got %q", reason)
+	}
+
+	res := checkTargetOverlaps(cfg)
+	if len(res) != 1 || res[0].Passed || res[0].Item != "test-skills" {
+		t.Errorf("checkTargetOverlaps = %+v, want one failure for test-skills", res)
+	}
+}
@@ -53,7 +53,7 @@ func listItems(cfg *config.Config) []categoryItems {
 
 	for _, t := range cfg.Targets {
 		src := strings.TrimSpace(t.Source)
-		if src == "" || seen[src] {
+		if src == "" || seen[src] || t.IsFile() {
 			continue
 		}
 		seen[src] = true
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/frontmatter"
+	"github.com/spf13/cobra"
+)
+
+var metaCmd = &cobra.Command{
+	Use:   "meta",
+	Short: "Read or edit SKILL.md frontmatter",
+	Long: `The meta command family reads and edits the YAML frontmatter of a
+skill's SKILL.md without touching its body or YAML comments, so scripts and
+CI can bump versions or toggle flags without sed.
+
+A skill is given by name (as in 'axon inspect'), or by a path to its
+directory or SKILL.md. Keys may be dotted to reach nested fields.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var metaSetCmd = &cobra.Command{
+	Use:   "set <skill> key=value [key=value...]",
+	Short: "Set frontmatter fields of a skill",
+	Long: `Set one or more frontmatter fields. Values are parsed as YAML, so
+'auto_invoke=true' stores a boolean and 'keywords=[a, b]' a list; quote a
+value to force a string (version="'2.0'"). 'key+=value' appends to a list,
+creating it if needed.
+
+Examples:
+  axon meta set humanizer version=1.2.0
+  axon meta set humanizer auto_invoke=true keywords+=tone
+  axon meta set ./skills/humanizer requires.skills="[git-helpers]"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runMetaSet,
+}
+
+var metaGetCmd = &cobra.Command{
+	Use:   "get <skill> [key]",
+	Short: "Print a frontmatter field of a skill",
+	Long: `Print the value of a frontmatter field, or the whole frontmatter when
+no key is given. Scalars are printed bare; lists and mappings as YAML. A
+missing key is an error.
+
+Examples:
+  axon meta get humanizer version
+  axon meta get humanizer requires.bins`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runMetaGet,
+}
+
+func init() {
+	metaCmd.AddCommand(metaSetCmd)
+	metaCmd.AddCommand(metaGetCmd)
+	rootCmd.AddCommand(metaCmd)
+}
+
+func runMetaSet(_ *cobra.Command, args []string) error {
+	path, err := resolveSkillMD(args[0])
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	doc, err := frontmatter.Parse(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	// Apply every assignment before writing so a bad one changes nothing.
+	for _, a := range args[1:] {
+		key, value, ok := strings.Cut(a, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid assignment %q (want key=value or key+=value)", a)
+		}
+		if k, isAppend := strings.CutSuffix(key, "+"); isAppend {
+			err = doc.Append(strings.TrimSpace(k), value)
+		} else {
+			err = doc.Set(strings.TrimSpace(key), value)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", a, err)
+		}
+	}
+
+	out, err := doc.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(path, out); err != nil {
+		return err
+	}
+	for _, a := range args[1:] {
+		printOK(filepath.Base(filepath.Dir(path)), a)
+	}
+	return nil
+}
+
+func runMetaGet(_ *cobra.Command, args []string) error {
+	path, err := resolveSkillMD(args[0])
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	doc, err := frontmatter.Parse(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if len(args) == 1 {
+		front, err := doc.Frontmatter()
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(front))
+		return nil
+	}
+
+	n, ok := doc.Get(args[1])
+	if !ok {
+		return fmt.Errorf("%s has no %q field", path, args[1])
+	}
+	s, err := frontmatter.Format(n)
+	if err != nil {
+		return err
+	}
+	fmt.Println(s)
+	return nil
+}
+
+// resolveSkillMD finds the SKILL.md for arg: a path to the file or its
+// directory, or a skill name in the Hub.
+func resolveSkillMD(arg string) (string, error) {
+	if info, err := os.Stat(arg); err == nil {
+		if !info.IsDir() {
+			return arg, nil
+		}
+		p := filepath.Join(arg, "SKILL.md")
+		if _, err := os.Stat(p); err != nil {
+			return "", fmt.Errorf("%s has no SKILL.md", arg)
+		}
+		return p, nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	node, ok := loadSkillGraph(cfg)[arg]
+	if !ok {
+		return "", fmt.Errorf("skill %q not found in Hub.\nTip: run 'axon list' to see available items.", arg)
+	}
+	return filepath.Join(node.Dir, "SKILL.md"), nil
+}
+
+// writeFileAtomic replaces path with data via a temp file in the same
+// directory, keeping the original permissions.
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".axon-meta-*")
+	if err != nil {
+		return fmt.Errorf("cannot write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
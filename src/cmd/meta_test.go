@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMetaSet_BadAssignmentChangesNothing(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "SKILL.md")
+	orig := "---\nname: x\nversion: 1.0.0\n---\nbody\n"
+	if err := os.WriteFile(path, []byte(orig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runMetaSet(nil, []string{dir, "version=1.1.0", "name.first=oops"}); err == nil {
+		t.Fatal("expected an error for a key below a scalar")
+	}
+	if data, _ := os.ReadFile(path); string(data) != orig {
+		t.Errorf("file changed after a failed set:\n%s", data)
+	}
+
+	if err := runMetaSet(nil, []string{path, "version=1.1.0"}); err != nil {
+		t.Fatalf("runMetaSet: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "---\nname: x\nversion: 1.1.0\n---\nbody\n" {
+		t.Errorf("unexpected result:\n%s", data)
+	}
+}
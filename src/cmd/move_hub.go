@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/portable"
+	"github.com/spf13/cobra"
+)
+
+var moveHubCmd = &cobra.Command{
+	Use:   "move-hub <new-path>",
+	Short: "Move the Hub repo to a new location and relink every target",
+	Long: `Move the Hub repo (repo_path) to <new-path>, e.g. a bigger disk or a
+synced folder, then update repo_path in axon.yaml and relink every
+destination that pointed into the old location.
+
+The steps run as one transaction: if updating the config or relinking
+fails, the links, the config, and the Hub are all put back. A move across
+filesystems copies the Hub and removes the old copy only at the end.
+
+  axon move-hub /data/axon-hub`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMoveHub,
+}
+
+func init() {
+	rootCmd.AddCommand(moveHubCmd)
+}
+
+func runMoveHub(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	oldPath := filepath.Clean(cfg.RepoPath)
+	newPath, err := config.ExpandPath(args[0])
+	if err != nil {
+		return err
+	}
+	if newPath, err = filepath.Abs(newPath); err != nil {
+		return err
+	}
+	if err := checkMoveHub(oldPath, newPath); err != nil {
+		return err
+	}
+
+	// ── 1. Move the repo ──────────────────────────────────────────────────────
+	copied, err := moveDir(oldPath, newPath)
+	if err != nil {
+		return fmt.Errorf("cannot move the Hub: %w", err)
+	}
+	moveBack := func() {
+		if copied {
+			_ = os.RemoveAll(newPath)
+			return
+		}
+		if err := os.Rename(newPath, oldPath); err != nil {
+			printErr("", fmt.Sprintf("could not move the Hub back: %v; it is at %s", err, newPath))
+		}
+	}
+	how := "moved"
+	if copied {
+		how = "copied"
+	}
+	printOK("", fmt.Sprintf("Hub %s: %s → %s", how, oldPath, newPath))
+
+	// ── 2. Update repo_path ───────────────────────────────────────────────────
+	if err := config.SetRepoPath(newPath); err != nil {
+		moveBack()
+		return fmt.Errorf("%w; the Hub was put back at %s", err, oldPath)
+	}
+	printOK("", "repo_path updated in axon.yaml")
+
+	// ── 3. Relink ─────────────────────────────────────────────────────────────
+	moved := *cfg
+	moved.RepoPath = newPath
+	relink := func() error {
+		matched, err := relinkCandidates(&moved, cfg.Targets, oldPath)
+		if err != nil || len(matched) == 0 {
+			return err
+		}
+		results, _, err := linkTargets(&moved, matched, linkOptions{})
+		for _, r := range results {
+			printOK(r.name, r.detail)
+		}
+		return err
+	}
+	if err := relink(); err != nil {
+		if rerr := config.SetRepoPath(oldPath); rerr != nil {
+			printErr("", fmt.Sprintf("could not restore repo_path: %v", rerr))
+		}
+		moveBack()
+		return fmt.Errorf("relink failed: %w\nThe Hub, axon.yaml, and the links were put back.", err)
+	}
+	refreshMergedViews(&moved)
+
+	if copied {
+		if err := os.RemoveAll(oldPath); err != nil {
+			printWarn("", fmt.Sprintf("could not remove the old copy at %s: %v", oldPath, err))
+		}
+	}
+	printOK("", fmt.Sprintf("The Hub now lives at %s.", newPath))
+	return nil
+}
+
+// checkMoveHub validates a move of the Hub from oldPath to newPath.
+func checkMoveHub(oldPath, newPath string) error {
+	if _, err := os.Stat(filepath.Join(oldPath, ".git")); err != nil {
+		return fmt.Errorf("no Hub repo at %s", oldPath)
+	}
+	if pathWithin(oldPath, newPath) {
+		return fmt.Errorf("%s is inside the Hub at %s", newPath, oldPath)
+	}
+	if hubOperation(oldPath) != "" {
+		return fmt.Errorf("a merge or rebase is in progress in the Hub; run 'axon sync --continue' or 'axon sync --abort' first")
+	}
+	if entries, err := os.ReadDir(newPath); err == nil {
+		if len(entries) > 0 {
+			return fmt.Errorf("%s already exists and is not empty", newPath)
+		}
+		if err := os.Remove(newPath); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	} else if _, err := os.Lstat(newPath); err == nil {
+		return fmt.Errorf("%s already exists", newPath)
+	}
+	return os.MkdirAll(filepath.Dir(newPath), 0o755)
+}
+
+// moveDir renames src to dst, or copies it when they are on different
+// filesystems. copied reports the latter; src is then left for the caller
+// to remove once it is no longer needed.
+func moveDir(src, dst string) (copied bool, err error) {
+	if err := os.Rename(src, dst); err == nil {
+		return false, nil
+	}
+	if err := copyTree(src, dst); err != nil {
+		_ = os.RemoveAll(dst)
+		return false, err
+	}
+	return true, nil
+}
+
+// copyTree copies the directory src to dst, keeping file modes and
+// recreating symlinks as they are.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := portable.LongPath(filepath.Join(dst, rel))
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyRegularFile(p, target, info.Mode().Perm())
+		}
+		return nil // sockets, pipes, devices
+	})
+}
+
+func copyRegularFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(portable.LongPath(src))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(portable.LongPath(dst), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// setupMoveHub links the target of a fresh link test from a Hub that is a
+// git repo, and saves the config.
+func setupMoveHub(t *testing.T) (*config.Config, string) {
+	t.Helper()
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	if out, err := exec.Command("git", "init", "-q", cfg.RepoPath).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	if err := os.MkdirAll(filepath.Join(tmp, "dest"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := linkTargets(cfg, cfg.Targets, linkOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	return cfg, tmp
+}
+
+func TestMoveHub(t *testing.T) {
+	cfg, tmp := setupMoveHub(t)
+	newHub := filepath.Join(tmp, "disk", "hub")
+
+	if err := runMoveHub(nil, []string{newHub}); err != nil {
+		t.Fatalf("runMoveHub: %v", err)
+	}
+	if _, err := os.Stat(cfg.RepoPath); !os.IsNotExist(err) {
+		t.Errorf("old Hub should be gone, stat err = %v", err)
+	}
+	loaded, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.RepoPath != newHub {
+		t.Errorf("repo_path = %s, want %s", loaded.RepoPath, newHub)
+	}
+	dest := cfg.Targets[0].Destination
+	if raw, _ := os.Readlink(dest); raw != filepath.Join(newHub, "skills") {
+		t.Errorf("%s → %s, want the moved Hub", dest, raw)
+	}
+}
+
+func TestMoveHub_Refuses(t *testing.T) {
+	cfg, tmp := setupMoveHub(t)
+	full := filepath.Join(tmp, "full")
+	if err := os.MkdirAll(full, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(full, "x"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []string{full, filepath.Join(cfg.RepoPath, "sub")} {
+		err := runMoveHub(nil, []string{p})
+		if err == nil {
+			t.Fatalf("runMoveHub(%s) should fail", p)
+		}
+		if !strings.Contains(err.Error(), p) {
+			t.Errorf("error should name %s: %v", p, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cfg.RepoPath, "skills", "sentinel.md")); err != nil {
+		t.Errorf("Hub should be untouched: %v", err)
+	}
+}
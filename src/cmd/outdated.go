@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/vendor"
+	"github.com/spf13/cobra"
+)
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List vendored skills with a newer upstream version",
+	Long: `Compare each vendored skill in the Hub with its upstream repository.
+
+A skill is vendored when it lives under the 'dest' of an entry in the
+'vendors' block of axon.yaml, which includes skills added by 'axon
+install'. When both copies declare 'version:' in their SKILL.md
+frontmatter the versions are compared; otherwise the skill is reported as
+changed when upstream commits touched it since it was last mirrored.
+
+Update a single skill with 'axon update-skill <name>', or every vendor with
+'axon vendor sync'.
+
+Examples:
+  axon outdated
+  axon outdated --no-fetch   # use the cached upstream state`,
+	Args: cobra.NoArgs,
+	RunE: runOutdated,
+}
+
+var flagOutdatedNoFetch bool
+
+func init() {
+	outdatedCmd.Flags().BoolVar(&flagOutdatedNoFetch, "no-fetch", false, "Do not fetch vendor repos; compare against the cached state")
+	rootCmd.AddCommand(outdatedCmd)
+}
+
+// vendoredSkill is a Hub skill mirrored from a vendor entry.
+type vendoredSkill struct {
+	Name   string
+	Vendor config.Vendor
+	Dir    string // absolute path in the Hub
+	Dest   string // Hub-relative, cleaned
+	Subdir string // upstream path, slash-separated
+	// Whole is true when the vendor entry mirrors exactly this skill.
+	Whole bool
+}
+
+// skillUpstreamState compares a vendored skill with its upstream copy.
+type skillUpstreamState struct {
+	Skill    vendoredSkill
+	Local    string // local version, may be empty
+	Upstream string // upstream version, may be empty
+	Changed  bool
+	Err      error
+}
+
+func runOutdated(_ *cobra.Command, _ []string) error {
+	if err := checkGitAvailable(); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	if len(cfg.Vendors) == 0 {
+		return fmt.Errorf("no vendors configured — add a 'vendors' block to ~/.axon/axon.yaml")
+	}
+	if err := validateVendors(cfg.Vendors); err != nil {
+		return err
+	}
+
+	skills, err := vendoredSkills(cfg)
+	if err != nil {
+		return err
+	}
+	if len(skills) == 0 {
+		printSkip("", "no vendored skills in the Hub — run 'axon vendor sync' first")
+		return nil
+	}
+
+	printSection("Outdated Skills")
+	fetched := make(map[string]error)
+	var outdated, current, failed int
+	for _, s := range skills {
+		st := checkSkillUpstream(s, !flagOutdatedNoFetch, fetched)
+		switch {
+		case st.Err != nil:
+			failed++
+			printErr(s.Name, st.Err.Error())
+		case !st.Changed:
+			current++
+		case st.Local != "" && st.Upstream != "":
+			outdated++
+			printWarn(s.Name, fmt.Sprintf("%s → %s (%s)", st.Local, st.Upstream, upstreamLabel(s.Vendor)))
+		default:
+			outdated++
+			printWarn(s.Name, fmt.Sprintf("changed upstream (%s, no version to compare)", upstreamLabel(s.Vendor)))
+		}
+	}
+
+	fmt.Println()
+	printOK("", fmt.Sprintf("%d outdated, %d up to date, %d error", outdated, current, failed))
+	if outdated > 0 {
+		fmt.Println("   Run 'axon update-skill <name>' to update one skill.")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d skill(s) could not be checked", failed)
+	}
+	return nil
+}
+
+// vendoredSkills lists the skills in the Hub that came from vendor entries.
+// A vendor dest holding a SKILL.md is one skill; otherwise each child
+// directory with a SKILL.md is.
+func vendoredSkills(cfg *config.Config) ([]vendoredSkill, error) {
+	var out []vendoredSkill
+	for _, v := range cfg.Vendors {
+		dest, err := vendor.ValidateDest(v.Dest)
+		if err != nil {
+			return nil, err
+		}
+		destAbs := filepath.Join(cfg.RepoPath, dest)
+		if _, err := os.Stat(filepath.Join(destAbs, "SKILL.md")); err == nil {
+			out = append(out, vendoredSkill{Name: filepath.Base(dest), Vendor: v, Dir: destAbs, Dest: dest, Subdir: path.Clean(v.Subdir), Whole: true})
+			continue
+		}
+		entries, err := os.ReadDir(destAbs)
+		if err != nil {
+			continue // not mirrored yet
+		}
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(destAbs, e.Name(), "SKILL.md")); err != nil {
+				continue
+			}
+			// Renames and flattening break the name mapping: such skills
+			// are compared with the whole vendor subdir.
+			subdir := path.Join(v.Subdir, e.Name())
+			if vendorReshaped(v) {
+				subdir = path.Clean(v.Subdir)
+			}
+			out = append(out, vendoredSkill{
+				Name:   e.Name(),
+				Vendor: v,
+				Dir:    filepath.Join(destAbs, e.Name()),
+				Dest:   filepath.Join(dest, e.Name()),
+				Subdir: subdir,
+			})
+		}
+	}
+	return out, nil
+}
+
+// checkSkillUpstream compares s with upstream. Each vendor cache is fetched
+// at most once per run; fetched records the outcome by cache path.
+func checkSkillUpstream(s vendoredSkill, fetch bool, fetched map[string]error) skillUpstreamState {
+	st := skillUpstreamState{Skill: s}
+	cachePath, err := vendor.CachePath(s.Vendor.Repo)
+	if err != nil {
+		st.Err = fmt.Errorf("cannot resolve cache path: %w", err)
+		return st
+	}
+	if !vendor.IsCloned(cachePath) {
+		st.Err = fmt.Errorf("vendor %s is not cached — run 'axon vendor sync'", s.Vendor.Name)
+		return st
+	}
+	if fetch {
+		ferr, done := fetched[cachePath]
+		if !done {
+			printInfo(s.Vendor.Name, "fetching remote refs…")
+			ferr = vendor.Fetch(cachePath, s.Vendor.Depth)
+			fetched[cachePath] = ferr
+		}
+		if ferr != nil {
+			st.Err = ferr
+			return st
+		}
+	}
+
+	ref := vendor.ResolveRef(cachePath, vendorRef(s.Vendor))
+	// The upstream SKILL.md of a reshaped vendor cannot be located by name.
+	if !vendorReshaped(s.Vendor) {
+		if meta, ok := parseSkillMeta(filepath.Join(s.Dir, "SKILL.md")); ok {
+			st.Local = strings.TrimSpace(meta.Version)
+		}
+		data, err := vendor.ShowFile(cachePath, ref, path.Join(s.Subdir, "SKILL.md"))
+		if err != nil {
+			st.Err = fmt.Errorf("SKILL.md not found upstream at %s:%s", ref, s.Subdir)
+			return st
+		}
+		if meta, ok := parseSkillMetaFrom(bytes.NewReader(data)); ok {
+			st.Upstream = strings.TrimSpace(meta.Version)
+		}
+	}
+
+	if st.Local != "" && st.Upstream != "" {
+		st.Changed = compareSkillVersions(st.Upstream, st.Local) > 0
+		return st
+	}
+
+	// No versions: changed unless the skill is current as of the last
+	// whole-vendor sync or the last update-skill.
+	var bases []string
+	if sha, _ := vendor.ReadVendorSHA(s.Vendor.Name); sha != "" {
+		bases = append(bases, sha)
+	}
+	if sha, _ := vendor.ReadSkillSHA(s.Vendor.Name, s.Name); sha != "" {
+		bases = append(bases, sha)
+	}
+	st.Changed = true
+	for _, base := range bases {
+		// A shallow clone may have been fetched past the last sync: get
+		// the full history to compare with it, when fetching is allowed.
+		if fetch && !vendor.HasCommit(cachePath, base) && vendor.IsShallow(cachePath) {
+			printInfo(s.Vendor.Name, "fetching the full history to compare with the last sync…")
+			if err := vendor.Unshallow(cachePath); err != nil {
+				st.Err = err
+				return st
+			}
+		}
+		changed, err := vendor.ChangedSince(cachePath, base, ref, s.Subdir)
+		if err == nil && !changed {
+			st.Changed = false
+			break
+		}
+	}
+	return st
+}
+
+// upstreamLabel names where a vendored skill comes from: the registry it
+// was installed from, or its vendor entry.
+func upstreamLabel(v config.Vendor) string {
+	if v.Registry != "" {
+		return "registry " + v.Registry
+	}
+	return "vendor " + v.Name
+}
+
+// vendorRef returns the ref a vendor entry tracks; "main" when unset.
+func vendorRef(v config.Vendor) string {
+	if v.Ref == "" {
+		return "main"
+	}
+	return v.Ref
+}
+
+// compareSkillVersions compares two version strings such as "1.2.0" or
+// "v2.0-beta". Numeric dot-separated parts are compared numerically; a
+// release sorts after its pre-releases. Other strings fall back to a plain
+// comparison. It returns -1, 0, or 1.
+func compareSkillVersions(a, b string) int {
+	split := func(v string) ([]string, string) {
+		v = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(v), "v"), "V")
+		if i := strings.IndexByte(v, '+'); i >= 0 {
+			v = v[:i] // build metadata does not affect precedence
+		}
+		pre := ""
+		if i := strings.IndexByte(v, '-'); i >= 0 {
+			v, pre = v[:i], v[i+1:]
+		}
+		return strings.Split(v, "."), pre
+	}
+	ap, apre := split(a)
+	bp, bpre := split(b)
+	for i := 0; i < len(ap) || i < len(bp); i++ {
+		as, bs := "0", "0"
+		if i < len(ap) {
+			as = ap[i]
+		}
+		if i < len(bp) {
+			bs = bp[i]
+		}
+		an, aerr := strconv.Atoi(as)
+		bn, berr := strconv.Atoi(bs)
+		if aerr != nil || berr != nil {
+			if c := strings.Compare(as, bs); c != 0 {
+				return c
+			}
+			continue
+		}
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case apre == bpre:
+		return 0
+	case apre == "":
+		return 1
+	case bpre == "":
+		return -1
+	}
+	return strings.Compare(apre, bpre)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/vendor"
+)
+
+func TestCompareSkillVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.0", "1.2.0", 0},
+		{"1.10.0", "1.9.3", 1},
+		{"v2", "1.9", 1},
+		{"1.2", "1.2.0", 0},
+		{"1.0.0-beta", "1.0.0", -1},
+		{"1.0.0+build.7", "1.0.0", 0},
+		{"2024-01", "2023-12", 1},
+	}
+	for _, c := range cases {
+		if got := compareSkillVersions(c.a, c.b); got != c.want {
+			t.Errorf("compareSkillVersions(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestOutdated_DetectsAndUpdatesSkill(t *testing.T) {
+	resetVendorCache(t)
+	orig := vendor.RsyncAvailable
+	vendor.RsyncAvailable = func() bool { return false }
+	defer func() { vendor.RsyncAvailable = orig }()
+
+	src := makeLocalVendorRepo(t, "skills/pack/foo", "SKILL.md", "---\nname: foo\nversion: 1.0.0\n---\n")
+	hub := t.TempDir()
+	if err := os.Mkdir(filepath.Join(hub, "skills"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	v := config.Vendor{Name: "pack", Repo: src, Subdir: "skills/pack", Dest: "skills/pack", Ref: "master"}
+	if _, err := syncVendorEntry(hub, v); err != nil {
+		t.Fatalf("syncVendorEntry: %v", err)
+	}
+	cfg := &config.Config{RepoPath: hub, Vendors: []config.Vendor{v}}
+
+	skills, err := vendoredSkills(cfg)
+	if err != nil || len(skills) != 1 || skills[0].Name != "foo" || skills[0].Subdir != "skills/pack/foo" {
+		t.Fatalf("vendoredSkills = %+v, %v", skills, err)
+	}
+	if st := checkSkillUpstream(skills[0], true, map[string]error{}); st.Err != nil || st.Changed {
+		t.Fatalf("fresh mirror reported %+v", st)
+	}
+
+	// Upstream bumps the version.
+	upstream := filepath.Join(src, "skills", "pack", "foo", "SKILL.md")
+	if err := os.WriteFile(upstream, []byte("---\nname: foo\nversion: 1.1.0\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"-C", src, "add", "."}, {"-C", src, "commit", "-m", "bump"}} {
+		if err := gitRun(args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	st := checkSkillUpstream(skills[0], true, map[string]error{})
+	if st.Err != nil || !st.Changed || st.Local != "1.0.0" || st.Upstream != "1.1.0" {
+		t.Fatalf("after bump: %+v", st)
+	}
+
+	if _, err := updateVendoredSkill(hub, skills[0]); err != nil {
+		t.Fatalf("updateVendoredSkill: %v", err)
+	}
+	if st := checkSkillUpstream(skills[0], false, nil); st.Err != nil || st.Changed || st.Local != "1.1.0" {
+		t.Errorf("after update: %+v", st)
+	}
+}
@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/i18n"
 )
 
 // ── Unified output helpers ────────────────────────────────────────────────────
@@ -23,12 +28,12 @@ const (
 
 // printSection prints a top-level section header, e.g. "=== Link ===".
 func printSection(title string) {
-	fmt.Printf("\n=== %s ===\n", title)
+	outf("\n%s\n", paint(colorStdout, sgrBold, "=== "+i18n.T(title)+" ==="))
 }
 
 // printBullet prints a grouped-section bullet, e.g. "● Already linked:".
 func printBullet(title string) {
-	fmt.Printf("\n● %s\n", title)
+	outf("\n%s\n", paint(colorStdout, sgrBold, "● "+i18n.T(title)))
 }
 
 // printOK prints a success line.
@@ -37,45 +42,45 @@ func printBullet(title string) {
 //	name set  → "  ✓  [name] msg"
 func printOK(name, msg string) {
 	if name == "" {
-		fmt.Printf("  %s  %s\n", iconOK, msg)
+		outf("  %s  %s\n", tint(iconOK), i18n.T(msg))
 	} else {
-		fmt.Printf("  %s  [%s] %s\n", iconOK, name, msg)
+		outf("  %s  [%s] %s\n", tint(iconOK), name, i18n.T(msg))
 	}
 }
 
 // printErr prints an error line to stderr.
 func printErr(name, msg string) {
 	if name == "" {
-		fmt.Fprintf(os.Stderr, "  %s  %s\n", iconError, msg)
+		errf("  %s  %s\n", tintErr(iconError), i18n.T(msg))
 	} else {
-		fmt.Fprintf(os.Stderr, "  %s  [%s] %s\n", iconError, name, msg)
+		errf("  %s  [%s] %s\n", tintErr(iconError), name, i18n.T(msg))
 	}
 }
 
 // printWarn prints a warning line.
 func printWarn(name, msg string) {
 	if name == "" {
-		fmt.Printf("  %s  %s\n", iconWarn, msg)
+		outf("  %s  %s\n", tint(iconWarn), i18n.T(msg))
 	} else {
-		fmt.Printf("  %s  [%s] %s\n", iconWarn, name, msg)
+		outf("  %s  [%s] %s\n", tint(iconWarn), name, i18n.T(msg))
 	}
 }
 
 // printBackup prints a backup-created line.
 func printBackup(name, msg string) {
 	if name == "" {
-		fmt.Printf("  %s  %s\n", iconBackup, msg)
+		outf("  %s  %s\n", tint(iconBackup), i18n.T(msg))
 	} else {
-		fmt.Printf("  %s  [%s] %s\n", iconBackup, name, msg)
+		outf("  %s  [%s] %s\n", tint(iconBackup), name, i18n.T(msg))
 	}
 }
 
 // printRestore prints a backup-restore line.
 func printRestore(name, msg string) {
 	if name == "" {
-		fmt.Printf("  %s  %s\n", iconRestore, msg)
+		outf("  %s  %s\n", tint(iconRestore), i18n.T(msg))
 	} else {
-		fmt.Printf("  %s  [%s] %s\n", iconRestore, name, msg)
+		outf("  %s  [%s] %s\n", tint(iconRestore), name, i18n.T(msg))
 	}
 }
 
@@ -92,31 +97,55 @@ func printItem(name string) {
 // printSkip prints a skipped / not-applicable line.
 func printSkip(name, msg string) {
 	if name == "" {
-		fmt.Printf("  %s  %s\n", iconSkip, msg)
+		outf("  %s  %s\n", tint(iconSkip), i18n.T(msg))
 	} else {
-		fmt.Printf("  %s  [%s] %s\n", iconSkip, name, msg)
+		outf("  %s  [%s] %s\n", tint(iconSkip), name, i18n.T(msg))
 	}
 }
 
 // printMiss prints a not-found / missing line.
 func printMiss(name, msg string) {
 	if name == "" {
-		fmt.Printf("  %s  %s\n", iconMiss, msg)
+		outf("  %s  %s\n", tint(iconMiss), i18n.T(msg))
 	} else {
-		fmt.Printf("  %s  [%s] %s\n", iconMiss, name, msg)
+		outf("  %s  [%s] %s\n", tint(iconMiss), name, i18n.T(msg))
 	}
 }
 
 // printInfo prints a neutral informational / state-change line.
 func printInfo(name, msg string) {
 	if name == "" {
-		fmt.Printf("  %s  %s\n", iconInfo, msg)
+		outf("  %s  %s\n", tint(iconInfo), i18n.T(msg))
 	} else {
-		fmt.Printf("  %s  [%s] %s\n", iconInfo, name, msg)
+		outf("  %s  [%s] %s\n", tint(iconInfo), name, i18n.T(msg))
 	}
 }
 
 // printListItem prints a bulleted list item with a custom icon.
 func printListItem(icon, name string) {
-	fmt.Printf("  %s  %s\n", icon, name)
+	outf("  %s  %s\n", tint(icon), name)
+}
+
+// outf prints to stdout like fmt.Printf, keeping any progress line below
+// the output.
+func outf(format string, a ...any) {
+	withProgressHidden(func() { fmt.Printf(format, a...) })
+}
+
+// errf is outf for stderr.
+func errf(format string, a ...any) {
+	withProgressHidden(func() { fmt.Fprintf(os.Stderr, format, a...) })
+}
+
+// confirm prints question with a [y/N] suffix and reports whether the answer
+// read from r is yes. Anything else, including EOF, counts as no.
+func confirm(r io.Reader, question string) bool {
+	defer pauseProgress()()
+	fmt.Printf("\n  %s [y/N] ", question)
+	line, _ := bufio.NewReader(r).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	}
+	return false
 }
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ── Progress indicators ───────────────────────────────────────────────────────
+// A progress line is drawn on stderr below the regular output of long
+// operations: a spinner, an optional bar with a count, and the item being
+// worked on. The output helpers erase it before they print and draw it again
+// afterwards, so it always stays the last line. Only one is shown at a time;
+// starting another while one is active returns nil, and every method is a
+// no-op on nil, so callers never need to check.
+
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+const progressBarWidth = 20
+
+var (
+	progressMu     sync.Mutex
+	activeProgress *progressLine
+)
+
+// progressLine is the state of the progress indicator on screen. All fields
+// are guarded by progressMu.
+type progressLine struct {
+	label   string
+	item    string
+	done    int64
+	total   int64                // 0 shows a spinner without a bar
+	count   func(n int64) string // renders done and total
+	frame   int
+	drawn   int // columns used by the last draw, to erase it
+	columns int
+	paused  int
+
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// progressEnabled reports whether progress may be drawn: not with --quiet,
+// and only when stderr is an interactive terminal.
+func progressEnabled() bool {
+	if flagQuiet || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(os.Stderr.Fd())
+}
+
+// startProgress shows a progress line for label. total is the number of
+// items, or 0 when unknown (spinner only). Call Done when finished.
+func startProgress(label string, total int64) *progressLine {
+	return startProgressCount(label, total, func(n int64) string { return fmt.Sprint(n) })
+}
+
+// startByteProgress is startProgress for a byte count, e.g. a download.
+func startByteProgress(label string, total int64) *progressLine {
+	return startProgressCount(label, total, humanBytes)
+}
+
+func startProgressCount(label string, total int64, count func(int64) string) *progressLine {
+	if !progressEnabled() {
+		return nil
+	}
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	if activeProgress != nil {
+		return nil
+	}
+	columns := 80
+	if c, _, err := terminalSize(os.Stderr.Fd()); err == nil && c > 0 {
+		columns = c
+	}
+	p := &progressLine{
+		label:   label,
+		total:   total,
+		count:   count,
+		columns: columns,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	activeProgress = p
+	p.draw()
+	go p.spin()
+	return p
+}
+
+// spin advances the spinner and redraws the line until Done; counts and
+// items changed in between show up on the next tick.
+func (p *progressLine) spin() {
+	defer close(p.stopped)
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-t.C:
+			progressMu.Lock()
+			p.frame++
+			p.draw()
+			progressMu.Unlock()
+		}
+	}
+}
+
+// Item names what is being worked on now.
+func (p *progressLine) Item(item string) {
+	if p == nil {
+		return
+	}
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	p.item = item
+}
+
+// Add counts n more items as done.
+func (p *progressLine) Add(n int64) {
+	if p == nil {
+		return
+	}
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	p.done += n
+}
+
+// Set replaces the done and total counts.
+func (p *progressLine) Set(done, total int64) {
+	if p == nil {
+		return
+	}
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	p.done, p.total = done, total
+}
+
+// setCount is Set for int counts, to be passed as a progress callback.
+func (p *progressLine) setCount(done, total int) {
+	p.Set(int64(done), int64(total))
+}
+
+// Done erases the progress line for good. Calling it again does nothing.
+func (p *progressLine) Done() {
+	if p == nil {
+		return
+	}
+	p.once.Do(func() {
+		close(p.stop)
+		<-p.stopped
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		p.erase()
+		activeProgress = nil
+	})
+}
+
+// draw renders the line in place of the previous one.
+func (p *progressLine) draw() {
+	if p.paused > 0 {
+		return
+	}
+	line := renderProgress(p.label, p.item, p.done, p.total, p.count, p.frame, p.columns-1)
+	n := utf8.RuneCountInString(line)
+	pad := ""
+	if p.drawn > n {
+		pad = strings.Repeat(" ", p.drawn-n)
+	}
+	fmt.Fprint(os.Stderr, "\r"+line+pad)
+	p.drawn = n
+}
+
+// erase blanks the line and returns the cursor to its start.
+func (p *progressLine) erase() {
+	if p.drawn == 0 {
+		return
+	}
+	fmt.Fprint(os.Stderr, "\r"+strings.Repeat(" ", p.drawn)+"\r")
+	p.drawn = 0
+}
+
+// renderProgress formats one progress line, cut to width columns:
+//
+//	⠹ Vendor sync [████████░░░░░░░░░░░░] 2/5 anthropic-skills
+func renderProgress(label, item string, done, total int64, count func(int64) string, frame, width int) string {
+	var b strings.Builder
+	b.WriteRune(spinnerFrames[frame%len(spinnerFrames)])
+	b.WriteString(" " + label)
+	switch {
+	case total > 0:
+		filled := int(min(done, total) * progressBarWidth / total)
+		b.WriteString(" [" + strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled) + "] ")
+		b.WriteString(count(done) + "/" + count(total))
+	case done > 0:
+		b.WriteString(" " + count(done))
+	}
+	if item != "" {
+		b.WriteString(" " + item)
+	}
+	line := b.String()
+	if width > 0 && utf8.RuneCountInString(line) > width {
+		r := []rune(line)
+		line = string(r[:width-1]) + "…"
+	}
+	return line
+}
+
+// withProgressHidden runs fn, which prints, with the progress line out of
+// the way, then draws it again below the new output.
+func withProgressHidden(fn func()) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	p := activeProgress
+	if p == nil {
+		fn()
+		return
+	}
+	p.erase()
+	fn()
+	p.draw()
+}
+
+// pauseProgress hides the progress line while something else writes to the
+// terminal directly, such as git streaming its own output or a prompt
+// waiting for input. Call the returned function to show it again.
+func pauseProgress() func() {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	p := activeProgress
+	if p == nil {
+		return func() {}
+	}
+	p.erase()
+	p.paused++
+	return func() {
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		p.paused--
+		if activeProgress == p {
+			p.draw()
+		}
+	}
+}
+
+// withProgressPaused runs fn, which writes to the terminal directly, with the
+// progress line paused.
+func withProgressPaused(fn func() error) error {
+	defer pauseProgress()()
+	return fn()
+}
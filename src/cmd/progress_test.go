@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRenderProgress(t *testing.T) {
+	count := func(n int64) string { return fmt.Sprint(n) }
+	tests := []struct {
+		name        string
+		done, total int64
+		item        string
+		width       int
+		want        string
+	}{
+		{"spinner", 0, 0, "", 0, "⠋ Syncing"},
+		{"spinner with count", 3, 0, "pdf", 0, "⠋ Syncing 3 pdf"},
+		{"bar", 1, 4, "pdf", 0, "⠋ Syncing [█████░░░░░░░░░░░░░░░] 1/4 pdf"},
+		{"bar overflow", 9, 4, "", 0, "⠋ Syncing [████████████████████] 9/4"},
+		{"cut to width", 0, 0, "a-very-long-skill-name", 16, "⠋ Syncing a-ver…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderProgress("Syncing", tt.item, tt.done, tt.total, count, 0, tt.width)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgress_DisabledWhenQuiet(t *testing.T) {
+	old := flagQuiet
+	flagQuiet = true
+	t.Cleanup(func() { flagQuiet = old })
+
+	p := startProgress("Syncing", 3)
+	if p != nil {
+		t.Fatal("progress must be off with --quiet")
+	}
+	// Every method is a no-op on the nil line.
+	p.Item("pdf")
+	p.Add(1)
+	p.Set(2, 3)
+	p.Done()
+
+	ran := false
+	withProgressHidden(func() { ran = true })
+	if !ran {
+		t.Error("withProgressHidden must run fn without an active progress line")
+	}
+}
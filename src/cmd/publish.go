@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish <skill>",
+	Short: "Open a pull request that contributes a skill to the upstream Hub",
+	Long: `Contribute a Hub skill back to a shared upstream Hub on GitHub: clone the
+upstream, create a branch, copy the skill into it, commit, push, and open a
+pull request through the GitHub API.
+
+The upstream is 'upstream' in axon.yaml unless --upstream is given. Without
+push access to it, push the branch to your fork with --fork; the pull
+request is then opened from the fork. Pushing uses your usual git
+credentials; the API call needs a token in AXON_GITHUB_TOKEN or
+GITHUB_TOKEN, as 'axon update' does.
+
+Examples:
+  axon publish pdf-tools
+  axon publish pdf-tools --fork https://github.com/me/axon-hub.git
+  axon publish pdf-tools --dry-run   # commit locally, show the change, push nothing`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPublish,
+}
+
+var (
+	flagPublishUpstream string
+	flagPublishFork     string
+	flagPublishBase     string
+	flagPublishBranch   string
+	flagPublishTitle    string
+	flagPublishDryRun   bool
+)
+
+func init() {
+	publishCmd.Flags().StringVar(&flagPublishUpstream, "upstream", "", "GitHub repo to contribute to (default: upstream in axon.yaml)")
+	publishCmd.Flags().StringVar(&flagPublishFork, "fork", "", "Repo URL to push the branch to, e.g. your fork of the upstream")
+	publishCmd.Flags().StringVar(&flagPublishBase, "base", "", "Branch the pull request targets (default: the upstream's default branch)")
+	publishCmd.Flags().StringVar(&flagPublishBranch, "branch", "", "Branch to create (default: axon/publish-<skill>-<timestamp>)")
+	publishCmd.Flags().StringVar(&flagPublishTitle, "title", "", "Pull request title (default: Add/Update skill <skill>)")
+	publishCmd.Flags().BoolVar(&flagPublishDryRun, "dry-run", false, "Prepare the commit but do not push or open a pull request")
+	rootCmd.AddCommand(publishCmd)
+}
+
+// publishPlan is what 'axon publish' pushes and proposes.
+type publishPlan struct {
+	Skill   string
+	Rel     string // skill path inside the repo, slash-separated
+	Branch  string
+	Base    string
+	Title   string
+	Updated bool // the upstream already had the skill
+}
+
+func runPublish(cmd *cobra.Command, args []string) error {
+	if err := checkGitAvailable(); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	name := args[0]
+	node, ok := loadSkillGraph(cfg)[name]
+	if !ok {
+		return fmt.Errorf("skill %q not found in Hub.\nTip: run 'axon list' to see available items.", name)
+	}
+	rel, identity := "", []string(nil)
+	for _, r := range cfg.HubRepos() {
+		if pathWithin(r.Path, node.Dir) {
+			rel, _ = filepath.Rel(r.Path, node.Dir)
+			identity = gitIdentityArgs(r.Path)
+			break
+		}
+	}
+
+	upstream := flagPublishUpstream
+	if upstream == "" {
+		upstream = cfg.Upstream
+	}
+	if upstream == "" {
+		return fmt.Errorf("no upstream configured — set 'upstream' in axon.yaml or pass --upstream")
+	}
+	gh, err := publishRepo(upstream)
+	if err != nil {
+		return err
+	}
+	pushURL, head := upstream, ""
+	if flagPublishFork != "" {
+		fork, err := publishRepo(flagPublishFork)
+		if err != nil {
+			return fmt.Errorf("--fork: %w", err)
+		}
+		pushURL, head = flagPublishFork, fork.owner+":"
+	}
+	tok, _ := envToken("AXON_GITHUB_TOKEN", "GITHUB_TOKEN")
+	if tok == "" && !flagPublishDryRun {
+		return fmt.Errorf("opening a pull request needs a GitHub token — set AXON_GITHUB_TOKEN or GITHUB_TOKEN")
+	}
+
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return err
+	}
+	tmpBase := filepath.Join(axonDir, "tmp")
+	if err := os.MkdirAll(tmpBase, 0o755); err != nil {
+		return fmt.Errorf("cannot create temp dir: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(tmpBase, "axon-publish-*")
+	if err != nil {
+		return fmt.Errorf("cannot create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	plan := publishPlan{
+		Skill:  name,
+		Rel:    filepath.ToSlash(rel),
+		Branch: flagPublishBranch,
+		Base:   flagPublishBase,
+		Title:  flagPublishTitle,
+	}
+	if plan.Branch == "" {
+		plan.Branch = fmt.Sprintf("axon/publish-%s-%s", name, time.Now().Format("20060102150405"))
+	}
+
+	printSection("Publish")
+	work := filepath.Join(tmpDir, "upstream")
+	changed, err := preparePublishBranch(work, upstream, node.Dir, identity, &plan)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		printOK(name, fmt.Sprintf("%s already has this version of %s; nothing to publish", gh, plan.Rel))
+		return nil
+	}
+	printOK(name, fmt.Sprintf("committed %s on branch %s (based on %s)", plan.Rel, plan.Branch, plan.Base))
+
+	if flagPublishDryRun {
+		if out, err := gitOutput(work, "show", "--stat", "--format=%s", "HEAD"); err == nil {
+			fmt.Println()
+			fmt.Println(strings.TrimRight(out, "\n"))
+		}
+		printSkip("", "dry run — nothing was pushed")
+		return nil
+	}
+
+	printInfo(name, "pushing "+plan.Branch+"…")
+	if err := gitRunRemote(pushURL, "-C", work, "push", pushURL, "HEAD:refs/heads/"+plan.Branch); err != nil {
+		return fmt.Errorf("git push failed: %w", err)
+	}
+	url, err := createPullRequest(commandContext(cmd), gh, tok, pullRequest{
+		Title: plan.Title,
+		Head:  head + plan.Branch,
+		Base:  plan.Base,
+		Body:  publishBody(plan),
+	})
+	if err != nil {
+		return fmt.Errorf("%w\nThe branch %s was pushed; open the pull request by hand.", err, plan.Branch)
+	}
+	printOK(name, "pull request opened: "+url)
+	return nil
+}
+
+// publishRepo resolves a GitHub repo given as owner/name, an https URL, or
+// an scp-like SSH URL (git@github.com:owner/name.git).
+func publishRepo(s string) (*githubSource, error) {
+	s = strings.TrimSpace(s)
+	if user, rest, ok := strings.Cut(s, "@"); ok && !strings.Contains(user, "/") && !strings.Contains(s, "://") {
+		if host, p, ok := strings.Cut(rest, ":"); ok {
+			s = "https://" + host + "/" + p
+		}
+	}
+	src, err := parseReleaseSource(strings.TrimSuffix(s, "/"))
+	if err != nil {
+		return nil, err
+	}
+	gh, ok := src.(*githubSource)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a GitHub repo; publishing opens a GitHub pull request", s)
+	}
+	gh.repo = strings.TrimSuffix(gh.repo, ".git")
+	return gh, nil
+}
+
+// preparePublishBranch clones upstream into work, creates plan.Branch, and
+// commits skillDir as plan.Rel. It fills in plan.Base, plan.Title, and
+// plan.Updated, and reports whether there was anything to commit.
+func preparePublishBranch(work, upstream, skillDir string, identity []string, plan *publishPlan) (bool, error) {
+	// -C keeps git out of the working directory, which may be gone.
+	cloneArgs := []string{"-C", filepath.Dir(work), "clone", "--quiet", "--depth", "1"}
+	if plan.Base != "" {
+		cloneArgs = append(cloneArgs, "--branch", plan.Base)
+	}
+	printInfo(plan.Skill, "cloning "+upstream+"…")
+	if err := gitRunRemote(upstream, append(cloneArgs, upstream, work)...); err != nil {
+		return false, fmt.Errorf("cannot clone %s: %w", upstream, err)
+	}
+	if plan.Base == "" {
+		out, err := gitOutput(work, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return false, fmt.Errorf("cannot determine the upstream's default branch: %s", strings.TrimSpace(out))
+		}
+		plan.Base = strings.TrimSpace(out)
+	}
+	if out, err := gitOutput(work, "checkout", "-q", "-b", plan.Branch); err != nil {
+		return false, fmt.Errorf("cannot create branch %s: %s", plan.Branch, strings.TrimSpace(out))
+	}
+
+	dest := filepath.Join(work, filepath.FromSlash(plan.Rel))
+	_, err := os.Stat(dest)
+	plan.Updated = err == nil
+	if err := os.RemoveAll(dest); err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return false, err
+	}
+	if err := copyTree(skillDir, dest); err != nil {
+		return false, fmt.Errorf("cannot copy %s: %w", plan.Skill, err)
+	}
+	if out, err := gitOutput(work, "add", "-A", "--", plan.Rel); err != nil {
+		return false, fmt.Errorf("git add: %s", strings.TrimSpace(out))
+	}
+	if out, _ := gitOutput(work, "status", "--porcelain"); strings.TrimSpace(out) == "" {
+		return false, nil
+	}
+
+	if plan.Title == "" {
+		verb := "Add"
+		if plan.Updated {
+			verb = "Update"
+		}
+		plan.Title = fmt.Sprintf("%s skill %s", verb, plan.Skill)
+		if meta, ok := parseSkillMeta(filepath.Join(skillDir, "SKILL.md")); ok && meta.Version != "" {
+			plan.Title += " " + meta.Version
+		}
+	}
+	commitArgs := append(append([]string{}, identity...), "commit", "-q", "-m", plan.Title)
+	if out, err := gitOutput(work, commitArgs...); err != nil {
+		return false, fmt.Errorf("git commit: %s", strings.TrimSpace(out))
+	}
+	return true, nil
+}
+
+// gitIdentityArgs returns -c flags carrying the user.name and user.email of
+// repo, so commits in a scratch clone get the Hub's identity.
+func gitIdentityArgs(repo string) []string {
+	var args []string
+	for _, key := range []string{"user.name", "user.email"} {
+		if v, _ := gitConfigValue(repo, key); v != "" {
+			args = append(args, "-c", key+"="+v)
+		}
+	}
+	return args
+}
+
+// publishBody is the pull request description.
+func publishBody(plan publishPlan) string {
+	verb := "adds"
+	if plan.Updated {
+		verb = "updates"
+	}
+	return fmt.Sprintf("This pull request %s the skill `%s` at `%s`.\n\nOpened with `axon publish`.", verb, plan.Skill, plan.Rel)
+}
+
+// pullRequest is the body of a GitHub "create a pull request" call.
+type pullRequest struct {
+	Title string `json:"title"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Body  string `json:"body"`
+}
+
+// createPullRequest opens pr against gh and returns its web URL.
+func createPullRequest(ctx context.Context, gh *githubSource, tok string, pr pullRequest) (string, error) {
+	payload, err := json.Marshal(pr)
+	if err != nil {
+		return "", err
+	}
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls", gh.apiBase, gh.owner, gh.repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "axon-cli")
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+tok)
+
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("pull request api request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("pull request api request failed: %s\n%s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	var created struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", fmt.Errorf("invalid pull request api response: %w", err)
+	}
+	return created.HTMLURL, nil
+}
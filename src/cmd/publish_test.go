@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPublishRepo(t *testing.T) {
+	for in, want := range map[string]string{
+		"kamusis/axon-hub":                        "https://api.github.com kamusis axon-hub",
+		"https://github.com/kamusis/axon-hub.git": "https://api.github.com kamusis axon-hub",
+		"git@github.com:kamusis/axon-hub.git":     "https://api.github.com kamusis axon-hub",
+		"https://ghe.corp/team/hub":               "https://ghe.corp/api/v3 team hub",
+	} {
+		gh, err := publishRepo(in)
+		if err != nil {
+			t.Errorf("publishRepo(%q): %v", in, err)
+			continue
+		}
+		if got := gh.apiBase + " " + gh.owner + " " + gh.repo; got != want {
+			t.Errorf("publishRepo(%q) = %s, want %s", in, got, want)
+		}
+	}
+	if _, err := publishRepo("https://gitlab.com/group/hub"); err == nil {
+		t.Error("a GitLab upstream should be refused")
+	}
+}
+
+func TestPreparePublishBranch(t *testing.T) {
+	upstream := makeLocalVendorRepo(t, "skills/other", "SKILL.md", "---\nname: other\n---\n")
+	skillDir := filepath.Join(t.TempDir(), "pdf")
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("---\nname: pdf\nversion: 1.0.0\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	identity := []string{"-c", "user.name=Axon Test", "-c", "user.email=test@axon.local"}
+
+	work := filepath.Join(t.TempDir(), "work")
+	plan := publishPlan{Skill: "pdf", Rel: "skills/pdf", Branch: "axon/publish-pdf"}
+	changed, err := preparePublishBranch(work, upstream, skillDir, identity, &plan)
+	if err != nil || !changed {
+		t.Fatalf("preparePublishBranch = %v, %v", changed, err)
+	}
+	if plan.Base == "" || plan.Updated || plan.Title != "Add skill pdf 1.0.0" {
+		t.Errorf("plan = %+v", plan)
+	}
+	if out, _ := gitOutput(work, "show", "--name-only", "--format=", "HEAD"); strings.TrimSpace(out) != "skills/pdf/SKILL.md" {
+		t.Errorf("commit touches %q, want skills/pdf/SKILL.md", out)
+	}
+
+	// Publishing what upstream already has is a no-op.
+	work2 := filepath.Join(t.TempDir(), "work")
+	plan2 := publishPlan{Skill: "other", Rel: "skills/other", Branch: "axon/publish-other"}
+	changed, err = preparePublishBranch(work2, upstream, filepath.Join(upstream, "skills", "other"), identity, &plan2)
+	if err != nil || changed {
+		t.Errorf("unchanged skill: changed = %v, err = %v", changed, err)
+	}
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	var got pullRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/team/hub/pulls" || r.Header.Get("Authorization") != "Bearer tok" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"html_url": "https://github.com/team/hub/pull/7"}`))
+	}))
+	defer srv.Close()
+
+	gh := &githubSource{apiBase: srv.URL, owner: "team", repo: "hub"}
+	url, err := createPullRequest(context.Background(), gh, "tok", pullRequest{Title: "Add skill pdf", Head: "me:axon/publish-pdf", Base: "main"})
+	if err != nil {
+		t.Fatalf("createPullRequest: %v", err)
+	}
+	if url != "https://github.com/team/hub/pull/7" || got.Head != "me:axon/publish-pdf" {
+		t.Errorf("url = %s, request = %+v", url, got)
+	}
+}
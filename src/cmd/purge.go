@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Unlink every target and remove Axon's data from this machine",
+	Long: `Uninstall Axon's footprint from this machine:
+
+  1. Unlink every target, restoring the latest backup of each destination.
+  2. After confirmation, remove what axon keeps in ~/.axon (Hub, config,
+     backups, locks, caches, indexes) and the backup left by 'axon update'
+     next to the binary. ~/.axon itself is removed only when nothing else is
+     left in it, since AXON_HOME may point at a directory shared with other
+     data; anything axon did not create is kept and listed.
+
+Nothing is removed while a destination still links into the Hub, so no tool
+is left with a dangling symlink. The confirmation warns when the Hub has
+uncommitted or unpushed changes. A Hub configured outside ~/.axon is never
+removed.
+
+  axon purge              Unlink, then ask before removing ~/.axon
+  axon purge --keep-hub   Keep the Hub repo(s); remove everything else
+  axon purge --yes        Do not ask for confirmation`,
+	Args: cobra.NoArgs,
+	RunE: runPurge,
+}
+
+var (
+	flagPurgeKeepHub bool
+	flagPurgeYes     bool
+)
+
+func init() {
+	purgeCmd.Flags().BoolVar(&flagPurgeKeepHub, "keep-hub", false, "Keep the Hub repo(s) inside ~/.axon")
+	purgeCmd.Flags().BoolVarP(&flagPurgeYes, "yes", "y", false, "Do not ask for confirmation")
+	rootCmd.AddCommand(purgeCmd)
+}
+
+func runPurge(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nFix axon.yaml so the targets can be unlinked, or remove ~/.axon by hand.", err)
+	}
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return err
+	}
+
+	// ── 1. Unlink ─────────────────────────────────────────────────────────────
+	if err := runUnlink(cmd, nil); err != nil {
+		return fmt.Errorf("%w; nothing was removed", err)
+	}
+	if left := linksIntoAxon(cfg, axonDir); len(left) > 0 {
+		for _, l := range left {
+			printErr("", l)
+		}
+		return fmt.Errorf("%d destination(s) still link into the Hub; nothing was removed", len(left))
+	}
+
+	// ── 2. Plan the removal ───────────────────────────────────────────────────
+	paths, keptHubs := purgePaths(cfg, axonDir)
+	if len(paths) == 0 {
+		printOK("", "Targets unlinked; there is nothing else to remove.")
+		return nil
+	}
+	printSection("Purge")
+	printBullet("Will remove:")
+	for _, p := range paths {
+		printListItem(iconError, p)
+	}
+	if len(keptHubs) > 0 {
+		printBullet("Will keep:")
+		for _, p := range keptHubs {
+			printListItem(iconOK, p)
+		}
+	}
+	warnUnsavedHubWork(cfg, axonDir)
+
+	if !flagPurgeYes && !confirm(cmd.InOrStdin(), "Remove these?") {
+		printSkip("", "targets unlinked; ~/.axon was left in place")
+		return nil
+	}
+
+	// ── 3. Remove ─────────────────────────────────────────────────────────────
+	// The Hub lock lives under ~/.axon/locks; let go of it first.
+	releaseHubLock()
+	releaseHubLock = func() {}
+	var failed int
+	for _, p := range paths {
+		if err := os.RemoveAll(p); err != nil {
+			printErr("", fmt.Sprintf("cannot remove %s: %v", p, err))
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d path(s) could not be removed", failed)
+	}
+	if other := foreignEntries(axonDir, keptHubs); len(other) > 0 {
+		printWarn("", fmt.Sprintf("%s was kept; it holds files axon did not create:", axonDir))
+		for _, name := range other {
+			printListItem(iconInfo, filepath.Join(axonDir, name))
+		}
+	} else if len(keptHubs) == 0 {
+		_ = os.Remove(axonDir) // fails harmlessly when not empty
+	}
+	printOK("", "Axon's data was removed from this machine.")
+	if exe, err := os.Executable(); err == nil {
+		printInfo("", fmt.Sprintf("Delete %s to finish uninstalling.", exe))
+	}
+	return nil
+}
+
+// linksIntoAxon describes the target destinations that are still symlinks
+// into axonDir or one of the Hub repos.
+func linksIntoAxon(cfg *config.Config, axonDir string) []string {
+	roots := []string{axonDir}
+	for _, r := range cfg.HubRepos() {
+		roots = append(roots, r.Path)
+	}
+	var left []string
+	for _, t := range cfg.Targets {
+		dest, err := config.ExpandPath(t.Destination)
+		if err != nil {
+			continue
+		}
+		target, err := os.Readlink(dest)
+		if err != nil {
+			continue
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(dest), target)
+		}
+		for _, root := range roots {
+			if pathWithin(root, target) {
+				left = append(left, fmt.Sprintf("%s → %s", dest, target))
+				break
+			}
+		}
+	}
+	return left
+}
+
+// axonOwnedEntries are the files and directories axon creates directly in
+// its home directory. purge removes these and nothing else from it.
+var axonOwnedEntries = []string{
+	"axon.yaml", ".env", "repo", "backups", "cache", "locks", "search", "tmp",
+	"versions", "trash", "merged", "audit-results", "completion",
+	journal.FileName, "usage.json", serveTokenFile, "update.lock", "allowed_signers", "minisign.pub",
+}
+
+// purgePaths returns what 'axon purge' removes: the entries of axonDir that
+// axon owns, including Hub repos inside it unless --keep-hub is set, plus
+// the binary backup left by 'axon update'. keptHubs lists the repos spared
+// by --keep-hub.
+func purgePaths(cfg *config.Config, axonDir string) (paths, keptHubs []string) {
+	owned := make(map[string]bool)
+	for _, name := range axonOwnedEntries {
+		owned[name] = true
+	}
+	keep := make(map[string]bool)
+	for _, r := range cfg.HubRepos() {
+		rel, err := filepath.Rel(axonDir, r.Path)
+		if err != nil || rel == "." || !pathWithin(axonDir, r.Path) {
+			continue
+		}
+		top := strings.Split(rel, string(filepath.Separator))[0]
+		owned[top] = true
+		if flagPurgeKeepHub && !keep[top] {
+			keep[top] = true
+			keptHubs = append(keptHubs, filepath.Join(axonDir, top))
+		}
+	}
+
+	entries, _ := os.ReadDir(axonDir)
+	for _, e := range entries {
+		if owned[e.Name()] && !keep[e.Name()] {
+			paths = append(paths, filepath.Join(axonDir, e.Name()))
+		}
+	}
+	if exe, err := os.Executable(); err == nil {
+		if _, err := os.Stat(exe + ".bak"); err == nil {
+			paths = append(paths, exe+".bak")
+		}
+	}
+	sort.Strings(keptHubs)
+	return paths, keptHubs
+}
+
+// foreignEntries returns the names left in axonDir after a purge other than
+// the kept Hub repos, i.e. files axon did not create.
+func foreignEntries(axonDir string, keptHubs []string) []string {
+	kept := make(map[string]bool, len(keptHubs))
+	for _, p := range keptHubs {
+		kept[filepath.Base(p)] = true
+	}
+	entries, _ := os.ReadDir(axonDir)
+	var names []string
+	for _, e := range entries {
+		if !kept[e.Name()] {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// warnUnsavedHubWork warns about Hub repos inside axonDir that purge would
+// delete with uncommitted edits or commits no remote has.
+func warnUnsavedHubWork(cfg *config.Config, axonDir string) {
+	if flagPurgeKeepHub || checkGitAvailable() != nil {
+		return
+	}
+	for _, r := range cfg.HubRepos() {
+		if !pathWithin(axonDir, r.Path) {
+			continue
+		}
+		if dirty, err := gitIsDirty(r.Path); err == nil && dirty {
+			printWarn(r.Name, "the Hub has uncommitted changes that will be lost")
+		}
+		if !gitHasRemote(r.Path) {
+			printWarn(r.Name, "the Hub has no remote; its history exists only here")
+		} else if _, ahead, _, err := hubAheadBehind(r.Path); err == nil && ahead > 0 {
+			printWarn(r.Name, fmt.Sprintf("%d commit(s) have not been pushed", ahead))
+		}
+	}
+}
+
+// pathWithin reports whether p is root or lies below it.
+func pathWithin(root, p string) bool {
+	rel, err := filepath.Rel(filepath.Clean(root), filepath.Clean(p))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// setupPurgeTest saves a config whose Hub lives in ~/.axon/repo and links
+// its one target over an existing directory, which gets backed up.
+func setupPurgeTest(t *testing.T) (cfg *config.Config, home, dest string) {
+	t.Helper()
+	tmp := t.TempDir()
+	home = filepath.Join(tmp, "home")
+	t.Setenv("HOME", home)
+	hub := filepath.Join(home, ".axon", "repo")
+	dest = filepath.Join(tmp, "dest", "skills")
+	for _, dir := range []string{filepath.Join(hub, "skills"), dest} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dest, "mine.md"), []byte("original"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg = &config.Config{
+		RepoPath: hub,
+		SyncMode: "read-write",
+		Targets:  []config.Target{{Name: "test-skills", Source: "skills", Destination: dest, Type: "directory"}},
+	}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := linkTargets(cfg, cfg.Targets, linkOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	return cfg, home, dest
+}
+
+func TestPurge(t *testing.T) {
+	_, home, dest := setupPurgeTest(t)
+	flagPurgeYes = true
+	t.Cleanup(func() { flagPurgeYes = false })
+
+	if err := runPurge(purgeCmd, nil); err != nil {
+		t.Fatalf("runPurge: %v", err)
+	}
+	if info, err := os.Lstat(dest); err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Fatalf("%s should be the restored directory: %v", dest, err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "mine.md")); err != nil {
+		t.Errorf("backup not restored: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".axon")); !os.IsNotExist(err) {
+		t.Errorf("~/.axon should be removed, stat err = %v", err)
+	}
+}
+
+func TestPurge_KeepsForeignFiles(t *testing.T) {
+	_, home, _ := setupPurgeTest(t)
+	flagPurgeYes = true
+	t.Cleanup(func() { flagPurgeYes = false })
+	axonDir := filepath.Join(home, ".axon")
+	if err := os.WriteFile(filepath.Join(axonDir, "notes.txt"), []byte("mine"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runPurge(purgeCmd, nil); err != nil {
+		t.Fatalf("runPurge: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(axonDir, "notes.txt")); err != nil {
+		t.Errorf("a file axon did not create was removed: %v", err)
+	}
+	for _, gone := range []string{"axon.yaml", "repo", "backups"} {
+		if _, err := os.Stat(filepath.Join(axonDir, gone)); !os.IsNotExist(err) {
+			t.Errorf("~/.axon/%s should be removed, stat err = %v", gone, err)
+		}
+	}
+}
+
+func TestPurge_KeepHub(t *testing.T) {
+	cfg, home, _ := setupPurgeTest(t)
+	flagPurgeYes, flagPurgeKeepHub = true, true
+	t.Cleanup(func() { flagPurgeYes, flagPurgeKeepHub = false, false })
+
+	if err := runPurge(purgeCmd, nil); err != nil {
+		t.Fatalf("runPurge --keep-hub: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.RepoPath, "skills")); err != nil {
+		t.Errorf("the Hub should be kept: %v", err)
+	}
+	for _, gone := range []string{"axon.yaml", "backups"} {
+		if _, err := os.Stat(filepath.Join(home, ".axon", gone)); !os.IsNotExist(err) {
+			t.Errorf("~/.axon/%s should be removed, stat err = %v", gone, err)
+		}
+	}
+}
+
+func TestPurge_Declined(t *testing.T) {
+	_, home, dest := setupPurgeTest(t)
+	purgeCmd.SetIn(strings.NewReader("n\n"))
+	t.Cleanup(func() { purgeCmd.SetIn(nil) })
+
+	if err := runPurge(purgeCmd, nil); err != nil {
+		t.Fatalf("runPurge: %v", err)
+	}
+	if info, err := os.Lstat(dest); err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Errorf("targets should be unlinked even when removal is declined: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".axon", "axon.yaml")); err != nil {
+		t.Errorf("~/.axon should be left in place: %v", err)
+	}
+}
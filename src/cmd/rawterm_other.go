@@ -0,0 +1,48 @@
+//go:build !windows
+
+package cmd
+
+import "golang.org/x/sys/unix"
+
+// isTerminal reports whether fd is a terminal.
+func isTerminal(fd uintptr) bool {
+	_, err := unix.IoctlGetTermios(int(fd), ioctlGetTermios)
+	return err == nil
+}
+
+// enableEscapes reports whether the terminal understands escape sequences;
+// Unix terminals always do.
+func enableEscapes(_ uintptr) bool {
+	return true
+}
+
+// makeRaw puts the terminal on in into raw mode (no echo, no line
+// buffering, no signals) and returns a function restoring it. Unix
+// terminals already understand escape sequences, so out is left alone.
+func makeRaw(in, _ uintptr) (func(), error) {
+	old, err := unix.IoctlGetTermios(int(in), ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+	raw := *old
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(int(in), ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return func() { _ = unix.IoctlSetTermios(int(in), ioctlSetTermios, old) }, nil
+}
+
+// terminalSize returns the columns and rows of the terminal on fd.
+func terminalSize(fd uintptr) (int, int, error) {
+	ws, err := unix.IoctlGetWinsize(int(fd), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
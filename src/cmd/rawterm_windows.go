@@ -0,0 +1,58 @@
+//go:build windows
+
+package cmd
+
+import "golang.org/x/sys/windows"
+
+// isTerminal reports whether fd is a console.
+func isTerminal(fd uintptr) bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(fd), &mode) == nil
+}
+
+// enableEscapes turns on escape-sequence output for the console on fd and
+// reports whether it is on. Consoles older than Windows 10 cannot.
+func enableEscapes(fd uintptr) bool {
+	var mode uint32
+	if err := windows.GetConsoleMode(windows.Handle(fd), &mode); err != nil {
+		return false
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(windows.Handle(fd), mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
+
+// makeRaw switches the console on in to raw virtual-terminal input and the
+// one on out to escape-sequence output, and returns a function restoring
+// both.
+func makeRaw(in, out uintptr) (func(), error) {
+	var inMode, outMode uint32
+	if err := windows.GetConsoleMode(windows.Handle(in), &inMode); err != nil {
+		return nil, err
+	}
+	if err := windows.GetConsoleMode(windows.Handle(out), &outMode); err != nil {
+		return nil, err
+	}
+	raw := inMode&^(windows.ENABLE_ECHO_INPUT|windows.ENABLE_LINE_INPUT|windows.ENABLE_PROCESSED_INPUT) | windows.ENABLE_VIRTUAL_TERMINAL_INPUT
+	if err := windows.SetConsoleMode(windows.Handle(in), raw); err != nil {
+		return nil, err
+	}
+	if err := windows.SetConsoleMode(windows.Handle(out), outMode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+		_ = windows.SetConsoleMode(windows.Handle(in), inMode)
+		return nil, err
+	}
+	return func() {
+		_ = windows.SetConsoleMode(windows.Handle(in), inMode)
+		_ = windows.SetConsoleMode(windows.Handle(out), outMode)
+	}, nil
+}
+
+// terminalSize returns the columns and rows of the console window on fd.
+func terminalSize(fd uintptr) (int, int, error) {
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(fd), &info); err != nil {
+		return 0, 0, err
+	}
+	return int(info.Window.Right-info.Window.Left) + 1, int(info.Window.Bottom-info.Window.Top) + 1, nil
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/registry"
+	"github.com/kamusis/axon-cli/internal/vendor"
+	"github.com/spf13/cobra"
+)
+
+var browseCmd = &cobra.Command{
+	Use:   "browse [query]",
+	Short: "List the skills available from skill registries",
+	Long: `List the skills a registry offers, with their versions and descriptions.
+A query filters by name, description, and tags. Skills already in the Hub
+are marked.
+
+Registries are configured in the 'registries' block of axon.yaml; without
+one, the community registry is used. When a registry cannot be reached,
+the copy from its last successful fetch is shown.
+
+Examples:
+  axon browse
+  axon browse pdf
+  axon browse --registry work`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBrowse,
+}
+
+var installCmd = &cobra.Command{
+	Use:   "install <registry>/<skill>",
+	Short: "Install a skill from a registry into the Hub",
+	Long: `Install a skill listed by 'axon browse' into skills/<skill> in the Hub.
+
+The skill is added to the 'vendors' block of axon.yaml as
+'<registry>-<skill>', with the registry it came from recorded, and
+mirrored like any vendor entry. 'axon outdated' then reports newer
+versions, and 'axon update-skill <skill>' pulls them. Without a registry
+prefix, the first configured registry that lists the skill is used.
+
+Commit the installed skill with 'axon sync'.
+
+Examples:
+  axon install community/pdf
+  axon install pdf`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInstall,
+}
+
+var flagBrowseRegistry string
+
+func init() {
+	browseCmd.Flags().StringVar(&flagBrowseRegistry, "registry", "", "Only list this registry")
+	rootCmd.AddCommand(browseCmd, installCmd)
+}
+
+func runBrowse(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	registries := cfg.EffectiveRegistries()
+	if flagBrowseRegistry != "" {
+		r, ok := cfg.FindRegistry(flagBrowseRegistry)
+		if !ok {
+			return fmt.Errorf("registry %q not found in axon.yaml", flagBrowseRegistry)
+		}
+		registries = []config.Registry{r}
+	}
+	query := ""
+	if len(args) == 1 {
+		query = args[0]
+	}
+
+	var found, failed int
+	for _, r := range registries {
+		printSection("Registry: " + r.Name)
+		ix, err := loadRegistry(commandContext(cmd), r)
+		if err != nil {
+			printErr(r.Name, err.Error())
+			failed++
+			continue
+		}
+		skills := ix.Search(query)
+		if len(skills) == 0 {
+			printSkip("", "no matching skills")
+			continue
+		}
+		for _, s := range skills {
+			icon := iconItem
+			if hubHasSkill(cfg, s.Name) {
+				icon = iconOK
+			}
+			line := s.Name
+			if s.Version != "" {
+				line += "  v" + strings.TrimPrefix(s.Version, "v")
+			}
+			if s.Description != "" {
+				line += "  — " + s.Description
+			}
+			printListItem(icon, line)
+		}
+		found += len(skills)
+	}
+	if failed == len(registries) {
+		return fmt.Errorf("no registry could be read")
+	}
+	if found > 0 {
+		fmt.Printf("\n  %s = already in the Hub. Install with 'axon install <registry>/<skill>'.\n", iconOK)
+	}
+	return nil
+}
+
+func runInstall(cmd *cobra.Command, args []string) error {
+	if err := checkGitAvailable(); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	r, s, err := findRegistrySkill(commandContext(cmd), cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	v := config.Vendor{
+		Name:     r.Name + "-" + s.Name,
+		Repo:     s.Repo,
+		Subdir:   s.Subdir,
+		Dest:     filepath.ToSlash(filepath.Join("skills", s.Name)),
+		Ref:      s.Ref,
+		Registry: r.Name,
+	}
+	for _, existing := range cfg.Vendors {
+		if existing.Name == v.Name {
+			return fmt.Errorf("%s/%s is already installed.\nTip: run 'axon update-skill %s' to update it.", r.Name, s.Name, s.Name)
+		}
+	}
+	if hubHasSkill(cfg, s.Name) {
+		return fmt.Errorf("skills/%s already exists in the Hub; remove it first to install %s/%s", s.Name, r.Name, s.Name)
+	}
+	if err := validateVendors(append(append([]config.Vendor{}, cfg.Vendors...), v)); err != nil {
+		return err
+	}
+	if _, err := exec.LookPath("rsync"); err != nil {
+		printWarn("", "rsync not found — will use cp fallback for mirroring")
+	}
+
+	printSection("Install")
+	// A state file left by an earlier install would make the sync skip the
+	// mirror as already up to date.
+	_ = vendor.WriteVendorSHA(v.Name, "")
+	if _, err := syncVendorEntry(cfg.RepoPath, v); err != nil {
+		printErr(v.Name, err.Error())
+		return fmt.Errorf("install failed")
+	}
+	if err := config.AddVendor(v); err != nil {
+		_ = os.RemoveAll(filepath.Join(cfg.RepoPath, filepath.FromSlash(v.Dest)))
+		_ = vendor.WriteVendorSHA(v.Name, "")
+		return fmt.Errorf("%w; the skill was removed from the Hub again", err)
+	}
+	recordVendorSync(v)
+
+	msg := fmt.Sprintf("installed into %s from %s", v.Dest, r.Name)
+	if meta, ok := parseSkillMeta(filepath.Join(cfg.RepoPath, filepath.FromSlash(v.Dest), "SKILL.md")); ok && meta.Version != "" {
+		msg += fmt.Sprintf(" (version %s)", meta.Version)
+	}
+	printOK(s.Name, msg)
+	fmt.Println("   Run 'axon sync' to commit the skill, then 'axon link' if it is not linked yet.")
+	return nil
+}
+
+// findRegistrySkill resolves '<registry>/<skill>', or a bare skill name
+// looked up in each registry in turn.
+func findRegistrySkill(ctx context.Context, cfg *config.Config, arg string) (config.Registry, registry.Skill, error) {
+	regName, skillName, qualified := strings.Cut(arg, "/")
+	registries := cfg.EffectiveRegistries()
+	if qualified {
+		r, ok := cfg.FindRegistry(regName)
+		if !ok {
+			return config.Registry{}, registry.Skill{}, fmt.Errorf("registry %q not found in axon.yaml", regName)
+		}
+		registries = []config.Registry{r}
+	} else {
+		skillName = arg
+	}
+
+	var lastErr error
+	for _, r := range registries {
+		ix, err := loadRegistry(ctx, r)
+		if err != nil {
+			printWarn(r.Name, err.Error())
+			lastErr = err
+			continue
+		}
+		if s, ok := ix.Find(skillName); ok {
+			return r, s, nil
+		}
+	}
+	if lastErr != nil && len(registries) == 1 {
+		return config.Registry{}, registry.Skill{}, lastErr
+	}
+	return config.Registry{}, registry.Skill{}, fmt.Errorf("skill %q not found in any registry.\nTip: run 'axon browse' to see available skills.", arg)
+}
+
+// loadRegistry fetches the index of r, falling back to the copy cached by
+// the last successful fetch when r cannot be reached.
+func loadRegistry(ctx context.Context, r config.Registry) (*registry.Index, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return nil, err
+	}
+	cacheFile := filepath.Join(axonDir, "cache", "registries", r.Name+".json")
+	ix, err := registry.Fetch(ctx, r.URL, cacheFile)
+	if err == nil {
+		return ix, nil
+	}
+	cached, cerr := registry.LoadCached(cacheFile)
+	if cerr != nil {
+		return nil, err
+	}
+	printWarn(r.Name, fmt.Sprintf("%v; using the cached index", err))
+	return cached, nil
+}
+
+// hubHasSkill reports whether skills/<name> exists in the primary Hub.
+func hubHasSkill(cfg *config.Config, name string) bool {
+	_, err := os.Stat(filepath.Join(cfg.RepoPath, "skills", name))
+	return err == nil
+}
+
+// commandContext returns cmd's context, or a background context when the
+// command runs outside Execute (e.g. in tests).
+func commandContext(cmd *cobra.Command) context.Context {
+	if cmd != nil && cmd.Context() != nil {
+		return cmd.Context()
+	}
+	return context.Background()
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/vendor"
+)
+
+func TestInstallFromRegistry(t *testing.T) {
+	resetVendorCache(t)
+	orig := vendor.RsyncAvailable
+	vendor.RsyncAvailable = func() bool { return false }
+	t.Cleanup(func() { vendor.RsyncAvailable = orig })
+
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	if err := os.MkdirAll(filepath.Join(tmp, ".axon"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	srcRepo := makeLocalVendorRepo(t, "skills/pdf", "SKILL.md", "---\nname: pdf\ndescription: Fill PDF forms\nversion: 1.2.0\n---\n")
+	index := filepath.Join(tmp, "index.json")
+	entry := `{"skills": [{"name": "pdf", "description": "Fill PDF forms", "version": "1.2.0", "repo": "` + filepath.ToSlash(srcRepo) + `", "subdir": "skills/pdf", "ref": "master"}]}`
+	if err := os.WriteFile(index, []byte(entry), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Registries = []config.Registry{{Name: "local", URL: index}}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runInstall(installCmd, []string{"local/pdf"}); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.RepoPath, "skills", "pdf", "SKILL.md")); err != nil {
+		t.Fatalf("skill not mirrored: %v", err)
+	}
+	loaded, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Vendors) != 1 || loaded.Vendors[0].Name != "local-pdf" || loaded.Vendors[0].Registry != "local" {
+		t.Fatalf("vendors = %+v", loaded.Vendors)
+	}
+	skills, err := vendoredSkills(loaded)
+	if err != nil || len(skills) != 1 || skills[0].Name != "pdf" {
+		t.Errorf("installed skill should be vendored for 'axon outdated': %+v, %v", skills, err)
+	}
+
+	err = runInstall(installCmd, []string{"pdf"})
+	if err == nil || !strings.Contains(err.Error(), "already installed") {
+		t.Errorf("second install: err = %v, want already installed", err)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var relinkCmd = &cobra.Command{
+	Use:   "relink --from <old-hub-path> [target-name]",
+	Short: "Repoint destinations that still link into an old Hub location",
+	Long: `After repo_path changes or the Hub is moved, every destination linked to
+the old location dangles. 'axon relink --from <old-path>' finds the
+destinations whose symlinks point into <old-path> (or that were copied
+from it, with link_mode: copy) and links them to the same content in the
+current Hub, as one 'axon link' transaction.
+
+Destinations linked anywhere else are left alone. 'axon status' and
+'axon doctor' suggest the command when they find links into a missing Hub.
+
+  axon relink --from ~/old-axon-hub
+  axon relink --from /mnt/old/.axon/repo cursor-skills`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRelink,
+}
+
+var flagRelinkFrom string
+
+func init() {
+	relinkCmd.Flags().StringVar(&flagRelinkFrom, "from", "", "Old Hub path the destinations still point into (required)")
+	_ = relinkCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(relinkCmd)
+}
+
+func runRelink(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	from, err := config.ExpandPath(flagRelinkFrom)
+	if err != nil {
+		return err
+	}
+	if from, err = filepath.Abs(from); err != nil {
+		return err
+	}
+	if filepath.Clean(from) == filepath.Clean(cfg.RepoPath) {
+		return fmt.Errorf("%s is the current repo_path; run 'axon link' instead", from)
+	}
+
+	targets := cfg.Targets
+	if len(args) == 1 {
+		t, ok := findTarget(cfg, args[0])
+		if !ok {
+			return fmt.Errorf("target %q not found in axon.yaml", args[0])
+		}
+		targets = []config.Target{t}
+	}
+	matched, err := relinkCandidates(cfg, targets, from)
+	if err != nil {
+		return err
+	}
+	if len(matched) == 0 {
+		printOK("", fmt.Sprintf("No destination links into %s.", from))
+		return nil
+	}
+
+	results, _, err := linkTargets(cfg, matched, linkOptions{})
+	if err != nil {
+		return err
+	}
+	printSection("Relink")
+	for _, r := range results {
+		printOK(r.name, r.detail)
+	}
+	return nil
+}
+
+// relinkCandidates returns the targets whose destination is a symlink into
+// from, or a copy made from it, sorted by name. A target whose source is
+// missing from the current Hub is an error, since relinking it would only
+// move the dangling link.
+func relinkCandidates(cfg *config.Config, targets []config.Target, from string) ([]config.Target, error) {
+	var matched []config.Target
+	var missing []string
+	for _, t := range targets {
+		dest, err := config.ExpandPath(t.Destination)
+		if err != nil {
+			continue
+		}
+		source, ok := copyMarkerSource(dest)
+		if raw, err := os.Readlink(dest); err == nil {
+			source, ok = absLinkTarget(dest, raw), true
+		}
+		if !ok || !pathWithin(from, source) {
+			continue
+		}
+		expected, _, err := targetLinkSource(cfg, t)
+		if err == nil {
+			_, err = os.Stat(expected)
+		}
+		if err != nil {
+			missing = append(missing, fmt.Sprintf("%s: %v", t.Name, err))
+			continue
+		}
+		matched = append(matched, t)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the current Hub at %s lacks the content of:\n  %s\nCheck repo_path in axon.yaml, or run 'axon sync' first.",
+			cfg.RepoPath, strings.Join(missing, "\n  "))
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched, nil
+}
+
+// missingHubRoot returns the Hub root a dangling symlink at dest once
+// pointed into: raw names <root>/<source> for t's source, nothing exists
+// there anymore, and root is not the current Hub.
+func missingHubRoot(cfg *config.Config, t config.Target, dest, raw string) (string, bool) {
+	abs := absLinkTarget(dest, raw)
+	if _, err := os.Stat(abs); !errors.Is(err, os.ErrNotExist) {
+		return "", false
+	}
+	suffix := string(filepath.Separator) + filepath.FromSlash(t.Source)
+	if !strings.HasSuffix(abs, suffix) {
+		return "", false
+	}
+	root := strings.TrimSuffix(abs, suffix)
+	if root == "" || root == filepath.Clean(cfg.RepoPath) {
+		return "", false
+	}
+	return root, true
+}
+
+// absLinkTarget resolves the symlink value raw of dest to a clean path.
+func absLinkTarget(dest, raw string) string {
+	if !filepath.IsAbs(raw) {
+		raw = filepath.Join(filepath.Dir(dest), raw)
+	}
+	return filepath.Clean(raw)
+}
+
+// findTarget returns the target called name.
+func findTarget(cfg *config.Config, name string) (config.Target, bool) {
+	for _, t := range cfg.Targets {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return config.Target{}, false
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// setupMovedHub links the target of a fresh link test, then moves the Hub
+// and points repo_path at the new location. It returns the old Hub path.
+func setupMovedHub(t *testing.T) (*config.Config, string) {
+	t.Helper()
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	if err := os.MkdirAll(filepath.Join(tmp, "dest"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := linkTargets(cfg, cfg.Targets, linkOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	old := cfg.RepoPath
+	cfg.RepoPath = filepath.Join(tmp, "new-hub")
+	if err := os.Rename(old, cfg.RepoPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	return cfg, old
+}
+
+func TestMissingHubRoot(t *testing.T) {
+	cfg, old := setupMovedHub(t)
+	tg := cfg.Targets[0]
+	dest := tg.Destination
+	raw, _ := os.Readlink(dest)
+
+	root, ok := missingHubRoot(cfg, tg, dest, raw)
+	if !ok || root != old {
+		t.Fatalf("missingHubRoot = %q, %v; want %q", root, ok, old)
+	}
+	if h := collectLinkHealth(cfg); len(h.missingHubs) != 1 || h.missingHubs[0] != old {
+		t.Errorf("collectLinkHealth missingHubs = %v, want [%s]", h.missingHubs, old)
+	}
+}
+
+func TestRelink(t *testing.T) {
+	cfg, old := setupMovedHub(t)
+	flagRelinkFrom = old
+	t.Cleanup(func() { flagRelinkFrom = "" })
+
+	if err := runRelink(nil, nil); err != nil {
+		t.Fatalf("runRelink: %v", err)
+	}
+	dest := cfg.Targets[0].Destination
+	if _, err := os.Stat(filepath.Join(dest, "sentinel.md")); err != nil {
+		t.Errorf("relinked destination should reach the moved Hub: %v", err)
+	}
+	if raw, _ := os.Readlink(dest); raw != filepath.Join(cfg.RepoPath, "skills") {
+		t.Errorf("%s → %s, want the new Hub", dest, raw)
+	}
+}
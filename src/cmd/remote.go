@@ -67,7 +67,7 @@ func runRemoteSet(cmd *cobra.Command, args []string) error {
 	// Best-effort: fetch origin and set origin/HEAD to the remote's default branch.
 	// This improves UX for commands that rely on origin/HEAD (e.g. status --fetch).
 	if out, err := gitOutput(repo, "fetch", "--prune", "origin"); err != nil {
-		printWarn("", fmt.Sprintf("git fetch origin failed; remote default branch may be unknown:\n%s", strings.TrimSpace(out)))
+		printWarn("", fmt.Sprintf("git fetch origin failed; remote default branch may be unknown:\n%s%s", strings.TrimSpace(out), gitRemoteHint(out, url)))
 	}
 	if err := gitRun("-C", repo, "remote", "set-head", "origin", "-a"); err != nil {
 		printWarn("", "could not set origin/HEAD automatically; remote default branch may be unknown")
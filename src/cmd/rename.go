@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/frontmatter"
+	"github.com/kamusis/axon-cli/internal/journal"
+	searchindex "github.com/kamusis/axon-cli/internal/search/index"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var renameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename Hub items and update references to them",
+}
+
+var renameSkillCmd = &cobra.Command{
+	Use:   "skill <old> <new>",
+	Short: "Rename a skill and update references to it across the Hub",
+	Long: `Rename a skill directory with 'git mv' and rewrite what refers to it:
+
+  - the skill's own name: field, and its name in its own triggers
+  - requires.skills entries in other skills' SKILL.md
+  - in every Markdown file of the Hub: paths such as skills/<old>/...
+    and 'axon run <old>' commands
+
+Only read-write repos are changed. References found in read-only repos are
+listed so they can be fixed upstream; editing them here would break their
+next pull.
+
+The keyword index and the local semantic index are updated to the new name,
+and merged views are refreshed. Nothing is committed; review the changes
+with 'git diff' and run 'axon sync'.
+
+Examples:
+  axon rename skill pdf pdf-tools
+  axon rename skill pdf pdf-tools --dry-run`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRenameSkill,
+}
+
+var flagRenameDryRun bool
+
+func init() {
+	renameSkillCmd.Flags().BoolVar(&flagRenameDryRun, "dry-run", false, "Show what would change without changing anything")
+	renameCmd.AddCommand(renameSkillCmd)
+	rootCmd.AddCommand(renameCmd)
+}
+
+// skillNameChars are the characters that can continue a skill name, so a
+// reference to "pdf" is not found inside "pdf-tools".
+const skillNameChars = `\w.-`
+
+func runRenameSkill(_ *cobra.Command, args []string) error {
+	oldName, newName := args[0], args[1]
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	if newName == "" || newName == "." || newName == ".." || strings.ContainsAny(newName, `/\:`) || strings.TrimSpace(newName) != newName {
+		return fmt.Errorf("invalid skill name %q", newName)
+	}
+	graph := loadSkillGraph(cfg)
+	node := graph[oldName]
+	if node == nil {
+		return fmt.Errorf("skill %q not found in the Hub", oldName)
+	}
+	if graph[newName] != nil {
+		return fmt.Errorf("a skill named %q already exists at %s", newName, graph[newName].Dir)
+	}
+	newDir := filepath.Join(filepath.Dir(node.Dir), newName)
+	if _, err := os.Lstat(newDir); err == nil {
+		return fmt.Errorf("%s already exists", newDir)
+	}
+	repo, root, err := skillRepoRoot(cfg, node.Dir)
+	if err != nil {
+		return err
+	}
+	if repo.SyncMode != "read-write" {
+		return fmt.Errorf("skill %q is in the read-only repo %q; rename it upstream", oldName, repo.Name)
+	}
+
+	title := "Rename skill"
+	if flagRenameDryRun {
+		title += " (dry run)"
+	}
+	printSection(title)
+
+	edits, readOnly, err := planSkillRename(cfg, node.Dir, root, oldName, newName)
+	if err != nil {
+		return err
+	}
+	if flagRenameDryRun {
+		printInfo("", fmt.Sprintf("would move %s/%s to %s/%s", root, oldName, root, newName))
+		for _, e := range edits {
+			printInfo(e.rel, fmt.Sprintf("%d reference(s) would be updated", e.count))
+		}
+		printReadOnlyRefs(readOnly)
+		return nil
+	}
+
+	if err := moveSkillDir(repo.Path, node.Dir, newDir); err != nil {
+		return err
+	}
+	printOK("", fmt.Sprintf("moved %s/%s to %s/%s", root, oldName, root, newName))
+
+	for _, e := range edits {
+		// Edits inside the skill moved with it.
+		path, rel := e.path, e.rel
+		if r, err := filepath.Rel(node.Dir, path); err == nil && !strings.HasPrefix(r, "..") {
+			path = filepath.Join(newDir, r)
+			rel = root + "/" + newName + "/" + filepath.ToSlash(r)
+		}
+		if err := os.WriteFile(path, e.content, e.mode); err != nil {
+			return fmt.Errorf("cannot update %s: %w", path, err)
+		}
+		printOK(rel, fmt.Sprintf("%d reference(s) updated", e.count))
+	}
+
+	printReadOnlyRefs(readOnly)
+
+	renameIndexes(cfg, repo, root, oldName, newName)
+	refreshMergedViews(cfg)
+	recordHistory(journal.Entry{
+		Op:     journal.OpRename,
+		Target: newName,
+		Detail: fmt.Sprintf("renamed skill %s to %s (%d file(s) updated)", oldName, newName, len(edits)),
+		Data:   map[string]string{"repo": repo.Path, "from": node.Dir, "to": newDir},
+	})
+	fmt.Println("\n  Review the changes with 'git diff', then run 'axon sync' to commit them.")
+	return nil
+}
+
+// skillRepoRoot returns the Hub repo holding the skill directory dir and
+// the search root it is under (slash-separated).
+func skillRepoRoot(cfg *config.Config, dir string) (config.Repo, string, error) {
+	for _, r := range cfg.HubRepos() {
+		rel, err := filepath.Rel(r.Path, filepath.Dir(dir))
+		if err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return r, filepath.ToSlash(rel), nil
+		}
+	}
+	return config.Repo{}, "", fmt.Errorf("%s is not inside a Hub repo", dir)
+}
+
+// moveSkillDir renames the skill with 'git mv', so git records a rename,
+// and falls back to a plain rename when the skill is not tracked yet.
+func moveSkillDir(repo, from, to string) error {
+	out, err := gitOutput(repo, "mv", from, to)
+	if err == nil {
+		return nil
+	}
+	if !strings.Contains(out, "not under version control") {
+		return fmt.Errorf("git mv failed: %s", firstLine(strings.TrimSpace(out), err))
+	}
+	if err := os.Rename(from, to); err != nil {
+		return fmt.Errorf("cannot move %s: %w", from, err)
+	}
+	return nil
+}
+
+// renameEdit is the new content of one file that refers to the skill.
+type renameEdit struct {
+	repo    string // Hub repo name
+	path    string // current path
+	rel     string // repo-relative, for display
+	content []byte
+	mode    fs.FileMode
+	count   int
+}
+
+// planSkillRename computes, without writing anything, the new content of
+// every Markdown file in the Hub that refers to the skill at dir. Files in
+// read-write repos are returned as edits; files in read-only repos, which
+// must not be changed locally, are returned separately to be reported.
+func planSkillRename(cfg *config.Config, dir, root, oldName, newName string) (edits, readOnly []renameEdit, err error) {
+	for _, repo := range cfg.HubRepos() {
+		for _, searchRoot := range cfg.EffectiveSearchRoots() {
+			base := filepath.Join(repo.Path, searchRoot)
+			err := filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					if p == base {
+						return filepath.SkipDir
+					}
+					return err
+				}
+				if d.IsDir() {
+					if d.Name() == ".git" {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if !strings.EqualFold(filepath.Ext(p), ".md") || !d.Type().IsRegular() {
+					return nil
+				}
+				info, err := d.Info()
+				if err != nil {
+					return err
+				}
+				data, err := os.ReadFile(p)
+				if err != nil {
+					return err
+				}
+				own := filepath.Clean(p) == filepath.Join(dir, "SKILL.md")
+				content, n := renameSkillRefs(data, root, oldName, newName, own)
+				if n == 0 {
+					return nil
+				}
+				rel, _ := filepath.Rel(repo.Path, p)
+				e := renameEdit{repo: repo.Name, path: p, rel: filepath.ToSlash(rel), content: content, mode: info.Mode().Perm(), count: n}
+				if repo.SyncMode == "read-write" {
+					edits = append(edits, e)
+				} else {
+					readOnly = append(readOnly, e)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].rel < edits[j].rel })
+	sort.Slice(readOnly, func(i, j int) bool {
+		if readOnly[i].repo != readOnly[j].repo {
+			return readOnly[i].repo < readOnly[j].repo
+		}
+		return readOnly[i].rel < readOnly[j].rel
+	})
+	return edits, readOnly, nil
+}
+
+// printReadOnlyRefs lists references left unchanged in read-only repos.
+func printReadOnlyRefs(refs []renameEdit) {
+	if len(refs) == 0 {
+		return
+	}
+	printBullet("References in read-only repos, not changed (fix them upstream):")
+	for _, e := range refs {
+		printItem(fmt.Sprintf("%s: %s (%d reference(s))", e.repo, e.rel, e.count))
+	}
+}
+
+// renameSkillRefs rewrites the references to skill oldName under root in a
+// Markdown file and returns the new content and how many references it
+// changed. Only unambiguous references count: <root>/<old> paths,
+// requires.skills entries, and 'axon run <old>'; the bare name in prose or
+// code spans may mean something else. own marks the skill's own SKILL.md,
+// whose name: field and triggers are rewritten too.
+func renameSkillRefs(data []byte, root, oldName, newName string, own bool) ([]byte, int) {
+	count := 0
+	if doc, err := frontmatter.Parse(data); err == nil && bytes.HasPrefix(data, []byte("---")) {
+		changed := 0
+		if n, ok := doc.Get("requires.skills"); ok && n.Kind == yaml.SequenceNode {
+			for _, item := range n.Content {
+				if item.Kind == yaml.ScalarNode && item.Value == oldName {
+					item.Value = newName
+					changed++
+				}
+			}
+		}
+		if own {
+			if n, ok := doc.Get("name"); ok && n.Kind == yaml.ScalarNode && n.Value == oldName {
+				n.Value = newName
+				changed++
+			}
+			if n, ok := doc.Get("triggers"); ok {
+				changed += renameInNode(n, wordPattern(oldName), newName)
+			}
+		}
+		if changed > 0 {
+			if out, err := doc.Bytes(); err == nil {
+				data, count = out, changed
+			}
+		}
+	}
+
+	text := string(data)
+	path := regexp.MustCompile(`(^|[^` + skillNameChars + `])` + regexp.QuoteMeta(root+"/"+oldName) + `([^` + skillNameChars + `]|\.?$|\.[^` + skillNameChars + `])`)
+	for _, r := range []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{path, "${1}" + root + "/" + newName + "${2}"},
+		{regexp.MustCompile(`axon run ` + regexp.QuoteMeta(oldName) + `(/|\s|$)`), "axon run " + newName + "${1}"},
+	} {
+		count += len(r.re.FindAllStringIndex(text, -1))
+		text = r.re.ReplaceAllString(text, r.repl)
+	}
+	return []byte(text), count
+}
+
+// wordPattern matches name as a whole skill name.
+func wordPattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^` + skillNameChars + `])` + regexp.QuoteMeta(name) + `([^` + skillNameChars + `]|$)`)
+}
+
+// renameInNode replaces re's name with newName in every scalar under n and
+// returns how many it replaced.
+func renameInNode(n *yaml.Node, re *regexp.Regexp, newName string) int {
+	if n.Kind == yaml.ScalarNode {
+		k := len(re.FindAllStringIndex(n.Value, -1))
+		if k > 0 {
+			n.Value = re.ReplaceAllString(n.Value, "${1}"+newName+"${2}")
+		}
+		return k
+	}
+	count := 0
+	for i, c := range n.Content {
+		// Leave mapping keys such as "pattern" alone.
+		if n.Kind == yaml.MappingNode && i%2 == 0 {
+			continue
+		}
+		count += renameInNode(c, re, newName)
+	}
+	return count
+}
+
+// renameIndexes points the search indexes at the renamed skill. The keyword
+// index is refreshed from disk; the local semantic index keeps the skill's
+// vectors under its new ID until the next 'axon search --index'.
+func renameIndexes(cfg *config.Config, repo config.Repo, root, oldName, newName string) {
+	dir, err := keywordIndexDir()
+	if err != nil {
+		return
+	}
+	if _, err := refreshKeywordIndex(cfg, dir, false); err != nil {
+		printWarn("", fmt.Sprintf("keyword index not updated: %v", err))
+	} else {
+		printOK("", "keyword index updated")
+	}
+
+	idx, err := searchindex.Load(dir)
+	if err != nil {
+		return // no local semantic index
+	}
+	renamed := 0
+	for i, e := range idx.Skills {
+		if e.ID != oldName || (e.Repo != "" && e.Repo != repo.Name) {
+			continue
+		}
+		idx.Skills[i].ID = newName
+		idx.Skills[i].Path = strings.Replace(e.Path, root+"/"+oldName, root+"/"+newName, 1)
+		if e.Name == oldName {
+			idx.Skills[i].Name = newName
+		}
+		renamed++
+	}
+	if renamed == 0 {
+		return
+	}
+	if err := searchindex.Write(dir, idx.Manifest, idx.Skills, idx.Vectors); err != nil {
+		printWarn("", fmt.Sprintf("semantic index not updated: %v", err))
+		return
+	}
+	printOK("", "semantic index updated")
+}
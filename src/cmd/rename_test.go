@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestRenameSkillRefs(t *testing.T) {
+	in := "---\nname: pdf\ndescription: Work with PDF files\ntriggers:\n  - pdf\n  - pdf-tools helper\n---\n" +
+		"See skills/pdf/SKILL.md and skills/pdf. Not skills/pdf-tools or skills/pdfx.\n" +
+		"Use `pdf`, not `pdf-tools`. Run axon run pdf/extract.py in.pdf\n"
+	out, n := renameSkillRefs([]byte(in), "skills", "pdf", "docs-pdf", true)
+	got := string(out)
+	for _, want := range []string{
+		"name: docs-pdf",
+		"- docs-pdf\n",
+		"- pdf-tools helper",
+		"See skills/docs-pdf/SKILL.md and skills/docs-pdf.",
+		"Not skills/pdf-tools or skills/pdfx.",
+		"Use `pdf`, not `pdf-tools`.",
+		"axon run docs-pdf/extract.py in.pdf",
+		"description: Work with PDF files",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if n != 5 {
+		t.Errorf("count = %d, want 5", n)
+	}
+}
+
+func TestRenameSkillRefsRequires(t *testing.T) {
+	in := "---\nname: report\nrequires:\n  skills: [pdf, pdf-tools]\n---\nBody mentions pdf.\n"
+	out, n := renameSkillRefs([]byte(in), "skills", "pdf", "docs-pdf", false)
+	got := string(out)
+	if !strings.Contains(got, "docs-pdf, pdf-tools") || !strings.Contains(got, "Body mentions pdf.") || n != 1 {
+		t.Errorf("count %d, got:\n%s", n, got)
+	}
+
+	// Other skills' names and triggers are left alone.
+	in = "---\nname: pdf\ntriggers: [pdf]\n---\n"
+	if _, n := renameSkillRefs([]byte(in), "skills", "pdf", "docs-pdf", false); n != 0 {
+		t.Errorf("count = %d for another skill's frontmatter, want 0", n)
+	}
+}
+
+func TestPlanSkillRename(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	skills := filepath.Join(tmp, "hub", "skills")
+	write := func(rel, content string) {
+		t.Helper()
+		p := filepath.Join(skills, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("pdf/SKILL.md", "---\nname: pdf\n---\n")
+	write("report/SKILL.md", "---\nname: report\nrequires:\n  skills:\n    - pdf\n---\n")
+	write("other/SKILL.md", "---\nname: other\n---\nNothing here.\n")
+
+	upstream := filepath.Join(tmp, "upstream")
+	if err := os.MkdirAll(filepath.Join(upstream, "skills", "guide"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(upstream, "skills", "guide", "SKILL.md"), []byte("See skills/pdf/SKILL.md.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Repos = append(cfg.Repos, config.Repo{Name: "upstream", Path: upstream})
+
+	edits, readOnly, err := planSkillRename(cfg, filepath.Join(skills, "pdf"), "skills", "pdf", "docs-pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(readOnly) != 1 || readOnly[0].repo != "upstream" || readOnly[0].rel != "skills/guide/SKILL.md" {
+		t.Errorf("read-only references = %+v", readOnly)
+	}
+	var rels []string
+	for _, e := range edits {
+		rels = append(rels, e.rel)
+	}
+	if strings.Join(rels, ",") != "skills/pdf/SKILL.md,skills/report/SKILL.md" {
+		t.Errorf("edits = %v", rels)
+	}
+	// Planning writes nothing.
+	if data, _ := os.ReadFile(filepath.Join(skills, "report", "SKILL.md")); strings.Contains(string(data), "docs-pdf") {
+		t.Error("planSkillRename changed a file")
+	}
+}
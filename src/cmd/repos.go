@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// ── Multi-repo Hubs ───────────────────────────────────────────────────────────
+// A target that draws from a single repo links straight to <repo>/<source>,
+// exactly as before repos were introduced. A target that draws from several
+// repos links to a merged view under ~/.axon/merged: a directory of
+// per-item symlinks where, for every item name, the highest-priority repo
+// providing it wins.
+//
+// Skills limited to some tools (tools: [claude] in SKILL.md) are left out of
+// the views of other tools' targets. A single-repo target whose source holds
+// such a skill links to a view as well, so Cursor never sees a Claude-only
+// skill.
+
+// mergedItem is one entry of a merged view.
+type mergedItem struct {
+	Name string
+	Repo string // winning repo name
+	Path string // absolute path inside the winning repo
+}
+
+// shadowedItem is an item hidden by a higher-priority repo.
+type shadowedItem struct {
+	Name   string
+	Winner string
+	Loser  string
+}
+
+// mergedViewPlan is the desired content of a merged view.
+type mergedViewPlan struct {
+	Items    []mergedItem
+	Shadowed []shadowedItem
+	Filtered []string // items limited to other tools
+	Style    string   // link style of the per-item symlinks
+}
+
+// mergedViewDir returns ~/.axon/merged/<target>, or
+// ~/.axon/merged/projects/<project-key>/<target> for project targets.
+func mergedViewDir(t config.Target) (string, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	if t.Project != "" {
+		return filepath.Join(axonDir, "merged", "projects", config.ProjectKey(t.Project), t.Name), nil
+	}
+	return filepath.Join(axonDir, "merged", t.Name), nil
+}
+
+// targetLinkSource returns the directory t's destination should point to and
+// the repos it draws from: <repo>/<source> for a single repo, or the merged
+// view when there are several or some items are limited to other tools.
+// File targets link the file itself.
+func targetLinkSource(cfg *config.Config, t config.Target) (string, []config.Repo, error) {
+	if t.IsFile() {
+		return fileTargetSource(cfg, t)
+	}
+	repos, err := cfg.TargetRepos(t)
+	if err != nil {
+		return "", nil, err
+	}
+	if !usesMergedView(t, repos) {
+		return filepath.Join(repos[0].Path, t.Source), repos, nil
+	}
+	view, err := mergedViewDir(t)
+	if err != nil {
+		return "", nil, err
+	}
+	return view, repos, nil
+}
+
+// usesMergedView reports whether t, drawing from repos, links to a merged
+// view rather than straight to its source.
+func usesMergedView(t config.Target, repos []config.Repo) bool {
+	if len(repos) != 1 {
+		return len(repos) > 1
+	}
+	if t.IsFile() || t.IsRules() {
+		return false
+	}
+	return hidesSkillsFrom(filepath.Join(repos[0].Path, t.Source), t.Tool())
+}
+
+// hidesSkillsFrom reports whether dir holds a skill limited to tools other
+// than tool.
+func hidesSkillsFrom(dir, tool string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), ".") && !skillAllowsTool(filepath.Join(dir, e.Name()), tool) {
+			return true
+		}
+	}
+	return false
+}
+
+// skillAllowsTool reports whether the item at path may be linked for tool:
+// anything but a skill whose SKILL.md lists tools without it.
+func skillAllowsTool(path, tool string) bool {
+	meta, ok := parseSkillMeta(filepath.Join(path, "SKILL.md"))
+	if !ok {
+		return true
+	}
+	tools := meta.GetTools()
+	return len(tools) == 0 || slices.Contains(tools, strings.ToLower(tool))
+}
+
+// planMergedView lists the items each repo provides under source, keeping
+// the first (highest-priority) repo for every name. Hidden entries and repos
+// without the source directory are skipped, and so are skills limited to
+// tools other than tool, unless tool is empty.
+func planMergedView(source string, repos []config.Repo, style, tool string) (mergedViewPlan, error) {
+	plan := mergedViewPlan{Style: style}
+	winners := make(map[string]string)
+	for _, r := range repos {
+		dir := filepath.Join(r.Path, source)
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return plan, fmt.Errorf("cannot read %s: %w", dir, err)
+		}
+		for _, e := range entries {
+			if strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			if tool != "" && !skillAllowsTool(filepath.Join(dir, e.Name()), tool) {
+				plan.Filtered = append(plan.Filtered, e.Name())
+				continue
+			}
+			if w, ok := winners[e.Name()]; ok {
+				plan.Shadowed = append(plan.Shadowed, shadowedItem{e.Name(), w, r.Name})
+				continue
+			}
+			winners[e.Name()] = r.Name
+			plan.Items = append(plan.Items, mergedItem{e.Name(), r.Name, filepath.Join(dir, e.Name())})
+		}
+	}
+	sort.Slice(plan.Items, func(i, j int) bool { return plan.Items[i].Name < plan.Items[j].Name })
+	return plan, nil
+}
+
+// mergedViewDrift compares view against plan and returns the item names that
+// are missing, point elsewhere, or use the other link style, the stale
+// symlinks to remove, and any real
+// files or directories that were written into the view directly (they belong
+// in a Hub repo and are never touched).
+func mergedViewDrift(view string, plan mergedViewPlan) (changed, stale, stray []string) {
+	want := make(map[string]string, len(plan.Items))
+	for _, it := range plan.Items {
+		want[it.Name] = it.Path
+	}
+	entries, _ := os.ReadDir(view)
+	have := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		p := filepath.Join(view, e.Name())
+		info, err := os.Lstat(p)
+		if err != nil {
+			continue
+		}
+		have[e.Name()] = true
+		if info.Mode()&os.ModeSymlink == 0 {
+			stray = append(stray, e.Name())
+			continue
+		}
+		target, _ := os.Readlink(p)
+		w, ok := want[e.Name()]
+		switch {
+		case !ok:
+			stale = append(stale, e.Name())
+		case target != w:
+			changed = append(changed, e.Name())
+		}
+	}
+	for _, it := range plan.Items {
+		if !have[it.Name] {
+			changed = append(changed, it.Name)
+		}
+	}
+	sort.Strings(changed)
+	return changed, stale, stray
+}
+
+// refreshMergedView brings view in line with the repos' current content and
+// returns the plan it applied along with any stray entries left in place.
+func refreshMergedView(view, source string, repos []config.Repo, style, tool string) (mergedViewPlan, []string, error) {
+	plan, err := planMergedView(source, repos, style, tool)
+	if err != nil {
+		return plan, nil, err
+	}
+	if err := os.MkdirAll(view, 0o755); err != nil {
+		return plan, nil, fmt.Errorf("cannot create merged view: %w", err)
+	}
+	changed, stale, stray := mergedViewDrift(view, plan)
+	for _, name := range stale {
+		if err := os.Remove(filepath.Join(view, name)); err != nil {
+			return plan, stray, fmt.Errorf("cannot remove stale entry %s: %w", name, err)
+		}
+	}
+	byName := make(map[string]string, len(plan.Items))
+	for _, it := range plan.Items {
+		byName[it.Name] = it.Path
+	}
+	strayed := make(map[string]bool, len(stray))
+	for _, s := range stray {
+		strayed[s] = true
+	}
+	for _, name := range changed {
+		if strayed[name] {
+			continue
+		}
+		p := filepath.Join(view, name)
+		link, err := symlinkValue(style, p, byName[name])
+		if err != nil {
+			return plan, stray, err
+		}
+		_ = os.Remove(p)
+		if err := os.Symlink(link, p); err != nil {
+			return plan, stray, fmt.Errorf("symlink %s → %s: %w", p, link, err)
+		}
+	}
+	return plan, stray, nil
+}
+
+// refreshMergedViews updates every merged view that already exists, e.g.
+// after 'axon sync' pulled new items.
+func refreshMergedViews(cfg *config.Config) {
+	seen := make(map[string]bool)
+	for _, t := range cfg.Targets {
+		view, repos, err := targetLinkSource(cfg, t)
+		if err != nil || !usesMergedView(t, repos) || seen[view] {
+			continue
+		}
+		seen[view] = true
+		if _, err := os.Stat(view); err != nil {
+			continue
+		}
+		if _, _, err := refreshMergedView(view, t.Source, repos, cfg.TargetLinkStyle(t), t.Tool()); err != nil {
+			printWarn(t.Name, fmt.Sprintf("cannot refresh merged view: %v", err))
+		}
+	}
+}
+
+// repoConfig returns a copy of cfg that treats r as the Hub, so single-repo
+// helpers (sync, excludes, signature checks) can operate on any repo.
+func repoConfig(cfg *config.Config, r config.Repo) *config.Config {
+	c := *cfg
+	c.RepoPath = r.Path
+	c.SyncMode = r.SyncMode
+	return &c
+}
+
+// nestedSource reports whether source is a subpath such as skills/db-only
+// rather than a top-level Hub directory.
+func nestedSource(source string) bool {
+	return strings.Contains(filepath.ToSlash(filepath.Clean(filepath.FromSlash(source))), "/")
+}
+
+// missingTargetSource returns an error when t has a nested source that none
+// of its repos provides. Top-level sources (skills, workflows, ...) are part
+// of the Hub's layout and 'axon link' creates them on demand; a missing
+// subpath is more likely a typo, so it is only created with --create-sources.
+func missingTargetSource(cfg *config.Config, t config.Target) error {
+	if t.IsFile() || !nestedSource(t.Source) {
+		return nil
+	}
+	repos, err := cfg.TargetRepos(t)
+	if err != nil {
+		return err
+	}
+	for _, r := range repos {
+		if info, err := os.Stat(filepath.Join(r.Path, t.Source)); err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("source %s in repo %s is not a directory", t.Source, r.Name)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("source %s does not exist in the Hub; create it, fix 'source' in axon.yaml, or run 'axon link --create-sources'", t.Source)
+}
+
+// createTargetSource creates t's source in the first repo it draws from.
+func createTargetSource(cfg *config.Config, t config.Target) error {
+	repos, err := cfg.TargetRepos(t)
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		return fmt.Errorf("target %q draws from no repo", t.Name)
+	}
+	if err := os.MkdirAll(filepath.Join(repos[0].Path, t.Source), 0o755); err != nil {
+		return fmt.Errorf("cannot create source %s: %w", t.Source, err)
+	}
+	return nil
+}
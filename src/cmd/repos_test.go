@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// setupMultiRepoTest creates a primary Hub and a higher-priority company Hub
+// that both provide skills/shared.
+func setupMultiRepoTest(t *testing.T) (*config.Config, string) {
+	t.Helper()
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	for _, dir := range []string{
+		filepath.Join(tmp, "personal", "skills", "shared"),
+		filepath.Join(tmp, "personal", "skills", "mine"),
+		filepath.Join(tmp, "company", "skills", "shared"),
+		filepath.Join(tmp, "company", "skills", "policy"),
+		filepath.Join(tmp, "dest"),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &config.Config{
+		RepoPath: filepath.Join(tmp, "personal"),
+		Repos:    []config.Repo{{Name: "company", Path: filepath.Join(tmp, "company"), Priority: 10}},
+		Targets: []config.Target{{
+			Name:        "test-skills",
+			Source:      "skills",
+			Destination: filepath.Join(tmp, "dest", "skills"),
+			Type:        "directory",
+		}},
+	}
+	return cfg, tmp
+}
+
+func TestPlanMergedView_Priority(t *testing.T) {
+	cfg, _ := setupMultiRepoTest(t)
+	plan, err := planMergedView("skills", cfg.HubRepos(), config.LinkStyleAbsolute, "")
+	if err != nil {
+		t.Fatalf("planMergedView: %v", err)
+	}
+	got := map[string]string{}
+	for _, it := range plan.Items {
+		got[it.Name] = it.Repo
+	}
+	want := map[string]string{"shared": "company", "policy": "company", "mine": "default"}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("item %s from %q, want %q", k, got[k], v)
+		}
+	}
+	if len(plan.Shadowed) != 1 || plan.Shadowed[0].Name != "shared" || plan.Shadowed[0].Loser != "default" {
+		t.Errorf("unexpected shadowed list: %+v", plan.Shadowed)
+	}
+}
+
+func TestLinkTarget_MultiRepoMergedView(t *testing.T) {
+	cfg, tmp := setupMultiRepoTest(t)
+	target := cfg.Targets[0]
+
+	if err := callLinkTarget(cfg, target); err != nil {
+		t.Fatalf("linkTarget: %v", err)
+	}
+	view := filepath.Join(tmp, ".axon", "merged", target.Name)
+	if got, _ := os.Readlink(target.Destination); got != view {
+		t.Fatalf("dest → %q, want merged view %q", got, view)
+	}
+	if got, _ := os.Readlink(filepath.Join(view, "shared")); got != filepath.Join(tmp, "company", "skills", "shared") {
+		t.Errorf("shared → %q, want the company copy", got)
+	}
+
+	// A new skill in the primary Hub and a stray file written via the tool.
+	if err := os.MkdirAll(filepath.Join(tmp, "personal", "skills", "fresh"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(tmp, "company", "skills", "policy")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(view, "stray.md"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := collectLinkHealth(cfg)
+	if len(h.linked) != 1 || len(h.drift) != 2 {
+		t.Errorf("expected linked with drift + stray, got linked=%v drift=%v", h.linked, h.drift)
+	}
+
+	refreshMergedViews(cfg)
+	if _, err := os.Lstat(filepath.Join(view, "fresh")); err != nil {
+		t.Errorf("fresh should be added to the view: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(view, "policy")); !os.IsNotExist(err) {
+		t.Errorf("policy should be removed from the view, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(view, "stray.md")); err != nil {
+		t.Errorf("stray file must be left alone: %v", err)
+	}
+}
+
+func TestTargetLinkSource_SingleRepoUnchanged(t *testing.T) {
+	cfg, _ := setupMultiRepoTest(t)
+	target := cfg.Targets[0]
+	target.Repos = []string{config.PrimaryRepoName}
+
+	got, repos, err := targetLinkSource(cfg, target)
+	if err != nil || len(repos) != 1 {
+		t.Fatalf("targetLinkSource = %q, %v, %v", got, repos, err)
+	}
+	if want := filepath.Join(cfg.RepoPath, "skills"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLinkTarget_ProjectItemsShadowGlobal(t *testing.T) {
+	cfg, tmp := setupMultiRepoTest(t)
+	root := filepath.Join(tmp, "app")
+	for _, dir := range []string{
+		filepath.Join(root, ".axon", "skills", "shared"),
+		filepath.Join(root, ".claude"),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := &config.ProjectConfig{Targets: []config.Target{{Name: "app-skills", Source: "skills", Destination: ".claude/skills"}}}
+	if err := cfg.ApplyProject(p, root); err != nil {
+		t.Fatalf("ApplyProject: %v", err)
+	}
+	target := cfg.Targets[len(cfg.Targets)-1]
+
+	if err := callLinkTarget(cfg, target); err != nil {
+		t.Fatalf("linkTarget: %v", err)
+	}
+	view, _ := os.Readlink(filepath.Join(root, ".claude", "skills"))
+	if got, _ := os.Readlink(filepath.Join(view, "shared")); got != filepath.Join(root, ".axon", "skills", "shared") {
+		t.Errorf("shared → %q, want the project copy", got)
+	}
+	if got, _ := os.Readlink(filepath.Join(view, "policy")); got != filepath.Join(tmp, "company", "skills", "policy") {
+		t.Errorf("policy → %q, want the company copy", got)
+	}
+
+	// The global target's view is unaffected by project items.
+	global, _, _ := targetLinkSource(cfg, cfg.Targets[0])
+	if global == view {
+		t.Error("project and global targets must not share a merged view")
+	}
+}
+
+func TestLinkTarget_ToolLimitedSkills(t *testing.T) {
+	cfg, tmp := setupMultiRepoTest(t)
+	skills := filepath.Join(cfg.RepoPath, "skills")
+	for name, md := range map[string]string{
+		"shared": "---\nname: shared\ntools: [Claude, windsurf]\n---\n",
+		"mine":   "---\nname: mine\nmetadata:\n  tools: [cursor]\n---\n",
+	} {
+		if err := os.WriteFile(filepath.Join(skills, name, "SKILL.md"), []byte(md), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cursor := config.Target{Name: "cursor-skills", Source: "skills", Destination: filepath.Join(tmp, "dest", "cursor"), Type: "directory", Repos: []string{config.PrimaryRepoName}}
+	claude := config.Target{Name: "claude-skills", Source: "skills", Destination: filepath.Join(tmp, "dest", "claude"), Type: "directory", Repos: []string{config.PrimaryRepoName}}
+	cfg.Targets = []config.Target{cursor, claude}
+
+	if err := callLinkTarget(cfg, cursor); err != nil {
+		t.Fatalf("linkTarget: %v", err)
+	}
+	view := filepath.Join(tmp, ".axon", "merged", cursor.Name)
+	if got, _ := os.Readlink(cursor.Destination); got != view {
+		t.Fatalf("cursor dest → %q, want a filtered view %q", got, view)
+	}
+	if _, err := os.Lstat(filepath.Join(view, "shared")); !os.IsNotExist(err) {
+		t.Errorf("Claude-only skill must not be in the Cursor view, got %v", err)
+	}
+	if got, _ := os.Readlink(filepath.Join(view, "mine")); got != filepath.Join(skills, "mine") {
+		t.Errorf("mine → %q, want the Hub skill", got)
+	}
+
+	// The other targets still see their skills; a view is only used when
+	// something is left out.
+	if err := callLinkTarget(cfg, claude); err != nil {
+		t.Fatalf("linkTarget: %v", err)
+	}
+	claudeView := filepath.Join(tmp, ".axon", "merged", claude.Name)
+	if got, _ := os.Readlink(claude.Destination); got != claudeView {
+		t.Fatalf("claude dest → %q, want %q", got, claudeView)
+	}
+	if _, err := os.Lstat(filepath.Join(claudeView, "shared")); err != nil {
+		t.Errorf("shared should be linked for Claude: %v", err)
+	}
+	if err := os.Remove(filepath.Join(skills, "mine", "SKILL.md")); err != nil {
+		t.Fatal(err)
+	}
+	if got, _, _ := targetLinkSource(cfg, claude); got != skills {
+		t.Errorf("claude source = %q, want %q once nothing is left out", got, skills)
+	}
+
+	h := collectLinkHealth(cfg)
+	if len(h.limited) != 1 || h.limited[0].name != "cursor-skills" || h.limited[0].msg != "shared" {
+		t.Errorf("limited = %+v", h.limited)
+	}
+}
@@ -1,13 +1,22 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
-var flagVersion bool
+var (
+	flagVersion  bool
+	flagQuiet    bool
+	flagPortable string
+)
 
 var rootCmd = &cobra.Command{
 	Use:           "axon",
@@ -21,7 +30,12 @@ using a central Git-backed Hub at ~/.axon/repo/.`,
 			fmt.Fprintln(os.Stdout, version)
 			os.Exit(0)
 		}
-		return nil
+		if err := setupColor(flagColor); err != nil {
+			return err
+		}
+		flushConfigWarnings(cmd)
+		startBackgroundUpdateCheck(cmd)
+		return lockHubForCommand(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if flagVersion {
@@ -34,12 +48,58 @@ using a central Git-backed Hub at ~/.axon/repo/.`,
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&flagVersion, "version", "v", false, "Print axon version and exit")
+	rootCmd.PersistentFlags().BoolVar(&flagQuiet, "quiet", false, "Hide progress indicators on long operations")
+	rootCmd.PersistentFlags().StringVar(&flagColor, "color", colorAuto, "Color output: auto, always, or never")
+	rootCmd.PersistentFlags().StringVar(&flagPortable, "portable", "", "Keep config, Hub, cache, and locks in this directory and treat <dir>/home as ~")
+}
+
+// applyPortableFlag sets AXON_PORTABLE from a --portable flag in args. It
+// runs before cobra parses the flags, since the language setup already
+// loads the config; child processes such as skill scripts inherit it.
+func applyPortableFlag(args []string) error {
+	for i, a := range args {
+		if a == "--" {
+			return nil
+		}
+		dir, ok := strings.CutPrefix(a, "--portable=")
+		if !ok && a == "--portable" && i+1 < len(args) {
+			dir, ok = args[i+1], true
+		}
+		if !ok {
+			continue
+		}
+		if dir == "" {
+			return fmt.Errorf("--portable needs a directory")
+		}
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		return os.Setenv(config.PortableEnv, abs)
+	}
+	return nil
 }
 
 // Execute is called by main.go.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if err := applyPortableFlag(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", i18n.T("Error"), err)
 		os.Exit(1)
 	}
+	setupLanguage()
+	err := rootCmd.Execute()
+	releaseHubLock()
+	code := 1
+	var scriptErr *scriptExitError
+	if errors.As(err, &scriptErr) {
+		// 'axon run' passes the script's status on; the script has already
+		// reported its own error.
+		code = scriptErr.code
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", i18n.T("Error"), err)
+	}
+	finishBackgroundUpdateCheck()
+	if err != nil {
+		os.Exit(code)
+	}
 }
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestApplyPortableFlag(t *testing.T) {
+	tmp := t.TempDir()
+	t.Chdir(tmp)
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"status"}, ""},
+		{[]string{"--portable", "stick", "status"}, filepath.Join(tmp, "stick")},
+		{[]string{"link", "--portable=" + filepath.Join(tmp, "usb")}, filepath.Join(tmp, "usb")},
+		{[]string{"run", "demo", "--", "--portable", "x"}, ""},
+	} {
+		t.Setenv(config.PortableEnv, "")
+		if err := applyPortableFlag(tc.args); err != nil {
+			t.Fatalf("%v: %v", tc.args, err)
+		}
+		if got := os.Getenv(config.PortableEnv); got != tc.want {
+			t.Errorf("%v: %s = %q, want %q", tc.args, config.PortableEnv, got, tc.want)
+		}
+	}
+	if err := applyPortableFlag([]string{"--portable="}); err == nil {
+		t.Error("an empty --portable should be an error")
+	}
+}
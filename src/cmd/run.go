@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run <skill>/<script> [args...]",
+	Short: "Run a script shipped with a skill",
+	Long: `Run one of a skill's helper scripts the same way from any tool or shell.
+
+The script is looked up in the skill's directory, then under its scripts/
+directory, so 'axon run pdf/extract.py' runs skills/pdf/scripts/extract.py.
+It runs with the skill directory as its working directory, after checking
+the skill's requires.bins and requires.envs. Output is streamed, and the
+script's exit status becomes axon's.
+
+The script sees these variables besides the environment:
+  AXON_HUB         the Hub repo
+  AXON_HOME        ~/.axon
+  AXON_SKILL       the skill name
+  AXON_SKILL_DIR   the skill directory
+Required variables that are only set in ~/.axon/.env are passed on too.
+
+.sh, .py, .js, .ps1, .rb, and .pl scripts run with their interpreter; other
+files must be executable. Arguments after the script are passed to it.
+
+Examples:
+  axon run pdf                      # list the scripts of a skill
+  axon run pdf/extract.py in.pdf
+  axon run deploy/check.sh --verbose`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRun,
+}
+
+var flagRunNoCheck bool
+
+func init() {
+	runCmd.Flags().BoolVar(&flagRunNoCheck, "no-check", false, "Run without checking requires.bins and requires.envs")
+	// Flags after the script belong to the script.
+	runCmd.Flags().SetInterspersed(false)
+	rootCmd.AddCommand(runCmd)
+}
+
+// scriptExitError carries a script's non-zero exit status out of 'axon run'
+// so axon exits with it.
+type scriptExitError struct {
+	code int
+}
+
+func (e *scriptExitError) Error() string {
+	return fmt.Sprintf("script exited with status %d", e.code)
+}
+
+// interpreters maps script extensions to the commands that run them, in
+// order of preference.
+var interpreters = map[string][]string{
+	".sh":   {"bash", "sh"},
+	".bash": {"bash"},
+	".py":   {"python3", "python"},
+	".js":   {"node"},
+	".mjs":  {"node"},
+	".cjs":  {"node"},
+	".ps1":  {"pwsh", "powershell"},
+	".rb":   {"ruby"},
+	".pl":   {"perl"},
+}
+
+func runRun(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	skill, script, _ := strings.Cut(filepath.ToSlash(args[0]), "/")
+	node := loadSkillGraph(cfg)[skill]
+	if node == nil {
+		return fmt.Errorf("skill %q not found in the Hub", skill)
+	}
+	if script == "" {
+		return listSkillScripts(skill, node.Dir)
+	}
+	path, err := resolveSkillScript(node.Dir, script)
+	if err != nil {
+		return err
+	}
+
+	meta, _ := parseSkillMeta(filepath.Join(node.Dir, "SKILL.md"))
+	env, missing := skillRunEnv(meta.GetRequiresEnvs())
+	if !flagRunNoCheck {
+		for _, bin := range meta.GetRequiresBins() {
+			if _, err := exec.LookPath(bin); err != nil {
+				missing = append(missing, "command "+bin)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("skill %s is missing requirements:\n  - %s\nInstall or set them, or pass --no-check.", skill, strings.Join(missing, "\n  - "))
+		}
+	}
+
+	name, cmdArgs, err := scriptCommand(path, args[1:])
+	if err != nil {
+		return err
+	}
+	c := exec.Command(name, cmdArgs...)
+	c.Dir = node.Dir
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if c.Env, err = skillScriptEnv(cfg, skill, node.Dir, env); err != nil {
+		return err
+	}
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			return &scriptExitError{code: exitErr.ExitCode()}
+		}
+		return fmt.Errorf("cannot run %s: %w", script, err)
+	}
+	return nil
+}
+
+// resolveSkillScript finds script (slash-separated) in the skill directory
+// dir or its scripts/ directory. The script must stay inside dir.
+func resolveSkillScript(dir, script string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(script))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("script %q must be inside the skill directory", script)
+	}
+	for _, candidate := range []string{
+		filepath.Join(dir, clean),
+		filepath.Join(dir, "scripts", clean),
+	} {
+		if info, err := os.Stat(candidate); err == nil && info.Mode().IsRegular() {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("script %q not found in %s or its scripts/ directory", script, dir)
+}
+
+// scriptCommand returns the command line that runs the script at path:
+// its interpreter by extension, or the file itself when it is executable.
+func scriptCommand(path string, args []string) (string, []string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if candidates, ok := interpreters[ext]; ok {
+		for _, name := range candidates {
+			if _, err := exec.LookPath(name); err == nil {
+				if ext == ".ps1" {
+					return name, append([]string{"-NoProfile", "-File", path}, args...), nil
+				}
+				return name, append([]string{path}, args...), nil
+			}
+		}
+		return "", nil, fmt.Errorf("cannot run %s: %s not found in PATH", filepath.Base(path), strings.Join(candidates, " or "))
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", nil, err
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o111 == 0 {
+		return "", nil, fmt.Errorf("cannot run %s: not executable and no known interpreter for %q files", filepath.Base(path), ext)
+	}
+	return path, args, nil
+}
+
+// skillScriptEnv is the environment a skill's scripts run with: axon's
+// own, extra, and the AXON_* variables describing the skill.
+func skillScriptEnv(cfg *config.Config, skill, dir string, extra []string) ([]string, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return nil, err
+	}
+	env := append(os.Environ(), extra...)
+	return append(env,
+		"AXON_HUB="+cfg.RepoPath,
+		"AXON_HOME="+axonDir,
+		"AXON_SKILL="+skill,
+		"AXON_SKILL_DIR="+dir,
+	), nil
+}
+
+// skillRunEnv returns KEY=value entries for the required variables only set
+// in ~/.axon/.env, and the variables that are not set anywhere.
+func skillRunEnv(envs []string) (extra, missing []string) {
+	dotenv, _ := config.LoadDotEnv()
+	for _, key := range envs {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		if v := dotenv[key]; v != "" {
+			extra = append(extra, key+"="+v)
+			continue
+		}
+		missing = append(missing, "environment variable "+key)
+	}
+	return extra, missing
+}
+
+// listSkillScripts prints the scripts of a skill for 'axon run <skill>'.
+func listSkillScripts(skill, dir string) error {
+	var scripts []string
+	root := filepath.Join(dir, "scripts")
+	_ = filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, p)
+		scripts = append(scripts, filepath.ToSlash(rel))
+		return nil
+	})
+	printSection(fmt.Sprintf("Scripts: %s", skill))
+	if len(scripts) == 0 {
+		printSkip("", "no scripts/ directory in this skill")
+		return nil
+	}
+	sort.Strings(scripts)
+	for _, s := range scripts {
+		printItem(skill + "/" + s)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestResolveSkillScript(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"scripts/extract.py", "tool.sh", "scripts/sub/x.sh"} {
+		p := filepath.Join(dir, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for script, want := range map[string]string{
+		"extract.py":         "scripts/extract.py",
+		"scripts/extract.py": "scripts/extract.py",
+		"tool.sh":            "tool.sh",
+		"sub/x.sh":           "scripts/sub/x.sh",
+	} {
+		got, err := resolveSkillScript(dir, script)
+		if err != nil || got != filepath.Join(dir, filepath.FromSlash(want)) {
+			t.Errorf("resolveSkillScript(%q) = %q, %v; want %s", script, got, err, want)
+		}
+	}
+	for _, bad := range []string{"../escape.sh", "missing.sh", "scripts"} {
+		if _, err := resolveSkillScript(dir, bad); err == nil {
+			t.Errorf("resolveSkillScript(%q) should fail", bad)
+		}
+	}
+}
+
+func TestScriptCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interpreter lookup and file modes differ on Windows")
+	}
+	dir := t.TempDir()
+	sh := filepath.Join(dir, "a.sh")
+	bin := filepath.Join(dir, "tool")
+	data := filepath.Join(dir, "notes.txt")
+	for p, mode := range map[string]os.FileMode{sh: 0o644, bin: 0o755, data: 0o644} {
+		if err := os.WriteFile(p, []byte("#!/bin/sh\n"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	name, args, err := scriptCommand(sh, []string{"-v"})
+	if err != nil || (name != "bash" && name != "sh") || len(args) != 2 || args[0] != sh || args[1] != "-v" {
+		t.Errorf("a.sh: %s %v, %v", name, args, err)
+	}
+	if name, args, err := scriptCommand(bin, nil); err != nil || name != bin || len(args) != 0 {
+		t.Errorf("executable: %s %v, %v", name, args, err)
+	}
+	if _, _, err := scriptCommand(data, nil); err == nil {
+		t.Error("a non-executable file without an interpreter must not run")
+	}
+}
+
+func TestSkillRunEnv(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	t.Setenv("USERPROFILE", tmp)
+	if err := os.MkdirAll(filepath.Join(tmp, ".axon"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmp, ".axon", ".env"), []byte("FROM_DOTENV=abc\nEMPTY=\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("FROM_SHELL", "1")
+
+	extra, missing := skillRunEnv([]string{"FROM_SHELL", "FROM_DOTENV", "EMPTY", "NOWHERE_AXON_TEST"})
+	if len(extra) != 1 || extra[0] != "FROM_DOTENV=abc" {
+		t.Errorf("extra = %v", extra)
+	}
+	if len(missing) != 2 || missing[0] != "environment variable EMPTY" || missing[1] != "environment variable NOWHERE_AXON_TEST" {
+		t.Errorf("missing = %v", missing)
+	}
+}
@@ -14,18 +14,29 @@ import (
 	"github.com/kamusis/axon-cli/internal/config"
 	"github.com/kamusis/axon-cli/internal/embeddings"
 	"github.com/kamusis/axon-cli/internal/search"
+	"github.com/kamusis/axon-cli/internal/search/bm25"
 	searchindex "github.com/kamusis/axon-cli/internal/search/index"
 	"github.com/spf13/cobra"
 )
 
 var (
-	flagSearchIndex    bool
-	flagSearchKeyword  bool
-	flagSearchSemantic bool
-	flagSearchK        int
-	flagSearchMinScore float64
-	flagSearchDebug    bool
-	flagSearchForce    bool
+	flagSearchIndex       bool
+	flagSearchKeyword     bool
+	flagSearchSemantic    bool
+	flagSearchK           int
+	flagSearchMinScore    float64
+	flagSearchDebug       bool
+	flagSearchForce       bool
+	flagSearchOpen        int
+	flagSearchPath        int
+	flagSearchCopy        int
+	flagSearchInteractive bool
+	flagSearchPublish     bool
+	flagSearchReadOnly    bool
+	flagSearchTypes       []string
+	flagSearchPathPrefix  string
+	flagSearchTags        []string
+	flagSearchExplain     bool
 )
 
 var searchCmd = &cobra.Command{
@@ -43,6 +54,16 @@ func init() {
 	searchCmd.Flags().Float64Var(&flagSearchMinScore, "min-score", 0, "Minimum cosine similarity score to include (semantic only)")
 	searchCmd.Flags().BoolVar(&flagSearchDebug, "debug", false, "Print debug information")
 	searchCmd.Flags().BoolVar(&flagSearchForce, "force", false, "Force re-indexing even if no changes detected")
+	searchCmd.Flags().IntVar(&flagSearchOpen, "open", 0, "Open result `N`'s file in $EDITOR instead of listing results")
+	searchCmd.Flags().IntVar(&flagSearchPath, "path", 0, "Print result `N`'s absolute path instead of listing results")
+	searchCmd.Flags().IntVar(&flagSearchCopy, "copy", 0, "Copy result `N`'s content to the clipboard instead of listing results")
+	searchCmd.Flags().BoolVarP(&flagSearchInteractive, "interactive", "i", false, "Pick a document in an interactive fuzzy finder (Enter prints its path, Ctrl-O opens it)")
+	searchCmd.Flags().BoolVar(&flagSearchPublish, "publish", false, "With --index: build the index into the Hub's search/ directory and commit it")
+	searchCmd.Flags().StringSliceVar(&flagSearchTypes, "type", nil, "Only search this content type, e.g. skills or workflows (repeatable)")
+	searchCmd.Flags().StringVar(&flagSearchPathPrefix, "path-prefix", "", "Only search documents under this Hub path, e.g. skills/db/")
+	searchCmd.Flags().StringSliceVar(&flagSearchTags, "tag", nil, "Only search documents with this frontmatter tag (repeatable; all must match)")
+	searchCmd.Flags().BoolVar(&flagSearchExplain, "explain", false, "Show why each semantic result matched: its embedded text, best rows, shared terms, and closest sentences")
+	searchCmd.Flags().BoolVar(&flagSearchReadOnly, "read-only-index", false, "Query only the Hub's published index, without checking that the provider's model ID matches it")
 	rootCmd.AddCommand(searchCmd)
 }
 
@@ -54,10 +75,26 @@ func runSearch(cmd *cobra.Command, args []string) error {
 
 	minScore := resolveSemanticMinScore(cmd)
 
+	if flagSearchPublish && !flagSearchIndex {
+		return errors.New("--publish requires --index")
+	}
+	if flagSearchReadOnly && (flagSearchIndex || flagSearchKeyword) {
+		return errors.New("--read-only-index cannot be combined with --index or --keyword")
+	}
+	if flagSearchExplain && (flagSearchIndex || flagSearchKeyword || flagSearchInteractive) {
+		return errors.New("--explain cannot be combined with --index, --keyword, or --interactive")
+	}
 	if flagSearchIndex {
 		return runSearchIndex(cmd, cfg)
 	}
 
+	if err := validateSearchAction(); err != nil {
+		return err
+	}
+	filter := searchFilter()
+	if flagSearchInteractive {
+		return runSearchInteractive(cfg, strings.Join(args, " "), filter)
+	}
 	if len(args) == 0 {
 		return cmd.Help()
 	}
@@ -65,72 +102,172 @@ func runSearch(cmd *cobra.Command, args []string) error {
 
 	// Keyword-only mode.
 	if flagSearchKeyword {
-		return runSearchKeyword(cfg, query)
+		return runSearchKeyword(cfg, query, filter)
 	}
 
 	// Default: attempt semantic; fallback to keyword on failure.
 	if flagSearchSemantic {
-		return runSearchSemanticStrict(cfg, query, minScore)
+		return runSearchSemanticStrict(cfg, query, minScore, filter)
 	}
 
-	if err := runSearchSemanticBestEffort(cfg, query, minScore); err == nil {
-		return nil
+	if res, err := semanticSearchBestEffort(cfg, query, minScore, filter); err == nil {
+		return showSearchResults(cfg, query, filter, res)
 	}
-	return runSearchKeyword(cfg, query)
+	return runSearchKeyword(cfg, query, filter)
 }
 
-func runSearchKeyword(cfg *config.Config, query string) error {
-	docs, err := search.DiscoverDocuments(cfg.RepoPath, cfg.EffectiveSearchRoots())
+// searchFilter returns the restrictions given by --type, --path-prefix, and
+// --tag.
+func searchFilter() search.Filter {
+	return search.Filter{Types: flagSearchTypes, PathPrefix: flagSearchPathPrefix, Tags: flagSearchTags}
+}
+
+func runSearchKeyword(cfg *config.Config, query string, filter search.Filter) error {
+	results, err := keywordSearch(cfg, query, flagSearchK, filter)
 	if err != nil {
 		return err
 	}
-	results := search.KeywordSearch(docs, query, flagSearchK)
-	printSearchResults(query, results)
-	return nil
+	return showSearchResults(cfg, query, filter, results)
 }
 
-func runSearchSemanticBestEffort(cfg *config.Config, query string, minScore float64) error {
-	res, err := semanticSearch(cfg, query, minScore)
+// keywordSearch returns the top k keyword matches that pass filter, from the
+// keyword index when it can be used and by scanning the documents otherwise.
+func keywordSearch(cfg *config.Config, query string, k int, filter search.Filter) ([]search.SearchResult, error) {
+	results, err := indexedKeywordSearch(cfg, query, k, filter)
 	if err != nil {
 		if flagSearchDebug {
-			printInfo("", fmt.Sprintf("semantic search unavailable, falling back to keyword: %v", err))
+			fmt.Fprintf(os.Stderr, "debug: keyword index unavailable, scanning documents: %v\n", err)
 		}
-		return err
+		return scanKeywordSearch(cfg, query, k, filter)
 	}
-	printSearchResults(query, res)
-	return nil
+	return results, nil
 }
 
-func runSearchSemanticStrict(cfg *config.Config, query string, minScore float64) error {
-	res, err := semanticSearch(cfg, query, minScore)
+// indexedKeywordSearch answers query from the persisted keyword index in
+// ~/.axon/search, refreshing it first for documents that changed on disk.
+func indexedKeywordSearch(cfg *config.Config, query string, k int, filter search.Filter) ([]search.SearchResult, error) {
+	dir, err := keywordIndexDir()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	printSearchResults(query, res)
-	return nil
+	idx, err := refreshKeywordIndex(cfg, dir, false)
+	if err != nil {
+		return nil, err
+	}
+	return idx.SearchWhere(query, k, filter), nil
 }
 
-func semanticSearch(cfg *config.Config, query string, minScore float64) ([]search.SearchResult, error) {
-	idx, idxDir, err := selectSemanticIndex(cfg)
+// refreshKeywordIndex loads the keyword index in dir (or starts a new one
+// when force is set), updates it, and saves it back if anything changed.
+func refreshKeywordIndex(cfg *config.Config, dir string, force bool) (*bm25.Index, error) {
+	repos := searchRepoRoots(cfg)
+	if repos == nil {
+		repos = []search.RepoRoot{{Name: config.PrimaryRepoName, Path: cfg.RepoPath}}
+	}
+	roots := cfg.EffectiveSearchRoots()
+	source := bm25.Source(repos, roots)
+
+	idx := bm25.New(source)
+	if !force {
+		idx = bm25.Load(dir, source)
+	}
+	n, err := idx.Update(repos, roots)
 	if err != nil {
 		return nil, err
 	}
+	if n > 0 || force {
+		if err := idx.Save(dir); err != nil {
+			return nil, err
+		}
+	}
+	return idx, nil
+}
 
-	embCfg, err := embeddings.LoadConfig()
+// keywordIndexDir is where the keyword index lives, next to the user's
+// semantic index.
+func keywordIndexDir() (string, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(axonDir, "search"), nil
+}
+
+// scanKeywordSearch reads every document and ranks them without an index.
+func scanKeywordSearch(cfg *config.Config, query string, k int, filter search.Filter) ([]search.SearchResult, error) {
+	docs, err := discoverHubDocuments(cfg)
 	if err != nil {
 		return nil, err
 	}
-	prov, err := embeddings.NewFromConfig(embCfg)
+	return search.KeywordSearch(filterDocs(docs, filter), query, k), nil
+}
+
+// filterDocs returns the documents that pass filter.
+func filterDocs(docs []search.SkillDoc, filter search.Filter) []search.SkillDoc {
+	if filter.Empty() {
+		return docs
+	}
+	var out []search.SkillDoc
+	for _, d := range docs {
+		if filter.Match(d) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// searchRepoRoots returns every Hub repo in priority order when additional
+// repos are configured, or nil for a single-repo setup.
+func searchRepoRoots(cfg *config.Config) []search.RepoRoot {
+	if len(cfg.Repos) == 0 {
+		return nil
+	}
+	var out []search.RepoRoot
+	for _, r := range cfg.HubRepos() {
+		out = append(out, search.RepoRoot{Name: r.Name, Path: r.Path})
+	}
+	return out
+}
+
+func semanticSearchBestEffort(cfg *config.Config, query string, minScore float64, filter search.Filter) ([]search.SearchResult, error) {
+	res, err := semanticSearch(cfg, query, minScore, flagSearchK, filter)
+	if err != nil && flagSearchDebug {
+		printInfo("", fmt.Sprintf("semantic search unavailable, falling back to keyword: %v", err))
+	}
+	return res, err
+}
+
+func runSearchSemanticStrict(cfg *config.Config, query string, minScore float64, filter search.Filter) error {
+	res, err := semanticSearch(cfg, query, minScore, flagSearchK, filter)
+	if err != nil {
+		return err
+	}
+	return showSearchResults(cfg, query, filter, res)
+}
+
+// semanticSearch ranks the rows of the semantic index that pass filter by
+// cosine similarity to query.
+func semanticSearch(cfg *config.Config, query string, minScore float64, k int, filter search.Filter) ([]search.SearchResult, error) {
+	idx, idxDir, err := selectSemanticIndex(cfg)
 	if err != nil {
 		return nil, err
 	}
-	if prov.ModelID() != idx.Manifest.ModelID {
+
+	prov, err := searchEmbeddingsProvider()
+	if err != nil {
+		return nil, err
+	}
+	// --read-only-index trusts that the provider serves the index's model
+	// under another ID (e.g. an Azure deployment of an OpenAI model); the
+	// dimension check below still applies.
+	if prov.ModelID() != idx.Manifest.ModelID && !flagSearchReadOnly {
 		return nil, fmt.Errorf("embeddings model mismatch: index=%s provider=%s (index dir %s)", idx.Manifest.ModelID, prov.ModelID(), idxDir)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	qv, err := prov.Embed(ctx, query)
+	// Normalize the query exactly as the indexed texts were.
+	qv, err := prov.Embed(ctx, searchindex.NormalizeText(idx.Manifest.TextNorm, query))
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +279,12 @@ func semanticSearch(cfg *config.Config, query string, minScore float64) ([]searc
 	}
 
 	results := make([]search.SearchResult, 0, len(idx.Skills))
+	// A chunked document has several rows; it ranks by its best one.
+	best := make(map[string]int)
 	for i, s := range idx.Skills {
+		if !filter.MatchFields(s.ID, s.Path, s.Tags) {
+			continue
+		}
 		start := i * idx.Manifest.Dim
 		end := start + idx.Manifest.Dim
 		sv := idx.Vectors[start:end]
@@ -153,25 +295,37 @@ func semanticSearch(cfg *config.Config, query string, minScore float64) ([]searc
 		if minScore > 0 && score < minScore {
 			continue
 		}
+		key := s.Repo + "\x00" + s.ID
+		if j, ok := best[key]; ok {
+			if score > results[j].Score {
+				results[j].Score = score
+			}
+			continue
+		}
+		best[key] = len(results)
 		results = append(results, search.SearchResult{
 			Skill: search.SkillDoc{
 				ID:          s.ID,
 				Path:        s.Path,
 				Name:        s.Name,
 				Description: s.Description,
+				Repo:        s.Repo,
 			},
 			Score: score,
 			Why:   "semantic",
 		})
 	}
 	if len(results) == 0 {
+		if !filter.Empty() {
+			return nil, fmt.Errorf("no semantic results above min score %.3f matching %s", minScore, filter)
+		}
 		return nil, fmt.Errorf("no semantic results above min score %.3f", minScore)
 	}
 
 	// Sort by score desc.
 	search.SortResults(results)
-	if flagSearchK > 0 && len(results) > flagSearchK {
-		results = results[:flagSearchK]
+	if k > 0 && len(results) > k {
+		results = results[:k]
 	}
 
 	if flagSearchDebug {
@@ -205,6 +359,14 @@ func selectSemanticIndex(cfg *config.Config) (*searchindex.Index, string, error)
 	userDir := filepath.Join(axonDir, "search")
 	repoDir := filepath.Join(cfg.RepoPath, "search")
 
+	if flagSearchReadOnly {
+		idx, err := tryLoadIndex(repoDir)
+		if err != nil {
+			return nil, "", fmt.Errorf("no published semantic index in the Hub: %w", err)
+		}
+		return idx, repoDir, nil
+	}
+
 	// Prefer user index if it loads.
 	if idx, err := tryLoadIndex(userDir); err == nil {
 		return idx, userDir, nil
@@ -223,13 +385,52 @@ func tryLoadIndex(dir string) (*searchindex.Index, error) {
 	return idx, nil
 }
 
-func printSearchResults(query string, results []search.SearchResult) {
-	fmt.Printf("\naxon search %q\n\n", query)
+func printSearchResults(query string, filter search.Filter, results []search.SearchResult) {
+	fmt.Printf("\naxon search %q\n", query)
+	if !filter.Empty() {
+		fmt.Printf("Filters: %s\n", filter)
+	}
+	fmt.Println()
 	fmt.Printf("Results (%d found):\n", len(results))
 	if len(results) == 0 {
 		return
 	}
 
+	groupOrder, grouped := groupSearchResults(results)
+
+	n := 0
+	for _, g := range groupOrder {
+		items := grouped[g]
+		fmt.Printf("\n%s (%d):\n", g, len(items))
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, r := range items {
+			n++
+			displayID := r.Skill.ID
+			if g != "skills" {
+				prefix := g + ":"
+				displayID = strings.TrimPrefix(displayID, prefix)
+				displayID = strings.ReplaceAll(displayID, ":", "/")
+			}
+
+			score := ""
+			if r.Why == "semantic" {
+				score = fmt.Sprintf("[%.3f]", r.Score)
+			}
+			if r.Skill.Repo != "" {
+				displayID += "  (" + r.Skill.Repo + ")"
+			}
+
+			fmt.Fprintf(w, "  %d.\t%s\t%s\n", n, score, displayID)
+			fmt.Fprintf(w, "  - %s\n", strings.TrimSpace(r.Skill.Description))
+		}
+		_ = w.Flush()
+	}
+}
+
+// groupSearchResults groups results by their top-level root (skills,
+// workflows, commands, then others by name), keeping rank order within each.
+func groupSearchResults(results []search.SearchResult) ([]string, map[string][]search.SearchResult) {
 	grouped := make(map[string][]search.SearchResult)
 	orderSeen := make(map[string]struct{})
 	groupOrder := make([]string, 0, 8)
@@ -249,7 +450,7 @@ func printSearchResults(query string, results []search.SearchResult) {
 		grouped[root] = append(grouped[root], r)
 	}
 
-	priority := map[string]int{"skills": 0, "workflows": 1, "commands": 2}
+	priority := map[string]int{"skills": 0, "workflows": 1, "commands": 2, "rules": 3, "memories": 4}
 	sort.SliceStable(groupOrder, func(i, j int) bool {
 		pi, okI := priority[groupOrder[i]]
 		pj, okJ := priority[groupOrder[j]]
@@ -264,47 +465,119 @@ func printSearchResults(query string, results []search.SearchResult) {
 		}
 		return groupOrder[i] < groupOrder[j]
 	})
+	return groupOrder, grouped
+}
 
-	for _, g := range groupOrder {
-		items := grouped[g]
-		fmt.Printf("\n%s (%d):\n", g, len(items))
+// searchTextNormalizationKey selects the text normalization for new semantic
+// indexes (environment or ~/.axon/.env).
+const searchTextNormalizationKey = "AXON_SEARCH_NORMALIZE"
 
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		for i, r := range items {
-			displayID := r.Skill.ID
-			if g != "skills" {
-				prefix := g + ":"
-				displayID = strings.TrimPrefix(displayID, prefix)
-				displayID = strings.ReplaceAll(displayID, ":", "/")
-			}
+// searchTextNormalization returns the configured normalization mode.
+func searchTextNormalization() (string, error) {
+	v, err := config.GetConfigValue(searchTextNormalizationKey)
+	if err != nil {
+		return "", err
+	}
+	mode, err := searchindex.ParseTextNormalization(v)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", searchTextNormalizationKey, err)
+	}
+	return mode, nil
+}
 
-			score := ""
-			if r.Why == "semantic" {
-				score = fmt.Sprintf("[%.3f]", r.Score)
-			}
+// Body chunking for new semantic indexes (environment or ~/.axon/.env):
+// chunk size in words (unset or 0 disables it) and the words shared by
+// consecutive chunks.
+const (
+	searchChunkSizeKey    = "AXON_SEARCH_CHUNK_SIZE"
+	searchChunkOverlapKey = "AXON_SEARCH_CHUNK_OVERLAP"
+)
 
-			fmt.Fprintf(w, "  %d.\t%s\t%s\n", i+1, score, displayID)
-			fmt.Fprintf(w, "  - %s\n", strings.TrimSpace(r.Skill.Description))
-		}
-		_ = w.Flush()
+func searchChunking() (searchindex.ChunkOptions, error) {
+	size, err := config.GetConfigValue(searchChunkSizeKey)
+	if err != nil {
+		return searchindex.ChunkOptions{}, err
+	}
+	overlap, err := config.GetConfigValue(searchChunkOverlapKey)
+	if err != nil {
+		return searchindex.ChunkOptions{}, err
+	}
+	opts, err := searchindex.ParseChunkOptions(size, overlap)
+	if err != nil {
+		return searchindex.ChunkOptions{}, fmt.Errorf("%s/%s: %w", searchChunkSizeKey, searchChunkOverlapKey, err)
 	}
+	return opts, nil
 }
 
-func runSearchIndex(cmd *cobra.Command, cfg *config.Config) error {
-	_ = cmd
+// searchEmbeddingsCacheKey turns the persistent embeddings cache off when
+// set to "off", "false", or "0".
+const searchEmbeddingsCacheKey = "AXON_EMBEDDINGS_CACHE"
 
-	// We require embeddings config for indexing.
+// searchEmbeddingsProvider returns the configured embeddings provider
+// behind the persistent embeddings cache.
+func searchEmbeddingsProvider() (embeddings.Provider, error) {
 	embCfg, err := embeddings.LoadConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	prov, err := embeddings.NewFromConfig(embCfg)
+	if err != nil {
+		return nil, err
+	}
+	v, err := config.GetConfigValue(searchEmbeddingsCacheKey)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "off", "false", "0":
+		return prov, nil
+	}
+	dir, err := embeddings.CacheDir()
+	if err != nil {
+		return prov, nil
+	}
+	return embeddings.Cached(prov, dir), nil
+}
+
+// searchHubRevision identifies the Hub content a semantic index covers: the
+// content commit, or name@commit for every repo when several are configured.
+func searchHubRevision(cfg *config.Config) string {
+	repos := cfg.HubRepos()
+	if len(repos) == 1 {
+		return hubContentRevision(cfg.RepoPath)
+	}
+	parts := make([]string, 0, len(repos))
+	for _, r := range repos {
+		parts = append(parts, r.Name+"@"+hubContentRevision(r.Path))
+	}
+	return strings.Join(parts, ",")
+}
+
+// hubContentRevision is the last commit of repo that changed anything but
+// the published index in search/, so publishing the index does not make it
+// look stale. It falls back to HEAD.
+func hubContentRevision(repo string) string {
+	out, err := gitOutput(repo, "log", "-1", "--format=%H", "--", ".", ":(exclude)search")
+	if rev := strings.TrimSpace(out); err == nil && rev != "" {
+		return rev
+	}
+	return hubHeadSHA(repo)
+}
+
+func runSearchIndex(cmd *cobra.Command, cfg *config.Config) error {
+	_ = cmd
+
+	// We require embeddings config for indexing.
+	prov, err := searchEmbeddingsProvider()
 	if err != nil {
 		return err
 	}
 	if prov.ModelID() == "" {
 		return errors.New("embeddings provider is not configured")
 	}
+	if flagSearchPublish {
+		return publishSearchIndex(cfg, prov)
+	}
 
 	axonDir, err := config.AxonDir()
 	if err != nil {
@@ -321,24 +594,225 @@ func runSearchIndex(cmd *cobra.Command, cfg *config.Config) error {
 	}
 	defer os.RemoveAll(tmpDir)
 
+	textNorm, err := searchTextNormalization()
+	if err != nil {
+		return err
+	}
+	chunking, err := searchChunking()
+	if err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
 
 	printInfo("", fmt.Sprintf("building semantic index using %s", prov.ModelID()))
-	_, err = searchindex.BuildUserIndex(ctx, prov, searchindex.BuildOptions{
+	progress := startProgress("Embedding documents", 0)
+	idx, err := searchindex.BuildUserIndex(ctx, prov, searchindex.BuildOptions{
 		RepoPath:  cfg.RepoPath,
+		Repos:     searchRepoRoots(cfg),
 		OutDir:    tmpDir,
+		PrevDir:   userDir,
 		Roots:     cfg.EffectiveSearchRoots(),
 		Force:     flagSearchForce,
 		Normalize: true,
+
+		TextNormalization: textNorm,
+		Chunking:          chunking,
+
+		HubRevision: searchHubRevision(cfg),
+		Checkpoint:  filepath.Join(axonDir, searchCheckpointFile),
+		Progress:    progress.setCount,
 	})
+	progress.Done()
 	if err != nil {
-		return fmt.Errorf("index build failed: %w", err)
+		return indexBuildError(err)
+	}
+	reportIndexResumed(idx)
+
+	// The keyword index shares the directory; carry it over the swap.
+	_ = os.Rename(filepath.Join(userDir, bm25.FileName), filepath.Join(tmpDir, bm25.FileName))
+	if _, err := refreshKeywordIndex(cfg, tmpDir, flagSearchForce); err != nil {
+		printWarn("", fmt.Sprintf("keyword index not updated: %v", err))
 	}
 
 	if err := searchindex.AtomicSwap(tmpDir, userDir); err != nil {
 		return fmt.Errorf("cannot install index: %w", err)
 	}
 	printOK("", fmt.Sprintf("semantic index written: %s", userDir))
-	return nil
+	reportIndexDelta(idx)
+	return reportIndexFailures(idx)
+}
+
+// publishSearchIndex builds a semantic index of the primary Hub repo into
+// its search/ directory and commits it, so machines that sync the Hub can
+// query it without building (and paying for) their own.
+func publishSearchIndex(cfg *config.Config, prov embeddings.Provider) error {
+	if err := checkGitAvailable(); err != nil {
+		return err
+	}
+	textNorm, err := searchTextNormalization()
+	if err != nil {
+		return err
+	}
+	chunking, err := searchChunking()
+	if err != nil {
+		return err
+	}
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return err
+	}
+	repo := cfg.RepoPath
+	hubDir := filepath.Join(repo, "search")
+	// Build next to the destination so the swap is a rename.
+	tmpDir, err := os.MkdirTemp(repo, ".search-index-*")
+	if err != nil {
+		return fmt.Errorf("cannot create temp index dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	printInfo("", fmt.Sprintf("building the Hub's semantic index using %s", prov.ModelID()))
+	progress := startProgress("Embedding documents", 0)
+	idx, err := searchindex.BuildUserIndex(ctx, prov, searchindex.BuildOptions{
+		RepoPath:  repo,
+		OutDir:    tmpDir,
+		PrevDir:   hubDir,
+		Roots:     cfg.EffectiveSearchRoots(),
+		Force:     flagSearchForce,
+		Normalize: true,
+
+		TextNormalization: textNorm,
+		Chunking:          chunking,
+
+		HubRevision: hubContentRevision(repo),
+		Checkpoint:  filepath.Join(axonDir, publishCheckpointFile),
+		Progress:    progress.setCount,
+	})
+	progress.Done()
+	if err != nil {
+		return indexBuildError(err)
+	}
+	reportIndexResumed(idx)
+	reportIndexDelta(idx)
+	// A rebuild only differs in its timestamp when nothing changed; keep the
+	// Hub history free of such commits.
+	if built, err := searchindex.Load(tmpDir); err == nil {
+		if old, err := searchindex.Load(hubDir); err == nil &&
+			old.Manifest.Checksum == built.Manifest.Checksum &&
+			old.Manifest.ModelID == built.Manifest.ModelID &&
+			old.Manifest.HubRevision == built.Manifest.HubRevision {
+			printSkip("", "published index is up to date")
+			return reportIndexFailures(idx)
+		}
+	}
+	// Keep git from treating the vectors as text (see eol=lf in
+	// .gitattributes); the manifest checksum catches any damage anyway.
+	if err := os.WriteFile(filepath.Join(tmpDir, ".gitattributes"), []byte("*.f32 binary\n"), 0o644); err != nil {
+		return err
+	}
+	if err := searchindex.AtomicSwap(tmpDir, hubDir); err != nil {
+		return fmt.Errorf("cannot install index: %w", err)
+	}
+
+	msg := fmt.Sprintf("axon: publish semantic index (%s)", prov.ModelID())
+	if err := gitRun("-C", repo, "add", "--all", "--", "search"); err != nil {
+		return fmt.Errorf("git add failed: %w", err)
+	}
+	if out, err := gitOutput(repo, "commit", "-m", msg, "--", "search"); err != nil {
+		if !strings.Contains(out, "nothing to commit") && !strings.Contains(out, "nothing added to commit") {
+			return fmt.Errorf("git commit failed: %w\n%s", err, out)
+		}
+		printSkip("", "published index unchanged")
+	} else {
+		printOK("", fmt.Sprintf("semantic index committed to %s", hubDir))
+		printInfo("", "Run 'axon sync' to share it.")
+	}
+	return reportIndexFailures(idx)
+}
+
+// Checkpoints of interrupted index builds, in ~/.axon, so that running the
+// build again resumes it (see searchindex.BuildOptions.Checkpoint).
+const (
+	searchCheckpointFile  = "search.checkpoint"
+	publishCheckpointFile = "search-publish.checkpoint"
+)
+
+// indexBuildError reports a failed build; what it embedded before failing
+// is kept in its checkpoint.
+func indexBuildError(err error) error {
+	if errors.Is(err, embeddings.ErrAuth) {
+		return fmt.Errorf("index build failed: %w", err)
+	}
+	return fmt.Errorf("index build failed: %w\nRun the same command again to resume; texts embedded so far are kept.", err)
+}
+
+// reportIndexResumed notes a build that resumed an interrupted one.
+func reportIndexResumed(idx *searchindex.Index) {
+	if idx.Resumed > 0 {
+		printInfo("", fmt.Sprintf("resumed an interrupted build: %d text(s) were already embedded", idx.Resumed))
+	}
+}
+
+// maxDeltaNames caps how many document IDs reportIndexDelta lists per kind
+// of change.
+const maxDeltaNames = 10
+
+// reportIndexDelta summarizes what a build changed from the previous index
+// and warns about documents left out because they share an ID.
+func reportIndexDelta(idx *searchindex.Index) {
+	d := idx.Delta
+	printInfo("", fmt.Sprintf("index delta: %d added, %d updated, %d removed, %d unchanged",
+		len(d.Added), len(d.Updated), len(d.Removed), d.Unchanged))
+	for _, c := range []struct {
+		label string
+		ids   []string
+	}{{"added", d.Added}, {"updated", d.Updated}, {"removed", d.Removed}} {
+		if len(c.ids) == 0 {
+			continue
+		}
+		ids := c.ids
+		more := ""
+		if len(ids) > maxDeltaNames {
+			more = fmt.Sprintf(" and %d more", len(ids)-maxDeltaNames)
+			ids = ids[:maxDeltaNames]
+		}
+		printInfo(c.label, strings.Join(ids, ", ")+more)
+	}
+	for _, c := range idx.Collisions {
+		printWarn(c.ID, fmt.Sprintf("%d documents share this ID; indexed %s, skipped %s",
+			len(c.Paths), c.Paths[0], strings.Join(c.Paths[1:], ", ")))
+	}
+}
+
+// reportIndexFailures lists the documents a build could not embed. The
+// index is installed regardless; rerunning the build retries them.
+func reportIndexFailures(idx *searchindex.Index) error {
+	if len(idx.Failures) == 0 {
+		return nil
+	}
+	printSection("Embedding failures")
+	for _, f := range idx.Failures {
+		msg := f.Err.Error()
+		if f.Stale {
+			msg += " (kept the previous embedding)"
+		}
+		printErr(f.ID, msg)
+	}
+	return fmt.Errorf("%d of %d text(s) could not be embedded; run 'axon search --index' again to retry them",
+		len(idx.Failures), len(idx.Skills)+countMissing(idx.Failures))
+}
+
+// countMissing counts failures that left a document out of the index.
+func countMissing(failures []searchindex.BuildFailure) int {
+	n := 0
+	for _, f := range failures {
+		if !f.Stale {
+			n++
+		}
+	}
+	return n
 }
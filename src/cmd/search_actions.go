@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/search"
+)
+
+// ── Result actions ────────────────────────────────────────────────────────────
+// --open, --path, and --copy act on one numbered result instead of listing
+// them, so a search can feed an editor, a shell, or the clipboard directly.
+
+// validateSearchAction rejects more than one action, a non-positive N, or
+// an action combined with the interactive finder.
+func validateSearchAction() error {
+	set := 0
+	for _, f := range []struct {
+		name string
+		n    int
+	}{{"--open", flagSearchOpen}, {"--path", flagSearchPath}, {"--copy", flagSearchCopy}} {
+		if f.n < 0 {
+			return fmt.Errorf("%s: result numbers start at 1", f.name)
+		}
+		if f.n > 0 {
+			set++
+		}
+	}
+	if set > 1 {
+		return errors.New("use only one of --open, --path, and --copy")
+	}
+	if set > 0 && flagSearchInteractive {
+		return errors.New("--open, --path, and --copy cannot be combined with --interactive")
+	}
+	return nil
+}
+
+// showSearchResults lists results, or performs the requested result action.
+func showSearchResults(cfg *config.Config, query string, filter search.Filter, results []search.SearchResult) error {
+	switch {
+	case flagSearchOpen > 0:
+		r, err := pickSearchResult(results, flagSearchOpen)
+		if err != nil {
+			return err
+		}
+		file, err := searchResultFile(cfg, r)
+		if err != nil {
+			return err
+		}
+		return openInEditor(file)
+
+	case flagSearchPath > 0:
+		r, err := pickSearchResult(results, flagSearchPath)
+		if err != nil {
+			return err
+		}
+		file, err := searchResultFile(cfg, r)
+		if err != nil {
+			return err
+		}
+		fmt.Println(shellPath(file))
+		return nil
+
+	case flagSearchCopy > 0:
+		r, err := pickSearchResult(results, flagSearchCopy)
+		if err != nil {
+			return err
+		}
+		file, err := searchResultFile(cfg, r)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("cannot read %s: %w", file, err)
+		}
+		if err := copyToClipboard(data); err != nil {
+			return err
+		}
+		printOK("", fmt.Sprintf("copied %s to the clipboard", file))
+		return nil
+	}
+	printSearchResults(query, filter, results)
+	if flagSearchExplain {
+		return explainSearchResults(cfg, query, results)
+	}
+	return nil
+}
+
+// pickSearchResult returns result n as numbered by printSearchResults.
+func pickSearchResult(results []search.SearchResult, n int) (search.SearchResult, error) {
+	groupOrder, grouped := groupSearchResults(results)
+	i := 0
+	for _, g := range groupOrder {
+		for _, r := range grouped[g] {
+			i++
+			if i == n {
+				return r, nil
+			}
+		}
+	}
+	if len(results) == 0 {
+		return search.SearchResult{}, errors.New("no results")
+	}
+	return search.SearchResult{}, fmt.Errorf("no result %d (only %d found)", n, len(results))
+}
+
+// searchResultFile returns the absolute path of the document behind r.
+func searchResultFile(cfg *config.Config, r search.SearchResult) (string, error) {
+	repoPath := cfg.RepoPath
+	if r.Skill.Repo != "" {
+		repo, ok := cfg.FindRepo(r.Skill.Repo)
+		if !ok {
+			return "", fmt.Errorf("unknown Hub repo %q", r.Skill.Repo)
+		}
+		repoPath = repo.Path
+	}
+	files, err := search.ListDocumentFiles(repoPath, cfg.EffectiveSearchRoots())
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if f.ID == r.Skill.ID {
+			return filepath.Abs(f.File)
+		}
+	}
+	return "", fmt.Errorf("%s no longer exists in %s", r.Skill.ID, repoPath)
+}
+
+// shellPath is what --path prints for a document: a skill's directory, or
+// the file itself for workflows and commands.
+func shellPath(file string) string {
+	if filepath.Base(file) == "SKILL.md" {
+		return filepath.Dir(file)
+	}
+	return file
+}
+
+// openInEditor opens file in $VISUAL or $EDITOR, falling back to the
+// platform's usual editor. The variable may carry arguments ("code -w").
+func openInEditor(file string) error {
+	editor := strings.TrimSpace(os.Getenv("VISUAL"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("EDITOR"))
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	args := strings.Fields(editor)
+	c := exec.Command(args[0], append(args[1:], file)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", args[0], err)
+	}
+	return nil
+}
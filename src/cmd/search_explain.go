@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/search"
+	"github.com/kamusis/axon-cli/internal/search/bm25"
+	searchindex "github.com/kamusis/axon-cli/internal/search/index"
+)
+
+// ── --explain ─────────────────────────────────────────────────────────────────
+// A semantic hit can rank high without sharing a word with the query. For
+// each hit, --explain shows the text that was embedded, how each of its
+// index rows (name and description, then body chunks) scored, the query
+// terms it shares, and which of its sentences are closest to the query.
+
+const (
+	// explainMaxSentences caps the sentences embedded per hit; they go
+	// through the embeddings cache, so repeated explanations are free.
+	explainMaxSentences = 12
+	explainTopRows      = 3
+	explainTopSentences = 3
+	explainSnippetWidth = 72
+)
+
+// rowScore is the similarity of one index row of a document to the query.
+type rowScore struct {
+	Chunk int
+	Score float64
+	Text  string
+	Stale bool // the document changed since the row was embedded
+}
+
+// explainSearchResults prints an explanation of each semantic result.
+func explainSearchResults(cfg *config.Config, query string, results []search.SearchResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	if results[0].Why != "semantic" {
+		printInfo("", "--explain only applies to semantic results; these are keyword matches")
+		return nil
+	}
+	idx, _, err := selectSemanticIndex(cfg)
+	if err != nil {
+		return err
+	}
+	prov, err := searchEmbeddingsProvider()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	embed := func(text string) ([]float32, error) {
+		v, err := prov.Embed(ctx, searchindex.NormalizeText(idx.Manifest.TextNorm, text))
+		if err != nil {
+			return nil, err
+		}
+		if idx.Manifest.Normalize {
+			v = searchindex.NormalizeL2(v)
+		}
+		return v, nil
+	}
+	qv, err := embed(query)
+	if err != nil {
+		return err
+	}
+
+	printSection("Why these results")
+	groupOrder, grouped := groupSearchResults(results)
+	n := 0
+	for _, g := range groupOrder {
+		for _, r := range grouped[g] {
+			n++
+			fmt.Printf("\n  %d. %s  [%.3f]\n", n, r.Skill.ID, r.Score)
+			texts := resultTexts(cfg, r, idx.Manifest)
+			rows, err := resultRowScores(idx, qv, r.Skill, texts)
+			if err != nil {
+				return err
+			}
+			printExplanation(query, qv, texts, rows, embed)
+		}
+	}
+	return nil
+}
+
+// resultTexts re-reads the document behind r and returns the texts its index
+// rows were embedded from, or nil when it cannot be read.
+func resultTexts(cfg *config.Config, r search.SearchResult, m searchindex.Manifest) []string {
+	file, err := searchResultFile(cfg, r)
+	if err != nil {
+		return nil
+	}
+	doc, err := search.ReadDocument(search.DocumentFile{File: file, ID: r.Skill.ID, Path: r.Skill.Path})
+	if err != nil {
+		return nil
+	}
+	texts := searchindex.DocumentTexts(doc, searchindex.ChunkOptions{Size: m.ChunkSize, Overlap: m.ChunkOverlap})
+	for i, t := range texts {
+		texts[i] = searchindex.NormalizeText(m.TextNorm, t)
+	}
+	return texts
+}
+
+// resultRowScores scores every index row of doc against the query vector qv,
+// best first. texts are the document's current texts by chunk.
+func resultRowScores(idx *searchindex.Index, qv []float32, doc search.SkillDoc, texts []string) ([]rowScore, error) {
+	var rows []rowScore
+	dim := idx.Manifest.Dim
+	for i, e := range idx.Skills {
+		if e.ID != doc.ID || e.Repo != doc.Repo {
+			continue
+		}
+		score, err := searchindex.Cosine(qv, idx.Vectors[i*dim:(i+1)*dim])
+		if err != nil {
+			return nil, err
+		}
+		row := rowScore{Chunk: e.Chunk, Score: score, Stale: true}
+		if e.Chunk < len(texts) {
+			row.Text = texts[e.Chunk]
+			row.Stale = e.TextHash != "" && e.TextHash != searchindex.TextHash(row.Text)
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Score > rows[j].Score })
+	return rows, nil
+}
+
+// printExplanation prints what is known about one result: texts are its
+// current texts by chunk, rows its scored index rows, and embed embeds its
+// sentences for comparison with the query vector qv.
+func printExplanation(query string, qv []float32, texts []string, rows []rowScore, embed func(string) ([]float32, error)) {
+	if len(texts) == 0 {
+		printWarn("", "the document can no longer be read; only its index rows are shown")
+	} else {
+		fmt.Println("     Embedded text:")
+		for _, line := range strings.Split(texts[0], "\n") {
+			fmt.Printf("       | %s\n", line)
+		}
+	}
+
+	if len(rows) > 1 {
+		fmt.Println("     Best rows:")
+		for _, row := range rows[:min(len(rows), explainTopRows)] {
+			label := "name and description"
+			if row.Chunk > 0 {
+				label = fmt.Sprintf("body chunk %d", row.Chunk)
+			}
+			fmt.Printf("       %.3f  %s", row.Score, label)
+			if row.Chunk > 0 && row.Text != "" {
+				_, chunk, _ := strings.Cut(row.Text, "\n")
+				fmt.Printf(": %s", explainSnippet(chunk))
+			}
+			fmt.Println()
+		}
+	}
+	if len(rows) == 0 || rows[0].Text == "" {
+		return
+	}
+	best := rows[0]
+	if best.Stale {
+		printWarn("", "the document changed since it was indexed; run 'axon search --index'")
+	}
+
+	if terms := sharedTerms(query, best.Text); len(terms) > 0 {
+		fmt.Printf("     Shared terms: %s\n", strings.Join(terms, ", "))
+	} else {
+		fmt.Println("     Shared terms: none; the match is by meaning, not wording")
+	}
+
+	sentences := splitSentences(best.Text)
+	if len(sentences) < 2 {
+		return
+	}
+	if len(sentences) > explainMaxSentences {
+		sentences = sentences[:explainMaxSentences]
+	}
+	type scored struct {
+		text  string
+		score float64
+	}
+	var top []scored
+	for _, s := range sentences {
+		v, err := embed(s)
+		if err != nil {
+			printWarn("", fmt.Sprintf("cannot embed sentences: %v", err))
+			return
+		}
+		score, err := searchindex.Cosine(qv, v)
+		if err != nil {
+			return
+		}
+		top = append(top, scored{s, score})
+	}
+	sort.SliceStable(top, func(i, j int) bool { return top[i].score > top[j].score })
+	fmt.Println("     Closest sentences:")
+	for _, s := range top[:min(len(top), explainTopSentences)] {
+		fmt.Printf("       %.3f  %s\n", s.score, explainSnippet(s.text))
+	}
+}
+
+// sharedTerms returns the query terms that occur in text, in query order.
+func sharedTerms(query, text string) []string {
+	have := make(map[string]bool)
+	for _, t := range bm25.Terms(text) {
+		have[t] = true
+	}
+	var out []string
+	seen := make(map[string]bool)
+	for _, t := range bm25.Terms(query) {
+		if have[t] && !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// splitSentences splits an embedded text into lines and sentences, dropping
+// the "field:" labels CanonicalText adds and fragments without a word.
+func splitSentences(text string) []string {
+	var out []string
+	for _, line := range strings.Split(text, "\n") {
+		if label, rest, ok := strings.Cut(line, ": "); ok && fieldLabel.MatchString(label) {
+			line = rest
+		}
+		start := 0
+		for i, r := range line {
+			if r != '.' && r != '!' && r != '?' && r != '。' {
+				continue
+			}
+			// A sentence ends at punctuation followed by a space or the end.
+			next := i + len(string(r))
+			if next < len(line) && line[next] != ' ' {
+				continue
+			}
+			out = appendSentence(out, line[start:next])
+			start = next
+		}
+		out = appendSentence(out, line[start:])
+	}
+	return out
+}
+
+// fieldLabel matches the frontmatter field names CanonicalText writes, such
+// as "description" or "description_zh".
+var fieldLabel = regexp.MustCompile(`^[a-z]+(_[a-z-]+)?$`)
+
+func appendSentence(out []string, s string) []string {
+	s = strings.TrimSpace(strings.TrimLeft(s, "#>-* "))
+	if len(bm25.Terms(s)) == 0 {
+		return out
+	}
+	return append(out, s)
+}
+
+// explainSnippet shortens text to one line for the explanation.
+func explainSnippet(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len([]rune(text)) > explainSnippetWidth {
+		text = string([]rune(text)[:explainSnippetWidth-1]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/search"
+	searchindex "github.com/kamusis/axon-cli/internal/search/index"
+)
+
+func TestSplitSentences(t *testing.T) {
+	text := "name: oracle\ndescription: Rotate passwords. Audit users! Done\n# Usage\nNote: run v1.2 first?"
+	want := []string{"oracle", "Rotate passwords.", "Audit users!", "Done", "Usage", "Note: run v1.2 first?"}
+	if got := splitSentences(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("splitSentences = %q, want %q", got, want)
+	}
+}
+
+func TestSharedTerms(t *testing.T) {
+	if got := sharedTerms("Rotate the Oracle passwords", "name: oracle\ndescription: rotate credentials"); !reflect.DeepEqual(got, []string{"rotate", "oracle"}) {
+		t.Errorf("sharedTerms = %q", got)
+	}
+	if got := sharedTerms("postgres", "name: oracle"); len(got) != 0 {
+		t.Errorf("sharedTerms = %q, want none", got)
+	}
+}
+
+func TestResultRowScores(t *testing.T) {
+	texts := []string{"name: oracle\ndescription: db", "name: oracle\nrotate passwords"}
+	idx := &searchindex.Index{
+		Manifest: searchindex.Manifest{Dim: 2},
+		Skills: []searchindex.SkillEntry{
+			{ID: "oracle", TextHash: searchindex.TextHash(texts[0])},
+			{ID: "oracle", Chunk: 1, TextHash: "outdated"},
+			{ID: "oracle", Repo: "team"},
+			{ID: "mysql"},
+		},
+		Vectors: []float32{1, 0, 0, 1, 0, 1, 0, 1},
+	}
+	rows, err := resultRowScores(idx, []float32{0, 1}, search.SkillDoc{ID: "oracle"}, texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Chunk != 1 || rows[0].Score < 0.99 || !rows[0].Stale || rows[1].Stale {
+		t.Errorf("rows = %+v, want chunk 1 first and marked stale", rows)
+	}
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/embeddings"
+	"github.com/kamusis/axon-cli/internal/search"
+	"github.com/kamusis/axon-cli/internal/search/bm25"
+	searchindex "github.com/kamusis/axon-cli/internal/search/index"
+)
+
+func TestIndexedKeywordSearch_PersistsAndRefreshes(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	skill := filepath.Join(cfg.RepoPath, "skills", "deployer", "SKILL.md")
+	if err := os.MkdirAll(filepath.Dir(skill), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(skill, []byte("---\nname: deployer\ndescription: ship releases\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := indexedKeywordSearch(cfg, "ship", 5, search.Filter{})
+	if err != nil {
+		t.Fatalf("indexedKeywordSearch: %v", err)
+	}
+	if len(results) != 1 || results[0].Skill.Name != "deployer" {
+		t.Fatalf("results = %+v, want deployer", results)
+	}
+	if _, err := os.Stat(filepath.Join(tmp, ".axon", "search", bm25.FileName)); err != nil {
+		t.Fatalf("keyword index not saved: %v", err)
+	}
+
+	// An edited skill is re-indexed on the next query.
+	if err := os.WriteFile(skill, []byte("---\nname: deployer\ndescription: roll out builds to production\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if results, _ := indexedKeywordSearch(cfg, "ship", 5, search.Filter{}); len(results) != 0 {
+		t.Errorf("stale results after edit: %+v", results)
+	}
+	if results, _ := indexedKeywordSearch(cfg, "production", 5, search.Filter{}); len(results) != 1 {
+		t.Errorf("results = %+v, want the edited skill", results)
+	}
+}
+
+func TestPickSearchResult_NumbersAcrossGroups(t *testing.T) {
+	results := []search.SearchResult{
+		{Skill: search.SkillDoc{ID: "workflows:deploy", Path: "workflows"}},
+		{Skill: search.SkillDoc{ID: "alpha", Path: "skills/alpha"}},
+		{Skill: search.SkillDoc{ID: "beta", Path: "skills/beta"}},
+	}
+	// Listed as skills first: 1. alpha, 2. beta, 3. workflows/deploy.
+	for n, want := range map[int]string{1: "alpha", 2: "beta", 3: "workflows:deploy"} {
+		r, err := pickSearchResult(results, n)
+		if err != nil || r.Skill.ID != want {
+			t.Errorf("pickSearchResult(%d) = %q, %v; want %q", n, r.Skill.ID, err, want)
+		}
+	}
+	if _, err := pickSearchResult(results, 4); err == nil {
+		t.Error("expected an error for a result past the end")
+	}
+}
+
+func TestSearchResultFile_ResolvesSkillsAndWorkflows(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	cfg.Targets = append(cfg.Targets, config.Target{Name: "test-workflows", Source: "workflows", Destination: filepath.Join(tmp, "dest", "workflows")})
+	skill := filepath.Join(cfg.RepoPath, "skills", "deployer", "SKILL.md")
+	flow := filepath.Join(cfg.RepoPath, "workflows", "release.md")
+	for _, p := range []string{skill, flow} {
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("---\nname: x\n---\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for id, want := range map[string]string{"deployer": skill, "workflows:release": flow} {
+		got, err := searchResultFile(cfg, search.SearchResult{Skill: search.SkillDoc{ID: id}})
+		if err != nil || got != want {
+			t.Errorf("searchResultFile(%s) = %q, %v; want %q", id, got, err, want)
+		}
+	}
+	if _, err := searchResultFile(cfg, search.SearchResult{Skill: search.SkillDoc{ID: "gone"}}); err == nil {
+		t.Error("expected an error for a missing document")
+	}
+}
+
+func TestValidateSearchAction(t *testing.T) {
+	t.Cleanup(func() { flagSearchOpen, flagSearchPath, flagSearchCopy = 0, 0, 0 })
+	flagSearchOpen, flagSearchPath = 1, 2
+	if err := validateSearchAction(); err == nil {
+		t.Error("expected an error for two actions")
+	}
+	flagSearchOpen, flagSearchPath = 0, -1
+	if err := validateSearchAction(); err == nil {
+		t.Error("expected an error for a negative result number")
+	}
+	flagSearchPath = 1
+	if err := validateSearchAction(); err != nil {
+		t.Errorf("validateSearchAction: %v", err)
+	}
+}
+
+func TestPublishSearchIndex_CommitsToHub(t *testing.T) {
+	cfg, tmp := initTestRepo(t)
+	t.Setenv("HOME", tmp)
+	cfg.Targets = []config.Target{{Name: "test", Source: "skills", Destination: filepath.Join(tmp, "dest")}}
+	skill := filepath.Join(cfg.RepoPath, "skills", "deployer", "SKILL.md")
+	if err := os.MkdirAll(filepath.Dir(skill), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(skill, []byte("---\nname: deployer\ndescription: ship releases\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"-C", cfg.RepoPath, "add", "."}, {"-C", cfg.RepoPath, "commit", "-m", "add skill"}} {
+		if err := gitRun(args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	contentRev := hubHeadSHA(cfg.RepoPath)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [{"embedding": [0.6, 0.8]}]}`))
+	}))
+	defer server.Close()
+	prov := embeddings.NewOpenAI(&embeddings.Config{Provider: "openai", APIKey: "k", BaseURL: server.URL, Model: "test"})
+
+	if err := publishSearchIndex(cfg, prov); err != nil {
+		t.Fatalf("publishSearchIndex: %v", err)
+	}
+	idx, err := searchindex.Load(filepath.Join(cfg.RepoPath, "search"))
+	if err != nil {
+		t.Fatalf("published index does not load: %v", err)
+	}
+	if len(idx.Skills) != 1 || idx.Manifest.Checksum == "" {
+		t.Errorf("index = %d skills, checksum %q", len(idx.Skills), idx.Manifest.Checksum)
+	}
+	if out, _ := gitOutput(cfg.RepoPath, "status", "--porcelain"); strings.TrimSpace(out) != "" {
+		t.Errorf("publish left uncommitted changes:\n%s", out)
+	}
+	// The publish commit itself does not make the index look stale.
+	if hubHeadSHA(cfg.RepoPath) == contentRev {
+		t.Fatal("no commit was made")
+	}
+	if got := searchHubRevision(cfg); got != contentRev || idx.Manifest.HubRevision != contentRev {
+		t.Errorf("revision = %s, manifest %s, want %s", got, idx.Manifest.HubRevision, contentRev)
+	}
+
+	// Publishing again without changes makes no new commit.
+	head := hubHeadSHA(cfg.RepoPath)
+	if err := publishSearchIndex(cfg, prov); err != nil {
+		t.Fatalf("second publish: %v", err)
+	}
+	if hubHeadSHA(cfg.RepoPath) != head {
+		t.Error("unchanged index was committed again")
+	}
+}
+
+func TestSemanticSearch_RanksChunkedDocumentsByBestRow(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	cfg := &config.Config{RepoPath: filepath.Join(tmp, "hub")}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [{"embedding": [0, 1]}]}`))
+	}))
+	defer server.Close()
+	t.Setenv("AXON_EMBEDDINGS_PROVIDER", "openai")
+	t.Setenv("AXON_EMBEDDINGS_BASE_URL", server.URL)
+	t.Setenv("AXON_EMBEDDINGS_API_KEY", "k")
+	t.Setenv("AXON_EMBEDDINGS_MODEL", "test")
+
+	// "oracle" only matches the query through its second body chunk.
+	m := searchindex.Manifest{IndexVersion: 1, ModelID: "openai:test", Dim: 2, Normalize: true, ChunkSize: 200}
+	skills := []searchindex.SkillEntry{
+		{ID: "oracle", Path: "skills/oracle"},
+		{ID: "oracle", Path: "skills/oracle", Chunk: 1},
+		{ID: "oracle", Path: "skills/oracle", Chunk: 2},
+		{ID: "mysql", Path: "skills/mysql"},
+	}
+	vectors := []float32{1, 0, 0.6, 0.8, 0, 1, 0.8, 0.6}
+	if err := searchindex.Write(filepath.Join(tmp, ".axon", "search"), m, skills, vectors); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := semanticSearch(cfg, "rotate passwords", 0, 5, search.Filter{})
+	if err != nil {
+		t.Fatalf("semanticSearch: %v", err)
+	}
+	if len(results) != 2 || results[0].Skill.ID != "oracle" || results[0].Score < 0.99 {
+		t.Errorf("results = %+v, want oracle once with its best chunk's score", results)
+	}
+}
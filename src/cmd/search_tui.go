@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/search"
+	"golang.org/x/text/width"
+)
+
+// ── Interactive finder ────────────────────────────────────────────────────────
+// 'axon search -i' filters every skill, workflow, and command as you type and
+// previews the highlighted file. Enter prints its path, Ctrl-O opens it in
+// $EDITOR. The finder draws on stderr so the path can be captured:
+// cd "$(axon search -i)".
+
+type finderAction int
+
+const (
+	finderNone finderAction = iota
+	finderPath
+	finderOpen
+	finderCancel
+)
+
+type keyCode int
+
+const (
+	keyUnknown keyCode = iota
+	keyRune
+	keyEnter
+	keyBackspace
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyEsc
+	keyClear  // Ctrl-U
+	keyOpen   // Ctrl-O
+	keyCancel // Ctrl-C, Ctrl-G
+)
+
+type finderKey struct {
+	code keyCode
+	r    rune
+}
+
+// readFinderKey reads one key press from a raw-mode terminal.
+func readFinderKey(r *bufio.Reader) (finderKey, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return finderKey{}, err
+	}
+	switch c {
+	case '\r', '\n':
+		return finderKey{code: keyEnter}, nil
+	case 0x7f, 0x08:
+		return finderKey{code: keyBackspace}, nil
+	case 0x03, 0x07:
+		return finderKey{code: keyCancel}, nil
+	case 0x15:
+		return finderKey{code: keyClear}, nil
+	case 0x0f:
+		return finderKey{code: keyOpen}, nil
+	case 0x10:
+		return finderKey{code: keyUp}, nil
+	case 0x0e:
+		return finderKey{code: keyDown}, nil
+	case 0x1b:
+		// A lone Esc arrives by itself; arrow keys arrive as one sequence.
+		if r.Buffered() == 0 {
+			return finderKey{code: keyEsc}, nil
+		}
+		if next, _, err := r.ReadRune(); err != nil || (next != '[' && next != 'O') {
+			return finderKey{code: keyUnknown}, err
+		}
+		var seq []rune
+		for {
+			b, _, err := r.ReadRune()
+			if err != nil {
+				return finderKey{}, err
+			}
+			seq = append(seq, b)
+			if b >= 0x40 && b <= 0x7e {
+				break
+			}
+		}
+		switch string(seq) {
+		case "A":
+			return finderKey{code: keyUp}, nil
+		case "B":
+			return finderKey{code: keyDown}, nil
+		case "5~":
+			return finderKey{code: keyPageUp}, nil
+		case "6~":
+			return finderKey{code: keyPageDown}, nil
+		}
+		return finderKey{code: keyUnknown}, nil
+	}
+	if unicode.IsControl(c) {
+		return finderKey{code: keyUnknown}, nil
+	}
+	return finderKey{code: keyRune, r: c}, nil
+}
+
+// finder is the state of the interactive finder, independent of the
+// terminal.
+type finder struct {
+	docs    []search.SkillDoc
+	query   []rune
+	matches []search.SearchResult
+	cursor  int
+	offset  int // first visible match
+}
+
+func newFinder(docs []search.SkillDoc, query string) *finder {
+	f := &finder{docs: docs, query: []rune(query)}
+	f.filter()
+	return f
+}
+
+func (f *finder) filter() {
+	f.matches = search.FuzzyFilter(f.docs, string(f.query))
+	f.cursor, f.offset = 0, 0
+}
+
+// handle applies k; page is how far PageUp/PageDown move.
+func (f *finder) handle(k finderKey, page int) finderAction {
+	switch k.code {
+	case keyRune:
+		f.query = append(f.query, k.r)
+		f.filter()
+	case keyBackspace:
+		if len(f.query) > 0 {
+			f.query = f.query[:len(f.query)-1]
+			f.filter()
+		}
+	case keyClear:
+		f.query = nil
+		f.filter()
+	case keyUp:
+		f.move(-1)
+	case keyDown:
+		f.move(1)
+	case keyPageUp:
+		f.move(-page)
+	case keyPageDown:
+		f.move(page)
+	case keyEnter:
+		if len(f.matches) > 0 {
+			return finderPath
+		}
+	case keyOpen:
+		if len(f.matches) > 0 {
+			return finderOpen
+		}
+	case keyEsc, keyCancel:
+		return finderCancel
+	}
+	return finderNone
+}
+
+func (f *finder) move(n int) {
+	f.cursor += n
+	if f.cursor >= len(f.matches) {
+		f.cursor = len(f.matches) - 1
+	}
+	if f.cursor < 0 {
+		f.cursor = 0
+	}
+}
+
+func (f *finder) selected() (search.SkillDoc, bool) {
+	if len(f.matches) == 0 {
+		return search.SkillDoc{}, false
+	}
+	return f.matches[f.cursor].Skill, true
+}
+
+// finderListRows is how many matches fit on a screen of height rows; the
+// rest below the prompt and the rule is preview.
+func finderListRows(height int) int {
+	if n := (height - 2) * 2 / 5; n > 1 {
+		return n
+	}
+	return 1
+}
+
+// render draws the prompt, the visible matches, and the preview of the
+// highlighted document on a width×height screen.
+func (f *finder) render(w io.Writer, cols, height int, preview []string) {
+	rows := finderListRows(height)
+	if f.cursor < f.offset {
+		f.offset = f.cursor
+	}
+	if f.cursor >= f.offset+rows {
+		f.offset = f.cursor - rows + 1
+	}
+
+	var b strings.Builder
+	b.WriteString("\x1b[?25l\x1b[H")
+	line := func(s string) {
+		b.WriteString(s)
+		b.WriteString("\x1b[K\r\n")
+	}
+
+	count := fmt.Sprintf("  %d/%d", len(f.matches), len(f.docs))
+	query := truncateWidth(string(f.query), cols-2-len(count))
+	line("> " + query + "\x1b[2m" + count + "\x1b[0m")
+	for i := 0; i < rows; i++ {
+		j := f.offset + i
+		if j >= len(f.matches) {
+			line("")
+			continue
+		}
+		label := truncateWidth(finderLabel(f.matches[j].Skill), cols-2)
+		if j == f.cursor {
+			line("\x1b[7m> " + label + "\x1b[0m")
+		} else {
+			line("  " + label)
+		}
+	}
+	line("\x1b[2m" + strings.Repeat("─", cols) + "\x1b[0m")
+	for i, n := 0, height-2-rows; i < n; i++ {
+		s := ""
+		if i < len(preview) {
+			s = truncateWidth(preview[i], cols)
+		}
+		if i == n-1 {
+			b.WriteString(s + "\x1b[K") // no newline: it would scroll the screen
+		} else {
+			line(s)
+		}
+	}
+	// Park the cursor after the query.
+	fmt.Fprintf(&b, "\x1b[1;%dH\x1b[?25h", 3+displayWidth(query))
+	_, _ = io.WriteString(w, b.String())
+}
+
+// finderLabel is a document's list entry: its path-like ID, the repo it came
+// from when several are searched, and its description.
+func finderLabel(d search.SkillDoc) string {
+	label := strings.ReplaceAll(d.ID, ":", "/")
+	if d.Repo != "" {
+		label += " (" + d.Repo + ")"
+	}
+	if desc := strings.TrimSpace(d.Description); desc != "" {
+		label += "  — " + desc
+	}
+	return label
+}
+
+// runSearchInteractive runs the finder over the keyword index's documents
+// that pass filter.
+func runSearchInteractive(cfg *config.Config, query string, filter search.Filter) error {
+	in, out := os.Stdin.Fd(), os.Stderr.Fd()
+	if !isTerminal(in) || !isTerminal(out) {
+		return errors.New("interactive search needs a terminal on stdin and stderr")
+	}
+	docs, files, err := finderDocuments(cfg)
+	if err != nil {
+		return err
+	}
+	if docs = filterDocs(docs, filter); len(docs) == 0 && !filter.Empty() {
+		return fmt.Errorf("no documents match %s", filter)
+	}
+
+	restore, err := makeRaw(in, out)
+	if err != nil {
+		return fmt.Errorf("cannot switch the terminal to raw mode: %w", err)
+	}
+	screen := os.Stderr
+	_, _ = io.WriteString(screen, "\x1b[?1049h") // alternate screen
+	leave := func() {
+		_, _ = io.WriteString(screen, "\x1b[?1049l")
+		restore()
+	}
+
+	f := newFinder(docs, query)
+	keys := bufio.NewReader(os.Stdin)
+	previews := make(map[string][]string)
+	action := finderNone
+	for action == finderNone {
+		cols, height, err := terminalSize(out)
+		if err != nil || cols < 20 || height < 6 {
+			cols, height = 80, 24
+		}
+		var preview []string
+		if d, ok := f.selected(); ok {
+			file := files[d.ID]
+			if _, cached := previews[file]; !cached {
+				previews[file] = previewLines(file)
+			}
+			preview = previews[file]
+		}
+		f.render(screen, cols, height, preview)
+
+		k, err := readFinderKey(keys)
+		if err != nil {
+			leave()
+			return err
+		}
+		action = f.handle(k, finderListRows(height))
+	}
+	leave()
+
+	d, _ := f.selected()
+	switch action {
+	case finderPath:
+		fmt.Println(shellPath(files[d.ID]))
+	case finderOpen:
+		return openInEditor(files[d.ID])
+	}
+	return nil
+}
+
+// finderDocuments returns every searchable document and its file by ID,
+// taken from the (refreshed) keyword index.
+func finderDocuments(cfg *config.Config) ([]search.SkillDoc, map[string]string, error) {
+	dir, err := keywordIndexDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	idx, err := refreshKeywordIndex(cfg, dir, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	docs := make([]search.SkillDoc, 0, idx.Live)
+	files := make(map[string]string, idx.Live)
+	for _, d := range idx.Docs {
+		if d.Removed {
+			continue
+		}
+		docs = append(docs, d.SkillDoc)
+		files[d.ID] = d.File
+	}
+	return docs, files, nil
+}
+
+// previewLines reads file for the preview pane.
+func previewLines(file string) []string {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return []string{fmt.Sprintf("(cannot read %s: %v)", file, err)}
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\t", "    ")
+	return strings.Split(text, "\n")
+}
+
+// runeWidth is the number of terminal columns r takes.
+func runeWidth(r rune) int {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	if unicode.Is(unicode.Mn, r) {
+		return 0
+	}
+	return 1
+}
+
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// truncateWidth cuts s to at most cols terminal columns and drops control
+// characters, which would otherwise reach the terminal as escapes.
+func truncateWidth(s string, cols int) string {
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			continue
+		}
+		w := runeWidth(r)
+		if used+w > cols {
+			break
+		}
+		used += w
+		b.WriteRune(r)
+	}
+	return b.String()
+}
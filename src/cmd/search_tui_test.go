@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/search"
+)
+
+func TestReadFinderKey(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("a\x1b[A\x1b[B\x7f\r\x0f\x03界"))
+	want := []finderKey{
+		{code: keyRune, r: 'a'}, {code: keyUp}, {code: keyDown}, {code: keyBackspace},
+		{code: keyEnter}, {code: keyOpen}, {code: keyCancel}, {code: keyRune, r: '界'},
+	}
+	for i, w := range want {
+		got, err := readFinderKey(r)
+		if err != nil || got != w {
+			t.Fatalf("key %d = %+v, %v; want %+v", i, got, err, w)
+		}
+	}
+}
+
+func TestFinder_FiltersMovesAndSelects(t *testing.T) {
+	docs := []search.SkillDoc{
+		{ID: "docker-compose", Name: "docker-compose"},
+		{ID: "db-migrate", Name: "db-migrate"},
+		{ID: "workflows:deploy", Name: "deploy"},
+	}
+	f := newFinder(docs, "")
+	if len(f.matches) != 3 {
+		t.Fatalf("empty query matches %d, want 3", len(f.matches))
+	}
+	for _, r := range "dep" {
+		f.handle(finderKey{code: keyRune, r: r}, 5)
+	}
+	if d, _ := f.selected(); d.ID != "workflows:deploy" {
+		t.Fatalf("selected %q after typing dep", d.ID)
+	}
+	f.handle(finderKey{code: keyClear}, 5)
+	f.handle(finderKey{code: keyDown}, 5)
+	f.handle(finderKey{code: keyPageDown}, 5)
+	if f.cursor != 2 {
+		t.Errorf("cursor = %d, want clamped to 2", f.cursor)
+	}
+	if a := f.handle(finderKey{code: keyEnter}, 5); a != finderPath {
+		t.Errorf("enter = %v, want finderPath", a)
+	}
+	if a := f.handle(finderKey{code: keyEsc}, 5); a != finderCancel {
+		t.Errorf("esc = %v, want finderCancel", a)
+	}
+
+	f.handle(finderKey{code: keyRune, r: 'z'}, 5)
+	if a := f.handle(finderKey{code: keyEnter}, 5); a != finderNone {
+		t.Errorf("enter with no matches = %v, want finderNone", a)
+	}
+}
+
+func TestFinder_RenderShowsMatchesAndPreview(t *testing.T) {
+	f := newFinder([]search.SkillDoc{{ID: "alpha", Description: "first"}, {ID: "beta"}}, "")
+	var b strings.Builder
+	f.render(&b, 40, 10, []string{"# Alpha preview", "\x1b[31mred"})
+	out := b.String()
+	for _, want := range []string{"2/2", "> alpha  — first", "# Alpha preview", "[31mred"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render output lacks %q", want)
+		}
+	}
+	if strings.Contains(out, "\x1b[31m") {
+		t.Error("control characters from the preview reached the terminal")
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	if got := truncateWidth("技能搜索", 5); got != "技能" {
+		t.Errorf("truncateWidth(wide) = %q, want two wide runes", got)
+	}
+	if got := truncateWidth("abc", 0); got != "" {
+		t.Errorf("truncateWidth(abc, 0) = %q", got)
+	}
+}
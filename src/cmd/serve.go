@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/mcp"
+	"github.com/kamusis/axon-cli/internal/search"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve [--mcp]",
+	Short: "Serve the Hub to editors and AI tools (REST API or MCP)",
+	Long: `Run axon as a local server.
+
+Without flags, serve a REST API on localhost so editor extensions and GUIs
+can drive axon without shelling out:
+
+  GET  /v1/version              GET  /v1/status
+  GET  /v1/skills[?kind=]       GET  /v1/skills/{id}[?repo=]
+  GET  /v1/search?q=[&k=&mode=semantic|keyword]
+  POST /v1/link   {"target": "..."}   (optional body)
+  POST /v1/unlink {"target": "..."}
+  POST /v1/sync   {"repo": "..."}
+
+Every request needs 'Authorization: Bearer <token>'. The token is
+AXON_SERVE_TOKEN if set, otherwise ~/.axon/serve.token, which is generated
+on first start. The server only binds loopback addresses.
+
+With --mcp, serve the Hub over the Model Context Protocol (MCP) instead, so
+AI tools can list, search, read, and inspect skills without a linked skills
+directory. MCP speaks over stdin/stdout, which is how most tools launch
+servers. Register it in the tool's MCP configuration, e.g.:
+
+  {"mcpServers": {"axon": {"command": "axon", "args": ["serve", "--mcp"]}}}
+
+With --mcp --sse, it serves the HTTP+SSE transport on a local address
+instead: clients open http://<addr>/sse.
+
+MCP tools: list_skills, search_skills, get_skill, inspect_skill. Every
+document is also a resource (axon://doc/<id>).`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+var (
+	flagServeMCP  bool
+	flagServeSSE  string
+	flagServeAddr string
+)
+
+func init() {
+	serveCmd.Flags().BoolVar(&flagServeMCP, "mcp", false, "Speak the Model Context Protocol instead of the REST API")
+	serveCmd.Flags().StringVar(&flagServeSSE, "sse", "", "With --mcp: serve HTTP+SSE on this local address (e.g. 127.0.0.1:8765) instead of stdio")
+	serveCmd.Flags().StringVar(&flagServeAddr, "addr", defaultServeAddr, "Address of the REST API")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, _ []string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	if !flagServeMCP {
+		if flagServeSSE != "" {
+			return errors.New("--sse requires --mcp")
+		}
+		// The API loads the config per request; fail early if there is none.
+		if _, err := config.Load(); err != nil {
+			return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+		}
+		return runServeAPI(ctx, flagServeAddr)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	srv := newMCPServer(cfg)
+
+	if flagServeSSE == "" {
+		// stdout carries the protocol; nothing else may be printed there.
+		return srv.ServeStdio(ctx, os.Stdin, os.Stdout)
+	}
+
+	ln, err := net.Listen("tcp", flagServeSSE)
+	if err != nil {
+		return err
+	}
+	if ip := ln.Addr().(*net.TCPAddr).IP; !ip.IsLoopback() {
+		ln.Close()
+		return fmt.Errorf("refusing to serve on %s: use a loopback address such as 127.0.0.1", flagServeSSE)
+	}
+	httpSrv := &http.Server{Handler: srv.SSEHandler()}
+	go func() {
+		<-ctx.Done()
+		_ = httpSrv.Close()
+	}()
+	printOK("", fmt.Sprintf("MCP server listening on http://%s/sse", ln.Addr()))
+	if err := httpSrv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// hubDocInfo is how tools describe a document.
+type hubDocInfo struct {
+	ID          string  `json:"id"`
+	Kind        string  `json:"kind"` // top-level directory: skills, workflows, commands, …
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Path        string  `json:"path"`
+	Repo        string  `json:"repo,omitempty"`
+	Score       float64 `json:"score,omitempty"`
+}
+
+func newHubDocInfo(d search.SkillDoc) hubDocInfo {
+	kind, _, _ := strings.Cut(d.Path, "/")
+	return hubDocInfo{ID: d.ID, Kind: kind, Name: d.Name, Description: d.Description, Path: d.Path, Repo: d.Repo}
+}
+
+// newMCPServer exposes cfg's Hub as MCP tools and resources.
+func newMCPServer(cfg *config.Config) *mcp.Server {
+	srv := mcp.NewServer("axon", version)
+	idArgs := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":   map[string]any{"type": "string", "description": "Document ID from list_skills or search_skills"},
+			"repo": map[string]any{"type": "string", "description": "Hub repo, when the ID was listed with one"},
+		},
+		"required": []string{"id"},
+	}
+
+	srv.AddTool(mcp.Tool{
+		Name:        "list_skills",
+		Description: "List the skills, workflows, and commands in the Axon Hub with their descriptions.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"kind": map[string]any{"type": "string", "description": "Only list this kind (skills, workflows, commands)"},
+			},
+		},
+	}, func(raw json.RawMessage) (string, error) {
+		var args struct {
+			Kind string `json:"kind"`
+		}
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return "", err
+		}
+		docs, err := discoverHubDocuments(cfg)
+		if err != nil {
+			return "", err
+		}
+		out := []hubDocInfo{}
+		for _, d := range docs {
+			info := newHubDocInfo(d)
+			if args.Kind == "" || info.Kind == args.Kind {
+				out = append(out, info)
+			}
+		}
+		return mcpJSON(out)
+	})
+
+	srv.AddTool(mcp.Tool{
+		Name:        "search_skills",
+		Description: "Search the Axon Hub for documents relevant to a task, semantically when an index is available and by keywords otherwise.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string", "description": "What the skill should help with"},
+				"k":     map[string]any{"type": "integer", "description": "Number of results (default 5)"},
+			},
+			"required": []string{"query"},
+		},
+	}, func(raw json.RawMessage) (string, error) {
+		var args struct {
+			Query string `json:"query"`
+			K     int    `json:"k"`
+		}
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(args.Query) == "" {
+			return "", errors.New("query is required")
+		}
+		if args.K <= 0 {
+			args.K = 5
+		}
+		results, err := semanticSearch(cfg, args.Query, 0.30, args.K, search.Filter{})
+		if err != nil || len(results) == 0 {
+			if results, err = keywordSearch(cfg, args.Query, args.K, search.Filter{}); err != nil {
+				return "", err
+			}
+		}
+		out := []hubDocInfo{}
+		for _, r := range results {
+			info := newHubDocInfo(r.Skill)
+			if r.Why == "semantic" {
+				info.Score = r.Score
+			}
+			out = append(out, info)
+		}
+		return mcpJSON(out)
+	})
+
+	srv.AddTool(mcp.Tool{
+		Name:        "get_skill",
+		Description: "Return the full content of a skill's SKILL.md, or of a workflow or command file.",
+		InputSchema: idArgs,
+	}, func(raw json.RawMessage) (string, error) {
+		file, _, err := mcpDocumentFile(cfg, raw)
+		if err != nil {
+			return "", err
+		}
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		recordSkillRead(cfg, file)
+		return string(b), nil
+	})
+
+	srv.AddTool(mcp.Tool{
+		Name:        "inspect_skill",
+		Description: "Return a document's metadata: frontmatter fields, triggers, declared dependencies, and the files of a skill.",
+		InputSchema: idArgs,
+	}, func(raw json.RawMessage) (string, error) {
+		file, id, err := mcpDocumentFile(cfg, raw)
+		if err != nil {
+			return "", err
+		}
+		return mcpJSON(inspectDocument(file, id))
+	})
+
+	srv.SetResources(hubResources{cfg: cfg})
+	return srv
+}
+
+// mcpDocumentFile resolves the {id, repo} arguments of a tool call.
+func mcpDocumentFile(cfg *config.Config, raw json.RawMessage) (file, id string, err error) {
+	var args struct {
+		ID   string `json:"id"`
+		Repo string `json:"repo"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return "", "", err
+	}
+	if args.ID == "" {
+		return "", "", errors.New("id is required")
+	}
+	file, err = searchResultFile(cfg, search.SearchResult{Skill: search.SkillDoc{ID: args.ID, Repo: args.Repo}})
+	return file, args.ID, err
+}
+
+// documentInspection is what inspect_skill reports.
+type documentInspection struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Version      string   `json:"version,omitempty"`
+	License      string   `json:"license,omitempty"`
+	AllowedTools []string `json:"allowed_tools,omitempty"`
+	Triggers     []string `json:"triggers,omitempty"`
+	Requires     struct {
+		Bins   []string `json:"bins,omitempty"`
+		Envs   []string `json:"envs,omitempty"`
+		NPM    []string `json:"npm,omitempty"`
+		Python []string `json:"python,omitempty"`
+		Skills []string `json:"skills,omitempty"`
+	} `json:"requires"`
+	Files []string `json:"files,omitempty"`
+}
+
+func inspectDocument(file, id string) documentInspection {
+	out := documentInspection{ID: id}
+	if meta, ok := parseSkillMeta(file); ok {
+		out.Name, out.Description = meta.Name, meta.Description
+		out.Version, out.License = meta.Version, meta.License
+		out.AllowedTools = meta.AllowedTools
+		out.Triggers = extractTriggers(meta.Triggers)
+		out.Requires.Bins = meta.GetRequiresBins()
+		out.Requires.Envs = meta.GetRequiresEnvs()
+		out.Requires.NPM = meta.GetRequiresNPM()
+		out.Requires.Python = meta.GetRequiresPython()
+		out.Requires.Skills = meta.GetRequiresSkills()
+	}
+	if filepath.Base(file) == "SKILL.md" {
+		out.Files = listSkillFiles(filepath.Dir(file))
+	}
+	return out
+}
+
+func mcpJSON(v any) (string, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	return string(b), err
+}
+
+// discoverHubDocuments reads every searchable document of every Hub repo.
+func discoverHubDocuments(cfg *config.Config) ([]search.SkillDoc, error) {
+	if repos := searchRepoRoots(cfg); repos != nil {
+		return search.DiscoverRepoDocuments(repos, cfg.EffectiveSearchRoots())
+	}
+	return search.DiscoverDocuments(cfg.RepoPath, cfg.EffectiveSearchRoots())
+}
+
+// hubResources exposes every document as axon://doc/<id>, with ?repo=<name>
+// when several Hub repos are configured.
+type hubResources struct {
+	cfg *config.Config
+}
+
+func (h hubResources) ListResources() ([]mcp.Resource, error) {
+	docs, err := discoverHubDocuments(h.cfg)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]mcp.Resource, 0, len(docs))
+	for _, d := range docs {
+		out = append(out, mcp.Resource{URI: docResourceURI(d.ID, d.Repo), Name: d.Name, Description: d.Description, MimeType: "text/markdown"})
+	}
+	return out, nil
+}
+
+func (h hubResources) ReadResource(uri string) (mcp.ResourceContents, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "axon" || u.Host != "doc" {
+		return mcp.ResourceContents{}, fmt.Errorf("unknown resource %q", uri)
+	}
+	id := strings.TrimPrefix(u.Path, "/")
+	file, err := searchResultFile(h.cfg, search.SearchResult{Skill: search.SkillDoc{ID: id, Repo: u.Query().Get("repo")}})
+	if err != nil {
+		return mcp.ResourceContents{}, err
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return mcp.ResourceContents{}, err
+	}
+	return mcp.ResourceContents{URI: uri, MimeType: "text/markdown", Text: string(b)}, nil
+}
+
+func docResourceURI(id, repo string) string {
+	u := url.URL{Scheme: "axon", Host: "doc", Path: "/" + id}
+	if repo != "" {
+		u.RawQuery = url.Values{"repo": {repo}}.Encode()
+	}
+	return u.String()
+}
@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/search"
+)
+
+// defaultServeAddr is where 'axon serve' listens without --addr.
+const defaultServeAddr = "127.0.0.1:7777"
+
+// serveTokenKey overrides the generated API token (environment or
+// ~/.axon/.env).
+const serveTokenKey = "AXON_SERVE_TOKEN"
+
+// serveTokenFile is the token file under ~/.axon, created on first start.
+const serveTokenFile = "serve.token"
+
+// loadServeToken returns AXON_SERVE_TOKEN, or the token in
+// ~/.axon/serve.token, generating one when there is none yet.
+func loadServeToken() (token, source string, err error) {
+	if v, _ := config.GetConfigValue(serveTokenKey); strings.TrimSpace(v) != "" {
+		return strings.TrimSpace(v), serveTokenKey, nil
+	}
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", "", err
+	}
+	path := filepath.Join(axonDir, serveTokenFile)
+	if b, err := os.ReadFile(path); err == nil && len(bytes.TrimSpace(b)) > 0 {
+		return string(bytes.TrimSpace(b)), path, nil
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = hex.EncodeToString(buf)
+	if err := os.MkdirAll(axonDir, 0o755); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", "", fmt.Errorf("cannot write %s: %w", path, err)
+	}
+	return token, path, nil
+}
+
+// runServeAPI serves the REST API on addr until ctx is done.
+func runServeAPI(ctx context.Context, addr string) error {
+	token, source, err := loadServeToken()
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	if ip := ln.Addr().(*net.TCPAddr).IP; !ip.IsLoopback() {
+		ln.Close()
+		return fmt.Errorf("refusing to serve on %s: use a loopback address such as 127.0.0.1", addr)
+	}
+	srv := &http.Server{Handler: newServeAPI(config.Load, token), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdown)
+	}()
+	printOK("", fmt.Sprintf("axon API listening on http://%s/v1/", ln.Addr()))
+	printInfo("", fmt.Sprintf("Send 'Authorization: Bearer <token>' with the token from %s.", source))
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// axonSubcommand runs axon itself with args and returns its combined
+// output and exit code. The API runs commands that change state (link,
+// unlink, sync) this way, so their flags and output never interfere with
+// the server. Tests replace it.
+var axonSubcommand = func(ctx context.Context, args ...string) (string, int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", -1, err
+	}
+	out, err := exec.CommandContext(ctx, exe, args...).CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(out), exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return string(out), -1, err
+	}
+	return string(out), 0, nil
+}
+
+// serveAPI is the REST API behind 'axon serve'. The config is loaded per
+// request so edits to axon.yaml apply without a restart.
+type serveAPI struct {
+	loadConfig func() (*config.Config, error)
+	token      string
+
+	// busy serializes operations that change the Hub or destinations.
+	busy sync.Mutex
+}
+
+func newServeAPI(loadConfig func() (*config.Config, error), token string) http.Handler {
+	api := &serveAPI{loadConfig: loadConfig, token: token}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/version", api.version)
+	mux.HandleFunc("GET /v1/skills", api.withConfig(api.listSkills))
+	mux.HandleFunc("GET /v1/skills/{id}", api.withConfig(api.getSkill))
+	mux.HandleFunc("GET /v1/search", api.withConfig(api.search))
+	mux.HandleFunc("GET /v1/status", api.withConfig(api.status))
+	mux.HandleFunc("POST /v1/link", api.runCommand("link"))
+	mux.HandleFunc("POST /v1/unlink", api.runCommand("unlink"))
+	mux.HandleFunc("POST /v1/sync", api.runCommand("sync"))
+	return api.authorize(mux)
+}
+
+// authorize requires the bearer token and a loopback Host header, which
+// keeps web pages (including DNS-rebinding ones) out.
+func (api *serveAPI) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			writeAPIError(w, http.StatusForbidden, "forbidden host")
+			return
+		}
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(api.token)) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (api *serveAPI) withConfig(h func(http.ResponseWriter, *http.Request, *config.Config)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cfg, err := api.loadConfig()
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("cannot load config: %v", err))
+			return
+		}
+		h(w, r, cfg)
+	}
+}
+
+func (api *serveAPI) version(w http.ResponseWriter, _ *http.Request) {
+	writeAPIJSON(w, http.StatusOK, map[string]string{"version": version, "commit": commit})
+}
+
+func (api *serveAPI) listSkills(w http.ResponseWriter, r *http.Request, cfg *config.Config) {
+	docs, err := discoverHubDocuments(cfg)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	kind := r.URL.Query().Get("kind")
+	out := []hubDocInfo{}
+	for _, d := range docs {
+		if info := newHubDocInfo(d); kind == "" || info.Kind == kind {
+			out = append(out, info)
+		}
+	}
+	writeAPIJSON(w, http.StatusOK, out)
+}
+
+func (api *serveAPI) getSkill(w http.ResponseWriter, r *http.Request, cfg *config.Config) {
+	doc := search.SkillDoc{ID: r.PathValue("id"), Repo: r.URL.Query().Get("repo")}
+	file, err := searchResultFile(cfg, search.SearchResult{Skill: doc})
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	recordSkillRead(cfg, file)
+	writeAPIJSON(w, http.StatusOK, struct {
+		documentInspection
+		Repo    string `json:"repo,omitempty"`
+		File    string `json:"file"`
+		Content string `json:"content"`
+	}{inspectDocument(file, doc.ID), doc.Repo, file, string(b)})
+}
+
+func (api *serveAPI) search(w http.ResponseWriter, r *http.Request, cfg *config.Config) {
+	q := r.URL.Query()
+	query := strings.TrimSpace(q.Get("q"))
+	if query == "" {
+		writeAPIError(w, http.StatusBadRequest, "q is required")
+		return
+	}
+	k := 5
+	if v := q.Get("k"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeAPIError(w, http.StatusBadRequest, "k must be a positive number")
+			return
+		}
+		k = n
+	}
+
+	var (
+		results []search.SearchResult
+		err     error
+	)
+	switch mode := q.Get("mode"); mode {
+	case "semantic":
+		results, err = semanticSearch(cfg, query, 0, k, search.Filter{})
+	case "keyword":
+		results, err = keywordSearch(cfg, query, k, search.Filter{})
+	case "":
+		if results, err = semanticSearch(cfg, query, 0.30, k, search.Filter{}); err != nil || len(results) == 0 {
+			results, err = keywordSearch(cfg, query, k, search.Filter{})
+		}
+	default:
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("unknown mode %q (use semantic or keyword)", mode))
+		return
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	out := []hubDocInfo{}
+	for _, r := range results {
+		info := newHubDocInfo(r.Skill)
+		info.Score = r.Score
+		out = append(out, info)
+	}
+	writeAPIJSON(w, http.StatusOK, out)
+}
+
+// apiRepoStatus is one Hub repo in GET /v1/status. Ahead and behind are nil
+// when the repo has no remote default branch.
+type apiRepoStatus struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Dirty  bool   `json:"dirty"`
+	Ahead  *int   `json:"ahead,omitempty"`
+	Behind *int   `json:"behind,omitempty"`
+}
+
+type apiBrokenTarget struct {
+	Target string `json:"target"`
+	Detail string `json:"detail"`
+}
+
+func (api *serveAPI) status(w http.ResponseWriter, _ *http.Request, cfg *config.Config) {
+	h := collectLinkHealth(cfg)
+	out := struct {
+		OK           bool              `json:"ok"`
+		Linked       []string          `json:"linked"`
+		NeedLink     []string          `json:"need_link"`
+		RealDir      []string          `json:"real_dir"`
+		Broken       []apiBrokenTarget `json:"broken"`
+		NotInstalled []string          `json:"not_installed"`
+		Repos        []apiRepoStatus   `json:"repos"`
+	}{
+		OK:           len(h.broken) == 0 && len(h.needLink) == 0 && len(h.realDir) == 0,
+		Linked:       append([]string{}, h.linked...),
+		NeedLink:     append([]string{}, h.needLink...),
+		RealDir:      append([]string{}, h.realDir...),
+		Broken:       []apiBrokenTarget{},
+		NotInstalled: append([]string{}, h.notInstalled...),
+		Repos:        []apiRepoStatus{},
+	}
+	for _, b := range h.broken {
+		out.Broken = append(out.Broken, apiBrokenTarget{b.name, b.msg})
+	}
+	if checkGitAvailable() == nil {
+		for _, r := range cfg.HubRepos() {
+			rs := apiRepoStatus{Name: r.Name, Path: r.Path}
+			rs.Dirty, _ = gitIsDirty(r.Path)
+			if ref, a, b, err := hubAheadBehind(r.Path); err == nil && ref != "" {
+				rs.Ahead, rs.Behind = &a, &b
+			}
+			out.Repos = append(out.Repos, rs)
+		}
+	}
+	writeAPIJSON(w, http.StatusOK, out)
+}
+
+// runCommand runs 'axon <name>' for POST requests. The optional JSON body
+// names a target ({"target": "cursor-skills"}) for link and unlink, or a
+// repo ({"repo": "team"}) for sync. Only one such command runs at a time.
+func (api *serveAPI) runCommand(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Target string `json:"target"`
+			Repo   string `json:"repo"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			writeAPIError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		if strings.HasPrefix(body.Target, "-") || strings.HasPrefix(body.Repo, "-") {
+			writeAPIError(w, http.StatusBadRequest, "invalid target or repo name")
+			return
+		}
+		args := []string{name}
+		switch {
+		case name == "sync" && body.Repo != "":
+			args = append(args, "--repo", body.Repo)
+		case name != "sync" && body.Target != "":
+			args = append(args, body.Target)
+		}
+
+		if !api.busy.TryLock() {
+			writeAPIError(w, http.StatusConflict, "another operation is running")
+			return
+		}
+		defer api.busy.Unlock()
+
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Minute)
+		defer cancel()
+		output, code, err := axonSubcommand(ctx, args...)
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		status := http.StatusOK
+		if code != 0 {
+			status = http.StatusUnprocessableEntity
+		}
+		writeAPIJSON(w, status, map[string]any{"ok": code == 0, "exit_code": code, "output": output})
+	}
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, msg string) {
+	writeAPIJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestServeAPI(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	t.Setenv("AXON_EMBEDDINGS_PROVIDER", "")
+	skill := filepath.Join(cfg.RepoPath, "skills", "oracle-admin", "SKILL.md")
+	for _, dir := range []string{filepath.Dir(skill), filepath.Dir(cfg.Targets[0].Destination)} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(skill, []byte("---\nname: oracle-admin\ndescription: rotate oracle passwords\n---\nRun ALTER USER.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var ran [][]string
+	orig := axonSubcommand
+	axonSubcommand = func(_ context.Context, args ...string) (string, int, error) {
+		ran = append(ran, args)
+		if args[0] == "sync" {
+			return "conflict\n", 1, nil
+		}
+		return "linked\n", 0, nil
+	}
+	t.Cleanup(func() { axonSubcommand = orig })
+
+	ts := httptest.NewServer(newServeAPI(func() (*config.Config, error) { return cfg, nil }, "secret"))
+	defer ts.Close()
+	do := func(method, path, body string, v any) int {
+		t.Helper()
+		req, _ := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if v != nil {
+			if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+				t.Fatalf("%s %s: %v", method, path, err)
+			}
+		}
+		return resp.StatusCode
+	}
+
+	var docs []hubDocInfo
+	if code := do("GET", "/v1/skills?kind=skills", "", &docs); code != 200 || len(docs) != 1 || docs[0].ID != "oracle-admin" {
+		t.Errorf("GET /v1/skills = %d %+v", code, docs)
+	}
+	var doc struct {
+		Name    string
+		Content string
+	}
+	if code := do("GET", "/v1/skills/oracle-admin", "", &doc); code != 200 || doc.Name != "oracle-admin" || !strings.Contains(doc.Content, "ALTER USER") {
+		t.Errorf("GET /v1/skills/oracle-admin = %d %+v", code, doc)
+	}
+	if code := do("GET", "/v1/skills/missing", "", nil); code != http.StatusNotFound {
+		t.Errorf("missing skill = %d", code)
+	}
+	if code := do("GET", "/v1/search?q=oracle&mode=keyword", "", &docs); code != 200 || len(docs) != 1 {
+		t.Errorf("GET /v1/search = %d %+v", code, docs)
+	}
+	if code := do("GET", "/v1/search?q=oracle&mode=fuzzy", "", nil); code != http.StatusBadRequest {
+		t.Errorf("unknown mode = %d", code)
+	}
+	var status struct {
+		OK       bool
+		NeedLink []string `json:"need_link"`
+	}
+	if code := do("GET", "/v1/status", "", &status); code != 200 || status.OK || len(status.NeedLink) != 1 {
+		t.Errorf("GET /v1/status = %d %+v", code, status)
+	}
+
+	var result struct {
+		OK       bool
+		ExitCode int `json:"exit_code"`
+		Output   string
+	}
+	if code := do("POST", "/v1/link", `{"target": "test-skills"}`, &result); code != 200 || !result.OK || result.Output != "linked\n" {
+		t.Errorf("POST /v1/link = %d %+v", code, result)
+	}
+	if code := do("POST", "/v1/sync", "", &result); code != http.StatusUnprocessableEntity || result.OK || result.ExitCode != 1 {
+		t.Errorf("failed sync = %d %+v", code, result)
+	}
+	if code := do("POST", "/v1/unlink", `{"target": "--all"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("flag injection = %d", code)
+	}
+	if want := [][]string{{"link", "test-skills"}, {"sync"}}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+
+	// Without the token, or from a foreign host name, nothing is served.
+	if resp, err := http.Get(ts.URL + "/v1/version"); err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("no token: %v %v", resp, err)
+	}
+	req, _ := http.NewRequest("GET", ts.URL+"/v1/version", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Host = "evil.example"
+	if resp, err := http.DefaultClient.Do(req); err != nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("foreign host: %v %v", resp, err)
+	}
+}
+
+func TestLoadServeToken_GeneratesOnce(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(serveTokenKey, "")
+
+	tok, src, err := loadServeToken()
+	if err != nil || len(tok) != 64 || src != filepath.Join(home, ".axon", serveTokenFile) {
+		t.Fatalf("loadServeToken() = %q, %q, %v", tok, src, err)
+	}
+	if fi, err := os.Stat(src); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Errorf("token file mode: %v %v", fi, err)
+	}
+	if again, _, _ := loadServeToken(); again != tok {
+		t.Error("token changed between starts")
+	}
+	t.Setenv(serveTokenKey, "from-env")
+	if tok, src, _ := loadServeToken(); tok != "from-env" || src != serveTokenKey {
+		t.Errorf("env token = %q from %q", tok, src)
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mcpCall sends one request to the server and returns its result.
+func mcpCall(t *testing.T, srv interface{ Handle([]byte) []byte }, method string, params any) map[string]any {
+	t.Helper()
+	p, _ := json.Marshal(params)
+	req, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": json.RawMessage(p)})
+	var resp struct {
+		Result map[string]any
+		Error  map[string]any
+	}
+	if err := json.Unmarshal(srv.Handle(req), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != nil {
+		t.Fatalf("%s: %v", method, resp.Error)
+	}
+	return resp.Result
+}
+
+func toolText(t *testing.T, res map[string]any) string {
+	t.Helper()
+	if res["isError"] == true {
+		t.Fatalf("tool failed: %v", res["content"])
+	}
+	return res["content"].([]any)[0].(map[string]any)["text"].(string)
+}
+
+func TestMCPServer_HubTools(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	t.Setenv("AXON_EMBEDDINGS_PROVIDER", "")
+	skill := filepath.Join(cfg.RepoPath, "skills", "oracle-admin", "SKILL.md")
+	if err := os.MkdirAll(filepath.Join(filepath.Dir(skill), "scripts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: oracle-admin\ndescription: rotate oracle passwords\nrequires:\n  bins: [sqlplus]\n---\nRun ALTER USER.\n"
+	if err := os.WriteFile(skill, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	srv := newMCPServer(cfg)
+
+	var docs []hubDocInfo
+	if err := json.Unmarshal([]byte(toolText(t, mcpCall(t, srv, "tools/call", map[string]any{"name": "list_skills"}))), &docs); err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].ID != "oracle-admin" || docs[0].Kind != "skills" {
+		t.Errorf("list_skills = %+v", docs)
+	}
+
+	found := toolText(t, mcpCall(t, srv, "tools/call", map[string]any{"name": "search_skills", "arguments": map[string]any{"query": "oracle passwords"}}))
+	if !strings.Contains(found, `"id": "oracle-admin"`) {
+		t.Errorf("search_skills = %s", found)
+	}
+
+	if got := toolText(t, mcpCall(t, srv, "tools/call", map[string]any{"name": "get_skill", "arguments": map[string]any{"id": "oracle-admin"}})); got != content {
+		t.Errorf("get_skill = %q", got)
+	}
+
+	var meta documentInspection
+	if err := json.Unmarshal([]byte(toolText(t, mcpCall(t, srv, "tools/call", map[string]any{"name": "inspect_skill", "arguments": map[string]any{"id": "oracle-admin"}}))), &meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Name != "oracle-admin" || len(meta.Requires.Bins) != 1 || len(meta.Files) != 2 {
+		t.Errorf("inspect_skill = %+v", meta)
+	}
+
+	res := mcpCall(t, srv, "tools/call", map[string]any{"name": "get_skill", "arguments": map[string]any{"id": "missing"}})
+	if res["isError"] != true {
+		t.Errorf("missing document should fail the tool call: %v", res)
+	}
+
+	list := mcpCall(t, srv, "resources/list", nil)["resources"].([]any)
+	uri := list[0].(map[string]any)["uri"].(string)
+	if uri != "axon://doc/oracle-admin" {
+		t.Errorf("resource URI = %s", uri)
+	}
+	read := mcpCall(t, srv, "resources/read", map[string]any{"uri": uri})["contents"].([]any)
+	if read[0].(map[string]any)["text"] != content {
+		t.Errorf("resources/read = %v", read)
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/frontmatter"
+	"github.com/kamusis/axon-cli/internal/termmd"
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Render a skill's instructions in the terminal",
+	Long: `Render the body of a skill's SKILL.md, or of a workflow, command, rule, or
+memory file, as formatted Markdown: headings, lists, code blocks, and
+emphasis, wrapped to the terminal width.
+
+The name is resolved like 'axon inspect': a skill name, a file name such as
+deploy.md, or a document ID from 'axon search' such as rules:pnpm.
+
+Output longer than the terminal is shown in $PAGER (default: less -R).
+--no-pager prints it directly; output to a pipe is never paged.
+
+Examples:
+  axon show humanizer
+  axon show rules:pnpm
+  axon show git-release --no-pager`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShow,
+}
+
+var flagShowNoPager bool
+
+func init() {
+	showCmd.Flags().BoolVar(&flagShowNoPager, "no-pager", false, "Print directly instead of using a pager")
+	rootCmd.AddCommand(showCmd)
+}
+
+func runShow(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	paths, err := resolveInspectPaths(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	if len(paths) > 1 {
+		var names []string
+		for _, p := range paths {
+			rel, _ := filepath.Rel(cfg.RepoPath, p)
+			names = append(names, filepath.ToSlash(rel))
+		}
+		return fmt.Errorf("%q matches several items:\n  - %s\nPlease specify the full name.", args[0], strings.Join(names, "\n  - "))
+	}
+
+	file := paths[0]
+	if info, err := os.Stat(file); err == nil && info.IsDir() {
+		file = filepath.Join(file, "SKILL.md")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s has no SKILL.md to show", paths[0])
+		}
+		return err
+	}
+
+	width := 80
+	if cols, _, err := terminalSize(os.Stdout.Fd()); err == nil && cols > 0 {
+		width = min(cols, 100)
+	}
+	return pageOutput(renderShow(file, data, width, colorStdout), flagShowNoPager)
+}
+
+// renderShow renders the Markdown document file (with content data) for
+// 'axon show': a title line from its frontmatter, then the body.
+func renderShow(file string, data []byte, width int, color bool) string {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if name == "SKILL" {
+		name = filepath.Base(filepath.Dir(file))
+	}
+	// Without (valid) frontmatter, the whole document is the body.
+	body := data
+	var meta skillMeta
+	if doc, err := frontmatter.Parse(data); err == nil {
+		body = doc.Body()
+		_ = doc.Decode(&meta)
+	}
+	if meta.Name != "" {
+		name = meta.Name
+	}
+
+	var out strings.Builder
+	out.WriteString(paint(color, sgrBold, name) + "\n")
+	if desc := strings.Join(strings.Fields(meta.Description), " "); desc != "" {
+		out.WriteString(paint(color, sgrDim, desc) + "\n")
+	}
+	out.WriteString(paint(color, sgrDim, strings.Repeat("─", width)) + "\n\n")
+	out.WriteString(termmd.Render(body, termmd.Options{Width: width, Color: color}))
+	return out.String()
+}
+
+// pageOutput writes text to stdout, through $PAGER when stdout is a
+// terminal and text does not fit on one screen.
+func pageOutput(text string, noPager bool) error {
+	_, rows, err := terminalSize(os.Stdout.Fd())
+	if noPager || err != nil || !isTerminal(os.Stdout.Fd()) || strings.Count(text, "\n") < rows {
+		_, err := os.Stdout.WriteString(text)
+		return err
+	}
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less", "-R"}
+		if runtime.GOOS == "windows" {
+			pager = []string{"more"}
+		}
+	}
+	c := exec.Command(pager[0], pager[1:]...)
+	c.Stdin = strings.NewReader(text)
+	c.Stdout, c.Stderr = os.Stdout, os.Stderr
+	if os.Getenv("LESS") == "" {
+		// Quit at once on short output and keep the colors.
+		c.Env = append(os.Environ(), "LESS=FRX")
+	}
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil // the pager ran; quitting it early is not an error
+		}
+		_, err := os.Stdout.WriteString(text)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderShow(t *testing.T) {
+	data := []byte("---\nname: release-notes\ndescription: >\n  Draft release notes\n  from merged PRs.\n---\n# Steps\n\n- Run `git log`\n")
+	got := renderShow(filepath.Join("skills", "release", "SKILL.md"), data, 20, false)
+	want := "release-notes\nDraft release notes from merged PRs.\n" + strings.Repeat("─", 20) + "\n\nSteps\n═════\n\n  • Run `git log`\n"
+	if got != want {
+		t.Errorf("renderShow =\n%q\nwant\n%q", got, want)
+	}
+
+	// A file without frontmatter is named after the file.
+	got = renderShow(filepath.Join("rules", "pnpm.md"), []byte("Always run pnpm.\n"), 20, false)
+	if !strings.HasPrefix(got, "pnpm\n") || !strings.HasSuffix(got, "\nAlways run pnpm.\n") {
+		t.Errorf("renderShow without frontmatter = %q", got)
+	}
+}
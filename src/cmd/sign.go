@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/signing"
+	"github.com/spf13/cobra"
+)
+
+var signCmd = &cobra.Command{
+	Use:   "sign <skill>",
+	Short: "Write a detached signature for a skill",
+	Long: `Sign a skill directory so other machines can detect tampering.
+
+axon writes a manifest of every file's SHA-256 to <skill>/.axon-sig/manifest
+and a detached signature next to it, using ssh-keygen -Y sign or minisign
+depending on the key in AXON_SIGNING_KEY (environment or ~/.axon/.env).
+
+Skills whose SKILL.md frontmatter contains 'signed: true' are verified by
+'axon link', 'axon sync', and 'axon doctor'. Trusted keys are read from
+AXON_ALLOWED_SIGNERS (default ~/.axon/allowed_signers, ssh-keygen format) or
+AXON_MINISIGN_PUBKEY (default ~/.axon/minisign.pub).
+
+Examples:
+  AXON_SIGNING_KEY=~/.ssh/id_ed25519 axon sign git-release`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSign,
+}
+
+func init() {
+	rootCmd.AddCommand(signCmd)
+}
+
+func runSign(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	rel, err := resolveSkillPath(cfg.RepoPath, args[0])
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(cfg.RepoPath, rel)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a skill directory", rel)
+	}
+
+	sigCfg, err := signing.LoadConfig()
+	if err != nil {
+		return err
+	}
+	method, err := signing.Sign(dir, sigCfg)
+	if err != nil {
+		return err
+	}
+	printOK(rel, fmt.Sprintf("signed with %s → %s", method, filepath.Join(rel, signing.SigDir)))
+
+	if meta, _ := parseSkillMeta(filepath.Join(dir, "SKILL.md")); !meta.Signed {
+		printWarn(rel, "SKILL.md does not declare 'signed: true'; the signature will not be enforced.")
+		fmt.Println("   Add 'signed: true' to the frontmatter and run 'axon sign' again.")
+	}
+	return nil
+}
+
+// signatureStatus is the verification outcome for one skill marked signed.
+type signatureStatus struct {
+	Skill  string // Hub-relative path
+	Signer string // ssh principal, when known
+	Err    error
+}
+
+// verifySignedSkills verifies every skill directory under the configured
+// source roots whose SKILL.md declares 'signed: true'.
+func verifySignedSkills(cfg *config.Config) []signatureStatus {
+	var out []signatureStatus
+	var (
+		sigCfg    *signing.Config
+		sigCfgErr error
+		loaded    bool
+	)
+	for _, root := range cfg.EffectiveSearchRoots() {
+		entries, err := os.ReadDir(filepath.Join(cfg.RepoPath, root))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			dir := filepath.Join(cfg.RepoPath, root, e.Name())
+			meta, ok := parseSkillMeta(filepath.Join(dir, "SKILL.md"))
+			if !ok || !meta.Signed {
+				continue
+			}
+			if !loaded {
+				sigCfg, sigCfgErr = signing.LoadConfig()
+				loaded = true
+			}
+			st := signatureStatus{Skill: filepath.ToSlash(filepath.Join(root, e.Name()))}
+			if sigCfgErr != nil {
+				st.Err = sigCfgErr
+			} else {
+				st.Signer, st.Err = signing.Verify(dir, sigCfg)
+			}
+			out = append(out, st)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Skill < out[j].Skill })
+	return out
+}
+
+// warnSignatureFailures prints a warning for each signed skill that fails
+// verification. It never fails the calling command.
+func warnSignatureFailures(cfg *config.Config) {
+	var failed []signatureStatus
+	for _, st := range verifySignedSkills(cfg) {
+		if st.Err != nil {
+			failed = append(failed, st)
+		}
+	}
+	if len(failed) == 0 {
+		return
+	}
+	fmt.Println()
+	printWarn("", fmt.Sprintf("%d signed skill(s) failed signature verification:", len(failed)))
+	for _, st := range failed {
+		printWarn(st.Skill, signatureErrorMessage(st.Err))
+	}
+	fmt.Println("   Run 'axon doctor' for details.")
+}
+
+// signatureErrorMessage turns a verification error into a short user message.
+func signatureErrorMessage(err error) string {
+	var tampered *signing.TamperedError
+	switch {
+	case errors.As(err, &tampered):
+		return "possible tampering: " + tampered.Error()
+	case errors.Is(err, signing.ErrUnsigned):
+		return "marked 'signed: true' but has no signature"
+	default:
+		return err.Error()
+	}
+}
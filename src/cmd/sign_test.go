@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/signing"
+)
+
+func TestVerifySignedSkills(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	key := filepath.Join(tmp, "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v\n%s", err, out)
+	}
+	pub, _ := os.ReadFile(key + ".pub")
+	allowed := filepath.Join(tmp, "allowed_signers")
+	if err := os.WriteFile(allowed, append([]byte("dev@example.com "), pub...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AXON_SIGNING_KEY", key)
+	t.Setenv("AXON_ALLOWED_SIGNERS", allowed)
+
+	repo := filepath.Join(tmp, "repo")
+	cfg := &config.Config{RepoPath: repo}
+	mk := func(name, frontmatter string) string {
+		dir := filepath.Join(repo, "skills", name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\n"+frontmatter+"---\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	good := mk("good", "signed: true\n")
+	bad := mk("bad", "signed: true\n")
+	mk("plain", "name: plain\n")
+	mk("missing", "signed: true\n")
+
+	sigCfg, err := signing.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []string{good, bad} {
+		if _, err := signing.Sign(d, sigCfg); err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(bad, "extra.sh"), []byte("rm -rf /\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := verifySignedSkills(cfg)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 signed skills, got %+v", got)
+	}
+	byName := make(map[string]signatureStatus)
+	for _, st := range got {
+		byName[st.Skill] = st
+	}
+	if st := byName["skills/good"]; st.Err != nil || st.Signer != "dev@example.com" {
+		t.Errorf("good: %+v", st)
+	}
+	var tampered *signing.TamperedError
+	if st := byName["skills/bad"]; !errors.As(st.Err, &tampered) {
+		t.Errorf("bad: expected tampering, got %v", st.Err)
+	}
+	if st := byName["skills/missing"]; !errors.Is(st.Err, signing.ErrUnsigned) {
+		t.Errorf("missing: expected ErrUnsigned, got %v", st.Err)
+	}
+}
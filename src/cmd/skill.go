@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/frontmatter"
+	"github.com/kamusis/axon-cli/internal/textdiff"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var skillCmd = &cobra.Command{
+	Use:   "skill",
+	Short: "Work with a single skill in the Hub",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var skillDiffCmd = &cobra.Command{
+	Use:   "diff <name> [rev]",
+	Short: "Show what changed in one skill",
+	Long: `Show what changed in one skill, workflow, or command, rather than the
+whole Hub. Frontmatter changes are listed field by field, version and
+description first, apart from the diff of the SKILL.md body; other files
+follow with their own diffs.
+
+Without a revision, HEAD is compared with the working tree (including new,
+untracked files). A single revision is compared with the working tree;
+A..B compares two revisions.
+
+Examples:
+  axon skill diff humanizer
+  axon skill diff humanizer HEAD~3
+  axon skill diff humanizer v1.0..v1.1
+  axon skill diff humanizer --stat`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSkillDiff,
+}
+
+var flagSkillDiffStat bool
+
+func init() {
+	skillDiffCmd.Flags().BoolVar(&flagSkillDiffStat, "stat", false, "List changed files without their diffs")
+	skillCmd.AddCommand(skillDiffCmd)
+	rootCmd.AddCommand(skillCmd)
+}
+
+// skillDiff is the change to one Hub item between two revisions.
+type skillDiff struct {
+	Meta  []metaChange
+	Body  string     // unified diff of the SKILL.md body
+	Files []fileDiff // every changed file, SKILL.md included
+}
+
+// metaChange is one top-level frontmatter field that changed. Old is empty
+// for an added field and New for a removed one.
+type metaChange struct {
+	Key, Old, New string
+}
+
+// fileDiff is one changed file, relative to the item's directory.
+type fileDiff struct {
+	Status string // A, M, or D
+	Path   string
+	Patch  string
+}
+
+func runSkillDiff(_ *cobra.Command, args []string) error {
+	if err := checkGitAvailable(); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	rev := ""
+	if len(args) == 2 {
+		rev = args[1]
+	}
+	from, to := parseRevRange(rev)
+	for _, r := range []string{from, to} {
+		if r == "" {
+			continue
+		}
+		if _, err := gitOutput(cfg.RepoPath, "rev-parse", "--verify", "--quiet", r+"^{commit}"); err != nil {
+			return fmt.Errorf("unknown revision %q", r)
+		}
+	}
+	itemPath, err := diffItemPath(cfg.RepoPath, args[0], from)
+	if err != nil {
+		return err
+	}
+
+	d, err := collectSkillDiff(cfg.RepoPath, itemPath, from, to)
+	if err != nil {
+		return err
+	}
+	toLabel := to
+	if toLabel == "" {
+		toLabel = "working tree"
+	}
+	printSection(fmt.Sprintf("Skill Diff: %s (%s → %s)", itemPath, from, toLabel))
+	if len(d.Files) == 0 {
+		printOK("", "no changes")
+		return nil
+	}
+
+	if len(d.Meta) > 0 {
+		printBullet("Frontmatter:")
+		for _, m := range d.Meta {
+			switch {
+			case m.Old == "":
+				printListItem("+", fmt.Sprintf("[%s] %s", m.Key, m.New))
+			case m.New == "":
+				printListItem("-", fmt.Sprintf("[%s] %s", m.Key, m.Old))
+			default:
+				printInfo(m.Key, fmt.Sprintf("%s → %s", m.Old, m.New))
+			}
+		}
+	}
+	if d.Body != "" && !flagSkillDiffStat {
+		printBullet("Body (SKILL.md):")
+		printPatch(d.Body)
+	}
+
+	printBullet("Files:")
+	for _, f := range d.Files {
+		printListItem(f.Status, f.Path)
+	}
+	if !flagSkillDiffStat {
+		for _, f := range d.Files {
+			if f.Path == "SKILL.md" || f.Patch == "" {
+				continue
+			}
+			fmt.Println()
+			printPatch(f.Patch)
+		}
+	}
+	return nil
+}
+
+// parseRevRange splits the optional revision argument of 'axon skill diff'
+// into the two sides to compare. An empty to means the working tree.
+func parseRevRange(rev string) (from, to string) {
+	if rev == "" {
+		return "HEAD", ""
+	}
+	if a, b, ok := strings.Cut(rev, ".."); ok {
+		if a == "" {
+			a = "HEAD"
+		}
+		if b == "" {
+			b = "HEAD"
+		}
+		return a, b
+	}
+	return rev, ""
+}
+
+// diffItemPath resolves name like resolveSkillPath, falling back to the
+// item as it was at rev so a deleted skill can still be compared.
+func diffItemPath(repo, name, rev string) (string, error) {
+	p, err := resolveSkillPath(repo, name)
+	if err == nil {
+		return filepath.ToSlash(p), nil
+	}
+	for _, prefix := range config.DefaultContentRoots() {
+		candidate := prefix + "/" + name
+		if _, gerr := gitOutput(repo, "cat-file", "-e", rev+":"+candidate); gerr == nil {
+			return candidate, nil
+		}
+	}
+	return "", err
+}
+
+// collectSkillDiff compares the item at itemPath (slash-separated, relative
+// to repo) between from and to; an empty to is the working tree.
+func collectSkillDiff(repo, itemPath, from, to string) (*skillDiff, error) {
+	// Unquoted paths, so names outside ASCII come back as they are on disk.
+	args := []string{"-c", "core.quotePath=false", "diff", "--no-renames", "--name-status", from}
+	if to != "" {
+		args = append(args, to)
+	}
+	out, err := gitOutput(repo, append(args, "--", itemPath)...)
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %s", strings.TrimSpace(out))
+	}
+	var changed []fileDiff
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		status, p, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		changed = append(changed, fileDiff{Status: status[:1], Path: p})
+	}
+	if to == "" {
+		untracked, err := gitOutput(repo, "-c", "core.quotePath=false", "ls-files", "--others", "--exclude-standard", "--", itemPath)
+		if err != nil {
+			return nil, fmt.Errorf("git ls-files failed: %s", strings.TrimSpace(untracked))
+		}
+		for _, p := range strings.Split(strings.TrimSpace(untracked), "\n") {
+			if p == "" {
+				continue
+			}
+			changed = append(changed, fileDiff{Status: "A", Path: p})
+		}
+	}
+	slices.SortFunc(changed, func(a, b fileDiff) int { return strings.Compare(a.Path, b.Path) })
+
+	toLabel := to
+	if toLabel == "" {
+		toLabel = "working tree"
+	}
+	d := &skillDiff{}
+	for _, f := range changed {
+		oldData, _ := readAtRev(repo, from, f.Path)
+		newData, _ := readAtRev(repo, to, f.Path)
+		rel := strings.TrimPrefix(f.Path, itemPath+"/")
+		aName, bName := from+":"+f.Path, toLabel+":"+f.Path
+		if f.Status == "A" {
+			aName = "/dev/null"
+		}
+		if f.Status == "D" {
+			bName = "/dev/null"
+		}
+
+		if rel == "SKILL.md" {
+			meta, body, ok := diffSkillMD(oldData, newData, aName, bName)
+			if ok {
+				d.Meta, d.Body = meta, body
+			} else {
+				d.Body = textdiff.Unified(aName, bName, oldData, newData, 3)
+			}
+		}
+		patch := ""
+		switch {
+		case bytes.IndexByte(oldData, 0) >= 0 || bytes.IndexByte(newData, 0) >= 0:
+			patch = fmt.Sprintf("Binary file %s differs\n", f.Path)
+		case rel != "SKILL.md":
+			patch = textdiff.Unified(aName, bName, oldData, newData, 3)
+		}
+		d.Files = append(d.Files, fileDiff{Status: f.Status, Path: rel, Patch: patch})
+	}
+	return d, nil
+}
+
+// readAtRev returns the file at p (slash-separated, relative to repo) as of
+// rev, or in the working tree when rev is empty.
+func readAtRev(repo, rev, p string) ([]byte, bool) {
+	if rev == "" {
+		data, err := os.ReadFile(filepath.Join(repo, filepath.FromSlash(p)))
+		return data, err == nil
+	}
+	data, err := exec.Command("git", "-C", repo, "show", rev+":"+p).Output()
+	return data, err == nil
+}
+
+// diffSkillMD compares two SKILL.md files field by field and body by body.
+// A missing file counts as empty. ok is false when either frontmatter does
+// not parse.
+func diffSkillMD(oldData, newData []byte, aName, bName string) (meta []metaChange, body string, ok bool) {
+	oldDoc, err := parseOptionalSkillMD(oldData)
+	if err != nil {
+		return nil, "", false
+	}
+	newDoc, err := parseOptionalSkillMD(newData)
+	if err != nil {
+		return nil, "", false
+	}
+	return diffFrontmatter(oldDoc, newDoc), textdiff.Unified(aName, bName, oldDoc.Body(), newDoc.Body(), 3), true
+}
+
+func parseOptionalSkillMD(data []byte) (*frontmatter.Document, error) {
+	if len(data) == 0 {
+		data = []byte("---\n---\n")
+	}
+	return frontmatter.Parse(data)
+}
+
+// diffFrontmatter lists the top-level fields that differ between two
+// documents: version and description first, then the rest in document
+// order, removed fields last.
+func diffFrontmatter(oldDoc, newDoc *frontmatter.Document) []metaChange {
+	keys := []string{"version", "description"}
+	for _, k := range append(newDoc.Keys(), oldDoc.Keys()...) {
+		if !slices.Contains(keys, k) {
+			keys = append(keys, k)
+		}
+	}
+	var out []metaChange
+	for _, k := range keys {
+		o, n := metaValue(oldDoc, k), metaValue(newDoc, k)
+		if o != n {
+			out = append(out, metaChange{Key: k, Old: o, New: n})
+		}
+	}
+	return out
+}
+
+// metaValue renders a top-level field on one line, lists and mappings in
+// flow style; "" when the field is absent.
+func metaValue(doc *frontmatter.Document, key string) string {
+	n, ok := doc.Get(key)
+	if !ok {
+		return ""
+	}
+	if n.Kind == yaml.ScalarNode {
+		if n.Value == "" {
+			return `""`
+		}
+		return n.Value
+	}
+	flow := flowNode(n)
+	s, err := frontmatter.Format(flow)
+	if err != nil {
+		return n.Value
+	}
+	return s
+}
+
+// flowNode returns a copy of n with every collection in flow style.
+func flowNode(n *yaml.Node) *yaml.Node {
+	c := *n
+	c.HeadComment, c.LineComment, c.FootComment = "", "", ""
+	if c.Kind == yaml.SequenceNode || c.Kind == yaml.MappingNode {
+		c.Style |= yaml.FlowStyle
+	}
+	c.Content = make([]*yaml.Node, len(n.Content))
+	for i, child := range n.Content {
+		c.Content[i] = flowNode(child)
+	}
+	return &c
+}
+
+// printPatch prints a unified diff indented under the current section.
+func printPatch(patch string) {
+	for _, line := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		fmt.Println("  " + line)
+	}
+}
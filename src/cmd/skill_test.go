@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/frontmatter"
+)
+
+func TestParseRevRange(t *testing.T) {
+	cases := []struct{ rev, from, to string }{
+		{"", "HEAD", ""},
+		{"HEAD~2", "HEAD~2", ""},
+		{"v1..v2", "v1", "v2"},
+		{"v1..", "v1", "HEAD"},
+	}
+	for _, c := range cases {
+		if from, to := parseRevRange(c.rev); from != c.from || to != c.to {
+			t.Errorf("parseRevRange(%q) = %q, %q; want %q, %q", c.rev, from, to, c.from, c.to)
+		}
+	}
+}
+
+func TestDiffFrontmatter(t *testing.T) {
+	oldDoc, err := frontmatter.Parse([]byte("---\nname: pdf\nlicense: MIT\ndescription: Fill forms\nversion: 1.0.0\nkeywords: [pdf]\n---\nbody\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newDoc, err := frontmatter.Parse([]byte("---\nname: pdf\nversion: 1.1.0\ndescription: Fill and sign forms\nkeywords:\n  - pdf\n  - sign\nauto_invoke: true\n---\nbody\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := diffFrontmatter(oldDoc, newDoc)
+	want := []metaChange{
+		{Key: "version", Old: "1.0.0", New: "1.1.0"},
+		{Key: "description", Old: "Fill forms", New: "Fill and sign forms"},
+		{Key: "keywords", Old: "[pdf]", New: "[pdf, sign]"},
+		{Key: "auto_invoke", New: "true"},
+		{Key: "license", Old: "MIT"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffFrontmatter =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestCollectSkillDiff(t *testing.T) {
+	repo := t.TempDir()
+	skill := filepath.Join(repo, "skills", "pdf")
+	write := func(rel, content string) {
+		t.Helper()
+		p := filepath.Join(skill, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("SKILL.md", "---\nname: pdf\nversion: 1.0.0\n---\n# PDF\n\nFill forms.\n")
+	write("scripts/fill.py", "print('fill')\n")
+	write("old.txt", "gone\n")
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("hub\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"-C", repo, "init", "-q"},
+		{"-C", repo, "add", "."},
+		{"-C", repo, "-c", "user.email=test@axon.local", "-c", "user.name=Axon Test", "commit", "-q", "-m", "initial"},
+	} {
+		if err := gitRun(args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+
+	write("SKILL.md", "---\nname: pdf\nversion: 1.1.0\n---\n# PDF\n\nFill and sign forms.\n")
+	write("scripts/sign.py", "print('sign')\n")
+	if err := os.Remove(filepath.Join(skill, "old.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("changed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := collectSkillDiff(repo, "skills/pdf", "HEAD", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []metaChange{{Key: "version", Old: "1.0.0", New: "1.1.0"}}; !reflect.DeepEqual(d.Meta, want) {
+		t.Errorf("Meta = %+v, want %+v", d.Meta, want)
+	}
+	if !strings.Contains(d.Body, "-Fill forms.\n+Fill and sign forms.\n") || strings.Contains(d.Body, "version") {
+		t.Errorf("Body should hold only the body change, got\n%s", d.Body)
+	}
+	var files []string
+	for _, f := range d.Files {
+		files = append(files, f.Status+" "+f.Path)
+	}
+	if want := []string{"M SKILL.md", "D old.txt", "A scripts/sign.py"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("Files = %v, want %v", files, want)
+	}
+	if p := d.Files[2].Patch; !strings.Contains(p, "+++ working tree:skills/pdf/scripts/sign.py") || !strings.Contains(p, "+print('sign')") {
+		t.Errorf("patch for new file = %q", p)
+	}
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/importer"
+	"github.com/kamusis/axon-cli/internal/skillzip"
+	"github.com/spf13/cobra"
+)
+
+var importBundleCmd = &cobra.Command{
+	Use:   "import-bundle <file.zip|url>",
+	Short: "Import a Claude skill zip into the Hub",
+	Long: `Unpack a Claude skill zip (SKILL.md plus resources, at the archive root or
+in one top-level folder) into skills/<name> in the Hub. The zip may be a
+local file or an http(s) URL.
+
+Frontmatter is mapped to the Hub layout: fields Claude nests under
+metadata (version, requires, triggers, ...) move to the top level, where
+Axon reads them. Merging follows 'axon import': identical files are
+skipped, new files are copied, and differing files are kept side by side
+as <name>.conflict-bundle<ext> for manual review.
+
+Examples:
+  axon import-bundle ~/Downloads/pdf-tools.zip
+  axon import-bundle https://example.com/skills/pdf-tools.zip --name pdf`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportBundle,
+}
+
+var exportBundleCmd = &cobra.Command{
+	Use:   "export-bundle <skill>",
+	Short: "Export a Hub skill as a Claude skill zip",
+	Long: `Write the Hub skill <skill> as a Claude skill zip, ready to upload to
+Claude or share: every file under a <skill>/ folder, with SKILL.md's
+frontmatter mapped to Claude's layout. name, description, license, and
+allowed-tools stay at the top level; every other field moves under
+metadata, so 'axon import-bundle' restores it. The Hub is not changed.
+
+Examples:
+  axon export-bundle pdf-tools
+  axon export-bundle pdf-tools -o ~/Desktop/pdf-tools.zip`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExportBundle,
+}
+
+var (
+	flagImportBundleName   string
+	flagExportBundleOutput string
+)
+
+func init() {
+	importBundleCmd.Flags().StringVar(&flagImportBundleName, "name", "", "Skill name in the Hub (default: the zip's folder or file name)")
+	exportBundleCmd.Flags().StringVarP(&flagExportBundleOutput, "output", "o", "", "Output zip path (default: <skill>.zip)")
+	rootCmd.AddCommand(importBundleCmd, exportBundleCmd)
+}
+
+func runImportBundle(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return err
+	}
+	tmpBase := filepath.Join(axonDir, "tmp")
+	if err := ensureFreeSpace(cfg, tmpBase, "import"); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpBase, 0o755); err != nil {
+		return fmt.Errorf("cannot create temp dir: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(tmpBase, "axon-import-bundle-*")
+	if err != nil {
+		return fmt.Errorf("cannot create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	zipPath := args[0]
+	if isHTTPURL(zipPath) {
+		zipPath = filepath.Join(tmpDir, bundleURLName(args[0]))
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if err := downloadWithProgress(ctx, args[0], zipPath, nil, false); err != nil {
+			return err
+		}
+	}
+
+	skillDir, name, err := skillzip.Extract(zipPath, filepath.Join(tmpDir, "zip"))
+	if err != nil {
+		return fmt.Errorf("cannot read skill zip: %w", err)
+	}
+	if flagImportBundleName != "" {
+		name = flagImportBundleName
+	}
+	if err := checkSkillName(name); err != nil {
+		return err
+	}
+
+	// Stage the skill as <name>/ so the importer counts it as one skill.
+	stage := filepath.Join(tmpDir, "stage")
+	if err := os.MkdirAll(stage, 0o755); err != nil {
+		return err
+	}
+	staged := filepath.Join(stage, name)
+	if err := os.Rename(skillDir, staged); err != nil {
+		return err
+	}
+	skillMD := filepath.Join(staged, skillzip.SkillFile)
+	data, err := os.ReadFile(skillMD)
+	if err != nil {
+		return err
+	}
+	converted, err := skillzip.ToHub(data, name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", skillzip.SkillFile, err)
+	}
+	if err := os.WriteFile(skillMD, converted, 0o644); err != nil {
+		return err
+	}
+
+	printPortabilityHazards(importHazards(stage, "skills", longPathPrefix(cfg)))
+	res, err := importer.ImportDir(stage, filepath.Join(cfg.RepoPath, "skills"), "bundle", cfg.Excludes)
+	if err != nil {
+		return fmt.Errorf("import [%s]: %w", name, err)
+	}
+	recordImport("skills/"+name, args[0], res)
+
+	printSection("Import Skill Zip")
+	switch {
+	case res.SkillsConflicts > 0:
+		printWarn(name, fmt.Sprintf("merged into skills/%s with %d conflict(s)", name, len(res.Conflicts)))
+		fmt.Println("   Please review and resolve the following files manually:")
+		for _, c := range res.Conflicts {
+			fmt.Printf("     - %s  ← conflicts with %s\n", c.Conflict, c.Original)
+		}
+	case res.Imported == 0:
+		printSkip(name, fmt.Sprintf("skills/%s already has identical content", name))
+		return nil
+	default:
+		printOK(name, fmt.Sprintf("imported into skills/%s (%d file(s))", name, res.Imported+res.Skipped))
+	}
+	fmt.Println("\n  Run 'axon sync' to commit the imported skill.")
+	return nil
+}
+
+func runExportBundle(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	name := args[0]
+	node, ok := loadSkillGraph(cfg)[name]
+	if !ok {
+		return fmt.Errorf("skill %q not found in Hub.\nTip: run 'axon list' to see available items.", name)
+	}
+
+	out := flagExportBundleOutput
+	if out == "" {
+		out = name + ".zip"
+	}
+	// Write to a temp file beside the target so a failed export never leaves
+	// a truncated archive behind.
+	tmp, err := os.CreateTemp(filepath.Dir(out), ".axon-export-bundle-*")
+	if err != nil {
+		return fmt.Errorf("cannot create output file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	skip := func(rel string) bool {
+		base := filepath.Base(rel)
+		for _, pattern := range cfg.Excludes {
+			if m, _ := filepath.Match(pattern, base); m {
+				return true
+			}
+			if m, _ := filepath.Match(pattern, rel); m {
+				return true
+			}
+		}
+		return false
+	}
+	err = skillzip.Write(tmp, node.Dir, name, skip)
+	if cerr := tmp.Close(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+	if err := os.Rename(tmpName, out); err != nil {
+		return fmt.Errorf("cannot write %s: %w", out, err)
+	}
+
+	var size int64
+	if info, err := os.Stat(out); err == nil {
+		size = info.Size()
+	}
+	printOK(name, fmt.Sprintf("%s → %s (%s)", node.Dir, out, humanBytes(size)))
+	return nil
+}
+
+// isHTTPURL reports whether s is an http(s) URL rather than a local path.
+func isHTTPURL(s string) bool {
+	lower := strings.ToLower(s)
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://")
+}
+
+// bundleURLName returns the file name to save the zip at url under, so the
+// zip's base name can still name a skill whose files sit at the root.
+func bundleURLName(url string) string {
+	base := url
+	if i := strings.IndexAny(base, "?#"); i >= 0 {
+		base = base[:i]
+	}
+	base = base[strings.LastIndex(base, "/")+1:]
+	if base == "" || checkSkillName(strings.TrimSuffix(base, filepath.Ext(base))) != nil {
+		return "skill.zip"
+	}
+	return base
+}
+
+// checkSkillName rejects names that are not a single path segment.
+func checkSkillName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\:`) {
+		return fmt.Errorf("invalid skill name %q; pass --name", name)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestSkillZipRoundTrip(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	if err := os.MkdirAll(filepath.Join(tmp, ".axon"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	skill := filepath.Join(cfg.RepoPath, "skills", "pdf")
+	if err := os.MkdirAll(filepath.Join(skill, "scripts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skill, "SKILL.md"), []byte("---\nname: pdf\ndescription: Fill PDF forms\nversion: 1.0.0\n---\n# PDF\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skill, "scripts", "fill.py"), []byte("print()\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { flagImportBundleName, flagExportBundleOutput = "", "" })
+
+	zipPath := filepath.Join(tmp, "pdf.zip")
+	flagExportBundleOutput = zipPath
+	if err := runExportBundle(nil, []string{"pdf"}); err != nil {
+		t.Fatalf("export-bundle: %v", err)
+	}
+
+	flagImportBundleName = "pdf-copy"
+	if err := runImportBundle(importBundleCmd, []string{zipPath}); err != nil {
+		t.Fatalf("import-bundle: %v", err)
+	}
+	copyDir := filepath.Join(cfg.RepoPath, "skills", "pdf-copy")
+	got, err := os.ReadFile(filepath.Join(copyDir, "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(got); !strings.Contains(s, "name: pdf-copy\n") || !strings.Contains(s, "\nversion: 1.0.0\n") || strings.Contains(s, "metadata") {
+		t.Errorf("imported SKILL.md not in Hub layout:\n%s", s)
+	}
+	if _, err := os.Stat(filepath.Join(copyDir, "scripts", "fill.py")); err != nil {
+		t.Error(err)
+	}
+
+	// Re-importing the unchanged skill is a no-op; a Hub copy that changed
+	// since the export is kept, with the zip's version side by side.
+	flagImportBundleName = "pdf"
+	if err := runImportBundle(importBundleCmd, []string{zipPath}); err != nil {
+		t.Fatalf("import-bundle: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(skill, "SKILL.conflict-bundle.md")); !os.IsNotExist(err) {
+		t.Errorf("identical re-import should not leave a conflict file, stat err = %v", err)
+	}
+	edited := "---\nname: pdf\ndescription: Fill PDF forms\nversion: 1.1.0\n---\n# PDF\n"
+	if err := os.WriteFile(filepath.Join(skill, "SKILL.md"), []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runImportBundle(importBundleCmd, []string{zipPath}); err != nil {
+		t.Fatalf("import-bundle: %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(skill, "SKILL.md")); string(got) != edited {
+		t.Errorf("Hub SKILL.md was overwritten:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(skill, "SKILL.conflict-bundle.md")); err != nil {
+		t.Errorf("conflict file missing: %v", err)
+	}
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show how the Hub and its links evolve over time",
+	Long: `Summarise the Hub's history from two local sources: the operation journal
+(~/.axon/history.log) and the Hub's git log. Nothing is sent anywhere.
+
+  Most-edited    skills and other items touched by the most commits
+  Syncs          how often 'axon sync' ran, and when it last did
+  Link churn     link, unlink, and rollback operations per target
+  By month       commits, skills added and removed, and conflict files
+                 committed, with the current number of skills
+  Unused         skills no tool has read for 90 days, when usage_tracking
+                 is on in axon.yaml
+
+--since accepts a duration (90m, 24h, 7d) or a date (2006-01-02 or RFC 3339).
+
+Examples:
+  axon stats
+  axon stats --since 90d --top 5`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+var (
+	flagStatsSince string
+	flagStatsTop   int
+)
+
+func init() {
+	statsCmd.Flags().StringVar(&flagStatsSince, "since", "", "Only count activity newer than a duration (24h, 7d) or date (2006-01-02)")
+	statsCmd.Flags().IntVar(&flagStatsTop, "top", 10, "Number of items to list in the most-edited and link churn rankings")
+	rootCmd.AddCommand(statsCmd)
+}
+
+// hubCommit is one commit of the Hub's git log with the files it touched.
+type hubCommit struct {
+	Time  time.Time
+	Files []hubFileChange
+}
+
+// hubFileChange is one --name-status line: A, M, D, ... and the path.
+type hubFileChange struct {
+	Status string
+	Path   string
+}
+
+// monthStats aggregates one calendar month of Hub history.
+type monthStats struct {
+	Month         string // 2006-01
+	Commits       int
+	SkillsAdded   int
+	SkillsRemoved int
+	Conflicts     int
+}
+
+// rankedCount is a name with a count, for rankings.
+type rankedCount struct {
+	Name  string
+	Count int
+}
+
+func runStats(_ *cobra.Command, _ []string) error {
+	if flagStatsTop < 1 {
+		return fmt.Errorf("--top must be at least 1")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	var since time.Time
+	if flagStatsSince != "" {
+		if since, err = parseSince(flagStatsSince, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	journalPath, err := journal.Path()
+	if err != nil {
+		return err
+	}
+	entries, err := journal.Read(journalPath, since)
+	if err != nil {
+		return fmt.Errorf("cannot read history: %w", err)
+	}
+	var commits []hubCommit
+	if err := checkGitAvailable(); err == nil {
+		commits, err = hubCommits(cfg.RepoPath, since)
+		if err != nil {
+			printWarn("", fmt.Sprintf("cannot read the Hub's git log: %v", err))
+		}
+	}
+
+	roots := cfg.EffectiveSearchRoots()
+
+	printSection("Most-edited")
+	edits := rankEdits(commits, roots)
+	if len(edits) == 0 {
+		printSkip("", "no commits touch Hub items")
+	}
+	for _, r := range topRanked(edits, flagStatsTop) {
+		printListItem(iconItem, fmt.Sprintf("%-40s %d commit(s)", r.Name, r.Count))
+	}
+
+	printSection("Syncs")
+	printSyncStats(entries, since, time.Now())
+
+	printSection("Link churn")
+	churn := linkChurn(entries)
+	if len(churn) == 0 {
+		printSkip("", "no link or unlink operations recorded")
+	}
+	for _, r := range topRanked(churn, flagStatsTop) {
+		printListItem(iconItem, fmt.Sprintf("%-40s %d operation(s)", r.Name, r.Count))
+	}
+
+	printSection("By month")
+	months := monthlyStats(commits, roots)
+	if len(months) == 0 {
+		printSkip("", "no commits in this period")
+	} else {
+		fmt.Printf("  %-8s  %7s  %12s  %9s\n", "Month", "Commits", "Skills +/-", "Conflicts")
+		for _, m := range months {
+			fmt.Printf("  %-8s  %7d  %12s  %9d\n", m.Month, m.Commits, fmt.Sprintf("+%d / -%d", m.SkillsAdded, m.SkillsRemoved), m.Conflicts)
+		}
+	}
+	fmt.Printf("\n  %d skill(s) in the Hub today.\n", len(loadSkillGraph(cfg)))
+
+	printSection("Unused")
+	printUnusedSkills(cfg, defaultUnusedAge)
+	return nil
+}
+
+// hubCommits reads the Hub's git log, newest first, with the files each
+// commit touched. Merge commits list no files, so edits are not counted
+// twice.
+func hubCommits(repo string, since time.Time) ([]hubCommit, error) {
+	args := []string{"log", "--no-renames", "--name-status", "--format=@%cI"}
+	if !since.IsZero() {
+		args = append(args, "--since="+since.Format(time.RFC3339))
+	}
+	out, err := gitOutput(repo, args...)
+	if err != nil {
+		if strings.Contains(out, "does not have any commits") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s", strings.TrimSpace(out))
+	}
+	return parseHubLog(out), nil
+}
+
+// parseHubLog parses 'git log --name-status --format=@%cI' output.
+func parseHubLog(out string) []hubCommit {
+	var commits []hubCommit
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "@"):
+			t, err := time.Parse(time.RFC3339, line[1:])
+			if err != nil {
+				continue
+			}
+			commits = append(commits, hubCommit{Time: t})
+		case len(commits) > 0:
+			status, p, ok := strings.Cut(line, "\t")
+			if !ok {
+				continue
+			}
+			c := &commits[len(commits)-1]
+			c.Files = append(c.Files, hubFileChange{Status: status[:1], Path: p})
+		}
+	}
+	return commits
+}
+
+// hubItem maps a Hub path to the item it belongs to: <root>/<name> for a
+// path under one of roots, e.g. skills/pdf for skills/pdf/SKILL.md.
+func hubItem(p string, roots []string) (string, bool) {
+	for _, root := range roots {
+		rest, ok := strings.CutPrefix(p, strings.TrimSuffix(root, "/")+"/")
+		if !ok || rest == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(rest, "/")
+		return path.Join(root, name), true
+	}
+	return "", false
+}
+
+// rankEdits counts, per item, the commits that touched it.
+func rankEdits(commits []hubCommit, roots []string) []rankedCount {
+	counts := make(map[string]int)
+	for _, c := range commits {
+		seen := make(map[string]bool)
+		for _, f := range c.Files {
+			if item, ok := hubItem(f.Path, roots); ok && !seen[item] {
+				seen[item] = true
+				counts[item]++
+			}
+		}
+	}
+	return sortedCounts(counts)
+}
+
+// linkChurn counts link, unlink, and link rollback operations per target.
+func linkChurn(entries []journal.Entry) []rankedCount {
+	counts := make(map[string]int)
+	for _, e := range entries {
+		switch e.Op {
+		case journal.OpLink, journal.OpUnlink, journal.OpLinkRollback:
+			if e.Target != "" {
+				counts[e.Target]++
+			}
+		}
+	}
+	return sortedCounts(counts)
+}
+
+// monthlyStats buckets commits by month, oldest first. A skill is added or
+// removed when its SKILL.md is; conflicts are committed files whose name
+// marks a conflict copy (<name>.conflict-<source>.<ext>).
+func monthlyStats(commits []hubCommit, roots []string) []monthStats {
+	byMonth := make(map[string]*monthStats)
+	for _, c := range commits {
+		key := c.Time.Local().Format("2006-01")
+		m := byMonth[key]
+		if m == nil {
+			m = &monthStats{Month: key}
+			byMonth[key] = m
+		}
+		m.Commits++
+		for _, f := range c.Files {
+			base := path.Base(f.Path)
+			if _, isItem := hubItem(f.Path, roots); isItem && base == "SKILL.md" {
+				switch f.Status {
+				case "A":
+					m.SkillsAdded++
+				case "D":
+					m.SkillsRemoved++
+				}
+			}
+			if f.Status == "A" && strings.Contains(base, ".conflict-") {
+				m.Conflicts++
+			}
+		}
+	}
+	out := make([]monthStats, 0, len(byMonth))
+	for _, m := range byMonth {
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Month < out[j].Month })
+	return out
+}
+
+// printSyncStats prints how many syncs the journal holds, their rate, and
+// the most recent one.
+func printSyncStats(entries []journal.Entry, since, now time.Time) {
+	var syncs []journal.Entry
+	for _, e := range entries {
+		if e.Op == journal.OpSync {
+			syncs = append(syncs, e)
+		}
+	}
+	if len(syncs) == 0 {
+		printSkip("", "no syncs recorded")
+		return
+	}
+	from := since
+	if from.IsZero() {
+		from = syncs[0].Time
+	}
+	weeks := now.Sub(from).Hours() / (24 * 7)
+	if weeks < 1 {
+		weeks = 1
+	}
+	last := syncs[len(syncs)-1]
+	printListItem(iconItem, fmt.Sprintf("%d sync(s), %.1f per week", len(syncs), float64(len(syncs))/weeks))
+	printListItem(iconItem, fmt.Sprintf("last sync %s (%s ago)", last.Time.Local().Format("2006-01-02 15:04"), humanDuration(now.Sub(last.Time))))
+}
+
+// humanDuration renders d coarsely: minutes, hours, or days.
+func humanDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// sortedCounts orders counts by count, then name.
+func sortedCounts(counts map[string]int) []rankedCount {
+	out := make([]rankedCount, 0, len(counts))
+	for name, n := range counts {
+		out = append(out, rankedCount{Name: name, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// topRanked returns at most n entries of ranked.
+func topRanked(ranked []rankedCount, n int) []rankedCount {
+	if len(ranked) > n {
+		return ranked[:n]
+	}
+	return ranked
+}
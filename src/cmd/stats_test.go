@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/journal"
+)
+
+const statsLog = `@2026-03-02T10:00:00+00:00
+
+M	skills/pdf/SKILL.md
+M	skills/pdf/scripts/fill.py
+A	skills/pdf/SKILL.conflict-laptop.md
+@2026-02-10T10:00:00+00:00
+
+A	skills/pdf/SKILL.md
+A	skills/docx/SKILL.md
+M	README.md
+@2026-02-01T10:00:00+00:00
+
+D	skills/old/SKILL.md
+M	skills/docx/SKILL.md
+`
+
+func TestParseHubLog(t *testing.T) {
+	commits := parseHubLog(statsLog)
+	if len(commits) != 3 {
+		t.Fatalf("got %d commits, want 3", len(commits))
+	}
+	if len(commits[0].Files) != 3 || commits[0].Files[2] != (hubFileChange{Status: "A", Path: "skills/pdf/SKILL.conflict-laptop.md"}) {
+		t.Errorf("first commit files = %+v", commits[0].Files)
+	}
+	if !commits[2].Time.Equal(time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("last commit time = %v", commits[2].Time)
+	}
+}
+
+func TestRankEdits(t *testing.T) {
+	got := rankEdits(parseHubLog(statsLog), []string{"skills"})
+	want := []rankedCount{{"skills/docx", 2}, {"skills/pdf", 2}, {"skills/old", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rankEdits = %+v, want %+v", got, want)
+	}
+}
+
+func TestMonthlyStats(t *testing.T) {
+	loc := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = loc })
+	got := monthlyStats(parseHubLog(statsLog), []string{"skills"})
+	want := []monthStats{
+		{Month: "2026-02", Commits: 2, SkillsAdded: 2, SkillsRemoved: 1},
+		{Month: "2026-03", Commits: 1, Conflicts: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("monthlyStats = %+v, want %+v", got, want)
+	}
+}
+
+func TestLinkChurn(t *testing.T) {
+	entries := []journal.Entry{
+		{Op: journal.OpLink, Target: "cursor"},
+		{Op: journal.OpUnlink, Target: "cursor"},
+		{Op: journal.OpLinkRollback, Target: "claude"},
+		{Op: journal.OpSync},
+	}
+	got := linkChurn(entries)
+	want := []rankedCount{{"cursor", 2}, {"claude", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("linkChurn = %+v, want %+v", got, want)
+	}
+	if top := topRanked(got, 1); len(top) != 1 || top[0].Name != "cursor" {
+		t.Errorf("topRanked = %+v", top)
+	}
+}
@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -16,12 +18,26 @@ import (
 var statusCmd = &cobra.Command{
 	Use:   "status [skill-name]",
 	Short: "Validate symlinks and show Hub Git status",
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  runStatus,
+	Long: `Show the health of every target's link and the git status of each Hub
+repo. With a skill name, show that skill's path, link state, and history.
+
+--git-log adds the last N Hub commits (10 without a number) with the skills
+each one touched, so you can see what changed recently across machines.
+
+Examples:
+  axon status
+  axon status --fetch --git-log
+  axon status --git-log=30
+  axon status humanizer`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStatus,
 }
 
 func init() {
 	statusCmd.Flags().Bool("fetch", false, "Fetch remote updates for the Hub repo before showing status")
+	statusCmd.Flags().Bool("health", false, "Print a one-line machine-readable summary; exit non-zero if any link is broken or missing")
+	statusCmd.Flags().Int("git-log", 0, "Also show the last N Hub commits and the skills each touched")
+	statusCmd.Flags().Lookup("git-log").NoOptDefVal = "10"
 	rootCmd.AddCommand(statusCmd)
 }
 
@@ -31,121 +47,137 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
 	}
 
+	fetchFirst, _ := cmd.Flags().GetBool("fetch")
+	gitLog, _ := cmd.Flags().GetInt("git-log")
+	if gitLog < 0 {
+		return fmt.Errorf("--git-log must be >= 0")
+	}
+
 	// Skill-level mode: axon status <skill-name>
 	if len(args) == 1 {
 		if err := checkGitAvailable(); err != nil {
 			return err
 		}
-		fetchFirst, _ := cmd.Flags().GetBool("fetch")
 		return showSkillStatus(cfg, args[0], fetchFirst)
 	}
-	// Sort targets alphabetically by name.
-	targets := make([]config.Target, len(cfg.Targets))
-	copy(targets, cfg.Targets)
-	sort.Slice(targets, func(i, j int) bool {
-		return targets[i].Name < targets[j].Name
-	})
-
-	printSection("Symlink Health")
-
-	type brokenEntry struct{ name, msg string }
-	var linked, needLink, realDir []string
-	var broken []brokenEntry
-	notInstalledMap := make(map[string]bool)
-	var notInstalled []string
-	var notInstalledCount int
-
-	for _, t := range targets {
-		dest, err := config.ExpandPath(t.Destination)
-		if err != nil {
-			broken = append(broken, brokenEntry{t.Name, fmt.Sprintf("cannot expand path: %v", err)})
-			continue
-		}
-
-		// Check parent dir first — if missing, the tool is not installed at all.
-		parent := filepath.Dir(dest)
-		if _, parentErr := os.Stat(parent); os.IsNotExist(parentErr) {
-			notInstalledCount++
-			baseName := t.Name
-			if idx := strings.LastIndex(t.Name, "-"); idx != -1 {
-				baseName = t.Name[:idx]
-			}
-			if !notInstalledMap[baseName] {
-				notInstalledMap[baseName] = true
-				notInstalled = append(notInstalled, baseName)
-			}
-			continue
-		}
 
-		expected := filepath.Join(cfg.RepoPath, t.Source)
-		info, err := os.Lstat(dest)
-
-		switch {
-		case os.IsNotExist(err):
-			needLink = append(needLink, t.Name)
-
-		case err != nil:
-			broken = append(broken, brokenEntry{t.Name, fmt.Sprintf("stat error: %v", err)})
+	// Machine-oriented mode for cron/monit checks.
+	if health, _ := cmd.Flags().GetBool("health"); health {
+		return runStatusHealth(cfg, fetchFirst)
+	}
 
-		case info.Mode()&os.ModeSymlink == 0:
-			realDir = append(realDir, t.Name)
+	printSection("Symlink Health")
 
-		default:
-			target, err := os.Readlink(dest)
-			if err != nil {
-				broken = append(broken, brokenEntry{t.Name, fmt.Sprintf("cannot read symlink: %v", err)})
-			} else if target != expected {
-				broken = append(broken, brokenEntry{t.Name, fmt.Sprintf("wrong target:\n      got:  %s\n      want: %s", target, expected)})
-			} else {
-				linked = append(linked, t.Name)
-			}
-		}
-	}
+	h := collectLinkHealth(cfg)
 
 	// Print grouped output.
-	if len(linked) > 0 {
+	if len(h.linked) > 0 {
 		printBullet("Linked (healthy symlinks):")
-		for _, s := range linked {
+		for _, s := range h.linked {
 			printOK(s, "OK")
 		}
 	}
-	if len(realDir) > 0 {
+	if len(h.realDir) > 0 {
 		printBullet("Real directories (not yet converted to symlinks):")
-		for _, s := range realDir {
+		for _, s := range h.realDir {
 			printWarn(s, fmt.Sprintf("real directory — run 'axon link %s' to convert (original will be backed up)", s))
 		}
 	}
-	if len(needLink) > 0 {
+	if len(h.needLink) > 0 {
 		printBullet("Installed but not linked:")
-		for _, s := range needLink {
+		for _, s := range h.needLink {
 			printMiss(s, "not linked (run: axon link "+s+")")
 		}
 	}
-	if len(broken) > 0 {
+	if len(h.broken) > 0 {
 		printBullet("Errors:")
-		for _, e := range broken {
+		for _, e := range h.broken {
 			printErr(e.name, e.msg)
 		}
+		for _, root := range h.missingHubs {
+			printInfo("", fmt.Sprintf("run 'axon relink --from %s' to point them at %s", root, cfg.RepoPath))
+		}
 	}
-	if len(notInstalled) > 0 {
+	if len(h.notInstalled) > 0 {
 		printBullet("Not installed (skipped):")
-		sort.Strings(notInstalled)
-		for _, s := range notInstalled {
+		sort.Strings(h.notInstalled)
+		for _, s := range h.notInstalled {
 			printSkip("", s)
 		}
 	}
 
-	total := len(targets)
 	fmt.Printf("\n  %d linked / %d real dir / %d not linked / %d not installed (tools) / %d error  (total: %d targets)\n",
-		len(linked), len(realDir), len(needLink), len(notInstalled), len(broken), total)
+		len(h.linked), len(h.realDir), len(h.needLink), len(h.notInstalled), len(h.broken), len(cfg.Targets))
+
+	if len(h.drift) > 0 {
+		printBullet("Merged views and copies out of date:")
+		for _, e := range h.drift {
+			printWarn(e.name, e.msg)
+		}
+	}
+	if len(h.shadowed) > 0 {
+		printBullet("Shadowed by a higher-priority repo:")
+		for _, s := range h.shadowed {
+			printInfo(s.Loser, fmt.Sprintf("%s (using %s)", s.Name, s.Winner))
+		}
+	}
+	if len(h.deepChains) > 0 {
+		printBullet("Long symlink chains:")
+		for _, e := range h.deepChains {
+			printWarn(e.name, e.msg)
+		}
+	}
+	if len(h.limited) > 0 {
+		printBullet("Limited to other tools (not linked):")
+		for _, e := range h.limited {
+			printSkip(e.name, e.msg)
+		}
+	}
+	printHubSymlinkProblems(cfg)
+	printHubWrites(cfg)
 
-	printSection("Hub Git Status")
+	var repos []config.Repo
+	for _, r := range cfg.HubRepos() {
+		if r.Project == "" {
+			repos = append(repos, r)
+		}
+	}
 	if err := checkGitAvailable(); err != nil {
+		printSection("Hub Git Status")
 		printWarn("", "git not available — skipping Hub Git status.")
 		return nil
 	}
+	if cfg.Machine != "" {
+		printInfo("machine", machineOverrideSummary(cfg))
+	}
+	if cfg.ProjectRoot != "" {
+		printInfo("project", fmt.Sprintf("%s (%s) — project items are versioned with the project", cfg.ProjectRoot, config.ProjectFileName))
+	}
+	for _, r := range repos {
+		if len(repos) == 1 {
+			printSection("Hub Git Status")
+		} else {
+			printSection(fmt.Sprintf("Hub Git Status [%s] %s", r.Name, r.Path))
+			if _, err := os.Stat(filepath.Join(r.Path, ".git")); err != nil {
+				printMiss("", "not cloned yet (run 'axon sync')")
+				continue
+			}
+		}
+		if err := showHubGitStatus(repoConfig(cfg, r), fetchFirst); err != nil {
+			return err
+		}
+		if gitLog > 0 {
+			if err := printHubActivity(r.Path, gitLog, cfg.EffectiveSearchRoots()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
 
-	fetchFirst, _ := cmd.Flags().GetBool("fetch")
+// showHubGitStatus prints the remote comparison and 'git status' for the Hub
+// at cfg.RepoPath.
+func showHubGitStatus(cfg *config.Config, fetchFirst bool) error {
 	if fetchFirst {
 		// Require a configured origin remote for fetch-based checks.
 		if _, originErr := exec.Command("git", "-C", cfg.RepoPath, "remote", "get-url", "origin").Output(); originErr != nil {
@@ -159,39 +191,27 @@ func runStatus(cmd *cobra.Command, args []string) error {
 			if trimmed == "" {
 				return fmt.Errorf("git fetch failed: %w", fetchErr)
 			}
-			return fmt.Errorf("git fetch failed:\n%s", trimmed)
+			return fmt.Errorf("git fetch failed:\n%s%s", trimmed, gitRemoteHint(trimmed, originURL(cfg.RepoPath)))
 		}
 		printOK("", "Fetch complete.")
 	}
 
 	// Remote update summary (origin-based only).
-	// We intentionally do not rely on Git's upstream tracking configuration (@{u}).
-	originHead, originHeadErr := exec.Command("git", "-C", cfg.RepoPath, "rev-parse", "--abbrev-ref", "origin/HEAD").Output()
-	if originHeadErr != nil {
+	compareRef, ahead, behind, refErr := hubAheadBehind(cfg.RepoPath)
+	if refErr != nil {
 		if fetchFirst {
 			printWarn("", "Remote default branch not available (origin/HEAD). Re-run 'axon remote set <url>' to initialize the remote default branch reference.")
 		}
-	} else {
-		compareRef := strings.TrimSpace(string(originHead))
-		countsRaw, countsErr := exec.Command("git", "-C", cfg.RepoPath, "rev-list", "--left-right", "--count", "HEAD..."+compareRef).Output()
-		if countsErr == nil {
-			fields := strings.Fields(strings.TrimSpace(string(countsRaw)))
-			if len(fields) >= 2 {
-				ahead, aErr := strconv.Atoi(fields[0])
-				behind, bErr := strconv.Atoi(fields[1])
-				if aErr == nil && bErr == nil {
-					printOK("", fmt.Sprintf("Remote: %s (ahead %d / behind %d)", compareRef, ahead, behind))
-					if behind > 0 {
-						printInfo("", fmt.Sprintf("Remote is newer by %d commit(s). Run 'axon sync' to pull updates.", behind))
-					}
-					if ahead > 0 {
-						if cfg.SyncMode == "read-only" {
-							printWarn("", fmt.Sprintf("Local is newer by %d commit(s), but sync_mode is read-only so changes will not be pushed.", ahead))
-						} else {
-							printInfo("", fmt.Sprintf("Local is newer by %d commit(s). Run 'axon sync' to publish your changes.", ahead))
-						}
-					}
-				}
+	} else if compareRef != "" {
+		printOK("", fmt.Sprintf("Remote: %s (ahead %d / behind %d)", compareRef, ahead, behind))
+		if behind > 0 {
+			printInfo("", fmt.Sprintf("Remote is newer by %d commit(s). Run 'axon sync' to pull updates.", behind))
+		}
+		if ahead > 0 {
+			if cfg.SyncMode == "read-only" {
+				printWarn("", fmt.Sprintf("Local is newer by %d commit(s), but sync_mode is read-only so changes will not be pushed.", ahead))
+			} else {
+				printInfo("", fmt.Sprintf("Local is newer by %d commit(s). Run 'axon sync' to publish your changes.", ahead))
 			}
 		}
 	}
@@ -207,6 +227,249 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// brokenEntry is a target whose symlink state is an error.
+type brokenEntry struct{ name, msg string }
+
+// linkHealth groups targets by symlink state.
+type linkHealth struct {
+	linked, realDir, needLink []string
+	broken                    []brokenEntry
+	notInstalled              []string // tool base names, deduplicated
+
+	// missingHubs are old Hub roots that broken links still point into.
+	missingHubs []string
+
+	// Multi-repo targets only: linked targets whose merged view no longer
+	// matches the repos, and items hidden by a higher-priority repo.
+	drift    []brokenEntry
+	shadowed []shadowedItem
+
+	// limited lists, per linked target, the skills left out because they
+	// are limited to other tools.
+	limited []brokenEntry
+
+	// deepChains are working links that resolve through too many symlinks.
+	deepChains []brokenEntry
+}
+
+// machineOverrideSummary describes the machine override Load applied.
+func machineOverrideSummary(cfg *config.Config) string {
+	o := cfg.Overrides[cfg.Machine]
+	msg := fmt.Sprintf("overrides for %q applied (%d target(s) adjusted", cfg.Machine, len(o.Targets))
+	if disabled := cfg.DisabledTargets(); len(disabled) > 0 {
+		msg += ", disabled: " + strings.Join(disabled, ", ")
+	}
+	if n := len(o.ExcludesAdd) + len(o.ExcludesRemove); n > 0 {
+		msg += fmt.Sprintf(", %d exclude change(s)", n)
+	}
+	return msg + ")"
+}
+
+// collectLinkHealth classifies every target in cfg, sorted by name.
+func collectLinkHealth(cfg *config.Config) linkHealth {
+	// Sort targets alphabetically by name.
+	targets := make([]config.Target, len(cfg.Targets))
+	copy(targets, cfg.Targets)
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+
+	var h linkHealth
+	notInstalledMap := make(map[string]bool)
+	seenViews := make(map[string]bool)
+
+	for _, t := range targets {
+		dest, err := config.ExpandPath(t.Destination)
+		if err != nil && !errors.Is(err, config.ErrNoWindowsHome) {
+			h.broken = append(h.broken, brokenEntry{t.Name, fmt.Sprintf("cannot expand path: %v", err)})
+			continue
+		}
+
+		// Check parent dir first — if missing, the tool is not installed at all.
+		// {windows_home} targets on a machine without Windows count as missing.
+		parent := filepath.Dir(dest)
+		if _, parentErr := os.Stat(parent); err != nil || os.IsNotExist(parentErr) {
+			baseName := t.Name
+			if idx := strings.LastIndex(t.Name, "-"); idx != -1 {
+				baseName = t.Name[:idx]
+			}
+			if !notInstalledMap[baseName] {
+				notInstalledMap[baseName] = true
+				h.notInstalled = append(h.notInstalled, baseName)
+			}
+			continue
+		}
+
+		if real, inHub := destParentInHub(cfg, dest); inHub {
+			h.broken = append(h.broken, brokenEntry{t.Name, destInHubRefusal(dest, real)})
+			continue
+		}
+		expected, repos, err := targetLinkSource(cfg, t)
+		if err != nil {
+			h.broken = append(h.broken, brokenEntry{t.Name, err.Error()})
+			continue
+		}
+		info, err := os.Lstat(dest)
+
+		switch {
+		case os.IsNotExist(err):
+			h.needLink = append(h.needLink, t.Name)
+
+		case err != nil:
+			h.broken = append(h.broken, brokenEntry{t.Name, fmt.Sprintf("stat error: %v", err)})
+
+		case info.Mode()&os.ModeSymlink == 0:
+			if marked, ok := copyMarkerSource(dest); ok {
+				h.checkCopy(cfg, t, dest, marked, expected)
+				continue
+			}
+			h.realDir = append(h.realDir, t.Name)
+
+		default:
+			target, err := os.Readlink(dest)
+			if err != nil {
+				h.broken = append(h.broken, brokenEntry{t.Name, fmt.Sprintf("cannot read symlink: %v", err)})
+			} else if problem, broken := linkChainProblem(dest); broken {
+				h.broken = append(h.broken, brokenEntry{t.Name, problem})
+			} else if root, ok := missingHubRoot(cfg, t, dest, target); ok {
+				h.broken = append(h.broken, brokenEntry{t.Name, fmt.Sprintf("points into a missing Hub (moved or deleted?):\n      got:  %s\n      want: %s", target, expected)})
+				if !slices.Contains(h.missingHubs, root) {
+					h.missingHubs = append(h.missingHubs, root)
+				}
+			} else if !linkResolvesTo(dest, target, expected) {
+				h.broken = append(h.broken, brokenEntry{t.Name, fmt.Sprintf("wrong target:\n      got:  %s\n      want: %s", target, expected)})
+			} else {
+				h.linked = append(h.linked, t.Name)
+				if problem, _ := linkChainProblem(dest); problem != "" {
+					h.deepChains = append(h.deepChains, brokenEntry{t.Name, problem})
+				}
+				if usesMergedView(t, repos) {
+					h.checkMergedView(t, expected, repos, cfg.TargetLinkStyle(t), seenViews)
+				}
+			}
+		}
+	}
+	return h
+}
+
+// checkCopy classifies a destination provisioned by copy mode: linked when it
+// mirrors the expected source, with drift when the Hub changed since.
+func (h *linkHealth) checkCopy(cfg *config.Config, t config.Target, dest, marked, expected string) {
+	if marked != expected {
+		h.broken = append(h.broken, brokenEntry{t.Name, fmt.Sprintf("copy of the wrong source:\n      got:  %s\n      want: %s", marked, expected)})
+		return
+	}
+	h.linked = append(h.linked, t.Name)
+	n, err := copyDrift(cfg, t, dest, expected)
+	switch {
+	case err != nil:
+		h.drift = append(h.drift, brokenEntry{t.Name, fmt.Sprintf("cannot compare copy: %v", err)})
+	case n > 0:
+		h.drift = append(h.drift, brokenEntry{t.Name, fmt.Sprintf("copy out of date (%d change(s)) — run 'axon link %s'", n, t.Name)})
+	}
+}
+
+// checkMergedView records drift, shadowed items, and skills limited to
+// other tools for a target linked to a merged view. Views shared by several targets are only inspected once.
+func (h *linkHealth) checkMergedView(t config.Target, view string, repos []config.Repo, style string, seen map[string]bool) {
+	if seen[view] {
+		return
+	}
+	seen[view] = true
+	plan, err := planMergedView(t.Source, repos, style, t.Tool())
+	if err != nil {
+		h.drift = append(h.drift, brokenEntry{t.Name, err.Error()})
+		return
+	}
+	for _, s := range plan.Shadowed {
+		s.Name = t.Source + "/" + s.Name
+		h.shadowed = append(h.shadowed, s)
+	}
+	if len(plan.Filtered) > 0 {
+		h.limited = append(h.limited, brokenEntry{t.Name, strings.Join(plan.Filtered, ", ")})
+	}
+	changed, stale, stray := mergedViewDrift(view, plan)
+	if len(changed)+len(stale) > 0 {
+		h.drift = append(h.drift, brokenEntry{t.Name, fmt.Sprintf("%d item(s) changed in the repos — run 'axon link %s'", len(changed)+len(stale), t.Name)})
+	}
+	for _, name := range stray {
+		h.drift = append(h.drift, brokenEntry{t.Name, fmt.Sprintf("%s was written into the merged view; move it into a Hub repo", filepath.Join(view, name))})
+	}
+}
+
+// hubAheadBehind compares HEAD with origin/HEAD. An empty compareRef with a
+// nil error means the counts could not be computed.
+// We intentionally do not rely on Git's upstream tracking configuration (@{u}).
+func hubAheadBehind(repoPath string) (compareRef string, ahead, behind int, err error) {
+	originHead, err := exec.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "origin/HEAD").Output()
+	if err != nil {
+		return "", 0, 0, err
+	}
+	ref := strings.TrimSpace(string(originHead))
+	countsRaw, countsErr := exec.Command("git", "-C", repoPath, "rev-list", "--left-right", "--count", "HEAD..."+ref).Output()
+	if countsErr != nil {
+		return "", 0, 0, nil
+	}
+	fields := strings.Fields(strings.TrimSpace(string(countsRaw)))
+	if len(fields) < 2 {
+		return "", 0, 0, nil
+	}
+	a, aErr := strconv.Atoi(fields[0])
+	b, bErr := strconv.Atoi(fields[1])
+	if aErr != nil || bErr != nil {
+		return "", 0, 0, nil
+	}
+	return ref, a, b, nil
+}
+
+// runStatusHealth prints a single machine-readable summary line and returns
+// an error (non-zero exit) when any target is broken or not linked.
+//
+//	status=ok linked=5 broken=0 missing=0 not_installed=3 dirty=false ahead=0 behind=0
+func runStatusHealth(cfg *config.Config, fetchFirst bool) error {
+	h := collectLinkHealth(cfg)
+	missing := len(h.needLink) + len(h.realDir)
+
+	// With several repos, dirty is true if any repo is dirty and ahead/behind
+	// are summed over the repos that have a remote default branch.
+	dirty, ahead, behind := "n/a", "n/a", "n/a"
+	if checkGitAvailable() == nil {
+		var anyDirty, dirtyKnown, countsKnown bool
+		var a, b int
+		for _, r := range cfg.HubRepos() {
+			if fetchFirst && gitHasRemote(r.Path) {
+				_, _ = gitOutput(r.Path, "fetch", "--prune", "origin")
+			}
+			if d, err := gitIsDirty(r.Path); err == nil {
+				dirtyKnown = true
+				anyDirty = anyDirty || d
+			}
+			if ref, ra, rb, err := hubAheadBehind(r.Path); err == nil && ref != "" {
+				countsKnown = true
+				a, b = a+ra, b+rb
+			}
+		}
+		if dirtyKnown {
+			dirty = strconv.FormatBool(anyDirty)
+		}
+		if countsKnown {
+			ahead, behind = strconv.Itoa(a), strconv.Itoa(b)
+		}
+	}
+
+	state := "ok"
+	if len(h.broken) > 0 || missing > 0 {
+		state = "broken"
+	}
+	fmt.Printf("status=%s linked=%d broken=%d missing=%d not_installed=%d dirty=%s ahead=%s behind=%s\n",
+		state, len(h.linked), len(h.broken), missing, len(h.notInstalled), dirty, ahead, behind)
+
+	if state != "ok" {
+		return fmt.Errorf("unhealthy: %d broken, %d missing link(s)", len(h.broken), missing)
+	}
+	return nil
+}
+
 // showSkillStatus prints focused status for a single skill: path, link state,
 // recent commit history, and (with --fetch) a remote comparison.
 func showSkillStatus(cfg *config.Config, skillName string, fetchFirst bool) error {
@@ -247,7 +510,7 @@ func showSkillStatus(cfg *config.Config, skillName string, fetchFirst bool) erro
 			if trimmed == "" {
 				return fmt.Errorf("git fetch failed: %w", fetchErr)
 			}
-			return fmt.Errorf("git fetch failed:\n%s", trimmed)
+			return fmt.Errorf("git fetch failed:\n%s%s", trimmed, gitRemoteHint(trimmed, originURL(cfg.RepoPath)))
 		}
 		printOK("", "Fetch complete.")
 	}
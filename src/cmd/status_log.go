@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// hubActivity is one Hub commit for 'axon status --git-log': when, what,
+// and the items it touched.
+type hubActivity struct {
+	SHA, Date, Subject string
+	Items              []string // <root>/<name>, sorted
+	Other              int      // files outside every item
+}
+
+// recentHubActivity returns the last n commits of repo, newest first.
+func recentHubActivity(repo string, n int, roots []string) ([]hubActivity, error) {
+	out, err := gitOutput(repo, "log", fmt.Sprintf("-n%d", n), "--no-renames", "--name-only",
+		"--format=@%h|%cd|%s", "--date=format:%Y-%m-%d %H:%M")
+	if err != nil {
+		if strings.Contains(out, "does not have any commits") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("git log: %s", strings.TrimSpace(out))
+	}
+	return parseHubActivity(out, roots), nil
+}
+
+// parseHubActivity parses 'git log --name-only --format=@%h|%cd|%s' output,
+// mapping each file to its Hub item.
+func parseHubActivity(out string, roots []string) []hubActivity {
+	var commits []hubActivity
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "@"):
+			parts := strings.SplitN(line[1:], "|", 3)
+			if len(parts) != 3 {
+				continue
+			}
+			commits = append(commits, hubActivity{SHA: parts[0], Date: parts[1], Subject: parts[2]})
+			seen = make(map[string]bool)
+		case len(commits) > 0:
+			c := &commits[len(commits)-1]
+			item, ok := hubItem(line, roots)
+			if !ok {
+				c.Other++
+				continue
+			}
+			if !seen[item] {
+				seen[item] = true
+				c.Items = append(c.Items, item)
+				sort.Strings(c.Items)
+			}
+		}
+	}
+	return commits
+}
+
+// printHubActivity prints the recent commits of one Hub repo.
+func printHubActivity(repo string, n int, roots []string) error {
+	commits, err := recentHubActivity(repo, n, roots)
+	if err != nil {
+		return err
+	}
+	printBullet("Recent Hub activity:")
+	if len(commits) == 0 {
+		fmt.Println("  (no commits yet)")
+		return nil
+	}
+	for _, c := range commits {
+		fmt.Printf("  %s  %s  %s\n", c.SHA, c.Date, c.Subject)
+		touched := strings.Join(c.Items, ", ")
+		switch {
+		case c.Other > 0 && touched != "":
+			touched += fmt.Sprintf(" (+%d other file(s))", c.Other)
+		case c.Other > 0:
+			touched = fmt.Sprintf("%d other file(s)", c.Other)
+		case touched == "":
+			touched = "no file changes"
+		}
+		fmt.Printf("  %s  %s\n", strings.Repeat(" ", len(c.SHA)), touched)
+	}
+	return nil
+}
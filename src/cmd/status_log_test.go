@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHubActivity(t *testing.T) {
+	out := "@abc1234|2026-03-05 14:20|axon: sync from mac-mini\n\n" +
+		"skills/pdf/SKILL.md\nskills/pdf/scripts/run.py\nworkflows/release.md\nREADME.md\n" +
+		"@def5678|2026-03-04 10:15|axon: sync from vps-1\n\naxon.yaml\n" +
+		"@0123abc|2026-03-03 09:00|Merge branch 'main'\n"
+	got := parseHubActivity(out, []string{"skills", "workflows"})
+	want := []hubActivity{
+		{SHA: "abc1234", Date: "2026-03-05 14:20", Subject: "axon: sync from mac-mini", Items: []string{"skills/pdf", "workflows/release.md"}, Other: 1},
+		{SHA: "def5678", Date: "2026-03-04 10:15", Subject: "axon: sync from vps-1", Other: 1},
+		{SHA: "0123abc", Date: "2026-03-03 09:00", Subject: "Merge branch 'main'"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseHubActivity:\n got %+v\nwant %+v", got, want)
+	}
+}
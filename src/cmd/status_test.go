@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestCollectLinkHealth(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	cfg.Targets = append(cfg.Targets,
+		config.Target{Name: "absent-skills", Source: "skills", Destination: filepath.Join(tmp, "nope", "tool", "skills")},
+		config.Target{Name: "wrong-skills", Source: "skills", Destination: filepath.Join(tmp, "dest", "wrong")},
+	)
+
+	// test-skills: installed (parent exists) but not linked yet.
+	if err := os.MkdirAll(filepath.Join(tmp, "dest"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(tmp, filepath.Join(tmp, "dest", "wrong")); err != nil {
+		t.Fatal(err)
+	}
+
+	h := collectLinkHealth(cfg)
+	if len(h.needLink) != 1 || h.needLink[0] != "test-skills" {
+		t.Errorf("needLink = %v", h.needLink)
+	}
+	if len(h.broken) != 1 || h.broken[0].name != "wrong-skills" {
+		t.Errorf("broken = %v", h.broken)
+	}
+	if len(h.notInstalled) != 1 || h.notInstalled[0] != "absent" {
+		t.Errorf("notInstalled = %v", h.notInstalled)
+	}
+
+	if err := callLinkTarget(cfg, cfg.Targets[0]); err != nil {
+		t.Fatal(err)
+	}
+	if h := collectLinkHealth(cfg); len(h.linked) != 1 || len(h.needLink) != 0 {
+		t.Errorf("after link: linked=%v needLink=%v", h.linked, h.needLink)
+	}
+}
+
+func TestRunStatusHealth_ExitStatus(t *testing.T) {
+	cfg, _ := setupLinkTest(t)
+	if err := os.MkdirAll(filepath.Dir(cfg.Targets[0].Destination), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := runStatusHealth(cfg, false); err == nil {
+		t.Fatal("expected unhealthy status for an unlinked target")
+	}
+	if err := callLinkTarget(cfg, cfg.Targets[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := runStatusHealth(cfg, false); err != nil {
+		t.Fatalf("expected healthy status, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
 	"github.com/spf13/cobra"
 )
 
@@ -18,17 +19,79 @@ var syncCmd = &cobra.Command{
 
   read-write (default):
     Apply exclude filtering → git add . → git commit → git pull --rebase → git push
+    New or changed files larger than max_file_size (default 10MB) are left
+    out of the commit unless --allow-large is given. Files that look like they
+    contain API keys or private keys stop the sync (see 'secrets:' in axon.yaml).
 
   read-only:
-    git pull (fast-forward only). Local edits are allowed but warned about.`,
+    git pull (fast-forward only). Local edits are allowed but warned about.
+
+Additional repos from the 'repos' block in axon.yaml are synced after the
+primary Hub, each in its own sync_mode (read-only unless configured).
+A repo with a 'remote' that is not cloned yet is cloned first. Merged views
+of multi-repo targets are refreshed afterwards.
+
+With auto_sync set in axon.yaml (e.g. "auto_sync: 30m"), 'axon sync --daemon'
+syncs on that interval. A scheduled run skips a repo instead of rebasing when
+local edits or commits meet new remote commits, and never touches a repo with
+a rebase or merge in progress. Results of the last manual and scheduled runs
+are shown by 'axon sync --status'.
+
+When a pull conflicts in a way git cannot resolve, the merge is left in
+progress and the conflicting files are listed by skill. Fix them, then run
+'axon sync --continue', or 'axon sync --abort' to undo the pull.
+
+  axon sync                 Sync every repo
+  axon sync --repo company  Sync a single repo ("default" is repo_path)
+  axon sync --keep-both     On conflicting edits keep both versions side by side
+  axon sync --continue      Finish a sync stopped by conflicts
+  axon sync --abort         Abandon a sync stopped by conflicts
+  axon sync --pull-only     Pull remote changes without committing local edits
+  axon sync --push-only     Commit and push local changes without pulling
+  axon sync --daemon        Sync every auto_sync interval until stopped
+  axon sync --status        Show the last manual and scheduled sync results`,
 	RunE: runSync,
 }
 
+var (
+	flagSyncRepo   string
+	flagSyncDaemon bool
+	flagSyncStatus bool
+	flagSyncNotify bool
+
+	flagSyncAllowLarge bool
+	flagSyncPullOnly   bool
+	flagSyncPushOnly   bool
+	flagSyncContinue   bool
+	flagSyncAbort      bool
+	flagSyncKeepBoth   bool
+)
+
 func init() {
+	syncCmd.Flags().StringVar(&flagSyncRepo, "repo", "", "Only sync the named repo (\"default\" is repo_path)")
+	syncCmd.Flags().BoolVar(&flagSyncDaemon, "daemon", false, "Keep running and sync every auto_sync interval")
+	syncCmd.Flags().BoolVar(&flagSyncStatus, "status", false, "Show the results of the last sync runs")
+	syncCmd.Flags().BoolVar(&flagSyncNotify, "notify", false, "With --daemon, raise a desktop notification when a run fails or skips a repo")
+	syncCmd.Flags().BoolVar(&flagSyncAllowLarge, "allow-large", false, "Commit files larger than max_file_size")
+	syncCmd.Flags().BoolVar(&flagSyncPullOnly, "pull-only", false, "Only pull remote changes; local edits are kept uncommitted")
+	syncCmd.Flags().BoolVar(&flagSyncPushOnly, "push-only", false, "Only commit and push local changes, without pulling")
+	syncCmd.Flags().BoolVar(&flagSyncContinue, "continue", false, "Finish a sync stopped by conflicts once the files are fixed")
+	syncCmd.Flags().BoolVar(&flagSyncAbort, "abort", false, "Abandon a sync stopped by conflicts")
+	syncCmd.Flags().BoolVar(&flagSyncKeepBoth, "keep-both", false, "On conflicting edits, keep the remote file and save yours as <name>.conflict-<host>")
+	syncCmd.MarkFlagsMutuallyExclusive("pull-only", "push-only", "continue", "abort", "daemon", "status")
+	syncCmd.MarkFlagsMutuallyExclusive("keep-both", "continue", "abort", "push-only")
+	syncCmd.MarkFlagsMutuallyExclusive("daemon", "status")
+	syncCmd.MarkFlagsMutuallyExclusive("repo", "daemon")
 	rootCmd.AddCommand(syncCmd)
 }
 
 func runSync(cmd *cobra.Command, args []string) error {
+	if flagSyncNotify && !flagSyncDaemon {
+		return fmt.Errorf("--notify requires --daemon")
+	}
+	if flagSyncStatus {
+		return runSyncStatus()
+	}
 	if err := checkGitAvailable(); err != nil {
 		return err
 	}
@@ -36,27 +99,221 @@ func runSync(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
 	}
+	if flagSyncDaemon {
+		return runSyncDaemon(cmd.Context(), cfg)
+	}
+	if flagSyncContinue || flagSyncAbort {
+		return runSyncResolve(cfg)
+	}
+
+	run := syncRun{Trigger: syncTriggerManual, StartedAt: clock.Now()}
+	err = syncManual(cfg)
+	run.finish(err)
+	saveSyncRun(run)
+	return err
+}
+
+// syncManual runs one 'axon sync' over the selected repos.
+func syncManual(cfg *config.Config) error {
+	if err := ensureFreeSpace(cfg, cfg.RepoPath, "sync"); err != nil {
+		return err
+	}
+
+	repos, err := selectSyncRepos(cfg)
+	if err != nil {
+		return err
+	}
+
+	// A single repo keeps the original output and fail-fast behaviour.
+	if len(repos) == 1 && flagSyncRepo == "" {
+		progress := startProgress("Syncing the Hub", 0)
+		err := syncRepo(cfg, repos[0], false)
+		progress.Done()
+		refreshMergedViews(cfg)
+		refreshRenderedTargets(cfg)
+		return err
+	}
+
+	var failed []string
+	progress := startProgress("Syncing repos", int64(len(repos)))
+	for _, r := range repos {
+		printSection(fmt.Sprintf("Sync [%s] %s", r.Name, r.Path))
+		progress.Item(r.Name)
+		if err := syncRepo(cfg, r, true); err != nil {
+			printErr(r.Name, err.Error())
+			failed = append(failed, r.Name)
+		}
+		progress.Add(1)
+	}
+	progress.Done()
+	refreshMergedViews(cfg)
+	refreshRenderedTargets(cfg)
+	if len(failed) > 0 {
+		return fmt.Errorf("sync failed for repo(s): %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// selectSyncRepos returns the repo named by --repo, or every syncable repo.
+func selectSyncRepos(cfg *config.Config) ([]config.Repo, error) {
+	if flagSyncRepo == "" {
+		return syncableRepos(cfg), nil
+	}
+	r, ok := cfg.FindRepo(flagSyncRepo)
+	if !ok {
+		return nil, fmt.Errorf("repo %q not found in axon.yaml", flagSyncRepo)
+	}
+	if r.Project != "" {
+		return nil, fmt.Errorf("repo %q belongs to the project at %s; commit it with git", r.Name, r.Project)
+	}
+	return []config.Repo{r}, nil
+}
+
+// syncableRepos returns the Hub repos 'axon sync' manages. Project repos
+// live in the project's own checkout; git handles those.
+func syncableRepos(cfg *config.Config) []config.Repo {
+	var repos []config.Repo
+	for _, r := range cfg.HubRepos() {
+		if r.Project == "" {
+			repos = append(repos, r)
+		}
+	}
+	return repos
+}
+
+// syncRepo syncs one Hub repo in its sync_mode. Additional repos that are
+// not cloned yet are cloned from their remote, or skipped without one.
+func syncRepo(cfg *config.Config, r config.Repo, multi bool) error {
+	rc := repoConfig(cfg, r)
+	if r.Name != config.PrimaryRepoName {
+		if _, err := fsys.Stat(filepath.Join(r.Path, ".git")); os.IsNotExist(err) {
+			if r.Remote == "" {
+				printSkip(r.Name, "not cloned and no 'remote' configured — skipping")
+				return nil
+			}
+			printInfo(r.Name, fmt.Sprintf("git clone %s %s", r.Remote, r.Path))
+			if err := gitRunRemote(r.Remote, "clone", r.Remote, r.Path); err != nil {
+				return fmt.Errorf("git clone failed: %w", err)
+			}
+			recordSync(rc, "", r.Name)
+			return nil
+		}
+	}
+
+	if op := hubOperation(rc.RepoPath); op != "" {
+		if conflicts, _ := hubConflicts(rc.RepoPath); len(conflicts) > 0 {
+			printConflictSummary(rc.RepoPath, conflicts)
+		}
+		return fmt.Errorf("a %s stopped by conflicts is in progress; fix the files and run 'axon sync --continue', or 'axon sync --abort'", op)
+	}
 
 	// ── Apply exclude filtering (both modes) ──────────────────────────────────
 	// Write excludes to .git/info/exclude — the per-repo, non-committed exclude
 	// file. This is the Axon-layer guard (Layer 1) that operates independently
 	// of the committed .gitignore (Layer 2).
-	if err := writeGitExcludes(cfg); err != nil {
+	if err := writeGitExcludes(rc); err != nil {
 		return fmt.Errorf("cannot write git excludes: %w", err)
 	}
-	printOK("", fmt.Sprintf("Exclude filter applied (%d patterns)", len(cfg.Excludes)))
-
-	switch cfg.SyncMode {
-	case "read-only":
-		return syncReadOnly(cfg)
+	printOK("", fmt.Sprintf("Exclude filter applied (%d patterns)", len(rc.Excludes)))
+
+	// Journal the HEAD movement even when a later step (e.g. push) fails, so
+	// the local commit can still be found with 'axon history'.
+	before := hubHeadSHA(rc.RepoPath)
+	var err error
+	switch {
+	case flagSyncPullOnly && rc.SyncMode == "read-only":
+		err = syncReadOnly(rc)
+	case flagSyncPullOnly:
+		err = syncPullOnly(rc)
+	case flagSyncPushOnly && rc.SyncMode == "read-only":
+		printSkip("", "read-only repo — nothing to push")
+	case flagSyncPushOnly:
+		err = syncPushOnly(rc)
+	case rc.SyncMode == "read-only":
+		err = syncReadOnly(rc)
 	default:
-		return syncReadWrite(cfg)
+		err = syncReadWrite(rc)
+	}
+	name := ""
+	if multi {
+		name = r.Name
+	}
+	recordSync(rc, before, name)
+	return err
+}
+
+// hubHeadSHA returns the full HEAD commit SHA of repo, or "" when there is none.
+func hubHeadSHA(repo string) string {
+	out, err := gitOutput(repo, "rev-parse", "--verify", "-q", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// recordSync journals a sync that moved the Hub HEAD from before. repoName
+// is set when several repos are configured.
+func recordSync(cfg *config.Config, before, repoName string) {
+	after := hubHeadSHA(cfg.RepoPath)
+	if after == "" || after == before {
+		return
+	}
+	mode := cfg.SyncMode
+	if mode == "" {
+		mode = "read-write"
 	}
+	recordHistory(journal.Entry{
+		Op:     journal.OpSync,
+		Target: repoName,
+		Detail: fmt.Sprintf("%s → %s (%s)", abbrevSHA(before), abbrevSHA(after), mode),
+		Data:   map[string]string{"before": before, "after": after, "mode": mode, "repo": cfg.RepoPath},
+	})
+}
+
+// abbrevSHA abbreviates a commit SHA for display; "" renders as "(none)".
+func abbrevSHA(sha string) string {
+	if sha == "" {
+		return "(none)"
+	}
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
 }
 
 // syncReadWrite: filter → add → commit → pull --rebase → push
 func syncReadWrite(cfg *config.Config) error {
 	repo := cfg.RepoPath
+	if err := commitHubChanges(cfg); err != nil {
+		return err
+	}
+
+	if !gitHasRemote(repo) {
+		printOK("", "Local commit done (no remote configured; run 'axon remote set <url>' to push).")
+		return nil
+	}
+
+	// Detect whether the remote has any commits yet (empty repo = first push).
+	if gitRemoteIsEmpty(repo) {
+		return pushHubInitial(repo)
+	}
+	if err := pullHubRebase(repo); err != nil {
+		return err
+	}
+	// The pull may bring in changes to signed skills from other machines.
+	warnSignatureFailures(cfg)
+	if err := pushHub(repo); err != nil {
+		return err
+	}
+
+	printOK("", "Sync complete (read-write).")
+	return nil
+}
+
+// commitHubChanges is the local half of a read-write sync: strip nested
+// .git dirs, run the guardrails, then stage and commit every change.
+func commitHubChanges(cfg *config.Config) error {
+	repo := cfg.RepoPath
 
 	identityOK, identityErr := gitIdentityConfigured(repo)
 	if identityErr != nil {
@@ -73,9 +330,6 @@ func syncReadWrite(cfg *config.Config) error {
 				"Omit --global to set the identity only in this repository.")
 	}
 
-	// Check if there is a remote configured; push only if so.
-	hasRemote := gitHasRemote(repo)
-
 	// Strip any nested .git directories inside the Hub — skills are often
 	// cloned from the internet and may contain their own .git dirs.
 	// Leaving them in place causes git to treat them as submodules (embedded
@@ -91,11 +345,36 @@ func syncReadWrite(cfg *config.Config) error {
 		}
 	}
 
+	// Warn before committing if a signed skill no longer matches its signature.
+	warnSignatureFailures(cfg)
+
+	// Warn about names and path lengths that break on other platforms.
+	warnPortabilityHazards(repo, longPathPrefix(cfg))
+
+	// Refuse to commit credentials pasted into skills.
+	if err := checkHubSecrets(cfg, repo); err != nil {
+		return err
+	}
+
+	// Find files over max_file_size before they are staged.
+	limit := cfg.MaxFileBytes()
+	var large []largeFile
+	if !flagSyncAllowLarge {
+		if large, err = largeHubFiles(repo, limit); err != nil {
+			return err
+		}
+	}
+
 	// git add .
 	printInfo("", "git add .")
 	if err := gitRun("-C", repo, "add", "."); err != nil {
 		return fmt.Errorf("git add failed: %w", err)
 	}
+	if len(large) > 0 {
+		if err := unstageLargeFiles(repo, large, limit); err != nil {
+			return err
+		}
+	}
 
 	// git commit (skip if nothing to commit)
 	hostname, _ := os.Hostname()
@@ -103,65 +382,97 @@ func syncReadWrite(cfg *config.Config) error {
 	printInfo("", fmt.Sprintf("git commit -m %q", msg))
 	commitOut, commitErr := gitOutput(repo, "commit", "-m", msg)
 	if commitErr != nil {
+		// "no changes added" is what remains when every change was a
+		// large file left out above.
 		if strings.Contains(commitOut, "nothing to commit") ||
-			strings.Contains(commitOut, "nothing added to commit") {
+			strings.Contains(commitOut, "nothing added to commit") ||
+			strings.Contains(commitOut, "no changes added to commit") {
 			printSkip("", "nothing to commit")
 		} else {
 			return fmt.Errorf("git commit failed: %w\n%s", commitErr, commitOut)
 		}
 	}
 
-	if !hasRemote {
-		printOK("", "Local commit done (no remote configured; run 'axon remote set <url>' to push).")
-		return nil
-	}
-
-	// Detect whether the remote has any commits yet (empty repo = first push).
-	remoteEmpty := gitRemoteIsEmpty(repo)
+	return nil
+}
 
-	if remoteEmpty {
-		// First push — no upstream branch to pull from yet.
-		printInfo("", "git push -u origin master  (initial push to empty remote)")
-		if err := gitRun("-C", repo, "push", "-u", "origin", "master"); err != nil {
-			return fmt.Errorf("git push failed: %w", err)
-		}
-		printOK("", "Sync complete (initial push).")
-		return nil
+// pushHubInitial pushes to an empty remote, which has no branch to pull.
+func pushHubInitial(repo string) error {
+	printInfo("", "git push -u origin master  (initial push to empty remote)")
+	if err := gitRunRemote(originURL(repo), "-C", repo, "push", "-u", "origin", "master"); err != nil {
+		return fmt.Errorf("git push failed: %w", err)
 	}
+	printOK("", "Sync complete (initial push).")
+	return nil
+}
 
+// pullHubRebase rebases local commits onto origin/master, falling back to
+// a merge when the rebase cannot be resolved automatically.
+func pullHubRebase(repo string) error {
+	if flagSyncKeepBoth {
+		return pullHubKeepBoth(repo)
+	}
 	// git pull --rebase with auto conflict resolution.
 	// -X theirs: when content conflicts arise, favor the remote (upstream) version.
 	// This handles the common case of two machines independently importing the
 	// same skill file with slightly different content.
-	printInfo("", "git pull --rebase -X theirs origin master")
-	if err := gitRun("-C", repo, "pull", "--rebase", "-X", "theirs", "origin", "master"); err != nil {
+	// --autostash: large files left out of the commit keep the worktree
+	// dirty, and a plain rebase refuses to start on a dirty worktree.
+	printInfo("", "git pull --rebase --autostash -X theirs origin master")
+	if err := gitRunRemote(originURL(repo), "-C", repo, "pull", "--rebase", "--autostash", "-X", "theirs", "origin", "master"); err != nil {
 		// Stage 1 failed — likely a structural conflict (file vs directory, etc.)
 		// that -X theirs alone cannot resolve. Abort and fall back to merge.
 		printWarn("", "rebase auto-resolve failed; aborting and retrying with merge strategy")
 		_ = gitRun("-C", repo, "rebase", "--abort")
 
 		printInfo("", "git merge -X theirs origin/master  (fallback)")
-		if mergeErr := gitRun("-C", repo, "merge", "-X", "theirs", "origin/master"); mergeErr != nil {
-			// Both strategies failed. Abort the merge and tell the user.
-			_ = gitRun("-C", repo, "merge", "--abort")
-			return fmt.Errorf(
-				"sync conflict could not be auto-resolved.\n"+
-					"   Please resolve manually in %s:\n"+
-					"     git -C %s status\n"+
-					"   Then commit and run 'axon sync' again.", repo, repo)
+		if mergeErr := gitRun("-C", repo, "merge", "--no-edit", "-X", "theirs", "origin/master"); mergeErr != nil {
+			// Both strategies failed. Leave the merge for 'axon sync --continue'.
+			if hubOperation(repo) != "merge" {
+				return fmt.Errorf("git merge failed: %w", mergeErr)
+			}
+			return stopOnConflicts(repo)
 		}
 		printWarn("", "merged with remote (theirs wins on conflicts) — run 'axon doctor' to check for conflict files")
 	}
+	return nil
+}
 
-	// git push origin master
+// pullHubKeepBoth merges origin/master without favoring either side. When
+// every conflict is a file edited on both sides, both versions are kept (see
+// keepBothVersions); otherwise the merge stops for 'axon sync --continue'.
+func pullHubKeepBoth(repo string) error {
+	printInfo("", "git fetch origin")
+	if err := gitRunRemote(originURL(repo), "-C", repo, "fetch", "origin"); err != nil {
+		return fmt.Errorf("git fetch failed: %w", err)
+	}
+	printInfo("", "git merge --no-edit origin/master")
+	if err := gitRun("-C", repo, "merge", "--no-edit", "origin/master"); err == nil {
+		return nil
+	} else if hubOperation(repo) != "merge" {
+		return fmt.Errorf("git merge failed: %w", err)
+	}
+	conflicts, err := hubConflicts(repo)
+	if err != nil {
+		return err
+	}
+	resolved, err := keepBothVersions(repo, conflicts)
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		return stopOnConflicts(repo)
+	}
+	return nil
+}
+
+// pushHub pushes master to origin.
+func pushHub(repo string) error {
 	printInfo("", "git push origin master")
-	if err := gitRun("-C", repo, "push", "origin", "master"); err != nil {
+	if err := gitRunRemote(originURL(repo), "-C", repo, "push", "origin", "master"); err != nil {
 		return fmt.Errorf("git push failed: %w", err)
 	}
-
-	printOK("", "Sync complete (read-write).")
 	return nil
-
 }
 
 // syncReadOnly: warn on local edits, then pull fast-forward only.
@@ -175,32 +486,125 @@ func syncReadOnly(cfg *config.Config) error {
 	}
 	if dirty {
 		printWarn("", "You have local edits in the Hub.")
-		fmt.Println("   These will NOT be pushed (read-only mode) and may be overwritten on pull.")
-		fmt.Println("   Stash or discard them if you don't need them.")
-		fmt.Println()
+		outf("   These will NOT be pushed (read-only mode) and may be overwritten on pull.\n")
+		outf("   Stash or discard them if you don't need them.\n")
+		outf("\n")
 	}
 
 	printInfo("", "git pull --ff-only origin master")
-	if err := gitRun("-C", repo, "pull", "--ff-only", "origin", "master"); err != nil {
+	if err := gitRunRemote(originURL(repo), "-C", repo, "pull", "--ff-only", "origin", "master"); err != nil {
 		return fmt.Errorf("git pull failed (fast-forward only enforced in read-only mode): %w", err)
 	}
+	warnSignatureFailures(cfg)
 
 	printOK("", "Sync complete (read-only).")
 	return nil
 }
 
+// syncPullOnly brings in remote changes without committing local edits.
+// Uncommitted edits are stashed around the rebase, which is refused when
+// the remote changed the same files.
+func syncPullOnly(cfg *config.Config) error {
+	repo := cfg.RepoPath
+	if !gitHasRemote(repo) {
+		return fmt.Errorf("no remote configured; run 'axon remote set <url>' first")
+	}
+	printInfo("", "git fetch origin")
+	if err := gitRunRemote(originURL(repo), "-C", repo, "fetch", "origin"); err != nil {
+		return fmt.Errorf("git fetch failed: %w", err)
+	}
+	if _, err := gitOutput(repo, "rev-parse", "--verify", "-q", "origin/master"); err != nil {
+		printSkip("", "remote has no commits yet — nothing to pull")
+		return nil
+	}
+
+	overlap, err := pullOverlapsLocalEdits(repo)
+	if err != nil {
+		return err
+	}
+	if len(overlap) > 0 {
+		printErr("", fmt.Sprintf("%d file(s) changed both locally and on the remote:", len(overlap)))
+		for _, p := range overlap {
+			printErr("", p)
+		}
+		return fmt.Errorf("pull-only refused to touch uncommitted edits; run 'axon sync' to commit and merge them")
+	}
+
+	printInfo("", "git pull --rebase --autostash origin master")
+	if err := gitRunRemote(originURL(repo), "-C", repo, "pull", "--rebase", "--autostash", "origin", "master"); err != nil {
+		_ = gitRun("-C", repo, "rebase", "--abort")
+		return fmt.Errorf("git pull failed: %w", err)
+	}
+	warnSignatureFailures(cfg)
+
+	printOK("", "Pull complete; local edits were left uncommitted.")
+	return nil
+}
+
+// pullOverlapsLocalEdits returns the uncommitted files in repo that the
+// fetched origin/master also changed since the common ancestor.
+func pullOverlapsLocalEdits(repo string) ([]string, error) {
+	local, err := changedHubFiles(repo)
+	if err != nil || len(local) == 0 {
+		return nil, err
+	}
+	out, err := gitOutput(repo, "diff", "--name-only", "HEAD...origin/master")
+	if err != nil {
+		return nil, fmt.Errorf("git diff: %w", err)
+	}
+	incoming := make(map[string]bool)
+	for _, p := range strings.Split(strings.TrimSpace(out), "\n") {
+		if p != "" {
+			incoming[p] = true
+		}
+	}
+	var overlap []string
+	for _, p := range local {
+		if incoming[p] {
+			overlap = append(overlap, p)
+		}
+	}
+	return overlap, nil
+}
+
+// syncPushOnly commits local changes and pushes them without pulling. The
+// push is refused by git if the remote moved on; nothing is merged.
+func syncPushOnly(cfg *config.Config) error {
+	repo := cfg.RepoPath
+	if err := commitHubChanges(cfg); err != nil {
+		return err
+	}
+	if !gitHasRemote(repo) {
+		printOK("", "Local commit done (no remote configured; run 'axon remote set <url>' to push).")
+		return nil
+	}
+	if gitRemoteIsEmpty(repo) {
+		return pushHubInitial(repo)
+	}
+	printInfo("", "git push origin master")
+	if out, err := gitRunRemoteStderr(originURL(repo), "-C", repo, "push", "origin", "master"); err != nil {
+		if strings.Contains(out, "non-fast-forward") || strings.Contains(out, "fetch first") || strings.Contains(out, "[rejected]") {
+			return fmt.Errorf("push rejected: the remote has commits you do not have.\n" +
+				"   Run 'axon sync' (or 'axon sync --pull-only' first) to bring them in.")
+		}
+		return fmt.Errorf("git push failed: %w", err)
+	}
+	printOK("", "Push complete (nothing pulled).")
+	return nil
+}
+
 // writeGitExcludes writes the Axon exclude patterns to .git/info/exclude,
 // the per-repo non-committed exclude file analogous to .gitignore.
 func writeGitExcludes(cfg *config.Config) error {
 	excludeFile := filepath.Join(cfg.RepoPath, ".git", "info", "exclude")
-	if err := os.MkdirAll(filepath.Dir(excludeFile), 0o755); err != nil {
+	if err := fsys.MkdirAll(filepath.Dir(excludeFile), 0o755); err != nil {
 		return err
 	}
 
 	header := "# Auto-generated by axon sync — do not edit manually.\n# Edit 'excludes:' in ~/.axon/axon.yaml instead.\n\n"
 	body := strings.Join(cfg.Excludes, "\n") + "\n"
 
-	return os.WriteFile(excludeFile, []byte(header+body), 0o644)
+	return fsys.WriteFile(excludeFile, []byte(header+body), 0o644)
 }
 
 // stripNestedGitDirs walks the Hub working tree and removes any .git directory
@@ -239,10 +643,10 @@ func stripNestedGitDirs(repoPath string) ([]string, error) {
 		// De-index any cached submodule entry (ignore errors — entry may not exist).
 		// Use exec directly so git's "rm 'path'" stdout doesn't leak to the user.
 		rmCmd := exec.Command("git", "-C", repoPath, "rm", "--cached", "-q", rel)
-		_ = rmCmd.Run()
+		_ = runner.Run(rmCmd)
 
 		// Remove the nested .git entirely.
-		if err := os.RemoveAll(path); err != nil {
+		if err := fsys.RemoveAll(path); err != nil {
 			return fmt.Errorf("cannot remove %s: %w", path, err)
 		}
 		stripped = append(stripped, rel)
@@ -0,0 +1,382 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// Sync run triggers and results recorded in the sync status file.
+const (
+	syncTriggerManual = "manual"
+	syncTriggerAuto   = "auto"
+
+	syncResultOK      = "ok"
+	syncResultSkipped = "skipped"
+	syncResultFailed  = "failed"
+)
+
+// syncStatus is the on-disk record of the last manual and scheduled syncs.
+type syncStatus struct {
+	LastManual *syncRun `json:"last_manual,omitempty"`
+	LastAuto   *syncRun `json:"last_auto,omitempty"`
+}
+
+// syncRun is the outcome of one sync run.
+type syncRun struct {
+	Trigger    string        `json:"trigger"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Result     string        `json:"result"`
+	Message    string        `json:"message,omitempty"`
+	Repos      []syncRepoRun `json:"repos,omitempty"`
+}
+
+// syncRepoRun is the outcome for one repo of a scheduled run.
+type syncRepoRun struct {
+	Repo    string `json:"repo"`
+	Result  string `json:"result"`
+	Message string `json:"message,omitempty"`
+}
+
+// finish stamps the run as finished with the result implied by err.
+func (r *syncRun) finish(err error) {
+	r.FinishedAt = clock.Now()
+	if err != nil {
+		r.Result, r.Message = syncResultFailed, err.Error()
+	} else {
+		r.Result = syncResultOK
+	}
+}
+
+// syncStatusPath returns ~/.axon/cache/sync-status.json.
+func syncStatusPath() (string, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(axonDir, "cache", "sync-status.json"), nil
+}
+
+func readSyncStatus(path string) syncStatus {
+	var s syncStatus
+	if b, err := fsys.ReadFile(path); err == nil {
+		_ = json.Unmarshal(b, &s)
+	}
+	return s
+}
+
+func writeSyncStatus(path string, s syncStatus) error {
+	if err := fsys.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := fsys.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return fsys.Rename(tmp, path)
+}
+
+// saveSyncRun records run as the last run of its trigger. Failing to write
+// the status file never fails the sync itself.
+func saveSyncRun(run syncRun) {
+	path, err := syncStatusPath()
+	if err != nil {
+		return
+	}
+	s := readSyncStatus(path)
+	if run.Trigger == syncTriggerAuto {
+		s.LastAuto = &run
+	} else {
+		s.LastManual = &run
+	}
+	if err := writeSyncStatus(path, s); err != nil {
+		printWarn("", fmt.Sprintf("cannot record sync status: %v", err))
+	}
+}
+
+// runSyncDaemon syncs every auto_sync interval until interrupted. The
+// config is reloaded before each run, so interval and repo changes apply
+// without a restart.
+func runSyncDaemon(ctx context.Context, cfg *config.Config) error {
+	interval, err := cfg.AutoSyncInterval()
+	if err != nil {
+		return err
+	}
+	if interval == 0 {
+		return fmt.Errorf("auto_sync is not set in axon.yaml\nAdd e.g. 'auto_sync: 30m' to enable scheduled syncs.")
+	}
+	statusPath, err := syncStatusPath()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	printSection("Auto-sync")
+	printInfo("", fmt.Sprintf("syncing every %s (pid %d); press Ctrl-C to stop", interval, os.Getpid()))
+	wait := autoSyncDelay(readSyncStatus(statusPath).LastAuto, interval, clock.Now())
+	if wait > 0 {
+		printInfo("", fmt.Sprintf("next run at %s", clock.Now().Add(wait).Format("15:04")))
+	}
+
+	for {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			printInfo("", "auto-sync stopped")
+			return nil
+		case <-timer.C:
+		}
+
+		run := syncRun{Trigger: syncTriggerAuto, StartedAt: clock.Now()}
+		next, err := config.Load()
+		if err != nil {
+			run.finish(fmt.Errorf("cannot load config: %w", err))
+		} else {
+			cfg = next
+			d, err := cfg.AutoSyncInterval()
+			if err == nil && d == 0 {
+				printInfo("", "auto_sync was turned off in axon.yaml; stopping")
+				return nil
+			}
+			if err == nil {
+				interval = d
+			}
+			// Sample before syncing, while access times still reflect
+			// the tools rather than this run.
+			if _, err := sampleUsage(cfg); err != nil {
+				printWarn("", fmt.Sprintf("cannot sample skill usage: %v", err))
+			}
+			run = autoSyncPass(cfg)
+		}
+		saveSyncRun(run)
+		reportAutoSync(run)
+		wait = interval
+	}
+}
+
+// autoSyncDelay returns how long to wait before the first scheduled run:
+// the rest of the interval since the last one, or nothing if it is overdue.
+func autoSyncDelay(last *syncRun, interval time.Duration, now time.Time) time.Duration {
+	if last == nil {
+		return 0
+	}
+	wait := last.FinishedAt.Add(interval).Sub(now)
+	if wait < 0 || wait > interval {
+		return 0
+	}
+	return wait
+}
+
+// autoSyncPass runs one scheduled sync. Repos where syncing could start a
+// conflicting rebase are skipped and left for a manual 'axon sync'.
+func autoSyncPass(cfg *config.Config) syncRun {
+	run := syncRun{Trigger: syncTriggerAuto, StartedAt: clock.Now()}
+	printSection("Auto-sync " + run.StartedAt.Format("2006-01-02 15:04"))
+	unlock, err := acquireHubLock(cfg.RepoPath, "axon sync --daemon", 0)
+	if err != nil {
+		// A manual command is using the Hub; try again next interval.
+		printSkip("", firstLine(err.Error(), nil))
+		run.FinishedAt = clock.Now()
+		run.Result, run.Message = syncResultSkipped, firstLine(err.Error(), nil)
+		return run
+	}
+	defer unlock()
+	if err := ensureFreeSpace(cfg, cfg.RepoPath, "sync"); err != nil {
+		run.finish(err)
+		return run
+	}
+
+	var synced, skipped, failed []string
+	for _, r := range syncableRepos(cfg) {
+		res := syncRepoRun{Repo: r.Name, Result: syncResultOK}
+		if reason := autoSyncBlocker(cfg, r); reason != "" {
+			printSkip(r.Name, reason)
+			res.Result, res.Message = syncResultSkipped, reason
+			skipped = append(skipped, r.Name)
+		} else if err := syncRepo(cfg, r, true); err != nil {
+			printErr(r.Name, err.Error())
+			res.Result, res.Message = syncResultFailed, err.Error()
+			failed = append(failed, r.Name)
+		} else {
+			synced = append(synced, r.Name)
+		}
+		run.Repos = append(run.Repos, res)
+	}
+	refreshMergedViews(cfg)
+	refreshRenderedTargets(cfg)
+
+	run.FinishedAt = clock.Now()
+	switch {
+	case len(failed) > 0:
+		run.Result = syncResultFailed
+		run.Message = fmt.Sprintf("sync failed for repo(s): %s", strings.Join(failed, ", "))
+	case len(skipped) > 0:
+		run.Result = syncResultSkipped
+		run.Message = fmt.Sprintf("skipped repo(s): %s", strings.Join(skipped, ", "))
+	default:
+		run.Result = syncResultOK
+		run.Message = fmt.Sprintf("synced %d repo(s)", len(synced))
+	}
+	return run
+}
+
+// autoSyncBlocker returns why a scheduled sync must not touch r, or "" when
+// it is safe. Syncing is unsafe while a rebase or merge is in progress, and
+// whenever new remote commits would have to be rebased over (or merged
+// with) local edits or unpushed commits.
+func autoSyncBlocker(cfg *config.Config, r config.Repo) string {
+	repo := repoConfig(cfg, r).RepoPath
+	gitDir := filepath.Join(repo, ".git")
+	if _, err := fsys.Stat(gitDir); err != nil {
+		// Not cloned yet: syncRepo clones it or skips it.
+		return ""
+	}
+	if hubOperation(repo) != "" {
+		return "a rebase or merge is in progress — run 'axon sync --continue' or 'axon sync --abort'"
+	}
+	if !gitHasRemote(repo) {
+		return ""
+	}
+	if out, err := gitOutput(repo, "fetch", "--quiet", "origin"); err != nil {
+		if p, ok := diagnoseGitRemote(out, originURL(repo)); ok {
+			return fmt.Sprintf("git fetch failed: %s — %s", p.Summary, p.Remediation)
+		}
+		return fmt.Sprintf("git fetch failed (offline?): %s", firstLine(strings.TrimSpace(out), err))
+	}
+	if _, err := gitOutput(repo, "rev-parse", "--verify", "-q", "origin/master"); err != nil {
+		// Empty remote: the first push cannot conflict.
+		return ""
+	}
+	out, err := gitOutput(repo, "rev-list", "--left-right", "--count", "HEAD...origin/master")
+	if err != nil {
+		return ""
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return ""
+	}
+	ahead, _ := strconv.Atoi(fields[0])
+	behind, _ := strconv.Atoi(fields[1])
+	if behind == 0 {
+		return ""
+	}
+	dirty, err := gitIsDirty(repo)
+	if err != nil {
+		return err.Error()
+	}
+	switch {
+	case dirty:
+		return fmt.Sprintf("local edits and %d new remote commit(s) — a rebase could conflict; run 'axon sync' by hand", behind)
+	case ahead > 0:
+		return fmt.Sprintf("diverged from origin/master (%d ahead, %d behind) — run 'axon sync' by hand", ahead, behind)
+	}
+	return ""
+}
+
+// firstLine returns the first line of out, or err's text when out is empty.
+func firstLine(out string, err error) string {
+	if out == "" {
+		return err.Error()
+	}
+	line, _, _ := strings.Cut(out, "\n")
+	return line
+}
+
+// reportAutoSync prints a one-line summary of a scheduled run to stderr and,
+// with --notify, raises a desktop notification when it did not fully succeed.
+func reportAutoSync(run syncRun) {
+	fmt.Fprintf(os.Stderr, "axon: auto-sync %s: %s\n", run.Result, run.Message)
+	if flagSyncNotify && run.Result != syncResultOK {
+		if err := desktopNotify("axon auto-sync "+run.Result, run.Message); err != nil {
+			fmt.Fprintf(os.Stderr, "axon: cannot send desktop notification: %v\n", err)
+		}
+	}
+}
+
+// desktopNotify shows a desktop notification with notify-send on Linux and
+// the BSDs, or osascript on macOS. Other platforms rely on the stderr line.
+func desktopNotify(title, body string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(body), strconv.Quote(title))
+		c = exec.Command("osascript", "-e", script)
+	case "windows":
+		return nil
+	default:
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return fmt.Errorf("notify-send not found")
+		}
+		c = exec.Command("notify-send", "--app-name=axon", title, body)
+	}
+	return c.Run()
+}
+
+// runSyncStatus prints the last manual and scheduled sync runs.
+func runSyncStatus() error {
+	path, err := syncStatusPath()
+	if err != nil {
+		return err
+	}
+	s := readSyncStatus(path)
+
+	printSection("Sync status")
+	var interval time.Duration
+	if cfg, err := config.Load(); err == nil {
+		interval, _ = cfg.AutoSyncInterval()
+	}
+	if interval > 0 {
+		printInfo("", fmt.Sprintf("auto_sync: every %s", interval))
+	} else {
+		printSkip("", "auto_sync: off")
+	}
+	printSyncRun("last sync", s.LastManual)
+	printSyncRun("last auto-sync", s.LastAuto)
+	if interval > 0 && s.LastAuto != nil {
+		printInfo("", fmt.Sprintf("next auto-sync due: %s (while 'axon sync --daemon' runs)",
+			s.LastAuto.FinishedAt.Add(interval).Format("2006-01-02 15:04")))
+	}
+	return nil
+}
+
+func printSyncRun(label string, run *syncRun) {
+	if run == nil {
+		printMiss("", label+": never")
+		return
+	}
+	line := fmt.Sprintf("%s: %s at %s", label, run.Result, run.FinishedAt.Format("2006-01-02 15:04"))
+	if run.Message != "" {
+		line += " — " + firstLine(run.Message, nil)
+	}
+	switch run.Result {
+	case syncResultOK:
+		printOK("", line)
+	case syncResultSkipped:
+		printWarn("", line)
+	default:
+		fmt.Printf("  %s  %s\n", tint(iconError), line)
+	}
+	for _, r := range run.Repos {
+		if r.Result != syncResultOK {
+			printInfo(r.Repo, r.Result+": "+r.Message)
+		}
+	}
+}
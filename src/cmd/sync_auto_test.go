@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// cloneWithNewRemoteCommit gives cfg's repo an origin whose master has one
+// commit the repo has not seen yet.
+func cloneWithNewRemoteCommit(t *testing.T, cfg *config.Config, tmp string) {
+	t.Helper()
+	origin := filepath.Join(tmp, "origin.git")
+	other := filepath.Join(tmp, "other")
+	steps := [][]string{
+		{"-C", tmp, "init", "-q", "--bare", origin},
+		{"-C", cfg.RepoPath, "remote", "add", "origin", origin},
+		{"-C", cfg.RepoPath, "push", "-q", "origin", "HEAD:master"},
+		{"-C", tmp, "clone", "-q", "-b", "master", origin, other},
+		{"-C", other, "config", "user.email", "test@axon.local"},
+		{"-C", other, "config", "user.name", "Axon Test"},
+		{"-C", other, "commit", "-q", "--allow-empty", "-m", "remote change"},
+		{"-C", other, "push", "-q", "origin", "master"},
+	}
+	for _, args := range steps {
+		if err := gitRun(args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+}
+
+func TestAutoSyncBlocker(t *testing.T) {
+	cfg, tmp := initTestRepo(t)
+	primary := cfg.HubRepos()[0]
+
+	if reason := autoSyncBlocker(cfg, primary); reason != "" {
+		t.Fatalf("repo without remote blocked: %s", reason)
+	}
+
+	cloneWithNewRemoteCommit(t, cfg, tmp)
+	if reason := autoSyncBlocker(cfg, primary); reason != "" {
+		t.Fatalf("clean repo behind origin blocked: %s", reason)
+	}
+
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "local.md"), []byte("edit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if reason := autoSyncBlocker(cfg, primary); !strings.Contains(reason, "local edits") {
+		t.Fatalf("dirty repo behind origin: reason = %q, want local edits", reason)
+	}
+
+	if err := gitRun("-C", cfg.RepoPath, "add", "."); err != nil {
+		t.Fatal(err)
+	}
+	if err := gitRun("-C", cfg.RepoPath, "commit", "-q", "-m", "local"); err != nil {
+		t.Fatal(err)
+	}
+	if reason := autoSyncBlocker(cfg, primary); !strings.Contains(reason, "diverged") {
+		t.Fatalf("diverged repo: reason = %q, want diverged", reason)
+	}
+
+	if err := os.MkdirAll(filepath.Join(cfg.RepoPath, ".git", "rebase-merge"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if reason := autoSyncBlocker(cfg, primary); !strings.Contains(reason, "in progress") {
+		t.Fatalf("repo mid-rebase: reason = %q, want in progress", reason)
+	}
+}
+
+func TestSaveSyncRun_KeepsManualAndAuto(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	manual := syncRun{Trigger: syncTriggerManual, StartedAt: time.Now()}
+	manual.finish(nil)
+	saveSyncRun(manual)
+	auto := syncRun{Trigger: syncTriggerAuto, Result: syncResultSkipped, Message: "skipped repo(s): default"}
+	saveSyncRun(auto)
+
+	path, err := syncStatusPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := readSyncStatus(path)
+	if s.LastManual == nil || s.LastManual.Result != syncResultOK {
+		t.Errorf("last manual = %+v, want ok", s.LastManual)
+	}
+	if s.LastAuto == nil || s.LastAuto.Message != auto.Message {
+		t.Errorf("last auto = %+v, want %q", s.LastAuto, auto.Message)
+	}
+}
+
+func TestAutoSyncDelay(t *testing.T) {
+	now := time.Now()
+	interval := 30 * time.Minute
+	if d := autoSyncDelay(nil, interval, now); d != 0 {
+		t.Errorf("first run delay = %v, want 0", d)
+	}
+	recent := &syncRun{FinishedAt: now.Add(-10 * time.Minute)}
+	if d := autoSyncDelay(recent, interval, now); d != 20*time.Minute {
+		t.Errorf("delay after recent run = %v, want 20m", d)
+	}
+	overdue := &syncRun{FinishedAt: now.Add(-time.Hour)}
+	if d := autoSyncDelay(overdue, interval, now); d != 0 {
+		t.Errorf("overdue delay = %v, want 0", d)
+	}
+}
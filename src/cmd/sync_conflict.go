@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/hublinks"
+	"github.com/kamusis/axon-cli/internal/importer"
+)
+
+// hubConflict is one unmerged path of a stopped merge or rebase.
+type hubConflict struct {
+	Path string // slash-separated, relative to the repo
+	Code string // git status XY code, e.g. "UU"
+}
+
+// describe says in plain words what happened to the path on each side. In a
+// merge "us" is the local Hub and "them" the remote.
+func (c hubConflict) describe() string {
+	switch c.Code {
+	case "UU":
+		return "edited on both sides"
+	case "AA":
+		return "added on both sides"
+	case "UD":
+		return "edited locally, deleted on the remote"
+	case "DU":
+		return "deleted locally, edited on the remote"
+	case "AU":
+		return "added locally, conflicts with the remote"
+	case "UA":
+		return "added on the remote, conflicts with a local change"
+	case "DD":
+		return "deleted on both sides"
+	}
+	return c.Code
+}
+
+// hubOperation returns "rebase" or "merge" when one is stopped in repo, or "".
+func hubOperation(repo string) string {
+	gitDir := filepath.Join(repo, ".git")
+	for _, marker := range []string{"rebase-merge", "rebase-apply"} {
+		if _, err := fsys.Stat(filepath.Join(gitDir, marker)); err == nil {
+			return "rebase"
+		}
+	}
+	if _, err := fsys.Stat(filepath.Join(gitDir, "MERGE_HEAD")); err == nil {
+		return "merge"
+	}
+	return ""
+}
+
+// hubConflicts lists the unmerged paths in repo.
+func hubConflicts(repo string) ([]hubConflict, error) {
+	out, err := gitOutput(repo, "status", "--porcelain=v1", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("git status: %w", err)
+	}
+	var conflicts []hubConflict
+	for _, rec := range strings.Split(out, "\x00") {
+		if len(rec) < 4 {
+			continue
+		}
+		switch code := rec[:2]; code {
+		case "DD", "AU", "UD", "UA", "DU", "AA", "UU":
+			conflicts = append(conflicts, hubConflict{Path: rec[3:], Code: code})
+		}
+	}
+	return conflicts, nil
+}
+
+// printConflictSummary lists conflicts grouped by the skill they belong to.
+func printConflictSummary(repo string, conflicts []hubConflict) {
+	bySkill := make(map[string][]hubConflict)
+	var skills []string
+	for _, c := range conflicts {
+		skill := hublinks.OwningSkill(repo, c.Path)
+		if _, ok := bySkill[skill]; !ok {
+			skills = append(skills, skill)
+		}
+		bySkill[skill] = append(bySkill[skill], c)
+	}
+	sort.Strings(skills)
+
+	printSection(fmt.Sprintf("Sync conflicts (%d file(s))", len(conflicts)))
+	for _, skill := range skills {
+		printBullet(skill)
+		for _, c := range bySkill[skill] {
+			printErr(c.Path, c.describe())
+		}
+	}
+}
+
+// stopOnConflicts reports the conflicts of the stopped operation in repo and
+// returns the error that tells the user how to go on.
+func stopOnConflicts(repo string) error {
+	conflicts, err := hubConflicts(repo)
+	if err != nil {
+		return err
+	}
+	printConflictSummary(repo, conflicts)
+	return fmt.Errorf("sync stopped on %d conflict(s) in %s.\n"+
+		"   Edit the files above (remove the <<<<<<< markers, or delete a file to keep it deleted),\n"+
+		"   then run 'axon sync --continue'. Run 'axon sync --abort' to go back to where you were.",
+		len(conflicts), repo)
+}
+
+// keepBothVersions resolves a stopped merge whose conflicts are all regular
+// files changed on both sides, the way 'axon import' handles conflicts: the
+// remote version stays in place and the local one is kept next to it as
+// <name>.conflict-<host><ext>. It returns false, changing nothing, when any
+// conflict is of another kind.
+func keepBothVersions(repo string, conflicts []hubConflict) (bool, error) {
+	if len(conflicts) == 0 {
+		return false, nil
+	}
+	for _, c := range conflicts {
+		if c.Code != "UU" && c.Code != "AA" {
+			return false, nil
+		}
+		out, err := gitOutput(repo, "ls-files", "-u", "--", c.Path)
+		if err != nil {
+			return false, fmt.Errorf("git ls-files: %w", err)
+		}
+		stages := 0
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			mode, _, _ := strings.Cut(line, " ")
+			if mode != "100644" && mode != "100755" {
+				return false, nil
+			}
+			stages++
+		}
+		if stages < 2 {
+			return false, nil
+		}
+	}
+
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "local"
+	}
+	for _, c := range conflicts {
+		local, err := gitOutput(repo, "show", ":2:"+c.Path)
+		if err != nil {
+			return false, fmt.Errorf("git show %s: %w", c.Path, err)
+		}
+		kept := importer.ConflictPath(c.Path, host)
+		if err := fsys.WriteFile(filepath.Join(repo, filepath.FromSlash(kept)), []byte(local), 0o644); err != nil {
+			return false, err
+		}
+		if out, err := gitOutput(repo, "checkout", "--theirs", "--", c.Path); err != nil {
+			return false, fmt.Errorf("git checkout %s: %s", c.Path, strings.TrimSpace(out))
+		}
+		if out, err := gitOutput(repo, "add", "--", c.Path, kept); err != nil {
+			return false, fmt.Errorf("git add %s: %s", c.Path, strings.TrimSpace(out))
+		}
+		printWarn(c.Path, "kept the remote version; your version is in "+kept)
+	}
+	if out, err := gitOutput(repo, "commit", "--no-edit"); err != nil {
+		return false, fmt.Errorf("git commit: %s", strings.TrimSpace(out))
+	}
+	return true, nil
+}
+
+// runSyncResolve handles 'axon sync --continue' and 'axon sync --abort' for
+// every selected repo with a stopped merge or rebase.
+func runSyncResolve(cfg *config.Config) error {
+	repos, err := selectSyncRepos(cfg)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, r := range repos {
+		rc := repoConfig(cfg, r)
+		op := hubOperation(rc.RepoPath)
+		if op == "" {
+			continue
+		}
+		found = true
+		if len(repos) > 1 {
+			printSection(fmt.Sprintf("Sync [%s] %s", r.Name, r.Path))
+		}
+		if flagSyncAbort {
+			printInfo("", fmt.Sprintf("git %s --abort", op))
+			if err := gitRun("-C", rc.RepoPath, op, "--abort"); err != nil {
+				return fmt.Errorf("git %s --abort failed: %w", op, err)
+			}
+			printOK("", "Sync aborted; the Hub is back to where it was before the pull.")
+			continue
+		}
+		before := hubHeadSHA(rc.RepoPath)
+		err := syncContinue(rc, op)
+		name := ""
+		if len(repos) > 1 {
+			name = r.Name
+		}
+		recordSync(rc, before, name)
+		if err != nil {
+			return err
+		}
+	}
+	if !found {
+		return fmt.Errorf("no stopped sync to continue or abort")
+	}
+	refreshMergedViews(cfg)
+	refreshRenderedTargets(cfg)
+	return nil
+}
+
+// syncContinue stages the files the user resolved, finishes the stopped op
+// ("merge" or "rebase"), and pushes the result in read-write mode. Files
+// still holding conflict markers stop it again.
+func syncContinue(cfg *config.Config, op string) error {
+	repo := cfg.RepoPath
+	conflicts, err := hubConflicts(repo)
+	if err != nil {
+		return err
+	}
+	var unresolved []hubConflict
+	for _, c := range conflicts {
+		b, err := fsys.ReadFile(filepath.Join(repo, filepath.FromSlash(c.Path)))
+		switch {
+		case os.IsNotExist(err):
+			if out, err := gitOutput(repo, "rm", "-q", "--", c.Path); err != nil {
+				return fmt.Errorf("git rm %s: %s", c.Path, strings.TrimSpace(out))
+			}
+		case err != nil:
+			return err
+		case bytes.Contains(b, []byte("<<<<<<<")) || bytes.Contains(b, []byte(">>>>>>>")):
+			unresolved = append(unresolved, c)
+		default:
+			if out, err := gitOutput(repo, "add", "--", c.Path); err != nil {
+				return fmt.Errorf("git add %s: %s", c.Path, strings.TrimSpace(out))
+			}
+		}
+	}
+	if len(unresolved) > 0 {
+		printConflictSummary(repo, unresolved)
+		return fmt.Errorf("%d file(s) still contain conflict markers; fix them and run 'axon sync --continue' again", len(unresolved))
+	}
+
+	if op == "rebase" {
+		printInfo("", "git rebase --continue")
+		if err := gitRun("-C", repo, "-c", "core.editor=true", "rebase", "--continue"); err != nil {
+			if hubOperation(repo) == "rebase" {
+				return stopOnConflicts(repo)
+			}
+			return fmt.Errorf("git rebase --continue failed: %w", err)
+		}
+	} else {
+		printInfo("", "git commit --no-edit")
+		if err := gitRun("-C", repo, "commit", "--no-edit"); err != nil {
+			return fmt.Errorf("git commit failed: %w", err)
+		}
+	}
+	warnSignatureFailures(cfg)
+
+	if cfg.SyncMode == "read-only" || !gitHasRemote(repo) {
+		printOK("", "Conflicts resolved.")
+		return nil
+	}
+	if err := pushHub(repo); err != nil {
+		return err
+	}
+	printOK("", "Conflicts resolved and pushed.")
+	return nil
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// divergeSkillFile gives cfg's repo and its new origin conflicting commits
+// for skills/demo/SKILL.md: remote is what another machine pushes and local
+// is committed here. An empty remote deletes the file on the remote.
+func divergeSkillFile(t *testing.T, cfg *config.Config, tmp, local, remote string) {
+	t.Helper()
+	origin := filepath.Join(tmp, "origin.git")
+	other := filepath.Join(tmp, "other")
+	rel := filepath.Join("skills", "demo", "SKILL.md")
+	write := func(repo, content string) {
+		t.Helper()
+		p := filepath.Join(repo, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run := func(args ...string) {
+		t.Helper()
+		if err := gitRun(args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+
+	write(cfg.RepoPath, "base\n")
+	run("-C", cfg.RepoPath, "add", ".")
+	run("-C", cfg.RepoPath, "commit", "-q", "-m", "add demo")
+	run("-C", tmp, "init", "-q", "--bare", origin)
+	run("-C", cfg.RepoPath, "remote", "add", "origin", origin)
+	run("-C", cfg.RepoPath, "push", "-q", "origin", "HEAD:master")
+	run("-C", tmp, "clone", "-q", "-b", "master", origin, other)
+	run("-C", other, "config", "user.email", "test@axon.local")
+	run("-C", other, "config", "user.name", "Axon Test")
+	if remote == "" {
+		run("-C", other, "rm", "-q", rel)
+	} else {
+		write(other, remote)
+		run("-C", other, "add", ".")
+	}
+	run("-C", other, "commit", "-q", "-m", "remote change")
+	run("-C", other, "push", "-q", "origin", "master")
+
+	write(cfg.RepoPath, local)
+}
+
+func TestSyncConflict_StopsAndContinues(t *testing.T) {
+	cfg, tmp := initTestRepo(t)
+	divergeSkillFile(t, cfg, tmp, "local edit\n", "")
+
+	err := syncReadWrite(cfg)
+	if err == nil || !strings.Contains(err.Error(), "axon sync --continue") {
+		t.Fatalf("syncReadWrite = %v, want a stop on conflicts", err)
+	}
+	if op := hubOperation(cfg.RepoPath); op != "merge" {
+		t.Fatalf("hubOperation = %q, want merge", op)
+	}
+	conflicts, err := hubConflicts(cfg.RepoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Path != "skills/demo/SKILL.md" || conflicts[0].Code != "UD" {
+		t.Fatalf("conflicts = %+v, want skills/demo/SKILL.md UD", conflicts)
+	}
+
+	if err := syncContinue(cfg, "merge"); err != nil {
+		t.Fatalf("syncContinue: %v", err)
+	}
+	if op := hubOperation(cfg.RepoPath); op != "" {
+		t.Fatalf("hubOperation after continue = %q", op)
+	}
+	remote, _ := gitOutput(filepath.Join(tmp, "origin.git"), "rev-parse", "master")
+	if hubHeadSHA(cfg.RepoPath) != strings.TrimSpace(remote) {
+		t.Error("the merge should be pushed after --continue")
+	}
+}
+
+func TestSyncContinue_RefusesConflictMarkers(t *testing.T) {
+	cfg, tmp := initTestRepo(t)
+	divergeSkillFile(t, cfg, tmp, "local edit\n", "")
+	_ = syncReadWrite(cfg)
+
+	marked := "<<<<<<< HEAD\nlocal edit\n=======\n>>>>>>> origin/master\n"
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "skills", "demo", "SKILL.md"), []byte(marked), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := syncContinue(cfg, "merge")
+	if err == nil || !strings.Contains(err.Error(), "conflict markers") {
+		t.Fatalf("syncContinue = %v, want refused on conflict markers", err)
+	}
+	if op := hubOperation(cfg.RepoPath); op != "merge" {
+		t.Errorf("merge should stay in progress, got %q", op)
+	}
+}
+
+func TestSyncKeepBoth(t *testing.T) {
+	cfg, tmp := initTestRepo(t)
+	divergeSkillFile(t, cfg, tmp, "local edit\n", "remote edit\n")
+	flagSyncKeepBoth = true
+	t.Cleanup(func() { flagSyncKeepBoth = false })
+
+	if err := syncReadWrite(cfg); err != nil {
+		t.Fatalf("syncReadWrite --keep-both: %v", err)
+	}
+	dir := filepath.Join(cfg.RepoPath, "skills", "demo")
+	if b, _ := os.ReadFile(filepath.Join(dir, "SKILL.md")); string(b) != "remote edit\n" {
+		t.Errorf("SKILL.md = %q, want the remote version", b)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "SKILL.conflict-*.md"))
+	if len(matches) != 1 {
+		t.Fatalf("conflict copies = %v, want one", matches)
+	}
+	if b, _ := os.ReadFile(matches[0]); string(b) != "local edit\n" {
+		t.Errorf("%s = %q, want the local version", matches[0], b)
+	}
+	if dirty, _ := gitIsDirty(cfg.RepoPath); dirty {
+		t.Error("keep-both should leave a clean, committed Hub")
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// largeFile is a new or changed Hub file over max_file_size.
+type largeFile struct {
+	Path string // slash-separated, relative to the repo
+	Size int64
+}
+
+// largeHubFiles returns the new or modified files in repo bigger than
+// limit, largest first. Excluded and ignored files are not considered;
+// limit 0 disables the check.
+func largeHubFiles(repo string, limit int64) ([]largeFile, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	changed, err := changedHubFiles(repo)
+	if err != nil {
+		return nil, err
+	}
+	var files []largeFile
+	for _, rel := range changed {
+		info, err := fsys.Lstat(filepath.Join(repo, filepath.FromSlash(rel)))
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if info.Size() > limit {
+			files = append(files, largeFile{Path: rel, Size: info.Size()})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Size > files[j].Size })
+	return files, nil
+}
+
+// changedHubFiles returns the slash-separated paths of new and modified
+// files in repo that 'git add .' would stage, i.e. not excluded or ignored.
+// Deleted files are included; callers stat the paths they need.
+func changedHubFiles(repo string) ([]string, error) {
+	out, err := gitOutput(repo, "status", "--porcelain=v1", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, fmt.Errorf("git status: %w", err)
+	}
+	var paths []string
+	fields := strings.Split(out, "\x00")
+	for i := 0; i < len(fields); i++ {
+		entry := fields[i]
+		if len(entry) < 4 {
+			continue
+		}
+		status, rel := entry[:2], entry[3:]
+		if status[0] == 'R' || status[0] == 'C' {
+			i++ // the next field is the original path
+		}
+		paths = append(paths, rel)
+	}
+	return paths, nil
+}
+
+// unstageLargeFiles removes files from the index after 'git add .', so the
+// sync commit leaves them out, and tells the user what happened.
+func unstageLargeFiles(repo string, files []largeFile, limit int64) error {
+	args := []string{"reset", "-q", "--"}
+	for _, f := range files {
+		args = append(args, f.Path)
+	}
+	if out, err := gitOutput(repo, args...); err != nil {
+		return fmt.Errorf("cannot leave large files out of the commit: %w\n%s", err, strings.TrimSpace(out))
+	}
+	printWarn("", fmt.Sprintf("%d file(s) larger than max_file_size (%s) left out of the commit:", len(files), humanBytes(limit)))
+	for _, f := range files {
+		printWarn(f.Path, humanBytes(f.Size))
+	}
+	outf("   Add them to 'excludes:' in axon.yaml, or re-run with --allow-large to commit them.\n")
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/secrets"
+)
+
+// maxSecretFindings caps how many suspected secrets sync lists.
+const maxSecretFindings = 20
+
+// scanHubSecrets scans the new and modified files in repo for credentials.
+func scanHubSecrets(cfg *config.Config, repo string) ([]secrets.Finding, error) {
+	changed, err := changedHubFiles(repo)
+	if err != nil {
+		return nil, err
+	}
+	var out []secrets.Finding
+	for _, rel := range changed {
+		found, err := secrets.ScanFile(filepath.Join(repo, filepath.FromSlash(rel)), rel, secrets.Allowlist(cfg.Secrets.Allow))
+		if err != nil {
+			continue // deleted, or unreadable: git add reports the latter
+		}
+		out = append(out, found...)
+	}
+	return out, nil
+}
+
+// checkHubSecrets runs the secrets scan before sync stages anything. In
+// block mode (the default) a finding stops the sync before any commit.
+func checkHubSecrets(cfg *config.Config, repo string) error {
+	mode := cfg.SecretsMode()
+	if mode == config.SecretsModeOff {
+		return nil
+	}
+	findings, err := scanHubSecrets(cfg, repo)
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+
+	report := printWarn
+	if mode == config.SecretsModeBlock {
+		report = printErr
+	}
+	outf("\n")
+	report("", fmt.Sprintf("%d possible secret(s) in new or changed Hub files:", len(findings)))
+	for i, f := range findings {
+		if i == maxSecretFindings {
+			printInfo("", fmt.Sprintf("… and %d more", len(findings)-i))
+			break
+		}
+		report(fmt.Sprintf("%s:%d", f.Path, f.Line), fmt.Sprintf("%s (%s)", f.Description, f.Match))
+	}
+	if mode == config.SecretsModeWarn {
+		outf("   Committing anyway (secrets.mode: warn).\n")
+		return nil
+	}
+	return fmt.Errorf("sync stopped before committing possible secrets.\n"+
+		"   Remove them from the Hub, or if they are placeholders, add '%s' to the line\n"+
+		"   or an entry under 'secrets: allow:' in axon.yaml (a path glob, a directory/, or rule:<id>).", secrets.AllowMarker)
+}
@@ -128,6 +128,134 @@ func TestSyncReadWrite_NoRemote(t *testing.T) {
 	}
 }
 
+func TestSyncReadWrite_LeavesOutLargeFiles(t *testing.T) {
+	cfg, _ := initTestRepo(t)
+	cfg.MaxFileSize = "1KB"
+
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "skill.md"), []byte("skill\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(cfg.RepoPath, "skills", "demo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	big := filepath.Join(cfg.RepoPath, "skills", "demo", "weights.bin")
+	if err := os.WriteFile(big, make([]byte, 4096), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	large, err := largeHubFiles(cfg.RepoPath, cfg.MaxFileBytes())
+	if err != nil || len(large) != 1 || large[0].Path != "skills/demo/weights.bin" {
+		t.Fatalf("largeHubFiles = %+v, %v; want weights.bin only", large, err)
+	}
+
+	if err := syncReadWrite(cfg); err != nil {
+		t.Fatalf("syncReadWrite: %v", err)
+	}
+	if tracked, _ := gitOutput(cfg.RepoPath, "ls-files", "skill.md"); strings.TrimSpace(tracked) == "" {
+		t.Error("skill.md should be committed")
+	}
+	if tracked, _ := gitOutput(cfg.RepoPath, "ls-files", "skills/demo/weights.bin"); strings.TrimSpace(tracked) != "" {
+		t.Error("weights.bin is over max_file_size and should not be committed")
+	}
+
+	flagSyncAllowLarge = true
+	t.Cleanup(func() { flagSyncAllowLarge = false })
+	if err := syncReadWrite(cfg); err != nil {
+		t.Fatalf("syncReadWrite --allow-large: %v", err)
+	}
+	if tracked, _ := gitOutput(cfg.RepoPath, "ls-files", "skills/demo/weights.bin"); strings.TrimSpace(tracked) == "" {
+		t.Error("--allow-large should commit weights.bin")
+	}
+}
+
+func TestSyncReadWrite_BlocksSecrets(t *testing.T) {
+	cfg, _ := initTestRepo(t)
+	key := "OPENAI_API_KEY=sk-proj-" + strings.Repeat("a1B2", 10) + "\n"
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "skill.md"), []byte(key), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	head := hubHeadSHA(cfg.RepoPath)
+
+	err := syncReadWrite(cfg)
+	if err == nil || !strings.Contains(err.Error(), "possible secrets") {
+		t.Fatalf("syncReadWrite = %v, want blocked by the secrets scan", err)
+	}
+	if hubHeadSHA(cfg.RepoPath) != head {
+		t.Fatal("a commit was made despite the secret")
+	}
+
+	cfg.Secrets.Allow = []string{"skill.md"}
+	if err := syncReadWrite(cfg); err != nil {
+		t.Fatalf("syncReadWrite with allowlist: %v", err)
+	}
+	if tracked, _ := gitOutput(cfg.RepoPath, "ls-files", "skill.md"); strings.TrimSpace(tracked) == "" {
+		t.Error("allowlisted skill.md should be committed")
+	}
+}
+
+func TestSyncPullOnly_KeepsLocalEditsUncommitted(t *testing.T) {
+	cfg, tmp := initTestRepo(t)
+	cloneWithNewRemoteCommit(t, cfg, tmp)
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "draft.md"), []byte("wip\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := syncPullOnly(cfg); err != nil {
+		t.Fatalf("syncPullOnly: %v", err)
+	}
+	remote, _ := gitOutput(cfg.RepoPath, "rev-parse", "origin/master")
+	if hubHeadSHA(cfg.RepoPath) != strings.TrimSpace(remote) {
+		t.Error("HEAD should match origin/master after a pull-only sync")
+	}
+	if tracked, _ := gitOutput(cfg.RepoPath, "ls-files", "draft.md"); strings.TrimSpace(tracked) != "" {
+		t.Error("draft.md should not be committed by a pull-only sync")
+	}
+	if _, err := os.Stat(filepath.Join(cfg.RepoPath, "draft.md")); err != nil {
+		t.Errorf("draft.md should survive the pull: %v", err)
+	}
+}
+
+func TestSyncPushOnly(t *testing.T) {
+	cfg, tmp := initTestRepo(t)
+	origin := filepath.Join(tmp, "origin.git")
+	for _, args := range [][]string{
+		{"-C", tmp, "init", "-q", "--bare", origin},
+		{"-C", cfg.RepoPath, "remote", "add", "origin", origin},
+		{"-C", cfg.RepoPath, "push", "-q", "origin", "HEAD:master"},
+	} {
+		if err := gitRun(args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "new.md"), []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := syncPushOnly(cfg); err != nil {
+		t.Fatalf("syncPushOnly: %v", err)
+	}
+	remote, _ := gitOutput(origin, "rev-parse", "master")
+	if hubHeadSHA(cfg.RepoPath) != strings.TrimSpace(remote) {
+		t.Error("origin/master should match HEAD after a push-only sync")
+	}
+}
+
+func TestSyncPushOnly_RejectedWhenBehind(t *testing.T) {
+	cfg, tmp := initTestRepo(t)
+	cloneWithNewRemoteCommit(t, cfg, tmp)
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "new.md"), []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := syncPushOnly(cfg)
+	if err == nil || !strings.Contains(err.Error(), "push rejected") {
+		t.Fatalf("syncPushOnly = %v, want push rejected", err)
+	}
+	if log, _ := gitOutput(cfg.RepoPath, "log", "--format=%s"); strings.Contains(log, "remote change") {
+		t.Error("push-only must not pull the remote commit")
+	}
+}
+
 func TestGitHasRemote(t *testing.T) {
 	cfg, _ := initTestRepo(t)
 	// Fresh local repo should have no remote.
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"runtime"
+
+	"github.com/kamusis/axon-cli/internal/system"
+)
+
+// The link, unlink, and sync code reaches the filesystem, the clock, and
+// git only through these, so tests can replace them with the fakes of
+// package system. hostOS stands in for runtime.GOOS where behavior differs
+// on Windows.
+var (
+	fsys   system.FS     = system.OSFS{}
+	clock  system.Clock  = system.RealClock{}
+	runner system.Runner = system.ExecRunner{}
+	hostOS               = runtime.GOOS
+)
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/system"
+)
+
+// restoreSystem puts the real filesystem, clock, runner, and OS name back
+// when the test ends, so the test can replace them.
+func restoreSystem(t *testing.T) {
+	t.Helper()
+	oldFS, oldClock, oldRunner, oldOS := fsys, clock, runner, hostOS
+	t.Cleanup(func() { fsys, clock, runner, hostOS = oldFS, oldClock, oldRunner, oldOS })
+}
+
+func TestLinkTarget_WindowsSymlinkPrivilege(t *testing.T) {
+	cfg, _ := setupLinkTest(t)
+	if err := os.MkdirAll(filepath.Dir(cfg.Targets[0].Destination), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	restoreSystem(t)
+	hostOS = "windows"
+	fsys = system.FaultFS{FS: system.OSFS{}, Fault: func(op, _ string) error {
+		if op == "Symlink" {
+			return syscall.Errno(1314) // ERROR_PRIVILEGE_NOT_HELD
+		}
+		return nil
+	}}
+
+	state, detail, _ := linkTarget(cfg, cfg.Targets[0], linkOptions{})
+	if state != "error" || !strings.Contains(detail, "symlink failed on Windows — run 'axon doctor'") {
+		t.Errorf("linkTarget = %s, %q; want the Windows remediation", state, detail)
+	}
+}
+
+func TestMoveAside_WindowsCrossDevice(t *testing.T) {
+	tmp := t.TempDir()
+	src, dst := filepath.Join(tmp, "src"), filepath.Join(tmp, "dst")
+	if err := os.MkdirAll(src, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "a.md"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	restoreSystem(t)
+	hostOS = "windows"
+	fsys = system.FaultFS{FS: system.OSFS{}, Fault: func(op, _ string) error {
+		if op == "Rename" {
+			return &os.LinkError{Op: "rename", Old: src, New: dst, Err: syscall.Errno(17)} // ERROR_NOT_SAME_DEVICE
+		}
+		return nil
+	}}
+
+	if err := moveAside(src, dst); err != nil {
+		t.Fatalf("moveAside: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "a.md")); err != nil {
+		t.Errorf("the directory was not copied: %v", err)
+	}
+	if _, err := os.Stat(src); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("the original should be removed, stat = %v", err)
+	}
+}
+
+func TestBackupDir_UsesClock(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	restoreSystem(t)
+	clock = system.NewFakeClock(time.Date(2026, 3, 4, 5, 6, 7, 0, time.Local))
+
+	dir, err := backupDir(nil, "claude-code-skills")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "claude-code-skills_" + clock.Now().Format(backupLayout); filepath.Base(dir) != want {
+		t.Errorf("backupDir = %s, want %s", filepath.Base(dir), want)
+	}
+}
+
+func TestSyncGit_FakeRunner(t *testing.T) {
+	restoreSystem(t)
+	fake := &system.FakeRunner{Handle: func(args []string) (string, error) {
+		if strings.Contains(strings.Join(args, " "), "status --porcelain") {
+			return " M skills/demo/SKILL.md\n", nil
+		}
+		return "", nil
+	}}
+	runner = fake
+
+	dirty, err := gitIsDirty("/hub")
+	if err != nil || !dirty {
+		t.Errorf("gitIsDirty = %v, %v; want true from the fake status", dirty, err)
+	}
+	if err := pushHubInitial("/hub"); err != nil {
+		t.Fatal(err)
+	}
+	calls := fake.Calls()
+	if len(calls) < 2 || calls[0] != "git -C /hub status --porcelain" || !strings.HasSuffix(calls[len(calls)-1], "-C /hub push -u origin master") {
+		t.Errorf("git calls = %q", calls)
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// selectTargets resolves the target argument of link and unlink: none or
+// "all" selects every target, "@group" the targets in that group, anything
+// else a single target by name. exclude removes target names or @groups
+// from the first two forms. Targets are returned sorted by name; single
+// reports the by-name form.
+func selectTargets(cfg *config.Config, args, exclude []string) (targets []config.Target, single bool, err error) {
+	arg := "all"
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	switch {
+	case arg == "all":
+		targets = append(targets, cfg.Targets...)
+	case strings.HasPrefix(arg, "@"):
+		if targets, err = groupTargets(cfg, arg); err != nil {
+			return nil, false, err
+		}
+	default:
+		if len(exclude) > 0 {
+			return nil, false, fmt.Errorf("--exclude only applies to 'all' or an @group")
+		}
+		t, ok := findTarget(cfg, arg)
+		if !ok {
+			return nil, false, fmt.Errorf("target %q not found in axon.yaml", arg)
+		}
+		return []config.Target{t}, true, nil
+	}
+
+	skip := make(map[string]bool)
+	for _, e := range exclude {
+		if strings.HasPrefix(e, "@") {
+			ts, err := groupTargets(cfg, e)
+			if err != nil {
+				return nil, false, fmt.Errorf("--exclude: %w", err)
+			}
+			for _, t := range ts {
+				skip[t.Name] = true
+			}
+			continue
+		}
+		if _, ok := findTarget(cfg, e); !ok {
+			return nil, false, fmt.Errorf("--exclude: target %q not found in axon.yaml", e)
+		}
+		skip[e] = true
+	}
+	kept := targets[:0]
+	for _, t := range targets {
+		if !skip[t.Name] {
+			kept = append(kept, t)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Name < kept[j].Name })
+	return kept, false, nil
+}
+
+// groupTargets returns the targets whose group is ref without its "@".
+func groupTargets(cfg *config.Config, ref string) ([]config.Target, error) {
+	name := strings.TrimPrefix(ref, "@")
+	var out []config.Target
+	groups := make(map[string]bool)
+	for _, t := range cfg.Targets {
+		if t.Group == name {
+			out = append(out, t)
+		}
+		if t.Group != "" {
+			groups["@"+t.Group] = true
+		}
+	}
+	if len(out) > 0 {
+		return out, nil
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("group %q not found: no target in axon.yaml sets 'group'", ref)
+	}
+	known := make([]string, 0, len(groups))
+	for g := range groups {
+		known = append(known, g)
+	}
+	sort.Strings(known)
+	return nil, fmt.Errorf("group %q not found; groups: %s", ref, strings.Join(known, ", "))
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+func TestSelectTargets(t *testing.T) {
+	cfg := &config.Config{Targets: []config.Target{
+		{Name: "idea-skills", Group: "jetbrains"},
+		{Name: "goland-skills", Group: "jetbrains"},
+		{Name: "claude-code-skills", Group: "terminal-agents"},
+		{Name: "cursor-skills"},
+	}}
+	names := func(ts []config.Target) string {
+		var out []string
+		for _, t := range ts {
+			out = append(out, t.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	cases := []struct {
+		args, exclude []string
+		want          string
+	}{
+		{nil, nil, "claude-code-skills,cursor-skills,goland-skills,idea-skills"},
+		{[]string{"@jetbrains"}, nil, "goland-skills,idea-skills"},
+		{[]string{"all"}, []string{"@jetbrains", "cursor-skills"}, "claude-code-skills"},
+		{[]string{"@jetbrains"}, []string{"idea-skills"}, "goland-skills"},
+		{[]string{"cursor-skills"}, nil, "cursor-skills"},
+	}
+	for _, c := range cases {
+		got, _, err := selectTargets(cfg, c.args, c.exclude)
+		if err != nil {
+			t.Errorf("selectTargets(%v, --exclude %v): %v", c.args, c.exclude, err)
+			continue
+		}
+		if names(got) != c.want {
+			t.Errorf("selectTargets(%v, --exclude %v) = %s, want %s", c.args, c.exclude, names(got), c.want)
+		}
+	}
+
+	for _, c := range []struct{ args, exclude []string }{
+		{[]string{"@ides"}, nil},
+		{[]string{"all"}, []string{"@ides"}},
+		{[]string{"all"}, []string{"typo-skills"}},
+		{[]string{"cursor-skills"}, []string{"idea-skills"}},
+	} {
+		if _, _, err := selectTargets(cfg, c.args, c.exclude); err == nil {
+			t.Errorf("selectTargets(%v, --exclude %v) should fail", c.args, c.exclude)
+		}
+	}
+	if _, _, err := selectTargets(cfg, []string{"@ides"}, nil); err == nil || !strings.Contains(err.Error(), "@jetbrains, @terminal-agents") {
+		t.Errorf("unknown group error should list the groups: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/trash"
+	"github.com/spf13/cobra"
+)
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List, restore, or empty items removed by gc and doctor --fix",
+	Long: `'axon gc' and 'axon doctor --fix' move what they remove, such as old
+backups and conflict files, to ~/.axon/trash instead of deleting it. Items
+stay there for trash_retention (default 30d, "0" keeps them until emptied);
+'axon gc' deletes the expired ones.
+
+Examples:
+  axon trash list
+  axon trash restore 20260301-142005-a1b2c3
+  axon trash restore 20260301-142005 --to ~/notes.md
+  axon trash empty --expired`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var trashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List trashed items",
+	Args:  cobra.NoArgs,
+	RunE:  runTrashList,
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <id>...",
+	Short: "Move trashed items back where they were",
+	Long: `Move trashed items back to where they were removed from. An ID may be
+shortened to any unique prefix. Nothing is overwritten: if something exists
+at the original path, restore it elsewhere with --to.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runTrashRestore,
+}
+
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Delete trashed items for good",
+	Args:  cobra.NoArgs,
+	RunE:  runTrashEmpty,
+}
+
+var (
+	flagTrashRestoreTo string
+	flagTrashExpired   bool
+	flagTrashYes       bool
+)
+
+func init() {
+	trashRestoreCmd.Flags().StringVar(&flagTrashRestoreTo, "to", "", "Restore a single item to this path instead")
+	trashEmptyCmd.Flags().BoolVar(&flagTrashExpired, "expired", false, "Only delete items older than trash_retention")
+	trashEmptyCmd.Flags().BoolVarP(&flagTrashYes, "yes", "y", false, "Do not ask for confirmation")
+	trashCmd.AddCommand(trashListCmd, trashRestoreCmd, trashEmptyCmd)
+	rootCmd.AddCommand(trashCmd)
+}
+
+// moveToTrash moves path to ~/.axon/trash, noting reason.
+func moveToTrash(path, reason string) error {
+	root, err := trash.Dir()
+	if err != nil {
+		return err
+	}
+	_, err = trash.Move(root, path, reason)
+	return err
+}
+
+func loadTrash() ([]trash.Entry, error) {
+	root, err := trash.Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := trash.List(root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the trash: %w", err)
+	}
+	return entries, nil
+}
+
+// trashRetention is trash_retention from axon.yaml, or the default when the
+// config does not load.
+func trashRetention() time.Duration {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.DefaultTrashRetention
+	}
+	return cfg.TrashRetentionPeriod()
+}
+
+func runTrashList(_ *cobra.Command, _ []string) error {
+	entries, err := loadTrash()
+	if err != nil {
+		return err
+	}
+	printSection("Trash")
+	if len(entries) == 0 {
+		printSkip("", "the trash is empty")
+		return nil
+	}
+	retention := trashRetention()
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+		expires := "kept until emptied"
+		if retention > 0 {
+			expires = "expires " + e.Time.Add(retention).Format("2006-01-02")
+		}
+		printListItem(iconItem, e.ID)
+		fmt.Printf("      %s\n", e.Path)
+		fmt.Printf("      %s, %s, %s\n", e.Reason, humanBytes(e.Size), expires)
+	}
+	fmt.Printf("\n  %d item(s), %s\n", len(entries), humanBytes(total))
+	return nil
+}
+
+func runTrashRestore(_ *cobra.Command, args []string) error {
+	if flagTrashRestoreTo != "" && len(args) > 1 {
+		return fmt.Errorf("--to restores a single item")
+	}
+	entries, err := loadTrash()
+	if err != nil {
+		return err
+	}
+	var picked []trash.Entry
+	for _, ref := range args {
+		e, err := trash.Find(entries, ref)
+		if err != nil {
+			return err
+		}
+		picked = append(picked, e)
+	}
+	for _, e := range picked {
+		to, err := trash.Restore(e, flagTrashRestoreTo)
+		if err != nil {
+			return err
+		}
+		printOK(e.ID, "restored to "+to)
+	}
+	return nil
+}
+
+func runTrashEmpty(_ *cobra.Command, _ []string) error {
+	entries, err := loadTrash()
+	if err != nil {
+		return err
+	}
+	if flagTrashExpired {
+		entries = trash.Expired(entries, trashRetention(), time.Now())
+	}
+	if len(entries) == 0 {
+		printSkip("", "nothing to delete")
+		return nil
+	}
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+	if !flagTrashYes && !confirm(os.Stdin, fmt.Sprintf("Delete %d trashed item(s) (%s) for good?", len(entries), humanBytes(total))) {
+		printSkip("", "kept (not confirmed)")
+		return nil
+	}
+	for _, e := range entries {
+		if err := trash.Delete(e); err != nil {
+			return fmt.Errorf("cannot delete trash entry %s: %w", e.ID, err)
+		}
+	}
+	printOK("", fmt.Sprintf("%d item(s) deleted, %s reclaimed", len(entries), humanBytes(total)))
+	return nil
+}
@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/hash"
+	"github.com/kamusis/axon-cli/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the most recent link, unlink, import, or sync",
+	Long: `Revert the most recent undoable operation recorded in ~/.axon/history.log.
+
+  link     remove the created symlinks; restore the backup or previous symlink
+  unlink   move restored backups aside again and re-create the symlinks
+  import   remove the files and directories the import created in the Hub,
+           keeping files edited since the import
+  sync     reset the Hub to the commit before the sync
+           (read-write: git reset --soft, so changes stay staged;
+            read-only:  git reset --hard, refused if the Hub has local edits)
+
+Every target touched by the same command invocation is reverted together.
+Each step is checked against the current state first; anything that changed
+since the operation (e.g. a symlink re-pointed by hand) is refused.
+
+vendor sync and update are journaled but not undoable here; use
+'axon update --rollback' for the binary.
+
+Examples:
+  axon undo --dry-run
+  axon undo
+  axon undo --yes`,
+	Args: cobra.NoArgs,
+	RunE: runUndo,
+}
+
+var (
+	flagUndoDryRun bool
+	flagUndoYes    bool
+)
+
+func init() {
+	undoCmd.Flags().BoolVar(&flagUndoDryRun, "dry-run", false, "Show what would be reverted without changing anything")
+	undoCmd.Flags().BoolVarP(&flagUndoYes, "yes", "y", false, "Do not ask for confirmation")
+	rootCmd.AddCommand(undoCmd)
+}
+
+// undoableOps lists the journal operations 'axon undo' knows how to revert.
+var undoableOps = map[string]bool{
+	journal.OpLink:   true,
+	journal.OpUnlink: true,
+	journal.OpImport: true,
+	journal.OpSync:   true,
+}
+
+// undoStep is the planned reversal of one journal entry.
+type undoStep struct {
+	entry   journal.Entry
+	actions []string // preview lines shown before confirmation
+	apply   func() error
+}
+
+func runUndo(cmd *cobra.Command, _ []string) error {
+	path, err := journal.Path()
+	if err != nil {
+		return err
+	}
+	entries, err := journal.Read(path, time.Time{})
+	if err != nil {
+		return fmt.Errorf("cannot read history: %w", err)
+	}
+
+	title := "Undo"
+	if flagUndoDryRun {
+		title += " (dry run)"
+	}
+	printSection(title)
+
+	group := latestUndoable(entries)
+	if len(group) == 0 {
+		printSkip("", "nothing to undo")
+		return nil
+	}
+
+	var steps []undoStep
+	for _, e := range group {
+		s, err := planUndo(e)
+		if err != nil {
+			return fmt.Errorf("cannot undo %s [%s]: %w", e.Op, e.Target, err)
+		}
+		steps = append(steps, s)
+	}
+
+	printInfo("", fmt.Sprintf("reverting '%s' from %s", group[0].Op, group[0].Time.Local().Format("2006-01-02 15:04:05")))
+	for _, s := range steps {
+		for _, a := range s.actions {
+			printInfo(s.entry.Target, a)
+		}
+	}
+
+	if flagUndoDryRun {
+		return nil
+	}
+	if !flagUndoYes && !confirm(cmd.InOrStdin(), "Proceed?") {
+		printSkip("", "aborted, nothing changed")
+		return nil
+	}
+
+	failed := 0
+	for _, s := range steps {
+		if err := s.apply(); err != nil {
+			printErr(s.entry.Target, err.Error())
+			failed++
+			continue
+		}
+		recordHistory(journal.Entry{
+			Op:     journal.OpUndo,
+			Target: s.entry.Target,
+			Detail: fmt.Sprintf("reverted %s from %s", s.entry.Op, s.entry.Time.Local().Format("2006-01-02 15:04:05")),
+			Data:   map[string]string{"undone": s.entry.Key()},
+		})
+		printOK(s.entry.Target, "reverted "+s.entry.Op)
+	}
+	if failed > 0 {
+		return fmt.Errorf("undo failed for %d of %d step(s)", failed, len(steps))
+	}
+	return nil
+}
+
+// latestUndoable returns the not-yet-undone entries of the most recent
+// undoable operation, newest first. Entries written by the same invocation
+// (same Run and Op) are grouped so a multi-target link is reverted at once.
+func latestUndoable(entries []journal.Entry) []journal.Entry {
+	undone := make(map[string]bool)
+	for _, e := range entries {
+		if e.Op == journal.OpUndo && e.Data["undone"] != "" {
+			undone[e.Data["undone"]] = true
+		}
+	}
+
+	var group []journal.Entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if !undoableOps[e.Op] || undone[e.Key()] {
+			continue
+		}
+		if len(group) == 0 {
+			group = append(group, e)
+			continue
+		}
+		if e.Op == group[0].Op && e.Run != "" && e.Run == group[0].Run {
+			group = append(group, e)
+		}
+	}
+	return group
+}
+
+// planUndo validates that e can still be reverted and returns the step.
+func planUndo(e journal.Entry) (undoStep, error) {
+	switch e.Op {
+	case journal.OpLink:
+		return planUndoLink(e)
+	case journal.OpUnlink:
+		return planUndoUnlink(e)
+	case journal.OpImport:
+		return planUndoImport(e), nil
+	case journal.OpSync:
+		return planUndoSync(e)
+	}
+	return undoStep{}, fmt.Errorf("operation %q cannot be undone", e.Op)
+}
+
+// planUndoLink removes the symlink created by link and puts back whatever
+// was at the destination before: the backup directory or the old symlink.
+func planUndoLink(e journal.Entry) (undoStep, error) {
+	dest, source := e.Data["dest"], e.Data["source"]
+	if dest == "" || source == "" {
+		return undoStep{}, fmt.Errorf("journal entry lacks destination details")
+	}
+	isCopy := e.Data["mode"] == config.LinkModeCopy || e.Data["mode"] == modeRender
+	remove := os.Remove
+	s := undoStep{entry: e, actions: []string{fmt.Sprintf("remove symlink %s → %s", dest, source)}}
+	if isCopy {
+		if marked, ok := copyMarkerSource(dest); !ok || marked != source {
+			return undoStep{}, fmt.Errorf("%s is no longer a copy of %s; refusing to touch it", dest, source)
+		}
+		remove = os.RemoveAll
+		s.actions = []string{fmt.Sprintf("remove copy %s of %s", dest, source)}
+	} else if current, err := os.Readlink(dest); err != nil || !linkResolvesTo(dest, current, source) {
+		return undoStep{}, fmt.Errorf("%s no longer points to %s; refusing to touch it", dest, source)
+	}
+
+	backup, previous := e.Data["backup"], e.Data["previous"]
+	switch {
+	case e.Data["state"] == "backed_up" && backup != "":
+		if _, err := os.Stat(backup); err != nil {
+			return undoStep{}, fmt.Errorf("backup %s is gone: %w", backup, err)
+		}
+		s.actions = append(s.actions, fmt.Sprintf("restore backup %s → %s", backup, dest))
+	case e.Data["state"] == "relinked" && previous != "":
+		s.actions = append(s.actions, fmt.Sprintf("re-create symlink %s → %s", dest, previous))
+	}
+
+	s.apply = func() error {
+		if err := remove(dest); err != nil {
+			return fmt.Errorf("cannot remove %s: %w", dest, err)
+		}
+		switch {
+		case e.Data["state"] == "backed_up" && backup != "":
+			if err := moveAside(backup, dest); err != nil {
+				return fmt.Errorf("cannot restore backup %s: %w", backup, err)
+			}
+		case e.Data["state"] == "relinked" && previous != "":
+			return createSymlink(previous, dest, e.Target)
+		}
+		return nil
+	}
+	return s, nil
+}
+
+// planUndoUnlink re-creates the symlink removed by unlink, first moving a
+// restored backup back into ~/.axon/backups.
+func planUndoUnlink(e journal.Entry) (undoStep, error) {
+	dest, link, restored := e.Data["dest"], e.Data["link"], e.Data["restored"]
+	if dest == "" || link == "" {
+		return undoStep{}, fmt.Errorf("journal entry lacks destination details")
+	}
+
+	s := undoStep{entry: e}
+	info, err := os.Lstat(dest)
+	if restored != "" {
+		if err != nil || info.Mode()&os.ModeSymlink != 0 {
+			return undoStep{}, fmt.Errorf("restored directory %s is not in place anymore", dest)
+		}
+		if _, err := os.Lstat(restored); err == nil {
+			return undoStep{}, fmt.Errorf("backup path %s is already taken", restored)
+		}
+		s.actions = append(s.actions, fmt.Sprintf("move %s back to backup %s", dest, restored))
+	} else if err == nil {
+		return undoStep{}, fmt.Errorf("%s exists again; refusing to replace it", dest)
+	}
+	isCopy := e.Data["mode"] == config.LinkModeCopy
+	rendered := e.Data["mode"] == modeRender
+	if isCopy {
+		s.actions = append(s.actions, fmt.Sprintf("re-create copy of %s at %s", link, dest))
+	} else if rendered {
+		s.actions = append(s.actions, fmt.Sprintf("render %s again from %s", dest, link))
+	} else {
+		s.actions = append(s.actions, fmt.Sprintf("re-create symlink %s → %s", dest, link))
+	}
+
+	s.apply = func() error {
+		if restored != "" {
+			if err := moveAside(dest, restored); err != nil {
+				return fmt.Errorf("cannot move %s back to backup: %w", dest, err)
+			}
+		}
+		if isCopy {
+			return installCopy(link, dest)
+		}
+		if rendered {
+			return reRenderTarget(e.Target, dest)
+		}
+		return createSymlink(link, dest, e.Target)
+	}
+	return s, nil
+}
+
+// planUndoImport removes the Hub paths an import created, children before
+// parents. Files whose content no longer matches the digest journaled at
+// import time (or that have none) were edited since and are kept, as are the
+// directories holding them or any other new content.
+func planUndoImport(e journal.Entry) undoStep {
+	existing := 0
+	edited := make(map[string]bool)
+	for _, p := range e.Paths {
+		info, err := os.Lstat(p)
+		if err != nil {
+			continue
+		}
+		existing++
+		if !info.IsDir() && (e.Digests[p] == "" || pathDigest(p) != e.Digests[p]) {
+			edited[p] = true
+		}
+	}
+	s := undoStep{entry: e, actions: []string{
+		fmt.Sprintf("remove %d path(s) the import created in the Hub (%d already gone)", existing-len(edited), len(e.Paths)-existing),
+	}}
+	for _, p := range e.Paths {
+		if edited[p] {
+			s.actions = append(s.actions, fmt.Sprintf("keep %s: it changed since the import", p))
+		}
+	}
+	s.apply = func() error {
+		for i := len(e.Paths) - 1; i >= 0; i-- {
+			p := e.Paths[i]
+			if edited[p] {
+				continue
+			}
+			info, err := os.Lstat(p)
+			if err != nil {
+				continue
+			}
+			if err := os.Remove(p); err != nil && !info.IsDir() {
+				return fmt.Errorf("cannot remove %s: %w", p, err)
+			}
+		}
+		return nil
+	}
+	return s
+}
+
+// pathDigest fingerprints p for the import journal: the SHA-256 of a regular
+// file or the target of a symlink. It returns "" for directories and for
+// paths that cannot be read.
+func pathDigest(p string) string {
+	info, err := os.Lstat(p)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(p)
+		if err != nil {
+			return ""
+		}
+		return "symlink:" + target
+	case info.Mode().IsRegular():
+		d, err := hash.File(p)
+		if err != nil {
+			return ""
+		}
+		return "sha256:" + d
+	}
+	return ""
+}
+
+// planUndoSync resets the Hub to the HEAD it had before the sync, provided
+// nothing has been committed since.
+func planUndoSync(e journal.Entry) (undoStep, error) {
+	repo, before, after := e.Data["repo"], e.Data["before"], e.Data["after"]
+	if repo == "" || after == "" {
+		return undoStep{}, fmt.Errorf("journal entry lacks commit details")
+	}
+	if before == "" {
+		return undoStep{}, fmt.Errorf("the sync created the Hub's first commit; there is nothing to reset to")
+	}
+	if err := checkGitAvailable(); err != nil {
+		return undoStep{}, err
+	}
+	if head := hubHeadSHA(repo); head != after {
+		return undoStep{}, fmt.Errorf("Hub HEAD moved since the sync (now %s, expected %s)", abbrevSHA(head), abbrevSHA(after))
+	}
+
+	resetMode := "--soft"
+	s := undoStep{entry: e}
+	if e.Data["mode"] == "read-only" {
+		dirty, err := gitIsDirty(repo)
+		if err != nil {
+			return undoStep{}, err
+		}
+		if dirty {
+			return undoStep{}, fmt.Errorf("the Hub has local edits; commit or discard them first")
+		}
+		resetMode = "--hard"
+		s.actions = append(s.actions, fmt.Sprintf("git reset --hard %s (discard the pulled commits)", abbrevSHA(before)))
+	} else {
+		// Rewriting pushed history would bring the synced commits back as
+		// staged edits, and the next sync would diverge from the remote.
+		if out, err := gitOutput(repo, "branch", "-r", "--contains", after); err == nil && strings.TrimSpace(out) != "" {
+			return undoStep{}, fmt.Errorf("%s is already on %s; revert it in the Hub with 'git revert' instead", abbrevSHA(after), firstLine(strings.TrimSpace(out), nil))
+		}
+		s.actions = append(s.actions, fmt.Sprintf("git reset --soft %s (the synced changes stay staged)", abbrevSHA(before)))
+	}
+
+	s.apply = func() error {
+		if out, err := gitOutput(repo, "reset", resetMode, before); err != nil {
+			return fmt.Errorf("git reset failed: %w\n%s", err, strings.TrimSpace(out))
+		}
+		return nil
+	}
+	return s, nil
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/journal"
+)
+
+func TestLatestUndoable_GroupsRunAndSkipsUndone(t *testing.T) {
+	t0 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	older := journal.Entry{Time: t0, Run: "r1", Op: journal.OpImport, Target: "windsurf"}
+	linkA := journal.Entry{Time: t0.Add(time.Minute), Run: "r2", Op: journal.OpLink, Target: "a"}
+	linkB := journal.Entry{Time: t0.Add(time.Minute), Run: "r2", Op: journal.OpLink, Target: "b"}
+	vendorSync := journal.Entry{Time: t0.Add(2 * time.Minute), Run: "r3", Op: journal.OpVendorSync, Target: "v"}
+
+	entries := []journal.Entry{older, linkA, linkB, vendorSync}
+	group := latestUndoable(entries)
+	if len(group) != 2 || group[0].Target != "b" || group[1].Target != "a" {
+		t.Fatalf("group = %+v, want link b then a", group)
+	}
+
+	// Once both links are undone, the import becomes the next candidate.
+	entries = append(entries,
+		journal.Entry{Op: journal.OpUndo, Data: map[string]string{"undone": linkA.Key()}},
+		journal.Entry{Op: journal.OpUndo, Data: map[string]string{"undone": linkB.Key()}},
+	)
+	group = latestUndoable(entries)
+	if len(group) != 1 || group[0].Op != journal.OpImport {
+		t.Fatalf("group = %+v, want the import", group)
+	}
+}
+
+func TestUndoLink_RestoresBackup(t *testing.T) {
+	cfg, tmp := setupLinkTest(t)
+	t.Setenv("HOME", tmp)
+	target := cfg.Targets[0]
+	dest := target.Destination
+
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dest, "local.md"), []byte("local"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := linkTargets(cfg, cfg.Targets, linkOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	path, _ := journal.Path()
+	entries, _ := journal.Read(path, time.Time{})
+	group := latestUndoable(entries)
+	if len(group) != 1 {
+		t.Fatalf("group = %+v", group)
+	}
+	step, err := planUndo(group[0])
+	if err != nil {
+		t.Fatalf("planUndo: %v", err)
+	}
+	if err := step.apply(); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	info, err := os.Lstat(dest)
+	if err != nil || info.Mode()&os.ModeSymlink != 0 || !info.IsDir() {
+		t.Fatalf("dest should be the restored real directory, got %v, %v", info, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dest, "local.md")); string(data) != "local" {
+		t.Errorf("restored content = %q", data)
+	}
+}
+
+func TestUndoLink_RefusesChangedSymlink(t *testing.T) {
+	tmp := t.TempDir()
+	dest := filepath.Join(tmp, "dest")
+	if err := os.Symlink(filepath.Join(tmp, "elsewhere"), dest); err != nil {
+		t.Fatal(err)
+	}
+	e := journal.Entry{Op: journal.OpLink, Target: "x", Data: map[string]string{
+		"state": "linked", "dest": dest, "source": filepath.Join(tmp, "hub"),
+	}}
+	if _, err := planUndo(e); err == nil || !strings.Contains(err.Error(), "no longer points") {
+		t.Errorf("planUndo err = %v, want refusal", err)
+	}
+}
+
+func TestUndoUnlink_RecreatesSymlink(t *testing.T) {
+	tmp := t.TempDir()
+	hub := filepath.Join(tmp, "hub")
+	dest := filepath.Join(tmp, "dest")
+	backup := filepath.Join(tmp, "backups", "x_20261001000000")
+	if err := os.MkdirAll(hub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(backup), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// State after unlink: the backup was restored to dest.
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	e := journal.Entry{Op: journal.OpUnlink, Target: "x", Data: map[string]string{
+		"dest": dest, "link": hub, "restored": backup,
+	}}
+	step, err := planUndo(e)
+	if err != nil {
+		t.Fatalf("planUndo: %v", err)
+	}
+	if err := step.apply(); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if got, _ := os.Readlink(dest); got != hub {
+		t.Errorf("dest → %q, want %q", got, hub)
+	}
+	if _, err := os.Stat(backup); err != nil {
+		t.Errorf("backup not moved back: %v", err)
+	}
+}
+
+func TestUndoImport_RemovesCreatedPaths(t *testing.T) {
+	tmp := t.TempDir()
+	dir := filepath.Join(tmp, "skills", "new-skill")
+	file := filepath.Join(dir, "SKILL.md")
+	kept := filepath.Join(tmp, "skills", "other.md")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{file, kept} {
+		if err := os.WriteFile(p, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	step, err := planUndo(journal.Entry{Op: journal.OpImport, Paths: []string{dir, file},
+		Digests: map[string]string{file: pathDigest(file)}})
+	if err != nil {
+		t.Fatalf("planUndo: %v", err)
+	}
+	if err := step.apply(); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if _, err := os.Lstat(dir); !os.IsNotExist(err) {
+		t.Errorf("created dir should be removed, got %v", err)
+	}
+	if _, err := os.Stat(kept); err != nil {
+		t.Errorf("unrelated file removed: %v", err)
+	}
+}
+
+func TestUndoImport_KeepsEditedFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "skills", "new-skill")
+	edited := filepath.Join(dir, "SKILL.md")
+	untouched := filepath.Join(dir, "notes.md")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{edited, untouched} {
+		if err := os.WriteFile(p, []byte("imported"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	e := journal.Entry{Op: journal.OpImport, Paths: []string{dir, edited, untouched},
+		Digests: map[string]string{edited: pathDigest(edited), untouched: pathDigest(untouched)}}
+	if err := os.WriteFile(edited, []byte("edited by hand"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	step, err := planUndo(e)
+	if err != nil {
+		t.Fatalf("planUndo: %v", err)
+	}
+	if !strings.Contains(strings.Join(step.actions, "\n"), "keep "+edited) {
+		t.Errorf("preview should report the edited file, got %q", step.actions)
+	}
+	if err := step.apply(); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if data, err := os.ReadFile(edited); err != nil || string(data) != "edited by hand" {
+		t.Errorf("edited file should be kept, got %q, %v", data, err)
+	}
+	if _, err := os.Lstat(untouched); !os.IsNotExist(err) {
+		t.Errorf("unchanged file should be removed, got %v", err)
+	}
+}
+
+func TestUndoSync_SoftReset(t *testing.T) {
+	cfg, _ := initTestRepo(t)
+	before := hubHeadSHA(cfg.RepoPath)
+
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "skill.md"), []byte("skill\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := syncReadWrite(cfg); err != nil {
+		t.Fatalf("syncReadWrite: %v", err)
+	}
+	after := hubHeadSHA(cfg.RepoPath)
+
+	step, err := planUndo(journal.Entry{Op: journal.OpSync, Data: map[string]string{
+		"repo": cfg.RepoPath, "before": before, "after": after, "mode": "read-write",
+	}})
+	if err != nil {
+		t.Fatalf("planUndo: %v", err)
+	}
+	if err := step.apply(); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if got := hubHeadSHA(cfg.RepoPath); got != before {
+		t.Errorf("HEAD = %s, want %s", got, before)
+	}
+	// --soft keeps the file staged in the working tree.
+	if _, err := os.Stat(filepath.Join(cfg.RepoPath, "skill.md")); err != nil {
+		t.Errorf("skill.md should survive a soft reset: %v", err)
+	}
+
+	// A second attempt is refused because HEAD no longer matches.
+	if _, err := planUndo(journal.Entry{Op: journal.OpSync, Data: map[string]string{
+		"repo": cfg.RepoPath, "before": before, "after": after,
+	}}); err == nil {
+		t.Error("expected refusal once HEAD moved")
+	}
+}
+
+func TestUndoSync_RefusesPushedCommit(t *testing.T) {
+	cfg, tmp := initTestRepo(t)
+	origin := filepath.Join(tmp, "origin.git")
+	if err := gitRun("-C", tmp, "init", "-q", "--bare", origin); err != nil {
+		t.Fatal(err)
+	}
+	if err := gitRun("-C", cfg.RepoPath, "remote", "add", "origin", origin); err != nil {
+		t.Fatal(err)
+	}
+	before := hubHeadSHA(cfg.RepoPath)
+	if err := os.WriteFile(filepath.Join(cfg.RepoPath, "skill.md"), []byte("skill\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"-C", cfg.RepoPath, "add", "."},
+		{"-C", cfg.RepoPath, "commit", "-q", "-m", "sync"},
+		{"-C", cfg.RepoPath, "push", "-q", "origin", "HEAD:refs/heads/master"},
+		{"-C", cfg.RepoPath, "fetch", "-q", "origin"},
+	} {
+		if err := gitRun(args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	after := hubHeadSHA(cfg.RepoPath)
+
+	_, err := planUndo(journal.Entry{Op: journal.OpSync, Data: map[string]string{
+		"repo": cfg.RepoPath, "before": before, "after": after, "mode": "read-write",
+	}})
+	if err == nil || !strings.Contains(err.Error(), "git revert") {
+		t.Fatalf("expected refusal for a pushed commit, got %v", err)
+	}
+	if got := hubHeadSHA(cfg.RepoPath); got != after {
+		t.Errorf("HEAD = %s, want it untouched at %s", got, after)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	for in, want := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "": false} {
+		if got := confirm(strings.NewReader(in), "ok?"); got != want {
+			t.Errorf("confirm(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,22 +10,44 @@ import (
 	"time"
 
 	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
 	"github.com/spf13/cobra"
 )
 
 var unlinkCmd = &cobra.Command{
-	Use:   "unlink [target-name | all]",
+	Use:   "unlink [target-name | @group | all]",
 	Short: "Remove symlinks and optionally restore from backup",
 	Long: `Remove the symbolic link at each target's destination.
 If a backup exists (created by axon link), the most recent backup is restored.
 
+--restore <timestamp> restores a specific backup instead, as named in
+~/.axon/backups/<target>_<timestamp>. --no-restore leaves the backups alone
+and the destination empty. --purge also moves all of the target's backups
+to the trash ('axon trash').
+
   axon unlink              Unlink all targets
-  axon unlink windsurf-skills  Unlink a single target`,
+  axon unlink windsurf-skills  Unlink a single target
+  axon unlink @jetbrains   Unlink the targets with 'group: jetbrains'
+  axon unlink all --exclude @terminal-agents
+  axon unlink windsurf-skills --restore 20250114093012
+  axon unlink windsurf-skills --purge`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runUnlink,
 }
 
+var (
+	flagUnlinkExclude   []string
+	flagUnlinkNoRestore bool
+	flagUnlinkPurge     bool
+	flagUnlinkRestore   string
+)
+
 func init() {
+	unlinkCmd.Flags().StringSliceVar(&flagUnlinkExclude, "exclude", nil, "Skip these targets or @groups when unlinking all or a group")
+	unlinkCmd.Flags().BoolVar(&flagUnlinkNoRestore, "no-restore", false, "Only remove the symlink; keep backups without restoring one")
+	unlinkCmd.Flags().BoolVar(&flagUnlinkPurge, "purge", false, "Remove the symlink and move all of the target's backups to the trash")
+	unlinkCmd.Flags().StringVar(&flagUnlinkRestore, "restore", "", "Restore the backup with this timestamp (YYYYMMDDHHMMSS) instead of the latest")
+	unlinkCmd.MarkFlagsMutuallyExclusive("no-restore", "purge", "restore")
 	rootCmd.AddCommand(unlinkCmd)
 }
 
@@ -34,32 +57,15 @@ func runUnlink(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
 	}
 
-	var targets []config.Target
-	singleTarget := false
-	if len(args) == 0 || args[0] == "all" {
-		targets = make([]config.Target, len(cfg.Targets))
-		copy(targets, cfg.Targets)
-		sort.Slice(targets, func(i, j int) bool {
-			return targets[i].Name < targets[j].Name
-		})
-	} else {
-		name := args[0]
-		for _, t := range cfg.Targets {
-			if t.Name == name {
-				targets = append(targets, t)
-				break
-			}
-		}
-		if len(targets) == 0 {
-			return fmt.Errorf("target %q not found in axon.yaml", name)
-		}
-		singleTarget = true
+	targets, singleTarget, err := selectTargets(cfg, args, flagUnlinkExclude)
+	if err != nil {
+		return err
 	}
 
 	// ── Collect results ────────────────────────────────────────────────────────
 	type unlinkResult struct {
 		name   string
-		state  string // "restored", "removed", "not_symlink", "not_exist", "not_installed", "error"
+		state  string // "restored", "removed", "purged", "not_symlink", "not_exist", "not_installed", "error"
 		detail string
 	}
 	var results []unlinkResult
@@ -68,6 +74,10 @@ func runUnlink(cmd *cobra.Command, args []string) error {
 
 	for _, t := range targets {
 		dest, err := config.ExpandPath(t.Destination)
+		if errors.Is(err, config.ErrNoWindowsHome) {
+			notInstalledMap[toolBaseName(t.Name)] = true
+			continue
+		}
 		if err != nil {
 			results = append(results, unlinkResult{t.Name, "error", err.Error()})
 			continue
@@ -75,7 +85,7 @@ func runUnlink(cmd *cobra.Command, args []string) error {
 
 		// If parent doesn't exist, tool isn't installed.
 		parent := filepath.Dir(dest)
-		if _, parentErr := os.Stat(parent); os.IsNotExist(parentErr) {
+		if _, parentErr := fsys.Stat(parent); os.IsNotExist(parentErr) {
 			baseName := t.Name
 			if idx := strings.LastIndex(t.Name, "-"); idx != -1 {
 				baseName = t.Name[:idx]
@@ -84,7 +94,7 @@ func runUnlink(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		info, err := os.Lstat(dest)
+		info, err := fsys.Lstat(dest)
 		if os.IsNotExist(err) {
 			results = append(results, unlinkResult{t.Name, "not_exist", ""})
 			continue
@@ -95,33 +105,104 @@ func runUnlink(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
+		// Copies made by copy mode are removed like symlinks; any other
+		// real directory is user data.
+		copySource, isCopy := "", false
 		if info.Mode()&os.ModeSymlink == 0 {
-			results = append(results, unlinkResult{t.Name, "not_symlink",
-				fmt.Sprintf("%s is not a symlink", dest)})
-			continue
+			if copySource, isCopy = copyMarkerSource(dest); !isCopy {
+				results = append(results, unlinkResult{t.Name, "not_symlink",
+					fmt.Sprintf("%s is not a symlink", dest)})
+				continue
+			}
+		}
+
+		// Resolve a requested backup before removing anything.
+		backup := ""
+		if flagUnlinkRestore != "" {
+			if backup, err = backupAt(t.Name, flagUnlinkRestore); err != nil {
+				results = append(results, unlinkResult{t.Name, "error", err.Error()})
+				continue
+			}
 		}
 
-		if err := os.Remove(dest); err != nil {
+		linkedTo := copySource
+		var removeErr error
+		if isCopy {
+			removeErr = fsys.RemoveAll(dest)
+		} else {
+			linkedTo, _ = fsys.Readlink(dest)
+			removeErr = fsys.Remove(dest)
+		}
+		if removeErr != nil {
 			results = append(results, unlinkResult{t.Name, "error",
-				fmt.Sprintf("cannot remove symlink: %v", err)})
+				fmt.Sprintf("cannot remove %s: %v", dest, removeErr)})
 			continue
 		}
+		entry := journal.Entry{
+			Op:     journal.OpUnlink,
+			Target: t.Name,
+			Detail: fmt.Sprintf("removed %s → %s", dest, linkedTo),
+			Data:   map[string]string{"dest": dest, "link": linkedTo},
+		}
+		if isCopy {
+			entry.Detail = fmt.Sprintf("removed copy %s of %s", dest, linkedTo)
+			entry.Data["mode"] = config.LinkModeCopy
+			if t.IsFile() || t.IsRules() {
+				entry.Detail = fmt.Sprintf("removed %s rendered from %s", dest, linkedTo)
+				entry.Data["mode"] = modeRender
+			}
+		}
 
-		backup, err := latestBackup(cfg, t.Name)
+		if flagUnlinkNoRestore {
+			recordHistory(entry)
+			results = append(results, unlinkResult{t.Name, "removed", "backups kept (--no-restore)"})
+			continue
+		}
+		if flagUnlinkPurge {
+			n, err := purgeBackups(t.Name)
+			entry.Data["purged"] = fmt.Sprint(n)
+			entry.Detail += fmt.Sprintf(" (%d backup(s) moved to the trash)", n)
+			recordHistory(entry)
+			if err != nil {
+				results = append(results, unlinkResult{t.Name, "error",
+					fmt.Sprintf("symlink removed, but cannot purge backups: %v", err)})
+				continue
+			}
+			results = append(results, unlinkResult{t.Name, "purged",
+				fmt.Sprintf("%d backup(s) moved to the trash", n)})
+			continue
+		}
+		if backup == "" {
+			backup, err = latestBackup(cfg, t.Name)
+		}
 		if err != nil || backup == "" {
+			recordHistory(entry)
 			results = append(results, unlinkResult{t.Name, "removed", "no backup found"})
 			continue
 		}
 
-		if err := os.Rename(backup, dest); err != nil {
+		if err := moveAside(backup, dest); err != nil {
+			recordHistory(entry)
 			results = append(results, unlinkResult{t.Name, "error",
 				fmt.Sprintf("cannot restore backup %s: %v", backup, err)})
 			continue
 		}
+		entry.Data["restored"] = backup
+		entry.Detail += fmt.Sprintf(" (restored %s)", backup)
+		recordHistory(entry)
 		results = append(results, unlinkResult{t.Name, "restored",
 			fmt.Sprintf("%s → %s", backup, dest)})
 	}
 
+	changed := make(map[string]bool)
+	for _, r := range results {
+		switch r.state {
+		case "restored", "removed", "purged":
+			changed[r.name] = true
+		}
+	}
+	defer runTargetHooks(cfg, targets, changed, hookPostUnlink)
+
 	// ── Print results ──────────────────────────────────────────────────────────
 	if singleTarget {
 		if len(results) == 1 {
@@ -131,6 +212,8 @@ func runUnlink(cmd *cobra.Command, args []string) error {
 				printRestore(r.name, "restored: "+r.detail)
 			case "removed":
 				printSkip(r.name, "symlink removed, "+r.detail)
+			case "purged":
+				printOK(r.name, "symlink removed, "+r.detail)
 			case "not_exist":
 				printMiss(r.name, "destination does not exist, nothing to unlink")
 			case "not_symlink":
@@ -146,11 +229,13 @@ func runUnlink(cmd *cobra.Command, args []string) error {
 	// Multi-target: grouped sections.
 	printSection("Unlink")
 
-	var restored, removed, notExist, notSymlink, errors []unlinkResult
+	var restored, removed, purged, notExist, notSymlink, errors []unlinkResult
 	for _, r := range results {
 		switch r.state {
 		case "restored":
 			restored = append(restored, r)
+		case "purged":
+			purged = append(purged, r)
 		case "removed":
 			removed = append(removed, r)
 		case "not_exist":
@@ -169,11 +254,17 @@ func runUnlink(cmd *cobra.Command, args []string) error {
 		}
 	}
 	if len(removed) > 0 {
-		printBullet("Symlink removed (no backup):")
+		printBullet("Symlink removed (no backup restored):")
 		for _, r := range removed {
 			printSkip(r.name, r.detail)
 		}
 	}
+	if len(purged) > 0 {
+		printBullet("Symlink removed, backups purged:")
+		for _, r := range purged {
+			printOK(r.name, r.detail)
+		}
+	}
 	if len(notExist) > 0 {
 		printBullet("Nothing to unlink:")
 		for _, r := range notExist {
@@ -208,25 +299,36 @@ func runUnlink(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// latestBackup returns the path of the most recent backup directory for a
-// target, or "" if none exist.
+// backupLayout is the timestamp in backup names: <target>_<timestamp>.
+const backupLayout = "20060102150405"
+
+// latestBackup returns the path of the most recent backup for a target (a
+// directory, or a file replaced by 'axon link --force'), or "" if none exist.
 func latestBackup(_ *config.Config, targetName string) (string, error) {
+	backups, err := targetBackups(targetName)
+	if err != nil || len(backups) == 0 {
+		return "", err
+	}
+	return backups[0], nil
+}
+
+// targetBackups returns the paths of a target's backups, newest first.
+func targetBackups(targetName string) ([]string, error) {
 	axonDir, err := config.AxonDir()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	backupsDir := filepath.Join(axonDir, "backups")
 
-	entries, err := os.ReadDir(backupsDir)
+	entries, err := fsys.ReadDir(backupsDir)
 	if os.IsNotExist(err) {
-		return "", nil
+		return nil, nil
 	}
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	prefix := targetName + "_"
-	layout := "20060102150405"
 
 	type candidate struct {
 		path string
@@ -235,11 +337,11 @@ func latestBackup(_ *config.Config, targetName string) (string, error) {
 	var candidates []candidate
 
 	for _, e := range entries {
-		if !e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+		if !strings.HasPrefix(e.Name(), prefix) {
 			continue
 		}
 		ts := strings.TrimPrefix(e.Name(), prefix)
-		t, err := time.Parse(layout, ts)
+		t, err := time.Parse(backupLayout, ts)
 		if err != nil {
 			continue
 		}
@@ -249,12 +351,50 @@ func latestBackup(_ *config.Config, targetName string) (string, error) {
 		})
 	}
 
-	if len(candidates) == 0 {
-		return "", nil
-	}
-
 	sort.Slice(candidates, func(i, j int) bool {
 		return candidates[i].t.After(candidates[j].t)
 	})
-	return candidates[0].path, nil
+	paths := make([]string, len(candidates))
+	for i, c := range candidates {
+		paths[i] = c.path
+	}
+	return paths, nil
+}
+
+// backupAt returns the target's backup taken at ts, listing the available
+// timestamps when there is none.
+func backupAt(targetName, ts string) (string, error) {
+	if _, err := time.Parse(backupLayout, ts); err != nil {
+		return "", fmt.Errorf("invalid backup timestamp %q: use YYYYMMDDHHMMSS as in ~/.axon/backups/%s_<timestamp>", ts, targetName)
+	}
+	backups, err := targetBackups(targetName)
+	if err != nil {
+		return "", err
+	}
+	var stamps []string
+	for _, b := range backups {
+		if strings.TrimPrefix(filepath.Base(b), targetName+"_") == ts {
+			return b, nil
+		}
+		stamps = append(stamps, strings.TrimPrefix(filepath.Base(b), targetName+"_"))
+	}
+	if len(stamps) == 0 {
+		return "", fmt.Errorf("no backup %s; this target has no backups", ts)
+	}
+	return "", fmt.Errorf("no backup %s; available: %s", ts, strings.Join(stamps, ", "))
+}
+
+// purgeBackups moves all of a target's backups to the trash and returns how
+// many it moved.
+func purgeBackups(targetName string) (int, error) {
+	backups, err := targetBackups(targetName)
+	if err != nil {
+		return 0, err
+	}
+	for i, b := range backups {
+		if err := moveToTrash(b, "unlink --purge"); err != nil {
+			return i, err
+		}
+	}
+	return len(backups), nil
 }
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/trash"
+)
+
+// makeBackups creates ~/.axon/backups/<target>_<ts> for each timestamp.
+func makeBackups(t *testing.T, target string, stamps ...string) string {
+	t.Helper()
+	dir := filepath.Join(os.Getenv("HOME"), ".axon", "backups")
+	for _, ts := range stamps {
+		if err := os.MkdirAll(filepath.Join(dir, target+"_"+ts), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestBackupAt(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := makeBackups(t, "claude-skills", "20250101120000", "20250301120000")
+	makeBackups(t, "claude-skills-extra", "20250201120000")
+
+	backups, err := targetBackups("claude-skills")
+	if err != nil || len(backups) != 2 || filepath.Base(backups[0]) != "claude-skills_20250301120000" {
+		t.Fatalf("targetBackups = %v, %v; want two, newest first", backups, err)
+	}
+
+	got, err := backupAt("claude-skills", "20250101120000")
+	if err != nil || got != filepath.Join(dir, "claude-skills_20250101120000") {
+		t.Fatalf("backupAt = %q, %v", got, err)
+	}
+	if _, err := backupAt("claude-skills", "20250201120000"); err == nil || !strings.Contains(err.Error(), "20250301120000") {
+		t.Errorf("missing backup: err = %v, want the available timestamps", err)
+	}
+	if _, err := backupAt("claude-skills", "yesterday"); err == nil || !strings.Contains(err.Error(), "YYYYMMDDHHMMSS") {
+		t.Errorf("bad timestamp: err = %v", err)
+	}
+}
+
+func TestPurgeBackups(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := makeBackups(t, "claude-skills", "20250101120000", "20250301120000")
+	makeBackups(t, "codex-skills", "20250101120000")
+
+	n, err := purgeBackups("claude-skills")
+	if err != nil || n != 2 {
+		t.Fatalf("purgeBackups = %d, %v; want 2", n, err)
+	}
+	if backups, _ := targetBackups("claude-skills"); len(backups) != 0 {
+		t.Errorf("backups left: %v", backups)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "codex-skills_20250101120000")); err != nil {
+		t.Errorf("other target's backup touched: %v", err)
+	}
+	root, err := trash.Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := trash.List(root)
+	if err != nil || len(entries) != 2 || entries[0].Reason != "unlink --purge" {
+		t.Errorf("trash = %+v, %v", entries, err)
+	}
+}
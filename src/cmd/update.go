@@ -4,11 +4,11 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"bufio"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/gofrs/flock"
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
 	"github.com/spf13/cobra"
 )
 
@@ -31,12 +33,18 @@ type updateFlags struct {
 	dryRun     bool
 	repo       string
 	prerelease bool
+	channel    string
 	force      bool
 	timeout    time.Duration
 	verbose    bool
+
+	skipSignature bool
+	rollback      bool
+	keepVersions  int
 }
 
 // githubRelease models the subset of GitHub Releases API fields used by axon update.
+// Other release sources are normalized into this shape.
 type githubRelease struct {
 	TagName string        `json:"tag_name"`
 	Draft   bool          `json:"draft"`
@@ -52,20 +60,38 @@ type githubAsset struct {
 }
 
 var updateCmd = &cobra.Command{
-	Use:   "update",
+	Use:   "update [--rollback [version]]",
 	Short: "Update the Axon CLI to the latest release",
-	RunE:  runUpdate,
+	Long: `Download and install the latest axon release.
+
+Each update keeps the replaced binary under ~/.axon/versions (the newest
+--keep-versions are retained). If a release regresses, restore one:
+
+  axon update --rollback          restore the most recent previous version
+  axon update --rollback 0.1.8    restore a specific kept version
+
+The release feed is chosen by --channel, else update_channel in axon.yaml:
+
+  stable    latest published release (default)
+  beta      newest release including prereleases
+  nightly   the rolling 'nightly' release`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUpdate,
 }
 
 func init() {
 	var f updateFlags
 	updateCmd.Flags().BoolVar(&f.check, "check", false, "Check for updates but do not download or install")
 	updateCmd.Flags().BoolVar(&f.dryRun, "dry-run", false, "Resolve update details but do not download or install")
-	updateCmd.Flags().StringVar(&f.repo, "repo", "kamusis/axon-cli", "GitHub repo in owner/name format")
-	updateCmd.Flags().BoolVar(&f.prerelease, "prerelease", false, "Allow updating to a prerelease")
+	updateCmd.Flags().StringVar(&f.repo, "repo", defaultUpdateRepo, "Release source: owner/name (GitHub), a GitHub Enterprise URL, or a GitLab project (gitlab:group/name or URL)")
+	updateCmd.Flags().BoolVar(&f.prerelease, "prerelease", false, "Allow updating to a prerelease (same as --channel beta)")
+	updateCmd.Flags().StringVar(&f.channel, "channel", "", "Release channel: stable, beta, or nightly (default: update_channel in axon.yaml, else stable)")
 	updateCmd.Flags().BoolVar(&f.force, "force", false, "Reinstall even if already on the latest version")
+	updateCmd.Flags().BoolVar(&f.skipSignature, "skip-signature", false, "Do not verify checksums.txt.sig (unsafe; escape hatch only)")
 	updateCmd.Flags().DurationVar(&f.timeout, "timeout", 30*time.Second, "Overall timeout for network operations")
 	updateCmd.Flags().BoolVar(&f.verbose, "verbose", false, "Verbose output")
+	updateCmd.Flags().BoolVar(&f.rollback, "rollback", false, "Restore a previously installed version from ~/.axon/versions")
+	updateCmd.Flags().IntVar(&f.keepVersions, "keep-versions", defaultKeepVersions, "Number of replaced binaries to keep for rollback (0 disables)")
 	updateCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		cmd.SetContext(context.WithValue(cmd.Context(), updateFlagsKey{}, f))
 		return nil
@@ -76,11 +102,17 @@ func init() {
 type updateFlagsKey struct{}
 
 // runUpdate implements the `axon update` command.
-func runUpdate(cmd *cobra.Command, _ []string) error {
+func runUpdate(cmd *cobra.Command, args []string) error {
 	f, ok := cmd.Context().Value(updateFlagsKey{}).(updateFlags)
 	if !ok {
 		return fmt.Errorf("internal error: update flags missing")
 	}
+	if len(args) > 0 && !f.rollback {
+		return fmt.Errorf("unexpected argument %q (a version is only accepted with --rollback)", args[0])
+	}
+	if f.keepVersions < 0 {
+		return fmt.Errorf("--keep-versions must be >= 0")
+	}
 
 	_, unlock, err := acquireUpdateLock(f.timeout)
 	if err != nil {
@@ -88,15 +120,40 @@ func runUpdate(cmd *cobra.Command, _ []string) error {
 	}
 	defer unlock()
 
-	owner, repo, err := splitRepo(f.repo)
+	if f.rollback {
+		requested := ""
+		if len(args) > 0 {
+			requested = args[0]
+		}
+		return runUpdateRollback(f, requested)
+	}
+
+	src, err := parseReleaseSource(f.repo)
 	if err != nil {
 		return err
 	}
 
+	// axon.yaml is optional here: update must work before 'axon init'.
+	var cfgChannel string
+	cfg, err := config.Load()
+	if err == nil {
+		cfgChannel = cfg.UpdateChannel
+	}
+	channel, channelSource, err := resolveUpdateChannel(f.channel, f.prerelease, cfgChannel)
+	if err != nil {
+		return err
+	}
+	if f.check || f.verbose {
+		printInfo("", fmt.Sprintf("Channel: %s (%s)", channel, channelSource))
+	}
+
 	ctx, cancel := context.WithTimeout(cmd.Context(), f.timeout)
 	defer cancel()
 
-	rel, err := fetchRelease(ctx, owner, repo, f.prerelease)
+	if f.verbose {
+		printInfo("", fmt.Sprintf("Release source: %s", src))
+	}
+	rel, err := src.fetchRelease(ctx, channel, true)
 	if err != nil {
 		return err
 	}
@@ -105,13 +162,20 @@ func runUpdate(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("invalid release: empty tag_name")
 	}
 	latestVersion := normalizeReleaseVersion(latestTag)
+	if channel == updateChannelNightly {
+		// The nightly tag is rolling; the build version lives in the asset names.
+		if latestVersion, err = nightlyReleaseVersion(rel, runtime.GOOS, runtime.GOARCH); err != nil {
+			return err
+		}
+		latestTag = latestVersion
+	}
 
 	if !f.force && version == latestVersion {
 		printOK("", fmt.Sprintf("Axon is up to date: %s", version))
 		return nil
 	}
 
-	asset, err := selectReleaseAsset(rel, latestTag, runtime.GOOS, runtime.GOARCH)
+	asset, err := selectReleaseAsset(rel, src.archiveName(latestVersion, runtime.GOOS, runtime.GOARCH), runtime.GOOS, runtime.GOARCH)
 	if err != nil {
 		return err
 	}
@@ -133,6 +197,9 @@ func runUpdate(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	if err := ensureFreeSpace(cfg, baseTempDir, "update"); err != nil {
+		return err
+	}
 	tmpDir, err := os.MkdirTemp(baseTempDir, "axon-update-*")
 	if err != nil {
 		return fmt.Errorf("cannot create temp dir: %w", err)
@@ -140,13 +207,22 @@ func runUpdate(cmd *cobra.Command, _ []string) error {
 	defer os.RemoveAll(tmpDir)
 
 	archivePath := filepath.Join(tmpDir, asset.Name)
-	if err := downloadWithProgress(ctx, asset.BrowserDownloadURL, archivePath, f.verbose); err != nil {
+	if err := downloadWithProgress(ctx, asset.BrowserDownloadURL, archivePath, src.authorizeDownload, f.verbose); err != nil {
 		return err
 	}
 
-	checksumAsset, checksumAssetFound := findChecksumAsset(rel)
+	checksumAsset, checksumAssetFound := src.checksumAsset(rel)
 	if checksumAssetFound {
-		expected, expErr := fetchExpectedSHA256(ctx, checksumAsset.BrowserDownloadURL, asset.Name)
+		checksums, err := fetchReleaseFile(ctx, src, checksumAsset.BrowserDownloadURL, "checksum")
+		if err != nil {
+			return err
+		}
+		if f.skipSignature {
+			printWarn("", "--skip-signature set; release signature NOT verified")
+		} else if err := verifyReleaseChecksums(ctx, src, rel, latestVersion, checksumAsset, checksums); err != nil {
+			return err
+		}
+		expected, expErr := parseExpectedSHA256(bytes.NewReader(checksums), asset.Name)
 		if expErr != nil {
 			return expErr
 		}
@@ -159,6 +235,9 @@ func runUpdate(cmd *cobra.Command, _ []string) error {
 		}
 		printOK("", "Checksum verified.")
 	} else {
+		if !f.skipSignature && !predatesSigning(latestVersion) {
+			return fmt.Errorf("release has no checksums.txt; cannot verify its signature (use --skip-signature to override)")
+		}
 		printWarn("", "checksums.txt not found in release; skipping checksum verification")
 	}
 
@@ -177,6 +256,13 @@ func runUpdate(cmd *cobra.Command, _ []string) error {
 	}
 	currentPath, _ = filepath.EvalSymlinks(currentPath)
 
+	// Keep the binary being replaced so 'axon update --rollback' can restore it.
+	if dir, err := versionsDir(); err == nil {
+		if err := archiveBinary(dir, currentPath, version, f.keepVersions); err != nil {
+			printWarn("", err.Error())
+		}
+	}
+
 	if runtime.GOOS == "windows" {
 		stagedNew := filepath.Join(filepath.Dir(currentPath), "axon.new.exe")
 		if err := copyFile(newBinPath, stagedNew); err != nil {
@@ -186,6 +272,7 @@ func runUpdate(cmd *cobra.Command, _ []string) error {
 		if err := spawnWindowsSwapHelper(currentPath, stagedNew, backupPath, latestVersion, f.timeout); err != nil {
 			return err
 		}
+		recordUpdate(journal.OpUpdate, version, latestVersion, currentPath, true)
 		printOK("", "Update staged; it will complete after this process exits.")
 		return nil
 	}
@@ -194,10 +281,25 @@ func runUpdate(cmd *cobra.Command, _ []string) error {
 	if err := installWithRollback(currentPath, newBinPath, backupPath, latestVersion); err != nil {
 		return err
 	}
+	recordUpdate(journal.OpUpdate, version, latestVersion, currentPath, false)
 	printOK("", fmt.Sprintf("Updated to %s", latestTag))
 	return nil
 }
 
+// recordUpdate journals a binary replacement by 'axon update' or
+// 'axon update --rollback'. staged marks Windows swaps that finish after exit.
+func recordUpdate(op, from, to, binPath string, staged bool) {
+	detail := fmt.Sprintf("%s → %s", from, to)
+	if staged {
+		detail += " (staged)"
+	}
+	recordHistory(journal.Entry{
+		Op:     op,
+		Detail: detail,
+		Data:   map[string]string{"from": from, "to": to, "binary": binPath},
+	})
+}
+
 // normalizeReleaseVersion converts a GitHub release tag (e.g. "v0.1.9")
 // to the version string embedded in binaries and archive names (e.g. "0.1.9").
 func normalizeReleaseVersion(tag string) string {
@@ -216,83 +318,57 @@ func splitRepo(s string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
-// fetchRelease retrieves release metadata from GitHub.
-func fetchRelease(ctx context.Context, owner, repo string, allowPrerelease bool) (*githubRelease, error) {
-	client := &http.Client{}
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", owner, repo)
-	if allowPrerelease {
-		url = fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", owner, repo)
-	}
+// Update channels accepted by --channel and update_channel.
+const (
+	updateChannelStable  = "stable"
+	updateChannelBeta    = "beta"
+	updateChannelNightly = "nightly"
 
-	var tokenEnv string
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", "axon-cli")
-	if tok := os.Getenv("AXON_GITHUB_TOKEN"); tok != "" {
-		req.Header.Set("Authorization", "Bearer "+tok)
-		tokenEnv = "AXON_GITHUB_TOKEN"
-	} else if tok := os.Getenv("GITHUB_TOKEN"); tok != "" {
-		req.Header.Set("Authorization", "Bearer "+tok)
-		tokenEnv = "GITHUB_TOKEN"
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("github api request failed: %w", err)
-	}
-
-	if tokenEnv != "" && resp.StatusCode == http.StatusUnauthorized {
-		printWarn("", fmt.Sprintf("Authentication failed with %s. Retrying without authentication...", tokenEnv))
-		printInfo("", "If this keeps happening, unset the environment variable:")
-		fmt.Printf("  unset %s\n", tokenEnv)
-		fmt.Println()
-
-		_ = resp.Body.Close()
-
-		req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("User-Agent", "axon-cli")
-
-		resp, err = client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("github api request failed (retry): %w", err)
-		}
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 8192))
-		return nil, fmt.Errorf("github api request failed: %s\n%s", resp.Status, strings.TrimSpace(string(body)))
-	}
+	// nightlyReleaseTag is the rolling GitHub release the nightly channel tracks.
+	nightlyReleaseTag = "nightly"
+)
 
-	if !allowPrerelease {
-		var rel githubRelease
-		if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
-			return nil, fmt.Errorf("cannot decode release response: %w", err)
-		}
-		return &rel, nil
-	}
+// resolveUpdateChannel picks the release channel from the --channel flag, the
+// legacy --prerelease flag, or the update_channel config key, in that order.
+// It also returns a short description of where the value came from.
+func resolveUpdateChannel(flagValue string, prerelease bool, cfgValue string) (string, string, error) {
+	channel, source := updateChannelStable, "default"
+	switch {
+	case strings.TrimSpace(flagValue) != "":
+		channel, source = flagValue, "--channel"
+	case prerelease:
+		channel, source = updateChannelBeta, "--prerelease"
+	case strings.TrimSpace(cfgValue) != "":
+		channel, source = cfgValue, "update_channel in axon.yaml"
+	}
+	channel = strings.ToLower(strings.TrimSpace(channel))
+	switch channel {
+	case updateChannelStable, updateChannelBeta, updateChannelNightly:
+		return channel, source, nil
+	}
+	return "", "", fmt.Errorf("invalid update channel %q from %s (expected stable, beta, or nightly)", channel, source)
+}
 
-	var rels []githubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&rels); err != nil {
-		return nil, fmt.Errorf("cannot decode releases response: %w", err)
+// nightlyReleaseVersion derives the build version of a nightly release from
+// its archive name for the given platform (axon_<version>_<os>_<arch>.<ext>).
+func nightlyReleaseVersion(rel *githubRelease, goos, goarch string) (string, error) {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
 	}
-	for _, r := range rels {
-		if r.Draft {
-			continue
+	suffix := fmt.Sprintf("_%s_%s%s", goos, goarch, ext)
+	for _, a := range rel.Assets {
+		if strings.HasPrefix(a.Name, "axon_") && strings.HasSuffix(a.Name, suffix) {
+			if v := strings.TrimSuffix(strings.TrimPrefix(a.Name, "axon_"), suffix); v != "" {
+				return v, nil
+			}
 		}
-		return &r, nil
 	}
-	return nil, fmt.Errorf("no releases found")
+	return "", fmt.Errorf("nightly release has no archive for %s/%s", goos, goarch)
 }
 
-// selectReleaseAsset chooses the correct release archive for the current platform.
-func selectReleaseAsset(rel *githubRelease, versionTag, goos, goarch string) (*githubAsset, error) {
-	expected := expectedArchiveName(versionTag, goos, goarch)
+// selectReleaseAsset returns the release asset named expected.
+func selectReleaseAsset(rel *githubRelease, expected, goos, goarch string) (*githubAsset, error) {
 	for _, a := range rel.Assets {
 		if a.Name == expected {
 			return &a, nil
@@ -318,11 +394,12 @@ func expectedArchiveName(versionTag, goos, goarch string) string {
 // chooseWritableTempBase selects a temp base directory that is very likely to be writable.
 func chooseWritableTempBase() (string, error) {
 	candidates := []string{os.TempDir()}
-	if cacheDir, err := os.UserCacheDir(); err == nil && cacheDir != "" {
+	// Portable mode keeps everything in the portable directory.
+	if cacheDir, err := os.UserCacheDir(); err == nil && cacheDir != "" && config.PortableDir() == "" {
 		candidates = append(candidates, filepath.Join(cacheDir, "axon", "tmp"))
 	}
-	if home, err := os.UserHomeDir(); err == nil && home != "" {
-		candidates = append(candidates, filepath.Join(home, ".axon", "tmp"))
+	if axonDir, err := config.AxonDir(); err == nil {
+		candidates = append(candidates, filepath.Join(axonDir, "tmp"))
 	}
 
 	for _, base := range candidates {
@@ -342,14 +419,18 @@ func chooseWritableTempBase() (string, error) {
 	return "", fmt.Errorf("no writable temp directory found")
 }
 
-// downloadWithProgress downloads a URL to dest while printing a byte-based progress indicator.
-func downloadWithProgress(ctx context.Context, url, dest string, verbose bool) error {
-	client := &http.Client{}
+// downloadWithProgress downloads a URL to dest while showing a byte-based progress indicator.
+// authorize, if set, adds credentials to the request.
+func downloadWithProgress(ctx context.Context, url, dest string, authorize func(*http.Request), verbose bool) error {
+	client := newDownloadClient()
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return err
 	}
 	req.Header.Set("User-Agent", "axon-cli")
+	if authorize != nil {
+		authorize(req)
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -367,9 +448,10 @@ func downloadWithProgress(ctx context.Context, url, dest string, verbose bool) e
 	}
 	defer out.Close()
 
-	total := resp.ContentLength
+	total := max(resp.ContentLength, 0)
 	var downloaded int64
-	lastPrint := time.Now()
+	progress := startByteProgress("Downloading", total)
+	defer progress.Done()
 	buf := make([]byte, 32*1024)
 	for {
 		n, rerr := resp.Body.Read(buf)
@@ -378,10 +460,7 @@ func downloadWithProgress(ctx context.Context, url, dest string, verbose bool) e
 				return fmt.Errorf("write failed: %w", werr)
 			}
 			downloaded += int64(n)
-			if time.Since(lastPrint) > 200*time.Millisecond {
-				printDownloadProgress(downloaded, total)
-				lastPrint = time.Now()
-			}
+			progress.Set(downloaded, total)
 		}
 		if rerr != nil {
 			if errors.Is(rerr, io.EOF) {
@@ -390,24 +469,13 @@ func downloadWithProgress(ctx context.Context, url, dest string, verbose bool) e
 			return fmt.Errorf("download read failed: %w", rerr)
 		}
 	}
-	printDownloadProgress(downloaded, total)
-	fmt.Fprintln(os.Stderr)
+	progress.Done()
 	if verbose {
 		printInfo("", fmt.Sprintf("Downloaded %d bytes to %s", downloaded, dest))
 	}
 	return nil
 }
 
-// printDownloadProgress renders a single-line progress indicator to stderr.
-func printDownloadProgress(downloaded, total int64) {
-	if total > 0 {
-		pct := float64(downloaded) / float64(total) * 100
-		fmt.Fprintf(os.Stderr, "\rDownloading... %s / %s (%.1f%%)", humanBytes(downloaded), humanBytes(total), pct)
-		return
-	}
-	fmt.Fprintf(os.Stderr, "\rDownloading... %s", humanBytes(downloaded))
-}
-
 // humanBytes formats a byte count in a human-friendly binary unit.
 func humanBytes(n int64) string {
 	const unit = 1024
@@ -438,26 +506,31 @@ func findChecksumAsset(rel *githubRelease) (*githubAsset, bool) {
 	return nil, false
 }
 
-// fetchExpectedSHA256 downloads a checksum manifest and extracts the SHA256 for the given filename.
-func fetchExpectedSHA256(ctx context.Context, checksumURL, filename string) (string, error) {
-	client := &http.Client{}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checksumURL, nil)
+// fetchReleaseFile downloads a small release asset (checksum manifest or
+// signature) into memory. what names the asset in error messages.
+func fetchReleaseFile(ctx context.Context, src releaseSource, fileURL, what string) ([]byte, error) {
+	client := newDownloadClient()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	req.Header.Set("User-Agent", "axon-cli")
+	src.authorizeDownload(req)
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("checksum download failed: %w", err)
+		return nil, fmt.Errorf("%s download failed: %w", what, err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 8192))
-		return "", fmt.Errorf("checksum download failed: %s\n%s", resp.Status, strings.TrimSpace(string(body)))
+		return nil, fmt.Errorf("%s download failed: %s\n%s", what, resp.Status, strings.TrimSpace(string(body)))
 	}
-
-	return parseExpectedSHA256(resp.Body, filename)
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("%s download failed: %w", what, err)
+	}
+	return data, nil
 }
 
 // parseExpectedSHA256 parses a checksums manifest stream and returns the SHA256 for filename.
@@ -713,14 +786,13 @@ func acquireUpdateLock(timeout time.Duration) (*flock.Flock, func(), error) {
 
 // updateLockPath determines the per-user lock path used to prevent concurrent updates.
 func updateLockPath() (string, error) {
-	if cacheDir, err := os.UserCacheDir(); err == nil && cacheDir != "" {
+	if cacheDir, err := os.UserCacheDir(); err == nil && cacheDir != "" && config.PortableDir() == "" {
 		dir := filepath.Join(cacheDir, "axon")
 		if err := os.MkdirAll(dir, 0o755); err == nil {
 			return filepath.Join(dir, "update.lock"), nil
 		}
 	}
-	if home, err := os.UserHomeDir(); err == nil && home != "" {
-		dir := filepath.Join(home, ".axon")
+	if dir, err := config.AxonDir(); err == nil {
 		if err := os.MkdirAll(dir, 0o755); err == nil {
 			return filepath.Join(dir, "update.lock"), nil
 		}
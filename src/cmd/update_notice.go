@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultUpdateRepo is the GitHub repo axon updates from.
+	defaultUpdateRepo = "kamusis/axon-cli"
+
+	// updateCheckInterval rate-limits background release checks.
+	updateCheckInterval = 24 * time.Hour
+	// updateCheckTimeout bounds the background network request.
+	updateCheckTimeout = 3 * time.Second
+	// updateCheckGrace is the longest a command waits at exit for an
+	// in-flight check. Anything slower is abandoned and retried next time.
+	updateCheckGrace = 200 * time.Millisecond
+)
+
+// updateCheckCache is the on-disk record of the last background check.
+type updateCheckCache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Channel   string    `json:"channel"`
+	Latest    string    `json:"latest"`
+}
+
+// backgroundUpdateCheck tracks one asynchronous release check.
+type backgroundUpdateCheck struct {
+	done   chan struct{}
+	latest string // valid once done is closed
+}
+
+// pendingUpdateCheck is started by the root command and reported by Execute.
+var pendingUpdateCheck *backgroundUpdateCheck
+
+// latestVersionFetcher is the network query of the background check;
+// tests replace it.
+var latestVersionFetcher = fetchLatestVersion
+
+// updateCheckCachePath returns ~/.axon/cache/update-check.json.
+func updateCheckCachePath() (string, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(axonDir, "cache", "update-check.json"), nil
+}
+
+// updateCheckEnabled reports whether the background check should run for cmd.
+// It is opt-in via update_check in axon.yaml and can always be disabled with
+// AXON_NO_UPDATE_CHECK.
+func updateCheckEnabled(cmd *cobra.Command, cfg *config.Config) bool {
+	if cfg == nil || !cfg.UpdateCheck {
+		return false
+	}
+	if v := strings.TrimSpace(os.Getenv("AXON_NO_UPDATE_CHECK")); v != "" {
+		if off, err := strconv.ParseBool(v); err != nil || off {
+			return false
+		}
+	}
+	if version == "dev" {
+		return false
+	}
+	switch cmd.Name() {
+	case "update", "__selfupdate-swap", "version", "completion", "help", "__complete":
+		return false
+	}
+	return true
+}
+
+// startBackgroundUpdateCheck kicks off the daily release check for cmd. A
+// fresh cache is used directly; otherwise GitHub is queried in a goroutine.
+func startBackgroundUpdateCheck(cmd *cobra.Command) {
+	cfg, err := config.Load()
+	if err != nil || !updateCheckEnabled(cmd, cfg) {
+		return
+	}
+	channel, _, err := resolveUpdateChannel("", false, cfg.UpdateChannel)
+	if err != nil {
+		return
+	}
+	cachePath, err := updateCheckCachePath()
+	if err != nil {
+		return
+	}
+
+	check := &backgroundUpdateCheck{done: make(chan struct{})}
+	pendingUpdateCheck = check
+
+	cached, ok := readUpdateCheckCache(cachePath)
+	if ok && cached.Channel == channel && time.Since(cached.CheckedAt) < updateCheckInterval {
+		check.latest = cached.Latest
+		close(check.done)
+		return
+	}
+	// Record the attempt before going to the network, so a check that fails
+	// or outlives updateCheckGrace is not retried by every command that day.
+	// The last known release stays in the cache until a fetch replaces it.
+	attempt := updateCheckCache{CheckedAt: time.Now(), Channel: channel}
+	if ok && cached.Channel == channel {
+		attempt.Latest = cached.Latest
+	}
+	if err := writeUpdateCheckCache(cachePath, attempt); err != nil {
+		close(check.done)
+		return
+	}
+
+	go func() {
+		defer close(check.done)
+		ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+		defer cancel()
+		latest, err := latestVersionFetcher(ctx, channel)
+		if err != nil {
+			return
+		}
+		check.latest = latest
+		_ = writeUpdateCheckCache(cachePath, updateCheckCache{CheckedAt: time.Now(), Channel: channel, Latest: latest})
+	}()
+}
+
+// finishBackgroundUpdateCheck prints a one-line notice to stderr if a newer
+// release is known. It waits at most updateCheckGrace for an in-flight check.
+func finishBackgroundUpdateCheck() {
+	check := pendingUpdateCheck
+	if check == nil {
+		return
+	}
+	select {
+	case <-check.done:
+	case <-time.After(updateCheckGrace):
+		return
+	}
+	if check.latest != "" && isNewerVersion(check.latest, version) {
+		fmt.Fprintf(os.Stderr, "\n  %s  axon %s is available (you have %s), run 'axon update'\n", iconInfo, check.latest, version)
+	}
+}
+
+// fetchLatestVersion returns the newest version on channel without printing.
+func fetchLatestVersion(ctx context.Context, channel string) (string, error) {
+	src, err := parseReleaseSource(defaultUpdateRepo)
+	if err != nil {
+		return "", err
+	}
+	rel, err := src.fetchRelease(ctx, channel, false)
+	if err != nil {
+		return "", err
+	}
+	if channel == updateChannelNightly {
+		return nightlyReleaseVersion(rel, runtime.GOOS, runtime.GOARCH)
+	}
+	return normalizeReleaseVersion(rel.TagName), nil
+}
+
+func readUpdateCheckCache(path string) (updateCheckCache, bool) {
+	var c updateCheckCache
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return c, false
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, false
+	}
+	return c, true
+}
+
+func writeUpdateCheckCache(path string, c updateCheckCache) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// isNewerVersion reports whether latest is a higher version than current.
+// Versions are compared numerically by dot-separated core components; a
+// release without a pre-release suffix ranks above one with the same core.
+func isNewerVersion(latest, current string) bool {
+	latest, current = normalizeReleaseVersion(latest), normalizeReleaseVersion(current)
+	if latest == "" || latest == current {
+		return false
+	}
+	lCore, lPre, _ := strings.Cut(latest, "-")
+	cCore, cPre, _ := strings.Cut(current, "-")
+	lp, cp := strings.Split(lCore, "."), strings.Split(cCore, ".")
+	for i := 0; i < len(lp) || i < len(cp); i++ {
+		var l, c int
+		if i < len(lp) {
+			l, _ = strconv.Atoi(lp[i])
+		}
+		if i < len(cp) {
+			c, _ = strconv.Atoi(cp[i])
+		}
+		if l != c {
+			return l > c
+		}
+	}
+	switch {
+	case lPre == "" && cPre != "":
+		return true
+	case lPre != "" && cPre == "":
+		return false
+	}
+	return lPre > cPre
+}
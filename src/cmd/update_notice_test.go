@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestIsNewerVersion(t *testing.T) {
+	cases := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"0.2.0", "0.1.9", true},
+		{"v0.10.0", "0.9.3", true},
+		{"0.1.9", "0.2.0", false},
+		{"0.2.0", "0.2.0", false},
+		{"0.2.0", "0.2.0-beta.1", true},
+		{"0.2.0-beta.2", "0.2.0-beta.1", true},
+		{"0.2.0-beta.1", "0.2.0", false},
+		{"", "0.2.0", false},
+	}
+	for _, c := range cases {
+		if got := isNewerVersion(c.latest, c.current); got != c.want {
+			t.Errorf("isNewerVersion(%q, %q) = %v, want %v", c.latest, c.current, got, c.want)
+		}
+	}
+}
+
+func TestUpdateCheckEnabled(t *testing.T) {
+	oldVersion := version
+	version = "0.1.0"
+	t.Cleanup(func() { version = oldVersion })
+
+	list := &cobra.Command{Use: "list"}
+	update := &cobra.Command{Use: "update"}
+	on := &config.Config{UpdateCheck: true}
+
+	t.Setenv("AXON_NO_UPDATE_CHECK", "")
+	if updateCheckEnabled(list, &config.Config{}) {
+		t.Error("check must be opt-in")
+	}
+	if !updateCheckEnabled(list, on) {
+		t.Error("expected check enabled when update_check is true")
+	}
+	if updateCheckEnabled(update, on) {
+		t.Error("check must not run for axon update itself")
+	}
+	t.Setenv("AXON_NO_UPDATE_CHECK", "1")
+	if updateCheckEnabled(list, on) {
+		t.Error("AXON_NO_UPDATE_CHECK=1 must disable the check")
+	}
+}
+
+func TestUpdateCheckCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "update-check.json")
+	if _, ok := readUpdateCheckCache(path); ok {
+		t.Fatal("expected missing cache")
+	}
+	want := updateCheckCache{CheckedAt: time.Now().Truncate(time.Second), Channel: "stable", Latest: "0.3.0"}
+	if err := writeUpdateCheckCache(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := readUpdateCheckCache(path)
+	if !ok || got.Latest != want.Latest || got.Channel != want.Channel || !got.CheckedAt.Equal(want.CheckedAt) {
+		t.Fatalf("cache = %+v, want %+v", got, want)
+	}
+}
+
+func TestBackgroundUpdateCheck_RateLimitsFailedAttempts(t *testing.T) {
+	oldVersion, oldFetcher, oldPending := version, latestVersionFetcher, pendingUpdateCheck
+	t.Cleanup(func() { version, latestVersionFetcher, pendingUpdateCheck = oldVersion, oldFetcher, oldPending })
+	version = "0.1.0"
+	list := &cobra.Command{Use: "list"}
+
+	cases := []struct {
+		name  string
+		fetch func(ctx context.Context) (string, error)
+	}{
+		{"fails", func(context.Context) (string, error) { return "", errors.New("offline") }},
+		{"outlives grace", func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv(config.PortableEnv, "")
+			t.Setenv(config.HomeEnv, home)
+			t.Setenv("AXON_NO_UPDATE_CHECK", "")
+			t.Chdir(t.TempDir())
+			if err := os.WriteFile(filepath.Join(home, "axon.yaml"), []byte("update_check: true\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			calls := 0
+			latestVersionFetcher = func(_ context.Context, _ string) (string, error) {
+				calls++
+				return c.fetch(ctx)
+			}
+
+			startBackgroundUpdateCheck(list)
+			first := pendingUpdateCheck
+			finishBackgroundUpdateCheck()
+			cancel()
+			<-first.done
+			if calls != 1 {
+				t.Fatalf("first run fetched %d times, want 1", calls)
+			}
+
+			startBackgroundUpdateCheck(list)
+			<-pendingUpdateCheck.done
+			if calls != 1 {
+				t.Errorf("second run within the interval fetched again after a failed attempt")
+			}
+		})
+	}
+}
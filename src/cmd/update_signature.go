@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// releasePublicKey is the base64-encoded Ed25519 key that signs release
+// checksums. The release workflow derives it from its AXON_RELEASE_SIGNING_KEY
+// secret and sets it at build time:
+//
+//	-X github.com/kamusis/axon-cli/cmd.releasePublicKey=<base64>
+//
+// Builds without it cannot verify signed releases and refuse to install them.
+var releasePublicKey = ""
+
+// firstSignedRelease is the first release published with checksums.txt.sig.
+// Releases older than this predate signing and are installed unsigned, with a
+// warning; from this version on a valid signature is required.
+const firstSignedRelease = "0.4.0"
+
+// checksumSignatureSuffix names the detached signature of the checksum
+// manifest (checksums.txt → checksums.txt.sig).
+const checksumSignatureSuffix = ".sig"
+
+// releaseVerificationKey decodes the release key embedded in this build.
+func releaseVerificationKey() (ed25519.PublicKey, error) {
+	raw := strings.TrimSpace(releasePublicKey)
+	if raw == "" {
+		return nil, fmt.Errorf("this axon build has no release public key and cannot verify signed releases (use --skip-signature to override)")
+	}
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid embedded release public key (expected base64 Ed25519 key)")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// predatesSigning reports whether releaseVersion is older than
+// firstSignedRelease. Pre-release suffixes are ignored, so a beta of the
+// first signed release still needs a signature.
+func predatesSigning(releaseVersion string) bool {
+	core, _, _ := strings.Cut(normalizeReleaseVersion(releaseVersion), "-")
+	return core != "" && isNewerVersion(firstSignedRelease, core)
+}
+
+// verifyChecksumSignature checks sig (base64 or raw Ed25519) over data.
+func verifyChecksumSignature(pub ed25519.PublicKey, data, sig []byte) error {
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return fmt.Errorf("malformed signature: %w", err)
+		}
+		sig = decoded
+	}
+	if len(sig) != ed25519.SignatureSize || !ed25519.Verify(pub, data, sig) {
+		return fmt.Errorf("signature does not match the release public key")
+	}
+	return nil
+}
+
+// findChecksumSignatureAsset returns the signature asset for the checksum
+// manifest named checksumName.
+func findChecksumSignatureAsset(rel *githubRelease, checksumName string) (*githubAsset, bool) {
+	want := checksumName + checksumSignatureSuffix
+	for _, a := range rel.Assets {
+		if a.Name == want {
+			return &a, true
+		}
+	}
+	return nil, false
+}
+
+// verifyReleaseChecksums authenticates the downloaded checksum manifest
+// before any digest in it is trusted. Only --skip-signature bypasses it, and
+// only releases that predate signing may come without a signature.
+func verifyReleaseChecksums(ctx context.Context, src releaseSource, rel *githubRelease, releaseVersion string, checksumAsset *githubAsset, checksums []byte) error {
+	sigAsset, ok := findChecksumSignatureAsset(rel, checksumAsset.Name)
+	if !ok {
+		if predatesSigning(releaseVersion) {
+			printWarn("", fmt.Sprintf("%s predates release signing (first signed: %s); signature NOT verified", releaseVersion, firstSignedRelease))
+			return nil
+		}
+		return fmt.Errorf("release has no %s%s; refusing to install an unsigned release (use --skip-signature to override)", checksumAsset.Name, checksumSignatureSuffix)
+	}
+	pub, err := releaseVerificationKey()
+	if err != nil {
+		return err
+	}
+	sig, err := fetchReleaseFile(ctx, src, sigAsset.BrowserDownloadURL, "signature")
+	if err != nil {
+		return err
+	}
+	if err := verifyChecksumSignature(pub, checksums, sig); err != nil {
+		return fmt.Errorf("%s: %w", sigAsset.Name, err)
+	}
+	printOK("", "Signature verified.")
+	return nil
+}
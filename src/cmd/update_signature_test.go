@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVerifyChecksumSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("abc  axon_0.2.0_linux_amd64.tar.gz\n")
+	sig := ed25519.Sign(priv, data)
+
+	if err := verifyChecksumSignature(pub, data, sig); err != nil {
+		t.Fatalf("raw signature: %v", err)
+	}
+	b64 := []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+	if err := verifyChecksumSignature(pub, data, b64); err != nil {
+		t.Fatalf("base64 signature: %v", err)
+	}
+	if err := verifyChecksumSignature(pub, []byte("tampered"), b64); err == nil {
+		t.Fatal("expected failure for tampered data")
+	}
+	if err := verifyChecksumSignature(pub, data, []byte("not base64!")); err == nil {
+		t.Fatal("expected failure for malformed signature")
+	}
+}
+
+func TestReleaseVerificationKey(t *testing.T) {
+	old := releasePublicKey
+	t.Cleanup(func() { releasePublicKey = old })
+
+	releasePublicKey = ""
+	if _, err := releaseVerificationKey(); err == nil || !strings.Contains(err.Error(), "--skip-signature") {
+		t.Fatalf("expected missing key error, got %v", err)
+	}
+
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	releasePublicKey = base64.StdEncoding.EncodeToString(pub)
+	if k, err := releaseVerificationKey(); err != nil || !k.Equal(pub) {
+		t.Fatalf("embedded key = %v %v", k, err)
+	}
+
+	releasePublicKey = "bogus"
+	if _, err := releaseVerificationKey(); err == nil {
+		t.Fatal("expected invalid key error")
+	}
+}
+
+func TestPredatesSigning(t *testing.T) {
+	cases := map[string]bool{
+		"0.3.1":                        true,
+		"v0.3.9":                       true,
+		firstSignedRelease:             false,
+		firstSignedRelease + "-beta.1": false,
+		"1.0.0":                        false,
+		"":                             false,
+	}
+	for v, want := range cases {
+		if got := predatesSigning(v); got != want {
+			t.Errorf("predatesSigning(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestVerifyReleaseChecksums(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	checksums := []byte("deadbeef  axon_0.2.0_linux_amd64.tar.gz\n")
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, checksums))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(sig))
+	}))
+	defer srv.Close()
+
+	old := releasePublicKey
+	t.Cleanup(func() { releasePublicKey = old })
+	releasePublicKey = base64.StdEncoding.EncodeToString(pub)
+
+	ctx := context.Background()
+	src := &githubSource{apiBase: "https://api.github.com", owner: "o", repo: "r"}
+	sumAsset := &githubAsset{Name: "checksums.txt"}
+	rel := &githubRelease{Assets: []githubAsset{*sumAsset, {Name: "checksums.txt.sig", BrowserDownloadURL: srv.URL}}}
+
+	if err := verifyReleaseChecksums(ctx, src, rel, firstSignedRelease, sumAsset, checksums); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+	if err := verifyReleaseChecksums(ctx, src, rel, firstSignedRelease, sumAsset, []byte("evil")); err == nil {
+		t.Fatal("tampered checksums accepted")
+	}
+
+	unsigned := &githubRelease{Assets: []githubAsset{*sumAsset}}
+	err := verifyReleaseChecksums(ctx, src, unsigned, firstSignedRelease, sumAsset, checksums)
+	if err == nil || !strings.Contains(err.Error(), "--skip-signature") {
+		t.Fatalf("expected unsigned release error, got %v", err)
+	}
+	if err := verifyReleaseChecksums(ctx, src, unsigned, "0.3.1", sumAsset, checksums); err != nil {
+		t.Fatalf("release predating signing rejected: %v", err)
+	}
+
+	// A signed release still needs a key in the build.
+	releasePublicKey = ""
+	if err := verifyReleaseChecksums(ctx, src, rel, firstSignedRelease, sumAsset, checksums); err == nil {
+		t.Fatal("signed release accepted by a build without a key")
+	}
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
+	"github.com/kamusis/axon-cli/internal/vendor"
+	"github.com/spf13/cobra"
+)
+
+var updateSkillCmd = &cobra.Command{
+	Use:   "update-skill <name>",
+	Short: "Pull the upstream copy of one vendored skill into the Hub",
+	Long: `Mirror a single vendored skill from its upstream repository, leaving
+the rest of its vendor entry untouched. Run 'axon outdated' to see which
+skills have a newer upstream version.
+
+Like 'axon vendor sync', the upstream copy overwrites the skill directory in
+the Hub. Commit the result with 'axon sync'.
+
+Example:
+  axon update-skill humanizer`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUpdateSkill,
+}
+
+func init() {
+	rootCmd.AddCommand(updateSkillCmd)
+}
+
+func runUpdateSkill(_ *cobra.Command, args []string) error {
+	if err := checkGitAvailable(); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	if err := validateVendors(cfg.Vendors); err != nil {
+		return err
+	}
+	skills, err := vendoredSkills(cfg)
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	var s *vendoredSkill
+	for i := range skills {
+		if skills[i].Name == name {
+			if s != nil {
+				return fmt.Errorf("skill %q is provided by vendors %q and %q; update them with 'axon vendor sync'", name, s.Vendor.Name, skills[i].Vendor.Name)
+			}
+			s = &skills[i]
+		}
+	}
+	if s == nil {
+		return fmt.Errorf("skill %q is not vendored.\nTip: run 'axon outdated' to list vendored skills.", name)
+	}
+
+	if _, err := exec.LookPath("rsync"); err != nil {
+		printWarn("", "rsync not found — will use cp fallback for mirroring")
+	}
+
+	printSection("Update Skill")
+	sha, err := updateVendoredSkill(cfg.RepoPath, *s)
+	if err != nil {
+		printErr(s.Name, err.Error())
+		return fmt.Errorf("update-skill failed")
+	}
+
+	ref := vendorRef(s.Vendor)
+	recordHistory(journal.Entry{
+		Op:     journal.OpVendorSync,
+		Target: s.Vendor.Name,
+		Detail: fmt.Sprintf("%s/%s@%s (%s) → %s", s.Vendor.Repo, s.Subdir, ref, abbrevSHA(sha), s.Dest),
+		Data:   map[string]string{"repo": s.Vendor.Repo, "subdir": s.Subdir, "ref": ref, "sha": sha, "dest": s.Dest, "skill": s.Name},
+	})
+	fmt.Println("   Run 'axon sync' to commit the update.")
+	return nil
+}
+
+// updateVendoredSkill fetches the vendor cache and mirrors only s into the
+// Hub. It returns the upstream commit that last touched the skill.
+func updateVendoredSkill(hubRoot string, s vendoredSkill) (string, error) {
+	cachePath, err := vendor.CachePath(s.Vendor.Repo)
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve cache path: %w", err)
+	}
+	if !vendor.IsCloned(cachePath) {
+		return "", fmt.Errorf("vendor %s is not cached — run 'axon vendor sync'", s.Vendor.Name)
+	}
+
+	printInfo(s.Name, "fetching remote refs…")
+	if err := vendor.Fetch(cachePath, s.Vendor.Depth); err != nil {
+		return "", err
+	}
+	ref := vendorRef(s.Vendor)
+	// A vendor entry that is exactly this skill keeps to its sparse paths,
+	// and one whose transform moves paths is staged whole.
+	paths := []string{s.Subdir}
+	if s.Whole || vendorReshaped(s.Vendor) {
+		paths = vendorSparsePaths(s.Vendor)
+	}
+	sha, err := vendor.SubdirLatestSHA(cachePath, vendor.ResolveRef(cachePath, ref), paths...)
+	if err != nil {
+		return "", err
+	}
+
+	for _, p := range paths {
+		if err := vendor.AddSparseCheckoutDir(cachePath, p); err != nil {
+			return "", err
+		}
+	}
+	printInfo(s.Name, fmt.Sprintf("checking out %s…", ref))
+	if err := checkoutVendorRef(s.Name, cachePath, ref); err != nil {
+		return "", err
+	}
+	src, err := vendor.SourcePath(cachePath, s.Subdir)
+	if err != nil {
+		return "", err
+	}
+	if vendorNeedsStaging(s.Vendor) {
+		staged, skillSrc, err := stageVendoredSkill(s, sha)
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(staged)
+		src = skillSrc
+	}
+
+	printInfo(s.Name, fmt.Sprintf("mirroring %s → %s…", s.Subdir, s.Dest))
+	if err := vendor.Mirror(hubRoot, s.Dest, src); err != nil {
+		return "", err
+	}
+
+	// Record the state so 'axon outdated' sees the skill as current. A
+	// vendor entry that is exactly this skill advances its own state too.
+	if sha != "" {
+		_ = vendor.WriteSkillSHA(s.Vendor.Name, s.Name, sha)
+		if s.Whole {
+			_ = vendor.WriteVendorState(s.Vendor.Name, sha, vendorDigest(s.Vendor))
+		}
+	}
+
+	msg := fmt.Sprintf("updated from %s@%s", s.Subdir, ref)
+	if meta, ok := parseSkillMeta(filepath.Join(s.Dir, "SKILL.md")); ok && meta.Version != "" {
+		msg += fmt.Sprintf(" (version %s)", meta.Version)
+	}
+	printOK(s.Name, msg)
+	return sha, nil
+}
+
+// stageVendoredSkill stages s the way 'axon vendor sync' stages its vendor.
+// It returns the staged tree, which the caller removes, and the skill
+// directory in it. Unless the transform moves paths, only the skill itself
+// is staged.
+func stageVendoredSkill(s vendoredSkill, sha string) (staged, src string, err error) {
+	v := s.Vendor
+	cachePath, err := vendor.CachePath(v.Repo)
+	if err != nil {
+		return "", "", err
+	}
+	root, err := vendor.SourcePath(cachePath, v.Subdir)
+	if err != nil {
+		return "", "", err
+	}
+	paths := cleanSparse(v.Sparse)
+	rel := "."
+	if !s.Whole {
+		dest, err := vendor.ValidateDest(v.Dest)
+		if err != nil {
+			return "", "", err
+		}
+		r, err := filepath.Rel(dest, s.Dest)
+		if err != nil {
+			return "", "", err
+		}
+		rel = filepath.ToSlash(r)
+		if !vendorReshaped(v) {
+			paths = []string{rel}
+		}
+	}
+	staged, err = vendor.Stage(root, paths, vendorTransform(v, sha))
+	if err != nil {
+		return "", "", fmt.Errorf("cannot stage %s: %w", v.Subdir, err)
+	}
+	src = filepath.Join(staged, filepath.FromSlash(rel))
+	if _, err := os.Stat(src); err != nil {
+		os.RemoveAll(staged)
+		return "", "", fmt.Errorf("skill %s is not produced by the transform of vendor %s", s.Name, v.Name)
+	}
+	return staged, src, nil
+}
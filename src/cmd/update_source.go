@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// releaseSource resolves axon releases from a hosting service. Releases are
+// normalized into githubRelease/githubAsset so the download, checksum, and
+// install steps are shared by every source.
+type releaseSource interface {
+	// String describes the source for messages, e.g. "github.com/kamusis/axon-cli".
+	String() string
+	// fetchRelease returns the newest release on channel. When warn is false
+	// nothing is printed.
+	fetchRelease(ctx context.Context, channel string, warn bool) (*githubRelease, error)
+	// archiveName is the release asset holding the binary for a platform.
+	archiveName(version, goos, goarch string) string
+	// checksumAsset locates the checksum manifest of rel.
+	checksumAsset(rel *githubRelease) (*githubAsset, bool)
+	// authorizeDownload adds credentials needed to download release assets.
+	// Credentials are only added when req targets the source's API host.
+	authorizeDownload(req *http.Request)
+}
+
+// parseReleaseSource interprets --repo. Accepted forms:
+//
+//	owner/name                                  GitHub.com
+//	https://github.com/owner/name               GitHub.com
+//	https://ghe.corp/api/v3/repos/owner/name    GitHub Enterprise (API URL)
+//	https://ghe.corp/owner/name                 GitHub Enterprise (web URL)
+//	gitlab:group/project                        GitLab.com
+//	https://gitlab.example.com/group/project    GitLab (host name contains "gitlab")
+//	https://host/api/v4/projects/<id|path>      GitLab (API URL)
+func parseReleaseSource(s string) (releaseSource, error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, "gitlab:"); ok {
+		return newGitLabSource("https://gitlab.com/api/v4", rest, s)
+	}
+	if !strings.Contains(s, "://") {
+		owner, repo, err := splitRepo(s)
+		if err != nil {
+			return nil, err
+		}
+		return &githubSource{apiBase: "https://api.github.com", owner: owner, repo: repo}, nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid --repo %q: %v", s, err)
+	}
+	base := u.Scheme + "://" + u.Host
+	p := strings.Trim(u.Path, "/")
+
+	switch {
+	case strings.Contains(p, "api/v4/projects/"):
+		idx := strings.Index(p, "api/v4/projects/")
+		project := strings.Trim(p[idx+len("api/v4/projects/"):], "/")
+		project = strings.TrimSuffix(project, "/releases")
+		return newGitLabSource(base+"/"+path.Join(p[:idx], "api/v4"), project, s)
+	case strings.Contains(p, "api/v3"):
+		idx := strings.Index(p, "api/v3")
+		rest := strings.TrimPrefix(strings.Trim(p[idx+len("api/v3"):], "/"), "repos/")
+		owner, repo, err := splitRepo(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --repo %q (expected %s/repos/owner/name)", s, base+"/api/v3")
+		}
+		return &githubSource{apiBase: base + "/" + p[:idx+len("api/v3")], owner: owner, repo: repo}, nil
+	case strings.Contains(strings.ToLower(u.Host), "gitlab"):
+		return newGitLabSource(base+"/api/v4", strings.TrimSuffix(p, ".git"), s)
+	}
+
+	owner, repo, err := splitRepo(strings.TrimSuffix(p, ".git"))
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(u.Host, "github.com") {
+		return &githubSource{apiBase: "https://api.github.com", owner: owner, repo: repo}, nil
+	}
+	return &githubSource{apiBase: base + "/api/v3", owner: owner, repo: repo}, nil
+}
+
+// ── GitHub / GitHub Enterprise ────────────────────────────────────────────────
+
+type githubSource struct {
+	apiBase string // https://api.github.com or https://<ghe>/api/v3
+	owner   string
+	repo    string
+}
+
+func (g *githubSource) String() string {
+	host := "github.com"
+	if g.apiBase != "https://api.github.com" {
+		host = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(g.apiBase, "https://"), "http://"), "/api/v3")
+	}
+	return host + "/" + g.owner + "/" + g.repo
+}
+
+func (g *githubSource) archiveName(version, goos, goarch string) string {
+	return expectedArchiveName(version, goos, goarch)
+}
+
+func (g *githubSource) checksumAsset(rel *githubRelease) (*githubAsset, bool) {
+	return findChecksumAsset(rel)
+}
+
+// authorizeDownload sends the token only to Enterprise hosts. github.com
+// assets are public downloads and historically fetched anonymously.
+func (g *githubSource) authorizeDownload(req *http.Request) {
+	if g.apiBase == "https://api.github.com" || !sameHost(req.URL, g.apiBase) {
+		return
+	}
+	if tok, _ := envToken("AXON_GITHUB_TOKEN", "GITHUB_TOKEN"); tok != "" {
+		req.Header.Set("Authorization", "Bearer "+tok)
+	}
+}
+
+func (g *githubSource) fetchRelease(ctx context.Context, channel string, warn bool) (*githubRelease, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/releases/latest", g.apiBase, g.owner, g.repo)
+	switch channel {
+	case updateChannelBeta:
+		endpoint = fmt.Sprintf("%s/repos/%s/%s/releases", g.apiBase, g.owner, g.repo)
+	case updateChannelNightly:
+		endpoint = fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", g.apiBase, g.owner, g.repo, nightlyReleaseTag)
+	}
+
+	auth := func(req *http.Request, tok string) { req.Header.Set("Authorization", "Bearer "+tok) }
+	body, err := getReleaseJSON(ctx, endpoint, auth, warn, "AXON_GITHUB_TOKEN", "GITHUB_TOKEN")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if channel != updateChannelBeta {
+		var rel githubRelease
+		if err := json.NewDecoder(body).Decode(&rel); err != nil {
+			return nil, fmt.Errorf("cannot decode release response: %w", err)
+		}
+		return &rel, nil
+	}
+
+	var rels []githubRelease
+	if err := json.NewDecoder(body).Decode(&rels); err != nil {
+		return nil, fmt.Errorf("cannot decode releases response: %w", err)
+	}
+	for _, r := range rels {
+		if r.Draft {
+			continue
+		}
+		return &r, nil
+	}
+	return nil, fmt.Errorf("no releases found")
+}
+
+// ── GitLab ────────────────────────────────────────────────────────────────────
+
+type gitlabSource struct {
+	apiBase string // https://<host>/api/v4
+	project string // numeric ID or full path (group/subgroup/project)
+}
+
+func newGitLabSource(apiBase, project, raw string) (*gitlabSource, error) {
+	if p, err := url.PathUnescape(project); err == nil {
+		project = p
+	}
+	project = strings.Trim(project, "/")
+	if project == "" {
+		return nil, fmt.Errorf("invalid --repo %q (missing GitLab project)", raw)
+	}
+	return &gitlabSource{apiBase: strings.TrimSuffix(apiBase, "/"), project: project}, nil
+}
+
+func (g *gitlabSource) String() string {
+	host := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(g.apiBase, "https://"), "http://"), "/api/v4")
+	return host + "/" + g.project
+}
+
+func (g *gitlabSource) archiveName(version, goos, goarch string) string {
+	return expectedArchiveName(version, goos, goarch)
+}
+
+func (g *gitlabSource) checksumAsset(rel *githubRelease) (*githubAsset, bool) {
+	return findChecksumAsset(rel)
+}
+
+// authorizeDownload sends the token only to the GitLab host itself. Release
+// links may point anywhere, and PRIVATE-TOKEN is not a header net/http
+// strips on its own.
+func (g *gitlabSource) authorizeDownload(req *http.Request) {
+	if !sameHost(req.URL, g.apiBase) {
+		return
+	}
+	if tok, _ := envToken("AXON_GITLAB_TOKEN", "GITLAB_TOKEN"); tok != "" {
+		req.Header.Set("PRIVATE-TOKEN", tok)
+	}
+}
+
+// gitlabRelease models the subset of the GitLab Releases API used by axon.
+type gitlabRelease struct {
+	TagName    string    `json:"tag_name"`
+	Upcoming   bool      `json:"upcoming_release"`
+	ReleasedAt time.Time `json:"released_at"`
+	Assets     struct {
+		Links []struct {
+			Name           string `json:"name"`
+			URL            string `json:"url"`
+			DirectAssetURL string `json:"direct_asset_url"`
+		} `json:"links"`
+	} `json:"assets"`
+}
+
+func (r gitlabRelease) normalize() *githubRelease {
+	rel := &githubRelease{TagName: r.TagName, Pre: r.Upcoming}
+	for _, l := range r.Assets.Links {
+		u := l.DirectAssetURL
+		if u == "" {
+			u = l.URL
+		}
+		rel.Assets = append(rel.Assets, githubAsset{Name: l.Name, BrowserDownloadURL: u})
+	}
+	return rel
+}
+
+func (g *gitlabSource) fetchRelease(ctx context.Context, channel string, warn bool) (*githubRelease, error) {
+	projectURL := g.apiBase + "/projects/" + url.PathEscape(g.project)
+	endpoint := projectURL + "/releases"
+	if channel == updateChannelNightly {
+		endpoint = projectURL + "/releases/" + url.PathEscape(nightlyReleaseTag)
+	}
+
+	auth := func(req *http.Request, tok string) { req.Header.Set("PRIVATE-TOKEN", tok) }
+	body, err := getReleaseJSON(ctx, endpoint, auth, warn, "AXON_GITLAB_TOKEN", "GITLAB_TOKEN")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if channel == updateChannelNightly {
+		var rel gitlabRelease
+		if err := json.NewDecoder(body).Decode(&rel); err != nil {
+			return nil, fmt.Errorf("cannot decode release response: %w", err)
+		}
+		return rel.normalize(), nil
+	}
+
+	var rels []gitlabRelease
+	if err := json.NewDecoder(body).Decode(&rels); err != nil {
+		return nil, fmt.Errorf("cannot decode releases response: %w", err)
+	}
+	sort.SliceStable(rels, func(i, j int) bool { return rels[i].ReleasedAt.After(rels[j].ReleasedAt) })
+	for _, r := range rels {
+		// GitLab has no prerelease flag; upcoming releases are the closest match.
+		if r.Upcoming && channel != updateChannelBeta {
+			continue
+		}
+		return r.normalize(), nil
+	}
+	return nil, fmt.Errorf("no releases found")
+}
+
+// ── Shared HTTP helpers ───────────────────────────────────────────────────────
+
+// envToken returns the first non-empty token among envs and its variable name.
+func envToken(envs ...string) (string, string) {
+	for _, e := range envs {
+		if tok := os.Getenv(e); tok != "" {
+			return tok, e
+		}
+	}
+	return "", ""
+}
+
+// sameHost reports whether u is served by the host of apiBase.
+func sameHost(u *url.URL, apiBase string) bool {
+	base, err := url.Parse(apiBase)
+	if err != nil || u == nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, base.Host)
+}
+
+// newDownloadClient returns an HTTP client for release assets. Credential
+// headers set by authorizeDownload are dropped when a redirect leaves the
+// original host; net/http already does this for Authorization but not for
+// PRIVATE-TOKEN.
+func newDownloadClient() *http.Client {
+	return &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			if !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+				req.Header.Del("Authorization")
+				req.Header.Del("PRIVATE-TOKEN")
+			}
+			return nil
+		},
+	}
+}
+
+// getReleaseJSON GETs a release API endpoint, authenticating with the first
+// token found in tokenEnvs. If the token is rejected, the request is retried
+// anonymously (with a hint when warn is set). The caller closes the body.
+func getReleaseJSON(ctx context.Context, endpoint string, auth func(*http.Request, string), warn bool, tokenEnvs ...string) (io.ReadCloser, error) {
+	client := &http.Client{}
+	newReq := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "axon-cli")
+		return req, nil
+	}
+
+	req, err := newReq()
+	if err != nil {
+		return nil, err
+	}
+	tok, tokenEnv := envToken(tokenEnvs...)
+	if tok != "" {
+		auth(req, tok)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("release api request failed: %w", err)
+	}
+
+	if tokenEnv != "" && resp.StatusCode == http.StatusUnauthorized {
+		if warn {
+			printWarn("", fmt.Sprintf("Authentication failed with %s. Retrying without authentication...", tokenEnv))
+			printInfo("", "If this keeps happening, unset the environment variable:")
+			fmt.Printf("  unset %s\n", tokenEnv)
+			fmt.Println()
+		}
+
+		_ = resp.Body.Close()
+
+		req, err = newReq()
+		if err != nil {
+			return nil, err
+		}
+		resp, err = client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("release api request failed (retry): %w", err)
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 8192))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("release api request failed: %s\n%s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp.Body, nil
+}
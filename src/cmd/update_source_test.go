@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseReleaseSource(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		api  string
+	}{
+		{"kamusis/axon-cli", "github.com/kamusis/axon-cli", "https://api.github.com"},
+		{"https://github.com/kamusis/axon-cli.git", "github.com/kamusis/axon-cli", "https://api.github.com"},
+		{"https://ghe.corp/api/v3/repos/tools/axon", "ghe.corp/tools/axon", "https://ghe.corp/api/v3"},
+		{"https://ghe.corp/tools/axon", "ghe.corp/tools/axon", "https://ghe.corp/api/v3"},
+		{"gitlab:group/sub/axon", "gitlab.com/group/sub/axon", "https://gitlab.com/api/v4"},
+		{"https://gitlab.corp/group/axon", "gitlab.corp/group/axon", "https://gitlab.corp/api/v4"},
+		{"https://code.corp/api/v4/projects/group%2Faxon", "code.corp/group/axon", "https://code.corp/api/v4"},
+		{"https://host/gitlab/api/v4/projects/x", "host/gitlab/x", "https://host/gitlab/api/v4"},
+	}
+	for _, c := range cases {
+		src, err := parseReleaseSource(c.in)
+		if err != nil {
+			t.Fatalf("parseReleaseSource(%q): %v", c.in, err)
+		}
+		if got := src.String(); got != c.want {
+			t.Errorf("parseReleaseSource(%q) = %q, want %q", c.in, got, c.want)
+		}
+		var api string
+		switch s := src.(type) {
+		case *githubSource:
+			api = s.apiBase
+		case *gitlabSource:
+			api = s.apiBase
+		}
+		if api != c.api {
+			t.Errorf("parseReleaseSource(%q) api = %q, want %q", c.in, api, c.api)
+		}
+	}
+
+	for _, bad := range []string{"", "axon-cli", "gitlab:", "https://ghe.corp/api/v3/repos/only"} {
+		if _, err := parseReleaseSource(bad); err == nil {
+			t.Errorf("parseReleaseSource(%q): expected error", bad)
+		}
+	}
+}
+
+func TestGitLabSource_FetchRelease(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/group%2Faxon/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"tag_name":"v0.3.0","upcoming_release":true,"released_at":"2026-10-10T00:00:00Z","assets":{"links":[]}},
+			{"tag_name":"v0.2.0","released_at":"2026-09-01T00:00:00Z","assets":{"links":[
+				{"name":"axon_0.2.0_linux_amd64.tar.gz","url":"https://x/a","direct_asset_url":"https://x/direct"},
+				{"name":"checksums.txt","url":"https://x/c"}]}}
+		]`))
+	}))
+	defer srv.Close()
+	t.Setenv("AXON_GITLAB_TOKEN", "secret")
+
+	src, err := newGitLabSource(srv.URL+"/api/v4", "group/axon", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := src.fetchRelease(context.Background(), updateChannelStable, false)
+	if err != nil {
+		t.Fatalf("fetchRelease: %v", err)
+	}
+	if rel.TagName != "v0.2.0" || len(rel.Assets) != 2 || rel.Assets[0].BrowserDownloadURL != "https://x/direct" {
+		t.Fatalf("unexpected stable release: %+v", rel)
+	}
+	if a, ok := src.checksumAsset(rel); !ok || a.Name != "checksums.txt" {
+		t.Fatalf("checksum asset = %+v, %v", a, ok)
+	}
+
+	rel, err = src.fetchRelease(context.Background(), updateChannelBeta, false)
+	if err != nil || rel.TagName != "v0.3.0" {
+		t.Fatalf("beta release = %+v, %v", rel, err)
+	}
+}
+
+func TestGitHubSource_EnterpriseFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/repos/tools/axon/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"tag_name":"v1.0.0","assets":[{"name":"axon_1.0.0_linux_amd64.tar.gz"}]}`))
+	}))
+	defer srv.Close()
+	t.Setenv("AXON_GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+
+	src, err := parseReleaseSource(srv.URL + "/api/v3/repos/tools/axon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := src.fetchRelease(context.Background(), updateChannelStable, false)
+	if err != nil || rel.TagName != "v1.0.0" {
+		t.Fatalf("release = %+v, %v", rel, err)
+	}
+	if got := src.archiveName("1.0.0", "linux", "amd64"); got != rel.Assets[0].Name {
+		t.Fatalf("archiveName = %q", got)
+	}
+}
+
+func TestGitLabSource_DownloadTokenStaysOnAPIHost(t *testing.T) {
+	var foreignToken string
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignToken += r.Header.Get("PRIVATE-TOKEN")
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer foreign.Close()
+
+	var apiToken string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiToken = r.Header.Get("PRIVATE-TOKEN")
+		http.Redirect(w, r, foreign.URL+"/storage/checksums.txt", http.StatusFound)
+	}))
+	defer api.Close()
+	t.Setenv("AXON_GITLAB_TOKEN", "secret")
+
+	src, err := newGitLabSource(api.URL+"/api/v4", "group/axon", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Asset link on a foreign host: no token at all.
+	if _, err := fetchReleaseFile(context.Background(), src, foreign.URL+"/checksums.txt", "checksum"); err != nil {
+		t.Fatalf("fetchReleaseFile (foreign): %v", err)
+	}
+	if foreignToken != "" {
+		t.Fatalf("foreign host received PRIVATE-TOKEN %q", foreignToken)
+	}
+
+	// Asset on the API host redirecting elsewhere: token dropped on redirect.
+	dest := filepath.Join(t.TempDir(), "asset")
+	if err := downloadWithProgress(context.Background(), api.URL+"/uploads/asset", dest, src.authorizeDownload, false); err != nil {
+		t.Fatalf("downloadWithProgress: %v", err)
+	}
+	if apiToken != "secret" {
+		t.Fatalf("API host token = %q, want secret", apiToken)
+	}
+	if foreignToken != "" {
+		t.Fatalf("redirect target received PRIVATE-TOKEN %q", foreignToken)
+	}
+}
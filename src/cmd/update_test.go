@@ -68,3 +68,45 @@ func TestSanitizeArchivePath(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveUpdateChannel(t *testing.T) {
+	cases := []struct {
+		flag       string
+		prerelease bool
+		cfg        string
+		want       string
+		wantSource string
+	}{
+		{"", false, "", "stable", "default"},
+		{"", false, "beta", "beta", "update_channel in axon.yaml"},
+		{"", true, "nightly", "beta", "--prerelease"},
+		{"Nightly", true, "beta", "nightly", "--channel"},
+	}
+	for _, c := range cases {
+		got, src, err := resolveUpdateChannel(c.flag, c.prerelease, c.cfg)
+		if err != nil {
+			t.Fatalf("resolveUpdateChannel(%q,%v,%q): %v", c.flag, c.prerelease, c.cfg, err)
+		}
+		if got != c.want || src != c.wantSource {
+			t.Fatalf("resolveUpdateChannel(%q,%v,%q) = %q (%s), want %q (%s)", c.flag, c.prerelease, c.cfg, got, src, c.want, c.wantSource)
+		}
+	}
+	if _, _, err := resolveUpdateChannel("", false, "edge"); err == nil || !strings.Contains(err.Error(), "axon.yaml") {
+		t.Fatalf("expected invalid channel error naming axon.yaml, got %v", err)
+	}
+}
+
+func TestNightlyReleaseVersion(t *testing.T) {
+	rel := &githubRelease{TagName: "nightly", Assets: []githubAsset{
+		{Name: "checksums.txt"},
+		{Name: "axon_0.3.0-next.20261016_darwin_arm64.tar.gz"},
+		{Name: "axon_0.3.0-next.20261016_linux_amd64.tar.gz"},
+	}}
+	got, err := nightlyReleaseVersion(rel, "linux", "amd64")
+	if err != nil || got != "0.3.0-next.20261016" {
+		t.Fatalf("nightlyReleaseVersion = %q, %v", got, err)
+	}
+	if _, err := nightlyReleaseVersion(rel, "windows", "amd64"); err == nil {
+		t.Fatal("expected error for missing platform archive")
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
+)
+
+// defaultKeepVersions is how many replaced binaries axon update retains.
+const defaultKeepVersions = 3
+
+// archivedVersion is a previously installed axon binary kept for rollback.
+type archivedVersion struct {
+	Version string
+	Path    string
+	ModTime time.Time
+}
+
+// versionsDir returns ~/.axon/versions, where replaced binaries are kept.
+func versionsDir() (string, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(axonDir, "versions"), nil
+}
+
+// archivedBinaryName returns the file name used to store binary version ver.
+func archivedBinaryName(ver string) string {
+	if runtime.GOOS == "windows" {
+		return "axon-" + ver + ".exe"
+	}
+	return "axon-" + ver
+}
+
+// archiveBinary copies the binary at binPath into dir as version ver, then
+// prunes dir down to the keep most recently archived versions.
+func archiveBinary(dir, binPath, ver string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("cannot create %s: %w", dir, err)
+	}
+	dst := filepath.Join(dir, archivedBinaryName(ver))
+	if err := copyFile(binPath, dst); err != nil {
+		return fmt.Errorf("cannot archive current binary: %w", err)
+	}
+	// Order by archive time, not build time, so pruning keeps the most
+	// recently replaced binaries.
+	now := time.Now()
+	_ = os.Chtimes(dst, now, now)
+	return pruneArchivedVersions(dir, keep)
+}
+
+// listArchivedVersions returns archived binaries in dir, newest first.
+func listArchivedVersions(dir string) ([]archivedVersion, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []archivedVersion
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), "axon-") {
+			continue
+		}
+		ver := strings.TrimSuffix(strings.TrimPrefix(e.Name(), "axon-"), ".exe")
+		if ver == "" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		out = append(out, archivedVersion{Version: ver, Path: filepath.Join(dir, e.Name()), ModTime: info.ModTime()})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ModTime.After(out[j].ModTime) })
+	return out, nil
+}
+
+// pruneArchivedVersions removes all but the keep newest archived binaries.
+func pruneArchivedVersions(dir string, keep int) error {
+	list, err := listArchivedVersions(dir)
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(list); i++ {
+		if err := os.Remove(list[i].Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("cannot prune %s: %w", list[i].Path, err)
+		}
+	}
+	return nil
+}
+
+// selectRollbackVersion picks the archived binary to restore: the requested
+// version, or the newest one that differs from current.
+func selectRollbackVersion(list []archivedVersion, requested, current string) (*archivedVersion, error) {
+	requested = normalizeReleaseVersion(requested)
+	for i := range list {
+		v := &list[i]
+		if requested != "" {
+			if v.Version == requested {
+				return v, nil
+			}
+			continue
+		}
+		if v.Version != current {
+			return v, nil
+		}
+	}
+
+	var avail []string
+	for _, v := range list {
+		avail = append(avail, v.Version)
+	}
+	if len(avail) == 0 {
+		return nil, fmt.Errorf("no previous versions available for rollback (kept under ~/.axon/versions after each update)")
+	}
+	if requested != "" {
+		return nil, fmt.Errorf("version %s is not available for rollback. Available: %s", requested, strings.Join(avail, ", "))
+	}
+	return nil, fmt.Errorf("no version other than the current one (%s) is available. Available: %s", current, strings.Join(avail, ", "))
+}
+
+// runUpdateRollback restores a previously archived binary using the same
+// swap-and-verify path as a regular update.
+func runUpdateRollback(f updateFlags, requested string) error {
+	dir, err := versionsDir()
+	if err != nil {
+		return err
+	}
+	list, err := listArchivedVersions(dir)
+	if err != nil {
+		return err
+	}
+	target, err := selectRollbackVersion(list, requested, version)
+	if err != nil {
+		return err
+	}
+
+	if f.check || f.dryRun {
+		printInfo("", fmt.Sprintf("Would roll back: %s -> %s", version, target.Version))
+		printInfo("", fmt.Sprintf("From: %s", target.Path))
+		return nil
+	}
+
+	printInfo("", fmt.Sprintf("Rolling back: %s -> %s", version, target.Version))
+
+	baseTempDir, err := chooseWritableTempBase()
+	if err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp(baseTempDir, "axon-update-*")
+	if err != nil {
+		return fmt.Errorf("cannot create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Copy out of the archive first so pruning below cannot remove it.
+	newBinPath := filepath.Join(tmpDir, "axon.new")
+	if runtime.GOOS == "windows" {
+		newBinPath = filepath.Join(tmpDir, "axon.new.exe")
+	}
+	if err := copyFile(target.Path, newBinPath); err != nil {
+		return err
+	}
+
+	currentPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot determine current executable path: %w", err)
+	}
+	currentPath, _ = filepath.EvalSymlinks(currentPath)
+
+	// Keep the binary being replaced so the rollback itself can be undone.
+	if err := archiveBinary(dir, currentPath, version, f.keepVersions); err != nil {
+		printWarn("", err.Error())
+	}
+
+	if runtime.GOOS == "windows" {
+		stagedNew := filepath.Join(filepath.Dir(currentPath), "axon.new.exe")
+		if err := copyFile(newBinPath, stagedNew); err != nil {
+			return err
+		}
+		if err := spawnWindowsSwapHelper(currentPath, stagedNew, currentPath+".bak", target.Version, f.timeout); err != nil {
+			return err
+		}
+		recordUpdate(journal.OpRollback, version, target.Version, currentPath, true)
+		printOK("", "Rollback staged; it will complete after this process exits.")
+		return nil
+	}
+
+	if err := installWithRollback(currentPath, newBinPath, currentPath+".bak", target.Version); err != nil {
+		return err
+	}
+	recordUpdate(journal.OpRollback, version, target.Version, currentPath, false)
+	printOK("", fmt.Sprintf("Rolled back to %s", target.Version))
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArchiveBinary_KeepsNewest(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "axon")
+	if err := os.WriteFile(src, []byte("bin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "versions")
+
+	base := time.Now().Add(-time.Hour)
+	for i, v := range []string{"0.1.0", "0.1.1", "0.1.2"} {
+		if err := archiveBinary(dir, src, v, 2); err != nil {
+			t.Fatal(err)
+		}
+		// Spread archive times so ordering is deterministic.
+		ts := base.Add(time.Duration(i) * time.Minute)
+		_ = os.Chtimes(filepath.Join(dir, archivedBinaryName(v)), ts, ts)
+		_ = pruneArchivedVersions(dir, 2)
+	}
+
+	list, err := listArchivedVersions(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Version != "0.1.2" || list[1].Version != "0.1.1" {
+		t.Fatalf("unexpected archive: %+v", list)
+	}
+}
+
+func TestArchiveBinary_KeepZeroDisables(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "axon")
+	_ = os.WriteFile(src, []byte("bin"), 0o755)
+	dir := filepath.Join(t.TempDir(), "versions")
+	if err := archiveBinary(dir, src, "0.1.0", 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("expected no versions dir, got %v", err)
+	}
+}
+
+func TestSelectRollbackVersion(t *testing.T) {
+	list := []archivedVersion{{Version: "0.2.0"}, {Version: "0.1.9"}, {Version: "0.1.8"}}
+
+	got, err := selectRollbackVersion(list, "", "0.2.0")
+	if err != nil || got.Version != "0.1.9" {
+		t.Fatalf("default rollback = %+v, %v", got, err)
+	}
+	got, err = selectRollbackVersion(list, "v0.1.8", "0.2.0")
+	if err != nil || got.Version != "0.1.8" {
+		t.Fatalf("explicit rollback = %+v, %v", got, err)
+	}
+	if _, err := selectRollbackVersion(list, "0.0.1", "0.2.0"); err == nil {
+		t.Fatal("expected error for unknown version")
+	}
+	if _, err := selectRollbackVersion(nil, "", "0.2.0"); err == nil {
+		t.Fatal("expected error for empty archive")
+	}
+}
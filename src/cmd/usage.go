@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/usage"
+)
+
+// defaultUnusedAge is how long a skill must go unread before 'axon stats'
+// and 'axon gc --suggest' list it.
+const defaultUnusedAge = 90 * 24 * time.Hour
+
+// usageMu serialises updates of usage.json from concurrent API requests.
+var usageMu sync.Mutex
+
+// sampleUsage samples the access times of the Hub's skills and saves the
+// result. It returns nil when usage_tracking is off. Reads from this
+// process, such as loading the skill graph, come after now and are not
+// counted.
+func sampleUsage(cfg *config.Config) (*usage.Store, error) {
+	if !cfg.UsageTracking {
+		return nil, nil
+	}
+	usageMu.Lock()
+	defer usageMu.Unlock()
+	path, err := usage.Path()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	store, err := usage.Load(path, now)
+	if err != nil {
+		return nil, err
+	}
+	skills := make(map[string]string)
+	for name, node := range loadSkillGraph(cfg) {
+		skills[name] = node.Dir
+	}
+	store.Sample(skills, now)
+	return store, store.Save()
+}
+
+// recordSkillRead notes that 'axon serve' handed out file, when it is a
+// skill's SKILL.md and usage_tracking is on. Failures are ignored; usage is
+// only an estimate.
+func recordSkillRead(cfg *config.Config, file string) {
+	if !cfg.UsageTracking || filepath.Base(file) != "SKILL.md" {
+		return
+	}
+	usageMu.Lock()
+	defer usageMu.Unlock()
+	path, err := usage.Path()
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	store, err := usage.Load(path, now)
+	if err != nil {
+		return
+	}
+	store.Record(filepath.Base(filepath.Dir(file)), now, usage.SourceServe)
+	_ = store.Save()
+}
+
+// printUnusedSkills lists the skills not read for age, or explains why
+// there is nothing to list yet.
+func printUnusedSkills(cfg *config.Config, age time.Duration) {
+	if !cfg.UsageTracking {
+		printSkip("", "usage tracking is off; set 'usage_tracking: true' in axon.yaml to enable it")
+		return
+	}
+	store, err := sampleUsage(cfg)
+	if err != nil {
+		printWarn("", fmt.Sprintf("cannot sample skill usage: %v", err))
+		return
+	}
+	now := time.Now()
+	cutoff := now.Add(-age)
+	var names []string
+	for name := range loadSkillGraph(cfg) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if store.Since.After(cutoff) {
+		printSkip("", fmt.Sprintf("tracking since %s; skills unread for %s are listed from %s",
+			store.Since.Format("2006-01-02"), gcAge(age), store.Since.Add(age).Format("2006-01-02")))
+		return
+	}
+	unused := store.Unused(names, cutoff)
+	if len(unused) == 0 {
+		printOK("", fmt.Sprintf("every skill was read in the last %s", gcAge(age)))
+		return
+	}
+	for _, name := range unused {
+		last := "never read since " + store.Since.Format("2006-01-02")
+		if r, ok := store.Skills[name]; ok {
+			last = "last read " + r.Time.Format("2006-01-02")
+		}
+		printListItem(iconItem, fmt.Sprintf("%-40s %s", name, last))
+	}
+}
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/vendor"
+	"github.com/spf13/cobra"
+)
+
+var vendorCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect, verify, and prune the vendor clone cache",
+	Long: `Vendor repos are cloned once into ~/.axon/cache/vendors and fetched on
+every 'axon vendor sync'. These commands show where the cache is and how
+big it is, check the cached clones for corruption, and prune what is no
+longer needed.
+
+Examples:
+  axon vendor cache path
+  axon vendor cache verify --repair
+  axon vendor cache prune --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var vendorCachePathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Show the vendor cache location and size",
+	Long: `Print the vendor cache directory, the size of every cached clone, and the
+vendors using it. Clones no vendor uses are marked; 'axon vendor cache
+prune' removes them.
+
+With --quiet, only the directory is printed, for use in scripts.`,
+	Args: cobra.NoArgs,
+	RunE: runVendorCachePath,
+}
+
+var vendorCacheVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the cached vendor clones for corruption",
+	Long: `Run 'git fsck' on every cached clone and check that its origin is the repo
+its vendors point at. A failed check exits with an error.
+
+--repair removes the clones that fail along with the last-synced commit of
+their vendors, so the next 'axon vendor sync' clones and mirrors them again.`,
+	Args: cobra.NoArgs,
+	RunE: runVendorCacheVerify,
+}
+
+var vendorCachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove unused vendor clones and stale cache data",
+	Long: `Remove the clones and sync state of vendors no longer in the 'vendors'
+block of ~/.axon/axon.yaml, then compact the remaining clones: objects
+only kept alive by refs mirrored earlier are dropped.
+
+Examples:
+  axon vendor cache prune --dry-run
+  axon vendor cache prune`,
+	Args: cobra.NoArgs,
+	RunE: runVendorCachePrune,
+}
+
+var (
+	flagVendorCacheQuiet  bool
+	flagVendorCacheRepair bool
+	flagVendorCacheDryRun bool
+)
+
+func init() {
+	vendorCachePathCmd.Flags().BoolVarP(&flagVendorCacheQuiet, "quiet", "q", false, "Print only the cache directory")
+	vendorCacheVerifyCmd.Flags().BoolVar(&flagVendorCacheRepair, "repair", false, "Remove clones that fail so the next vendor sync clones them again")
+	vendorCachePruneCmd.Flags().BoolVar(&flagVendorCacheDryRun, "dry-run", false, "Show what would be removed without removing it")
+	vendorCacheCmd.AddCommand(vendorCachePathCmd, vendorCacheVerifyCmd, vendorCachePruneCmd)
+	vendorCmd.AddCommand(vendorCacheCmd)
+}
+
+// configuredVendors returns the vendors of axon.yaml, or none when there is
+// no config: the cache can still be inspected without one.
+func configuredVendors() []config.Vendor {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	return cfg.Vendors
+}
+
+// vendorsByClone maps each cache path to the names of the vendors cloned
+// into it.
+func vendorsByClone(vendors []config.Vendor) map[string][]string {
+	users := make(map[string][]string)
+	for _, v := range vendors {
+		if p, err := vendor.CachePath(v.Repo); err == nil {
+			users[p] = append(users[p], v.Name)
+		}
+	}
+	return users
+}
+
+// cloneLabel names a clone by its <owner>/<repo> path in the cache.
+func cloneLabel(root, clone string) string {
+	rel, err := filepath.Rel(root, clone)
+	if err != nil {
+		return clone
+	}
+	return filepath.ToSlash(rel)
+}
+
+func runVendorCachePath(_ *cobra.Command, _ []string) error {
+	root, err := vendor.CacheRoot()
+	if err != nil {
+		return err
+	}
+	if flagVendorCacheQuiet {
+		fmt.Println(root)
+		return nil
+	}
+	clones, err := vendor.ListClones()
+	if err != nil {
+		return fmt.Errorf("cannot read vendor cache: %w", err)
+	}
+
+	printSection("Vendor Cache")
+	printInfo("", fmt.Sprintf("%s (%s)", root, humanBytes(dirSize(root))))
+	if len(clones) == 0 {
+		printSkip("", "no cached clones")
+		return nil
+	}
+	users := vendorsByClone(configuredVendors())
+	unused := 0
+	for _, c := range clones {
+		label := fmt.Sprintf("%s  %s", cloneLabel(root, c), humanBytes(dirSize(c)))
+		if names := users[c]; len(names) > 0 {
+			sort.Strings(names)
+			printOK("", fmt.Sprintf("%s  used by %s", label, strings.Join(names, ", ")))
+			continue
+		}
+		printSkip("", label+"  not used by any vendor")
+		unused++
+	}
+	if unused > 0 {
+		printInfo("", fmt.Sprintf("Run 'axon vendor cache prune' to remove %d unused clone(s).", unused))
+	}
+	return nil
+}
+
+// verifyClone checks one cached clone: its object database, and that its
+// origin is the repo its vendors are configured with.
+func verifyClone(clone string, vendors []config.Vendor) error {
+	if err := vendor.Fsck(clone); err != nil {
+		return err
+	}
+	url, err := vendor.RemoteURL(clone)
+	if err != nil {
+		return err
+	}
+	for _, v := range vendors {
+		if p, err := vendor.CachePath(v.Repo); err == nil && p == clone && !sameRepoURL(v.Repo, url) {
+			return fmt.Errorf("origin is %s, but vendor %s uses %s", url, v.Name, v.Repo)
+		}
+	}
+	return nil
+}
+
+// sameRepoURL reports whether two remote URLs name the same repo, ignoring
+// a trailing slash or .git suffix.
+func sameRepoURL(a, b string) bool {
+	trim := func(u string) string {
+		return strings.TrimSuffix(strings.TrimSuffix(u, "/"), ".git")
+	}
+	return trim(a) == trim(b)
+}
+
+func runVendorCacheVerify(_ *cobra.Command, _ []string) error {
+	if err := checkGitAvailable(); err != nil {
+		return err
+	}
+	root, err := vendor.CacheRoot()
+	if err != nil {
+		return err
+	}
+	clones, err := vendor.ListClones()
+	if err != nil {
+		return fmt.Errorf("cannot read vendor cache: %w", err)
+	}
+	vendors := configuredVendors()
+	users := vendorsByClone(vendors)
+
+	printSection("Vendor Cache Verify")
+	if len(clones) == 0 {
+		printSkip("", "no cached clones")
+		return nil
+	}
+	var failed, repaired int
+	for _, c := range clones {
+		label := cloneLabel(root, c)
+		err := verifyClone(c, vendors)
+		if err == nil {
+			printOK(label, "ok")
+			continue
+		}
+		failed++
+		printErr(label, firstLine(err.Error(), err))
+		if !flagVendorCacheRepair {
+			continue
+		}
+		if err := removeClone(c, users[c]); err != nil {
+			printErr(label, err.Error())
+			continue
+		}
+		repaired++
+		printOK(label, "removed; the next 'axon vendor sync' clones it again")
+	}
+
+	switch {
+	case failed == 0:
+		printOK("", fmt.Sprintf("%d clone(s) verified", len(clones)))
+		return nil
+	case repaired == failed:
+		printOK("", fmt.Sprintf("%d of %d clone(s) failed and were removed", failed, len(clones)))
+		return nil
+	case flagVendorCacheRepair:
+		return fmt.Errorf("%d of %d clone(s) failed verification; %d could not be removed", failed, len(clones), failed-repaired)
+	}
+	return fmt.Errorf("%d of %d clone(s) failed verification\nRun 'axon vendor cache verify --repair' to remove them.", failed, len(clones))
+}
+
+// removeClone deletes a cached clone and the last-synced commit of the
+// vendors using it, so the next sync re-mirrors them from a fresh clone.
+func removeClone(clone string, names []string) error {
+	if err := os.RemoveAll(clone); err != nil {
+		return fmt.Errorf("cannot remove %s: %w", clone, err)
+	}
+	for _, name := range names {
+		if err := vendor.RemoveVendorSHA(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runVendorCachePrune(_ *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w\nRun 'axon init' first.", err)
+	}
+	title := "Vendor Cache Prune"
+	if flagVendorCacheDryRun {
+		title += " (dry run)"
+	}
+	printSection(title)
+
+	verb := "Removed"
+	if flagVendorCacheDryRun {
+		verb = "Would remove"
+	}
+	rep := gcVendorCaches(cfg.Vendors, flagVendorCacheDryRun)
+	if rep.Err != nil {
+		return rep.Err
+	}
+	if rep.Items == 0 {
+		printSkip("", "no unused clones or sync state")
+	} else {
+		printOK("", fmt.Sprintf("%s %d unused clone(s) and sync state file(s), %s", verb, rep.Items, humanBytes(rep.Bytes)))
+	}
+
+	keep := make(map[string]bool, len(cfg.Vendors))
+	for _, v := range cfg.Vendors {
+		keep[v.Name] = true
+	}
+	dropped, err := vendor.PruneSkillState(keep, flagVendorCacheDryRun)
+	if err != nil {
+		return fmt.Errorf("cannot prune skill state: %w", err)
+	}
+	if dropped > 0 {
+		printOK("", fmt.Sprintf("%s the update state of %d skill(s) of removed vendors", verb, dropped))
+	}
+
+	if flagVendorCacheDryRun {
+		printInfo("", "Used clones are compacted when run without --dry-run.")
+		return nil
+	}
+	if err := checkGitAvailable(); err != nil {
+		return err
+	}
+	clones, err := vendor.ListClones()
+	if err != nil {
+		return fmt.Errorf("cannot read vendor cache: %w", err)
+	}
+	root, _ := vendor.CacheRoot()
+	var reclaimed int64
+	compacted := 0
+	for _, c := range clones {
+		if !vendor.IsCloned(c) {
+			continue
+		}
+		before := dirSize(c)
+		if err := vendor.Compact(c); err != nil {
+			printWarn(cloneLabel(root, c), firstLine(err.Error(), err))
+			continue
+		}
+		compacted++
+		if after := dirSize(c); after < before {
+			reclaimed += before - after
+		}
+	}
+	if compacted > 0 {
+		printOK("", fmt.Sprintf("Compacted %d clone(s), reclaimed %s", compacted, humanBytes(reclaimed)))
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/vendor"
+)
+
+func TestVerifyClone_DetectsCorruptionAndRepairRemovesIt(t *testing.T) {
+	resetVendorCache(t)
+	orig := vendor.RsyncAvailable
+	vendor.RsyncAvailable = func() bool { return false }
+	defer func() { vendor.RsyncAvailable = orig }()
+
+	srcRepo := makeLocalVendorRepo(t, "skills/foo", "SKILL.md", "# Foo Skill\n")
+	hubRoot := t.TempDir()
+	if err := os.Mkdir(filepath.Join(hubRoot, "skills"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	v := config.Vendor{Name: "foo", Repo: srcRepo, Subdir: "skills/foo", Dest: "skills/foo", Ref: "master"}
+	if _, err := syncVendorEntry(hubRoot, v); err != nil {
+		t.Fatalf("syncVendorEntry: %v", err)
+	}
+	clone, err := vendor.CachePath(v.Repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendors := []config.Vendor{v}
+
+	if err := verifyClone(clone, vendors); err != nil {
+		t.Fatalf("fresh clone failed verification: %v", err)
+	}
+
+	// A clone whose origin is not the vendor's repo is reported.
+	if err := gitRun("-C", clone, "remote", "set-url", "origin", "https://example.com/o/other.git"); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyClone(clone, vendors); err == nil {
+		t.Error("expected an origin mismatch to fail verification")
+	}
+	if err := gitRun("-C", clone, "remote", "set-url", "origin", srcRepo); err != nil {
+		t.Fatal(err)
+	}
+
+	// Drop every object: the clone no longer has its commits.
+	objects := filepath.Join(clone, ".git", "objects")
+	entries, err := os.ReadDir(objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Name() != "info" {
+			if err := os.RemoveAll(filepath.Join(objects, e.Name())); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := verifyClone(clone, vendors); err == nil {
+		t.Fatal("expected a clone without objects to fail verification")
+	}
+
+	if err := removeClone(clone, []string{v.Name}); err != nil {
+		t.Fatalf("removeClone: %v", err)
+	}
+	if _, err := os.Stat(clone); !os.IsNotExist(err) {
+		t.Errorf("clone still present: %v", err)
+	}
+	if sha, _ := vendor.ReadVendorSHA(v.Name); sha != "" {
+		t.Errorf("last-synced SHA kept after repair: %q", sha)
+	}
+}
+
+func TestSameRepoURL(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{"https://github.com/o/r.git", "https://github.com/o/r", true},
+		{"https://github.com/o/r/", "https://github.com/o/r", true},
+		{"https://github.com/o/r", "https://github.com/o/other", false},
+	} {
+		if got := sameRepoURL(tc.a, tc.b); got != tc.want {
+			t.Errorf("sameRepoURL(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
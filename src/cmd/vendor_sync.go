@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"path"
 
 	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/journal"
 	"github.com/kamusis/axon-cli/internal/vendor"
 	"github.com/spf13/cobra"
 )
@@ -51,8 +57,12 @@ func runVendorSync(_ *cobra.Command, _ []string) error {
 	printSection("Vendor Sync")
 
 	var mirrored, skipped, failed int
+	progress := startProgress("Vendor sync", int64(len(cfg.Vendors)))
+	defer progress.Done()
 	for _, v := range cfg.Vendors {
+		progress.Item(v.Name)
 		ok, err := syncVendorEntry(cfg.RepoPath, v)
+		progress.Add(1)
 		if err != nil {
 			printErr(v.Name, err.Error())
 			failed++
@@ -61,6 +71,7 @@ func runVendorSync(_ *cobra.Command, _ []string) error {
 		}
 		if ok {
 			mirrored++
+			recordVendorSync(v)
 		} else {
 			skipped++
 		}
@@ -90,6 +101,17 @@ func validateVendors(vendors []config.Vendor) error {
 		if v.Dest == "" {
 			return fmt.Errorf("vendor %q: 'dest' is required", v.Name)
 		}
+		if v.Depth < 0 {
+			return fmt.Errorf("vendor %q: 'depth' must be 0 (full history) or positive", v.Name)
+		}
+		for _, sp := range v.Sparse {
+			if _, err := vendor.ValidateSparsePath(sp); err != nil {
+				return fmt.Errorf("vendor %q: %w", v.Name, err)
+			}
+		}
+		if err := vendor.ValidateTransform(vendorTransform(v, "")); err != nil {
+			return fmt.Errorf("vendor %q: transform: %w", v.Name, err)
+		}
 		if _, dup := seen[v.Name]; dup {
 			return fmt.Errorf("duplicate vendor name %q — each vendor entry must have a unique name", v.Name)
 		}
@@ -108,6 +130,7 @@ func syncVendorEntry(hubRoot string, v config.Vendor) (bool, error) {
 	}
 
 	printInfo(v.Name, fmt.Sprintf("repo=%s subdir=%s ref=%s", v.Repo, v.Subdir, ref))
+	sparse := vendorSparsePaths(v)
 
 	// 1. Resolve cache path.
 	cachePath, err := vendor.CachePath(v.Repo)
@@ -119,18 +142,23 @@ func syncVendorEntry(hubRoot string, v config.Vendor) (bool, error) {
 	alreadyCached := vendor.IsCloned(cachePath)
 	if !alreadyCached {
 		printInfo(v.Name, "cloning repository into cache…")
-		if err := vendor.Clone(v.Repo, cachePath); err != nil {
+		if err := withProgressPaused(func() error { return vendor.Clone(v.Repo, cachePath, v.Depth) }); err != nil {
 			return false, err
 		}
 		// 3. Configure sparse-checkout after fresh clone.
-		if err := vendor.EnableSparseCheckout(cachePath, v.Subdir); err != nil {
+		if err := withProgressPaused(func() error { return vendor.EnableSparseCheckout(cachePath, sparse...) }); err != nil {
 			return false, err
 		}
 	}
 
-	// 4. Fetch latest refs.
-	printInfo(v.Name, "fetching remote refs…")
-	if err := vendor.Fetch(cachePath); err != nil {
+	// 4. Fetch latest refs. A shallow clone shared with a vendor that wants
+	//    the full history is upgraded here.
+	if alreadyCached && v.Depth == 0 && vendor.IsShallow(cachePath) {
+		printInfo(v.Name, "fetching the full history of the shallow clone…")
+	} else {
+		printInfo(v.Name, "fetching remote refs…")
+	}
+	if err := withProgressPaused(func() error { return vendor.Fetch(cachePath, v.Depth) }); err != nil {
 		return false, err
 	}
 
@@ -141,18 +169,21 @@ func syncVendorEntry(hubRoot string, v config.Vendor) (bool, error) {
 	//    processed HEAD advances to origin/<ref>, making every subsequent
 	//    entry appear current even if its subdir was never mirrored.
 	remoteRef := "origin/" + ref
-	remoteSHA, err := vendor.SubdirLatestSHA(cachePath, remoteRef, v.Subdir)
+	remoteSHA, err := vendor.SubdirLatestSHA(cachePath, remoteRef, sparse...)
 	if err != nil {
 		// Log a warning if we can't get remote SHA, but keep going.
 		printWarn(v.Name, fmt.Sprintf("could not determine remote SHA: %v", err))
 	}
-	storedSHA, err := vendor.ReadVendorSHA(v.Name)
+	storedSHA, storedDigest, err := vendor.ReadVendorState(v.Name)
 	if err != nil {
 		// Log a warning if we can't read stored SHA, but keep going.
 		printWarn(v.Name, fmt.Sprintf("could not read stored SHA: %v", err))
 	}
 
-	if storedSHA != "" && remoteSHA != "" && storedSHA == remoteSHA {
+	// Changed sparse paths or transform rules re-mirror unchanged upstream
+	// content too.
+	digest := vendorDigest(v)
+	if storedSHA != "" && remoteSHA != "" && storedSHA == remoteSHA && storedDigest == digest {
 		printOK(v.Name, fmt.Sprintf(
 			"already up to date (%.8s) — no changes in %s, skipping mirror",
 			remoteSHA, v.Subdir,
@@ -165,22 +196,38 @@ func syncVendorEntry(hubRoot string, v config.Vendor) (bool, error) {
 	//    subdir here so that a second entry sharing the same repo cache gets
 	//    its files checked out too (git sparse-checkout add is idempotent).
 	if alreadyCached {
-		if err := vendor.AddSparseCheckoutDir(cachePath, v.Subdir); err != nil {
-			return false, err
+		for _, p := range sparse {
+			if err := withProgressPaused(func() error { return vendor.AddSparseCheckoutDir(cachePath, p) }); err != nil {
+				return false, err
+			}
 		}
 	}
 
 	// 7. Checkout requested ref.
 	printInfo(v.Name, fmt.Sprintf("checking out %s…", ref))
-	if err := vendor.Checkout(cachePath, ref); err != nil {
+	if err := checkoutVendorRef(v.Name, cachePath, ref); err != nil {
 		return false, err
 	}
 
-	// 8. Verify subdir exists in the checked-out tree.
+	// 8. Verify subdir exists in the checked-out tree. With sparse paths or
+	//    transform rules, the mirror comes from a staged copy: the cone may
+	//    hold more when other vendors share the clone.
 	src, err := vendor.SourcePath(cachePath, v.Subdir)
 	if err != nil {
 		return false, err
 	}
+	if vendorNeedsStaging(v) {
+		sha := remoteSHA
+		if sha == "" {
+			sha = ref
+		}
+		staged, err := vendor.Stage(src, cleanSparse(v.Sparse), vendorTransform(v, sha))
+		if err != nil {
+			return false, fmt.Errorf("cannot stage %s: %w", v.Subdir, err)
+		}
+		defer os.RemoveAll(staged)
+		src = staged
+	}
 
 	// 9. Validate and mirror into Hub.
 	cleanDest, err := vendor.ValidateDest(v.Dest)
@@ -189,16 +236,113 @@ func syncVendorEntry(hubRoot string, v config.Vendor) (bool, error) {
 	}
 
 	printInfo(v.Name, fmt.Sprintf("mirroring %s → %s…", v.Subdir, v.Dest))
-	if err := vendor.Mirror(hubRoot, cleanDest, src); err != nil {
+	if err := withProgressPaused(func() error { return vendor.Mirror(hubRoot, cleanDest, src) }); err != nil {
 		return false, err
 	}
 
 	// 10. Record the mirrored SHA so future runs can skip unchanged entries.
 	//     Errors here are non-fatal — worst case the next run re-mirrors.
 	if remoteSHA != "" {
-		_ = vendor.WriteVendorSHA(v.Name, remoteSHA)
+		_ = vendor.WriteVendorState(v.Name, remoteSHA, digest)
 	}
 
 	printOK(v.Name, fmt.Sprintf("successfully mirrored %s@%s → %s", v.Subdir, ref, v.Dest))
 	return true, nil
 }
+
+// vendorSparsePaths returns the repo paths the checkout of v needs: its
+// sparse paths under the subdir, or the whole subdir.
+func vendorSparsePaths(v config.Vendor) []string {
+	if len(v.Sparse) == 0 {
+		return []string{v.Subdir}
+	}
+	out := make([]string, 0, len(v.Sparse))
+	for _, p := range cleanSparse(v.Sparse) {
+		out = append(out, path.Join(v.Subdir, p))
+	}
+	return out
+}
+
+// vendorNeedsStaging reports whether v is mirrored from a staged copy
+// rather than straight from the checkout.
+func vendorNeedsStaging(v config.Vendor) bool {
+	t := v.Transform
+	return len(v.Sparse) > 0 || len(t.Exclude) > 0 || len(t.Rename) > 0 || len(t.Flatten) > 0 || t.Provenance
+}
+
+// vendorReshaped reports whether the transform of v moves paths, so Hub
+// skills no longer map to upstream paths by name.
+func vendorReshaped(v config.Vendor) bool {
+	return len(v.Transform.Rename) > 0 || len(v.Transform.Flatten) > 0
+}
+
+// vendorTransform converts the transform rules of v. Provenance comments
+// name sha, the commit being mirrored.
+func vendorTransform(v config.Vendor, sha string) vendor.Transform {
+	t := vendor.Transform{Exclude: v.Transform.Exclude, Flatten: v.Transform.Flatten}
+	for _, r := range v.Transform.Rename {
+		t.Rename = append(t.Rename, vendor.Rename{From: r.From, To: r.To})
+	}
+	if v.Transform.Provenance {
+		t.Provenance = func(upstream string) string {
+			return fmt.Sprintf("<!-- Vendored by axon from %s (%s) at %s. 'axon vendor sync' overwrites local edits. -->",
+				v.Repo, path.Join(v.Subdir, upstream), sha)
+		}
+	}
+	return t
+}
+
+// vendorDigest fingerprints the sparse paths and transform rules of v, so a
+// sync notices when they change. It is empty when v has none.
+func vendorDigest(v config.Vendor) string {
+	if !vendorNeedsStaging(v) {
+		return ""
+	}
+	data, _ := json.Marshal(struct {
+		Sparse    []string
+		Transform config.VendorTransform
+	}{v.Sparse, v.Transform})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// cleanSparse cleans validated sparse paths.
+func cleanSparse(paths []string) []string {
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if clean, err := vendor.ValidateSparsePath(p); err == nil {
+			out = append(out, clean)
+		}
+	}
+	return out
+}
+
+// checkoutVendorRef checks out ref in the cache repo. A ref missing from a
+// shallow clone, such as an old pinned commit, is retried after fetching
+// the full history.
+func checkoutVendorRef(name, cachePath, ref string) error {
+	err := withProgressPaused(func() error { return vendor.Checkout(cachePath, ref) })
+	if err == nil || !vendor.IsShallow(cachePath) {
+		return err
+	}
+	printInfo(name, fmt.Sprintf("%s is not in the shallow clone — fetching the full history…", ref))
+	if err := withProgressPaused(func() error { return vendor.Unshallow(cachePath) }); err != nil {
+		return err
+	}
+	return withProgressPaused(func() error { return vendor.Checkout(cachePath, ref) })
+}
+
+// recordVendorSync journals a vendor entry that was mirrored into the Hub.
+func recordVendorSync(v config.Vendor) {
+	sha, _ := vendor.ReadVendorSHA(v.Name)
+	ref := v.Ref
+	if ref == "" {
+		ref = "main"
+	}
+	recordHistory(journal.Entry{
+		Op:     journal.OpVendorSync,
+		Target: v.Name,
+		Detail: fmt.Sprintf("%s/%s@%s (%s) → %s", v.Repo, v.Subdir, ref, abbrevSHA(sha), v.Dest),
+		Data:   map[string]string{"repo": v.Repo, "subdir": v.Subdir, "ref": ref, "sha": sha, "dest": v.Dest},
+	})
+}
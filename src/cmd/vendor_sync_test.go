@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kamusis/axon-cli/internal/config"
@@ -247,3 +248,146 @@ func TestSyncVendorEntry_IdempotentOnRerun(t *testing.T) {
 		t.Fatalf("file missing after second run: %v", err)
 	}
 }
+
+func TestValidateVendors_DepthAndSparse(t *testing.T) {
+	base := config.Vendor{Name: "a", Repo: "https://x/y.git", Subdir: "s", Dest: "d"}
+	neg := base
+	neg.Depth = -1
+	if err := validateVendors([]config.Vendor{neg}); err == nil {
+		t.Error("expected an error for a negative depth")
+	}
+	escape := base
+	escape.Sparse = []string{"../other"}
+	if err := validateVendors([]config.Vendor{escape}); err == nil {
+		t.Error("expected an error for a sparse path outside the subdir")
+	}
+	ok := base
+	ok.Depth, ok.Sparse = 1, []string{"pdf"}
+	if err := validateVendors([]config.Vendor{ok}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestSyncVendorEntry_ShallowSparse mirrors only the sparse paths from a
+// shallow clone, then upgrades the clone to full history once the vendor no
+// longer sets a depth.
+func TestSyncVendorEntry_ShallowSparse(t *testing.T) {
+	resetVendorCache(t)
+	// git serves file:// URLs from the working directory, which earlier
+	// tests may have left pointing at a removed temp dir.
+	t.Chdir(t.TempDir())
+	orig := vendor.RsyncAvailable
+	vendor.RsyncAvailable = func() bool { return false }
+	defer func() { vendor.RsyncAvailable = orig }()
+
+	srcRepo := makeLocalVendorRepo(t, "skills/pdf", "SKILL.md", "# PDF\n")
+	if err := os.MkdirAll(filepath.Join(srcRepo, "skills", "docx"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(srcRepo, "skills", "docx", "SKILL.md"), []byte("# DOCX\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"-C", srcRepo, "add", "."},
+		{"-C", srcRepo, "commit", "-m", "add docx"},
+	} {
+		if err := gitRun(args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	first, err := gitOutput(srcRepo, "rev-list", "--max-parents=0", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hubRoot := t.TempDir()
+	if err := os.Mkdir(filepath.Join(hubRoot, "skills"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// Local paths ignore --depth; a file:// URL honors it.
+	v := config.Vendor{
+		Name:   "shallow",
+		Repo:   "file://" + filepath.ToSlash(srcRepo),
+		Subdir: "skills",
+		Dest:   "skills/vendored",
+		Ref:    "master",
+		Depth:  1,
+		Sparse: []string{"pdf"},
+	}
+	if _, err := syncVendorEntry(hubRoot, v); err != nil {
+		t.Fatalf("syncVendorEntry: %v", err)
+	}
+	cachePath, _ := vendor.CachePath(v.Repo)
+	if !vendor.IsShallow(cachePath) {
+		t.Error("expected a shallow clone")
+	}
+	if _, err := os.Stat(filepath.Join(hubRoot, "skills", "vendored", "pdf", "SKILL.md")); err != nil {
+		t.Errorf("sparse path not mirrored: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(hubRoot, "skills", "vendored", "docx")); !os.IsNotExist(err) {
+		t.Errorf("path outside sparse mirrored: %v", err)
+	}
+
+	// Pin the first commit, which the depth-1 clone does not have, and
+	// drop the depth.
+	v.Ref, v.Depth = strings.TrimSpace(first), 0
+	if _, err := syncVendorEntry(hubRoot, v); err != nil {
+		t.Fatalf("syncVendorEntry at an old commit: %v", err)
+	}
+	if vendor.IsShallow(cachePath) {
+		t.Error("expected the clone to be upgraded to full history")
+	}
+	if !vendor.HasCommit(cachePath, v.Ref) {
+		t.Errorf("commit %s missing after the upgrade", v.Ref)
+	}
+}
+
+func TestSyncVendorEntry_TransformRemirrorsWhenRulesChange(t *testing.T) {
+	resetVendorCache(t)
+	orig := vendor.RsyncAvailable
+	vendor.RsyncAvailable = func() bool { return false }
+	defer func() { vendor.RsyncAvailable = orig }()
+
+	srcRepo := makeLocalVendorRepo(t, "skills/group/pdf", "SKILL.md", "---\nname: pdf\n---\n# PDF\n")
+	hubRoot := t.TempDir()
+	if err := os.Mkdir(filepath.Join(hubRoot, "skills"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	v := config.Vendor{
+		Name:   "grouped",
+		Repo:   srcRepo,
+		Subdir: "skills",
+		Dest:   "skills/grouped",
+		Ref:    "master",
+		Transform: config.VendorTransform{
+			Flatten:    []string{"group"},
+			Provenance: true,
+		},
+	}
+	if _, err := syncVendorEntry(hubRoot, v); err != nil {
+		t.Fatalf("syncVendorEntry: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(hubRoot, "skills", "grouped", "pdf", "SKILL.md"))
+	if err != nil {
+		t.Fatalf("flattened skill missing: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "---\nname: pdf\n---\n<!-- Vendored by axon from "+srcRepo+" (skills/group/pdf/SKILL.md) at ") {
+		t.Errorf("provenance comment missing:\n%s", data)
+	}
+
+	// Unchanged upstream and rules: skipped.
+	if mirrored, err := syncVendorEntry(hubRoot, v); err != nil || mirrored {
+		t.Fatalf("rerun: mirrored=%v, err=%v; want a skip", mirrored, err)
+	}
+	// Changed rules: mirrored again even though upstream is unchanged.
+	v.Transform.Rename = []config.VendorRename{{From: "group/pdf", To: "group/pdf-tools"}}
+	if mirrored, err := syncVendorEntry(hubRoot, v); err != nil || !mirrored {
+		t.Fatalf("after a rule change: mirrored=%v, err=%v; want a mirror", mirrored, err)
+	}
+	if _, err := os.Stat(filepath.Join(hubRoot, "skills", "grouped", "pdf-tools", "SKILL.md")); err != nil {
+		t.Errorf("renamed skill missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(hubRoot, "skills", "grouped", "pdf")); !os.IsNotExist(err) {
+		t.Errorf("old path left behind: %v", err)
+	}
+}
@@ -24,14 +24,16 @@ func init() {
 }
 
 func runVersion(_ *cobra.Command, _ []string) error {
-	fmt.Printf("Version:    %s\n", version)
-	fmt.Printf("Commit:     %s\n", emptyAsNA(commit))
-	fmt.Printf("Build Date: %s\n", emptyAsNA(buildDate))
-	fmt.Printf("Go Version: %s\n", runtime.Version())
-	fmt.Printf("OS/Arch:    %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Print(versionInfo())
 	return nil
 }
 
+// versionInfo renders the version and build details, one per line.
+func versionInfo() string {
+	return fmt.Sprintf("Version:    %s\nCommit:     %s\nBuild Date: %s\nGo Version: %s\nOS/Arch:    %s/%s\n",
+		version, emptyAsNA(commit), emptyAsNA(buildDate), runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
+
 func emptyAsNA(s string) string {
 	if s == "" {
 		return "n/a"
@@ -0,0 +1,431 @@
+// Package bundle reads and writes portable Hub archives: a gzip-compressed
+// tarball holding selected Hub content under hub/ plus a JSON manifest that
+// records item versions, per-file SHA-256 digests, and where the content came
+// from. Bundles let air-gapped machines receive skills without Git access.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/hash"
+)
+
+const (
+	// ManifestName is the archive entry holding the JSON manifest.
+	ManifestName = "axon-manifest.json"
+	// ContentDir is the archive directory that mirrors the Hub root.
+	ContentDir = "hub"
+	// FormatVersion is the manifest format written by Create.
+	FormatVersion = 1
+)
+
+// Manifest describes the contents of a bundle.
+type Manifest struct {
+	FormatVersion int    `json:"format_version"`
+	CreatedAt     string `json:"created_at"`
+	AxonVersion   string `json:"axon_version"`
+	Source        Source `json:"source"`
+	Items         []Item `json:"items"`
+	Files         []File `json:"files"`
+}
+
+// Source records where the exported content came from.
+type Source struct {
+	Host     string `json:"host,omitempty"`
+	Remote   string `json:"remote,omitempty"`
+	Revision string `json:"revision,omitempty"`
+}
+
+// Item is one exported Hub path (a skill directory, a workflow file, or a
+// whole source root such as skills/).
+type Item struct {
+	Path    string `json:"path"`
+	Version string `json:"version,omitempty"`
+}
+
+// File is one regular file or symlink inside the bundle.
+type File struct {
+	Path   string      `json:"path"`
+	Size   int64       `json:"size"`
+	Mode   fs.FileMode `json:"mode"`
+	SHA256 string      `json:"sha256,omitempty"`
+	Link   string      `json:"link,omitempty"`
+}
+
+// Create writes a bundle of m.Items (paths relative to root) to w. The file
+// list and digests are computed here and returned in the final manifest.
+// .git directories and files are never included.
+func Create(w io.Writer, root string, m Manifest) (*Manifest, error) {
+	if len(m.Items) == 0 {
+		return nil, fmt.Errorf("nothing to export")
+	}
+	m.FormatVersion = FormatVersion
+	m.Files = nil
+
+	seen := make(map[string]bool)
+	for _, it := range m.Items {
+		start := filepath.Join(root, filepath.FromSlash(it.Path))
+		if _, err := os.Lstat(start); err != nil {
+			return nil, fmt.Errorf("cannot export %s: %w", it.Path, err)
+		}
+		err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.Name() == ".git" {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if seen[rel] {
+				return nil
+			}
+			seen[rel] = true
+
+			info, err := os.Lstat(p)
+			if err != nil {
+				return err
+			}
+			f := File{Path: rel, Mode: info.Mode()}
+			switch {
+			case info.Mode()&os.ModeSymlink != 0:
+				target, err := os.Readlink(p)
+				if err != nil {
+					return err
+				}
+				f.Link = filepath.ToSlash(target)
+			case info.Mode().IsRegular():
+				sum, err := hash.File(p)
+				if err != nil {
+					return err
+				}
+				f.Size = info.Size()
+				f.SHA256 = sum
+			default:
+				return nil
+			}
+			m.Files = append(m.Files, f)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan %s: %w", it.Path, err)
+		}
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	mb, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: ManifestName, Mode: 0o644, Size: int64(len(mb))}); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write(mb); err != nil {
+		return nil, err
+	}
+
+	for _, f := range m.Files {
+		if err := writeEntry(tw, root, f); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// writeEntry appends one manifest file to the tar stream.
+func writeEntry(tw *tar.Writer, root string, f File) error {
+	name := path.Join(ContentDir, f.Path)
+	src := filepath.Join(root, filepath.FromSlash(f.Path))
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(info, filepath.FromSlash(f.Link))
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if f.Link != "" {
+		hdr.Linkname = f.Link
+		return tw.WriteHeader(hdr)
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	_, err = io.Copy(tw, in)
+	return err
+}
+
+// Extract unpacks the bundle at archivePath into destDir and verifies every
+// file against the manifest digests. Content lands in destDir/hub/.
+func Extract(archivePath, destDir string) (*Manifest, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("not a gzip archive: %w", err)
+	}
+	defer gzr.Close()
+
+	contentRoot := filepath.Join(destDir, ContentDir)
+	if err := os.MkdirAll(contentRoot, 0o755); err != nil {
+		return nil, err
+	}
+	// Every write goes through root, which refuses paths that leave
+	// contentRoot, even through symlinks extracted earlier.
+	root, err := os.OpenRoot(contentRoot)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+
+	var (
+		m     *Manifest
+		files map[string]File // manifest files by path
+		dirs  map[string]bool // their parent directories
+	)
+	tr := tar.NewReader(gzr)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read archive: %w", err)
+		}
+
+		if h.Name == ManifestName {
+			var mm Manifest
+			if err := json.NewDecoder(tr).Decode(&mm); err != nil {
+				return nil, fmt.Errorf("invalid manifest: %w", err)
+			}
+			if files, dirs, err = manifestIndex(&mm); err != nil {
+				return nil, err
+			}
+			m = &mm
+			continue
+		}
+
+		rel, ok := contentPath(h.Name)
+		if !ok {
+			return nil, fmt.Errorf("unsafe or unexpected archive entry %q", h.Name)
+		}
+		// Create writes the manifest first, so every entry can be checked
+		// against it before anything is written.
+		if m == nil {
+			return nil, fmt.Errorf("archive entry %s comes before %s", rel, ManifestName)
+		}
+		if err := checkListed(h, rel, files, dirs); err != nil {
+			return nil, err
+		}
+		// A bundle never holds entries below a symlink (Create does not
+		// follow them), and linkStaysInside only holds for real directories.
+		if link, ok := linkedParent(root, rel); ok {
+			return nil, fmt.Errorf("archive entry %s lies below the symlink %s", rel, link)
+		}
+		dst := filepath.FromSlash(rel)
+		if err := root.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return nil, err
+		}
+
+		switch h.Typeflag {
+		case tar.TypeDir:
+			if err := root.MkdirAll(dst, 0o755); err != nil {
+				return nil, err
+			}
+		case tar.TypeSymlink:
+			if !linkStaysInside(rel, h.Linkname) {
+				return nil, fmt.Errorf("archive symlink %s → %s points outside the bundle", rel, h.Linkname)
+			}
+			if err := root.Symlink(filepath.FromSlash(h.Linkname), dst); err != nil {
+				return nil, err
+			}
+		case tar.TypeReg:
+			out, err := root.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, h.FileInfo().Mode().Perm())
+			if err != nil {
+				return nil, err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return nil, err
+			}
+			if err := out.Close(); err != nil {
+				return nil, err
+			}
+			_ = root.Chtimes(dst, h.ModTime, h.ModTime)
+		default:
+			return nil, fmt.Errorf("unsupported archive entry type for %s", h.Name)
+		}
+	}
+
+	if m == nil {
+		return nil, fmt.Errorf("archive has no %s; not an axon bundle", ManifestName)
+	}
+	if m.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("bundle format %d is newer than supported (%d); upgrade axon", m.FormatVersion, FormatVersion)
+	}
+	if err := Verify(contentRoot, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Verify checks that every manifest file exists under contentRoot with the
+// recorded digest or link target.
+func Verify(contentRoot string, m *Manifest) error {
+	for _, f := range m.Files {
+		p := filepath.Join(contentRoot, filepath.FromSlash(f.Path))
+		if f.Link != "" {
+			got, err := os.Readlink(p)
+			if err != nil {
+				return fmt.Errorf("bundle is missing symlink %s: %w", f.Path, err)
+			}
+			if filepath.ToSlash(got) != f.Link {
+				return fmt.Errorf("bundle symlink %s → %s, manifest says %s", f.Path, got, f.Link)
+			}
+			continue
+		}
+		sum, err := hash.File(p)
+		if err != nil {
+			return fmt.Errorf("bundle is missing %s: %w", f.Path, err)
+		}
+		if sum != f.SHA256 {
+			return fmt.Errorf("checksum mismatch for %s (archive corrupted or tampered)", f.Path)
+		}
+	}
+	return nil
+}
+
+// manifestIndex indexes the files of m by path, along with every directory
+// above them. It rejects paths that are unsafe or lie inside a .git
+// directory, which would let a bundle plant hooks in the Hub.
+func manifestIndex(m *Manifest) (map[string]File, map[string]bool, error) {
+	files := make(map[string]File, len(m.Files))
+	dirs := make(map[string]bool)
+	for _, f := range m.Files {
+		rel, ok := contentPath(ContentDir + "/" + f.Path)
+		if !ok || rel != f.Path {
+			return nil, nil, fmt.Errorf("invalid manifest: unsafe path %q", f.Path)
+		}
+		if inGitDir(rel) {
+			return nil, nil, fmt.Errorf("invalid manifest: %s lies inside a .git directory", rel)
+		}
+		files[rel] = f
+		for d := path.Dir(rel); d != "."; d = path.Dir(d) {
+			dirs[d] = true
+		}
+	}
+	return files, dirs, nil
+}
+
+// checkListed reports an error unless the archive entry h at rel is what
+// the manifest lists there.
+func checkListed(h *tar.Header, rel string, files map[string]File, dirs map[string]bool) error {
+	if h.Typeflag == tar.TypeDir {
+		if !dirs[rel] {
+			return fmt.Errorf("archive directory %s is not listed in the manifest", rel)
+		}
+		return nil
+	}
+	f, ok := files[rel]
+	if !ok {
+		return fmt.Errorf("archive entry %s is not listed in the manifest", rel)
+	}
+	if (h.Typeflag == tar.TypeSymlink) != (f.Link != "") {
+		return fmt.Errorf("archive entry %s does not match its manifest type", rel)
+	}
+	return nil
+}
+
+// inGitDir reports whether the slash-separated rel is or lies inside a
+// .git directory.
+func inGitDir(rel string) bool {
+	for _, part := range strings.Split(rel, "/") {
+		if strings.EqualFold(part, ".git") {
+			return true
+		}
+	}
+	return false
+}
+
+// contentPath maps an archive entry name to a safe path relative to hub/.
+func contentPath(name string) (string, bool) {
+	name = strings.TrimPrefix(strings.ReplaceAll(name, "\\", "/"), "./")
+	if !strings.HasPrefix(name, ContentDir+"/") {
+		return "", false
+	}
+	rel := path.Clean(strings.TrimPrefix(name, ContentDir+"/"))
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") || strings.HasPrefix(rel, "/") {
+		return "", false
+	}
+	return rel, true
+}
+
+// linkedParent returns the first directory above rel (slash-separated,
+// relative to root) that is a symlink.
+func linkedParent(root *os.Root, rel string) (string, bool) {
+	dir := path.Dir(rel)
+	if dir == "." {
+		return "", false
+	}
+	parts := strings.Split(dir, "/")
+	for i := range parts {
+		p := strings.Join(parts[:i+1], "/")
+		info, err := root.Lstat(filepath.FromSlash(p))
+		if err != nil {
+			return "", false // not created yet, so nothing below it either
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// linkStaysInside reports whether a relative symlink at rel resolves within
+// the bundle content root.
+func linkStaysInside(rel, target string) bool {
+	target = strings.ReplaceAll(target, "\\", "/")
+	if target == "" || strings.HasPrefix(target, "/") || filepath.IsAbs(target) {
+		return false
+	}
+	resolved := path.Clean(path.Join(path.Dir(rel), target))
+	return resolved != ".." && !strings.HasPrefix(resolved, "../")
+}
@@ -0,0 +1,197 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateExtract_RoundTrip(t *testing.T) {
+	hub := t.TempDir()
+	writeFile(t, filepath.Join(hub, "skills", "a", "SKILL.md"), "# A\n")
+	writeFile(t, filepath.Join(hub, "skills", "a", "scripts", "run.sh"), "echo hi\n")
+	writeFile(t, filepath.Join(hub, "skills", "a", ".git", "HEAD"), "ignored\n")
+	writeFile(t, filepath.Join(hub, "skills", "b", "SKILL.md"), "# B\n")
+	if runtime.GOOS != "windows" {
+		if err := os.Symlink("SKILL.md", filepath.Join(hub, "skills", "a", "README.md")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive := filepath.Join(t.TempDir(), "hub.tar.gz")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Create(f, hub, Manifest{Items: []Item{{Path: "skills/a", Version: "1.2.0"}}})
+	f.Close()
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	for _, file := range m.Files {
+		if strings.Contains(file.Path, ".git") || strings.HasPrefix(file.Path, "skills/b") {
+			t.Fatalf("unexpected file in bundle: %s", file.Path)
+		}
+	}
+
+	dest := t.TempDir()
+	got, err := Extract(archive, dest)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if len(got.Items) != 1 || got.Items[0].Version != "1.2.0" {
+		t.Fatalf("items = %+v", got.Items)
+	}
+	b, err := os.ReadFile(filepath.Join(dest, ContentDir, "skills", "a", "scripts", "run.sh"))
+	if err != nil || string(b) != "echo hi\n" {
+		t.Fatalf("run.sh = %q, %v", b, err)
+	}
+	if runtime.GOOS != "windows" {
+		link, err := os.Readlink(filepath.Join(dest, ContentDir, "skills", "a", "README.md"))
+		if err != nil || link != "SKILL.md" {
+			t.Fatalf("README.md link = %q, %v", link, err)
+		}
+	}
+}
+
+// buildArchive writes a raw bundle with the given manifest and entries.
+func buildArchive(t *testing.T, m Manifest, entries map[string]string, links map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	mb, _ := json.Marshal(m)
+	_ = tw.WriteHeader(&tar.Header{Name: ManifestName, Mode: 0o644, Size: int64(len(mb))})
+	_, _ = tw.Write(mb)
+	for name, body := range entries {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte(body))
+	}
+	for name, target := range links {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Linkname: target, Typeflag: tar.TypeSymlink})
+	}
+	tw.Close()
+	gz.Close()
+	p := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := os.WriteFile(p, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestExtract_DetectsTampering(t *testing.T) {
+	m := Manifest{
+		FormatVersion: FormatVersion,
+		Files:         []File{{Path: "skills/a/SKILL.md", Size: 4, SHA256: strings.Repeat("0", 64)}},
+	}
+	p := buildArchive(t, m, map[string]string{"hub/skills/a/SKILL.md": "# A\n"}, nil)
+	if _, err := Extract(p, t.TempDir()); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+}
+
+func TestExtract_RejectsUnsafeEntries(t *testing.T) {
+	m := Manifest{FormatVersion: FormatVersion}
+
+	p := buildArchive(t, m, map[string]string{"hub/../../evil": "x"}, nil)
+	if _, err := Extract(p, t.TempDir()); err == nil {
+		t.Fatal("expected error for path traversal entry")
+	}
+
+	p = buildArchive(t, m, nil, map[string]string{"hub/skills/a/x": "../../../etc/passwd"})
+	if _, err := Extract(p, t.TempDir()); err == nil {
+		t.Fatal("expected error for escaping symlink")
+	}
+}
+
+func TestExtract_MissingManifest(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tar.NewWriter(gz).Close()
+	gz.Close()
+	p := filepath.Join(t.TempDir(), "empty.tar.gz")
+	_ = os.WriteFile(p, buf.Bytes(), 0o644)
+	if _, err := Extract(p, t.TempDir()); err == nil || !strings.Contains(err.Error(), ManifestName) {
+		t.Fatalf("expected missing manifest error, got %v", err)
+	}
+}
+
+func TestExtract_RejectsSymlinkChainEscape(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks need extra privileges on Windows")
+	}
+	// Each link passes the string check on its own, but a/b is the content
+	// root itself, so a/b/c is hub/c → .., the extraction directory, and
+	// a/b/c/d is its parent.
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	m := Manifest{FormatVersion: FormatVersion, Files: []File{
+		{Path: "a/b", Link: ".."}, {Path: "a/b/c", Link: ".."}, {Path: "a/b/c/d", Link: ".."},
+		{Path: "a/b/c/d/evil", Size: 6, SHA256: strings.Repeat("0", 64)},
+	}}
+	mb, _ := json.Marshal(m)
+	_ = tw.WriteHeader(&tar.Header{Name: ManifestName, Mode: 0o644, Size: int64(len(mb))})
+	_, _ = tw.Write(mb)
+	for _, l := range [][2]string{{"hub/a/b", ".."}, {"hub/a/b/c", ".."}, {"hub/a/b/c/d", ".."}} {
+		_ = tw.WriteHeader(&tar.Header{Name: l[0], Linkname: l[1], Typeflag: tar.TypeSymlink})
+	}
+	body := "pwned\n"
+	_ = tw.WriteHeader(&tar.Header{Name: "hub/a/b/c/d/evil", Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg})
+	_, _ = tw.Write([]byte(body))
+	tw.Close()
+	gz.Close()
+
+	parent := t.TempDir()
+	dest := filepath.Join(parent, "x", "dest")
+	p := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := os.WriteFile(p, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Extract(p, dest); err == nil {
+		t.Fatal("expected an error for entries below extracted symlinks")
+	}
+	for _, dir := range []string{dest, filepath.Join(parent, "x"), parent} {
+		if _, err := os.Lstat(filepath.Join(dir, "evil")); err == nil {
+			t.Errorf("Extract wrote %s outside the content root", filepath.Join(dir, "evil"))
+		}
+	}
+}
+
+func TestExtract_RejectsUnlistedAndGitEntries(t *testing.T) {
+	listed := Manifest{
+		FormatVersion: FormatVersion,
+		Files:         []File{{Path: "skills/a/SKILL.md", Size: 4, SHA256: strings.Repeat("0", 64)}},
+	}
+	hook := map[string]string{"hub/skills/a/SKILL.md": "# A\n", "hub/.git/hooks/x": "#!/bin/sh\ntouch pwned\n"}
+
+	dest := t.TempDir()
+	if _, err := Extract(buildArchive(t, listed, hook, nil), dest); err == nil || !strings.Contains(err.Error(), "not listed") {
+		t.Fatalf("expected an error for an entry missing from the manifest, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, ContentDir, ".git")); err == nil {
+		t.Error("Extract wrote the unlisted .git entry")
+	}
+
+	withGit := listed
+	withGit.Files = append(withGit.Files, File{Path: ".git/hooks/x", Size: 21, Mode: 0o755, SHA256: strings.Repeat("0", 64)})
+	if _, err := Extract(buildArchive(t, withGit, hook, nil), t.TempDir()); err == nil || !strings.Contains(err.Error(), ".git") {
+		t.Fatalf("expected an error for a .git entry in the manifest, got %v", err)
+	}
+}
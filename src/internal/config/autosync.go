@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MinAutoSyncInterval is the shortest auto_sync interval accepted, so a
+// typo such as "30s" does not hammer the remote.
+const MinAutoSyncInterval = time.Minute
+
+// AutoSyncInterval returns the auto_sync interval, or 0 when scheduled
+// syncs are disabled.
+func (c *Config) AutoSyncInterval() (time.Duration, error) {
+	if c == nil {
+		return 0, nil
+	}
+	v := strings.TrimSpace(c.AutoSync)
+	if v == "" || strings.EqualFold(v, "off") {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("auto_sync: invalid interval %q (use e.g. 30m or 2h)", c.AutoSync)
+	}
+	if d < MinAutoSyncInterval {
+		return 0, fmt.Errorf("auto_sync: interval %s is shorter than %s", d, MinAutoSyncInterval)
+	}
+	return d, nil
+}
+
+// validateAutoSync rejects an auto_sync interval that does not parse.
+func (c *Config) validateAutoSync() error {
+	_, err := c.AutoSyncInterval()
+	return err
+}
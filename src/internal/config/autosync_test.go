@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_AutoSyncInterval(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":    0,
+		"off": 0,
+		"OFF": 0,
+		"30m": 30 * time.Minute,
+		"2h":  2 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := (&Config{AutoSync: in}).AutoSyncInterval()
+		if err != nil || got != want {
+			t.Errorf("AutoSyncInterval(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"often", "30", "10s"} {
+		if _, err := (&Config{AutoSync: in}).AutoSyncInterval(); err == nil {
+			t.Errorf("AutoSyncInterval(%q) should fail", in)
+		}
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
+	"github.com/kamusis/axon-cli/internal/rules"
 	"gopkg.in/yaml.v3"
 )
 
@@ -15,6 +18,101 @@ type Target struct {
 	Source      string `yaml:"source"`
 	Destination string `yaml:"destination"`
 	Type        string `yaml:"type"`
+
+	// Vars and Append apply to type: file targets only. With either set,
+	// the destination is a rendered file instead of a symlink: the source
+	// followed by the Append files (Hub paths), run through text/template
+	// with Vars plus {{.target}} and {{.tool}} when Vars is non-empty.
+	Vars   map[string]string `yaml:"vars,omitempty"`
+	Append []string          `yaml:"append,omitempty"`
+
+	// Format is the tool format a type: rules target is rendered into:
+	// cursor, windsurf, or claude (see internal/rules).
+	Format string `yaml:"format,omitempty"`
+
+	// Group puts the target in a named set, e.g. jetbrains, so
+	// 'axon link @jetbrains' and 'axon unlink @jetbrains' act on all of them.
+	Group string `yaml:"group,omitempty"`
+
+	// Repos lists, highest priority first, the Hub repos this target draws
+	// from. Empty means every configured repo in priority order.
+	Repos []string `yaml:"repos,omitempty"`
+
+	// LinkStyle overrides the global link_style for this target.
+	LinkStyle string `yaml:"link_style,omitempty"`
+
+	// Mode is how the destination is provisioned: auto (default), symlink,
+	// or copy. Auto copies into Windows drives under WSL when a symlink
+	// would not be usable by Windows programs.
+	Mode string `yaml:"mode,omitempty"`
+
+	// ReadOnly marks a directory target as read-only on the tool side: files
+	// the tool writes into the Hub through it are reported by 'axon status'
+	// and 'axon doctor'.
+	ReadOnly bool `yaml:"read_only,omitempty"`
+
+	// PostLink and PostUnlink are shell commands run after 'axon link' or
+	// 'axon unlink' changed the destination, e.g. to refresh a tool's cache.
+	// AXON_DEST, AXON_TARGET, AXON_SOURCE, and AXON_HUB describe the target.
+	PostLink   string `yaml:"post_link,omitempty"`
+	PostUnlink string `yaml:"post_unlink,omitempty"`
+
+	// Project is the project root for targets added from a .axon.yaml.
+	Project string `yaml:"-"`
+}
+
+// Target types: what a target's source names in the Hub.
+const (
+	TargetTypeDirectory = "directory"
+	TargetTypeFile      = "file"
+	TargetTypeRules     = "rules"
+)
+
+// IsFile reports whether t links a single file, e.g. a shared AGENTS.md.
+func (t Target) IsFile() bool {
+	return t.Type == TargetTypeFile
+}
+
+// IsRules reports whether t renders a directory of canonical rules into a
+// tool's rule format.
+func (t Target) IsRules() bool {
+	return t.Type == TargetTypeRules
+}
+
+// Link styles for the symlinks created by 'axon link'.
+const (
+	LinkStyleAbsolute = "absolute"
+	LinkStyleRelative = "relative"
+)
+
+// Target modes: how 'axon link' provisions a destination.
+const (
+	LinkModeAuto    = "auto"
+	LinkModeSymlink = "symlink"
+	LinkModeCopy    = "copy"
+)
+
+// PrimaryRepoName names the Hub at repo_path when several repos are configured.
+const PrimaryRepoName = "default"
+
+// Repo is an additional Hub repository layered with the primary Hub at
+// repo_path, e.g. a read-only company Hub next to a personal one.
+type Repo struct {
+	Name   string `yaml:"name"`
+	Path   string `yaml:"path"`
+	Remote string `yaml:"remote,omitempty"`
+
+	// SyncMode defaults to read-only for additional repos.
+	SyncMode string `yaml:"sync_mode,omitempty"`
+
+	// Priority orders repos when several provide the same item; higher wins.
+	// The primary Hub has priority 0 and wins ties.
+	Priority int `yaml:"priority,omitempty"`
+
+	// Project is the project root for the repo added from a .axon.yaml.
+	// Project repos live inside the project's own checkout and are never
+	// synced by axon.
+	Project string `yaml:"-"`
 }
 
 // Vendor represents a single external repo/subdir source entry in axon.yaml.
@@ -24,6 +122,47 @@ type Vendor struct {
 	Subdir string `yaml:"subdir"`
 	Dest   string `yaml:"dest"`
 	Ref    string `yaml:"ref,omitempty"`
+
+	// Depth clones and fetches only the last Depth commits of the repo; 0
+	// keeps the full history. A shallow clone is deepened to full history
+	// when a ref or an earlier sync is not in it.
+	Depth int `yaml:"depth,omitempty"`
+
+	// Sparse limits the checkout and the mirror to these paths, relative to
+	// Subdir. Empty takes the whole Subdir.
+	Sparse []string `yaml:"sparse,omitempty"`
+
+	// Transform reshapes the files on their way into the Hub.
+	Transform VendorTransform `yaml:"transform,omitempty"`
+
+	// Registry names the registry 'axon install' took the entry from.
+	Registry string `yaml:"registry,omitempty"`
+}
+
+// VendorTransform lists the rules applied to a vendor's files before they
+// are mirrored, in this order: exclude, rename, flatten, provenance. Paths
+// and globs are slash-separated and relative to the vendor's subdir.
+type VendorTransform struct {
+	// Exclude drops files and directories whose name or path matches one
+	// of these globs.
+	Exclude []string `yaml:"exclude,omitempty"`
+
+	// Rename moves files or directories, one rule after another.
+	Rename []VendorRename `yaml:"rename,omitempty"`
+
+	// Flatten lifts the contents of directories matching these globs into
+	// their parent and removes the emptied directories.
+	Flatten []string `yaml:"flatten,omitempty"`
+
+	// Provenance adds a comment naming the upstream repo, path, and commit
+	// to every SKILL.md, after its frontmatter.
+	Provenance bool `yaml:"provenance,omitempty"`
+}
+
+// VendorRename moves From to To.
+type VendorRename struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
 }
 
 // Config is the in-memory representation of ~/.axon/axon.yaml.
@@ -34,6 +173,72 @@ type Config struct {
 	Excludes []string `yaml:"excludes,omitempty"`
 	Targets  []Target `yaml:"targets,omitempty"`
 	Vendors  []Vendor `yaml:"vendors,omitempty"`
+
+	// Registries are the skill indexes 'axon browse' and 'axon install'
+	// read; the community registry is used when none are configured.
+	Registries []Registry `yaml:"registries,omitempty"`
+
+	// Repos are additional Hubs merged with repo_path by 'axon link'.
+	Repos []Repo `yaml:"repos,omitempty"`
+
+	// LinkStyle selects absolute (default) or relative symlink paths.
+	// Relative links survive home directories mounted at different paths.
+	LinkStyle string `yaml:"link_style,omitempty"`
+
+	// UpdateChannel selects the release feed used by 'axon update':
+	// stable (default), beta (prereleases), or nightly.
+	UpdateChannel string `yaml:"update_channel,omitempty"`
+
+	// UpdateCheck opts in to a once-a-day background check for new releases.
+	// AXON_NO_UPDATE_CHECK=1 disables it regardless of this setting.
+	UpdateCheck bool `yaml:"update_check,omitempty"`
+
+	// Language selects the language of messages and command summaries
+	// (en, zh);
+	// AXON_LANG overrides it.
+	Language string `yaml:"language,omitempty"`
+
+	// MinFreeSpace is the free space ("500MB", "2GB") sync, import, and
+	// update require before writing; "0" disables the check.
+	MinFreeSpace string `yaml:"min_free_space,omitempty"`
+
+	// MaxFileSize is the largest file ("10MB") 'axon sync' commits to the
+	// Hub; bigger files are left out unless --allow-large. "0" disables it.
+	MaxFileSize string `yaml:"max_file_size,omitempty"`
+
+	// ContextLimits is the largest file ("64KB") each tool reads into its
+	// context, keyed by the tool part of target names (e.g. claude-code);
+	// "default" applies to tools not listed. 'axon inspect --tree' flags
+	// bigger files. "0" disables the check for a tool.
+	ContextLimits map[string]string `yaml:"context_limits,omitempty"`
+
+	// Secrets configures the credential scan run before sync commits.
+	Secrets SecretsConfig `yaml:"secrets,omitempty"`
+
+	// TrashRetention is how long ("30d", "72h") items removed by 'axon gc'
+	// and 'axon doctor --fix' stay in ~/.axon/trash; "0" keeps them until
+	// 'axon trash empty'.
+	TrashRetention string `yaml:"trash_retention,omitempty"`
+
+	// UsageTracking turns on sampling of skill access times, so 'axon stats'
+	// and 'axon gc --suggest' can list skills no tool has read in a while.
+	UsageTracking bool `yaml:"usage_tracking,omitempty"`
+
+	// AutoSync is the interval ("30m", "2h") at which 'axon sync --daemon'
+	// syncs the Hub; empty or "off" disables scheduled syncs.
+	AutoSync string `yaml:"auto_sync,omitempty"`
+
+	// Overrides adjust targets and excludes per machine, keyed by the
+	// AXON_MACHINE label or hostname.
+	Overrides map[string]MachineOverride `yaml:"overrides,omitempty"`
+
+	// ProjectRoot is the directory of the .axon.yaml applied by Load, if any.
+	ProjectRoot string `yaml:"-"`
+
+	// Machine is the overrides key applied by Load, if any.
+	Machine string `yaml:"-"`
+
+	base *machineBase
 }
 
 // EffectiveSearchRoots derives the searchable top-level directories from configured targets.
@@ -41,13 +246,13 @@ type Config struct {
 // The intent is to avoid introducing a separate search_roots config item; instead, any new
 // content directory (e.g. rules/) will naturally appear as a new Target.Source.
 //
-// If no targets are configured (older configs), a backwards-compatible default is returned.
+// If no targets are configured (older configs), DefaultContentRoots is returned.
 func (c *Config) EffectiveSearchRoots() []string {
 	seen := make(map[string]struct{})
 	out := make([]string, 0, len(c.Targets))
 	for _, t := range c.Targets {
 		s := strings.TrimSpace(t.Source)
-		if s == "" {
+		if s == "" || t.IsFile() {
 			continue
 		}
 		if _, ok := seen[s]; ok {
@@ -57,21 +262,207 @@ func (c *Config) EffectiveSearchRoots() []string {
 		out = append(out, s)
 	}
 	if len(out) == 0 {
-		return []string{"skills", "workflows", "commands"}
+		return DefaultContentRoots()
 	}
 	return out
 }
 
-// AxonDir returns the absolute path to ~/.axon/.
-func AxonDir() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("cannot determine home directory: %w", err)
+// DefaultContentRoots returns the Hub's standard top-level content
+// directories. Skills are directories holding a SKILL.md; every other root
+// holds one document per Markdown file.
+func DefaultContentRoots() []string {
+	return []string{"skills", "workflows", "commands", "rules", "memories"}
+}
+
+// HubRepos returns the primary Hub followed by any additional repos, ordered
+// by descending priority. Ties keep config order with the primary Hub first.
+func (c *Config) HubRepos() []Repo {
+	primaryMode := c.SyncMode
+	if primaryMode == "" {
+		primaryMode = "read-write"
 	}
-	return filepath.Join(home, ".axon"), nil
+	out := []Repo{{Name: PrimaryRepoName, Path: c.RepoPath, SyncMode: primaryMode}}
+	for _, r := range c.Repos {
+		if r.SyncMode == "" {
+			r.SyncMode = "read-only"
+		}
+		out = append(out, r)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Priority > out[j].Priority })
+	return out
+}
+
+// FindRepo looks up a Hub repo by name; PrimaryRepoName is the repo_path Hub.
+func (c *Config) FindRepo(name string) (Repo, bool) {
+	for _, r := range c.HubRepos() {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Repo{}, false
+}
+
+// TargetRepos returns the repos t draws from, highest priority first.
+// Targets without a repos list draw from every repo except project repos.
+func (c *Config) TargetRepos(t Target) ([]Repo, error) {
+	if len(t.Repos) == 0 {
+		var out []Repo
+		for _, r := range c.HubRepos() {
+			if r.Project == "" {
+				out = append(out, r)
+			}
+		}
+		return out, nil
+	}
+	out := make([]Repo, 0, len(t.Repos))
+	for _, name := range t.Repos {
+		r, ok := c.FindRepo(name)
+		if !ok {
+			return nil, fmt.Errorf("target %q: unknown repo %q", t.Name, name)
+		}
+		out = append(out, r)
+	}
+	return out, nil
 }
 
-// ConfigPath returns the absolute path to ~/.axon/axon.yaml.
+// TargetLinkStyle returns the link style for t: its own link_style, else the
+// global one, else absolute.
+func (c *Config) TargetLinkStyle(t Target) string {
+	if t.LinkStyle != "" {
+		return t.LinkStyle
+	}
+	if c.LinkStyle != "" {
+		return c.LinkStyle
+	}
+	return LinkStyleAbsolute
+}
+
+// validateLinkStyles rejects unknown link_style values.
+func (c *Config) validateLinkStyles() error {
+	valid := func(s string) bool { return s == "" || s == LinkStyleAbsolute || s == LinkStyleRelative }
+	if !valid(c.LinkStyle) {
+		return fmt.Errorf("link_style must be %s or %s, got %q", LinkStyleAbsolute, LinkStyleRelative, c.LinkStyle)
+	}
+	for _, t := range c.Targets {
+		if !valid(t.LinkStyle) {
+			return fmt.Errorf("target %q: link_style must be %s or %s, got %q", t.Name, LinkStyleAbsolute, LinkStyleRelative, t.LinkStyle)
+		}
+	}
+	return nil
+}
+
+// validateLinkModes rejects unknown target modes.
+func (c *Config) validateLinkModes() error {
+	for _, t := range c.Targets {
+		switch t.Mode {
+		case "", LinkModeAuto, LinkModeSymlink, LinkModeCopy:
+		default:
+			return fmt.Errorf("target %q: mode must be %s, %s, or %s, got %q", t.Name, LinkModeAuto, LinkModeSymlink, LinkModeCopy, t.Mode)
+		}
+	}
+	return nil
+}
+
+// validateTargets checks target types, that every source is a path inside
+// the Hub, such as skills or skills/db-only, and that no two targets of the
+// same tool share a destination.
+func (c *Config) validateTargets() error {
+	dests := make(map[string]string)
+	for _, t := range c.Targets {
+		if err := t.validateType(); err != nil {
+			return err
+		}
+		if !ValidSource(t.Source) {
+			return fmt.Errorf("target %q: source must be a relative path inside the Hub, got %q", t.Name, t.Source)
+		}
+		dest, err := ExpandPath(t.Destination)
+		if err != nil {
+			dest = t.Destination
+		}
+		key := t.Tool() + "\x00" + filepath.Clean(dest)
+		if other, ok := dests[key]; ok {
+			return fmt.Errorf("targets %q and %q both link %s", other, t.Name, dest)
+		}
+		dests[key] = t.Name
+	}
+	return nil
+}
+
+// validateType checks t's type and the fields that only file targets take.
+func (t Target) validateType() error {
+	if t.Format != "" && t.Type != TargetTypeRules {
+		return fmt.Errorf("target %q: format needs type: %s", t.Name, TargetTypeRules)
+	}
+	if t.ReadOnly && t.Type != "" && t.Type != TargetTypeDirectory {
+		return fmt.Errorf("target %q: read_only needs type: %s", t.Name, TargetTypeDirectory)
+	}
+	switch t.Type {
+	case "", TargetTypeDirectory, TargetTypeRules:
+		if len(t.Vars) > 0 || len(t.Append) > 0 {
+			return fmt.Errorf("target %q: vars and append need type: %s", t.Name, TargetTypeFile)
+		}
+		if t.Type == TargetTypeRules && !slices.Contains(rules.Formats, t.Format) {
+			return fmt.Errorf("target %q: format must be one of %s, got %q", t.Name, strings.Join(rules.Formats, ", "), t.Format)
+		}
+	case TargetTypeFile:
+		if t.Source == "" {
+			return fmt.Errorf("target %q: a file target needs a source file", t.Name)
+		}
+		for _, a := range t.Append {
+			if a == "" || !ValidSource(a) {
+				return fmt.Errorf("target %q: append must list relative paths inside the Hub, got %q", t.Name, a)
+			}
+		}
+	default:
+		return fmt.Errorf("target %q: type must be %s, %s, or %s, got %q", t.Name, TargetTypeDirectory, TargetTypeFile, TargetTypeRules, t.Type)
+	}
+	return nil
+}
+
+// ValidSource reports whether source names a directory inside the Hub: a
+// relative path that does not climb out with "..". The empty source (the
+// Hub root) is allowed.
+func ValidSource(source string) bool {
+	return source == "" || filepath.IsLocal(filepath.FromSlash(source))
+}
+
+// Tool returns the tool t belongs to: its name up to the last "-", so
+// claude-code-skills and claude-code-db-only are both claude-code targets.
+func (t Target) Tool() string {
+	if i := strings.LastIndex(t.Name, "-"); i != -1 {
+		return t.Name[:i]
+	}
+	return t.Name
+}
+
+// validateRepos checks repo names, paths, and target repo references.
+func (c *Config) validateRepos() error {
+	seen := map[string]bool{PrimaryRepoName: true}
+	for i, r := range c.Repos {
+		switch {
+		case r.Name == "":
+			return fmt.Errorf("repos[%d]: 'name' is required", i)
+		case r.Name == PrimaryRepoName:
+			return fmt.Errorf("repos[%d]: name %q is reserved for repo_path", i, PrimaryRepoName)
+		case seen[r.Name]:
+			return fmt.Errorf("repos[%d]: duplicate name %q", i, r.Name)
+		case r.Path == "":
+			return fmt.Errorf("repo %q: 'path' is required", r.Name)
+		case r.SyncMode != "" && r.SyncMode != "read-only" && r.SyncMode != "read-write":
+			return fmt.Errorf("repo %q: sync_mode must be read-only or read-write", r.Name)
+		}
+		seen[r.Name] = true
+	}
+	for _, t := range c.Targets {
+		if _, err := c.TargetRepos(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfigPath returns the absolute path to axon.yaml in the axon home
+// (~/.axon/axon.yaml by default).
 func ConfigPath() (string, error) {
 	dir, err := AxonDir()
 	if err != nil {
@@ -82,10 +473,14 @@ func ConfigPath() (string, error) {
 
 // ExpandPath expands a leading ~ to the user's home directory.
 func ExpandPath(p string) (string, error) {
+	p, err := expandTemplates(p)
+	if err != nil {
+		return "", err
+	}
 	if !strings.HasPrefix(p, "~") {
 		return p, nil
 	}
-	home, err := os.UserHomeDir()
+	home, err := UserHome()
 	if err != nil {
 		return "", fmt.Errorf("cannot expand ~: %w", err)
 	}
@@ -94,14 +489,18 @@ func ExpandPath(p string) (string, error) {
 
 // DefaultConfig returns the default Config written on first axon init.
 func DefaultConfig() (*Config, error) {
-	home, err := os.UserHomeDir()
+	home, err := UserHome()
+	if err != nil {
+		return nil, err
+	}
+	axonDir, err := AxonDir()
 	if err != nil {
 		return nil, err
 	}
 	j := func(parts ...string) string { return filepath.Join(append([]string{home}, parts...)...) }
 
 	return &Config{
-		RepoPath: j(".axon", "repo"),
+		RepoPath: filepath.Join(axonDir, "repo"),
 		SyncMode: "read-write",
 		Upstream: "https://github.com/kamusis/axon-hub.git",
 		Excludes: []string{
@@ -136,6 +535,9 @@ func DefaultConfig() (*Config, error) {
 			{Name: "claude-code-commands", Source: "commands", Destination: j(".claude", "commands"), Type: "directory"},
 			{Name: "gemini-commands", Source: "commands", Destination: j(".gemini", "commands"), Type: "directory"},
 			{Name: "qoder-commands", Source: "commands", Destination: j(".qoder", "commands"), Type: "directory"},
+			// === RULES & MEMORIES (The Standing Instructions) ===
+			{Name: "claude-code-rules", Source: "rules", Destination: j(".claude", "rules"), Type: "directory"},
+			{Name: "windsurf-memories", Source: "memories", Destination: j(".codeium", "windsurf", "memories"), Type: "directory"},
 		},
 	}, nil
 }
@@ -150,7 +552,41 @@ func Load() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot read config %s: %w", path, err)
 	}
-	var cfg Config
+	cfg, err := parseConfig(path, data)
+	if err != nil {
+		return nil, err
+	}
+	// Schema problems Load does not fail on, such as unknown keys.
+	for _, p := range append(Validate(data), overlapProblems(cfg, data)...) {
+		Warn(path, p)
+	}
+
+	// Layer a project .axon.yaml found from the working directory upward.
+	if wd, err := os.Getwd(); err == nil {
+		projectPath, err := FindProjectConfig(wd)
+		if err != nil {
+			return nil, err
+		}
+		if projectPath != "" {
+			p, err := LoadProject(projectPath)
+			if err != nil {
+				return nil, err
+			}
+			if err := cfg.ApplyProject(p, filepath.Dir(projectPath)); err != nil {
+				return nil, fmt.Errorf("invalid project config %s: %w", projectPath, err)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// parseConfig parses and checks the axon.yaml content data read from path,
+// expanding paths and applying the machine overrides.
+func parseConfig(path string, data []byte) (*Config, error) {
+	var (
+		cfg Config
+		err error
+	)
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("invalid YAML in %s: %w", path, err)
 	}
@@ -159,6 +595,47 @@ func Load() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	for i := range cfg.Repos {
+		if cfg.Repos[i].Path, err = ExpandPath(cfg.Repos[i].Path); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.ApplyMachineOverrides(MachineLabel()); err != nil {
+		return nil, fmt.Errorf("invalid overrides in %s: %w", path, err)
+	}
+	if err := cfg.validateRepos(); err != nil {
+		return nil, fmt.Errorf("invalid repos in %s: %w", path, err)
+	}
+	if err := cfg.validateLinkStyles(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.validateLinkModes(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.validateTargets(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.validateMinFreeSpace(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.validateMaxFileSize(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.validateContextLimits(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.validateSecrets(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.validateAutoSync(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.validateTrashRetention(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := cfg.validateRegistries(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
 	return &cfg, nil
 }
 
@@ -168,7 +645,7 @@ func Save(cfg *Config) error {
 	if err != nil {
 		return err
 	}
-	data, err := yaml.Marshal(cfg)
+	data, err := yaml.Marshal(cfg.withoutProject().withoutMachineOverrides())
 	if err != nil {
 		return fmt.Errorf("cannot marshal config: %w", err)
 	}
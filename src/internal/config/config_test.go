@@ -1,6 +1,8 @@
 package config
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -73,3 +75,168 @@ func TestDefaultConfig_NoVendors(t *testing.T) {
 		t.Errorf("DefaultConfig should have 0 vendors, got %d", len(cfg.Vendors))
 	}
 }
+
+func TestDefaultConfig_RulesAndMemoriesRoots(t *testing.T) {
+	cfg, err := DefaultConfig()
+	if err != nil {
+		t.Fatalf("DefaultConfig: %v", err)
+	}
+	roots := cfg.EffectiveSearchRoots()
+	for _, want := range []string{"rules", "memories"} {
+		if !slices.Contains(roots, want) {
+			t.Errorf("EffectiveSearchRoots() = %v, missing %s", roots, want)
+		}
+		found := false
+		for _, tgt := range cfg.Targets {
+			if tgt.Source == want {
+				found = true
+				if err := tgt.validateType(); err != nil {
+					t.Errorf("default target %s: %v", tgt.Name, err)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("DefaultConfig has no target for %s", want)
+		}
+	}
+	if got := (&Config{}).EffectiveSearchRoots(); !slices.Equal(got, DefaultContentRoots()) {
+		t.Errorf("EffectiveSearchRoots() without targets = %v, want %v", got, DefaultContentRoots())
+	}
+}
+
+func TestConfig_HubReposPriority(t *testing.T) {
+	raw := `repo_path: /tmp/personal
+repos:
+  - name: company
+    path: /tmp/company
+    priority: 10
+  - name: archive
+    path: /tmp/archive
+    sync_mode: read-write
+    priority: -1
+targets:
+  - name: claude-skills
+    source: skills
+    destination: /tmp/dest
+  - name: work-only
+    source: skills
+    destination: /tmp/work
+    repos: [company]
+`
+	var cfg Config
+	if err := yaml.Unmarshal([]byte(raw), &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := cfg.validateRepos(); err != nil {
+		t.Fatalf("validateRepos: %v", err)
+	}
+
+	repos := cfg.HubRepos()
+	var names []string
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	if got := strings.Join(names, ","); got != "company,default,archive" {
+		t.Errorf("HubRepos order = %s", got)
+	}
+	if repos[0].SyncMode != "read-only" || repos[1].SyncMode != "read-write" || repos[2].SyncMode != "read-write" {
+		t.Errorf("unexpected sync modes: %+v", repos)
+	}
+
+	only, err := cfg.TargetRepos(cfg.Targets[1])
+	if err != nil || len(only) != 1 || only[0].Path != "/tmp/company" {
+		t.Errorf("TargetRepos(work-only) = %+v, %v", only, err)
+	}
+}
+
+func TestConfig_ValidateReposErrors(t *testing.T) {
+	cases := map[string]Config{
+		"reserved":  {Repos: []Repo{{Name: PrimaryRepoName, Path: "/x"}}},
+		"duplicate": {Repos: []Repo{{Name: "a", Path: "/x"}, {Name: "a", Path: "/y"}}},
+		"no path":   {Repos: []Repo{{Name: "a"}}},
+		"bad mode":  {Repos: []Repo{{Name: "a", Path: "/x", SyncMode: "push"}}},
+		"unknown":   {Targets: []Target{{Name: "t", Repos: []string{"missing"}}}},
+	}
+	for name, cfg := range cases {
+		if err := cfg.validateRepos(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestConfig_TargetLinkStyle(t *testing.T) {
+	cfg := Config{LinkStyle: LinkStyleRelative, Targets: []Target{
+		{Name: "a"},
+		{Name: "b", LinkStyle: LinkStyleAbsolute},
+	}}
+	if err := cfg.validateLinkStyles(); err != nil {
+		t.Fatalf("validateLinkStyles: %v", err)
+	}
+	if got := cfg.TargetLinkStyle(cfg.Targets[0]); got != LinkStyleRelative {
+		t.Errorf("a: got %q, want global relative", got)
+	}
+	if got := cfg.TargetLinkStyle(cfg.Targets[1]); got != LinkStyleAbsolute {
+		t.Errorf("b: got %q, want target override", got)
+	}
+	if got := (&Config{}).TargetLinkStyle(Target{}); got != LinkStyleAbsolute {
+		t.Errorf("default: got %q, want absolute", got)
+	}
+
+	cfg.Targets[1].LinkStyle = "hard"
+	if err := cfg.validateLinkStyles(); err == nil {
+		t.Error("expected error for unknown link_style")
+	}
+}
+
+func TestConfig_ValidateTargets(t *testing.T) {
+	ok := Config{Targets: []Target{
+		{Name: "claude-code-skills", Source: "skills", Destination: "/h/.claude/skills"},
+		{Name: "claude-code-db", Source: "skills/db-only", Destination: "/h/.claude/db"},
+		{Name: "cursor-skills", Source: "skills", Destination: "/h/.claude/skills"},
+	}}
+	if err := ok.validateTargets(); err != nil {
+		t.Fatalf("validateTargets: %v", err)
+	}
+
+	cases := map[string][]Target{
+		"absolute": {{Name: "a-skills", Source: "/etc"}},
+		"escapes":  {{Name: "a-skills", Source: "skills/../../x"}},
+		"collide": {
+			{Name: "claude-code-skills", Source: "skills", Destination: "/h/.claude/skills"},
+			{Name: "claude-code-db", Source: "skills/db-only", Destination: "/h/.claude/skills/"},
+		},
+	}
+	for name, targets := range cases {
+		cfg := Config{Targets: targets}
+		if err := cfg.validateTargets(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestTarget_ValidateType(t *testing.T) {
+	valid := []Target{
+		{Name: "a", Source: "skills"},
+		{Name: "b", Source: "skills", Type: TargetTypeDirectory},
+		{Name: "c", Source: "instructions/AGENTS.md", Type: TargetTypeFile, Vars: map[string]string{"x": "y"}, Append: []string{"instructions/extra.md"}},
+		{Name: "d", Source: "rules", Type: TargetTypeRules, Format: "cursor"},
+	}
+	for _, tg := range valid {
+		if err := tg.validateType(); err != nil {
+			t.Errorf("%s: %v", tg.Name, err)
+		}
+	}
+	invalid := []Target{
+		{Name: "symlink", Type: "link"},
+		{Name: "vars-on-dir", Source: "skills", Vars: map[string]string{"x": "y"}},
+		{Name: "no-source", Type: TargetTypeFile},
+		{Name: "bad-append", Source: "a.md", Type: TargetTypeFile, Append: []string{"../x.md"}},
+		{Name: "rules-no-format", Source: "rules", Type: TargetTypeRules},
+		{Name: "format-on-dir", Source: "rules", Format: "cursor"},
+	}
+	for _, tg := range invalid {
+		if err := tg.validateType(); err == nil {
+			t.Errorf("%s: expected error", tg.Name)
+		}
+	}
+}
@@ -109,3 +109,66 @@ func EnsureDotEnvTemplate() error {
 	}
 	return nil
 }
+
+// AppendDotEnvPlaceholders appends a commented "# KEY=" line to ~/.axon/.env
+// for every key in keys the file does not mention yet, set or commented
+// out, under a "# header" line. It creates the file when it does not exist
+// and returns the keys it added.
+func AppendDotEnvPlaceholders(header string, keys []string) ([]string, error) {
+	p, err := DotEnvPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("cannot read dotenv file %s: %w", p, err)
+	}
+
+	present := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+		if i := strings.Index(line, "="); i > 0 {
+			present[strings.TrimSpace(line[:i])] = true
+		}
+	}
+	var added []string
+	for _, k := range keys {
+		if k != "" && !present[k] {
+			present[k] = true
+			added = append(added, k)
+		}
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	var b strings.Builder
+	if len(data) > 0 {
+		if data[len(data)-1] != '\n' {
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	if header != "" {
+		b.WriteString("# " + header + "\n")
+	}
+	for _, k := range added {
+		b.WriteString("# " + k + "=\n")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open dotenv file %s: %w", p, err)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("cannot write dotenv file %s: %w", p, err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("cannot write dotenv file %s: %w", p, err)
+	}
+	return added, nil
+}
@@ -117,3 +117,41 @@ func TestEnsureDotEnvTemplate_CreatesWhenMissing(t *testing.T) {
 		t.Fatalf("expected non-empty template")
 	}
 }
+
+func TestAppendDotEnvPlaceholders(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	axonDir := filepath.Join(home, ".axon")
+	if err := os.MkdirAll(axonDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	p := filepath.Join(axonDir, ".env")
+	if err := os.WriteFile(p, []byte("A=1\n# B=\n#C=x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := AppendDotEnvPlaceholders("pdf (requires.envs)", []string{"A", "B", "C", "D", "E", "D"})
+	if err != nil {
+		t.Fatalf("AppendDotEnvPlaceholders: %v", err)
+	}
+	if len(added) != 2 || added[0] != "D" || added[1] != "E" {
+		t.Fatalf("added = %v, want [D E]", added)
+	}
+	data, _ := os.ReadFile(p)
+	want := "A=1\n# B=\n#C=x\n\n# pdf (requires.envs)\n# D=\n# E=\n"
+	if string(data) != want {
+		t.Fatalf("file = %q, want %q", data, want)
+	}
+
+	// Placeholders are not values.
+	m, _ := LoadDotEnv()
+	if _, ok := m["D"]; ok {
+		t.Fatalf("placeholder loaded as a value: %v", m)
+	}
+
+	added, err = AppendDotEnvPlaceholders("pdf (requires.envs)", []string{"D", "E"})
+	if err != nil || len(added) != 0 {
+		t.Fatalf("second run added %v, %v", added, err)
+	}
+}
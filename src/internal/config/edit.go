@@ -0,0 +1,103 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetRepoPath rewrites repo_path in ~/.axon/axon.yaml. Unlike Save, it
+// leaves the rest of the file, including comments, as it is.
+func SetRepoPath(repoPath string) error {
+	return editConfig(func(root *yaml.Node) error {
+		if v := mappingValue(root, "repo_path"); v != nil {
+			v.SetString(repoPath)
+			return nil
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "repo_path"}
+		val := &yaml.Node{}
+		val.SetString(repoPath)
+		root.Content = append([]*yaml.Node{key, val}, root.Content...)
+		return nil
+	})
+}
+
+// AddVendor appends v to the vendors block of ~/.axon/axon.yaml, leaving
+// the rest of the file as it is. A vendor of the same name is an error.
+func AddVendor(v Vendor) error {
+	var entry yaml.Node
+	if err := entry.Encode(v); err != nil {
+		return fmt.Errorf("cannot marshal vendor: %w", err)
+	}
+	return editConfig(func(root *yaml.Node) error {
+		vendors := mappingValue(root, "vendors")
+		switch {
+		case vendors == nil:
+			vendors = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "vendors"}, vendors)
+		case vendors.Tag == "!!null":
+			*vendors = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+		if vendors.Kind != yaml.SequenceNode {
+			return fmt.Errorf("vendors is not a list")
+		}
+		for _, item := range vendors.Content {
+			if name := mappingValue(item, "name"); name != nil && name.Value == v.Name {
+				return fmt.Errorf("vendor %q already exists", v.Name)
+			}
+		}
+		vendors.Content = append(vendors.Content, &entry)
+		return nil
+	})
+}
+
+// editConfig applies edit to the top-level mapping of ~/.axon/axon.yaml and
+// writes the file back, keeping comments and key order.
+func editConfig(edit func(root *yaml.Node) error) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read config %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid YAML in %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("invalid config %s: not a YAML mapping", path)
+	}
+	if err := edit(doc.Content[0]); err != nil {
+		return fmt.Errorf("cannot edit config %s: %w", path, err)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("cannot marshal config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("cannot marshal config: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("cannot write config %s: %w", path, err)
+	}
+	return nil
+}
+
+// mappingValue returns the value of key in the mapping node m, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetRepoPath_KeepsComments(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".axon", "axon.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	raw := "# my Hub\nrepo_path: ~/.axon/repo\nsync_mode: read-write # shared\n"
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetRepoPath("/data/axon-hub"); err != nil {
+		t.Fatalf("SetRepoPath: %v", err)
+	}
+	b, _ := os.ReadFile(path)
+	got := string(b)
+	for _, want := range []string{"# my Hub", "repo_path: /data/axon-hub", "# shared"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("axon.yaml missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestAddVendor(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".axon", "axon.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	raw := "repo_path: ~/.axon/repo # my Hub\n"
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	v := Vendor{Name: "community-pdf", Repo: "https://github.com/example/skills.git", Subdir: "pdf", Dest: "skills/pdf", Registry: "community"}
+	if err := AddVendor(v); err != nil {
+		t.Fatalf("AddVendor: %v", err)
+	}
+	if err := AddVendor(v); err == nil {
+		t.Error("adding the same vendor twice should fail")
+	}
+	b, _ := os.ReadFile(path)
+	if !strings.Contains(string(b), "# my Hub") {
+		t.Errorf("comment lost:\n%s", b)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Vendors) != 1 || !reflect.DeepEqual(cfg.Vendors[0], v) {
+		t.Errorf("vendors = %+v, want [%+v]", cfg.Vendors, v)
+	}
+}
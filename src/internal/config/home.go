@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// HomeEnv names the environment variable that moves the axon home (config,
+// Hub, backups, cache, locks, and search index) out of ~/.axon.
+const HomeEnv = "AXON_HOME"
+
+// PortableEnv names the environment variable (set by the global --portable
+// flag) that runs axon entirely from one directory.
+const PortableEnv = "AXON_PORTABLE"
+
+// PortableDir returns the absolute directory of portable mode, or "" when
+// axon runs normally. In portable mode the directory is the axon home and
+// ~ means its home/ subdirectory, so nothing is written to the real home.
+func PortableDir() string {
+	v := os.Getenv(PortableEnv)
+	if v == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(v); err == nil {
+		return abs
+	}
+	return filepath.Clean(v)
+}
+
+// UserHome returns the directory ~ and {home} stand for: the user's home
+// directory, or <dir>/home in portable mode.
+func UserHome() (string, error) {
+	if dir := PortableDir(); dir != "" {
+		return filepath.Join(dir, "home"), nil
+	}
+	return os.UserHomeDir()
+}
+
+// AxonDir returns the absolute path to the axon home directory:
+//
+//  1. the portable directory, in portable mode
+//  2. $AXON_HOME, when set (a leading ~ is expanded)
+//  3. ~/.axon, when it exists
+//  4. $XDG_CONFIG_HOME/axon (default ~/.config/axon), when it exists
+//  5. ~/.axon
+//
+// An existing ~/.axon wins over the XDG location, so setting
+// XDG_CONFIG_HOME does not hide an installation made before it; move the
+// directory to switch.
+func AxonDir() (string, error) {
+	if dir := PortableDir(); dir != "" {
+		return dir, nil
+	}
+	if v := os.Getenv(HomeEnv); v != "" {
+		dir, err := ExpandPath(v)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", HomeEnv, err)
+		}
+		return filepath.Abs(dir)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	legacy := filepath.Join(home, ".axon")
+	if _, err := os.Stat(legacy); err == nil {
+		return legacy, nil
+	}
+	if xdg := xdgConfigDir(home); xdg != "" {
+		if _, err := os.Stat(xdg); err == nil {
+			return xdg, nil
+		}
+	}
+	return legacy, nil
+}
+
+// xdgConfigDir returns $XDG_CONFIG_HOME/axon, or ~/.config/axon when the
+// variable is unset. The spec ignores relative values, and so does axon.
+func xdgConfigDir(home string) string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		base = filepath.Join(home, ".config")
+	}
+	if !filepath.IsAbs(base) {
+		return ""
+	}
+	return filepath.Join(base, "axon")
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAxonDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(HomeEnv, "")
+	xdg := filepath.Join(home, "xdg")
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	check := func(want string) {
+		t.Helper()
+		got, err := AxonDir()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("AxonDir = %s, want %s", got, want)
+		}
+	}
+
+	// Nothing exists yet: the classic location.
+	check(filepath.Join(home, ".axon"))
+
+	// An existing XDG directory is used ...
+	if err := os.MkdirAll(filepath.Join(xdg, "axon"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	check(filepath.Join(xdg, "axon"))
+
+	// ... unless ~/.axon exists too.
+	if err := os.MkdirAll(filepath.Join(home, ".axon"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	check(filepath.Join(home, ".axon"))
+
+	// AXON_HOME beats both, with ~ expanded.
+	t.Setenv(HomeEnv, "~/volumes/axon")
+	check(filepath.Join(home, "volumes", "axon"))
+
+	t.Setenv(HomeEnv, "")
+	if p, err := ConfigPath(); err != nil || p != filepath.Join(home, ".axon", "axon.yaml") {
+		t.Errorf("ConfigPath = %s, %v", p, err)
+	}
+}
+
+func TestAxonDir_RelativeXDGIgnored(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(HomeEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", "relative")
+	t.Chdir(home)
+	if err := os.MkdirAll(filepath.Join(home, "relative", "axon"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := AxonDir(); got != filepath.Join(home, ".axon") {
+		t.Errorf("AxonDir = %s, want ~/.axon for a relative XDG_CONFIG_HOME", got)
+	}
+}
+
+func TestPortableDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(HomeEnv, filepath.Join(home, "elsewhere"))
+	dir := filepath.Join(t.TempDir(), "stick")
+	t.Setenv(PortableEnv, dir)
+
+	if got, _ := AxonDir(); got != dir {
+		t.Errorf("AxonDir = %s, want the portable dir %s", got, dir)
+	}
+	if got, _ := ExpandPath("~/.claude/skills"); got != filepath.Join(dir, "home", ".claude", "skills") {
+		t.Errorf("ExpandPath(~) = %s, want it under %s/home", got, dir)
+	}
+	cfg, err := DefaultConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.RepoPath != filepath.Join(dir, "repo") {
+		t.Errorf("RepoPath = %s, want %s/repo", cfg.RepoPath, dir)
+	}
+	for _, tgt := range cfg.Targets {
+		if d, _ := ExpandPath(tgt.Destination); !within(dir, d) {
+			t.Errorf("target %s links %s outside the portable dir", tgt.Name, d)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// MachineEnv labels the current machine for overrides; it defaults to the
+// hostname.
+const MachineEnv = "AXON_MACHINE"
+
+// MachineOverride adjusts the shared axon.yaml on one machine.
+//
+//	overrides:
+//	  work-laptop:                 # AXON_MACHINE label or hostname
+//	    targets:
+//	      windsurf-skills:
+//	        destination: ~/Library/Application Support/windsurf/skills
+//	      qoder-commands:
+//	        disabled: true
+//	    excludes_add: ["*.local.md"]
+//	    excludes_remove: ["*.draft.md"]
+type MachineOverride struct {
+	Targets        map[string]TargetOverride `yaml:"targets,omitempty"`
+	ExcludesAdd    []string                  `yaml:"excludes_add,omitempty"`
+	ExcludesRemove []string                  `yaml:"excludes_remove,omitempty"`
+}
+
+// TargetOverride replaces individual fields of a target on one machine.
+// Empty fields keep the shared value.
+type TargetOverride struct {
+	Destination string `yaml:"destination,omitempty"`
+	Disabled    bool   `yaml:"disabled,omitempty"`
+	Mode        string `yaml:"mode,omitempty"`
+	LinkStyle   string `yaml:"link_style,omitempty"`
+}
+
+// machineBase is the config as written, before machine overrides.
+type machineBase struct {
+	targets  []Target
+	excludes []string
+}
+
+// MachineLabel returns $AXON_MACHINE, or the hostname when it is unset.
+func MachineLabel() string {
+	if v := strings.TrimSpace(os.Getenv(MachineEnv)); v != "" {
+		return v
+	}
+	host, _ := os.Hostname()
+	return host
+}
+
+// matchOverride finds the override for label: an exact (case-insensitive)
+// key first, then the short hostname ("laptop" for "laptop.local").
+func (c *Config) matchOverride(label string) (string, MachineOverride, bool) {
+	if label == "" {
+		return "", MachineOverride{}, false
+	}
+	short := label
+	if i := strings.IndexByte(label, '.'); i > 0 {
+		short = label[:i]
+	}
+	keys := make([]string, 0, len(c.Overrides))
+	for k := range c.Overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, want := range []string{label, short} {
+		for _, k := range keys {
+			if strings.EqualFold(k, want) {
+				return k, c.Overrides[k], true
+			}
+		}
+	}
+	return "", MachineOverride{}, false
+}
+
+// ApplyMachineOverrides applies the override matching label, if any, and
+// records the matched key in c.Machine. Overrides naming unknown targets are
+// an error so typos do not silently do nothing. Save writes the config as it
+// was before overrides.
+func (c *Config) ApplyMachineOverrides(label string) error {
+	key, o, ok := c.matchOverride(label)
+	if !ok {
+		return nil
+	}
+	for name := range o.Targets {
+		if !c.hasTarget(name) {
+			return fmt.Errorf("overrides.%s: unknown target %q", key, name)
+		}
+	}
+
+	c.base = &machineBase{
+		targets:  append([]Target(nil), c.Targets...),
+		excludes: append([]string(nil), c.Excludes...),
+	}
+
+	targets := c.Targets[:0:0]
+	for _, t := range c.Targets {
+		ov, ok := o.Targets[t.Name]
+		if !ok {
+			targets = append(targets, t)
+			continue
+		}
+		if ov.Disabled {
+			continue
+		}
+		if ov.Destination != "" {
+			t.Destination = ov.Destination
+		}
+		if ov.Mode != "" {
+			t.Mode = ov.Mode
+		}
+		if ov.LinkStyle != "" {
+			t.LinkStyle = ov.LinkStyle
+		}
+		targets = append(targets, t)
+	}
+	c.Targets = targets
+
+	removed := make(map[string]bool, len(o.ExcludesRemove))
+	for _, p := range o.ExcludesRemove {
+		removed[p] = true
+	}
+	excludes := c.Excludes[:0:0]
+	for _, p := range c.Excludes {
+		if !removed[p] {
+			excludes = append(excludes, p)
+		}
+	}
+	c.Excludes = append(excludes, o.ExcludesAdd...)
+
+	c.Machine = key
+	return nil
+}
+
+// DisabledTargets returns the targets switched off for this machine.
+func (c *Config) DisabledTargets() []string {
+	if c.Machine == "" {
+		return nil
+	}
+	var out []string
+	for name, ov := range c.Overrides[c.Machine].Targets {
+		if ov.Disabled {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (c *Config) hasTarget(name string) bool {
+	for _, t := range c.Targets {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutMachineOverrides returns a copy of c with the shared targets and
+// excludes restored, i.e. what belongs in the synced axon.yaml.
+func (c *Config) withoutMachineOverrides() *Config {
+	out := *c
+	if c.base != nil {
+		out.Targets = c.base.targets
+		out.Excludes = c.base.excludes
+	}
+	return &out
+}
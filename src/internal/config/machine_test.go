@@ -0,0 +1,78 @@
+package config
+
+import "testing"
+
+func machineTestConfig() *Config {
+	return &Config{
+		Excludes: []string{".DS_Store", "*.draft.md"},
+		Targets: []Target{
+			{Name: "claude-skills", Source: "skills", Destination: "~/.claude/skills"},
+			{Name: "windsurf-skills", Source: "skills", Destination: "~/.codeium/windsurf/skills"},
+			{Name: "qoder-commands", Source: "commands", Destination: "~/.qoder/commands"},
+		},
+		Overrides: map[string]MachineOverride{
+			"laptop": {
+				Targets: map[string]TargetOverride{
+					"windsurf-skills": {Destination: "/Volumes/work/windsurf/skills", LinkStyle: LinkStyleRelative},
+					"qoder-commands":  {Disabled: true},
+				},
+				ExcludesAdd:    []string{"*.local.md"},
+				ExcludesRemove: []string{"*.draft.md"},
+			},
+		},
+	}
+}
+
+func TestApplyMachineOverrides(t *testing.T) {
+	cfg := machineTestConfig()
+	if err := cfg.ApplyMachineOverrides("Laptop.local"); err != nil {
+		t.Fatalf("ApplyMachineOverrides: %v", err)
+	}
+	if cfg.Machine != "laptop" {
+		t.Errorf("Machine = %q, want laptop", cfg.Machine)
+	}
+	if len(cfg.Targets) != 2 {
+		t.Fatalf("targets = %+v, want qoder-commands disabled", cfg.Targets)
+	}
+	ws := cfg.Targets[1]
+	if ws.Destination != "/Volumes/work/windsurf/skills" || ws.LinkStyle != LinkStyleRelative || ws.Source != "skills" {
+		t.Errorf("windsurf override not applied: %+v", ws)
+	}
+	if got := cfg.DisabledTargets(); len(got) != 1 || got[0] != "qoder-commands" {
+		t.Errorf("DisabledTargets = %v", got)
+	}
+	if len(cfg.Excludes) != 2 || cfg.Excludes[0] != ".DS_Store" || cfg.Excludes[1] != "*.local.md" {
+		t.Errorf("Excludes = %v", cfg.Excludes)
+	}
+
+	// The synced file keeps the shared values.
+	saved := cfg.withoutMachineOverrides()
+	if len(saved.Targets) != 3 || saved.Targets[1].Destination != "~/.codeium/windsurf/skills" || len(saved.Excludes) != 2 || saved.Excludes[1] != "*.draft.md" {
+		t.Errorf("withoutMachineOverrides = %+v", saved)
+	}
+}
+
+func TestApplyMachineOverrides_NoMatch(t *testing.T) {
+	cfg := machineTestConfig()
+	if err := cfg.ApplyMachineOverrides("desktop"); err != nil {
+		t.Fatalf("ApplyMachineOverrides: %v", err)
+	}
+	if cfg.Machine != "" || len(cfg.Targets) != 3 {
+		t.Errorf("unexpected changes without a match: %+v", cfg)
+	}
+}
+
+func TestApplyMachineOverrides_UnknownTarget(t *testing.T) {
+	cfg := machineTestConfig()
+	cfg.Overrides["laptop"].Targets["typo-skills"] = TargetOverride{Disabled: true}
+	if err := cfg.ApplyMachineOverrides("laptop"); err == nil {
+		t.Error("expected error for unknown target")
+	}
+}
+
+func TestMachineLabel_Env(t *testing.T) {
+	t.Setenv(MachineEnv, "ci-runner")
+	if got := MachineLabel(); got != "ci-runner" {
+		t.Errorf("MachineLabel = %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Overlap is a pair of targets whose destinations collide: one path linked
+// to different content, or one destination inside the other. Linking both
+// makes each 'axon link' undo the other.
+type Overlap struct {
+	A, B         Target
+	DestA, DestB string // expanded destinations
+	// Nested is set when DestB is inside DestA; otherwise they are equal.
+	Nested bool
+}
+
+func (o Overlap) String() string {
+	if o.Nested {
+		return fmt.Sprintf("target %q links %s inside %s of target %q", o.B.Name, o.DestB, o.DestA, o.A.Name)
+	}
+	return fmt.Sprintf("targets %q and %q link different content to %s", o.A.Name, o.B.Name, o.DestA)
+}
+
+// Involves reports whether the target named name is one of the pair.
+func (o Overlap) Involves(name string) bool {
+	return o.A.Name == name || o.B.Name == name
+}
+
+// Overlaps returns every pair of targets whose destinations overlap, in
+// config order. Targets of different tools may share a destination when
+// they link the same source the same way, e.g. a directory several tools
+// read; destinations that cannot be expanded are skipped.
+func (c *Config) Overlaps() []Overlap {
+	dests := make([]string, len(c.Targets))
+	for i, t := range c.Targets {
+		if d, err := ExpandPath(t.Destination); err == nil && t.Destination != "" {
+			dests[i] = filepath.Clean(d)
+		}
+	}
+	var out []Overlap
+	for i := range c.Targets {
+		for j := i + 1; j < len(c.Targets); j++ {
+			if o, ok := overlap(c.Targets[i], c.Targets[j], dests[i], dests[j]); ok {
+				out = append(out, o)
+			}
+		}
+	}
+	return out
+}
+
+// overlap compares two targets with the cleaned, expanded destinations da
+// and db ("" when unknown). A nested pair is ordered outer first.
+func overlap(a, b Target, da, db string) (Overlap, bool) {
+	if da == "" || db == "" {
+		return Overlap{}, false
+	}
+	if da == db {
+		if a.Source == b.Source && a.Type == b.Type && a.Format == b.Format {
+			return Overlap{}, false
+		}
+		return Overlap{A: a, B: b, DestA: da, DestB: db}, true
+	}
+	if within(da, db) {
+		return Overlap{A: a, B: b, DestA: da, DestB: db, Nested: true}, true
+	}
+	if within(db, da) {
+		return Overlap{A: b, B: a, DestA: db, DestB: da, Nested: true}, true
+	}
+	return Overlap{}, false
+}
+
+// within reports whether path is strictly inside dir.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && filepath.IsLocal(rel)
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfig_Overlaps(t *testing.T) {
+	cfg := &Config{Targets: []Target{
+		{Name: "claude-skills", Source: "skills", Destination: "/tmp/home/.claude/skills"},
+		{Name: "claude-home", Source: "claude", Destination: "/tmp/home/.claude"},
+		{Name: "codex-skills", Source: "skills", Destination: "/tmp/shared/skills"},
+		{Name: "gemini-skills", Source: "skills", Destination: "/tmp/shared/skills"},
+		{Name: "gemini-workflows", Source: "workflows", Destination: "/tmp/shared/skills/"},
+		{Name: "cursor-skills", Source: "skills", Destination: "/tmp/home/.cursor-skills"},
+	}}
+	var got []string
+	for _, o := range cfg.Overlaps() {
+		got = append(got, o.String())
+	}
+	want := []string{
+		`target "claude-skills" links ` + filepath.Clean("/tmp/home/.claude/skills") + ` inside ` + filepath.Clean("/tmp/home/.claude") + ` of target "claude-home"`,
+		`targets "codex-skills" and "gemini-workflows" link different content to ` + filepath.Clean("/tmp/shared/skills"),
+		`targets "gemini-skills" and "gemini-workflows" link different content to ` + filepath.Clean("/tmp/shared/skills"),
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Overlaps =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateFile_ReportsOverlaps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "axon.yaml")
+	raw := `repo_path: /tmp/repo
+targets:
+  - name: claude-home
+    source: ""
+    destination: /tmp/claude
+  - name: claude-code-skills
+    source: skills
+    destination: /tmp/claude/skills
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := ValidateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0].Line != 6 || !strings.Contains(problems[0].Message, "inside") {
+		t.Errorf("ValidateFile = %+v, want the nested destination on line 6", problems)
+	}
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the project-scoped config discovered by walking up from
+// the current directory, similar to how git finds .git.
+const ProjectFileName = ".axon.yaml"
+
+// ProjectRepoName names the repo holding a project's own items.
+const ProjectRepoName = "project"
+
+// defaultProjectRepoPath is where project-only items live, relative to the
+// project root, when .axon.yaml does not set repo_path.
+const defaultProjectRepoPath = ".axon"
+
+// ProjectConfig is the content of a project's .axon.yaml.
+//
+//	repo_path: .axon           # project-only items, relative to the root
+//	targets:
+//	  - name: project-claude-skills
+//	    source: skills
+//	    destination: .claude/skills
+type ProjectConfig struct {
+	RepoPath string   `yaml:"repo_path,omitempty"`
+	Targets  []Target `yaml:"targets,omitempty"`
+}
+
+// FindProjectConfig walks up from start and returns the path of the nearest
+// .axon.yaml, or "" when there is none.
+func FindProjectConfig(start string) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+	for {
+		p := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadProject reads and parses a project .axon.yaml.
+func LoadProject(path string) (*ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read project config %s: %w", path, err)
+	}
+	var p ProjectConfig
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid YAML in %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// ApplyProject layers a project config found at root on top of c:
+//
+//   - the project's repo_path becomes the "project" repo, ranked above every
+//     other repo so project-only items shadow global ones;
+//   - project targets are added with destinations resolved against root and,
+//     unless they list repos themselves, draw from the project repo followed
+//     by the global repos.
+//
+// Global targets keep drawing from the global repos only. Nothing added here
+// is written back by Save.
+func (c *Config) ApplyProject(p *ProjectConfig, root string) error {
+	repoPath := p.RepoPath
+	if repoPath == "" {
+		repoPath = defaultProjectRepoPath
+	}
+	repoPath, err := projectPath(root, repoPath, false)
+	if err != nil {
+		return fmt.Errorf("project repo_path: %w", err)
+	}
+
+	global := c.HubRepos()
+	priority := 1
+	if len(global) > 0 && global[0].Priority >= priority {
+		priority = global[0].Priority + 1
+	}
+	c.Repos = append(c.Repos, Repo{Name: ProjectRepoName, Path: repoPath, Priority: priority, Project: root})
+
+	names := make(map[string]bool, len(c.Targets))
+	for _, t := range c.Targets {
+		names[t.Name] = true
+	}
+	for i, t := range p.Targets {
+		switch {
+		case t.Name == "":
+			return fmt.Errorf("project targets[%d]: 'name' is required", i)
+		case names[t.Name]:
+			return fmt.Errorf("project target %q duplicates another target name", t.Name)
+		case t.Source == "" || t.Destination == "":
+			return fmt.Errorf("project target %q: 'source' and 'destination' are required", t.Name)
+		case !ValidSource(t.Source):
+			return fmt.Errorf("project target %q: source must be a relative path inside the Hub, got %q", t.Name, t.Source)
+		case t.LinkStyle != "" && t.LinkStyle != LinkStyleAbsolute && t.LinkStyle != LinkStyleRelative:
+			return fmt.Errorf("project target %q: link_style must be %s or %s", t.Name, LinkStyleAbsolute, LinkStyleRelative)
+		case t.Mode != "" && t.Mode != LinkModeAuto && t.Mode != LinkModeSymlink && t.Mode != LinkModeCopy:
+			return fmt.Errorf("project target %q: mode must be %s, %s, or %s", t.Name, LinkModeAuto, LinkModeSymlink, LinkModeCopy)
+		}
+		if err := t.validateType(); err != nil {
+			return fmt.Errorf("project %w", err)
+		}
+		names[t.Name] = true
+
+		// The destination itself becomes a link axon owns, so only the
+		// directories above it must stay inside the project.
+		dest, err := projectPath(root, t.Destination, true)
+		if err != nil {
+			return fmt.Errorf("project target %q: destination %w", t.Name, err)
+		}
+		t.Destination = dest
+		if t.Type == "" {
+			t.Type = "directory"
+		}
+		if len(t.Repos) == 0 {
+			t.Repos = []string{ProjectRepoName}
+			for _, r := range global {
+				t.Repos = append(t.Repos, r.Name)
+			}
+		}
+		t.Project = root
+		c.Targets = append(c.Targets, t)
+		if _, err := c.TargetRepos(t); err != nil {
+			return err
+		}
+	}
+	c.ProjectRoot = root
+	return nil
+}
+
+// projectPath resolves p, a path from .axon.yaml, against the project root
+// and requires it to stay there. A cloned repository must not be able to
+// point axon at ~/.ssh or any other directory outside it, so ~, absolute
+// paths, .. escapes, and symlinks leading out are all rejected. With
+// parentOnly, a symlink at p itself is allowed.
+func projectPath(root, p string, parentOnly bool) (string, error) {
+	if strings.HasPrefix(p, "~") || !filepath.IsLocal(filepath.FromSlash(p)) {
+		return "", fmt.Errorf("must be a relative path inside the project, got %q", p)
+	}
+	clean := filepath.Clean(filepath.FromSlash(p))
+	full := filepath.Join(root, clean)
+	check := full
+	if parentOnly {
+		check = filepath.Dir(full)
+	}
+	if r, c := resolveExisting(root), resolveExisting(check); c != r && !within(r, c) {
+		return "", fmt.Errorf("%q leads outside the project through a symlink", p)
+	}
+	return full, nil
+}
+
+// resolveExisting resolves the symlinks of the deepest existing ancestor of
+// p and appends the rest of p unchanged.
+func resolveExisting(p string) string {
+	var rest []string
+	for {
+		if resolved, err := filepath.EvalSymlinks(p); err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...)
+		}
+		parent := filepath.Dir(p)
+		if parent == p {
+			return filepath.Join(append([]string{p}, rest...)...)
+		}
+		rest = append([]string{filepath.Base(p)}, rest...)
+		p = parent
+	}
+}
+
+// ProjectKey returns a short, stable directory name for a project root, used
+// to keep per-project state (e.g. merged views) apart under ~/.axon.
+func ProjectKey(root string) string {
+	sum := sha256.Sum256([]byte(root))
+	return filepath.Base(root) + "-" + hex.EncodeToString(sum[:4])
+}
+
+// withoutProject returns a copy of c without the targets and repos added by
+// ApplyProject, i.e. what belongs in ~/.axon/axon.yaml.
+func (c *Config) withoutProject() *Config {
+	out := *c
+	out.Targets = nil
+	for _, t := range c.Targets {
+		if t.Project == "" {
+			out.Targets = append(out.Targets, t)
+		}
+	}
+	out.Repos = nil
+	for _, r := range c.Repos {
+		if r.Project == "" {
+			out.Repos = append(out.Repos, r)
+		}
+	}
+	return &out
+}
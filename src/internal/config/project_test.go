@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestFindProjectConfig_WalksUp(t *testing.T) {
+	root := t.TempDir()
+	deep := filepath.Join(root, "a", "b", "c")
+	if err := os.MkdirAll(deep, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := FindProjectConfig(deep); err != nil || got != "" {
+		t.Fatalf("without .axon.yaml: got %q, %v", got, err)
+	}
+
+	want := filepath.Join(root, "a", ProjectFileName)
+	if err := os.WriteFile(want, []byte("targets: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := FindProjectConfig(deep)
+	if err != nil || got != want {
+		t.Errorf("FindProjectConfig = %q, %v; want %q", got, err, want)
+	}
+}
+
+func TestApplyProject(t *testing.T) {
+	cfg := &Config{
+		RepoPath: "/hub/personal",
+		Repos:    []Repo{{Name: "company", Path: "/hub/company", Priority: 5}},
+		Targets:  []Target{{Name: "claude-skills", Source: "skills", Destination: "/home/u/.claude/skills"}},
+	}
+	p := &ProjectConfig{Targets: []Target{{Name: "proj-skills", Source: "skills", Destination: ".claude/skills"}}}
+	if err := cfg.ApplyProject(p, "/work/app"); err != nil {
+		t.Fatalf("ApplyProject: %v", err)
+	}
+
+	if cfg.ProjectRoot != "/work/app" {
+		t.Errorf("ProjectRoot = %q", cfg.ProjectRoot)
+	}
+	repos := cfg.HubRepos()
+	if repos[0].Name != ProjectRepoName || repos[0].Path != filepath.Join("/work/app", ".axon") || repos[0].Priority != 6 {
+		t.Errorf("project repo should rank first: %+v", repos[0])
+	}
+
+	proj := cfg.Targets[1]
+	if proj.Destination != filepath.Join("/work/app", ".claude", "skills") || proj.Project != "/work/app" {
+		t.Errorf("unexpected project target: %+v", proj)
+	}
+	projRepos, _ := cfg.TargetRepos(proj)
+	if len(projRepos) != 3 || projRepos[0].Name != ProjectRepoName {
+		t.Errorf("project target repos = %+v", projRepos)
+	}
+	globalRepos, _ := cfg.TargetRepos(cfg.Targets[0])
+	for _, r := range globalRepos {
+		if r.Name == ProjectRepoName {
+			t.Errorf("global target must not draw from the project repo")
+		}
+	}
+
+	saved := cfg.withoutProject()
+	if len(saved.Targets) != 1 || len(saved.Repos) != 1 {
+		t.Errorf("withoutProject kept project entries: %+v", saved)
+	}
+}
+
+func TestApplyProject_DuplicateName(t *testing.T) {
+	cfg := &Config{RepoPath: "/hub", Targets: []Target{{Name: "dup", Source: "skills", Destination: "/x"}}}
+	p := &ProjectConfig{Targets: []Target{{Name: "dup", Source: "skills", Destination: "y"}}}
+	if err := cfg.ApplyProject(p, "/work/app"); err == nil {
+		t.Error("expected duplicate target name error")
+	}
+}
+
+func TestApplyProject_PathsStayInsideProject(t *testing.T) {
+	root := t.TempDir()
+	bad := []ProjectConfig{
+		{RepoPath: "/etc"},
+		{RepoPath: "../other"},
+		{Targets: []Target{{Name: "ssh", Source: "skills", Destination: "~/.ssh"}}},
+		{Targets: []Target{{Name: "abs", Source: "skills", Destination: filepath.Join(t.TempDir(), "x")}}},
+		{Targets: []Target{{Name: "up", Source: "skills", Destination: "a/../../x"}}},
+	}
+	if runtime.GOOS != "windows" {
+		if err := os.Symlink(t.TempDir(), filepath.Join(root, "out")); err != nil {
+			t.Fatal(err)
+		}
+		bad = append(bad,
+			ProjectConfig{RepoPath: "out/repo"},
+			ProjectConfig{Targets: []Target{{Name: "via-link", Source: "skills", Destination: "out/skills"}}},
+		)
+	}
+	for _, p := range bad {
+		cfg := &Config{RepoPath: "/hub"}
+		if err := cfg.ApplyProject(&p, root); err == nil {
+			t.Errorf("ApplyProject(%+v) should reject a path outside the project", p)
+		}
+	}
+
+	// A destination that is itself a symlink, e.g. one axon created, is fine.
+	if runtime.GOOS != "windows" {
+		cfg := &Config{RepoPath: "/hub"}
+		p := &ProjectConfig{Targets: []Target{{Name: "linked", Source: "skills", Destination: "out"}}}
+		if err := cfg.ApplyProject(p, root); err != nil {
+			t.Errorf("a linked destination inside the project: %v", err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultRegistryName and DefaultRegistryURL describe the community
+// registry used when axon.yaml configures none.
+const (
+	DefaultRegistryName = "community"
+	DefaultRegistryURL  = "https://raw.githubusercontent.com/kamusis/axon-hub/main/registry.json"
+)
+
+// Registry is a skill index: a JSON file, served over http(s) or read from
+// a local path, listing installable skills and where they live.
+type Registry struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+}
+
+// EffectiveRegistries returns the configured registries, or the community
+// registry when there are none.
+func (c *Config) EffectiveRegistries() []Registry {
+	if len(c.Registries) > 0 {
+		return c.Registries
+	}
+	return []Registry{{Name: DefaultRegistryName, URL: DefaultRegistryURL}}
+}
+
+// FindRegistry returns the registry called name.
+func (c *Config) FindRegistry(name string) (Registry, bool) {
+	for _, r := range c.EffectiveRegistries() {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Registry{}, false
+}
+
+// validateRegistries checks registry names and URLs. Names end up in vendor
+// names and '<registry>/<skill>' arguments, so they cannot contain '/'.
+func (c *Config) validateRegistries() error {
+	seen := make(map[string]bool)
+	for i, r := range c.Registries {
+		switch {
+		case r.Name == "":
+			return fmt.Errorf("registries[%d]: 'name' is required", i)
+		case strings.ContainsAny(r.Name, `/\`):
+			return fmt.Errorf("registry %q: name cannot contain '/'", r.Name)
+		case seen[r.Name]:
+			return fmt.Errorf("registries[%d]: duplicate name %q", i, r.Name)
+		case strings.TrimSpace(r.URL) == "":
+			return fmt.Errorf("registry %q: 'url' is required", r.Name)
+		}
+		seen[r.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import "fmt"
+
+// Secret scan modes for secrets.mode.
+const (
+	SecretsModeBlock = "block"
+	SecretsModeWarn  = "warn"
+	SecretsModeOff   = "off"
+)
+
+// SecretsConfig controls the credential scan 'axon sync' runs on new and
+// changed Hub files before committing them.
+type SecretsConfig struct {
+	// Mode is block (default: refuse to commit), warn, or off.
+	Mode string `yaml:"mode,omitempty"`
+
+	// Allow suppresses findings: "rule:<id>", a directory ending in "/",
+	// or a glob on the Hub-relative path.
+	Allow []string `yaml:"allow,omitempty"`
+}
+
+// SecretsMode returns the effective secrets.mode.
+func (c *Config) SecretsMode() string {
+	if c == nil || c.Secrets.Mode == "" {
+		return SecretsModeBlock
+	}
+	return c.Secrets.Mode
+}
+
+// validateSecrets rejects an unknown secrets.mode.
+func (c *Config) validateSecrets() error {
+	switch c.Secrets.Mode {
+	case "", SecretsModeBlock, SecretsModeWarn, SecretsModeOff:
+		return nil
+	}
+	return fmt.Errorf("secrets.mode: %q is not one of block, warn, off", c.Secrets.Mode)
+}
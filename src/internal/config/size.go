@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultMinFreeSpace is the free space sync, import, and update require on
+// the filesystems they write to when min_free_space is not set.
+const DefaultMinFreeSpace = 500 << 20
+
+// DefaultMaxFileSize is the largest file 'axon sync' commits to the Hub
+// when max_file_size is not set.
+const DefaultMaxFileSize = 10 << 20
+
+// DefaultContextLimit is the largest file a tool is expected to read into
+// its context when context_limits sets no limit for it.
+const DefaultContextLimit = 100 << 10
+
+// ParseByteSize parses sizes such as "512MB", "2GiB", "1.5G", or "1048576".
+// Units are binary (1KB = 1024 bytes); "0" disables a threshold.
+func ParseByteSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+	upper := strings.ToUpper(s)
+	i := strings.IndexFunc(upper, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	num, unit := upper, ""
+	if i >= 0 {
+		num, unit = strings.TrimSpace(upper[:i]), strings.TrimSpace(upper[i:])
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	var mult float64
+	switch strings.TrimSuffix(strings.TrimSuffix(unit, "B"), "I") {
+	case "":
+		mult = 1
+	case "K":
+		mult = 1 << 10
+	case "M":
+		mult = 1 << 20
+	case "G":
+		mult = 1 << 30
+	case "T":
+		mult = 1 << 40
+	default:
+		return 0, fmt.Errorf("invalid size %q (use a unit such as MB or GB)", s)
+	}
+	return int64(v * mult), nil
+}
+
+// MinFreeBytes returns the min_free_space threshold in bytes. A nil config
+// (e.g. 'axon update' before 'axon init') uses the default.
+func (c *Config) MinFreeBytes() int64 {
+	if c == nil || c.MinFreeSpace == "" {
+		return DefaultMinFreeSpace
+	}
+	n, err := ParseByteSize(c.MinFreeSpace)
+	if err != nil {
+		return DefaultMinFreeSpace
+	}
+	return n
+}
+
+// validateMinFreeSpace rejects a min_free_space that does not parse.
+func (c *Config) validateMinFreeSpace() error {
+	if c.MinFreeSpace == "" {
+		return nil
+	}
+	if _, err := ParseByteSize(c.MinFreeSpace); err != nil {
+		return fmt.Errorf("min_free_space: %w", err)
+	}
+	return nil
+}
+
+// MaxFileBytes returns the max_file_size limit in bytes; 0 means no limit.
+func (c *Config) MaxFileBytes() int64 {
+	if c == nil || c.MaxFileSize == "" {
+		return DefaultMaxFileSize
+	}
+	n, err := ParseByteSize(c.MaxFileSize)
+	if err != nil {
+		return DefaultMaxFileSize
+	}
+	return n
+}
+
+// validateMaxFileSize rejects a max_file_size that does not parse.
+func (c *Config) validateMaxFileSize() error {
+	if c.MaxFileSize == "" {
+		return nil
+	}
+	if _, err := ParseByteSize(c.MaxFileSize); err != nil {
+		return fmt.Errorf("max_file_size: %w", err)
+	}
+	return nil
+}
+
+// ContextLimitBytes returns the context limit of tool in bytes: its
+// context_limits entry, else the "default" entry, else DefaultContextLimit.
+// 0 means no limit.
+func (c *Config) ContextLimitBytes(tool string) int64 {
+	if c == nil {
+		return DefaultContextLimit
+	}
+	for _, key := range []string{strings.ToLower(tool), "default"} {
+		if s, ok := c.ContextLimits[key]; ok {
+			if n, err := ParseByteSize(s); err == nil {
+				return n
+			}
+		}
+	}
+	return DefaultContextLimit
+}
+
+// validateContextLimits rejects a context_limits entry that does not parse.
+func (c *Config) validateContextLimits() error {
+	for tool, s := range c.ContextLimits {
+		if _, err := ParseByteSize(s); err != nil {
+			return fmt.Errorf("context_limits.%s: %w", tool, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import "testing"
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]int64{
+		"0":       0,
+		"1048576": 1 << 20,
+		"512MB":   512 << 20,
+		"2GiB":    2 << 30,
+		"1.5g":    3 << 29,
+		"10 KB":   10 << 10,
+	}
+	for in, want := range cases {
+		got, err := ParseByteSize(in)
+		if err != nil || got != want {
+			t.Errorf("ParseByteSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "lots", "-1GB", "5XB"} {
+		if _, err := ParseByteSize(in); err == nil {
+			t.Errorf("ParseByteSize(%q) should fail", in)
+		}
+	}
+}
+
+func TestConfig_MinFreeBytes(t *testing.T) {
+	var nilCfg *Config
+	if got := nilCfg.MinFreeBytes(); got != DefaultMinFreeSpace {
+		t.Errorf("nil config = %d, want default", got)
+	}
+	cfg := &Config{MinFreeSpace: "1GB"}
+	if got := cfg.MinFreeBytes(); got != 1<<30 {
+		t.Errorf("MinFreeBytes = %d, want 1GiB", got)
+	}
+}
+
+func TestConfig_MaxFileBytes(t *testing.T) {
+	var nilCfg *Config
+	if got := nilCfg.MaxFileBytes(); got != DefaultMaxFileSize {
+		t.Errorf("nil config = %d, want default", got)
+	}
+	if got := (&Config{MaxFileSize: "0"}).MaxFileBytes(); got != 0 {
+		t.Errorf("MaxFileBytes(0) = %d, want no limit", got)
+	}
+	if got := (&Config{MaxFileSize: "50MB"}).MaxFileBytes(); got != 50<<20 {
+		t.Errorf("MaxFileBytes = %d, want 50MiB", got)
+	}
+}
+
+func TestConfig_ContextLimitBytes(t *testing.T) {
+	var nilCfg *Config
+	if got := nilCfg.ContextLimitBytes("claude-code"); got != DefaultContextLimit {
+		t.Errorf("nil config = %d, want default", got)
+	}
+	cfg := &Config{ContextLimits: map[string]string{"claude-code": "200KB", "default": "32KB", "cursor": "0"}}
+	for tool, want := range map[string]int64{"claude-code": 200 << 10, "Claude-Code": 200 << 10, "windsurf": 32 << 10, "cursor": 0} {
+		if got := cfg.ContextLimitBytes(tool); got != want {
+			t.Errorf("ContextLimitBytes(%s) = %d, want %d", tool, got, want)
+		}
+	}
+	if err := (&Config{ContextLimits: map[string]string{"codex": "lots"}}).validateContextLimits(); err == nil {
+		t.Error("expected an error for an unparsable limit")
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTrashRetention is how long trashed items are kept when
+// trash_retention is not set.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// ParseRetention parses a duration such as "72h" or "30d"; "0" means
+// forever and returns 0.
+func ParseRetention(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if n, ok := strings.CutSuffix(s, "d"); ok {
+		if days, err := strconv.Atoi(n); err == nil && days >= 0 {
+			return time.Duration(days) * 24 * time.Hour, nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid duration %q (use e.g. 72h or 30d)", s)
+}
+
+// TrashRetentionPeriod returns how long trashed items are kept; 0 means
+// until the trash is emptied by hand. A nil config uses the default.
+func (c *Config) TrashRetentionPeriod() time.Duration {
+	if c == nil || c.TrashRetention == "" {
+		return DefaultTrashRetention
+	}
+	d, err := ParseRetention(c.TrashRetention)
+	if err != nil {
+		return DefaultTrashRetention
+	}
+	return d
+}
+
+// validateTrashRetention rejects a trash_retention that does not parse.
+func (c *Config) validateTrashRetention() error {
+	if c.TrashRetention == "" {
+		return nil
+	}
+	if _, err := ParseRetention(c.TrashRetention); err != nil {
+		return fmt.Errorf("trash_retention: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_TrashRetentionPeriod(t *testing.T) {
+	var nilCfg *Config
+	if got := nilCfg.TrashRetentionPeriod(); got != DefaultTrashRetention {
+		t.Errorf("nil config = %s, want default", got)
+	}
+	for in, want := range map[string]time.Duration{"7d": 7 * 24 * time.Hour, "72h": 72 * time.Hour, "0": 0} {
+		if got := (&Config{TrashRetention: in}).TrashRetentionPeriod(); got != want {
+			t.Errorf("TrashRetentionPeriod(%q) = %s, want %s", in, got, want)
+		}
+	}
+	if err := (&Config{TrashRetention: "soon"}).validateTrashRetention(); err == nil {
+		t.Error("invalid trash_retention accepted")
+	}
+}
@@ -0,0 +1,281 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is one schema problem found in axon.yaml.
+type Problem struct {
+	Line    int // 1-based line in the file; 0 when unknown
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// Warn receives the schema problems Load tolerates in the config at path,
+// such as unknown keys, which yaml.Unmarshal would silently ignore. It
+// discards them unless the caller installs a reporter.
+var Warn = func(path string, p Problem) {}
+
+var yamlLineRe = regexp.MustCompile(`line (\d+)`)
+
+// Validate checks the axon.yaml content data against the schema of Config:
+// unknown keys, required target fields, duplicate target names and
+// destinations, sync modes, and paths that cannot be expanded. Problems are
+// sorted by line. A YAML syntax error is the only problem returned.
+func Validate(data []byte) []Problem {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		p := Problem{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if m := yamlLineRe.FindStringSubmatch(err.Error()); m != nil {
+			p.Line, _ = strconv.Atoi(m[1])
+			p.Message = strings.TrimPrefix(p.Message, m[0]+": ")
+		}
+		return []Problem{p}
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return []Problem{{Line: root.Line, Message: "the config must be a mapping of keys such as repo_path and targets"}}
+	}
+
+	v := &validator{}
+	v.keys(root, reflect.TypeOf(Config{}), "")
+	v.syncMode(mappingValue(root, "sync_mode"), "sync_mode")
+	v.path(mappingValue(root, "repo_path"), "repo_path")
+	if repos := mappingValue(root, "repos"); repos != nil && repos.Kind == yaml.SequenceNode {
+		for i, r := range repos.Content {
+			v.syncMode(mappingValue(r, "sync_mode"), fmt.Sprintf("repos[%d].sync_mode", i))
+			v.path(mappingValue(r, "path"), fmt.Sprintf("repos[%d].path", i))
+		}
+	}
+	if targets := mappingValue(root, "targets"); targets != nil && targets.Kind == yaml.SequenceNode {
+		v.targets(targets)
+	}
+	sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].Line < v.problems[j].Line })
+	return v.problems
+}
+
+// ValidateFile validates the config at path: the schema problems of
+// Validate and overlapping target destinations, then the first error Load
+// would fail with, if it is not one of them already.
+func ValidateFile(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config %s: %w", path, err)
+	}
+	problems := Validate(data)
+	cfg, err := parseConfig(path, data)
+	if err == nil {
+		problems = append(problems, overlapProblems(cfg, data)...)
+		sort.SliceStable(problems, func(i, j int) bool { return problems[i].Line < problems[j].Line })
+	} else {
+		msg := err.Error()
+		for _, p := range problems {
+			if strings.Contains(msg, p.Message) {
+				return problems, nil
+			}
+		}
+		problems = append(problems, Problem{Message: msg})
+	}
+	return problems, nil
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) add(n *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Line: n.Line, Message: fmt.Sprintf(format, args...)})
+}
+
+// keys reports the keys of n that no field of t (or of the types t holds)
+// takes. where is the key path of n, "" for the top level.
+func (v *validator) keys(n *yaml.Node, t reflect.Type, where string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if n.Kind == yaml.AliasNode {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode || t == reflect.TypeOf(yaml.Node{}) {
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, val := n.Content[i], n.Content[i+1]
+			if key.Value == "<<" {
+				continue
+			}
+			ft, ok := fields[key.Value]
+			if !ok {
+				if where == "" {
+					v.add(key, "unknown key %q", key.Value)
+				} else {
+					v.add(key, "unknown key %q in %s", key.Value, where)
+				}
+				continue
+			}
+			v.keys(val, ft, joinKey(where, key.Value))
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v.keys(n.Content[i+1], t.Elem(), joinKey(where, n.Content[i].Value))
+		}
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range n.Content {
+			v.keys(item, t.Elem(), fmt.Sprintf("%s[%d]", where, i))
+		}
+	}
+}
+
+func joinKey(where, key string) string {
+	if where == "" {
+		return key
+	}
+	return where + "." + key
+}
+
+// yamlFields maps the YAML keys of struct t to their field types, including
+// the fields of inlined structs.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	out := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			for k, ft := range yamlFields(f.Type) {
+				out[k] = ft
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		out[name] = f.Type
+	}
+	return out
+}
+
+func (v *validator) syncMode(n *yaml.Node, where string) {
+	if n == nil || n.Value == "read-only" || n.Value == "read-write" {
+		return
+	}
+	v.add(n, "%s must be read-only or read-write, got %q", where, n.Value)
+}
+
+// path reports a path whose ~ or template cannot be expanded.
+func (v *validator) path(n *yaml.Node, where string) {
+	if n == nil || n.Kind != yaml.ScalarNode {
+		return
+	}
+	if _, err := ExpandPath(n.Value); err != nil {
+		v.add(n, "%s: %v", where, err)
+	}
+}
+
+// targets checks the required fields of every target and that names and
+// destinations are not used twice.
+func (v *validator) targets(seq *yaml.Node) {
+	names := make(map[string]int)
+	dests := make(map[string]string)
+	for i, n := range seq.Content {
+		if n.Kind != yaml.MappingNode {
+			v.add(n, "targets[%d] must be a mapping", i)
+			continue
+		}
+		var t Target
+		_ = n.Decode(&t)
+		where := fmt.Sprintf("targets[%d]", i)
+		if t.Name == "" {
+			v.add(n, "%s: 'name' is required", where)
+		} else {
+			where = fmt.Sprintf("target %q", t.Name)
+			if line, ok := names[t.Name]; ok {
+				v.add(n, "duplicate target name %q (first on line %d)", t.Name, line)
+			} else {
+				names[t.Name] = n.Line
+			}
+		}
+		if mappingValue(n, "source") == nil {
+			v.add(n, "%s: 'source' is required (use source: \"\" for the whole Hub)", where)
+		}
+		dn := mappingValue(n, "destination")
+		if dn == nil || strings.TrimSpace(t.Destination) == "" {
+			v.add(n, "%s: 'destination' is required", where)
+			continue
+		}
+		dest, err := ExpandPath(t.Destination)
+		if err != nil {
+			v.add(dn, "%s: destination: %v", where, err)
+			continue
+		}
+		// The same key as Load's check, so ValidateFile reports it once.
+		key := t.Tool() + "\x00" + filepath.Clean(dest)
+		if other, ok := dests[key]; ok {
+			v.add(dn, "targets %q and %q both link %s", other, t.Name, dest)
+		} else {
+			dests[key] = t.Name
+		}
+	}
+}
+
+// overlapProblems reports the overlapping destinations of cfg, parsed from
+// data, at the line of the later target of each pair. It works on the
+// targets after machine overrides, so an override that disables one of a
+// pair settles it.
+func overlapProblems(cfg *Config, data []byte) []Problem {
+	overlaps := cfg.Overlaps()
+	if len(overlaps) == 0 {
+		return nil
+	}
+	lines := make(map[string]int)
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) == nil && len(doc.Content) > 0 {
+		if seq := mappingValue(doc.Content[0], "targets"); seq != nil {
+			for _, n := range seq.Content {
+				if name := mappingValue(n, "name"); name != nil {
+					if _, ok := lines[name.Value]; !ok {
+						lines[name.Value] = n.Line
+					}
+				}
+			}
+		}
+	}
+	var out []Problem
+	for _, o := range overlaps {
+		line := max(lines[o.A.Name], lines[o.B.Name])
+		out = append(out, Problem{Line: line, Message: o.String()})
+	}
+	return out
+}
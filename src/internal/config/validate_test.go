@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidate_ReportsProblemsWithLines(t *testing.T) {
+	raw := `repo_path: /tmp/repo
+sync_mode: readwrite
+colour: always
+targets:
+  - name: cursor-skills
+    source: skills
+    destnation: ~/.cursor/skills
+  - name: cursor-skills
+    source: skills
+    destination: ~/.cursor/other
+  - name: claude-skills
+    destination: /tmp/claude
+  - name: claude-extra
+    source: extra
+    destination: /tmp/claude
+secrets:
+  mode: warn
+  alow: [skills/demo/]
+`
+	var got []string
+	for _, p := range Validate([]byte(raw)) {
+		got = append(got, p.String())
+	}
+	want := []string{
+		`line 2: sync_mode must be read-only or read-write, got "readwrite"`,
+		`line 3: unknown key "colour"`,
+		`line 5: target "cursor-skills": 'destination' is required`,
+		`line 7: unknown key "destnation" in targets[0]`,
+		`line 8: duplicate target name "cursor-skills" (first on line 5)`,
+		`line 11: target "claude-skills": 'source' is required (use source: "" for the whole Hub)`,
+		`line 15: targets "claude-skills" and "claude-extra" both link /tmp/claude`,
+		`line 18: unknown key "alow" in secrets`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidate_SyntaxError(t *testing.T) {
+	problems := Validate([]byte("repo_path: /tmp/repo\ntargets:\n  - name: [x\n"))
+	if len(problems) != 1 || problems[0].Line == 0 {
+		t.Fatalf("Validate = %+v, want one problem with a line", problems)
+	}
+}
+
+func TestValidate_DefaultConfigIsClean(t *testing.T) {
+	cfg, err := DefaultConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if problems := Validate(data); len(problems) != 0 {
+		t.Errorf("Validate(DefaultConfig) = %v, want none", problems)
+	}
+}
+
+func TestValidateFile_AddsLoadError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "axon.yaml")
+	raw := "repo_path: /tmp/repo\nlink_style: sideways\ntargets:\n  - name: a-skills\n    source: skills\n    destination: /tmp/a\n"
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := ValidateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0].Line != 0 || !strings.Contains(problems[0].Message, "link_style") {
+		t.Errorf("ValidateFile = %+v, want the link_style error of Load", problems)
+	}
+}
+
+func TestLoad_WarnsAboutUnknownKeys(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Chdir(home)
+	if err := os.MkdirAll(filepath.Join(home, ".axon"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	raw := "repo_path: ~/.axon/repo\nupdate_chanel: beta\n"
+	if err := os.WriteFile(filepath.Join(home, ".axon", "axon.yaml"), []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var warned []Problem
+	old := Warn
+	Warn = func(_ string, p Problem) { warned = append(warned, p) }
+	t.Cleanup(func() { Warn = old })
+
+	if _, err := Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(warned) != 1 || warned[0].Line != 2 || !strings.Contains(warned[0].Message, "update_chanel") {
+		t.Errorf("warnings = %+v, want the unknown key on line 2", warned)
+	}
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Path templates understood by ExpandPath in destinations and repo paths.
+const (
+	TemplateHome        = "{home}"
+	TemplateWindowsHome = "{windows_home}"
+)
+
+// WindowsHomeEnv overrides the detected Windows profile directory, e.g. when
+// cmd.exe interop is disabled in WSL.
+const WindowsHomeEnv = "AXON_WINDOWS_HOME"
+
+// IsWSL reports whether axon runs inside Windows Subsystem for Linux.
+func IsWSL() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+	data, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	return err == nil && strings.Contains(strings.ToLower(string(data)), "microsoft")
+}
+
+// ErrNoWindowsHome is returned when {windows_home} is used on a machine that
+// has no Windows side. Commands treat such targets as not installed.
+var ErrNoWindowsHome = errors.New(TemplateWindowsHome + " is only available on Windows or WSL (set " + WindowsHomeEnv + " to override)")
+
+var windowsDrivePattern = regexp.MustCompile(`^/mnt/[a-zA-Z](/|$)`)
+
+// OnWindowsDrive reports whether p is a Windows drive mounted into WSL
+// (/mnt/c/...). Linux symlinks there are not followed by Windows programs.
+func OnWindowsDrive(p string) bool {
+	return windowsDrivePattern.MatchString(filepath.ToSlash(p))
+}
+
+var (
+	wslHomeOnce sync.Once
+	wslHome     string
+	wslHomeErr  error
+)
+
+// WindowsHome returns the Windows user profile directory as seen by axon:
+// the home directory on Windows, or its /mnt/<drive> path inside WSL
+// (looked up once through cmd.exe and wslpath). AXON_WINDOWS_HOME wins;
+// in portable mode it is the portable home, like ~.
+func WindowsHome() (string, error) {
+	if v := os.Getenv(WindowsHomeEnv); v != "" {
+		return v, nil
+	}
+	if runtime.GOOS == "windows" || PortableDir() != "" {
+		return UserHome()
+	}
+	if !IsWSL() {
+		return "", ErrNoWindowsHome
+	}
+	wslHomeOnce.Do(func() { wslHome, wslHomeErr = lookupWSLWindowsHome() })
+	return wslHome, wslHomeErr
+}
+
+// lookupWSLWindowsHome asks Windows for %USERPROFILE% and converts it.
+func lookupWSLWindowsHome() (string, error) {
+	cmd := exec.Command("cmd.exe", "/c", "echo %USERPROFILE%")
+	// Running from a Windows drive avoids cmd.exe's UNC working-dir warning.
+	if _, err := os.Stat("/mnt/c"); err == nil {
+		cmd.Dir = "/mnt/c"
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot query %%USERPROFILE%% via cmd.exe (set %s instead): %w", WindowsHomeEnv, err)
+	}
+	winPath := strings.TrimSpace(string(out))
+	if winPath == "" || strings.Contains(winPath, "%") {
+		return "", fmt.Errorf("cmd.exe returned no %%USERPROFILE%% (set %s instead)", WindowsHomeEnv)
+	}
+	out, err = exec.Command("wslpath", "-u", winPath).Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot convert %s with wslpath: %w", winPath, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// expandTemplates substitutes {home} and {windows_home} in p.
+func expandTemplates(p string) (string, error) {
+	if !strings.Contains(p, "{") {
+		return p, nil
+	}
+	if strings.Contains(p, TemplateHome) {
+		home, err := UserHome()
+		if err != nil {
+			return "", fmt.Errorf("cannot expand %s: %w", TemplateHome, err)
+		}
+		p = strings.ReplaceAll(p, TemplateHome, home)
+	}
+	if strings.Contains(p, TemplateWindowsHome) {
+		home, err := WindowsHome()
+		if err != nil {
+			return "", err
+		}
+		p = strings.ReplaceAll(p, TemplateWindowsHome, home)
+	}
+	return filepath.Clean(p), nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandPath_Templates(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	t.Setenv(WindowsHomeEnv, "/mnt/c/Users/me")
+
+	got, err := ExpandPath("{windows_home}/.codeium/windsurf/skills")
+	if err != nil {
+		t.Fatalf("ExpandPath: %v", err)
+	}
+	if want := filepath.Clean("/mnt/c/Users/me/.codeium/windsurf/skills"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	got, err = ExpandPath("{home}/.claude/skills")
+	if err != nil {
+		t.Fatalf("ExpandPath: %v", err)
+	}
+	if want := filepath.Join(home, ".claude", "skills"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestExpandPath_NoWindowsHome(t *testing.T) {
+	if IsWSL() {
+		t.Skip("running under WSL")
+	}
+	t.Setenv(WindowsHomeEnv, "")
+	if _, err := ExpandPath("{windows_home}/x"); err != nil && !errors.Is(err, ErrNoWindowsHome) {
+		t.Errorf("err = %v, want ErrNoWindowsHome", err)
+	}
+}
+
+func TestOnWindowsDrive(t *testing.T) {
+	for p, want := range map[string]bool{
+		"/mnt/c/Users/me":   true,
+		"/mnt/d":            true,
+		"/mnt/wsl/shared":   false,
+		"/home/me/.codeium": false,
+	} {
+		if got := OnWindowsDrive(p); got != want {
+			t.Errorf("OnWindowsDrive(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Package dedupe finds skills that are copies or near-copies of each other.
+// Skills with identical file trees are always grouped; others are grouped
+// when the text of their SKILL.md overlaps enough, or when their embeddings
+// from the semantic index are nearly the same.
+package dedupe
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// shingleSize is the number of consecutive words compared as one unit.
+const shingleSize = 3
+
+// Item is one skill considered for deduplication.
+type Item struct {
+	ID     string // how the skill is shown, e.g. skills/pdf
+	Dir    string
+	Digest string          // TreeDigest of Dir
+	Words  map[string]bool // Shingles of SKILL.md
+	Vector []float32       // index embedding, L2-normalized; nil without one
+}
+
+// Group is a set of skills that duplicate each other.
+type Group struct {
+	Items []Item
+	// Identical is set when every item has the same files and contents.
+	Identical bool
+	// Score is the lowest similarity among the links that formed the
+	// group: 1 for identical trees.
+	Score float64
+}
+
+// Options tune which skills count as duplicates.
+type Options struct {
+	// TextThreshold is the Jaccard similarity of SKILL.md shingles at or
+	// above which two skills are duplicates.
+	TextThreshold float64
+	// VectorThreshold is the cosine similarity of embeddings at or above
+	// which two skills are duplicates; 0 ignores embeddings.
+	VectorThreshold float64
+}
+
+// TreeDigest hashes the relative paths and contents of the files under
+// dir, so two skill directories with the same files get the same digest.
+// .git directories are skipped.
+func TreeDigest(dir string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+	h := sha256.New()
+	for _, p := range files {
+		rel, _ := filepath.Rel(dir, p)
+		io.WriteString(h, filepath.ToSlash(rel)+"\x00")
+		f, err := os.Open(p)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Shingles returns the set of shingleSize-word runs in text, lower-cased
+// and stripped of punctuation. Texts shorter than that yield their words.
+func Shingles(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]bool)
+	if len(words) < shingleSize {
+		for _, w := range words {
+			set[w] = true
+		}
+		return set
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleSize], " ")] = true
+	}
+	return set
+}
+
+// Jaccard returns |a ∩ b| / |a ∪ b|, or 0 when both are empty.
+func Jaccard(a, b map[string]bool) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	inter := 0
+	for s := range a {
+		if b[s] {
+			inter++
+		}
+	}
+	union := len(a) + len(b) - inter
+	if union == 0 {
+		return 0
+	}
+	return float64(inter) / float64(union)
+}
+
+// similarity returns how alike a and b are and whether that makes them
+// duplicates under opts.
+func similarity(a, b Item, opts Options) (float64, bool) {
+	if a.Digest != "" && a.Digest == b.Digest {
+		return 1, true
+	}
+	text := Jaccard(a.Words, b.Words)
+	if text >= opts.TextThreshold {
+		return text, true
+	}
+	if opts.VectorThreshold > 0 && len(a.Vector) > 0 && len(a.Vector) == len(b.Vector) {
+		var dot float64
+		for i := range a.Vector {
+			dot += float64(a.Vector[i]) * float64(b.Vector[i])
+		}
+		if dot >= opts.VectorThreshold {
+			return dot, true
+		}
+	}
+	return text, false
+}
+
+// Cluster groups items whose similarity links them, directly or through
+// other items. Groups are sorted by their first item's ID, items by ID.
+func Cluster(items []Item, opts Options) []Group {
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	parent := make([]int, len(items))
+	score := make([]float64, len(items)) // per root: lowest link score
+	for i := range parent {
+		parent[i] = i
+		score[i] = 1
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range items {
+		for j := i + 1; j < len(items); j++ {
+			s, dup := similarity(items[i], items[j], opts)
+			if !dup {
+				continue
+			}
+			ri, rj := find(i), find(j)
+			low := min(score[ri], score[rj], s)
+			if ri != rj {
+				parent[rj] = ri
+			}
+			score[ri] = low
+		}
+	}
+
+	byRoot := make(map[int]*Group)
+	var roots []int
+	for i, it := range items {
+		r := find(i)
+		g := byRoot[r]
+		if g == nil {
+			g = &Group{Identical: true, Score: score[r]}
+			byRoot[r] = g
+			roots = append(roots, r)
+		}
+		if len(g.Items) > 0 && g.Items[0].Digest != it.Digest {
+			g.Identical = false
+		}
+		g.Items = append(g.Items, it)
+	}
+	var out []Group
+	for _, r := range roots {
+		if g := byRoot[r]; len(g.Items) > 1 {
+			out = append(out, *g)
+		}
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package dedupe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestTreeDigest(t *testing.T) {
+	root := t.TempDir()
+	a, b, c := filepath.Join(root, "a"), filepath.Join(root, "b"), filepath.Join(root, "c")
+	writeTree(t, a, map[string]string{"SKILL.md": "x", "scripts/run.sh": "echo"})
+	writeTree(t, b, map[string]string{"SKILL.md": "x", "scripts/run.sh": "echo", ".git/HEAD": "ref"})
+	writeTree(t, c, map[string]string{"SKILL.md": "x", "scripts/other.sh": "echo"})
+
+	da, _ := TreeDigest(a)
+	db, _ := TreeDigest(b)
+	dc, _ := TreeDigest(c)
+	if da != db {
+		t.Error("same files (ignoring .git) should have the same digest")
+	}
+	if da == dc {
+		t.Error("a renamed file should change the digest")
+	}
+}
+
+func TestCluster(t *testing.T) {
+	text := "Use this skill to extract tables and text from PDF files with pdfplumber and write them as CSV."
+	items := []Item{
+		{ID: "skills/pdf", Digest: "1", Words: Shingles(text)},
+		{ID: "skills/pdf-copy", Digest: "1", Words: Shingles("something else entirely")},
+		{ID: "skills/pdf-tools", Digest: "2", Words: Shingles(text + " Also handles scanned pages.")},
+		{ID: "skills/sql", Digest: "3", Words: Shingles("Write and review SQL queries for Oracle databases.")},
+		{ID: "skills/sql-vec", Digest: "4", Words: Shingles("Tune slow SQL."), Vector: []float32{1, 0}},
+		{ID: "skills/sql-vec2", Digest: "5", Words: Shingles("Query plans, indexes."), Vector: []float32{0.99, 0.141}},
+	}
+	groups := Cluster(items, Options{TextThreshold: 0.7, VectorThreshold: 0.95})
+	if len(groups) != 2 {
+		t.Fatalf("groups = %+v, want 2", groups)
+	}
+	pdf := groups[0]
+	if len(pdf.Items) != 3 || pdf.Identical || pdf.Items[0].ID != "skills/pdf" {
+		t.Errorf("pdf group = %+v", pdf)
+	}
+	if pdf.Score >= 1 || pdf.Score < 0.7 {
+		t.Errorf("pdf group score = %v", pdf.Score)
+	}
+	if vec := groups[1]; len(vec.Items) != 2 || vec.Items[0].ID != "skills/sql-vec" {
+		t.Errorf("vector group = %+v", vec)
+	}
+
+	// Without embeddings only text and digests count.
+	if groups := Cluster(items, Options{TextThreshold: 0.7}); len(groups) != 1 {
+		t.Errorf("text-only groups = %+v, want 1", groups)
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	if got := Jaccard(Shingles("a b c d"), Shingles("a b c d")); got != 1 {
+		t.Errorf("same text = %v", got)
+	}
+	if got := Jaccard(Shingles("a b c d"), Shingles("w x y z")); got != 0 {
+		t.Errorf("different text = %v", got)
+	}
+	if got := Jaccard(nil, nil); got != 0 {
+		t.Errorf("empty = %v", got)
+	}
+}
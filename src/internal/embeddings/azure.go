@@ -0,0 +1,37 @@
+package embeddings
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AzureAPIVersion is the Azure OpenAI REST API version used when
+// AXON_EMBEDDINGS_API_VERSION is unset.
+const AzureAPIVersion = "2024-02-01"
+
+// NewAzureOpenAI constructs an embeddings provider for an Azure OpenAI
+// deployment.
+//
+// It uses the REST endpoint:
+//
+//	POST {baseURL}/openai/deployments/{deployment}/embeddings?api-version={version}
+//
+// with the key in the api-key header. baseURL is the resource endpoint,
+// e.g. https://my-resource.openai.azure.com.
+func NewAzureOpenAI(cfg *Config) Provider {
+	version := cfg.APIVersion
+	if version == "" {
+		version = AzureAPIVersion
+	}
+	return &openAIProvider{
+		model:      cfg.Model,
+		apiKey:     cfg.APIKey,
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		client:     &http.Client{Timeout: 30 * time.Second},
+		retry:      newRetryPolicy(cfg),
+		azure:      true,
+		deployment: cfg.Deployment,
+		apiVersion: version,
+	}
+}
@@ -0,0 +1,51 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAzureOpenAI_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openai/deployments/embed-small/embeddings" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("api-version"); got != AzureAPIVersion {
+			t.Errorf("api-version = %q", got)
+		}
+		if r.Header.Get("api-key") != "test-key" || r.Header.Get("Authorization") != "" {
+			t.Errorf("want api-key auth only, got headers %v", r.Header)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["input"] != "hello" {
+			t.Errorf("request body = %v, %v", body, err)
+		}
+		if _, ok := body["model"]; ok {
+			t.Error("model must not be sent when unset")
+		}
+		w.Write([]byte(`{"data": [{"embedding": [0.1, 0.2]}]}`))
+	}))
+	defer server.Close()
+
+	p := NewAzureOpenAI(&Config{Provider: "azure-openai", APIKey: "test-key", BaseURL: server.URL + "/", Deployment: "embed-small"})
+	if p.ModelID() != "azure-openai:embed-small" {
+		t.Errorf("ModelID = %q", p.ModelID())
+	}
+	v, err := p.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(v) != 2 {
+		t.Errorf("vector = %v", v)
+	}
+}
+
+func TestAzureOpenAI_RequiresDeployment(t *testing.T) {
+	p := NewAzureOpenAI(&Config{APIKey: "k", BaseURL: "https://example.openai.azure.com"})
+	if _, err := p.Embed(context.Background(), "hello"); err == nil {
+		t.Error("expected an error without a deployment")
+	}
+}
@@ -0,0 +1,105 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// CacheDir returns ~/.axon/cache/embeddings, the persistent embeddings
+// cache shared by every index build ('axon gc' expires old entries).
+func CacheDir() (string, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(axonDir, "cache", "embeddings"), nil
+}
+
+type cachedProvider struct {
+	Provider
+	dir string
+}
+
+// Cached wraps p with a persistent cache in dir keyed by (model ID, SHA-256
+// of the text), so unchanged texts are never embedded twice. Each entry is
+// one file of little-endian float32s under <dir>/<model>/<hh>/<hash>; a hit
+// refreshes the file's mtime so that 'axon gc' only expires unused entries.
+// Cache failures are ignored: the cache never makes embedding fail.
+func Cached(p Provider, dir string) Provider {
+	return &cachedProvider{Provider: p, dir: dir}
+}
+
+func (c *cachedProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	path := c.entryPath(text)
+	if v, ok := readCacheEntry(path); ok {
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+		return v, nil
+	}
+	v, err := c.Provider.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	writeCacheEntry(path, v)
+	return v, nil
+}
+
+func (c *cachedProvider) entryPath(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	h := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, cacheModelDir(c.ModelID()), h[:2], h)
+}
+
+// cacheModelDir turns a model ID such as "openai:text-embedding-3-small"
+// into a directory name.
+func cacheModelDir(modelID string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '_':
+			return r
+		}
+		return '_'
+	}, modelID)
+}
+
+func readCacheEntry(path string) ([]float32, bool) {
+	b, err := os.ReadFile(path)
+	if err != nil || len(b) == 0 || len(b)%4 != 0 {
+		return nil, false
+	}
+	v := make([]float32, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[i*4:]))
+	}
+	return v, true
+}
+
+// writeCacheEntry stores v via a temp file and rename, so concurrent
+// builds never read a partial entry.
+func writeCacheEntry(path string, v []float32) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	b := make([]byte, 0, 4*len(v))
+	for _, x := range v {
+		b = binary.LittleEndian.AppendUint32(b, math.Float32bits(x))
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, werr := f.Write(b)
+	cerr := f.Close()
+	if werr != nil || cerr != nil || os.Rename(f.Name(), path) != nil {
+		_ = os.Remove(f.Name())
+	}
+}
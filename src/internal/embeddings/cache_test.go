@@ -0,0 +1,95 @@
+package embeddings
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type countingProvider struct {
+	model string
+	calls int
+}
+
+func (p *countingProvider) ModelID() string { return p.model }
+func (p *countingProvider) Dim() int        { return 2 }
+func (p *countingProvider) Embed(_ context.Context, text string) ([]float32, error) {
+	p.calls++
+	return []float32{float32(len(text)), 0.5}, nil
+}
+
+func TestCached_ReusesEmbeddings(t *testing.T) {
+	dir := t.TempDir()
+	inner := &countingProvider{model: "openai:text-embedding-3-small"}
+	p := Cached(inner, dir)
+	ctx := context.Background()
+
+	first, err := p.Embed(ctx, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Cached(inner, dir).Embed(ctx, "hello") // a later run
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 1 {
+		t.Errorf("provider called %d times, want 1", inner.calls)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("cached = %v, want %v", second, first)
+	}
+	if p.ModelID() != inner.model || p.Dim() != 2 {
+		t.Errorf("wrapper should report the provider's model and dim")
+	}
+
+	if _, err := p.Embed(ctx, "hello!"); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("a different text should miss the cache")
+	}
+
+	other := &countingProvider{model: "gemini:text-embedding-004"}
+	if _, err := Cached(other, dir).Embed(ctx, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if other.calls != 1 {
+		t.Errorf("a different model should miss the cache")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "openai_text-embedding-3-small")); err != nil {
+		t.Errorf("entries should be grouped by model: %v", err)
+	}
+}
+
+func TestCached_HitRefreshesMtimeAndSkipsCorruptEntries(t *testing.T) {
+	dir := t.TempDir()
+	inner := &countingProvider{model: "m"}
+	p := Cached(inner, dir).(*cachedProvider)
+	ctx := context.Background()
+	if _, err := p.Embed(ctx, "text"); err != nil {
+		t.Fatal(err)
+	}
+
+	path := p.entryPath("text")
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Embed(ctx, "text"); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || !info.ModTime().After(old.Add(time.Hour)) {
+		t.Errorf("a hit should refresh the entry's mtime")
+	}
+
+	if err := os.WriteFile(path, []byte{1, 2, 3}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v, err := p.Embed(ctx, "text")
+	if err != nil || len(v) != 2 || inner.calls != 2 {
+		t.Errorf("a corrupt entry should be re-embedded: %v %v calls=%d", v, err, inner.calls)
+	}
+}
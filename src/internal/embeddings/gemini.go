@@ -0,0 +1,143 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GeminiBaseURL is the default Generative Language API endpoint.
+const GeminiBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+type geminiProvider struct {
+	model   string
+	apiKey  string
+	baseURL string
+	client  *http.Client
+	retry   retryPolicy
+	dim     int
+}
+
+// NewGemini constructs a Google Gemini embeddings provider.
+//
+// It uses the REST endpoint:
+//
+//	POST {baseURL}/models/{model}:embedContent
+//
+// with the API key in the x-goog-api-key header and JSON body:
+//
+//	{"content": {"parts": [{"text": "..."}]}}
+func NewGemini(cfg *Config) Provider {
+	return &geminiProvider{
+		model:   strings.TrimPrefix(cfg.Model, "models/"),
+		apiKey:  cfg.APIKey,
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		client:  &http.Client{Timeout: 30 * time.Second},
+		retry:   newRetryPolicy(cfg),
+	}
+}
+
+func (p *geminiProvider) ModelID() string {
+	return "gemini:" + p.model
+}
+
+func (p *geminiProvider) Dim() int {
+	return p.dim
+}
+
+func (p *geminiProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	if p.model == "" {
+		return nil, fmt.Errorf("embeddings model is not configured (set AXON_EMBEDDINGS_MODEL, e.g. text-embedding-004)")
+	}
+	if p.apiKey == "" {
+		return nil, fmt.Errorf("embeddings API key is not configured (set AXON_EMBEDDINGS_API_KEY)")
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("cannot embed empty text")
+	}
+
+	reqBody := map[string]any{
+		"content": map[string]any{
+			"parts": []map[string]string{{"text": text}},
+		},
+	}
+	b, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	url := p.baseURL + "/models/" + p.model + ":embedContent"
+	status, body, err := p.retry.do(ctx, p.client, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-goog-api-key", p.apiKey)
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status < 200 || status >= 300 {
+		return nil, p.apiError(status, body)
+	}
+
+	var parsed struct {
+		Embedding struct {
+			Values []float64 `json:"values"`
+		} `json:"embedding"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("cannot parse embeddings response: %w", err)
+	}
+	if len(parsed.Embedding.Values) == 0 {
+		return nil, fmt.Errorf("embeddings response missing embedding")
+	}
+
+	out := make([]float32, len(parsed.Embedding.Values))
+	for i, v := range parsed.Embedding.Values {
+		out[i] = float32(v)
+	}
+	p.dim = len(out)
+	return out, nil
+}
+
+// apiError turns a Gemini error response into an error that says what to
+// fix. Quota and authentication failures wrap ErrQuota and ErrAuth.
+func (p *geminiProvider) apiError(status int, body []byte) error {
+	var parsed struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+			Details []struct {
+				Reason string `json:"reason"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	msg := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &parsed) == nil && parsed.Error.Message != "" {
+		msg = parsed.Error.Message
+	}
+	keyInvalid := false
+	for _, d := range parsed.Error.Details {
+		if d.Reason == "API_KEY_INVALID" {
+			keyInvalid = true
+		}
+	}
+
+	switch {
+	case status == http.StatusTooManyRequests || parsed.Error.Status == "RESOURCE_EXHAUSTED":
+		return fmt.Errorf("gemini: %w: %s (check your quota in Google AI Studio or retry later)", ErrQuota, msg)
+	case status == http.StatusUnauthorized || status == http.StatusForbidden || keyInvalid ||
+		parsed.Error.Status == "UNAUTHENTICATED" || parsed.Error.Status == "PERMISSION_DENIED":
+		return fmt.Errorf("gemini: %w: %s (check AXON_EMBEDDINGS_API_KEY)", ErrAuth, msg)
+	case status == http.StatusNotFound:
+		return fmt.Errorf("gemini: model %q not found: %s (check AXON_EMBEDDINGS_MODEL)", p.model, msg)
+	}
+	return fmt.Errorf("embeddings request failed: HTTP %d: %s", status, msg)
+}
@@ -0,0 +1,88 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGemini_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/text-embedding-004:embedContent" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if r.Header.Get("x-goog-api-key") != "test-key" {
+			t.Errorf("missing x-goog-api-key header")
+		}
+		var body struct {
+			Content struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Content.Parts) != 1 || body.Content.Parts[0].Text != "hello" {
+			t.Errorf("request body = %+v, %v", body, err)
+		}
+		w.Write([]byte(`{"embedding": {"values": [0.5, -0.25, 1]}}`))
+	}))
+	defer server.Close()
+
+	p := NewGemini(&Config{Provider: "gemini", Model: "models/text-embedding-004", APIKey: "test-key", BaseURL: server.URL})
+	if p.ModelID() != "gemini:text-embedding-004" {
+		t.Errorf("ModelID = %q", p.ModelID())
+	}
+	v, err := p.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(v) != 3 || v[1] != -0.25 || p.Dim() != 3 {
+		t.Errorf("vector = %v, dim %d", v, p.Dim())
+	}
+}
+
+func TestGemini_ErrorSurfaces(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		is     error
+		want   string
+	}{
+		{429, `{"error": {"code": 429, "message": "Resource has been exhausted", "status": "RESOURCE_EXHAUSTED"}}`, ErrQuota, "quota"},
+		{400, `{"error": {"code": 400, "message": "API key not valid.", "status": "INVALID_ARGUMENT", "details": [{"reason": "API_KEY_INVALID"}]}}`, ErrAuth, "AXON_EMBEDDINGS_API_KEY"},
+		{403, `{"error": {"code": 403, "message": "denied", "status": "PERMISSION_DENIED"}}`, ErrAuth, "denied"},
+		{404, `{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`, nil, "AXON_EMBEDDINGS_MODEL"},
+	}
+	for _, c := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.status)
+			w.Write([]byte(c.body))
+		}))
+		p := NewGemini(&Config{Model: "text-embedding-004", APIKey: "k", BaseURL: server.URL})
+		_, err := p.Embed(context.Background(), "hello")
+		server.Close()
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("HTTP %d: err = %v, want mention of %q", c.status, err, c.want)
+		}
+		if c.is != nil && !errors.Is(err, c.is) {
+			t.Errorf("HTTP %d: err = %v, want %v", c.status, err, c.is)
+		}
+	}
+}
+
+func TestLoadConfig_GeminiDefaultBaseURL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AXON_EMBEDDINGS_PROVIDER", "gemini")
+	t.Setenv("AXON_EMBEDDINGS_BASE_URL", "")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BaseURL != GeminiBaseURL {
+		t.Errorf("BaseURL = %q, want %q", cfg.BaseURL, GeminiBaseURL)
+	}
+}
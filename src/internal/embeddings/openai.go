@@ -5,8 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -16,7 +16,14 @@ type openAIProvider struct {
 	apiKey  string
 	baseURL string
 	client  *http.Client
+	retry   retryPolicy
 	dim     int
+
+	// Azure OpenAI addresses a deployment rather than a model and
+	// authenticates with an api-key header.
+	azure      bool
+	deployment string
+	apiVersion string
 }
 
 // NewOpenAI constructs an OpenAI-compatible embeddings provider.
@@ -32,11 +39,15 @@ func NewOpenAI(cfg *Config) Provider {
 		apiKey:  cfg.APIKey,
 		baseURL: baseURL,
 		client:  &http.Client{Timeout: 30 * time.Second},
+		retry:   newRetryPolicy(cfg),
 		dim:     0,
 	}
 }
 
 func (p *openAIProvider) ModelID() string {
+	if p.azure {
+		return "azure-openai:" + p.deployment
+	}
 	return "openai:" + p.model
 }
 
@@ -45,7 +56,14 @@ func (p *openAIProvider) Dim() int {
 }
 
 func (p *openAIProvider) Embed(ctx context.Context, text string) ([]float32, error) {
-	if p.model == "" {
+	if p.azure {
+		if p.deployment == "" {
+			return nil, fmt.Errorf("azure deployment is not configured (set AXON_EMBEDDINGS_DEPLOYMENT)")
+		}
+		if p.baseURL == "" {
+			return nil, fmt.Errorf("azure endpoint is not configured (set AXON_EMBEDDINGS_BASE_URL to https://<resource>.openai.azure.com)")
+		}
+	} else if p.model == "" {
 		return nil, fmt.Errorf("embeddings model is not configured (set AXON_EMBEDDINGS_MODEL)")
 	}
 	if p.apiKey == "" {
@@ -56,30 +74,38 @@ func (p *openAIProvider) Embed(ctx context.Context, text string) ([]float32, err
 	}
 
 	reqBody := map[string]any{
-		"model": p.model,
 		"input": text,
 	}
+	if p.model != "" {
+		reqBody["model"] = p.model
+	}
 	b, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/embeddings", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
+	endpoint := p.baseURL + "/embeddings"
+	if p.azure {
+		endpoint = p.baseURL + "/openai/deployments/" + url.PathEscape(p.deployment) + "/embeddings?api-version=" + url.QueryEscape(p.apiVersion)
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+p.apiKey)
-
-	resp, err := p.client.Do(req)
+	status, body, err := p.retry.do(ctx, p.client, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if p.azure {
+			req.Header.Set("api-key", p.apiKey)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+p.apiKey)
+		}
+		return req, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("embeddings request failed: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	if status < 200 || status >= 300 {
+		return nil, openAIError(status, body)
 	}
 
 	var parsed struct {
@@ -102,3 +128,16 @@ func (p *openAIProvider) Embed(ctx context.Context, text string) ([]float32, err
 	p.dim = len(out)
 	return out, nil
 }
+
+// openAIError describes a failed request once retries are exhausted.
+// Authentication and rate-limit failures wrap ErrAuth and ErrQuota.
+func openAIError(status int, body []byte) error {
+	msg := strings.TrimSpace(string(body))
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("embeddings request failed: %w: HTTP %d: %s (check AXON_EMBEDDINGS_API_KEY)", ErrAuth, status, msg)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("embeddings request failed: %w: HTTP %d: %s", ErrQuota, status, msg)
+	}
+	return fmt.Errorf("embeddings request failed: HTTP %d: %s", status, msg)
+}
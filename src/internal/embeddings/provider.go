@@ -2,7 +2,10 @@ package embeddings
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/kamusis/axon-cli/internal/config"
 )
@@ -16,12 +19,25 @@ type Provider interface {
 	Embed(ctx context.Context, text string) ([]float32, error)
 }
 
+// Errors providers wrap so callers can tell failures apart.
+var (
+	ErrAuth  = errors.New("authentication failed")
+	ErrQuota = errors.New("quota exceeded")
+)
+
 // Config contains the resolved embeddings configuration.
 type Config struct {
 	Provider string
 	Model    string
 	APIKey   string
 	BaseURL  string
+
+	// Azure OpenAI only.
+	Deployment string
+	APIVersion string
+
+	// MaxRetries bounds the retries of rate-limited or failed requests.
+	MaxRetries int
 }
 
 // LoadConfig resolves embeddings config from environment variables first, then ~/.axon/.env.
@@ -43,14 +59,33 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 	if baseURL == "" {
-		baseURL = "https://api.openai.com/v1"
+		baseURL = defaultBaseURL(provider)
+	}
+	deployment, err := config.GetConfigValue("AXON_EMBEDDINGS_DEPLOYMENT")
+	if err != nil {
+		return nil, err
+	}
+	apiVersion, err := config.GetConfigValue("AXON_EMBEDDINGS_API_VERSION")
+	if err != nil {
+		return nil, err
+	}
+	maxRetries := DefaultMaxRetries
+	if v, err := config.GetConfigValue("AXON_EMBEDDINGS_MAX_RETRIES"); err != nil {
+		return nil, err
+	} else if v != "" {
+		if maxRetries, err = strconv.Atoi(strings.TrimSpace(v)); err != nil || maxRetries < 0 {
+			return nil, fmt.Errorf("AXON_EMBEDDINGS_MAX_RETRIES must be a non-negative integer, got %q", v)
+		}
 	}
 
 	return &Config{
-		Provider: provider,
-		Model:    model,
-		APIKey:   apiKey,
-		BaseURL:  baseURL,
+		Provider:   provider,
+		Model:      model,
+		APIKey:     apiKey,
+		BaseURL:    baseURL,
+		Deployment: deployment,
+		APIVersion: apiVersion,
+		MaxRetries: maxRetries,
 	}, nil
 }
 
@@ -65,7 +100,24 @@ func NewFromConfig(cfg *Config) (Provider, error) {
 	switch cfg.Provider {
 	case "openai":
 		return NewOpenAI(cfg), nil
+	case "gemini":
+		return NewGemini(cfg), nil
+	case "azure-openai":
+		return NewAzureOpenAI(cfg), nil
 	default:
 		return nil, fmt.Errorf("unsupported embeddings provider: %s", cfg.Provider)
 	}
 }
+
+// defaultBaseURL is the API endpoint used when AXON_EMBEDDINGS_BASE_URL is
+// unset.
+func defaultBaseURL(provider string) string {
+	switch provider {
+	case "gemini":
+		return GeminiBaseURL
+	case "azure-openai":
+		return "" // every resource has its own endpoint
+	default:
+		return "https://api.openai.com/v1"
+	}
+}
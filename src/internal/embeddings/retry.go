@@ -0,0 +1,104 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultMaxRetries is how often a request is retried when
+// AXON_EMBEDDINGS_MAX_RETRIES is unset.
+const DefaultMaxRetries = 3
+
+// retryPolicy retries rate-limited (429) and failed (5xx) requests and
+// network errors with jittered exponential backoff, honoring Retry-After.
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+func newRetryPolicy(cfg *Config) retryPolicy {
+	return retryPolicy{maxRetries: max(cfg.MaxRetries, 0), baseDelay: 500 * time.Millisecond, maxDelay: time.Minute}
+}
+
+// do sends the request build returns until it succeeds, fails for good, or
+// runs out of retries, and returns the last status and body. build is
+// called once per attempt since a request body cannot be replayed.
+func (p retryPolicy) do(ctx context.Context, client *http.Client, build func() (*http.Request, error)) (int, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := build()
+		if err != nil {
+			return 0, nil, err
+		}
+		var (
+			status     int
+			body       []byte
+			retryAfter time.Duration
+		)
+		resp, err := client.Do(req)
+		if err == nil {
+			body, _ = io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+			resp.Body.Close()
+			status = resp.StatusCode
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+
+		retry := retryableStatus(status) || (err != nil && ctx.Err() == nil)
+		if !retry || attempt >= p.maxRetries {
+			if err != nil && attempt > 0 {
+				err = fmt.Errorf("%w (after %d retries)", err, attempt)
+			}
+			return status, body, err
+		}
+
+		delay := p.backoff(attempt)
+		if retryAfter > 0 {
+			delay = min(retryAfter, p.maxDelay)
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			if err == nil {
+				err = errors.New("retry interrupted")
+			}
+			return status, body, fmt.Errorf("%w: %w", err, ctx.Err())
+		case <-t.C:
+		}
+	}
+}
+
+// backoff returns a delay in [d/2, d) for d = baseDelay·2^attempt, capped
+// at maxDelay; the jitter keeps parallel clients from retrying in lockstep.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	d := p.baseDelay << min(attempt, 16)
+	if d <= 0 || d > p.maxDelay {
+		d = p.maxDelay
+	}
+	return d/2 + time.Duration(rand.Int64N(int64(d/2)+1))
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusRequestTimeout || status >= 500
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an
+// HTTP date.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
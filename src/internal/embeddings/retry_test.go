@@ -0,0 +1,113 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetries shrinks p's backoff so tests do not sleep.
+func fastRetries(t *testing.T, p Provider) Provider {
+	t.Helper()
+	op, ok := p.(*openAIProvider)
+	if !ok {
+		t.Fatalf("unexpected provider %T", p)
+	}
+	op.retry.baseDelay = time.Millisecond
+	op.retry.maxDelay = 5 * time.Millisecond
+	return op
+}
+
+func TestOpenAI_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "30") // capped by maxDelay
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"data":[{"embedding":[1,2]}]}`))
+		}
+	}))
+	defer server.Close()
+
+	p := fastRetries(t, NewOpenAI(&Config{Model: "m", APIKey: "k", BaseURL: server.URL, MaxRetries: 3}))
+	v, err := p.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v) != 2 || calls.Load() != 3 {
+		t.Errorf("got %v after %d calls, want a vector after 3", v, calls.Load())
+	}
+}
+
+func TestOpenAI_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"message":"Rate limit reached"}}`))
+	}))
+	defer server.Close()
+
+	p := fastRetries(t, NewOpenAI(&Config{Model: "m", APIKey: "k", BaseURL: server.URL, MaxRetries: 2}))
+	_, err := p.Embed(context.Background(), "hello")
+	if !errors.Is(err, ErrQuota) {
+		t.Fatalf("err = %v, want ErrQuota", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 1 + 2 retries", calls.Load())
+	}
+}
+
+func TestOpenAI_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	p := fastRetries(t, NewOpenAI(&Config{Model: "m", APIKey: "k", BaseURL: server.URL, MaxRetries: 3}))
+	_, err := p.Embed(context.Background(), "hello")
+	if !errors.Is(err, ErrAuth) {
+		t.Fatalf("err = %v, want ErrAuth", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"7":                             7 * time.Second,
+		"-1":                            0,
+		"Fri, 02 Jan 2026 03:04:15 GMT": 10 * time.Second,
+		"Fri, 02 Jan 2026 03:04:00 GMT": 0, // in the past
+		"soon":                          0,
+	}
+	for in, want := range cases {
+		if got := parseRetryAfter(in, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestRetryPolicy_BackoffIsBoundedAndJittered(t *testing.T) {
+	p := retryPolicy{baseDelay: 100 * time.Millisecond, maxDelay: time.Second}
+	for attempt := 0; attempt < 40; attempt++ {
+		d := p.backoff(attempt)
+		ceiling := min(p.baseDelay<<min(attempt, 16), p.maxDelay)
+		if d < ceiling/2 || d > ceiling {
+			t.Fatalf("backoff(%d) = %v, want within [%v, %v]", attempt, d, ceiling/2, ceiling)
+		}
+	}
+}
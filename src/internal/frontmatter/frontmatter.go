@@ -0,0 +1,285 @@
+// Package frontmatter reads and edits the YAML frontmatter of Markdown files
+// such as SKILL.md while leaving the body and YAML comments intact.
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoFrontmatter is returned when a document does not start with "---".
+var ErrNoFrontmatter = errors.New("no YAML frontmatter (file must start with a '---' line)")
+
+// Document is a Markdown file split into its frontmatter and body.
+type Document struct {
+	root *yaml.Node // mapping node
+	body []byte     // everything after the closing '---' line
+	crlf bool
+}
+
+// Parse splits data into frontmatter and body. A document without
+// frontmatter is an error; use New to start one.
+func Parse(data []byte) (*Document, error) {
+	crlf := bytes.Contains(data, []byte("\r\n"))
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return nil, ErrNoFrontmatter
+	}
+	rest := text[len("---\n"):]
+	var front, body string
+	switch {
+	case strings.HasPrefix(rest, "---\n") || rest == "---":
+		front, body = "", strings.TrimPrefix(strings.TrimPrefix(rest, "---"), "\n")
+	default:
+		end := strings.Index(rest, "\n---\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n---") {
+				return nil, fmt.Errorf("unterminated frontmatter (missing closing '---')")
+			}
+			end = len(rest) - len("\n---")
+			front, body = rest[:end+1], ""
+		} else {
+			front, body = rest[:end+1], rest[end+len("\n---\n"):]
+		}
+	}
+
+	doc := &Document{body: []byte(body), crlf: crlf}
+	var n yaml.Node
+	if err := yaml.Unmarshal([]byte(front), &n); err != nil {
+		return nil, fmt.Errorf("invalid frontmatter YAML: %w", err)
+	}
+	switch {
+	case n.Kind == 0:
+		doc.root = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	case n.Kind == yaml.DocumentNode && len(n.Content) == 1 && n.Content[0].Kind == yaml.MappingNode:
+		doc.root = n.Content[0]
+		// Keep comments attached to the document node.
+		doc.root.HeadComment = joinComments(n.HeadComment, doc.root.HeadComment)
+		doc.root.FootComment = joinComments(doc.root.FootComment, n.FootComment)
+	default:
+		return nil, fmt.Errorf("frontmatter must be a YAML mapping")
+	}
+	return doc, nil
+}
+
+func joinComments(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + "\n" + b
+}
+
+// Body returns everything after the closing '---' line.
+func (d *Document) Body() []byte {
+	return d.body
+}
+
+// Decode decodes the frontmatter into v, as yaml.Unmarshal would.
+func (d *Document) Decode(v any) error {
+	return d.root.Decode(v)
+}
+
+// Keys returns the top-level keys in document order.
+func (d *Document) Keys() []string {
+	var keys []string
+	for i := 0; i+1 < len(d.root.Content); i += 2 {
+		keys = append(keys, d.root.Content[i].Value)
+	}
+	return keys
+}
+
+// Get returns the node at the dotted key path (e.g. "requires.skills").
+func (d *Document) Get(key string) (*yaml.Node, bool) {
+	n := d.root
+	for _, part := range strings.Split(key, ".") {
+		if n.Kind != yaml.MappingNode {
+			return nil, false
+		}
+		v := mappingValue(n, part)
+		if v == nil {
+			return nil, false
+		}
+		n = v
+	}
+	return n, true
+}
+
+// Set replaces the value at the dotted key path with value, parsed as YAML
+// ("true", "[a, b]", "'1.0'"). Missing parent mappings are created. The
+// comments of a replaced value are kept.
+func (d *Document) Set(key, value string) error {
+	v, err := parseValue(value)
+	if err != nil {
+		return err
+	}
+	return d.SetNode(key, v)
+}
+
+// SetNode is Set for a value that is already a YAML node, e.g. one taken
+// from another key with Get.
+func (d *Document) SetNode(key string, v *yaml.Node) error {
+	parent, last, err := d.parentFor(key)
+	if err != nil {
+		return err
+	}
+	if old := mappingValue(parent, last); old != nil {
+		v.HeadComment, v.LineComment, v.FootComment = old.HeadComment, old.LineComment, old.FootComment
+		*old = *v
+		return nil
+	}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: last}, v)
+	return nil
+}
+
+// Append adds value to the sequence at the dotted key path, creating the
+// sequence when the key is missing. Values already present are not repeated.
+func (d *Document) Append(key, value string) error {
+	v, err := parseValue(value)
+	if err != nil {
+		return err
+	}
+	if v.Kind != yaml.ScalarNode {
+		return fmt.Errorf("can only append a single value to %s", key)
+	}
+	parent, last, err := d.parentFor(key)
+	if err != nil {
+		return err
+	}
+	seq := mappingValue(parent, last)
+	if seq == nil {
+		seq = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: last}, seq)
+	}
+	if seq.Kind != yaml.SequenceNode {
+		return fmt.Errorf("%s is not a list", key)
+	}
+	for _, item := range seq.Content {
+		if item.Kind == yaml.ScalarNode && item.Value == v.Value {
+			return nil
+		}
+	}
+	seq.Content = append(seq.Content, v)
+	return nil
+}
+
+// Delete removes the dotted key path and reports whether it existed.
+func (d *Document) Delete(key string) bool {
+	parent, last, err := d.parentFor(key)
+	if err != nil {
+		return false
+	}
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == last {
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Bytes renders the document: frontmatter, closing '---', then the body
+// unchanged.
+func (d *Document) Bytes() ([]byte, error) {
+	front, err := d.Frontmatter()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(front)
+	buf.WriteString("---\n")
+	buf.Write(d.body)
+	out := buf.Bytes()
+	if d.crlf {
+		out = bytes.ReplaceAll(out, []byte("\n"), []byte("\r\n"))
+	}
+	return out, nil
+}
+
+// Frontmatter renders the YAML between the '---' lines, with "\n" line
+// endings.
+func (d *Document) Frontmatter() ([]byte, error) {
+	if len(d.root.Content) == 0 && d.root.HeadComment == "" && d.root.FootComment == "" {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(d.root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Format renders a node as a plain value for scalars and as YAML otherwise.
+func Format(n *yaml.Node) (string, error) {
+	if n.Kind == yaml.ScalarNode {
+		return n.Value, nil
+	}
+	out, err := yaml.Marshal(n)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// parentFor walks to the mapping holding the last key segment, creating
+// intermediate mappings as needed.
+func (d *Document) parentFor(key string) (*yaml.Node, string, error) {
+	parts := strings.Split(key, ".")
+	for _, p := range parts {
+		if strings.TrimSpace(p) == "" {
+			return nil, "", fmt.Errorf("invalid key %q", key)
+		}
+	}
+	n := d.root
+	for i, part := range parts[:len(parts)-1] {
+		v := mappingValue(n, part)
+		if v == nil {
+			v = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, v)
+		}
+		if v.Kind != yaml.MappingNode {
+			return nil, "", fmt.Errorf("%s is not a mapping", strings.Join(parts[:i+1], "."))
+		}
+		n = v
+	}
+	return n, parts[len(parts)-1], nil
+}
+
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// parseValue parses a command-line value as a YAML node; an empty value is
+// an empty string.
+func parseValue(value string) (*yaml.Node, error) {
+	if strings.TrimSpace(value) == "" {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: yaml.DoubleQuotedStyle}, nil
+	}
+	var n yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &n); err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", value, err)
+	}
+	if n.Kind != yaml.DocumentNode || len(n.Content) != 1 {
+		return nil, fmt.Errorf("invalid value %q", value)
+	}
+	v := n.Content[0]
+	v.HeadComment, v.LineComment, v.FootComment = "", "", ""
+	return v, nil
+}
@@ -0,0 +1,113 @@
+package frontmatter
+
+import (
+	"strings"
+	"testing"
+)
+
+const skillMD = `---
+name: humanizer # display name
+version: 1.0.0
+# keywords help search
+keywords: [writing, tone]
+auto_invoke: false
+---
+# Humanizer
+
+Body text with --- inside.
+`
+
+func TestSetPreservesBodyAndComments(t *testing.T) {
+	doc, err := Parse([]byte(skillMD))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if err := doc.Set("version", "1.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("auto_invoke", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Append("keywords", "style"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Append("keywords", "tone"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("requires.skills", "[git-helpers]"); err != nil {
+		t.Fatal(err)
+	}
+	out, err := doc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(out)
+
+	for _, want := range []string{
+		"name: humanizer # display name\n",
+		"version: 1.1.0\n",
+		"# keywords help search\n",
+		"keywords: [writing, tone, style]\n",
+		"auto_invoke: true\n",
+		"requires:\n  skills: [git-helpers]\n",
+		"---\n# Humanizer\n\nBody text with --- inside.\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if !strings.HasPrefix(got, "---\n") {
+		t.Errorf("output does not start with frontmatter:\n%s", got)
+	}
+}
+
+func TestGetAndDelete(t *testing.T) {
+	doc, err := Parse([]byte(skillMD))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, ok := doc.Get("keywords")
+	if !ok {
+		t.Fatal("keywords not found")
+	}
+	if s, _ := Format(n); s != "[writing, tone]" {
+		t.Errorf("Format(keywords) = %q", s)
+	}
+	if _, ok := doc.Get("requires.skills"); ok {
+		t.Error("missing key reported as present")
+	}
+	if !doc.Delete("auto_invoke") || doc.Delete("auto_invoke") {
+		t.Error("Delete should succeed once")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{"# no frontmatter\n", "---\nname: x\n", "---\n- a\n---\n"} {
+		if _, err := Parse([]byte(in)); err == nil {
+			t.Errorf("Parse(%q) should fail", in)
+		}
+	}
+	doc, err := Parse([]byte("---\n---\nbody\n"))
+	if err != nil {
+		t.Fatalf("empty frontmatter: %v", err)
+	}
+	if err := doc.Set("version", "'2.0'"); err != nil {
+		t.Fatal(err)
+	}
+	out, _ := doc.Bytes()
+	if string(out) != "---\nversion: '2.0'\n---\nbody\n" {
+		t.Errorf("got %q", out)
+	}
+}
+
+func TestCRLFIsKept(t *testing.T) {
+	doc, err := Parse([]byte("---\r\nname: x\r\n---\r\nbody\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = doc.Set("name", "y")
+	out, _ := doc.Bytes()
+	if string(out) != "---\r\nname: y\r\n---\r\nbody\r\n" {
+		t.Errorf("got %q", out)
+	}
+}
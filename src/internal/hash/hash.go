@@ -0,0 +1,171 @@
+// Package hash provides SHA-256 file fingerprints with an optional on-disk
+// cache keyed by (path, size, mtime), so repeated scans of large skill trees
+// only rehash files that actually changed.
+package hash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// CachePathOverride allows tests to redirect the cache file to a temp location.
+// When non-empty, DefaultCachePath returns this value instead of
+// ~/.axon/cache/hashes.json.
+var CachePathOverride string
+
+// cacheVersion is bumped whenever the on-disk format changes; caches written
+// with another version are discarded rather than migrated.
+const cacheVersion = 1
+
+// File returns the hex-encoded SHA-256 digest of the file at path.
+func File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// DefaultCachePath returns the absolute path to ~/.axon/cache/hashes.json.
+func DefaultCachePath() (string, error) {
+	if CachePathOverride != "" {
+		return CachePathOverride, nil
+	}
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(axonDir, "cache", "hashes.json"), nil
+}
+
+// entry is one cached digest together with the stat fields it was computed for.
+type entry struct {
+	Size   int64  `json:"size"`
+	MTime  int64  `json:"mtime_ns"`
+	SHA256 string `json:"sha256"`
+}
+
+type cacheFile struct {
+	Version int              `json:"version"`
+	Entries map[string]entry `json:"entries"`
+}
+
+// Cache memoizes file digests. A nil *Cache is valid and hashes every file.
+// Cache is safe for concurrent use.
+type Cache struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]entry
+	dirty   bool
+}
+
+// OpenCache loads the cache stored at path. A missing, unreadable, or
+// outdated cache file yields an empty cache rather than an error: the cache is
+// purely an optimisation and can always be rebuilt.
+func OpenCache(path string) *Cache {
+	c := &Cache{path: path, entries: map[string]entry{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	var cf cacheFile
+	if err := json.Unmarshal(data, &cf); err != nil || cf.Version != cacheVersion || cf.Entries == nil {
+		return c
+	}
+	c.entries = cf.Entries
+	return c
+}
+
+// OpenDefaultCache opens the cache at DefaultCachePath.
+func OpenDefaultCache() (*Cache, error) {
+	p, err := DefaultCachePath()
+	if err != nil {
+		return nil, err
+	}
+	return OpenCache(p), nil
+}
+
+// File returns the SHA-256 digest of path, reusing the cached value when the
+// file's size and modification time are unchanged since it was last hashed.
+func (c *Cache) File(path string) (string, error) {
+	if c == nil {
+		return File(path)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[abs]
+	c.mu.Unlock()
+	if ok && e.Size == info.Size() && e.MTime == info.ModTime().UnixNano() {
+		return e.SHA256, nil
+	}
+
+	sum, err := File(abs)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.entries[abs] = entry{Size: info.Size(), MTime: info.ModTime().UnixNano(), SHA256: sum}
+	c.dirty = true
+	c.mu.Unlock()
+	return sum, nil
+}
+
+// Save writes the cache back to disk if it changed, dropping entries for files
+// that no longer exist. The write is atomic (temp file + rename).
+func (c *Cache) Save() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	for p := range c.entries {
+		if _, err := os.Stat(p); os.IsNotExist(err) {
+			delete(c.entries, p)
+		}
+	}
+
+	data, err := json.Marshal(cacheFile{Version: cacheVersion, Entries: c.entries})
+	if err != nil {
+		return fmt.Errorf("cannot marshal hash cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("cannot create hash cache dir: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("cannot write hash cache %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("cannot install hash cache %s: %w", c.path, err)
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,100 @@
+package hash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFile_KnownDigest(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "f.txt")
+	if err := os.WriteFile(p, []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := File(p)
+	if err != nil {
+		t.Fatalf("File: %v", err)
+	}
+	want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got != want {
+		t.Errorf("digest = %s, want %s", got, want)
+	}
+}
+
+func TestCache_ReusesDigestUntilFileChanges(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "f.txt")
+	cachePath := filepath.Join(dir, "cache", "hashes.json")
+	if err := os.WriteFile(p, []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(p, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	c := OpenCache(cachePath)
+	first, err := c.File(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// Rewrite with same size and restore mtime: a cache hit must return the
+	// stale digest, proving the file was not rehashed.
+	if err := os.WriteFile(p, []byte("xyz"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(p, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	reopened := OpenCache(cachePath)
+	cached, err := reopened.File(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached != first {
+		t.Errorf("expected cached digest %s, got %s", first, cached)
+	}
+
+	// A new mtime invalidates the entry.
+	later := mtime.Add(time.Hour)
+	if err := os.Chtimes(p, later, later); err != nil {
+		t.Fatal(err)
+	}
+	fresh, err := reopened.File(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh == first {
+		t.Error("expected a fresh digest after mtime change")
+	}
+}
+
+func TestOpenCache_CorruptFileIsIgnored(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "hashes.json")
+	if err := os.WriteFile(p, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := OpenCache(p)
+	if len(c.entries) != 0 {
+		t.Errorf("corrupt cache should load empty, got %d entries", len(c.entries))
+	}
+}
+
+func TestCache_NilFallsBackToDirectHash(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "f.txt")
+	if err := os.WriteFile(p, []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var c *Cache
+	if _, err := c.File(p); err != nil {
+		t.Fatalf("nil cache File: %v", err)
+	}
+	if err := c.Save(); err != nil {
+		t.Fatalf("nil cache Save: %v", err)
+	}
+}
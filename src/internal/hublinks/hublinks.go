@@ -0,0 +1,108 @@
+// Package hublinks finds symlinks inside a Hub that will not work after the
+// Hub is synced to another machine: links to deleted files, links that
+// leave the repo, and absolute links.
+package hublinks
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Problem kinds.
+const (
+	KindDangling = "dangling" // the target does not exist
+	KindOutside  = "outside"  // the target is outside the repo
+	KindAbsolute = "absolute" // the link stores an absolute path
+)
+
+// Link is one problematic symlink.
+type Link struct {
+	Path   string // slash-separated, relative to the scanned root
+	Skill  string // the skill (or top-level directory) the link belongs to
+	Target string // the raw link value
+	Kind   string
+	Detail string
+}
+
+func (l Link) String() string {
+	return fmt.Sprintf("%s: %s", l.Path, l.Detail)
+}
+
+// Scan walks root and reports every symlink that is dangling, points
+// outside root, or stores an absolute path, sorted by path. .git
+// directories are skipped and symlinked directories are not descended.
+func Scan(root string) ([]Link, error) {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, err
+	}
+	var out []Link
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		target, err := os.Readlink(p)
+		if err != nil {
+			return err
+		}
+		l := Link{Path: filepath.ToSlash(rel), Skill: OwningSkill(root, filepath.ToSlash(rel)), Target: target}
+		if l.Kind, l.Detail = classify(p, target, realRoot); l.Kind != "" {
+			out = append(out, l)
+		}
+		return nil
+	})
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, err
+}
+
+// classify returns the problem with the symlink at p, or "" when it is a
+// relative link that resolves inside realRoot.
+func classify(p, target, realRoot string) (kind, detail string) {
+	resolved, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return KindDangling, fmt.Sprintf("points to missing %s", target)
+		}
+		return KindDangling, fmt.Sprintf("cannot be resolved: %v", err)
+	}
+	if !within(realRoot, resolved) {
+		return KindOutside, fmt.Sprintf("points outside the Hub to %s; it will be broken on other machines", target)
+	}
+	if filepath.IsAbs(target) {
+		return KindAbsolute, fmt.Sprintf("stores the absolute path %s; use a relative link so it works where the Hub lives elsewhere", target)
+	}
+	return "", ""
+}
+
+// within reports whether p is root or inside it.
+func within(root, p string) bool {
+	rel, err := filepath.Rel(root, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// OwningSkill returns the nearest directory above rel that holds a SKILL.md,
+// or else rel's top-level directory.
+func OwningSkill(root, rel string) string {
+	for dir := path.Dir(rel); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(dir), "SKILL.md")); err == nil {
+			return dir
+		}
+	}
+	top, _, _ := strings.Cut(rel, "/")
+	return top
+}
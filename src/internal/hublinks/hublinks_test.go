@@ -0,0 +1,63 @@
+package hublinks
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks needs privileges on Windows")
+	}
+	root := t.TempDir()
+	skill := filepath.Join(root, "skills", "demo")
+	if err := os.MkdirAll(filepath.Join(skill, "refs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"SKILL.md", "notes.md"} {
+		if err := os.WriteFile(filepath.Join(skill, f), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	outside := filepath.Join(t.TempDir(), "secret.md")
+	if err := os.WriteFile(outside, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	links := map[string]string{
+		"skills/demo/refs/ok.md":       "../notes.md",
+		"skills/demo/refs/gone.md":     "../deleted.md",
+		"skills/demo/refs/outside.md":  outside,
+		"skills/demo/refs/absolute.md": filepath.Join(skill, "notes.md"),
+		"workflows/loose.md":           "missing.md",
+	}
+	if err := os.MkdirAll(filepath.Join(root, "workflows"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for p, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, filepath.FromSlash(p))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := Scan(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][2]string{
+		"skills/demo/refs/absolute.md": {KindAbsolute, "skills/demo"},
+		"skills/demo/refs/gone.md":     {KindDangling, "skills/demo"},
+		"skills/demo/refs/outside.md":  {KindOutside, "skills/demo"},
+		"workflows/loose.md":           {KindDangling, "workflows"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Scan = %v, want %d links", got, len(want))
+	}
+	for _, l := range got {
+		w, ok := want[l.Path]
+		if !ok || l.Kind != w[0] || l.Skill != w[1] {
+			t.Errorf("unexpected %+v", l)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package i18n translates axon's user-facing messages.
+//
+// English is the source language: messages are looked up by their English
+// text, and a message without a translation is shown in English. Only text
+// meant for people goes through T; machine-readable output (the REST API,
+// MCP, journal entries) always stays in English.
+package i18n
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EnvLang overrides the language set in axon.yaml.
+const EnvLang = "AXON_LANG"
+
+// English is the source language.
+const English = "en"
+
+// catalogs maps a language to its translations, keyed by English text.
+var catalogs = map[string]map[string]string{
+	"zh": zh,
+}
+
+var (
+	mu      sync.RWMutex
+	current = English
+)
+
+// Normalize maps a locale such as "zh_CN.UTF-8" or "zh-Hans" to a supported
+// language, or "" when it is not supported.
+func Normalize(locale string) string {
+	l := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(l, "_-.@"); i >= 0 {
+		l = l[:i]
+	}
+	if l == English {
+		return English
+	}
+	if _, ok := catalogs[l]; ok {
+		return l
+	}
+	return ""
+}
+
+// Supported lists the supported languages, English first.
+func Supported() []string {
+	out := []string{English}
+	for l := range catalogs {
+		out = append(out, l)
+	}
+	sort.Strings(out[1:])
+	return out
+}
+
+// SetLanguage selects the language of T. It reports false, leaving the
+// language unchanged, when locale is not supported.
+func SetLanguage(locale string) bool {
+	l := Normalize(locale)
+	if l == "" {
+		return false
+	}
+	mu.Lock()
+	current = l
+	mu.Unlock()
+	return true
+}
+
+// Language returns the selected language.
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T returns msg in the selected language, or msg itself when it has no
+// translation. Format strings are translated before formatting:
+//
+//	fmt.Sprintf(i18n.T("%d target(s) linked"), n)
+func T(msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if s, ok := catalogs[current][msg]; ok {
+		return s
+	}
+	return msg
+}
@@ -0,0 +1,60 @@
+package i18n
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	for in, want := range map[string]string{
+		"zh":          "zh",
+		"zh_CN.UTF-8": "zh",
+		"zh-Hans":     "zh",
+		"EN_us":       "en",
+		"fr":          "",
+		"":            "",
+	} {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { SetLanguage(English) })
+
+	if got := T("Linked:"); got != "Linked:" {
+		t.Errorf("English T = %q", got)
+	}
+	if SetLanguage("fr") {
+		t.Fatal("fr is not supported")
+	}
+	if !SetLanguage("zh_CN") {
+		t.Fatal("zh_CN should select zh")
+	}
+	if got := T("Linked:"); got != "已链接：" {
+		t.Errorf("zh T = %q", got)
+	}
+	if got := T("no translation for this"); got != "no translation for this" {
+		t.Errorf("untranslated messages must fall back to English, got %q", got)
+	}
+}
+
+// Every translation of a format string must keep its verbs.
+func TestCatalogsKeepVerbs(t *testing.T) {
+	for lang, cat := range catalogs {
+		for en, tr := range cat {
+			if verbs(en) != verbs(tr) {
+				t.Errorf("%s: %q → %q changes the format verbs", lang, en, tr)
+			}
+		}
+	}
+}
+
+func verbs(s string) string {
+	var out []byte
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] == '%' {
+			out = append(out, s[i+1])
+			i++
+		}
+	}
+	return string(out)
+}
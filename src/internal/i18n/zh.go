@@ -0,0 +1,190 @@
+package i18n
+
+// zh holds the Simplified Chinese translations.
+var zh = map[string]string{
+	// ── Help ──────────────────────────────────────────────────────────────────
+	"Usage:":                  "用法：",
+	"Aliases:":                "别名：",
+	"Examples:":               "示例：",
+	"Available Commands:":     "可用命令：",
+	"Additional Commands:":    "其他命令：",
+	"Flags:":                  "选项：",
+	"Global Flags:":           "全局选项：",
+	"Additional help topics:": "其他帮助主题：",
+	`Use "{{.CommandPath}} [command] --help" for more information about a command.`: `使用 "{{.CommandPath}} [command] --help" 查看命令的更多信息。`,
+	"help for %s":                 "显示 %s 的帮助",
+	"Error":                       "错误",
+	"Fix":                         "修复",
+	"Print axon version and exit": "打印 axon 版本并退出",
+	"Hide progress indicators on long operations":                                    "长时间操作时不显示进度",
+	"Color output: auto, always, or never":                                           "彩色输出：auto、always 或 never",
+	"Keep config, Hub, cache, and locks in this directory and treat <dir>/home as ~": "将配置、Hub、缓存和锁都放在此目录中，并把 <dir>/home 视为 ~",
+
+	// ── Commands ──────────────────────────────────────────────────────────────
+	"Axon keeps your AI-editor skills and workflows in sync across machines\nusing a central Git-backed Hub at ~/.axon/repo/.": "Axon 通过位于 ~/.axon/repo/ 的中心 Git Hub，\n在多台机器之间同步 AI 编辑器的技能和工作流。",
+	"Help about any command":                                                        "显示任意命令的帮助",
+	"Install shell completion for axon":                                             "为 axon 安装 shell 自动补全",
+	"Generate the autocompletion script for the specified shell":                    "为指定的 shell 生成自动补全脚本",
+	"Axon CLI — Hub-and-Spoke skill manager for AI editors":                         "Axon CLI — 面向 AI 编辑器的 Hub-and-Spoke 技能管理器",
+	"Answer a question from the skills in your Hub":                                 "根据 Hub 中的技能回答问题",
+	"Run security audit on Hub content":                                             "对 Hub 内容进行安全审计",
+	"Set up a new machine from an existing Hub in one step":                         "一步从现有 Hub 配置新机器",
+	"Show the skill dependency tree of a skill":                                     "显示技能的依赖树",
+	"Check the axon.yaml configuration":                                             "检查 axon.yaml 配置",
+	"Validate axon.yaml and report problems with line numbers":                      "校验 axon.yaml 并按行号报告问题",
+	"Render a skill's instructions in the terminal":                                 "在终端中渲染技能的说明",
+	"Write a redacted diagnostics report to attach to a bug report":                 "生成已脱敏的诊断报告，用于提交问题",
+	"Manage the variables skills need in ~/.axon/.env":                              "管理 ~/.axon/.env 中技能所需的变量",
+	"Add placeholders for a skill's required variables to ~/.axon/.env":             "在 ~/.axon/.env 中为技能所需变量添加占位符",
+	"Run a script shipped with a skill":                                             "运行技能自带的脚本",
+	"Run pre-flight environment checks":                                             "运行环境预检",
+	"Export Hub content to a portable tar.gz bundle":                                "将 Hub 内容导出为可移植的 tar.gz 包",
+	"List, restore, or empty items removed by gc and doctor --fix":                  "列出、恢复或清空 gc 和 doctor --fix 移除的项目",
+	"List trashed items":                                                            "列出回收站中的项目",
+	"Move trashed items back where they were":                                       "将回收站中的项目移回原处",
+	"Delete trashed items for good":                                                 "永久删除回收站中的项目",
+	"Rename Hub items and update references to them":                                "重命名 Hub 中的项目并更新对它们的引用",
+	"Rename a skill and update references to it across the Hub":                     "重命名技能并更新整个 Hub 中对它的引用",
+	"Find duplicate and near-duplicate skills in the Hub":                           "查找 Hub 中重复或近似重复的技能",
+	"Reclaim disk space used by backups, temp dirs, and caches":                     "回收备份、临时目录和缓存占用的磁盘空间",
+	"Show the journal of link, unlink, sync, import, vendor, and update operations": "显示链接、取消链接、同步、导入、vendor 和更新操作的日志",
+	"Merge a bundle created by 'axon export' into the Hub":                          "将 'axon export' 生成的包合并到 Hub",
+	"Bootstrap the Axon Hub and import existing skills":                             "初始化 Axon Hub 并导入现有技能",
+	"Show metadata and structure of a skill, workflow, rule, or target":             "显示技能、工作流、规则或目标的元数据和结构",
+	"Create symlinks from tool destinations to the Hub":                             "为各工具的目标目录创建指向 Hub 的符号链接",
+	"List local items grouped by category from axon.yaml":                           "按 axon.yaml 中的类别列出本地条目",
+	"Read or edit SKILL.md frontmatter":                                             "读取或编辑 SKILL.md 的 frontmatter",
+	"Set frontmatter fields of a skill":                                             "设置技能的 frontmatter 字段",
+	"Print a frontmatter field of a skill":                                          "打印技能的某个 frontmatter 字段",
+	"Move the Hub repo to a new location and relink every target":                   "将 Hub 仓库移动到新位置并重新链接所有目标",
+	"List vendored skills with a newer upstream version":                            "列出上游有新版本的 vendor 技能",
+	"Open a pull request that contributes a skill to the upstream Hub":              "发起 pull request，将技能贡献到上游 Hub",
+	"Unlink every target and remove Axon's data from this machine":                  "取消所有目标的链接并从本机删除 Axon 数据",
+	"List the skills available from skill registries":                               "列出技能注册表中可用的技能",
+	"Install a skill from a registry into the Hub":                                  "从注册表安装技能到 Hub",
+	"Repoint destinations that still link into an old Hub location":                 "将仍指向旧 Hub 位置的目标重新指向新位置",
+	"Manage the Hub's remote Git repository":                                        "管理 Hub 的远程 Git 仓库",
+	"Set (or update) the remote origin URL":                                         "设置（或更新）远程 origin 地址",
+	"Roll back a skill or the entire Hub to a previous Git state":                   "将某个技能或整个 Hub 回滚到之前的 Git 状态",
+	"Search skills by keyword or semantic similarity":                               "按关键词或语义相似度搜索技能",
+	"Serve the Hub to editors and AI tools (REST API or MCP)":                       "向编辑器和 AI 工具提供 Hub 服务（REST API 或 MCP）",
+	"Write a detached signature for a skill":                                        "为技能写入分离签名",
+	"Work with a single skill in the Hub":                                           "操作 Hub 中的单个技能",
+	"Show what changed in one skill":                                                "显示单个技能的改动",
+	"Import a Claude skill zip into the Hub":                                        "将 Claude 技能 zip 导入 Hub",
+	"Export a Hub skill as a Claude skill zip":                                      "将 Hub 技能导出为 Claude 技能 zip",
+	"Show how the Hub and its links evolve over time":                               "显示 Hub 及其链接随时间的变化",
+	"Validate symlinks and show Hub Git status":                                     "检查符号链接并显示 Hub 的 Git 状态",
+	"Sync the Hub with the remote Git repository":                                   "将 Hub 与远程 Git 仓库同步",
+	"Revert the most recent link, unlink, import, or sync":                          "撤销最近一次链接、取消链接、导入或同步",
+	"Remove symlinks and optionally restore from backup":                            "删除符号链接，可选择从备份恢复",
+	"Update the Axon CLI to the latest release":                                     "将 Axon CLI 更新到最新版本",
+	"Pull the upstream copy of one vendored skill into the Hub":                     "将单个 vendor 技能的上游版本拉取到 Hub",
+	"Manage external vendor content synced into the Hub":                            "管理同步到 Hub 的外部 vendor 内容",
+	"Sync all configured vendor entries into the Hub":                               "将所有已配置的 vendor 条目同步到 Hub",
+	"Inspect, verify, and prune the vendor clone cache":                             "查看、校验和清理 vendor 克隆缓存",
+	"Show the vendor cache location and size":                                       "显示 vendor 缓存的位置和大小",
+	"Check the cached vendor clones for corruption":                                 "检查缓存的 vendor 克隆是否损坏",
+	"Remove unused vendor clones and stale cache data":                              "删除未使用的 vendor 克隆和过期的缓存数据",
+	"Show Axon version and build information":                                       "显示 Axon 版本和构建信息",
+
+	// ── Sections ──────────────────────────────────────────────────────────────
+	"axon doctor":                          "axon 诊断",
+	"Auto-sync":                            "自动同步",
+	"By month":                             "按月统计",
+	"Embedding failures":                   "嵌入失败",
+	"Export":                               "导出",
+	"History":                              "历史",
+	"Hub Git Status":                       "Hub Git 状态",
+	"Import Bundle":                        "导入包",
+	"Import Existing Skills":               "导入现有技能",
+	"Import Skill Zip":                     "导入技能 zip",
+	"Install":                              "安装",
+	"Link":                                 "链接",
+	"Link churn":                           "链接变动",
+	"Local Inventory":                      "本地清单",
+	"Most-edited":                          "编辑最多",
+	"Outdated Skills":                      "过期技能",
+	"Publish":                              "发布",
+	"Purge":                                "清除",
+	"Re-checking":                          "重新检查",
+	"Readiness":                            "就绪状态",
+	"Relink":                               "重新链接",
+	"Security Audit":                       "安全审计",
+	"Sources":                              "来源",
+	"Symlink Health":                       "符号链接健康状况",
+	"Sync status":                          "同步状态",
+	"Syncs":                                "同步",
+	"Unlink":                               "取消链接",
+	"Update Skill":                         "更新技能",
+	"Vendor Sync":                          "Vendor 同步",
+	"Bootstrap 1/4: init":                  "引导 1/4：初始化",
+	"Bootstrap 2/4: link":                  "引导 2/4：链接",
+	"Bootstrap 3/4: search index":          "引导 3/4：搜索索引",
+	"Bootstrap 4/4: doctor":                "引导 4/4：诊断",
+	"Already linked:":                      "已链接：",
+	"Already linked (Hub manages these):":  "已链接（由 Hub 管理）：",
+	"Body (SKILL.md):":                     "正文（SKILL.md）：",
+	"Broken symlinks inside the Hub:":      "Hub 内损坏的符号链接：",
+	"Copies refreshed:":                    "已刷新的副本：",
+	"Destination not found:":               "未找到目标目录：",
+	"Errors:":                              "错误：",
+	"Files:":                               "文件：",
+	"Imported:":                            "已导入：",
+	"Installed but not linked:":            "已安装但未链接：",
+	"Limited to other tools (not linked):": "仅限其他工具（未链接）：",
+	"Linked:":                              "已链接：",
+	"Linked (healthy symlinks):":           "已链接（符号链接正常）：",
+	"Linked (original backed up):":         "已链接（原内容已备份）：",
+	"Linked (original deleted, --no-backup):":           "已链接（原内容已删除，--no-backup）：",
+	"Merged views and copies out of date:":              "过期的合并视图和副本：",
+	"Not installed (skipped):":                          "未安装（已跳过）：",
+	"Nothing to unlink:":                                "无需取消链接：",
+	"Re-linked (wrong target corrected):":               "已重新链接（已纠正错误目标）：",
+	"Real directories (not yet converted to symlinks):": "真实目录（尚未转换为符号链接）：",
+	"Recent commits:":                                   "最近提交：",
+	"Restored from backup:":                             "已从备份恢复：",
+	"Rolling back:":                                     "正在回滚：",
+	"Shadowed by a higher-priority repo:":               "被更高优先级的仓库覆盖：",
+	"Skipped (not a symlink — real data protected):":    "已跳过（不是符号链接——保护真实数据）：",
+	"Symlink removed (no backup):":                      "已删除符号链接（无备份）：",
+	"Will keep:":                                        "将保留：",
+	"Will remove:":                                      "将删除：",
+	"Written into the Hub through read-only targets:":   "通过只读目标写入 Hub 的文件：",
+
+	// ── Messages ──────────────────────────────────────────────────────────────
+	"Fetch complete.":                                                "获取完成。",
+	"Fetching remote updates (origin)...":                            "正在获取远程更新（origin）……",
+	"Sync complete (read-write).":                                    "同步完成（读写模式）。",
+	"Sync complete (read-only).":                                     "同步完成（只读模式）。",
+	"Sync complete (initial push).":                                  "同步完成（首次推送）。",
+	"Sync aborted; the Hub is back to where it was before the pull.": "同步已中止；Hub 已恢复到拉取之前的状态。",
+	"Local commit done (no remote configured; run 'axon remote set <url>' to push).": "已完成本地提交（未配置远程仓库；运行 'axon remote set <url>' 后即可推送）。",
+	"You have local edits in the Hub.":                                               "Hub 中有本地修改。",
+	"axon init complete. Run 'axon status' to verify your environment.":              "axon init 完成。运行 'axon status' 检查环境。",
+	"This machine is ready. Run 'axon sync' to pull future changes.":                 "本机已就绪。之后运行 'axon sync' 拉取更新。",
+	"Update applied successfully.":                                                   "更新成功。",
+	"Update staged; it will complete after this process exits.":                      "更新已就绪，将在本进程退出后完成。",
+	"Signature verified.":                                  "签名验证通过。",
+	"git not available — skipping Hub Git status.":         "git 不可用——跳过 Hub Git 状态。",
+	"rsync not found — will use cp fallback for mirroring": "未找到 rsync——将改用 cp 进行镜像",
+	"aborted, nothing changed":                             "已中止，未做任何更改",
+	"dry run — nothing was pushed":                         "试运行——未推送任何内容",
+	"nothing to commit":                                    "没有需要提交的内容",
+	"nothing to undo":                                      "没有可撤销的操作",
+	"no changes":                                           "没有改动",
+	"no matching skills":                                   "没有匹配的技能",
+	"no recorded operations":                               "没有操作记录",
+	"no syncs recorded":                                    "没有同步记录",
+	"no commits in this period":                            "此期间没有提交",
+	"no commits touch Hub items":                           "没有涉及 Hub 条目的提交",
+	"no link or unlink operations recorded":                "没有链接或取消链接的记录",
+	"no embeddings provider configured; semantic search is not available": "未配置嵌入服务，语义搜索不可用",
+	"not cloned yet (run 'axon sync')":                                    "尚未克隆（运行 'axon sync'）",
+	"not found in axon.yaml targets":                                      "不在 axon.yaml 的 targets 中",
+	"read-only repo — nothing to push":                                    "只读仓库——无需推送",
+	"remote has no commits yet — nothing to pull":                         "远程仓库还没有提交——无需拉取",
+	"dependency problems found; run 'axon doctor' for details":            "发现依赖问题；运行 'axon doctor' 查看详情",
+	"Targets unlinked; there is nothing else to remove.":                  "已取消所有目标的链接；没有其他需要删除的内容。",
+	"targets unlinked; ~/.axon was left in place":                         "已取消所有目标的链接；~/.axon 保持不变",
+}
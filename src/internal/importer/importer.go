@@ -1,14 +1,19 @@
 // Package importer handles copying existing skills into the Axon Hub,
-// applying exclude filtering and MD5-based conflict resolution.
+// applying exclude filtering and SHA-256-based conflict resolution.
+//
+// Imports are metadata-faithful: relative symlinks are recreated as symlinks,
+// and permission bits and modification times are carried over.
 package importer
 
 import (
-	"crypto/md5"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/kamusis/axon-cli/internal/hash"
+	"github.com/kamusis/axon-cli/internal/portable"
 )
 
 // ConflictPair records a conflict found during import.
@@ -28,13 +33,27 @@ type Result struct {
 	SkillsImported  int // skills with ≥1 newly copied file
 	SkillsSkipped   int // skills whose every file was an identical duplicate
 	SkillsConflicts int // skills with ≥1 conflict
+
+	// Created lists every Hub path the import created (directories, files,
+	// symlinks, and conflict copies), parents before children.
+	Created []string
 }
 
-// ImportDir copies files from srcDir into dstDir, applying excludes and MD5
+// ImportDir copies files from srcDir into dstDir, applying excludes and SHA-256
 // conflict resolution.  toolName is used to build conflict file names.
+//
+// File digests are memoized in the shared hash cache (~/.axon/cache/hashes.json)
+// so repeated imports of large trees only rehash files that changed.
 func ImportDir(srcDir, dstDir, toolName string, excludes []string) (*Result, error) {
 	result := &Result{}
 
+	hashes, err := hash.OpenDefaultCache()
+	if err != nil {
+		// Caching is an optimisation only; fall back to hashing every file.
+		hashes = nil
+	}
+	defer func() { _ = hashes.Save() }()
+
 	// Skill-level outcome sets — key is the top-level child name (skill dir).
 	skillImported := map[string]bool{}
 	skillSkipped  := map[string]bool{}
@@ -52,9 +71,11 @@ func ImportDir(srcDir, dstDir, toolName string, excludes []string) (*Result, err
 		}
 	}
 
-	visitedDirs := make(map[string]bool)
-	if resolvedSrc, err := filepath.EvalSymlinks(srcDir); err == nil {
-		visitedDirs[resolvedSrc] = true
+	if _, err := os.Lstat(dstDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(dstDir, 0o755); err != nil {
+			return result, err
+		}
+		result.Created = append(result.Created, dstDir)
 	}
 
 	var walk func(currentSrc, currentRel string) error
@@ -73,56 +94,88 @@ func ImportDir(srcDir, dstDir, toolName string, excludes []string) (*Result, err
 				continue
 			}
 
-			// Stat the file to follow symlinks transparently.
-			info, err := os.Stat(path)
+			// Lstat so symlinks are reproduced as symlinks rather than flattened.
+			info, err := os.Lstat(path)
 			if err != nil {
-				// Ignore broken symlinks or unreadable files.
+				// Ignore unreadable entries.
 				continue
 			}
 
 			dst := filepath.Join(dstDir, rel)
 
-			if info.IsDir() {
-				// Cycle detection for directory symlinks
-				resolved, err := filepath.EvalSymlinks(path)
-				if err == nil {
-					if visitedDirs[resolved] {
-						continue
-					}
-					visitedDirs[resolved] = true
+			// Top-level component = skill name (files at root get key ".").
+			skillKey := strings.SplitN(rel, string(filepath.Separator), 2)[0]
+
+			if info.Mode()&os.ModeSymlink != 0 {
+				linkTarget, err := checkSymlink(srcDir, path)
+				if err != nil {
+					return err
 				}
+				outcome, conflictDst, err := importSymlink(linkTarget, dst, toolName)
+				if err != nil {
+					return err
+				}
+				switch outcome {
+				case outcomeSkipped:
+					result.Skipped++
+					skillSkipped[skillKey] = true
+				case outcomeConflict:
+					result.Conflicts = append(result.Conflicts, ConflictPair{
+						Original: dst,
+						Conflict: conflictDst,
+						Tool:     toolName,
+					})
+					result.Imported++
+					result.Created = append(result.Created, conflictDst)
+					skillConflict[skillKey] = true
+				default:
+					result.Imported++
+					result.Created = append(result.Created, dst)
+					skillImported[skillKey] = true
+				}
+				continue
+			}
 
-				if err := os.MkdirAll(dst, 0o755); err != nil {
+			if info.IsDir() {
+				_, statErr := os.Lstat(dst)
+				created := os.IsNotExist(statErr)
+				if err := os.MkdirAll(portable.LongPath(dst), 0o755); err != nil {
 					return err
 				}
+				if created {
+					result.Created = append(result.Created, dst)
+				}
 				if err := walk(path, rel); err != nil {
 					return err
 				}
+				// Restore the directory mtime last: writing children bumps it.
+				if created {
+					_ = os.Chmod(dst, info.Mode().Perm())
+					_ = os.Chtimes(dst, info.ModTime(), info.ModTime())
+				}
 				continue
 			}
 
-			// Top-level component = skill name (files at root get key ".").
-			skillKey := strings.SplitN(rel, string(filepath.Separator), 2)[0]
+			if !info.Mode().IsRegular() {
+				// Sockets, devices, and FIFOs have no place in a skill tree.
+				continue
+			}
 
-			// ── MD5 conflict resolution ───────────────────────────────────────────
-			if _, err := os.Stat(dst); err == nil {
+			// ── SHA-256 conflict resolution ───────────────────────────────────────
+			if dstInfo, err := os.Lstat(dst); err == nil {
 				// Destination file already exists — compare fingerprints.
-				srcMD5, err := fileMD5(path)
-				if err != nil {
-					return fmt.Errorf("md5 %s: %w", path, err)
-				}
-				dstMD5, err := fileMD5(dst)
+				same, err := sameContent(hashes, path, info, dst, dstInfo)
 				if err != nil {
-					return fmt.Errorf("md5 %s: %w", dst, err)
+					return err
 				}
-				if srcMD5 == dstMD5 {
+				if same {
 					// Identical — skip silently.
 					result.Skipped++
 					skillSkipped[skillKey] = true
 					continue
 				}
 				// Different content — conflict-safe write.
-				conflictDst := conflictPath(dst, toolName)
+				conflictDst := ConflictPath(dst, toolName)
 				if err := copyFile(path, conflictDst); err != nil {
 					return fmt.Errorf("conflict copy %s → %s: %w", path, conflictDst, err)
 				}
@@ -132,18 +185,20 @@ func ImportDir(srcDir, dstDir, toolName string, excludes []string) (*Result, err
 					Tool:     toolName,
 				})
 				result.Imported++
+				result.Created = append(result.Created, conflictDst)
 				skillConflict[skillKey] = true
 				continue
 			}
 
 			// Destination file does not exist — plain copy.
-			if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			if err := os.MkdirAll(portable.LongPath(filepath.Dir(dst)), 0o755); err != nil {
 				return err
 			}
 			if err := copyFile(path, dst); err != nil {
 				return fmt.Errorf("copy %s → %s: %w", path, dst, err)
 			}
 			result.Imported++
+			result.Created = append(result.Created, dst)
 			skillImported[skillKey] = true
 		}
 		return nil
@@ -169,12 +224,64 @@ func ImportDir(srcDir, dstDir, toolName string, excludes []string) (*Result, err
 	return result, nil
 }
 
-// conflictPath builds the conflict filename for an incoming file.
+// importOutcome classifies how a single entry was handled.
+type importOutcome int
+
+const (
+	outcomeImported importOutcome = iota
+	outcomeSkipped
+	outcomeConflict
+)
+
+// checkSymlink validates the symlink at path and returns its (relative) target.
+// Absolute targets and relative targets that resolve outside root are
+// rejected: they would dangle or leak host-specific paths once the Hub is
+// synced to another machine.
+func checkSymlink(root, path string) (string, error) {
+	target, err := os.Readlink(path)
+	if err != nil {
+		return "", fmt.Errorf("readlink %s: %w", path, err)
+	}
+	if filepath.IsAbs(target) {
+		return "", fmt.Errorf("symlink %s → %s is absolute; replace it with a relative link or a real copy before importing", path, target)
+	}
+	resolved := filepath.Join(filepath.Dir(path), target)
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("symlink %s → %s points outside %s; replace it with a real copy before importing", path, target, root)
+	}
+	return target, nil
+}
+
+// importSymlink recreates a symlink with the given target at dst. An existing
+// identical link is skipped; a differing entry yields a conflict link.
+func importSymlink(target, dst, toolName string) (importOutcome, string, error) {
+	if _, err := os.Lstat(dst); err == nil {
+		if existing, err := os.Readlink(dst); err == nil && existing == target {
+			return outcomeSkipped, "", nil
+		}
+		conflictDst := ConflictPath(dst, toolName)
+		_ = os.Remove(conflictDst)
+		if err := os.Symlink(target, conflictDst); err != nil {
+			return 0, "", fmt.Errorf("conflict symlink %s → %s: %w", conflictDst, target, err)
+		}
+		return outcomeConflict, conflictDst, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return 0, "", err
+	}
+	if err := os.Symlink(target, dst); err != nil {
+		return 0, "", fmt.Errorf("symlink %s → %s: %w", dst, target, err)
+	}
+	return outcomeImported, "", nil
+}
+
+// ConflictPath builds the conflict filename for an incoming file.
 // Strategy: insert .conflict-<tool> before the final extension.
 //
 //	oracle_expert.md         → oracle_expert.conflict-antigravity.md
 //	oracle_expert.prompt.md  → oracle_expert.prompt.conflict-antigravity.md
-func conflictPath(original, tool string) string {
+func ConflictPath(original, tool string) string {
 	ext := filepath.Ext(original)
 	base := strings.TrimSuffix(original, ext)
 	return base + ".conflict-" + tool + ext
@@ -195,23 +302,26 @@ func matchesExclude(relPath string, patterns []string) bool {
 	return false
 }
 
-// fileMD5 returns the hex-encoded MD5 digest of the file at path.
-func fileMD5(path string) (string, error) {
-	f, err := os.Open(path)
+// sameContent reports whether src and dst hold identical bytes. Differing
+// sizes short-circuit without hashing; otherwise SHA-256 digests are compared.
+func sameContent(hashes *hash.Cache, src string, srcInfo os.FileInfo, dst string, dstInfo os.FileInfo) (bool, error) {
+	if !dstInfo.Mode().IsRegular() || srcInfo.Size() != dstInfo.Size() {
+		return false, nil
+	}
+	srcSum, err := hashes.File(src)
 	if err != nil {
-		return "", err
+		return false, fmt.Errorf("sha256 %s: %w", src, err)
 	}
-	defer f.Close()
-
-	h := md5.New()
-	if _, err := io.Copy(h, f); err != nil {
-		return "", err
+	dstSum, err := hashes.File(dst)
+	if err != nil {
+		return false, fmt.Errorf("sha256 %s: %w", dst, err)
 	}
-	return fmt.Sprintf("%x", h.Sum(nil)), nil
+	return srcSum == dstSum, nil
 }
 
-// copyFile copies src to dst, preserving permissions.
+// copyFile copies src to dst, preserving permissions and modification time.
 func copyFile(src, dst string) error {
+	src, dst = portable.LongPath(src), portable.LongPath(dst)
 	in, err := os.Open(src)
 	if err != nil {
 		return err
@@ -227,8 +337,18 @@ func copyFile(src, dst string) error {
 	if err != nil {
 		return err
 	}
-	defer out.Close()
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
 
-	_, err = io.Copy(out, in)
-	return err
+	// OpenFile's mode is filtered by umask and ignored for existing files, so
+	// apply the permission bits explicitly (keeps executable scripts runnable).
+	if err := os.Chmod(dst, info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
 }
@@ -3,11 +3,27 @@ package importer_test
 import (
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/kamusis/axon-cli/internal/hash"
 	"github.com/kamusis/axon-cli/internal/importer"
 )
 
+func TestMain(m *testing.M) {
+	// Keep the shared hash cache out of the real ~/.axon during tests.
+	dir, err := os.MkdirTemp("", "axon-hash-cache-*")
+	if err != nil {
+		panic(err)
+	}
+	hash.CachePathOverride = filepath.Join(dir, "hashes.json")
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 func TestImportDir_BasicAndConflict(t *testing.T) {
 	tmp := t.TempDir()
 	windsurf := filepath.Join(tmp, "windsurf")
@@ -86,10 +102,104 @@ func TestImportDir_BasicAndConflict(t *testing.T) {
 		t.Error("ag_tips.md should have been imported")
 	}
 
+	// Created lists the new file and the conflict copy, not the skipped duplicate.
+	created := strings.Join(r2.Created, "\n")
+	if len(r2.Created) != 2 || !strings.Contains(created, "ag_tips.md") || !strings.Contains(created, "conflict-antigravity") {
+		t.Errorf("antigravity: unexpected Created list %v", r2.Created)
+	}
+
 	t.Logf("windsurf import: %+v", r1)
 	t.Logf("antigravity import: %+v", r2)
 }
 
+func TestImportDir_PreservesSymlinksModesAndMtimes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink creation requires elevated privileges on Windows")
+	}
+	tmp := t.TempDir()
+	src := filepath.Join(tmp, "src")
+	hub := filepath.Join(tmp, "hub")
+	skill := filepath.Join(src, "demo")
+	if err := os.MkdirAll(filepath.Join(skill, "scripts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, skill, "SKILL.md", "demo")
+	script := filepath.Join(skill, "scripts", "run.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("SKILL.md", filepath.Join(skill, "README.md")); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := os.Chtimes(script, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := importer.ImportDir(src, hub, "tool", nil); err != nil {
+		t.Fatalf("ImportDir: %v", err)
+	}
+
+	link := filepath.Join(hub, "demo", "README.md")
+	target, err := os.Readlink(link)
+	if err != nil {
+		t.Fatalf("README.md should be a symlink: %v", err)
+	}
+	if target != "SKILL.md" {
+		t.Errorf("symlink target = %q, want SKILL.md", target)
+	}
+
+	info, err := os.Stat(filepath.Join(hub, "demo", "scripts", "run.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o111 == 0 {
+		t.Errorf("executable bit lost: %v", info.Mode())
+	}
+	if !info.ModTime().Equal(mtime) {
+		t.Errorf("mtime = %v, want %v", info.ModTime(), mtime)
+	}
+
+	// Re-importing the same tree is a no-op, including the symlink.
+	r, err := importer.ImportDir(src, hub, "tool", nil)
+	if err != nil {
+		t.Fatalf("re-import: %v", err)
+	}
+	if r.Imported != 0 || len(r.Conflicts) != 0 {
+		t.Errorf("re-import should skip everything, got %+v", r)
+	}
+}
+
+func TestImportDir_RejectsExternalSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink creation requires elevated privileges on Windows")
+	}
+	cases := map[string]string{
+		"absolute": "/etc/hosts",
+		"escaping": "../../outside.md",
+	}
+	for name, target := range cases {
+		t.Run(name, func(t *testing.T) {
+			tmp := t.TempDir()
+			src := filepath.Join(tmp, "src")
+			skill := filepath.Join(src, "demo")
+			if err := os.MkdirAll(skill, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Symlink(target, filepath.Join(skill, "link.md")); err != nil {
+				t.Fatal(err)
+			}
+			_, err := importer.ImportDir(src, filepath.Join(tmp, "hub"), "tool", nil)
+			if err == nil {
+				t.Fatal("expected an error for external symlink")
+			}
+			if !strings.Contains(err.Error(), "link.md") {
+				t.Errorf("error should name the offending symlink: %v", err)
+			}
+		})
+	}
+}
+
 func writeFile(t *testing.T, dir, name, content string) {
 	t.Helper()
 	if err := os.WriteFile(filepath.Join(dir, name), []byte(content+"\n"), 0o644); err != nil {
@@ -0,0 +1,164 @@
+// Package journal records every mutating axon operation in an append-only
+// JSONL log (~/.axon/history.log) so that past changes can be reviewed with
+// 'axon history' and reverted with 'axon undo'.
+//
+// Each line is one JSON-encoded Entry. Lines are written with a single
+// O_APPEND write so concurrent axon processes never interleave records.
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// FileName is the journal file name inside ~/.axon.
+const FileName = "history.log"
+
+// Operation names recorded in Entry.Op.
+const (
+	OpLink         = "link"
+	OpLinkRollback = "link-rollback"
+	OpUnlink       = "unlink"
+	OpSync         = "sync"
+	OpImport       = "import"
+	OpVendorSync   = "vendor-sync"
+	OpUpdate       = "update"
+	OpRollback     = "update-rollback"
+	OpUndo         = "undo"
+	OpRename       = "rename"
+)
+
+// runID identifies the current process so entries written by one command
+// invocation (e.g. 'axon link' over several targets) can be grouped.
+var runID = fmt.Sprintf("%d-%d", time.Now().UnixNano(), os.Getpid())
+
+// Entry is a single journal record.
+//
+// Data carries operation-specific facts (destination, backup path, commit
+// SHAs, versions); Paths lists files an operation created, when relevant,
+// and Digests maps those of them that are files or symlinks to their content
+// at the time, so undo can tell whether they were edited since.
+// Run is shared by every entry written by the same axon process.
+type Entry struct {
+	Time    time.Time         `json:"time"`
+	Run     string            `json:"run,omitempty"`
+	Op      string            `json:"op"`
+	Target  string            `json:"target,omitempty"`
+	Detail  string            `json:"detail,omitempty"`
+	Data    map[string]string `json:"data,omitempty"`
+	Paths   []string          `json:"paths,omitempty"`
+	Digests map[string]string `json:"digests,omitempty"`
+	User    string            `json:"user,omitempty"`
+	Host    string            `json:"host,omitempty"`
+}
+
+// Key uniquely identifies an entry; 'axon undo' records it in Data["undone"].
+func (e Entry) Key() string {
+	run := e.Run
+	if run == "" {
+		run = e.Time.UTC().Format(time.RFC3339Nano)
+	}
+	return e.Op + "@" + run + "@" + e.Target
+}
+
+// Path returns the default journal location (~/.axon/history.log).
+func Path() (string, error) {
+	dir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Record appends e to the default journal.
+func Record(e Entry) error {
+	p, err := Path()
+	if err != nil {
+		return err
+	}
+	return Append(p, e)
+}
+
+// Append writes e as one JSON line to the journal at path, filling in the
+// timestamp, run ID, user, and host when they are unset.
+func Append(path string, e Entry) error {
+	if e.Op == "" {
+		return fmt.Errorf("journal entry has no operation")
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Time = e.Time.UTC()
+	if e.Run == "" {
+		e.Run = runID
+	}
+	if e.User == "" {
+		if u, err := user.Current(); err == nil {
+			e.User = u.Username
+		}
+	}
+	if e.Host == "" {
+		e.Host, _ = os.Hostname()
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("cannot create journal dir: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot open journal: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("cannot write journal: %w", err)
+	}
+	return f.Close()
+}
+
+// Read returns the entries in the journal at path recorded at or after since,
+// oldest first. A zero since returns every entry. A missing journal yields no
+// entries; malformed lines are skipped. Lines have no length limit, since an
+// import entry lists every file it wrote.
+func Read(path string, since time.Time) ([]Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []Entry
+	r := bufio.NewReaderSize(f, 64*1024)
+	for {
+		line, readErr := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var e Entry
+			if err := json.Unmarshal(line, &e); err == nil && e.Op != "" && (since.IsZero() || !e.Time.Before(since)) {
+				out = append(out, e)
+			}
+		}
+		if readErr == io.EOF {
+			return out, nil
+		}
+		if readErr != nil {
+			return out, readErr
+		}
+	}
+}
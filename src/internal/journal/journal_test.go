@@ -0,0 +1,101 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", FileName)
+
+	old := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := Append(path, Entry{Time: old, Op: OpLink, Target: "claude-skills"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := Append(path, Entry{Op: OpSync, Data: map[string]string{"commit": "abc123"}}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	all, err := Read(path, time.Time{})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("got %d entries, want 2", len(all))
+	}
+	if all[0].Op != OpLink || all[0].Target != "claude-skills" || !all[0].Time.Equal(old) {
+		t.Errorf("unexpected first entry: %+v", all[0])
+	}
+	if all[1].Data["commit"] != "abc123" {
+		t.Errorf("data not preserved: %+v", all[1].Data)
+	}
+	if all[1].Host == "" || all[1].Time.IsZero() || all[1].Run == "" {
+		t.Errorf("host/time/run not filled in: %+v", all[1])
+	}
+	if all[0].Run != all[1].Run || all[0].Key() == all[1].Key() {
+		t.Errorf("entries from one process should share Run but not Key: %q %q", all[0].Key(), all[1].Key())
+	}
+
+	recent, err := Read(path, old.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Read since: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Op != OpSync {
+		t.Errorf("since filter: got %+v", recent)
+	}
+}
+
+func TestReadSkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	content := "not json\n\n{\"op\":\"unlink\",\"time\":\"2026-02-01T00:00:00Z\"}\n{\"time\":\"2026-02-01T00:00:00Z\"}\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(path, time.Time{})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(got) != 1 || got[0].Op != OpUnlink {
+		t.Errorf("got %+v, want the single unlink entry", got)
+	}
+}
+
+func TestReadLongLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	paths := make([]string, 200000)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("skills/s%d/SKILL.md", i)
+	}
+	big := Entry{Op: OpImport, Time: time.Now(), Paths: paths}
+	for _, e := range []Entry{big, {Op: OpUnlink, Time: time.Now()}} {
+		if err := Append(path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if info, _ := os.Stat(path); info.Size() < 4*1024*1024 {
+		t.Fatalf("journal is only %d bytes; the test needs a line over 4 MiB", info.Size())
+	}
+	got, err := Read(path, time.Time{})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(got) != 2 || len(got[0].Paths) != len(paths) || got[1].Op != OpUnlink {
+		t.Errorf("got %d entries, want the import and the unlink after it", len(got))
+	}
+}
+
+func TestReadMissingFile(t *testing.T) {
+	got, err := Read(filepath.Join(t.TempDir(), "absent.log"), time.Time{})
+	if err != nil || got != nil {
+		t.Errorf("Read missing = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestAppendRequiresOp(t *testing.T) {
+	if err := Append(filepath.Join(t.TempDir(), FileName), Entry{}); err == nil {
+		t.Error("expected error for entry without op")
+	}
+}
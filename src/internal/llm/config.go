@@ -6,21 +6,34 @@ import (
 	"github.com/kamusis/axon-cli/internal/config"
 )
 
-// LoadProviderFromConfig loads an LLM provider from environment/config.
+// LoadProviderFromConfig loads the LLM provider 'axon audit' uses from the
+// AXON_AUDIT_* environment/config values.
 // Returns nil if not configured (graceful fallback).
 func LoadProviderFromConfig() (Provider, error) {
-	provider, _ := config.GetConfigValue("AXON_AUDIT_PROVIDER")
+	return loadProvider("AXON_AUDIT")
+}
+
+// LoadAskProvider loads the chat provider 'axon ask' uses from the
+// AXON_ASK_* environment/config values. Returns nil if not configured:
+// asking is strictly opt-in and never borrows another feature's key.
+func LoadAskProvider() (Provider, error) {
+	return loadProvider("AXON_ASK")
+}
+
+// loadProvider reads <prefix>_PROVIDER, _API_KEY, _MODEL, and _BASE_URL.
+func loadProvider(prefix string) (Provider, error) {
+	provider, _ := config.GetConfigValue(prefix + "_PROVIDER")
 	if provider == "" {
 		return nil, nil // Not configured, graceful fallback
 	}
 
-	apiKey, _ := config.GetConfigValue("AXON_AUDIT_API_KEY")
+	apiKey, _ := config.GetConfigValue(prefix + "_API_KEY")
 	if apiKey == "" {
-		return nil, fmt.Errorf("AXON_AUDIT_API_KEY is required when AXON_AUDIT_PROVIDER is set")
+		return nil, fmt.Errorf("%s_API_KEY is required when %s_PROVIDER is set", prefix, prefix)
 	}
 
-	model, _ := config.GetConfigValue("AXON_AUDIT_MODEL")
-	baseURL, _ := config.GetConfigValue("AXON_AUDIT_BASE_URL")
+	model, _ := config.GetConfigValue(prefix + "_MODEL")
+	baseURL, _ := config.GetConfigValue(prefix + "_BASE_URL")
 
 	switch provider {
 	case "openai":
@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -30,3 +31,24 @@ func TestConvertMessages(t *testing.T) {
 		t.Errorf("unexpected content: %q", converted[1]["content"])
 	}
 }
+
+func TestLoadAskProvider_IsOptIn(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AXON_AUDIT_PROVIDER", "openai")
+	t.Setenv("AXON_AUDIT_API_KEY", "audit-key")
+	t.Setenv("AXON_ASK_PROVIDER", "")
+
+	// The audit configuration is not borrowed.
+	if p, err := LoadAskProvider(); p != nil || err != nil {
+		t.Fatalf("LoadAskProvider() = %v, %v; want nil, nil", p, err)
+	}
+
+	t.Setenv("AXON_ASK_PROVIDER", "openai")
+	if _, err := LoadAskProvider(); err == nil || !strings.Contains(err.Error(), "AXON_ASK_API_KEY") {
+		t.Errorf("missing key error = %v", err)
+	}
+	t.Setenv("AXON_ASK_API_KEY", "ask-key")
+	if p, err := LoadAskProvider(); err != nil || p == nil || p.Name() != "openai" {
+		t.Errorf("LoadAskProvider() = %v, %v", p, err)
+	}
+}
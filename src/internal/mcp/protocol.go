@@ -0,0 +1,89 @@
+// Package mcp implements the server side of the Model Context Protocol:
+// JSON-RPC 2.0 messages over stdio or server-sent events, with tools and
+// resources provided by the caller.
+package mcp
+
+import "encoding/json"
+
+// ProtocolVersions lists the protocol revisions the server speaks, newest
+// first. A client asking for another one is answered with the newest.
+var ProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// isNotification reports whether the request expects no response.
+func (r *request) isNotification() bool {
+	return len(r.ID) == 0 || string(r.ID) == "null"
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// Tool describes a tool the client may call. InputSchema is a JSON Schema
+// object for the arguments.
+type Tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+// ToolHandler runs a tool with its raw JSON arguments and returns the text
+// given back to the model. An error is reported as a failed tool call, not
+// as a protocol error, so the model can see it.
+type ToolHandler func(args json.RawMessage) (string, error)
+
+// Resource is a document the client may read by URI.
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ResourceContents is the text of a resource.
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text"`
+}
+
+// ResourceProvider lists and reads resources.
+type ResourceProvider interface {
+	ListResources() ([]Resource, error)
+	ReadResource(uri string) (ResourceContents, error)
+}
+
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type toolResult struct {
+	Content []textContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
@@ -0,0 +1,191 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Server answers MCP requests with the tools and resources registered on
+// it. Register everything before serving; Handle is safe for concurrent
+// use afterwards.
+type Server struct {
+	name    string
+	version string
+
+	tools     []Tool
+	handlers  map[string]ToolHandler
+	resources ResourceProvider
+}
+
+// NewServer returns a server that introduces itself as name and version.
+func NewServer(name, version string) *Server {
+	return &Server{name: name, version: version, handlers: make(map[string]ToolHandler)}
+}
+
+// AddTool registers a tool.
+func (s *Server) AddTool(t Tool, h ToolHandler) {
+	s.tools = append(s.tools, t)
+	s.handlers[t.Name] = h
+}
+
+// SetResources registers the provider behind resources/list and
+// resources/read.
+func (s *Server) SetResources(p ResourceProvider) {
+	s.resources = p
+}
+
+// Handle processes one JSON-RPC message and returns the encoded response,
+// or nil for notifications.
+func (s *Server) Handle(msg []byte) []byte {
+	var req request
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return encode(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{codeParseError, "parse error: " + err.Error()}})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if req.isNotification() {
+			return nil
+		}
+		return encode(response{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{codeInvalidRequest, "invalid request"}})
+	}
+
+	result, err := s.dispatch(&req)
+	if req.isNotification() {
+		return nil
+	}
+	resp := response{JSONRPC: "2.0", ID: req.ID, Result: result}
+	if err != nil {
+		var re *rpcError
+		if !errors.As(err, &re) {
+			re = &rpcError{codeInternalError, err.Error()}
+		}
+		resp.Result, resp.Error = nil, re
+	}
+	return encode(resp)
+}
+
+func (s *Server) dispatch(req *request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &p)
+		version := ProtocolVersions[0]
+		if slices.Contains(ProtocolVersions, p.ProtocolVersion) {
+			version = p.ProtocolVersion
+		}
+		caps := map[string]any{"tools": map[string]any{}}
+		if s.resources != nil {
+			caps["resources"] = map[string]any{}
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    caps,
+			"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		tools := s.tools
+		if tools == nil {
+			tools = []Tool{}
+		}
+		return map[string]any{"tools": tools}, nil
+	case "tools/call":
+		return s.callTool(req.Params)
+	case "resources/list":
+		if s.resources == nil {
+			return nil, &rpcError{codeMethodNotFound, "resources are not supported"}
+		}
+		list, err := s.resources.ListResources()
+		if err != nil {
+			return nil, err
+		}
+		if list == nil {
+			list = []Resource{}
+		}
+		return map[string]any{"resources": list}, nil
+	case "resources/read":
+		if s.resources == nil {
+			return nil, &rpcError{codeMethodNotFound, "resources are not supported"}
+		}
+		var p struct {
+			URI string `json:"uri"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil || p.URI == "" {
+			return nil, &rpcError{codeInvalidParams, "uri is required"}
+		}
+		c, err := s.resources.ReadResource(p.URI)
+		if err != nil {
+			return nil, &rpcError{codeInvalidParams, err.Error()}
+		}
+		return map[string]any{"contents": []ResourceContents{c}}, nil
+	}
+	if strings.HasPrefix(req.Method, "notifications/") {
+		return nil, nil
+	}
+	return nil, &rpcError{codeMethodNotFound, "method not found: " + req.Method}
+}
+
+func (s *Server) callTool(params json.RawMessage) (any, error) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{codeInvalidParams, "invalid tools/call params"}
+	}
+	h, ok := s.handlers[p.Name]
+	if !ok {
+		return nil, &rpcError{codeInvalidParams, "unknown tool: " + p.Name}
+	}
+	if len(p.Arguments) == 0 {
+		p.Arguments = json.RawMessage("{}")
+	}
+	text, err := h(p.Arguments)
+	if err != nil {
+		return toolResult{Content: []textContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	return toolResult{Content: []textContent{{Type: "text", Text: text}}}, nil
+}
+
+func encode(resp response) []byte {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		b, _ = json.Marshal(response{JSONRPC: "2.0", ID: resp.ID, Error: &rpcError{codeInternalError, err.Error()}})
+	}
+	return b
+}
+
+// maxMessageSize bounds one newline-delimited message on stdio.
+const maxMessageSize = 16 << 20
+
+// ServeStdio reads newline-delimited messages from r and writes responses
+// to w until r is exhausted or ctx is done. Requests are answered in order.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for sc.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		out := s.Handle(line)
+		if out == nil {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", out); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
@@ -0,0 +1,177 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testServer() *Server {
+	s := NewServer("axon", "1.2.3")
+	s.AddTool(Tool{Name: "echo", Description: "Echo text", InputSchema: map[string]any{"type": "object"}},
+		func(args json.RawMessage) (string, error) {
+			var a struct{ Text string }
+			if err := json.Unmarshal(args, &a); err != nil {
+				return "", err
+			}
+			if a.Text == "" {
+				return "", errors.New("text is required")
+			}
+			return a.Text, nil
+		})
+	return s
+}
+
+func call(t *testing.T, s *Server, msg string) map[string]any {
+	t.Helper()
+	out := s.Handle([]byte(msg))
+	if out == nil {
+		t.Fatalf("no response to %s", msg)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(out, &m); err != nil {
+		t.Fatalf("bad response %s: %v", out, err)
+	}
+	return m
+}
+
+func TestHandle_Initialize(t *testing.T) {
+	s := testServer()
+	m := call(t, s, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`)
+	res := m["result"].(map[string]any)
+	if res["protocolVersion"] != "2024-11-05" {
+		t.Errorf("protocolVersion = %v, want the client's", res["protocolVersion"])
+	}
+	if info := res["serverInfo"].(map[string]any); info["name"] != "axon" || info["version"] != "1.2.3" {
+		t.Errorf("serverInfo = %v", info)
+	}
+	if _, ok := res["capabilities"].(map[string]any)["resources"]; ok {
+		t.Error("resources advertised without a provider")
+	}
+
+	m = call(t, s, `{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`)
+	if v := m["result"].(map[string]any)["protocolVersion"]; v != ProtocolVersions[0] {
+		t.Errorf("unknown version answered with %v", v)
+	}
+	if out := s.Handle([]byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)); out != nil {
+		t.Errorf("notification got a response: %s", out)
+	}
+}
+
+func TestHandle_Tools(t *testing.T) {
+	s := testServer()
+	m := call(t, s, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	tools := m["result"].(map[string]any)["tools"].([]any)
+	if len(tools) != 1 || tools[0].(map[string]any)["name"] != "echo" {
+		t.Errorf("tools = %v", tools)
+	}
+
+	m = call(t, s, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`)
+	res := m["result"].(map[string]any)
+	if res["isError"] != nil || res["content"].([]any)[0].(map[string]any)["text"] != "hi" {
+		t.Errorf("result = %v", res)
+	}
+
+	// Tool failures are results the model can read, not protocol errors.
+	m = call(t, s, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo"}}`)
+	if res := m["result"].(map[string]any); res["isError"] != true {
+		t.Errorf("failed call = %v", m)
+	}
+
+	m = call(t, s, `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"nope"}}`)
+	if e := m["error"].(map[string]any); e["code"] != float64(codeInvalidParams) {
+		t.Errorf("unknown tool error = %v", e)
+	}
+}
+
+func TestHandle_Errors(t *testing.T) {
+	s := testServer()
+	tests := map[string]int{
+		`{not json`: codeParseError,
+		`{"jsonrpc":"1.0","id":1,"method":"ping"}`:           codeInvalidRequest,
+		`{"jsonrpc":"2.0","id":1,"method":"nope"}`:           codeMethodNotFound,
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`: codeMethodNotFound,
+	}
+	for msg, code := range tests {
+		m := call(t, s, msg)
+		if e, ok := m["error"].(map[string]any); !ok || e["code"] != float64(code) {
+			t.Errorf("%s: response %v, want code %d", msg, m, code)
+		}
+	}
+}
+
+func TestServeStdio(t *testing.T) {
+	in := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}` + "\n\n" +
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}` + "\n" +
+		`{"jsonrpc":"2.0","id":"two","method":"tools/list"}` + "\n")
+	var out strings.Builder
+	if err := testServer().ServeStdio(context.Background(), in, &out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"id":1`) || !strings.Contains(lines[1], `"id":"two"`) {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestSSEHandler(t *testing.T) {
+	ts := httptest.NewServer(testServer().SSEHandler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/sse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	events := bufio.NewReader(resp.Body)
+	readEvent := func() (string, string) {
+		var event, data string
+		for {
+			line, err := events.ReadString('\n')
+			if err != nil {
+				t.Fatalf("reading stream: %v", err)
+			}
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case line == "" && event != "":
+				return event, data
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}
+
+	event, endpoint := readEvent()
+	if event != "endpoint" || !strings.HasPrefix(endpoint, "/message?sessionId=") {
+		t.Fatalf("first event = %s %s", event, endpoint)
+	}
+	post, err := http.Post(ts.URL+endpoint, "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":7,"method":"ping"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	post.Body.Close()
+	if post.StatusCode != http.StatusAccepted {
+		t.Fatalf("POST status = %d", post.StatusCode)
+	}
+	if event, data := readEvent(); event != "message" || !strings.Contains(data, `"id":7`) {
+		t.Errorf("response event = %s %s", event, data)
+	}
+
+	if r, err := http.Post(ts.URL+"/message?sessionId=nope", "application/json", strings.NewReader("{}")); err != nil || r.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown session: %v %v", r, err)
+	}
+
+	// A rebinding page would arrive with its own host name.
+	req, _ := http.NewRequest("GET", ts.URL+"/sse", nil)
+	req.Host = "evil.example"
+	if r, err := http.DefaultClient.Do(req); err != nil || r.StatusCode != http.StatusForbidden {
+		t.Errorf("foreign host: %v %v", r, err)
+	}
+}
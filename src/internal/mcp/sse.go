@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// sseKeepAlive is how often an idle event stream gets a comment line, so
+// proxies and clients do not time it out.
+const sseKeepAlive = 30 * time.Second
+
+// SSEHandler serves the HTTP+SSE transport: a client opens an event stream
+// with GET /sse, is told the URL to POST its messages to, and receives the
+// responses as "message" events on the stream.
+//
+// Only requests addressed to a loopback host are accepted, so a web page
+// cannot reach the server through DNS rebinding.
+func (s *Server) SSEHandler() http.Handler {
+	h := &sseHandler{server: s, sessions: make(map[string]chan []byte)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sse", h.stream)
+	mux.HandleFunc("POST /message", h.message)
+	return localOnly(mux)
+}
+
+type sseHandler struct {
+	server *Server
+
+	mu       sync.Mutex
+	sessions map[string]chan []byte
+}
+
+func (h *sseHandler) stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	id := newSessionID()
+	out := make(chan []byte, 16)
+	h.mu.Lock()
+	h.sessions[id] = out
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.sessions, id)
+		h.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	fmt.Fprintf(w, "event: endpoint\ndata: /message?sessionId=%s\n\n", url.QueryEscape(id))
+	flusher.Flush()
+
+	tick := time.NewTicker(sseKeepAlive)
+	defer tick.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg := <-out:
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", msg)
+		case <-tick.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		flusher.Flush()
+	}
+}
+
+func (h *sseHandler) message(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	out, ok := h.sessions[r.URL.Query().Get("sessionId")]
+	h.mu.Unlock()
+	if !ok {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if resp := h.server.Handle(body); resp != nil {
+		select {
+		case out <- resp:
+		case <-r.Context().Done():
+			return
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// localOnly rejects requests whose Host header is not a loopback name.
+func localOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if host != "localhost" {
+			if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+				http.Error(w, "forbidden host", http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
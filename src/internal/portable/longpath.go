@@ -0,0 +1,58 @@
+package portable
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"unicode/utf16"
+)
+
+// KindLongPath marks a path that exceeds the Windows MAX_PATH limit once it
+// is placed under a destination or backup directory.
+const KindLongPath = "long-path"
+
+// MaxPath is the Windows path limit (MAX_PATH), including the drive and the
+// terminating NUL. Tools that do not opt in to long paths fail beyond it.
+const MaxPath = 260
+
+// pathLen is the length of p as Windows counts it, in UTF-16 code units.
+func pathLen(p string) int {
+	return len(utf16.Encode([]rune(p)))
+}
+
+// LongPaths walks root and reports paths that reach MaxPath when root is
+// replaced by a directory prefix characters long. Only the shallowest
+// offending path of each subtree is reported. .git directories are skipped.
+func LongPaths(root string, prefix int) ([]Hazard, error) {
+	var out []Hazard
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		// prefix + separator + rel + NUL
+		n := prefix + 1 + pathLen(rel) + 1
+		if n <= MaxPath {
+			return nil
+		}
+		out = append(out, Hazard{
+			Path:   filepath.ToSlash(rel),
+			Kind:   KindLongPath,
+			Detail: fmt.Sprintf("%d characters under a %d-character destination, over the Windows limit of %d", n-1, prefix, MaxPath-1),
+		})
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return out, err
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package portable
+
+// LongPath returns p unchanged: only Windows limits path length this way.
+func LongPath(p string) string {
+	return p
+}
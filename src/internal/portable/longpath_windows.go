@@ -0,0 +1,31 @@
+//go:build windows
+
+package portable
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// longPathThreshold is where Windows starts rejecting paths: directories
+// must leave room for an 8.3 file name below MAX_PATH.
+const longPathThreshold = MaxPath - 12
+
+// LongPath returns p in the \\?\ extended-length form when it is too long
+// for the Win32 path limit, so file operations on deep skill trees succeed
+// without the LongPathsEnabled policy. The os package only does this for
+// paths that are already clean and absolute; LongPath also accepts relative
+// paths and ones with . or .. elements.
+func LongPath(p string) string {
+	if len(p) < longPathThreshold || strings.HasPrefix(p, `\\?\`) {
+		return p
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return p
+	}
+	if strings.HasPrefix(abs, `\\`) {
+		return `\\?\UNC\` + abs[2:]
+	}
+	return `\\?\` + abs
+}
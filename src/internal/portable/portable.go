@@ -0,0 +1,117 @@
+// Package portable finds Hub paths that cannot be checked out on every
+// platform: names that collide on case-insensitive filesystems, Windows
+// reserved device names, characters Windows rejects, and paths too long for
+// Windows.
+package portable
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Hazard kinds.
+const (
+	KindCaseCollision = "case-collision"
+	KindReservedName  = "reserved-name"
+	KindInvalidChar   = "invalid-char"
+	KindTrailingChar  = "trailing-dot-or-space"
+)
+
+// Hazard is one path that will break on some platform.
+type Hazard struct {
+	Path   string // slash-separated, relative to the scanned root
+	Kind   string
+	Detail string
+}
+
+func (h Hazard) String() string {
+	return fmt.Sprintf("%s: %s", h.Path, h.Detail)
+}
+
+// reservedNames are Windows device names, reserved with any extension.
+var reservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// invalidChars are rejected by Windows in file names.
+const invalidChars = `<>:"\|?*`
+
+// CheckName returns the hazards of a single path component.
+func CheckName(name string) []Hazard {
+	var out []Hazard
+	base := strings.ToUpper(name)
+	if i := strings.IndexByte(base, '.'); i >= 0 {
+		base = base[:i]
+	}
+	if reservedNames[strings.TrimRight(base, " ")] {
+		out = append(out, Hazard{Kind: KindReservedName, Detail: fmt.Sprintf("%q is a reserved device name on Windows", name)})
+	}
+	for _, r := range name {
+		if r < 0x20 || strings.ContainsRune(invalidChars, r) {
+			out = append(out, Hazard{Kind: KindInvalidChar, Detail: fmt.Sprintf("%q contains %q, which Windows does not allow", name, r)})
+			break
+		}
+	}
+	if name != "." && name != ".." && (strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ")) {
+		out = append(out, Hazard{Kind: KindTrailingChar, Detail: fmt.Sprintf("%q ends with a dot or space, which Windows strips", name)})
+	}
+	return out
+}
+
+// Scan walks root and reports every hazard, sorted by path. .git
+// directories are skipped.
+func Scan(root string) ([]Hazard, error) {
+	var out []Hazard
+	// Per directory: lower-cased name → first name seen.
+	seen := make(map[string]map[string]string)
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		for _, h := range CheckName(d.Name()) {
+			h.Path = rel
+			out = append(out, h)
+		}
+
+		dir := path.Dir(rel)
+		names := seen[dir]
+		if names == nil {
+			names = make(map[string]string)
+			seen[dir] = names
+		}
+		lower := strings.ToLower(d.Name())
+		if first, ok := names[lower]; ok {
+			out = append(out, Hazard{
+				Path:   rel,
+				Kind:   KindCaseCollision,
+				Detail: fmt.Sprintf("differs from %q only by case; one overwrites the other on macOS/Windows", first),
+			})
+		} else {
+			names[lower] = d.Name()
+		}
+		return nil
+	})
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, err
+}
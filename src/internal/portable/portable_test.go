@@ -0,0 +1,100 @@
+package portable
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCheckName(t *testing.T) {
+	cases := map[string]string{
+		"SKILL.md":     "",
+		"con":          KindReservedName,
+		"aux.md":       KindReservedName,
+		"Com3.txt":     KindReservedName,
+		"console.md":   "",
+		"what?.md":     KindInvalidChar,
+		"a:b":          KindInvalidChar,
+		"notes.":       KindTrailingChar,
+		"trailing ":    KindTrailingChar,
+		".hidden-file": "",
+	}
+	for name, want := range cases {
+		got := CheckName(name)
+		switch {
+		case want == "" && len(got) != 0:
+			t.Errorf("CheckName(%q) = %v, want none", name, got)
+		case want != "" && (len(got) == 0 || got[0].Kind != want):
+			t.Errorf("CheckName(%q) = %v, want %s", name, got, want)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("needs a case-sensitive filesystem that allows these names")
+	}
+	root := t.TempDir()
+	for _, p := range []string{
+		"skills/Foo/SKILL.md",
+		"skills/foo/SKILL.md",
+		"skills/bar/aux.md",
+		"skills/bar/ok.md",
+		".git/CON",
+	} {
+		full := filepath.Join(root, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hazards, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(hazards) != 2 {
+		t.Fatalf("hazards = %v, want 2", hazards)
+	}
+	if hazards[0].Path != "skills/bar/aux.md" || hazards[0].Kind != KindReservedName {
+		t.Errorf("hazards[0] = %+v", hazards[0])
+	}
+	if hazards[1].Kind != KindCaseCollision {
+		t.Errorf("hazards[1] = %+v", hazards[1])
+	}
+}
+
+func TestLongPaths(t *testing.T) {
+	root := t.TempDir()
+	deep := filepath.Join("pdf", strings.Repeat("d", 100), strings.Repeat("e", 100))
+	for _, p := range []string{
+		filepath.Join("pdf", "SKILL.md"),
+		filepath.Join(deep, "a.md"),
+		filepath.Join(deep, "b.md"),
+	} {
+		full := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// pdf/ddd…/eee… is 205 characters.
+	if hazards, err := LongPaths(root, 40); err != nil || len(hazards) != 0 {
+		t.Fatalf("LongPaths(40) = %v, %v; want none", hazards, err)
+	}
+	hazards, err := LongPaths(root, 60)
+	if err != nil {
+		t.Fatalf("LongPaths: %v", err)
+	}
+	// Only the shallowest offending directory is reported, not its files.
+	if len(hazards) != 1 || hazards[0].Path != filepath.ToSlash(deep) || hazards[0].Kind != KindLongPath {
+		t.Fatalf("LongPaths(60) = %v, want just %s", hazards, filepath.ToSlash(deep))
+	}
+}
@@ -0,0 +1,149 @@
+// Package registry reads skill registries: JSON indexes that list
+// installable skills with their description, version, and the Git
+// repo/subdir they live in. 'axon install' turns an entry into a vendor
+// entry, so installing and updating reuse the vendor machinery.
+//
+// An index looks like:
+//
+//	{"skills": [{"name": "pdf", "description": "Fill PDF forms",
+//	  "version": "1.2.0", "repo": "https://github.com/acme/skills.git",
+//	  "subdir": "skills/pdf", "ref": "main", "tags": ["documents"]}]}
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxIndexSize caps the size of an index read from a registry.
+const MaxIndexSize = 16 << 20
+
+// Index is a parsed registry index.
+type Index struct {
+	Skills []Skill `json:"skills"`
+}
+
+// Skill is one installable skill.
+type Skill struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Repo        string   `json:"repo"`
+	Subdir      string   `json:"subdir"`
+	Ref         string   `json:"ref,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// Parse decodes and validates an index. Every skill needs a name that is a
+// single path segment, a repo, and a subdir; names must be unique.
+func Parse(data []byte) (*Index, error) {
+	var ix Index
+	if err := json.Unmarshal(data, &ix); err != nil {
+		return nil, fmt.Errorf("invalid registry index: %w", err)
+	}
+	seen := make(map[string]bool, len(ix.Skills))
+	for i, s := range ix.Skills {
+		switch {
+		case s.Name == "":
+			return nil, fmt.Errorf("invalid registry index: skills[%d]: 'name' is required", i)
+		case s.Name == "." || s.Name == ".." || strings.ContainsAny(s.Name, `/\:`):
+			return nil, fmt.Errorf("invalid registry index: skill name %q is not a plain name", s.Name)
+		case s.Repo == "" || s.Subdir == "":
+			return nil, fmt.Errorf("invalid registry index: skill %q needs 'repo' and 'subdir'", s.Name)
+		case seen[s.Name]:
+			return nil, fmt.Errorf("invalid registry index: duplicate skill %q", s.Name)
+		}
+		seen[s.Name] = true
+	}
+	sort.Slice(ix.Skills, func(i, j int) bool { return ix.Skills[i].Name < ix.Skills[j].Name })
+	return &ix, nil
+}
+
+// Find returns the skill called name.
+func (ix *Index) Find(name string) (Skill, bool) {
+	for _, s := range ix.Skills {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Skill{}, false
+}
+
+// Search returns the skills whose name, description, or tags contain query,
+// ignoring case. An empty query matches every skill.
+func (ix *Index) Search(query string) []Skill {
+	q := strings.ToLower(strings.TrimSpace(query))
+	var out []Skill
+	for _, s := range ix.Skills {
+		text := strings.ToLower(s.Name + "\n" + s.Description + "\n" + strings.Join(s.Tags, "\n"))
+		if strings.Contains(text, q) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Fetch reads the index at url, an http(s) URL, a file:// URL, or a local
+// path, and saves a copy to cacheFile for LoadCached.
+func Fetch(ctx context.Context, url, cacheFile string) (*Index, error) {
+	data, err := read(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	ix, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if cacheFile != "" {
+		if err := os.MkdirAll(filepath.Dir(cacheFile), 0o755); err == nil {
+			_ = os.WriteFile(cacheFile, data, 0o644)
+		}
+	}
+	return ix, nil
+}
+
+// LoadCached returns the index last saved to cacheFile by Fetch.
+func LoadCached(cacheFile string) (*Index, error) {
+	data, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+func read(ctx context.Context, url string) ([]byte, error) {
+	lower := strings.ToLower(url)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		f, err := os.Open(strings.TrimPrefix(url, "file://"))
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return io.ReadAll(io.LimitReader(f, MaxIndexSize))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "axon-cli")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("cannot fetch %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, MaxIndexSize))
+}
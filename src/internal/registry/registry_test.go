@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const index = `{"skills": [
+  {"name": "pdf", "description": "Fill PDF forms", "version": "1.2.0", "repo": "https://example.com/skills.git", "subdir": "skills/pdf", "tags": ["documents"]},
+  {"name": "git-release", "description": "Cut releases", "repo": "https://example.com/skills.git", "subdir": "skills/git-release"}
+]}`
+
+func TestParseAndSearch(t *testing.T) {
+	ix, err := Parse([]byte(index))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if ix.Skills[0].Name != "git-release" {
+		t.Errorf("skills should be sorted by name, got %s first", ix.Skills[0].Name)
+	}
+	if s, ok := ix.Find("pdf"); !ok || s.Version != "1.2.0" {
+		t.Errorf("Find(pdf) = %+v, %v", s, ok)
+	}
+	if got := ix.Search("DOCUMENTS"); len(got) != 1 || got[0].Name != "pdf" {
+		t.Errorf("Search by tag = %+v", got)
+	}
+	if got := ix.Search(""); len(got) != 2 {
+		t.Errorf("empty query should match everything, got %d", len(got))
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"not json":  `skills:`,
+		"no repo":   `{"skills": [{"name": "a", "subdir": "a"}]}`,
+		"path name": `{"skills": [{"name": "../a", "repo": "r", "subdir": "a"}]}`,
+		"duplicate": `{"skills": [{"name": "a", "repo": "r", "subdir": "a"}, {"name": "a", "repo": "r", "subdir": "b"}]}`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: Parse should fail", name)
+		}
+	}
+}
+
+func TestFetch_LocalAndCache(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "index.json")
+	if err := os.WriteFile(src, []byte(index), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cache := filepath.Join(dir, "cache", "community.json")
+	if _, err := Fetch(context.Background(), "file://"+src, cache); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if err := os.Remove(src); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Fetch(context.Background(), src, cache); err == nil {
+		t.Error("Fetch of a missing index should fail")
+	}
+	ix, err := LoadCached(cache)
+	if err != nil || len(ix.Skills) != 2 {
+		t.Errorf("LoadCached = %+v, %v", ix, err)
+	}
+}
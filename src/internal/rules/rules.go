@@ -0,0 +1,179 @@
+// Package rules converts the canonical rule files of a Hub rules/ directory
+// into the formats individual tools expect: Cursor .mdc rules, Windsurf
+// rules, and a single Claude memory file.
+//
+// A canonical rule is a Markdown file with optional frontmatter:
+//
+//	---
+//	description: Use pnpm, never npm
+//	globs: ["**/*.ts", "**/*.tsx"]
+//	always_apply: false
+//	---
+//	Rule text...
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/frontmatter"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats 'axon link' can render rules into.
+const (
+	FormatCursor   = "cursor"   // one <name>.mdc per rule
+	FormatWindsurf = "windsurf" // one <name>.md per rule with a trigger
+	FormatClaude   = "claude"   // every rule in one memory file
+)
+
+// Formats lists the supported formats.
+var Formats = []string{FormatCursor, FormatWindsurf, FormatClaude}
+
+// SingleFile reports whether format renders all rules into one file, so the
+// destination is a file rather than a directory.
+func SingleFile(format string) bool {
+	return format == FormatClaude
+}
+
+// Rule is one canonical rule.
+type Rule struct {
+	Name        string // file name without .md
+	Description string
+	Globs       []string
+	AlwaysApply bool
+	Body        string
+}
+
+// globList accepts globs as a YAML list or a comma-separated string.
+type globList []string
+
+func (g *globList) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		*g = splitGlobs(n.Value)
+		return nil
+	}
+	var list []string
+	if err := n.Decode(&list); err != nil {
+		return err
+	}
+	*g = list
+	return nil
+}
+
+func splitGlobs(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// Parse reads the rule in file name (e.g. "pnpm.md") from data. A file
+// without frontmatter is a rule that always applies.
+func Parse(name string, data []byte) (Rule, error) {
+	r := Rule{Name: strings.TrimSuffix(name, filepath.Ext(name))}
+	doc, err := frontmatter.Parse(data)
+	if errors.Is(err, frontmatter.ErrNoFrontmatter) {
+		r.AlwaysApply = true
+		r.Body = string(data)
+		return r, nil
+	}
+	if err != nil {
+		return r, fmt.Errorf("%s: %w", name, err)
+	}
+	var meta struct {
+		Description string   `yaml:"description"`
+		Globs       globList `yaml:"globs"`
+		AlwaysApply bool     `yaml:"always_apply"`
+	}
+	if err := doc.Decode(&meta); err != nil {
+		return r, fmt.Errorf("%s: %w", name, err)
+	}
+	r.Description, r.Globs, r.AlwaysApply = meta.Description, meta.Globs, meta.AlwaysApply
+	r.Body = string(doc.Body())
+	return r, nil
+}
+
+// Render converts rules into format. It returns file name → content; a
+// single-file format returns one entry under the empty name.
+func Render(format string, rules []Rule) (map[string][]byte, error) {
+	sorted := append([]Rule(nil), rules...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	out := make(map[string][]byte, len(sorted))
+	switch format {
+	case FormatCursor:
+		for _, r := range sorted {
+			out[r.Name+".mdc"] = withFrontmatter([][2]string{
+				{"description", r.Description},
+				{"globs", strings.Join(r.Globs, ",")},
+				{"alwaysApply", fmt.Sprint(r.AlwaysApply)},
+			}, r.Body)
+		}
+	case FormatWindsurf:
+		for _, r := range sorted {
+			fields := [][2]string{{"trigger", windsurfTrigger(r)}}
+			if r.Description != "" {
+				fields = append(fields, [2]string{"description", r.Description})
+			}
+			if len(r.Globs) > 0 && !r.AlwaysApply {
+				fields = append(fields, [2]string{"globs", strings.Join(r.Globs, ",")})
+			}
+			out[r.Name+".md"] = withFrontmatter(fields, r.Body)
+		}
+	case FormatClaude:
+		var buf bytes.Buffer
+		buf.WriteString("# Rules\n")
+		for _, r := range sorted {
+			fmt.Fprintf(&buf, "\n## %s\n\n", r.Name)
+			if r.Description != "" {
+				fmt.Fprintf(&buf, "%s\n\n", r.Description)
+			}
+			if len(r.Globs) > 0 && !r.AlwaysApply {
+				fmt.Fprintf(&buf, "Applies to files matching: %s\n\n", strings.Join(r.Globs, ", "))
+			}
+			buf.WriteString(strings.TrimSpace(r.Body))
+			buf.WriteString("\n")
+		}
+		out[""] = buf.Bytes()
+	default:
+		return nil, fmt.Errorf("unknown rules format %q (want %s)", format, strings.Join(Formats, ", "))
+	}
+	return out, nil
+}
+
+// windsurfTrigger maps a rule to Windsurf's activation modes.
+func windsurfTrigger(r Rule) string {
+	switch {
+	case r.AlwaysApply:
+		return "always_on"
+	case len(r.Globs) > 0:
+		return "glob"
+	case r.Description != "":
+		return "model_decision"
+	}
+	return "manual"
+}
+
+// withFrontmatter writes fields as "key: value" lines, unquoted as Cursor
+// and Windsurf expect, followed by body.
+func withFrontmatter(fields [][2]string, body string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	for _, f := range fields {
+		if f[1] == "" {
+			fmt.Fprintf(&buf, "%s:\n", f[0])
+			continue
+		}
+		fmt.Fprintf(&buf, "%s: %s\n", f[0], f[1])
+	}
+	buf.WriteString("---\n")
+	buf.WriteString(body)
+	return buf.Bytes()
+}
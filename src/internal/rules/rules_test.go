@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+)
+
+const pnpmRule = `---
+description: Use pnpm, never npm
+globs: "**/*.ts, **/*.tsx"
+---
+Always run pnpm.
+`
+
+func TestParse(t *testing.T) {
+	r, err := Parse("pnpm.md", []byte(pnpmRule))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Name != "pnpm" || r.Description != "Use pnpm, never npm" || r.AlwaysApply {
+		t.Errorf("unexpected rule: %+v", r)
+	}
+	if strings.Join(r.Globs, "|") != "**/*.ts|**/*.tsx" {
+		t.Errorf("globs = %v", r.Globs)
+	}
+	if r.Body != "Always run pnpm.\n" {
+		t.Errorf("body = %q", r.Body)
+	}
+
+	plain, err := Parse("style.md", []byte("Be terse.\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plain.AlwaysApply || plain.Body != "Be terse.\n" {
+		t.Errorf("a rule without frontmatter should always apply: %+v", plain)
+	}
+}
+
+func TestRender(t *testing.T) {
+	pnpm, _ := Parse("pnpm.md", []byte(pnpmRule))
+	style, _ := Parse("style.md", []byte("Be terse.\n"))
+	rules := []Rule{style, pnpm}
+
+	cursor, err := Render(FormatCursor, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(cursor["pnpm.mdc"]); !strings.Contains(got, "globs: **/*.ts,**/*.tsx\n") || !strings.Contains(got, "alwaysApply: false\n") {
+		t.Errorf("cursor rule:\n%s", got)
+	}
+
+	windsurf, err := Render(FormatWindsurf, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(windsurf["pnpm.md"]); !strings.Contains(got, "trigger: glob\n") {
+		t.Errorf("windsurf rule:\n%s", got)
+	}
+	if got := string(windsurf["style.md"]); !strings.Contains(got, "trigger: always_on\n") {
+		t.Errorf("windsurf rule:\n%s", got)
+	}
+
+	claude, err := Render(FormatClaude, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(claude[""])
+	if strings.Index(got, "## pnpm") > strings.Index(got, "## style") || !strings.Contains(got, "Applies to files matching: **/*.ts, **/*.tsx") {
+		t.Errorf("claude memory:\n%s", got)
+	}
+
+	if _, err := Render("vim", rules); err == nil {
+		t.Error("unknown format should fail")
+	}
+}
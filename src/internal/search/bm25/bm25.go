@@ -0,0 +1,390 @@
+// Package bm25 maintains a persisted inverted index over Hub documents and
+// ranks keyword queries with BM25. The index is refreshed incrementally: only
+// files whose size or modification time changed are read again.
+package bm25
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/kamusis/axon-cli/internal/search"
+)
+
+// FileName is the index file inside the user search directory.
+const FileName = "keyword.gob"
+
+// formatVersion changes whenever the on-disk layout or the analyzer does;
+// an index with another version is rebuilt from scratch.
+const formatVersion = 3
+
+// BM25 parameters.
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Field weights applied to term frequencies (a simplified BM25F): a term in
+// the name counts more than one in the description.
+const (
+	weightName        = 3
+	weightKeywords    = 2
+	weightDescription = 1
+	weightLocalized   = 1
+	weightID          = 1
+)
+
+// How a query token matched an indexed term, as a factor of its score.
+const (
+	matchWord      = 1.0
+	matchPrefix    = 0.7
+	matchSubstring = 0.4
+)
+
+// Doc is one indexed document. A removed document leaves a tombstone
+// (Removed set) until the index is compacted.
+type Doc struct {
+	search.SkillDoc
+	File    string
+	Size    int64
+	ModTime int64
+	Len     float64
+	Terms   map[string]float64 // weighted term frequency
+	Removed bool
+}
+
+// Posting records a term's weighted frequency in one document.
+type Posting struct {
+	Doc int
+	TF  float64
+}
+
+// Index is the persisted keyword index.
+type Index struct {
+	Version  int
+	Source   string // repos and roots the index covers
+	Docs     []Doc
+	Postings map[string][]Posting
+	TotalLen float64
+	Live     int
+
+	byFile map[string]int
+	vocab  []string // sorted terms, built lazily for prefix matching
+}
+
+// Source describes what an index covers; an index built for a different
+// source is rebuilt.
+func Source(repos []search.RepoRoot, roots []string) string {
+	var parts []string
+	for _, r := range repos {
+		parts = append(parts, r.Name+"="+r.Path)
+	}
+	return strings.Join(parts, ",") + "|" + strings.Join(roots, ",")
+}
+
+// New returns an empty index for source.
+func New(source string) *Index {
+	return &Index{Version: formatVersion, Source: source, Postings: map[string][]Posting{}}
+}
+
+// Load reads the index in dir. A missing, unreadable, or outdated index
+// yields an empty one for source, so callers can always Update it.
+func Load(dir, source string) *Index {
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		return New(source)
+	}
+	var idx Index
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&idx); err != nil || idx.Version != formatVersion || idx.Source != source {
+		return New(source)
+	}
+	if idx.Postings == nil {
+		idx.Postings = map[string][]Posting{}
+	}
+	return &idx
+}
+
+// Save writes the index to dir atomically.
+func (idx *Index) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("cannot create %s: %w", dir, err)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(idx); err != nil {
+		return fmt.Errorf("cannot encode keyword index: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".keyword-*.gob")
+	if err != nil {
+		return fmt.Errorf("cannot write keyword index: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot write keyword index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, FileName))
+}
+
+// Update brings the index in line with the documents in repos (highest
+// priority first; a document ID is taken from the first repo providing it).
+// It reports how many documents were added, changed, or removed.
+func (idx *Index) Update(repos []search.RepoRoot, roots []string) (int, error) {
+	idx.ensureByFile()
+
+	type wanted struct {
+		file search.DocumentFile
+		repo string
+	}
+	var want []wanted
+	seenID := make(map[string]bool)
+	for _, r := range repos {
+		files, err := search.ListDocumentFiles(r.Path, roots)
+		if err != nil {
+			return 0, fmt.Errorf("repo %s: %w", r.Name, err)
+		}
+		for _, f := range files {
+			if seenID[f.ID] {
+				continue
+			}
+			seenID[f.ID] = true
+			want = append(want, wanted{f, r.Name})
+		}
+	}
+	multi := len(repos) > 1
+
+	changes := 0
+	keep := make(map[string]bool, len(want))
+	var (
+		stale     []search.DocumentFile
+		staleRepo []string
+	)
+	for _, w := range want {
+		keep[w.file.File] = true
+		repo := ""
+		if multi {
+			repo = w.repo
+		}
+		if i, ok := idx.byFile[w.file.File]; ok {
+			d := idx.Docs[i]
+			if d.Size == w.file.Size && d.ModTime == w.file.ModTime && d.ID == w.file.ID && d.Repo == repo {
+				continue
+			}
+			idx.remove(i)
+		}
+		stale = append(stale, w.file)
+		staleRepo = append(staleRepo, repo)
+	}
+	// Re-read changed documents concurrently, then add them in order so the
+	// index does not depend on scheduling.
+	docs, err := search.ReadDocuments(stale)
+	if err != nil {
+		return changes, err
+	}
+	for i, doc := range docs {
+		doc.Repo = staleRepo[i]
+		idx.add(doc, stale[i])
+		changes++
+	}
+	for file, i := range idx.byFile {
+		if !keep[file] {
+			idx.remove(i)
+			changes++
+		}
+	}
+
+	if len(idx.Docs) > 2*idx.Live+16 {
+		idx.compact()
+	}
+	if changes > 0 {
+		idx.vocab = nil
+	}
+	return changes, nil
+}
+
+func (idx *Index) ensureByFile() {
+	if idx.byFile != nil {
+		return
+	}
+	idx.byFile = make(map[string]int, len(idx.Docs))
+	for i, d := range idx.Docs {
+		if !d.Removed {
+			idx.byFile[d.File] = i
+		}
+	}
+}
+
+func (idx *Index) add(doc search.SkillDoc, f search.DocumentFile) {
+	terms := map[string]float64{}
+	var length float64
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{
+		{doc.Name, weightName},
+		{doc.Keywords, weightKeywords},
+		{doc.Description, weightDescription},
+		{doc.LocalizedText(), weightLocalized},
+		{doc.ID, weightID},
+	} {
+		for _, t := range Terms(field.text) {
+			terms[t] += field.weight
+			length += field.weight
+		}
+	}
+
+	doc.Body = "" // keep keyword.gob small
+	i := len(idx.Docs)
+	idx.Docs = append(idx.Docs, Doc{SkillDoc: doc, File: f.File, Size: f.Size, ModTime: f.ModTime, Len: length, Terms: terms})
+	for t, tf := range terms {
+		idx.Postings[t] = append(idx.Postings[t], Posting{Doc: i, TF: tf})
+	}
+	idx.byFile[f.File] = i
+	idx.TotalLen += length
+	idx.Live++
+}
+
+func (idx *Index) remove(i int) {
+	d := &idx.Docs[i]
+	for t := range d.Terms {
+		list := idx.Postings[t]
+		for j, p := range list {
+			if p.Doc == i {
+				list = append(list[:j], list[j+1:]...)
+				break
+			}
+		}
+		if len(list) == 0 {
+			delete(idx.Postings, t)
+		} else {
+			idx.Postings[t] = list
+		}
+	}
+	delete(idx.byFile, d.File)
+	idx.TotalLen -= d.Len
+	idx.Live--
+	*d = Doc{Removed: true}
+}
+
+// compact drops tombstones and renumbers documents.
+func (idx *Index) compact() {
+	docs := make([]Doc, 0, idx.Live)
+	for _, d := range idx.Docs {
+		if !d.Removed {
+			docs = append(docs, d)
+		}
+	}
+	idx.Docs = docs
+	idx.Postings = make(map[string][]Posting)
+	idx.byFile = make(map[string]int, len(docs))
+	for i, d := range docs {
+		for t, tf := range d.Terms {
+			idx.Postings[t] = append(idx.Postings[t], Posting{Doc: i, TF: tf})
+		}
+		idx.byFile[d.File] = i
+	}
+}
+
+// Search ranks documents containing every query token, as a whole term, a
+// term prefix, or a substring of a term, by BM25 plus the exact-name and
+// phrase bonuses of search.KeywordSearch. Ties are broken by ID.
+func (idx *Index) Search(query string, limit int) []search.SearchResult {
+	return idx.SearchWhere(query, limit, search.Filter{})
+}
+
+// SearchWhere is Search over the documents that pass filter.
+func (idx *Index) SearchWhere(query string, limit int, filter search.Filter) []search.SearchResult {
+	tokens := Terms(query)
+	if len(tokens) == 0 || idx.Live == 0 {
+		return []search.SearchResult{}
+	}
+	avgLen := idx.TotalLen / float64(idx.Live)
+	n := float64(idx.Live)
+
+	var scores map[int]float64
+	for _, tok := range tokens {
+		tokScores := map[int]float64{}
+		for _, m := range idx.expand(tok) {
+			list := idx.Postings[m.term]
+			df := float64(len(list))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			for _, p := range list {
+				d := idx.Docs[p.Doc]
+				s := m.factor * idf * p.TF * (k1 + 1) / (p.TF + k1*(1-b+b*d.Len/avgLen))
+				if s > tokScores[p.Doc] {
+					tokScores[p.Doc] = s
+				}
+			}
+		}
+		if scores == nil {
+			scores = tokScores
+			continue
+		}
+		// AND semantics: keep documents matching every token.
+		for doc, s := range scores {
+			if ts, ok := tokScores[doc]; ok {
+				scores[doc] = s + ts
+			} else {
+				delete(scores, doc)
+			}
+		}
+	}
+
+	out := make([]search.SearchResult, 0, len(scores))
+	for doc, s := range scores {
+		d := idx.Docs[doc].SkillDoc
+		if !filter.Match(d) {
+			continue
+		}
+		out = append(out, search.SearchResult{Skill: d, Score: s + search.KeywordBonus(d, query), Why: "keyword"})
+	}
+	search.SortResults(out)
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+type termMatch struct {
+	term   string
+	factor float64
+}
+
+// expand returns the indexed terms tok matches with their match factor.
+func (idx *Index) expand(tok string) []termMatch {
+	if idx.vocab == nil {
+		idx.vocab = make([]string, 0, len(idx.Postings))
+		for t := range idx.Postings {
+			idx.vocab = append(idx.vocab, t)
+		}
+		sort.Strings(idx.vocab)
+	}
+	var out []termMatch
+	for _, t := range idx.vocab {
+		switch {
+		case t == tok:
+			out = append(out, termMatch{t, matchWord})
+		case strings.HasPrefix(t, tok):
+			out = append(out, termMatch{t, matchPrefix})
+		case strings.Contains(t, tok):
+			out = append(out, termMatch{t, matchSubstring})
+		}
+	}
+	return out
+}
+
+// Terms splits text into lower-case terms at anything that is not a letter
+// or digit.
+func Terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
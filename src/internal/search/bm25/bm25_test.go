@@ -0,0 +1,131 @@
+package bm25
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/search"
+)
+
+func writeDoc(t testing.TB, repo, rel, content string) {
+	t.Helper()
+	p := filepath.Join(repo, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func ids(results []search.SearchResult) []string {
+	out := make([]string, len(results))
+	for i, r := range results {
+		out[i] = r.Skill.ID
+	}
+	return out
+}
+
+func TestIndex_UpdateIncrementallyAndSearch(t *testing.T) {
+	repo := t.TempDir()
+	writeDoc(t, repo, "skills/release/SKILL.md", "---\nname: release\ndescription: Cut a git release\n---\n")
+	writeDoc(t, repo, "skills/notes/SKILL.md", "---\nname: notes\ndescription: Write release notes from git history\n---\n")
+	writeDoc(t, repo, "workflows/deploy.md", "# Deploy\n\nShip the service.\n")
+	repos := []search.RepoRoot{{Name: "hub", Path: repo}}
+	roots := []string{"skills", "workflows"}
+
+	idx := New(Source(repos, roots))
+	n, err := idx.Update(repos, roots)
+	if err != nil || n != 3 {
+		t.Fatalf("first Update = %d, %v; want 3", n, err)
+	}
+	if got := ids(idx.Search("release", 0)); len(got) != 2 || got[0] != "release" {
+		t.Errorf("Search(release) = %v, want release first", got)
+	}
+	if got := ids(idx.Search("rel git", 0)); len(got) != 2 {
+		t.Errorf("prefix search = %v", got)
+	}
+	if got := idx.Search("release deploy", 0); len(got) != 0 {
+		t.Errorf("AND search = %v, want none", ids(got))
+	}
+
+	dir := t.TempDir()
+	if err := idx.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	idx = Load(dir, Source(repos, roots))
+	if n, _ := idx.Update(repos, roots); n != 0 {
+		t.Errorf("unchanged Update = %d, want 0", n)
+	}
+
+	// Edit one file, delete another.
+	writeDoc(t, repo, "workflows/deploy.md", "# Deploy\n\nShip the release to production.\n")
+	future := time.Now().Add(time.Minute)
+	_ = os.Chtimes(filepath.Join(repo, "workflows", "deploy.md"), future, future)
+	if err := os.RemoveAll(filepath.Join(repo, "skills", "notes")); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := idx.Update(repos, roots); err != nil || n != 2 {
+		t.Fatalf("Update after edits = %d, %v; want 2", n, err)
+	}
+	if got := ids(idx.Search("release", 0)); len(got) != 2 || got[0] != "release" || got[1] != "workflows:deploy" {
+		t.Errorf("Search after edits = %v", got)
+	}
+
+	if Load(dir, "other").Live != 0 {
+		t.Error("index for another source should be discarded")
+	}
+}
+
+func TestIndex_CompactKeepsResults(t *testing.T) {
+	repo := t.TempDir()
+	for i := 0; i < 40; i++ {
+		writeDoc(t, repo, fmt.Sprintf("skills/s%02d/SKILL.md", i), fmt.Sprintf("---\nname: s%02d\ndescription: skill number %d\n---\n", i, i))
+	}
+	repos := []search.RepoRoot{{Name: "hub", Path: repo}}
+	idx := New("x")
+	if _, err := idx.Update(repos, []string{"skills"}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 35; i++ {
+		_ = os.RemoveAll(filepath.Join(repo, "skills", fmt.Sprintf("s%02d", i)))
+	}
+	if _, err := idx.Update(repos, []string{"skills"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(idx.Docs) != 5 {
+		t.Errorf("docs after compaction = %d, want 5", len(idx.Docs))
+	}
+	if got := ids(idx.Search("s37", 0)); len(got) != 1 || got[0] != "s37" {
+		t.Errorf("Search after compaction = %v", got)
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	repo := b.TempDir()
+	for i := 0; i < 3000; i++ {
+		writeDoc(b, repo, fmt.Sprintf("skills/skill-%04d/SKILL.md", i),
+			fmt.Sprintf("---\nname: skill-%04d\ndescription: helper %d for git, docker, and database tasks\nkeywords: tag%d\n---\n", i, i, i%50))
+	}
+	repos := []search.RepoRoot{{Name: "hub", Path: repo}}
+	dir := b.TempDir()
+	idx := New("bench")
+	if _, err := idx.Update(repos, []string{"skills"}); err != nil {
+		b.Fatal(err)
+	}
+	if err := idx.Save(dir); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Load, refresh, and query: what one 'axon search --keyword' does.
+		idx := Load(dir, "bench")
+		if _, err := idx.Update(repos, []string{"skills"}); err != nil {
+			b.Fatal(err)
+		}
+		idx.Search("git data", 5)
+	}
+}
@@ -0,0 +1,107 @@
+package search
+
+import (
+	"path"
+	"strings"
+)
+
+// Filter restricts a search to some documents before they are ranked. The
+// zero Filter matches everything.
+type Filter struct {
+	// Types lists content types (top-level roots such as "skills" or
+	// "workflows"); a document matches any of them.
+	Types []string
+	// PathPrefix is a slash-separated prefix of the document's location
+	// relative to its repo, such as "skills/db/".
+	PathPrefix string
+	// Tags lists frontmatter tags; a document must have all of them.
+	Tags []string
+}
+
+// Empty reports whether f matches every document.
+func (f Filter) Empty() bool {
+	return len(f.Types) == 0 && f.PathPrefix == "" && len(f.Tags) == 0
+}
+
+// Match reports whether d passes f.
+func (f Filter) Match(d SkillDoc) bool {
+	return f.MatchFields(d.ID, d.Path, d.Tags)
+}
+
+// MatchFields is Match for callers that only have a document's ID, path, and
+// tags, such as semantic index rows.
+func (f Filter) MatchFields(id, dir string, tags []string) bool {
+	if len(f.Types) > 0 {
+		root, _, _ := strings.Cut(dir, "/")
+		ok := false
+		for _, t := range f.Types {
+			if strings.EqualFold(strings.Trim(t, "/"), root) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if f.PathPrefix != "" {
+		prefix := strings.TrimPrefix(strings.ReplaceAll(f.PathPrefix, `\`, "/"), "./")
+		if !strings.HasPrefix(DocLocation(id, dir)+"/", prefix) {
+			return false
+		}
+	}
+	for _, want := range f.Tags {
+		ok := false
+		for _, t := range tags {
+			if strings.EqualFold(t, want) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// String describes f for result headers, e.g. "type=workflows tag=oracle".
+func (f Filter) String() string {
+	var parts []string
+	if len(f.Types) > 0 {
+		parts = append(parts, "type="+strings.Join(f.Types, ","))
+	}
+	if f.PathPrefix != "" {
+		parts = append(parts, "path="+f.PathPrefix)
+	}
+	if len(f.Tags) > 0 {
+		parts = append(parts, "tag="+strings.Join(f.Tags, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// DocLocation returns where a document lives relative to its repo, without
+// extension: its directory for a skill ("skills/db/migrate"), its file for
+// other documents ("workflows/release/deploy").
+func DocLocation(id, dir string) string {
+	if i := strings.LastIndexByte(id, ':'); i >= 0 {
+		return path.Join(dir, id[i+1:])
+	}
+	return dir
+}
+
+// splitTags splits a tags or keywords value written as a YAML list (joined
+// with commas by splitFrontmatter) or as a comma- or space-separated string.
+func splitTags(s string) []string {
+	sep := func(r rune) bool { return r == ',' }
+	if !strings.Contains(s, ",") {
+		sep = func(r rune) bool { return r == ' ' || r == '\t' }
+	}
+	var out []string
+	for _, t := range strings.FieldsFunc(s, sep) {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
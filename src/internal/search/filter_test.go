@@ -0,0 +1,63 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	migrate := SkillDoc{ID: "migrate", Path: "skills/db/migrate", Tags: []string{"Oracle", "db"}}
+	deploy := SkillDoc{ID: "workflows:release:deploy", Path: "workflows/release"}
+
+	for _, tc := range []struct {
+		filter Filter
+		doc    SkillDoc
+		want   bool
+	}{
+		{Filter{}, deploy, true},
+		{Filter{Types: []string{"workflows"}}, deploy, true},
+		{Filter{Types: []string{"workflows"}}, migrate, false},
+		{Filter{Types: []string{"commands", "skills"}}, migrate, true},
+		{Filter{PathPrefix: "skills/db/"}, migrate, true},
+		{Filter{PathPrefix: "skills/db"}, migrate, true},
+		{Filter{PathPrefix: "./skills/db/migrate/"}, migrate, true},
+		{Filter{PathPrefix: "skills/web/"}, migrate, false},
+		{Filter{PathPrefix: "workflows/release/deploy"}, deploy, true},
+		{Filter{Tags: []string{"oracle"}}, migrate, true},
+		{Filter{Tags: []string{"oracle", "db"}}, migrate, true},
+		{Filter{Tags: []string{"oracle", "postgres"}}, migrate, false},
+		{Filter{Tags: []string{"oracle"}}, deploy, false},
+	} {
+		if got := tc.filter.Match(tc.doc); got != tc.want {
+			t.Errorf("%q.Match(%s) = %v, want %v", tc.filter, tc.doc.ID, got, tc.want)
+		}
+	}
+}
+
+func TestReadDocument_Tags(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"list.md":     "---\ntags: [oracle, data guard]\n---\n",
+		"string.md":   "---\ntags: oracle, data guard\n---\n",
+		"keywords.md": "---\nkeywords: oracle rman\n---\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, want := range map[string][]string{
+		"list.md":     {"oracle", "data guard"},
+		"string.md":   {"oracle", "data guard"},
+		"keywords.md": {"oracle", "rman"},
+	} {
+		doc, err := ReadDocument(DocumentFile{File: filepath.Join(dir, name), ID: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(doc.Tags, want) {
+			t.Errorf("%s: tags = %q, want %q", name, doc.Tags, want)
+		}
+	}
+}
@@ -27,8 +27,20 @@ func splitFrontmatter(content string) (map[string]string, string) {
 
 	out := make(map[string]string)
 	for k, v := range raw {
-		if sv, ok := v.(string); ok {
-			out[strings.ToLower(k)] = sv
+		switch v := v.(type) {
+		case string:
+			out[strings.ToLower(k)] = v
+		case []any:
+			// Lists of strings, such as tags: [oracle, db], are joined.
+			var items []string
+			for _, item := range v {
+				if sv, ok := item.(string); ok {
+					items = append(items, sv)
+				}
+			}
+			if len(items) > 0 {
+				out[strings.ToLower(k)] = strings.Join(items, ", ")
+			}
 		}
 	}
 	return out, body
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Fuzzy scoring, in the spirit of fzf: every matched character earns
+// fuzzyMatch, more when it continues the previous match or starts a word,
+// and every character skipped between matches costs fuzzyGap.
+const (
+	fuzzyMatch       = 16
+	fuzzyConsecutive = 8
+	fuzzyWordStart   = 8
+	fuzzyGap         = 1
+)
+
+// FuzzyMatch reports whether the characters of pattern appear in text in
+// order (case-insensitively) and scores the best such alignment.
+func FuzzyMatch(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	if len(p) == 0 {
+		return 0, true
+	}
+	best, found := 0, false
+	// Try every occurrence of the first character as the anchor and match
+	// the rest greedily; good enough for names and one-line descriptions.
+	for start := range t {
+		if t[start] != p[0] {
+			continue
+		}
+		score, ok := fuzzyAlign(p, t, start)
+		if ok && (!found || score > best) {
+			best, found = score, true
+		}
+	}
+	return best, found
+}
+
+func fuzzyAlign(p, t []rune, start int) (int, bool) {
+	score := 0
+	prev := -1
+	i := start
+	for _, c := range p {
+		for i < len(t) && t[i] != c {
+			i++
+		}
+		if i == len(t) {
+			return 0, false
+		}
+		score += fuzzyMatch
+		if prev >= 0 && i == prev+1 {
+			score += fuzzyConsecutive
+		} else if prev >= 0 {
+			score -= fuzzyGap * (i - prev - 1)
+		}
+		if i == 0 || !isWordRune(t[i-1]) {
+			score += fuzzyWordStart
+		}
+		prev = i
+		i++
+	}
+	return score, true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// FuzzyFilter ranks docs for an interactive finder. Every whitespace-
+// separated query term must fuzzy-match the name, ID, or description; a
+// term scores by its best field, with description hits counting half. An
+// empty query returns every document ordered by ID.
+func FuzzyFilter(docs []SkillDoc, query string) []SearchResult {
+	terms := strings.Fields(query)
+	out := make([]SearchResult, 0, len(docs))
+	for _, d := range docs {
+		total := 0
+		matched := true
+		for _, term := range terms {
+			best, ok := 0, false
+			if s, hit := FuzzyMatch(term, d.Name); hit {
+				best, ok = s, true
+			}
+			if s, hit := FuzzyMatch(term, d.ID); hit && (!ok || s > best) {
+				best, ok = s, true
+			}
+			if s, hit := FuzzyMatch(term, d.Description); hit && (!ok || s/2 > best) {
+				best, ok = s/2, true
+			}
+			if !ok {
+				matched = false
+				break
+			}
+			total += best
+		}
+		if matched {
+			out = append(out, SearchResult{Skill: d, Score: float64(total), Why: "fuzzy"})
+		}
+	}
+	SortResults(out) // an empty query scores 0 everywhere: ordered by ID
+	return out
+}
@@ -0,0 +1,41 @@
+package search
+
+import "testing"
+
+func TestFuzzyMatch(t *testing.T) {
+	if _, ok := FuzzyMatch("gcm", "git-commit-message"); !ok {
+		t.Error("gcm should match git-commit-message")
+	}
+	if _, ok := FuzzyMatch("mcg", "git-commit-message"); ok {
+		t.Error("out-of-order characters must not match")
+	}
+	// Word starts and runs beat scattered letters.
+	starts, _ := FuzzyMatch("gcm", "git-commit-message")
+	scattered, _ := FuzzyMatch("gcm", "magic-mop")
+	if starts <= scattered {
+		t.Errorf("word-start score %d should beat scattered %d", starts, scattered)
+	}
+	run, _ := FuzzyMatch("dock", "docker")
+	gaps, _ := FuzzyMatch("dock", "d-o-c-k")
+	if run <= gaps {
+		t.Errorf("consecutive score %d should beat gapped %d", run, gaps)
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	docs := []SkillDoc{
+		{ID: "docker-compose", Name: "docker-compose", Description: "Run local stacks"},
+		{ID: "db-migrate", Name: "db-migrate", Description: "Apply docker database migrations"},
+		{ID: "writer", Name: "writer", Description: "Polish prose"},
+	}
+	got := FuzzyFilter(docs, "dock")
+	if len(got) != 2 || got[0].Skill.ID != "docker-compose" {
+		t.Fatalf("FuzzyFilter(dock) = %+v, want docker-compose first of two", got)
+	}
+	if got := FuzzyFilter(docs, "dock zzz"); len(got) != 0 {
+		t.Errorf("every term must match, got %+v", got)
+	}
+	if got := FuzzyFilter(docs, ""); len(got) != 3 || got[0].Skill.ID != "db-migrate" {
+		t.Errorf("empty query should list everything by ID, got %+v", got)
+	}
+}
@@ -2,6 +2,7 @@ package index
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,25 +16,72 @@ import (
 // BuildOptions controls user index building.
 type BuildOptions struct {
 	RepoPath  string
+	Repos     []search.RepoRoot // optional: several Hub repos, highest priority first
 	OutDir    string
+	PrevDir   string // previous index to reuse vectors from; empty means OutDir
 	Roots     []string
 	Force     bool
 	Normalize bool
+
+	// Checkpoint, if set, is a file that records each text as it is
+	// embedded, so an interrupted build can be resumed by running it again
+	// with the same Checkpoint. It is removed when the build succeeds.
+	Checkpoint string
+
+	// Chunking splits document bodies into extra, separately embedded
+	// rows; the zero value indexes names and descriptions only.
+	Chunking ChunkOptions
+
+	// TextNormalization is applied to each canonical text before embedding
+	// (NormalizeNone or NormalizeNFKC); empty means none.
+	TextNormalization string
+
+	// HubRevision identifies the Hub content indexed (e.g. HEAD commit);
+	// it is stored in the manifest so staleness can be detected later.
+	HubRevision string
+
+	// Progress, if set, is called after each document with the number of
+	// documents done so far and the total.
+	Progress func(done, total int)
 }
 
+// maxConsecutiveFailures is how many documents in a row may fail to embed
+// before a build gives up.
+const maxConsecutiveFailures = 5
+
 // BuildUserIndex builds a semantic index from skills found in repoPath and writes it to outDir.
 //
-// The build is incremental when an existing index is present in outDir (unless Force is true).
-// It is the caller's responsibility to apply an atomic swap strategy.
+// The build is incremental when an existing index is present in PrevDir or
+// outDir (unless Force is true). It is the caller's responsibility to apply
+// an atomic swap strategy.
+//
+// With a Checkpoint, texts embedded by an earlier, interrupted build are
+// taken from it instead of being embedded again.
+//
+// The result is reconciled with the Hub: entries of documents that no longer
+// exist are dropped, documents sharing an ID are reported in Collisions, and
+// Delta summarizes the changes from the previous index.
+//
+// A document whose embedding fails is skipped (or keeps its previous
+// vector) and recorded in the returned index's Failures; the build only
+// aborts on authentication errors, cancellation, or a run of failures.
 func BuildUserIndex(ctx context.Context, prov embeddings.Provider, opts BuildOptions) (*Index, error) {
-	if opts.RepoPath == "" {
+	if opts.RepoPath == "" && len(opts.Repos) == 0 {
 		return nil, fmt.Errorf("repo path is required")
 	}
 	if opts.OutDir == "" {
 		return nil, fmt.Errorf("out dir is required")
 	}
 
-	skills, err := search.DiscoverDocuments(opts.RepoPath, opts.Roots)
+	var (
+		skills []search.SkillDoc
+		err    error
+	)
+	if len(opts.Repos) > 0 {
+		skills, err = search.DiscoverRepoDocuments(opts.Repos, opts.Roots)
+	} else {
+		skills, err = search.DiscoverDocuments(opts.RepoPath, opts.Roots)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -41,10 +89,26 @@ func BuildUserIndex(ctx context.Context, prov embeddings.Provider, opts BuildOpt
 		return nil, fmt.Errorf("no documents found under %s", opts.RepoPath)
 	}
 
-	sort.Slice(skills, func(i, j int) bool { return skills[i].ID < skills[j].ID })
+	sort.SliceStable(skills, func(i, j int) bool {
+		if skills[i].ID != skills[j].ID {
+			return skills[i].ID < skills[j].ID
+		}
+		return skills[i].Path < skills[j].Path
+	})
+	skills, collisions := dropCollisions(skills)
 
 	// Load existing index for reuse.
-	old, _ := Load(opts.OutDir)
+	prevDir := opts.PrevDir
+	if prevDir == "" {
+		prevDir = opts.OutDir
+	}
+	old, _ := Load(prevDir)
+	prevChunks := map[string]int{}
+	if old != nil {
+		for _, se := range old.Skills {
+			prevChunks[se.ID]++
+		}
+	}
 	reuse := map[string]SkillEntry{}
 	reuseVec := map[string][]float32{}
 	if old != nil && !opts.Force {
@@ -52,69 +116,150 @@ func BuildUserIndex(ctx context.Context, prov embeddings.Provider, opts BuildOpt
 			start := i * old.Manifest.Dim
 			end := start + old.Manifest.Dim
 			if start >= 0 && end <= len(old.Vectors) {
-				reuse[se.ID] = se
+				key := entryKey(se.ID, se.Chunk)
+				reuse[key] = se
 				v := make([]float32, old.Manifest.Dim)
 				copy(v, old.Vectors[start:end])
-				reuseVec[se.ID] = v
+				reuseVec[key] = v
 			}
 		}
 	}
 
 	var (
-		entries []SkillEntry
-		vectors []float32
-		dim     int
+		entries     []SkillEntry
+		vectors     []float32
+		dim         int
+		failures    []BuildFailure
+		consecutive int
+		resumed     int
+		resume      map[string]checkpointRow
+		cp          *checkpoint
 	)
+	if opts.Checkpoint != "" {
+		header := checkpointHeader{ModelID: prov.ModelID(), Normalize: opts.Normalize, TextNorm: opts.TextNormalization}
+		if resume, cp, err = openCheckpoint(opts.Checkpoint, header); err != nil {
+			return nil, err
+		}
+		defer cp.close()
+	}
+
+	var delta Delta
+	for i, s := range skills {
+		texts := DocumentTexts(s, opts.Chunking)
+		embedded := 0
+		for chunk, raw := range texts {
+			text := NormalizeText(opts.TextNormalization, raw)
+			h := TextHash(text)
+			key := entryKey(s.ID, chunk)
 
-	for _, s := range skills {
-		text := CanonicalText(s)
-		h := TextHash(text)
-
-		if old != nil && !opts.Force {
-			if prev, ok := reuse[s.ID]; ok {
-				if prev.TextHash == h && prev.TextHash != "" {
-					if v, ok := reuseVec[s.ID]; ok {
-						entries = append(entries, prev)
-						vectors = append(vectors, v...)
-						if dim == 0 {
-							dim = len(v)
-						}
-						continue
-					}
+			if prev, ok := reuse[key]; ok && prev.TextHash == h && prev.TextHash != "" {
+				if v, ok := reuseVec[key]; ok && (dim == 0 || len(v) == dim) {
+					prev.Repo, prev.Tags = s.Repo, s.Tags
+					entries = append(entries, prev)
+					vectors = append(vectors, v...)
+					dim = len(v)
+					continue
 				}
 			}
-		}
 
-		emb, err := prov.Embed(ctx, text)
-		if err != nil {
-			return nil, err
-		}
-		if dim == 0 {
-			dim = len(emb)
+			if row, ok := resume[key]; ok && row.TextHash == h && (dim == 0 || len(row.Vector) == dim) {
+				e := SkillToEntry(s, h)
+				e.Chunk = chunk
+				entries = append(entries, e)
+				vectors = append(vectors, row.Vector...)
+				dim = len(row.Vector)
+				if err := cp.add(key, h, row.Vector); err != nil {
+					return nil, err
+				}
+				resumed++
+				embedded++
+				continue
+			}
+
+			emb, err := prov.Embed(ctx, text)
+			if err != nil {
+				// Bad credentials or a cancelled build fail every document; so
+				// does anything that keeps failing.
+				consecutive++
+				if errors.Is(err, embeddings.ErrAuth) || ctx.Err() != nil || consecutive >= maxConsecutiveFailures {
+					return nil, err
+				}
+				// Keep the previous vector, if any; its outdated text hash
+				// makes the next build retry the document.
+				f := BuildFailure{ID: key, Err: err}
+				if prev, ok := reuse[key]; ok && (dim == 0 || len(reuseVec[key]) == dim) {
+					prev.Repo, prev.Tags = s.Repo, s.Tags
+					entries = append(entries, prev)
+					vectors = append(vectors, reuseVec[key]...)
+					dim = len(reuseVec[key])
+					f.Stale = true
+				}
+				failures = append(failures, f)
+				continue
+			}
+			consecutive = 0
+			if dim == 0 {
+				dim = len(emb)
+			}
+			if len(emb) != dim {
+				return nil, fmt.Errorf("embedding dim changed mid-run: got %d want %d", len(emb), dim)
+			}
+			if opts.Normalize {
+				emb = NormalizeL2(emb)
+			}
+			if cp != nil {
+				if err := cp.add(key, h, emb); err != nil {
+					return nil, err
+				}
+			}
+
+			e := SkillToEntry(s, h)
+			e.Chunk = chunk
+			entries = append(entries, e)
+			vectors = append(vectors, emb...)
+			embedded++
 		}
-		if len(emb) != dim {
-			return nil, fmt.Errorf("embedding dim changed mid-run: got %d want %d", len(emb), dim)
+		switch n, ok := prevChunks[s.ID]; {
+		case !ok:
+			if embedded > 0 {
+				delta.Added = append(delta.Added, s.ID)
+			}
+		case embedded > 0 || n != len(texts):
+			delta.Updated = append(delta.Updated, s.ID)
+		default:
+			delta.Unchanged++
 		}
-		if opts.Normalize {
-			emb = NormalizeL2(emb)
+		delete(prevChunks, s.ID)
+		if opts.Progress != nil {
+			opts.Progress(i+1, len(skills))
 		}
-
-		entries = append(entries, SkillToEntry(s, h))
-		vectors = append(vectors, emb...)
 	}
 
 	manifest := Manifest{
 		IndexVersion: 1,
 		CreatedAt:    time.Now().UTC().Format(time.RFC3339),
-		HubRevision:  "",
+		HubRevision:  opts.HubRevision,
 		ModelID:      prov.ModelID(),
 		Dim:          dim,
 		Normalize:    opts.Normalize,
+		TextNorm:     opts.TextNormalization,
 		VectorFile:   "vectors.f32",
 		SkillsFile:   "skills.jsonl",
+		ChunkSize:    opts.Chunking.Size,
+		ChunkOverlap: opts.Chunking.Overlap,
 	}
 
-	idx := &Index{Manifest: manifest, Skills: entries, Vectors: vectors}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no document could be embedded: %w", failures[0].Err)
+	}
+
+	// Whatever is left of the previous index has no source any more.
+	for id := range prevChunks {
+		delta.Removed = append(delta.Removed, id)
+	}
+	sort.Strings(delta.Removed)
+
+	idx := &Index{Manifest: manifest, Skills: entries, Vectors: vectors, Failures: failures, Delta: delta, Collisions: collisions, Resumed: resumed}
 
 	if err := os.MkdirAll(opts.OutDir, 0o755); err != nil {
 		return nil, fmt.Errorf("cannot create out dir: %w", err)
@@ -122,10 +267,53 @@ func BuildUserIndex(ctx context.Context, prov embeddings.Provider, opts BuildOpt
 	if err := Write(opts.OutDir, manifest, entries, vectors); err != nil {
 		return nil, err
 	}
+	if cp != nil {
+		_ = cp.close()
+		_ = os.Remove(opts.Checkpoint)
+	}
 
 	return idx, nil
 }
 
+// dropCollisions keeps the first of the documents (sorted by ID) that share
+// an ID and reports the rest, which would otherwise be indexed under the same
+// rows and reuse each other's vectors.
+func dropCollisions(docs []search.SkillDoc) ([]search.SkillDoc, []Collision) {
+	var (
+		out        []search.SkillDoc
+		collisions []Collision
+	)
+	for i, d := range docs {
+		if i > 0 && docs[i-1].ID == d.ID {
+			c := &collisions[len(collisions)-1]
+			c.Paths = append(c.Paths, docPath(d))
+			continue
+		}
+		if i+1 < len(docs) && docs[i+1].ID == d.ID {
+			collisions = append(collisions, Collision{ID: d.ID, Paths: []string{docPath(d)}})
+		}
+		out = append(out, d)
+	}
+	return out, collisions
+}
+
+// docPath names a document's location for collision reports.
+func docPath(d search.SkillDoc) string {
+	if d.Repo != "" {
+		return d.Repo + ":" + d.Path
+	}
+	return d.Path
+}
+
+// entryKey identifies an index row: the document ID, with "#<n>" appended
+// for body chunks.
+func entryKey(id string, chunk int) string {
+	if chunk == 0 {
+		return id
+	}
+	return fmt.Sprintf("%s#%d", id, chunk)
+}
+
 // AtomicSwap replaces destDir with srcDir by renaming.
 func AtomicSwap(srcDir, destDir string) error {
 	parent := filepath.Dir(destDir)
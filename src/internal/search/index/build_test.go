@@ -0,0 +1,232 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/embeddings"
+)
+
+// flakyProvider fails for texts containing fail.
+type flakyProvider struct {
+	fail string
+	err  error
+}
+
+func (p *flakyProvider) ModelID() string { return "test:flaky" }
+func (p *flakyProvider) Dim() int        { return 2 }
+func (p *flakyProvider) Embed(_ context.Context, text string) ([]float32, error) {
+	if p.fail != "" && strings.Contains(text, p.fail) {
+		return nil, p.err
+	}
+	return []float32{1, float32(len(text))}, nil
+}
+
+func writeSkills(t *testing.T, repo string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		dir := filepath.Join(repo, "skills", name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		body := fmt.Sprintf("---\nname: %s\ndescription: the %s skill\n---\n", name, name)
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBuildUserIndex_CollectsFailures(t *testing.T) {
+	repo := t.TempDir()
+	writeSkills(t, repo, "alpha", "beta", "gamma")
+	prov := &flakyProvider{fail: "the beta skill", err: errors.New("HTTP 503")}
+
+	idx, err := BuildUserIndex(context.Background(), prov, BuildOptions{RepoPath: repo, OutDir: t.TempDir(), Roots: []string{"skills"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(idx.Skills) != 2 || len(idx.Vectors) != 4 {
+		t.Errorf("indexed %d skills / %d floats, want 2 / 4", len(idx.Skills), len(idx.Vectors))
+	}
+	if len(idx.Failures) != 1 || !strings.Contains(idx.Failures[0].ID, "beta") || idx.Failures[0].Stale {
+		t.Errorf("Failures = %+v, want beta", idx.Failures)
+	}
+}
+
+func TestBuildUserIndex_AbortsOnAuthErrors(t *testing.T) {
+	repo := t.TempDir()
+	writeSkills(t, repo, "alpha", "beta")
+	prov := &flakyProvider{fail: "skill", err: fmt.Errorf("openai: %w", embeddings.ErrAuth)}
+
+	_, err := BuildUserIndex(context.Background(), prov, BuildOptions{RepoPath: repo, OutDir: t.TempDir(), Roots: []string{"skills"}})
+	if !errors.Is(err, embeddings.ErrAuth) {
+		t.Fatalf("err = %v, want ErrAuth", err)
+	}
+}
+
+func TestBuildUserIndex_GivesUpAfterRepeatedFailures(t *testing.T) {
+	repo := t.TempDir()
+	names := make([]string, maxConsecutiveFailures+1)
+	for i := range names {
+		names[i] = fmt.Sprintf("s%d", i)
+	}
+	writeSkills(t, repo, names...)
+	prov := &flakyProvider{fail: "skill", err: errors.New("HTTP 500")}
+
+	if _, err := BuildUserIndex(context.Background(), prov, BuildOptions{RepoPath: repo, OutDir: t.TempDir(), Roots: []string{"skills"}}); err == nil {
+		t.Fatal("expected the build to give up")
+	}
+}
+
+func TestBuildUserIndex_ChunksBodies(t *testing.T) {
+	repo := t.TempDir()
+	writeSkills(t, repo, "alpha")
+	skill := filepath.Join(repo, "skills", "alpha", "SKILL.md")
+	body := "---\nname: alpha\ndescription: the alpha skill\n---\n# Usage\n\nrotate the oracle passwords every quarter\n"
+	if err := os.WriteFile(skill, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := t.TempDir()
+	opts := BuildOptions{RepoPath: repo, OutDir: out, Roots: []string{"skills"}, Chunking: ChunkOptions{Size: 4, Overlap: 1}}
+
+	idx, err := BuildUserIndex(context.Background(), &flakyProvider{}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// "# Usage rotate the oracle passwords every quarter" is 8 words: 3 chunks.
+	if len(idx.Skills) != 4 {
+		t.Fatalf("rows = %+v, want the document and 3 chunks", idx.Skills)
+	}
+	for i, e := range idx.Skills {
+		if e.ID != "alpha" || e.Chunk != i {
+			t.Errorf("row %d = %s#%d", i, e.ID, e.Chunk)
+		}
+	}
+	if idx.Manifest.ChunkSize != 4 || idx.Manifest.ChunkOverlap != 1 {
+		t.Errorf("manifest chunking = %d/%d", idx.Manifest.ChunkSize, idx.Manifest.ChunkOverlap)
+	}
+
+	// An unchanged rebuild reuses every row, chunks included.
+	prov := &flakyProvider{fail: "name:", err: errors.New("should not embed")}
+	if idx, err = BuildUserIndex(context.Background(), prov, opts); err != nil || len(idx.Failures) != 0 || len(idx.Skills) != 4 {
+		t.Errorf("rebuild = %d rows, failures %+v, err %v", len(idx.Skills), idx.Failures, err)
+	}
+}
+
+func TestBuildUserIndex_Reconciles(t *testing.T) {
+	repo := t.TempDir()
+	writeSkills(t, repo, "alpha", "beta", "gamma")
+	prev := t.TempDir()
+	if _, err := BuildUserIndex(context.Background(), &flakyProvider{}, BuildOptions{RepoPath: repo, OutDir: prev, Roots: []string{"skills"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Delete beta, change gamma, add delta, and add a second "alpha".
+	if err := os.RemoveAll(filepath.Join(repo, "skills", "beta")); err != nil {
+		t.Fatal(err)
+	}
+	writeSkills(t, repo, "delta", filepath.Join("team", "alpha"))
+	gamma := "---\nname: gamma\ndescription: a rewritten gamma skill\n---\n"
+	if err := os.WriteFile(filepath.Join(repo, "skills", "gamma", "SKILL.md"), []byte(gamma), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := BuildUserIndex(context.Background(), &flakyProvider{}, BuildOptions{RepoPath: repo, OutDir: t.TempDir(), PrevDir: prev, Roots: []string{"skills"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, e := range idx.Skills {
+		ids = append(ids, e.ID)
+	}
+	if strings.Join(ids, ",") != "alpha,delta,gamma" {
+		t.Errorf("indexed %v", ids)
+	}
+	d := idx.Delta
+	if fmt.Sprint(d.Added, d.Updated, d.Removed, d.Unchanged) != "[delta] [gamma] [beta] 1" {
+		t.Errorf("delta = %+v", d)
+	}
+	if len(idx.Collisions) != 1 || fmt.Sprint(idx.Collisions[0].Paths) != "[skills/alpha skills/team/alpha]" {
+		t.Errorf("collisions = %+v", idx.Collisions)
+	}
+	if idx.Skills[0].Path != "skills/alpha" {
+		t.Errorf("alpha indexed from %s", idx.Skills[0].Path)
+	}
+}
+
+// limitedProvider embeds up to limit texts, then fails every call.
+type limitedProvider struct {
+	limit, calls int
+}
+
+func (p *limitedProvider) ModelID() string { return "test:limited" }
+func (p *limitedProvider) Dim() int        { return 2 }
+func (p *limitedProvider) Embed(_ context.Context, text string) ([]float32, error) {
+	p.calls++
+	if p.limit >= 0 && p.calls > p.limit {
+		return nil, errors.New("connection reset")
+	}
+	return []float32{1, float32(len(text))}, nil
+}
+
+func TestBuildUserIndex_ResumesFromCheckpoint(t *testing.T) {
+	repo := t.TempDir()
+	names := make([]string, 10)
+	for i := range names {
+		names[i] = fmt.Sprintf("s%d", i)
+	}
+	writeSkills(t, repo, names...)
+	checkpoint := filepath.Join(t.TempDir(), "search.checkpoint")
+	opts := BuildOptions{RepoPath: repo, OutDir: t.TempDir(), Roots: []string{"skills"}, Checkpoint: checkpoint}
+
+	// The build dies after four documents.
+	if _, err := BuildUserIndex(context.Background(), &limitedProvider{limit: 4}, opts); err == nil {
+		t.Fatal("expected the build to fail")
+	}
+	if _, err := os.Stat(checkpoint); err != nil {
+		t.Fatalf("checkpoint not kept: %v", err)
+	}
+
+	prov := &limitedProvider{limit: -1}
+	idx, err := BuildUserIndex(context.Background(), prov, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prov.calls != 6 || idx.Resumed != 4 || len(idx.Skills) != 10 {
+		t.Errorf("embedded %d, resumed %d, indexed %d; want 6, 4, 10", prov.calls, idx.Resumed, len(idx.Skills))
+	}
+	if _, err := os.Stat(checkpoint); !os.IsNotExist(err) {
+		t.Errorf("checkpoint left after a successful build: %v", err)
+	}
+}
+
+func TestOpenCheckpoint_DiscardsOtherModels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cp")
+	_, cp, err := openCheckpoint(path, checkpointHeader{ModelID: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.add("x", "h", []float32{1}); err != nil {
+		t.Fatal(err)
+	}
+	cp.close()
+	// A crash can leave half a row behind.
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString(`{"key":"y","text_ha`)
+	f.Close()
+
+	rows, cp, err := openCheckpoint(path, checkpointHeader{ModelID: "a"})
+	if err != nil || len(rows) != 1 || rows["x"].TextHash != "h" {
+		t.Fatalf("rows = %+v, err %v", rows, err)
+	}
+	cp.close()
+	if rows, cp, _ = openCheckpoint(path, checkpointHeader{ModelID: "b"}); len(rows) != 0 {
+		t.Errorf("rows of another model reused: %+v", rows)
+	}
+	cp.close()
+}
@@ -0,0 +1,101 @@
+package index
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// A checkpoint records every text a build embeds as soon as it is embedded,
+// so a build that dies part-way (a network blip, a timeout) can be resumed:
+// the next build takes those vectors instead of embedding the texts again.
+// It is a JSON Lines file: a header naming the model and vector settings,
+// then one row per embedded text. A successful build removes it.
+
+// checkpointHeader identifies the embeddings a checkpoint holds; rows are
+// only reused by a build with the same header.
+type checkpointHeader struct {
+	ModelID   string `json:"model_id"`
+	Normalize bool   `json:"normalize"`
+	TextNorm  string `json:"text_normalization,omitempty"`
+}
+
+// checkpointRow is one embedded text.
+type checkpointRow struct {
+	Key      string    `json:"key"` // see entryKey
+	TextHash string    `json:"text_hash"`
+	Vector   []float32 `json:"vector"`
+}
+
+// checkpoint appends rows to a checkpoint file.
+type checkpoint struct {
+	f *os.File
+}
+
+// openCheckpoint returns the rows of the checkpoint at path that were
+// written with header, and opens it to append more. A checkpoint written
+// with another header, or unreadable, is started over. A row cut short by a
+// crash is ignored.
+func openCheckpoint(path string, header checkpointHeader) (map[string]checkpointRow, *checkpoint, error) {
+	rows := make(map[string]checkpointRow)
+	if data, err := os.ReadFile(path); err == nil {
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		var h checkpointHeader
+		if sc.Scan() && json.Unmarshal(sc.Bytes(), &h) == nil && h == header {
+			for sc.Scan() {
+				var r checkpointRow
+				if json.Unmarshal(sc.Bytes(), &r) == nil && r.Key != "" && len(r.Vector) > 0 {
+					rows[r.Key] = r
+				}
+			}
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("cannot read checkpoint: %w", err)
+	}
+
+	// Rewrite the file with the rows kept, dropping a partial last line.
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, nil, fmt.Errorf("cannot create checkpoint: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create checkpoint: %w", err)
+	}
+	cp := &checkpoint{f: f}
+	if err := cp.write(header); err != nil {
+		_ = f.Close()
+		return nil, nil, err
+	}
+	for _, r := range rows {
+		if err := cp.write(r); err != nil {
+			_ = f.Close()
+			return nil, nil, err
+		}
+	}
+	return rows, cp, nil
+}
+
+// add records an embedded text.
+func (cp *checkpoint) add(key, textHash string, vector []float32) error {
+	return cp.write(checkpointRow{Key: key, TextHash: textHash, Vector: vector})
+}
+
+func (cp *checkpoint) write(v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := cp.f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("cannot write checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (cp *checkpoint) close() error {
+	return cp.f.Close()
+}
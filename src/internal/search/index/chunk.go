@@ -0,0 +1,75 @@
+package index
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/search"
+)
+
+// ChunkOptions controls body chunking. Long documents are split into
+// overlapping windows of Size words that are embedded next to the name and
+// description, so a query about content deep in a SKILL.md still finds it.
+// A Size of 0 disables chunking.
+type ChunkOptions struct {
+	Size    int // words per chunk
+	Overlap int // words shared by consecutive chunks
+}
+
+// ParseChunkOptions validates chunk size and overlap settings given as
+// strings; an empty size disables chunking and an empty overlap defaults to
+// a fifth of the size.
+func ParseChunkOptions(size, overlap string) (ChunkOptions, error) {
+	var opts ChunkOptions
+	if s := strings.TrimSpace(size); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return ChunkOptions{}, fmt.Errorf("invalid chunk size %q (want a number of words, 0 to disable)", size)
+		}
+		opts.Size = n
+	}
+	if opts.Size == 0 {
+		return ChunkOptions{}, nil
+	}
+	opts.Overlap = opts.Size / 5
+	if s := strings.TrimSpace(overlap); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 || n >= opts.Size {
+			return ChunkOptions{}, fmt.Errorf("invalid chunk overlap %q (want a number of words below the chunk size %d)", overlap, opts.Size)
+		}
+		opts.Overlap = n
+	}
+	return opts, nil
+}
+
+// ChunkBody splits body into windows of opts.Size words, each starting
+// opts.Size-opts.Overlap words after the previous one. It returns nil when
+// chunking is disabled or the body is empty.
+func ChunkBody(body string, opts ChunkOptions) []string {
+	if opts.Size <= 0 {
+		return nil
+	}
+	words := strings.Fields(body)
+	step := max(opts.Size-opts.Overlap, 1)
+	var out []string
+	for start := 0; start < len(words); start += step {
+		end := min(start+opts.Size, len(words))
+		out = append(out, strings.Join(words[start:end], " "))
+		if end == len(words) {
+			break
+		}
+	}
+	return out
+}
+
+// DocumentTexts returns the texts embedded for s: its canonical text
+// followed by one text per body chunk, each prefixed with the document name
+// for context. The position of a text is its SkillEntry.Chunk.
+func DocumentTexts(s search.SkillDoc, opts ChunkOptions) []string {
+	texts := []string{CanonicalText(s)}
+	for _, c := range ChunkBody(s.Body, opts) {
+		texts = append(texts, "name: "+strings.TrimSpace(s.Name)+"\n"+c)
+	}
+	return texts
+}
@@ -0,0 +1,55 @@
+package index
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/search"
+)
+
+func TestChunkBody(t *testing.T) {
+	body := "one two three four five\n\nsix seven"
+	tests := []struct {
+		opts ChunkOptions
+		want []string
+	}{
+		{ChunkOptions{}, nil},
+		{ChunkOptions{Size: 10}, []string{"one two three four five six seven"}},
+		{ChunkOptions{Size: 3}, []string{"one two three", "four five six", "seven"}},
+		{ChunkOptions{Size: 3, Overlap: 1}, []string{"one two three", "three four five", "five six seven"}},
+	}
+	for _, tt := range tests {
+		if got := ChunkBody(body, tt.opts); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ChunkBody(%+v) = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+	if got := ChunkBody("  \n ", ChunkOptions{Size: 3}); got != nil {
+		t.Errorf("empty body gave chunks %q", got)
+	}
+}
+
+func TestParseChunkOptions(t *testing.T) {
+	if got, err := ParseChunkOptions("", "50"); err != nil || got != (ChunkOptions{}) {
+		t.Errorf("unset size = %+v, %v; want disabled", got, err)
+	}
+	if got, err := ParseChunkOptions("200", ""); err != nil || got != (ChunkOptions{Size: 200, Overlap: 40}) {
+		t.Errorf("default overlap = %+v, %v", got, err)
+	}
+	for _, bad := range [][2]string{{"-1", ""}, {"lots", ""}, {"100", "100"}, {"100", "-5"}} {
+		if _, err := ParseChunkOptions(bad[0], bad[1]); err == nil {
+			t.Errorf("ParseChunkOptions(%q, %q) should fail", bad[0], bad[1])
+		}
+	}
+}
+
+func TestDocumentTexts_PrefixesChunksWithName(t *testing.T) {
+	doc := search.SkillDoc{Name: "oracle", Description: "db admin", Body: "rotate passwords with alter user"}
+	texts := DocumentTexts(doc, ChunkOptions{Size: 3})
+	if len(texts) != 3 || texts[0] != CanonicalText(doc) {
+		t.Fatalf("texts = %q", texts)
+	}
+	if !strings.HasPrefix(texts[1], "name: oracle\n") || !strings.Contains(texts[2], "alter user") {
+		t.Errorf("chunk texts = %q", texts[1:])
+	}
+}
@@ -4,3 +4,6 @@ import "errors"
 
 // ErrVectorLengthMismatch indicates two vectors have different dimensions.
 var ErrVectorLengthMismatch = errors.New("vector length mismatch")
+
+// ErrChecksumMismatch indicates index files do not match their manifest.
+var ErrChecksumMismatch = errors.New("index files do not match the manifest checksum")
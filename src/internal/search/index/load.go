@@ -10,9 +10,13 @@ import (
 	"path/filepath"
 )
 
-// Load reads an index from dir containing manifest + skills + vectors.
-func Load(dir string) (*Index, error) {
-	manifestPath := filepath.Join(dir, "index_manifest.json")
+// ManifestFile is the name of the manifest inside an index directory.
+const ManifestFile = "index_manifest.json"
+
+// ReadManifest reads and validates only the manifest of the index in dir,
+// with file names defaulted.
+func ReadManifest(dir string) (*Manifest, error) {
+	manifestPath := filepath.Join(dir, ManifestFile)
 	b, err := os.ReadFile(manifestPath)
 	if err != nil {
 		return nil, fmt.Errorf("cannot read manifest %s: %w", manifestPath, err)
@@ -30,6 +34,26 @@ func Load(dir string) (*Index, error) {
 	if m.SkillsFile == "" {
 		m.SkillsFile = "skills.jsonl"
 	}
+	return &m, nil
+}
+
+// Load reads an index from dir containing manifest + skills + vectors.
+func Load(dir string) (*Index, error) {
+	mp, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	m := *mp
+
+	if m.Checksum != "" {
+		sum, err := contentChecksum(dir, m)
+		if err != nil {
+			return nil, err
+		}
+		if sum != m.Checksum {
+			return nil, fmt.Errorf("%w: %s (rebuild it with 'axon search --index')", ErrChecksumMismatch, dir)
+		}
+	}
 
 	skills, err := loadSkills(filepath.Join(dir, m.SkillsFile))
 	if err != nil {
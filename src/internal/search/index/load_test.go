@@ -3,6 +3,7 @@ package index
 import (
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -64,3 +65,24 @@ func TestLoad_IndexHappyPath(t *testing.T) {
 		t.Fatalf("vectors mismatch")
 	}
 }
+
+func TestLoad_ChecksumMismatch(t *testing.T) {
+	dir := t.TempDir()
+	m := Manifest{IndexVersion: 1, ModelID: "openai:test", Dim: 2}
+	if err := Write(dir, m, []SkillEntry{{ID: "a"}, {ID: "b"}}, []float32{1, 0, 0, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err != nil {
+		t.Fatalf("fresh index should load: %v", err)
+	}
+
+	// Same size, different content: only the checksum can tell.
+	vec := make([]byte, 16)
+	binary.LittleEndian.PutUint32(vec, 0x3f800000)
+	if err := os.WriteFile(filepath.Join(dir, "vectors.f32"), vec, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Load() error = %v, want ErrChecksumMismatch", err)
+	}
+}
@@ -0,0 +1,46 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Text normalization applied to canonical texts before embedding and to
+// queries before searching. The mode an index was built with is recorded in
+// its manifest so queries are always normalized the same way.
+const (
+	NormalizeNone = "none"
+	// NormalizeNFKC folds compatibility forms (full-width Latin and digits,
+	// half-width katakana, ligatures) and case, and collapses runs of
+	// spaces, so mixed-script text embeds consistently.
+	NormalizeNFKC = "nfkc"
+)
+
+// ParseTextNormalization validates a normalization mode; "" means none.
+func ParseTextNormalization(v string) (string, error) {
+	switch m := strings.ToLower(strings.TrimSpace(v)); m {
+	case "", NormalizeNone:
+		return NormalizeNone, nil
+	case NormalizeNFKC:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown text normalization %q (use %s or %s)", v, NormalizeNone, NormalizeNFKC)
+	}
+}
+
+// NormalizeText applies mode to text. Unknown modes and none leave text
+// unchanged.
+func NormalizeText(mode, text string) string {
+	if mode != NormalizeNFKC {
+		return text
+	}
+	text = cases.Fold().String(norm.NFKC.String(text))
+	lines := strings.Split(text, "\n")
+	for i, ln := range lines {
+		lines[i] = strings.Join(strings.Fields(ln), " ")
+	}
+	return strings.Join(lines, "\n")
+}
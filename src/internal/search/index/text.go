@@ -17,6 +17,11 @@ func CanonicalText(s search.SkillDoc) string {
 	if strings.TrimSpace(s.Keywords) != "" {
 		parts = append(parts, "keywords: "+strings.TrimSpace(s.Keywords))
 	}
+	// Translated fields let a query in one language find a skill documented
+	// in another.
+	for _, k := range s.LocalizedKeys() {
+		parts = append(parts, k+": "+s.Localized[k])
+	}
 	return strings.Join(parts, "\n")
 }
 
@@ -0,0 +1,41 @@
+package index
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kamusis/axon-cli/internal/search"
+)
+
+func TestCanonicalText_IncludesLocalizedFields(t *testing.T) {
+	s := search.SkillDoc{
+		Name:        "notes",
+		Description: "Summarize meeting notes",
+		Localized:   map[string]string{"name_zh": "笔记", "description_zh": "总结会议记录"},
+	}
+	got := CanonicalText(s)
+	want := "name: notes\ndescription: Summarize meeting notes\ndescription_zh: 总结会议记录\nname_zh: 笔记"
+	if got != want {
+		t.Errorf("CanonicalText = %q, want %q", got, want)
+	}
+	// Documents without translations keep their text (and text hash).
+	if strings.Contains(CanonicalText(search.SkillDoc{Name: "a"}), "_") {
+		t.Error("unexpected localized lines")
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	in := "name: ＡＰＩ　Client\ndescription:  Ｇｉｔ   ﬁles"
+	if got := NormalizeText(NormalizeNone, in); got != in {
+		t.Errorf("none changed the text: %q", got)
+	}
+	if got, want := NormalizeText(NormalizeNFKC, in), "name: api client\ndescription: git files"; got != want {
+		t.Errorf("nfkc = %q, want %q", got, want)
+	}
+	if m, err := ParseTextNormalization(""); err != nil || m != NormalizeNone {
+		t.Errorf("ParseTextNormalization(\"\") = %q, %v", m, err)
+	}
+	if _, err := ParseTextNormalization("nfd"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
@@ -8,18 +8,34 @@ type Manifest struct {
 	ModelID      string `json:"model_id"`
 	Dim          int    `json:"dim"`
 	Normalize    bool   `json:"normalize"`
+	TextNorm     string `json:"text_normalization,omitempty"` // see NormalizeText; empty means none
 	VectorFile   string `json:"vector_file"`
 	SkillsFile   string `json:"skills_file"`
+
+	// ChunkSize and ChunkOverlap record the body chunking the index was
+	// built with (see ChunkOptions); zero means no chunks.
+	ChunkSize    int `json:"chunk_size,omitempty"`
+	ChunkOverlap int `json:"chunk_overlap,omitempty"`
+
+	// Checksum is the SHA-256 of the skills and vector files, checked on
+	// load so a corrupted or half-synced index is never queried. Indexes
+	// written before it existed have none.
+	Checksum string `json:"checksum,omitempty"`
 }
 
-// SkillEntry represents one skill row in skills.jsonl.
+// SkillEntry represents one row in skills.jsonl. A chunked document has
+// several rows with the same ID: Chunk 0 for its name and description, then
+// one per body chunk.
 type SkillEntry struct {
-	ID          string `json:"id"`
-	Path        string `json:"path"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	TextHash    string `json:"text_hash"`
-	UpdatedAt   string `json:"updated_at"`
+	ID          string   `json:"id"`
+	Chunk       int      `json:"chunk,omitempty"`
+	Path        string   `json:"path"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Repo        string   `json:"repo,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	TextHash    string   `json:"text_hash"`
+	UpdatedAt   string   `json:"updated_at"`
 }
 
 // Index is a loaded semantic index.
@@ -27,4 +43,41 @@ type Index struct {
 	Manifest Manifest
 	Skills   []SkillEntry
 	Vectors  []float32
+
+	// Failures lists the documents a build could not embed. It is not
+	// persisted.
+	Failures []BuildFailure
+
+	// Delta and Collisions describe how a build reconciled the Hub with the
+	// previous index. They are not persisted.
+	Delta      Delta
+	Collisions []Collision
+
+	// Resumed counts the texts taken from the checkpoint of an interrupted
+	// build instead of being embedded again.
+	Resumed int
+}
+
+// Delta counts the documents a build added to, re-embedded in, or removed
+// from the previous index, by document ID.
+type Delta struct {
+	Added     []string
+	Updated   []string
+	Removed   []string
+	Unchanged int
+}
+
+// Collision records documents that share an ID. Only the first path is
+// indexed; the others are left out until they are renamed.
+type Collision struct {
+	ID    string
+	Paths []string
+}
+
+// BuildFailure records a document whose embedding failed during a build.
+// Stale is set when the document's vector from the previous index was kept.
+type BuildFailure struct {
+	ID    string
+	Err   error
+	Stale bool
 }
@@ -2,9 +2,12 @@ package index
 
 import (
 	"bufio"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
@@ -37,15 +40,6 @@ func Write(dir string, manifest Manifest, skills []SkillEntry, vectors []float32
 		return fmt.Errorf("cannot create index dir %s: %w", dir, err)
 	}
 
-	// manifest
-	mb, err := json.MarshalIndent(manifest, "", "  ")
-	if err != nil {
-		return err
-	}
-	if err := os.WriteFile(filepath.Join(dir, "index_manifest.json"), mb, 0o644); err != nil {
-		return fmt.Errorf("cannot write manifest: %w", err)
-	}
-
 	// skills jsonl
 	sf, err := os.Create(filepath.Join(dir, manifest.SkillsFile))
 	if err != nil {
@@ -88,9 +82,38 @@ func Write(dir string, manifest Manifest, skills []SkillEntry, vectors []float32
 		return err
 	}
 
+	// manifest, last: it carries the checksum of the files above.
+	if manifest.Checksum, err = contentChecksum(dir, manifest); err != nil {
+		return err
+	}
+	mb, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), mb, 0o644); err != nil {
+		return fmt.Errorf("cannot write manifest: %w", err)
+	}
+
 	return nil
 }
 
+// contentChecksum hashes the skills and vector files of the index in dir.
+func contentChecksum(dir string, m Manifest) (string, error) {
+	h := sha256.New()
+	for _, name := range []string{m.SkillsFile, m.VectorFile} {
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", fmt.Errorf("cannot read %s: %w", name, err)
+		}
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // SkillToEntry converts SkillDoc to SkillEntry for index writing.
 func SkillToEntry(s search.SkillDoc, textHash string) SkillEntry {
 	return SkillEntry{
@@ -98,6 +121,8 @@ func SkillToEntry(s search.SkillDoc, textHash string) SkillEntry {
 		Path:        s.Path,
 		Name:        s.Name,
 		Description: s.Description,
+		Repo:        s.Repo,
+		Tags:        s.Tags,
 		TextHash:    textHash,
 		UpdatedAt:   time.Now().UTC().Format(time.RFC3339),
 	}
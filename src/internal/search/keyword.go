@@ -1,37 +1,55 @@
 package search
 
 import (
-	"sort"
 	"strings"
+	"unicode"
 )
 
-// KeywordSearch searches skills by case-insensitive keyword matching over name, description,
-// and keywords. All query tokens must match (AND semantics).
+// Field weights for keyword scoring: a hit in the name says more about a
+// skill than one in its description, and the ID (mostly the path) the least.
+const (
+	weightName        = 8.0
+	weightKeywords    = 4.0
+	weightDescription = 2.0
+	weightLocalized   = 2.0 // translated name/description/keywords
+	weightID          = 1.0
+)
+
+// How well a token matched a field, as a factor of the field weight.
+const (
+	matchWord      = 1.0 // equals a whole word
+	matchPrefix    = 0.7 // starts a word
+	matchSubstring = 0.4 // anywhere else
+)
+
+// Bonuses on top of the per-token scores.
+const (
+	bonusExactName = 10.0 // the query is the skill name
+	bonusPhrase    = 2.0  // × field weight when a multi-word query appears verbatim
+)
+
+// KeywordSearch searches skills by case-insensitive keyword matching over
+// name, description, keywords, their translations, and ID. All query tokens must match (AND
+// semantics). Each token scores by the best field it hits (name > keywords >
+// description > ID) and how it hits (whole word > word prefix > substring);
+// exact names and verbatim phrases earn a bonus. Ties are broken by ID.
 func KeywordSearch(skills []SkillDoc, query string, limit int) []SearchResult {
 	tokens := tokenize(query)
 	if len(tokens) == 0 {
 		return []SearchResult{}
 	}
+	phrase := strings.Join(tokens, " ")
 
 	var out []SearchResult
 	for _, s := range skills {
-		blob := strings.ToLower(strings.Join([]string{s.ID, s.Name, s.Description, s.Keywords}, "\n"))
-		ok := true
-		for _, tok := range tokens {
-			if !strings.Contains(blob, tok) {
-				ok = false
-				break
-			}
-		}
+		score, ok := keywordScore(s, tokens, phrase)
 		if !ok {
 			continue
 		}
-		out = append(out, SearchResult{Skill: s, Score: 1, Why: "keyword"})
+		out = append(out, SearchResult{Skill: s, Score: score, Why: "keyword"})
 	}
 
-	sort.Slice(out, func(i, j int) bool {
-		return out[i].Skill.ID < out[j].Skill.ID
-	})
+	SortResults(out)
 
 	if limit > 0 && len(out) > limit {
 		out = out[:limit]
@@ -39,6 +57,104 @@ func KeywordSearch(skills []SkillDoc, query string, limit int) []SearchResult {
 	return out
 }
 
+type scoredField struct {
+	text   string
+	words  []string
+	weight float64
+}
+
+// keywordScore scores s for the query tokens; ok is false unless every
+// token matches some field.
+func keywordScore(s SkillDoc, tokens []string, phrase string) (float64, bool) {
+	fields := []scoredField{
+		newScoredField(s.Name, weightName),
+		newScoredField(s.Keywords, weightKeywords),
+		newScoredField(s.Description, weightDescription),
+		newScoredField(s.LocalizedText(), weightLocalized),
+		newScoredField(s.ID, weightID),
+	}
+
+	var score float64
+	for _, tok := range tokens {
+		best := 0.0
+		for _, f := range fields {
+			if m := f.weight * matchFactor(f, tok); m > best {
+				best = m
+			}
+		}
+		if best == 0 {
+			return 0, false
+		}
+		score += best
+	}
+
+	score += keywordBonus(s, fields, tokens, phrase)
+	return score, true
+}
+
+// KeywordBonus returns the exact-name and phrase bonus KeywordSearch adds
+// for query, for rankers that score tokens their own way.
+func KeywordBonus(s SkillDoc, query string) float64 {
+	tokens := tokenize(query)
+	if len(tokens) == 0 {
+		return 0
+	}
+	phrase := strings.Join(tokens, " ")
+	var fields []scoredField
+	if len(tokens) > 1 {
+		// Only the text is needed to find the phrase.
+		fields = []scoredField{
+			{text: strings.ToLower(s.Name), weight: weightName},
+			{text: strings.ToLower(s.Keywords), weight: weightKeywords},
+			{text: strings.ToLower(s.Description), weight: weightDescription},
+			{text: strings.ToLower(s.LocalizedText()), weight: weightLocalized},
+			{text: strings.ToLower(s.ID), weight: weightID},
+		}
+	}
+	return keywordBonus(s, fields, tokens, phrase)
+}
+
+func keywordBonus(s SkillDoc, fields []scoredField, tokens []string, phrase string) float64 {
+	var bonus float64
+	if name := strings.ToLower(strings.TrimSpace(s.Name)); name != "" && (name == phrase || name == strings.Join(tokens, "-")) {
+		bonus += bonusExactName
+	}
+	if len(tokens) > 1 {
+		for _, f := range fields {
+			if strings.Contains(f.text, phrase) {
+				bonus += bonusPhrase * f.weight
+				break // fields are ordered by weight
+			}
+		}
+	}
+	return bonus
+}
+
+func newScoredField(text string, weight float64) scoredField {
+	text = strings.ToLower(text)
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return scoredField{text: text, words: words, weight: weight}
+}
+
+// matchFactor returns how well tok matches f, or 0 when it does not.
+func matchFactor(f scoredField, tok string) float64 {
+	if !strings.Contains(f.text, tok) {
+		return 0
+	}
+	best := matchSubstring
+	for _, w := range f.words {
+		if w == tok {
+			return matchWord
+		}
+		if strings.HasPrefix(w, tok) {
+			best = matchPrefix
+		}
+	}
+	return best
+}
+
 func tokenize(q string) []string {
 	q = strings.TrimSpace(q)
 	if q == "" {
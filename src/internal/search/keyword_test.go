@@ -0,0 +1,51 @@
+package search
+
+import "testing"
+
+func TestKeywordSearch_RanksByField(t *testing.T) {
+	docs := []SkillDoc{
+		{ID: "skills:a-review-notes", Name: "a-review-notes", Description: "Collect notes"},
+		{ID: "skills:code-helper", Name: "code-helper", Description: "Helps with a code review"},
+		{ID: "skills:linter", Name: "linter", Keywords: "review, lint"},
+		{ID: "skills:review", Name: "review", Description: "Review pull requests"},
+	}
+	got := KeywordSearch(docs, "review", 0)
+	want := []string{"skills:review", "skills:a-review-notes", "skills:linter", "skills:code-helper"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d results, want %d", len(got), len(want))
+	}
+	for i, id := range want {
+		if got[i].Skill.ID != id {
+			t.Errorf("result %d = %s (%.1f), want %s", i, got[i].Skill.ID, got[i].Score, id)
+		}
+	}
+}
+
+func TestKeywordSearch_PrefixAndPhrase(t *testing.T) {
+	docs := []SkillDoc{
+		{ID: "skills:b", Name: "b", Description: "pull requests for git"},
+		{ID: "skills:a", Name: "a", Description: "git pull requests"},
+		{ID: "skills:c", Name: "c", Description: "depends on rebase, requested by git users"},
+	}
+	got := KeywordSearch(docs, "git pull", 0)
+	if len(got) != 2 || got[0].Skill.ID != "skills:a" || got[0].Score <= got[1].Score {
+		t.Fatalf("phrase match should rank first: %+v", got)
+	}
+
+	// "req" starts a word in every description.
+	prefix := KeywordSearch(docs, "req", 0)
+	if len(prefix) != 3 {
+		t.Fatalf("prefix results = %+v", prefix)
+	}
+	// Equal scores fall back to ID order.
+	if prefix[0].Skill.ID != "skills:a" || prefix[1].Skill.ID != "skills:b" || prefix[2].Skill.ID != "skills:c" {
+		t.Errorf("tie-break order = %s, %s, %s", prefix[0].Skill.ID, prefix[1].Skill.ID, prefix[2].Skill.ID)
+	}
+}
+
+func TestKeywordSearch_AllTokensRequired(t *testing.T) {
+	docs := []SkillDoc{{ID: "skills:x", Name: "x", Description: "alpha"}}
+	if got := KeywordSearch(docs, "alpha beta", 0); len(got) != 0 {
+		t.Errorf("got %+v, want no results", got)
+	}
+}
@@ -5,7 +5,11 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // DiscoverSkills scans repoRoot/skills/*/SKILL.md and returns parsed SkillDoc entries.
@@ -22,14 +26,56 @@ func DiscoverSkills(repoRoot string) ([]SkillDoc, error) {
 //   - skills:     scans skills/*/SKILL.md
 //   - workflows:  scans workflows/**/*.md
 //   - commands:   scans commands/**/*.md
+//   - rules:      scans rules/**/*.md
+//   - memories:   scans memories/**/*.md
+//
+// Documents outside skills get one ID per file: the path without the .md
+// extension, with "/" replaced by ":" (e.g. "rules:pnpm", "memories:db:pg").
 //
 // Missing roots are ignored.
 func DiscoverDocuments(repoRoot string, roots []string) ([]SkillDoc, error) {
+	files, err := ListDocumentFiles(repoRoot, roots)
+	if err != nil {
+		return nil, err
+	}
+	return ReadDocuments(files)
+}
+
+// ReadDocuments reads and parses files concurrently. The documents are in
+// the order of files; on failure, the error of the first failing file is
+// returned.
+func ReadDocuments(files []DocumentFile) ([]SkillDoc, error) {
+	out := make([]SkillDoc, len(files))
+	errs := make([]error, len(files))
+	forEachParallel(len(files), func(i int) {
+		out[i], errs[i] = ReadDocument(files[i])
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// DocumentFile is a searchable file found by ListDocumentFiles, before it is
+// read. ID and Path are derived from its location alone.
+type DocumentFile struct {
+	File    string // absolute path of the markdown file
+	ID      string
+	Path    string // slash-separated directory relative to the repo root
+	Size    int64
+	ModTime int64 // UnixNano
+}
+
+// ListDocumentFiles finds the documents DiscoverDocuments would return
+// without reading them, so callers can skip files that did not change.
+func ListDocumentFiles(repoRoot string, roots []string) ([]DocumentFile, error) {
 	if len(roots) == 0 {
-		roots = []string{"skills", "workflows", "commands"}
+		roots = []string{"skills", "workflows", "commands", "rules", "memories"}
 	}
 
-	var out []SkillDoc
+	var out []DocumentFile
 	for _, root := range roots {
 		dir := filepath.Join(repoRoot, root)
 		info, err := os.Stat(dir)
@@ -43,36 +89,136 @@ func DiscoverDocuments(repoRoot string, roots []string) ([]SkillDoc, error) {
 			return nil, fmt.Errorf("%s path is not a directory: %s", root, dir)
 		}
 
-		walkFn := func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
-			if d.IsDir() {
+		files, err := listRoot(repoRoot, root, dir)
+		if err != nil {
+			return nil, fmt.Errorf("cannot scan %s: %w", root, err)
+		}
+		out = append(out, files...)
+	}
+	return out, nil
+}
+
+// listRoot finds the documents under dir, the directory of root. Its
+// entries are walked concurrently; the result is in the lexical order a
+// single filepath.WalkDir would give.
+func listRoot(repoRoot, root, dir string) ([]DocumentFile, error) {
+	if info, err := os.Lstat(dir); err != nil || !info.IsDir() {
+		// A symlinked root is not descended into, as with WalkDir.
+		return walkDocuments(repoRoot, root, dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([][]DocumentFile, len(entries))
+	errs := make([]error, len(entries))
+	forEachParallel(len(entries), func(i int) {
+		parts[i], errs[i] = walkDocuments(repoRoot, root, filepath.Join(dir, entries[i].Name()))
+	})
+	var out []DocumentFile
+	for i := range entries {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		out = append(out, parts[i]...)
+	}
+	return out, nil
+}
+
+// walkDocuments walks path, inside the directory of root, for documents.
+func walkDocuments(repoRoot, root, path string) ([]DocumentFile, error) {
+	var out []DocumentFile
+	err := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		if root == "skills" {
+			if d.Name() != "SKILL.md" {
 				return nil
 			}
+		} else if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
+			// Every other root: include markdown files.
+			return nil
+		}
+		f, err := documentFile(repoRoot, path, root)
+		if err != nil {
+			return err
+		}
+		if fi, err := d.Info(); err == nil {
+			f.Size, f.ModTime = fi.Size(), fi.ModTime().UnixNano()
+		}
+		out = append(out, f)
+		return nil
+	})
+	return out, err
+}
 
-			if root == "skills" {
-				if d.Name() != "SKILL.md" {
-					return nil
+// maxDiscoverWorkers bounds the goroutines that walk and read documents.
+// Reading is mostly waiting on the disk, so a few workers help even on a
+// single CPU.
+const maxDiscoverWorkers = 16
+
+// forEachParallel calls fn for 0..n-1 on a bounded pool of goroutines.
+func forEachParallel(n int, fn func(i int)) {
+	workers := min(n, maxDiscoverWorkers, max(4, runtime.GOMAXPROCS(0)))
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	var (
+		next atomic.Int64
+		wg   sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= n {
+					return
 				}
-				return appendDocFromFile(repoRoot, path, root, &out)
+				fn(i)
 			}
+		}()
+	}
+	wg.Wait()
+}
 
-			// workflows/commands: include markdown files.
-			if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
-				return nil
+// DiscoverRepoDocuments runs DiscoverDocuments over several Hub repos given
+// highest priority first. When two repos provide a document with the same
+// ID, only the higher-priority one is kept; documents of one repo that share
+// an ID are all returned, as DiscoverDocuments does. Each document records
+// its repo.
+func DiscoverRepoDocuments(repos []RepoRoot, roots []string) ([]SkillDoc, error) {
+	var out []SkillDoc
+	seen := make(map[string]bool)
+	for _, r := range repos {
+		docs, err := DiscoverDocuments(r.Path, roots)
+		if err != nil {
+			return nil, fmt.Errorf("repo %s: %w", r.Name, err)
+		}
+		for _, d := range docs {
+			if seen[d.ID] {
+				continue
 			}
-			return appendDocFromFile(repoRoot, path, root, &out)
+			d.Repo = r.Name
+			out = append(out, d)
 		}
-
-		if err := filepath.WalkDir(dir, walkFn); err != nil {
-			return nil, fmt.Errorf("cannot scan %s: %w", root, err)
+		for _, d := range docs {
+			seen[d.ID] = true
 		}
 	}
 	return out, nil
 }
 
-func appendDocFromFile(repoRoot, path, root string, out *[]SkillDoc) error {
+func documentFile(repoRoot, path, root string) (DocumentFile, error) {
 	var (
 		relDir string
 		id     string
@@ -81,23 +227,27 @@ func appendDocFromFile(repoRoot, path, root string, out *[]SkillDoc) error {
 	if root == "skills" {
 		rel, err := filepath.Rel(repoRoot, filepath.Dir(path))
 		if err != nil {
-			return err
+			return DocumentFile{}, err
 		}
 		relDir = rel
 		id = filepath.Base(filepath.Dir(path))
 	} else {
 		relFile, err := filepath.Rel(repoRoot, path)
 		if err != nil {
-			return err
+			return DocumentFile{}, err
 		}
 		relDir = filepath.Dir(relFile)
 		base := strings.TrimSuffix(filepath.ToSlash(relFile), filepath.Ext(relFile))
 		id = strings.ReplaceAll(base, "/", ":")
 	}
+	return DocumentFile{File: path, ID: id, Path: filepath.ToSlash(relDir)}, nil
+}
 
-	b, err := os.ReadFile(path)
+// ReadDocument reads and parses one file found by ListDocumentFiles.
+func ReadDocument(f DocumentFile) (SkillDoc, error) {
+	b, err := os.ReadFile(f.File)
 	if err != nil {
-		return fmt.Errorf("cannot read %s: %w", path, err)
+		return SkillDoc{}, fmt.Errorf("cannot read %s: %w", f.File, err)
 	}
 	h, body := splitFrontmatter(string(b))
 
@@ -107,22 +257,47 @@ func appendDocFromFile(repoRoot, path, root string, out *[]SkillDoc) error {
 	if keywords == "" {
 		keywords = strings.TrimSpace(h["tags"])
 	}
+	tags := splitTags(h["tags"])
+	if len(tags) == 0 {
+		tags = splitTags(h["keywords"])
+	}
 
 	if name == "" {
-		name = id
+		name = f.ID
 	}
 	if desc == "" {
 		desc = inferDescriptionFromBody(body)
 	}
 
-	*out = append(*out, SkillDoc{
-		ID:          id,
-		Path:        filepath.ToSlash(relDir),
+	return SkillDoc{
+		ID:          f.ID,
+		Path:        f.Path,
 		Name:        name,
 		Description: desc,
 		Keywords:    keywords,
-	})
-	return nil
+		Tags:        tags,
+		Body:        body,
+		Localized:   localizedFields(h),
+	}, nil
+}
+
+// localizedFieldPattern matches translated frontmatter fields such as
+// description_zh, name_ja, or keywords_zh-tw.
+var localizedFieldPattern = regexp.MustCompile(`^(name|description|keywords)_[a-z]{2,3}([-_][a-z0-9]+)?$`)
+
+func localizedFields(h map[string]string) map[string]string {
+	var out map[string]string
+	for k, v := range h {
+		v = strings.TrimSpace(v)
+		if v == "" || !localizedFieldPattern.MatchString(k) {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string)
+		}
+		out[k] = v
+	}
+	return out
 }
 
 func inferDescriptionFromBody(body string) string {
@@ -1,8 +1,10 @@
 package search
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -101,3 +103,185 @@ func TestDiscoverDocuments_IncludesWorkflowsAndCommands(t *testing.T) {
 		t.Fatalf("unexpected commands path: %q", cmd.Path)
 	}
 }
+
+func TestDiscoverDocuments_IncludesRulesAndMemories(t *testing.T) {
+	repo := t.TempDir()
+	files := map[string]string{
+		"rules/pnpm.md":          "---\ndescription: Use pnpm, never npm\nglobs: [\"**/*.ts\"]\n---\nAlways run pnpm.\n",
+		"rules/db/postgres.md":   "Prefer COPY over INSERT.\n",
+		"memories/team.md":       "# Team\n",
+		"memories/readme.txt":    "not a document",
+		"memories/db/schemas.md": "# Schemas\n",
+	}
+	for rel, content := range files {
+		p := filepath.Join(repo, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	docs, err := DiscoverDocuments(repo, nil)
+	if err != nil {
+		t.Fatalf("DiscoverDocuments: %v", err)
+	}
+	got := map[string]string{}
+	for _, d := range docs {
+		got[d.ID] = d.Path
+	}
+	want := map[string]string{
+		"rules:pnpm":          "rules",
+		"rules:db:postgres":   "rules/db",
+		"memories:team":       "memories",
+		"memories:db:schemas": "memories/db",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("documents = %v, want %v", got, want)
+	}
+	for id, path := range want {
+		if got[id] != path {
+			t.Errorf("document %s path = %q, want %q", id, got[id], path)
+		}
+	}
+
+	f := Filter{Types: []string{"rules"}}
+	for _, d := range docs {
+		if f.Match(d) != strings.HasPrefix(d.ID, "rules:") {
+			t.Errorf("type=rules match of %s = %v", d.ID, f.Match(d))
+		}
+	}
+}
+
+func TestDiscoverRepoDocuments_HigherPriorityWins(t *testing.T) {
+	tmp := t.TempDir()
+	write := func(repo, skill, desc string) {
+		dir := filepath.Join(tmp, repo, "skills", skill)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		content := "---\nname: " + skill + "\ndescription: " + desc + "\n---\n"
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("company", "deploy", "company deploy")
+	write("personal", "deploy", "personal deploy")
+	write("personal", "notes", "personal notes")
+
+	docs, err := DiscoverRepoDocuments([]RepoRoot{
+		{Name: "company", Path: filepath.Join(tmp, "company")},
+		{Name: "default", Path: filepath.Join(tmp, "personal")},
+	}, []string{"skills"})
+	if err != nil {
+		t.Fatalf("DiscoverRepoDocuments: %v", err)
+	}
+	byID := map[string]SkillDoc{}
+	for _, d := range docs {
+		byID[d.ID] = d
+	}
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(docs))
+	}
+	if d := byID["deploy"]; d.Repo != "company" || d.Description != "company deploy" {
+		t.Errorf("deploy should come from company: %+v", d)
+	}
+	if d := byID["notes"]; d.Repo != "default" {
+		t.Errorf("notes should come from default: %+v", d)
+	}
+}
+
+func TestDiscoverSkills_CollectsLocalizedFields(t *testing.T) {
+	repo := t.TempDir()
+	skillDir := filepath.Join(repo, "skills", "demo")
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: demo\ndescription: Summarize meeting notes\ndescription_zh: 总结会议记录\nkeywords_zh-tw: 會議\ndescription_note: not a language\n---\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	skills, err := DiscoverSkills(repo)
+	if err != nil || len(skills) != 1 {
+		t.Fatalf("DiscoverSkills = %+v, %v", skills, err)
+	}
+	loc := skills[0].Localized
+	if len(loc) != 2 || loc["description_zh"] != "总结会议记录" || loc["keywords_zh-tw"] != "會議" {
+		t.Fatalf("Localized = %v", loc)
+	}
+
+	// Keyword search reaches the translations.
+	if got := KeywordSearch(skills, "会议", 5); len(got) != 1 {
+		t.Errorf("KeywordSearch(会议) = %+v, want the demo skill", got)
+	}
+}
+
+func TestListDocumentFiles_OrderMatchesSerialWalk(t *testing.T) {
+	repo := t.TempDir()
+	for _, rel := range []string{
+		"skills/b/SKILL.md", "skills/a/SKILL.md", "skills/team/c/SKILL.md", "skills/a/notes.md",
+		"workflows/z.md", "workflows/release/deploy.md", "workflows/a.md",
+	} {
+		p := filepath.Join(repo, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("---\nname: x\n---\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		files, err := ListDocumentFiles(repo, []string{"skills", "workflows"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, f := range files {
+			got = append(got, f.ID)
+		}
+		want := "a,b,c,workflows:a,workflows:release:deploy,workflows:z"
+		if strings.Join(got, ",") != want {
+			t.Fatalf("IDs = %v, want %s", got, want)
+		}
+	}
+}
+
+// benchHub writes a Hub with n skills and n/4 workflows, each a few KB.
+func benchHub(b *testing.B, n int) string {
+	b.Helper()
+	repo := b.TempDir()
+	body := strings.Repeat("Run the migration, check the logs, and roll back on failure.\n", 40)
+	for i := 0; i < n; i++ {
+		dir := filepath.Join(repo, "skills", fmt.Sprintf("skill-%04d", i))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			b.Fatal(err)
+		}
+		content := fmt.Sprintf("---\nname: skill-%04d\ndescription: Skill number %d\ntags: [bench, db]\n---\n%s", i, i, body)
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	wf := filepath.Join(repo, "workflows")
+	if err := os.MkdirAll(wf, 0o755); err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < n/4; i++ {
+		content := fmt.Sprintf("---\ndescription: Workflow %d\n---\n%s", i, body)
+		if err := os.WriteFile(filepath.Join(wf, fmt.Sprintf("wf-%04d.md", i)), []byte(content), 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return repo
+}
+
+func BenchmarkDiscoverDocuments(b *testing.B) {
+	repo := benchHub(b, 2000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DiscoverDocuments(repo, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
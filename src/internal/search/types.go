@@ -1,5 +1,10 @@
 package search
 
+import (
+	"sort"
+	"strings"
+)
+
 // SkillDoc represents the minimal searchable metadata for a skill.
 type SkillDoc struct {
 	ID          string
@@ -7,6 +12,44 @@ type SkillDoc struct {
 	Name        string
 	Description string
 	Keywords    string
+	Tags        []string // from the tags (or keywords) frontmatter field
+
+	// Body is the markdown after the frontmatter, used for chunk
+	// embeddings. It is not persisted in the keyword index.
+	Body string
+
+	// Localized holds translated variants of name, description, and
+	// keywords from the frontmatter, keyed as written ("description_zh").
+	Localized map[string]string
+
+	// Repo names the Hub repo the document came from when several repos are
+	// searched; empty for a single-repo search.
+	Repo string
+}
+
+// LocalizedKeys returns the keys of s.Localized in order.
+func (s SkillDoc) LocalizedKeys() []string {
+	keys := make([]string, 0, len(s.Localized))
+	for k := range s.Localized {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LocalizedText returns the localized values, one per line in key order.
+func (s SkillDoc) LocalizedText() string {
+	lines := make([]string, 0, len(s.Localized))
+	for _, k := range s.LocalizedKeys() {
+		lines = append(lines, s.Localized[k])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RepoRoot is a Hub repo to search, in priority order.
+type RepoRoot struct {
+	Name string
+	Path string
 }
 
 // SearchResult represents one matched skill.
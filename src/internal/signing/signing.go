@@ -0,0 +1,309 @@
+// Package signing produces and verifies detached signatures for Hub skills.
+//
+// A signature covers a canonical manifest of the skill directory: one
+// "<sha256>  <relative path>" line per file, sorted by path. The manifest and
+// its detached signature are stored in <skill>/.axon-sig/. Signing shells out
+// to ssh-keygen (-Y sign) or minisign, whichever matches the configured key.
+package signing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/hash"
+)
+
+const (
+	// SigDir is the per-skill directory holding the manifest and signature.
+	SigDir = ".axon-sig"
+	// ManifestFile is the signed manifest inside SigDir.
+	ManifestFile = "manifest"
+	// SignatureFile is the detached signature of ManifestFile inside SigDir.
+	SignatureFile = "manifest.sig"
+	// Namespace scopes ssh signatures so they cannot be replayed elsewhere.
+	Namespace = "axon-skill"
+)
+
+// Signature methods.
+const (
+	MethodSSH      = "ssh"
+	MethodMinisign = "minisign"
+)
+
+var (
+	// ErrUnsigned means the skill has no signature files.
+	ErrUnsigned = errors.New("skill is not signed")
+	// ErrBadSignature means the signature does not verify against a trusted key.
+	ErrBadSignature = errors.New("signature verification failed")
+)
+
+// TamperedError reports files whose content no longer matches the signed manifest.
+type TamperedError struct {
+	Changed []string
+}
+
+func (e *TamperedError) Error() string {
+	return fmt.Sprintf("content changed since signing: %s", strings.Join(e.Changed, ", "))
+}
+
+// Config holds key locations used for signing and verification.
+type Config struct {
+	Key            string // private key used by Sign (ssh or minisign secret key)
+	AllowedSigners string // ssh allowed_signers file used by Verify
+	MinisignPubKey string // minisign public key used by Verify
+}
+
+// LoadConfig resolves signing config from environment variables first, then
+// ~/.axon/.env. Verification keys default to files under ~/.axon/.
+func LoadConfig() (*Config, error) {
+	key, err := config.GetConfigValue("AXON_SIGNING_KEY")
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := config.GetConfigValue("AXON_ALLOWED_SIGNERS")
+	if err != nil {
+		return nil, err
+	}
+	pub, err := config.GetConfigValue("AXON_MINISIGN_PUBKEY")
+	if err != nil {
+		return nil, err
+	}
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return nil, err
+	}
+	if allowed == "" {
+		allowed = filepath.Join(axonDir, "allowed_signers")
+	}
+	if pub == "" {
+		pub = filepath.Join(axonDir, "minisign.pub")
+	}
+	for _, p := range []*string{&key, &allowed, &pub} {
+		if *p == "" {
+			continue
+		}
+		if *p, err = config.ExpandPath(*p); err != nil {
+			return nil, err
+		}
+	}
+	return &Config{Key: key, AllowedSigners: allowed, MinisignPubKey: pub}, nil
+}
+
+// Manifest returns the canonical manifest of dir. The signature directory and
+// any .git directory are excluded.
+func Manifest(dir string) ([]byte, error) {
+	var lines []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != dir && (d.Name() == SigDir || d.Name() == ".git") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		var digest string
+		if d.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			digest = "link:" + filepath.ToSlash(target)
+		} else if d.Type().IsRegular() {
+			if digest, err = hash.File(p); err != nil {
+				return err
+			}
+		} else {
+			return nil
+		}
+		lines = append(lines, digest+"  "+rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(lines, func(i, j int) bool { return pathOf(lines[i]) < pathOf(lines[j]) })
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// DetectMethod guesses the signature method from a key or signature file.
+func DetectMethod(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	head := string(b)
+	switch {
+	case strings.HasPrefix(head, "untrusted comment:"):
+		return MethodMinisign, nil
+	case strings.Contains(head, "SSH SIGNATURE"), strings.Contains(head, "OPENSSH PRIVATE KEY"),
+		strings.HasPrefix(head, "ssh-"), strings.HasPrefix(head, "ecdsa-"):
+		return MethodSSH, nil
+	}
+	return "", fmt.Errorf("unrecognised key or signature format: %s", path)
+}
+
+// Sign writes a fresh manifest and detached signature for dir using cfg.Key.
+// It returns the method used.
+func Sign(dir string, cfg *Config) (string, error) {
+	if cfg == nil || cfg.Key == "" {
+		return "", fmt.Errorf("no signing key configured (set AXON_SIGNING_KEY)")
+	}
+	method, err := DetectMethod(cfg.Key)
+	if err != nil {
+		return "", err
+	}
+
+	manifest, err := Manifest(dir)
+	if err != nil {
+		return "", fmt.Errorf("cannot build manifest: %w", err)
+	}
+	sigDir := filepath.Join(dir, SigDir)
+	if err := os.MkdirAll(sigDir, 0o755); err != nil {
+		return "", err
+	}
+	manifestPath := filepath.Join(sigDir, ManifestFile)
+	sigPath := filepath.Join(sigDir, SignatureFile)
+	if err := os.WriteFile(manifestPath, manifest, 0o644); err != nil {
+		return "", err
+	}
+	_ = os.Remove(sigPath)
+
+	var cmd *exec.Cmd
+	switch method {
+	case MethodSSH:
+		// ssh-keygen writes <file>.sig next to the input, which is SignatureFile.
+		cmd = exec.Command("ssh-keygen", "-Y", "sign", "-q", "-f", cfg.Key, "-n", Namespace, manifestPath)
+	case MethodMinisign:
+		cmd = exec.Command("minisign", "-S", "-s", cfg.Key, "-m", manifestPath, "-x", sigPath)
+		cmd.Stdin = os.Stdin
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("%s signing failed: %w\n%s", method, err, strings.TrimSpace(string(out)))
+	}
+	return method, nil
+}
+
+// Verify checks that dir matches its signed manifest and that the signature
+// was made by a trusted key. It returns the signer principal for ssh
+// signatures (empty for minisign).
+func Verify(dir string, cfg *Config) (string, error) {
+	sigDir := filepath.Join(dir, SigDir)
+	manifestPath := filepath.Join(sigDir, ManifestFile)
+	sigPath := filepath.Join(sigDir, SignatureFile)
+
+	stored, err := os.ReadFile(manifestPath)
+	if os.IsNotExist(err) {
+		return "", ErrUnsigned
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(sigPath); os.IsNotExist(err) {
+		return "", ErrUnsigned
+	}
+
+	current, err := Manifest(dir)
+	if err != nil {
+		return "", fmt.Errorf("cannot build manifest: %w", err)
+	}
+	if changed := diffManifests(stored, current); len(changed) > 0 {
+		return "", &TamperedError{Changed: changed}
+	}
+
+	method, err := DetectMethod(sigPath)
+	if err != nil {
+		return "", err
+	}
+	switch method {
+	case MethodSSH:
+		return verifySSH(cfg, manifestPath, sigPath)
+	default:
+		return "", verifyMinisign(cfg, manifestPath, sigPath)
+	}
+}
+
+func verifySSH(cfg *Config, manifestPath, sigPath string) (string, error) {
+	if _, err := os.Stat(cfg.AllowedSigners); err != nil {
+		return "", fmt.Errorf("no allowed signers file at %s (set AXON_ALLOWED_SIGNERS)", cfg.AllowedSigners)
+	}
+	out, err := exec.Command("ssh-keygen", "-Y", "find-principals", "-f", cfg.AllowedSigners, "-s", sigPath).Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: signer is not in %s", ErrBadSignature, cfg.AllowedSigners)
+	}
+	principal := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command("ssh-keygen", "-Y", "verify", "-f", cfg.AllowedSigners, "-I", principal, "-n", Namespace, "-s", sigPath)
+	cmd.Stdin = bytes.NewReader(data)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrBadSignature, strings.TrimSpace(string(out)))
+	}
+	return principal, nil
+}
+
+func verifyMinisign(cfg *Config, manifestPath, sigPath string) error {
+	if _, err := os.Stat(cfg.MinisignPubKey); err != nil {
+		return fmt.Errorf("no minisign public key at %s (set AXON_MINISIGN_PUBKEY)", cfg.MinisignPubKey)
+	}
+	out, err := exec.Command("minisign", "-V", "-q", "-p", cfg.MinisignPubKey, "-m", manifestPath, "-x", sigPath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBadSignature, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// diffManifests returns the paths added, removed, or modified between two
+// manifests.
+func diffManifests(stored, current []byte) []string {
+	parse := func(b []byte) map[string]string {
+		m := make(map[string]string)
+		for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+			if line == "" {
+				continue
+			}
+			if i := strings.Index(line, "  "); i > 0 {
+				m[line[i+2:]] = line[:i]
+			}
+		}
+		return m
+	}
+	a, b := parse(stored), parse(current)
+	var changed []string
+	for p, d := range a {
+		if b[p] != d {
+			changed = append(changed, p)
+		}
+	}
+	for p := range b {
+		if _, ok := a[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func pathOf(line string) string {
+	if i := strings.Index(line, "  "); i > 0 {
+		return line[i+2:]
+	}
+	return line
+}
@@ -0,0 +1,115 @@
+package signing
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManifest_ExcludesSigDirAndIsSorted(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "SKILL.md"), "# s\n")
+	writeFile(t, filepath.Join(dir, "a", "z.txt"), "z\n")
+	writeFile(t, filepath.Join(dir, SigDir, ManifestFile), "old\n")
+
+	m, err := Manifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(m)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("manifest = %q", m)
+	}
+	if !strings.HasSuffix(lines[0], "  SKILL.md") || !strings.HasSuffix(lines[1], "  a/z.txt") {
+		t.Fatalf("unexpected order: %q", lines)
+	}
+}
+
+func TestDiffManifests(t *testing.T) {
+	a := []byte("h1  a\nh2  b\n")
+	b := []byte("h1  a\nhX  b\nh3  c\n")
+	got := diffManifests(a, b)
+	if strings.Join(got, ",") != "b,c" {
+		t.Fatalf("diff = %v", got)
+	}
+}
+
+// sshKey generates an ed25519 key pair and an allowed_signers file trusting it.
+func sshKey(t *testing.T, principal string) (key, allowed string) {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	dir := t.TempDir()
+	key = filepath.Join(dir, "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", principal, "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v\n%s", err, out)
+	}
+	pub, err := os.ReadFile(key + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed = filepath.Join(dir, "allowed_signers")
+	writeFile(t, allowed, principal+" "+string(pub))
+	return key, allowed
+}
+
+func TestSignVerify_SSH(t *testing.T) {
+	key, allowed := sshKey(t, "dev@example.com")
+	cfg := &Config{Key: key, AllowedSigners: allowed}
+
+	skill := t.TempDir()
+	writeFile(t, filepath.Join(skill, "SKILL.md"), "---\nsigned: true\n---\n")
+
+	if _, err := Verify(skill, cfg); !errors.Is(err, ErrUnsigned) {
+		t.Fatalf("expected ErrUnsigned, got %v", err)
+	}
+
+	method, err := Sign(skill, cfg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if method != MethodSSH {
+		t.Fatalf("method = %q", method)
+	}
+	signer, err := Verify(skill, cfg)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if signer != "dev@example.com" {
+		t.Fatalf("signer = %q", signer)
+	}
+
+	writeFile(t, filepath.Join(skill, "SKILL.md"), "---\nsigned: true\n---\nevil\n")
+	var tampered *TamperedError
+	if _, err := Verify(skill, cfg); !errors.As(err, &tampered) || tampered.Changed[0] != "SKILL.md" {
+		t.Fatalf("expected TamperedError for SKILL.md, got %v", err)
+	}
+}
+
+func TestVerify_UntrustedSigner(t *testing.T) {
+	key, _ := sshKey(t, "mallory@example.com")
+	_, allowed := sshKey(t, "dev@example.com")
+
+	skill := t.TempDir()
+	writeFile(t, filepath.Join(skill, "SKILL.md"), "x\n")
+	if _, err := Sign(skill, &Config{Key: key}); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if _, err := Verify(skill, &Config{AllowedSigners: allowed}); !errors.Is(err, ErrBadSignature) {
+		t.Fatalf("expected ErrBadSignature, got %v", err)
+	}
+}
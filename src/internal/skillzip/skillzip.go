@@ -0,0 +1,317 @@
+// Package skillzip converts between Claude skill zips and the Hub layout.
+//
+// A Claude skill zip holds one skill: SKILL.md plus its resources, either at
+// the archive root or inside a single top-level folder named after the
+// skill. Its frontmatter keeps only name, description, license, and
+// allowed-tools at the top level and nests everything else under metadata,
+// while Hub skills keep Axon's fields (version, requires, triggers, ...) at
+// the top level. ToHub and FromHub map one form onto the other.
+package skillzip
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kamusis/axon-cli/internal/frontmatter"
+	"gopkg.in/yaml.v3"
+)
+
+// SkillFile is the file that makes a directory a skill.
+const SkillFile = "SKILL.md"
+
+// MaxSize caps the total uncompressed size Extract accepts.
+const MaxSize = 256 << 20
+
+// metadataKey holds the frontmatter fields Claude does not define.
+const metadataKey = "metadata"
+
+// topLevel are the frontmatter fields Claude keeps at the top level.
+var topLevel = map[string]bool{
+	"name":          true,
+	"description":   true,
+	"license":       true,
+	"allowed-tools": true,
+	metadataKey:     true,
+}
+
+// Extract unpacks the skill zip at zipPath into destDir and returns the
+// directory holding SKILL.md together with the skill name the zip suggests:
+// its top-level folder, or the zip's base name when files sit at the root.
+// Absolute paths, entries escaping destDir, and symlinks are refused; macOS
+// metadata (__MACOSX/, .DS_Store) is skipped.
+func Extract(zipPath, destDir string) (skillDir, name string, err error) {
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return "", "", fmt.Errorf("not a zip archive: %w", err)
+	}
+	defer zr.Close()
+
+	var total uint64
+	var files []string
+	for _, f := range zr.File {
+		rel, ok := entryPath(f.Name)
+		if !ok {
+			return "", "", fmt.Errorf("unsafe archive entry %q", f.Name)
+		}
+		if rel == "" || skipped(rel) {
+			continue
+		}
+		mode := f.Mode()
+		if mode&fs.ModeSymlink != 0 {
+			return "", "", fmt.Errorf("archive entry %s is a symlink; skill zips cannot contain symlinks", rel)
+		}
+		dst := filepath.Join(destDir, filepath.FromSlash(rel))
+		if mode.IsDir() {
+			if err := os.MkdirAll(dst, 0o755); err != nil {
+				return "", "", err
+			}
+			continue
+		}
+		if total += f.UncompressedSize64; total > MaxSize {
+			return "", "", fmt.Errorf("archive expands to more than %d MiB", MaxSize>>20)
+		}
+		if err := extractFile(f, dst); err != nil {
+			return "", "", fmt.Errorf("cannot extract %s: %w", rel, err)
+		}
+		files = append(files, rel)
+	}
+
+	root, err := skillRoot(files)
+	if err != nil {
+		return "", "", err
+	}
+	if root == "" {
+		name = strings.TrimSuffix(filepath.Base(zipPath), filepath.Ext(zipPath))
+		return destDir, name, nil
+	}
+	return filepath.Join(destDir, root), root, nil
+}
+
+// entryPath cleans a zip entry name to a slash-separated relative path.
+func entryPath(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(name, "/") || (len(name) > 1 && name[1] == ':') {
+		return "", false
+	}
+	rel := path.Clean(name)
+	if rel == "." {
+		return "", true
+	}
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", false
+	}
+	return rel, true
+}
+
+// skipped reports whether rel is archiver metadata rather than skill content.
+func skipped(rel string) bool {
+	return rel == "__MACOSX" || strings.HasPrefix(rel, "__MACOSX/") || path.Base(rel) == ".DS_Store"
+}
+
+func extractFile(f *zip.File, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	in, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	perm := f.Mode().Perm()
+	if perm == 0 {
+		perm = 0o644
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, io.LimitReader(in, MaxSize+1)); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// skillRoot returns the folder holding SKILL.md: "" for the archive root,
+// or the single top-level folder every file lives in.
+func skillRoot(files []string) (string, error) {
+	for _, f := range files {
+		if f == SkillFile {
+			return "", nil
+		}
+	}
+	var root string
+	for _, f := range files {
+		top, _, nested := strings.Cut(f, "/")
+		if !nested {
+			return "", fmt.Errorf("no %s at the archive root", SkillFile)
+		}
+		if root != "" && top != root {
+			return "", fmt.Errorf("no %s at the archive root, and more than one top-level folder (%s, %s)", SkillFile, root, top)
+		}
+		root = top
+	}
+	for _, f := range files {
+		if f == root+"/"+SkillFile {
+			return root, nil
+		}
+	}
+	return "", fmt.Errorf("archive has no %s", SkillFile)
+}
+
+// ToHub maps the frontmatter of a Claude SKILL.md to the Hub layout: keys
+// under metadata move to the top level unless the top level already has
+// them, and name is set to the skill name, the directory it lands in.
+func ToHub(data []byte, name string) ([]byte, error) {
+	doc, err := frontmatter.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if meta, ok := doc.Get(metadataKey); ok && meta.Kind == yaml.MappingNode {
+		var kept int
+		for i := 0; i+1 < len(meta.Content); i += 2 {
+			key, value := meta.Content[i].Value, meta.Content[i+1]
+			if _, exists := doc.Get(key); exists || strings.Contains(key, ".") {
+				kept++
+				continue
+			}
+			if err := doc.SetNode(key, value); err != nil {
+				return nil, err
+			}
+			doc.Delete(metadataKey + "." + key)
+			i -= 2
+		}
+		if kept == 0 {
+			doc.Delete(metadataKey)
+		}
+	}
+	if err := doc.SetNode("name", str(name)); err != nil {
+		return nil, err
+	}
+	return doc.Bytes()
+}
+
+// FromHub maps the frontmatter of a Hub SKILL.md to the Claude layout:
+// fields Claude does not define move under metadata, and name is set to the
+// skill name, which Claude requires to match the folder. A skill without a
+// description is an error, since Claude would not load it.
+func FromHub(data []byte, name string) ([]byte, error) {
+	doc, err := frontmatter.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := doc.Get("description"); !ok || strings.TrimSpace(d.Value) == "" {
+		return nil, errors.New("SKILL.md has no description; Claude requires one")
+	}
+	if meta, ok := doc.Get(metadataKey); ok && meta.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s in SKILL.md is not a mapping", metadataKey)
+	}
+	for _, key := range doc.Keys() {
+		if topLevel[key] || strings.Contains(key, ".") {
+			continue
+		}
+		value, _ := doc.Get(key)
+		if _, exists := doc.Get(metadataKey + "." + key); !exists {
+			if err := doc.SetNode(metadataKey+"."+key, value); err != nil {
+				return nil, err
+			}
+		}
+		doc.Delete(key)
+	}
+	if err := doc.SetNode("name", str(name)); err != nil {
+		return nil, err
+	}
+	return doc.Bytes()
+}
+
+// str returns s as a YAML string node, quoted only where YAML needs it.
+func str(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+// Write writes the skill directory skillDir as a Claude skill zip to w,
+// with every entry under the folder name and SKILL.md converted by
+// FromHub. Symlinks to files are stored as the files they point to; other
+// symlinks, .git, and paths matching skip are left out.
+func Write(w io.Writer, skillDir, name string, skip func(rel string) bool) error {
+	skillMD, err := os.ReadFile(filepath.Join(skillDir, SkillFile))
+	if err != nil {
+		return err
+	}
+	converted, err := FromHub(skillMD, name)
+	if err != nil {
+		return err
+	}
+
+	var rels []string
+	err = filepath.WalkDir(skillDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(skillDir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.Name() == ".git" || skipped(rel) || (skip != nil && skip(rel)) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			rels = append(rels, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(rels)
+
+	zw := zip.NewWriter(w)
+	for _, rel := range rels {
+		p := filepath.Join(skillDir, filepath.FromSlash(rel))
+		info, err := os.Stat(p)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		h, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		h.Name = name + "/" + rel
+		h.Method = zip.Deflate
+		fw, err := zw.CreateHeader(h)
+		if err != nil {
+			return err
+		}
+		if rel == SkillFile {
+			_, err = fw.Write(converted)
+		} else {
+			err = copyInto(fw, p)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", rel, err)
+		}
+	}
+	return zw.Close()
+}
+
+func copyInto(w io.Writer, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
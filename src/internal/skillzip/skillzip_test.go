@@ -0,0 +1,115 @@
+package skillzip
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "skill.zip")
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestExtract(t *testing.T) {
+	zipPath := writeZip(t, map[string]string{
+		"pdf/SKILL.md":            "---\nname: pdf\ndescription: PDFs\n---\n",
+		"pdf/scripts/fill.py":     "print()\n",
+		"__MACOSX/pdf/._SKILL.md": "junk",
+	})
+	dest := t.TempDir()
+	dir, name, err := Extract(zipPath, dest)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if name != "pdf" || dir != filepath.Join(dest, "pdf") {
+		t.Errorf("Extract = %s, %s", dir, name)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scripts", "fill.py")); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "__MACOSX")); !os.IsNotExist(err) {
+		t.Errorf("__MACOSX should be skipped, stat err = %v", err)
+	}
+
+	// Files at the root take the zip's name.
+	root := writeZip(t, map[string]string{"SKILL.md": "---\ndescription: x\n---\n"})
+	if _, name, err := Extract(root, t.TempDir()); err != nil || name != "skill" {
+		t.Errorf("root zip: name = %q, err = %v", name, err)
+	}
+}
+
+func TestExtract_Refuses(t *testing.T) {
+	for name, files := range map[string]map[string]string{
+		"escape":     {"../evil/SKILL.md": "x"},
+		"absolute":   {"/etc/SKILL.md": "x"},
+		"no skill":   {"pdf/README.md": "x"},
+		"two skills": {"a/SKILL.md": "x", "b/SKILL.md": "x"},
+	} {
+		if _, _, err := Extract(writeZip(t, files), t.TempDir()); err == nil {
+			t.Errorf("%s: Extract should fail", name)
+		}
+	}
+}
+
+func TestFrontmatterRoundTrip(t *testing.T) {
+	hub := "---\nname: pdf\ndescription: Fill PDF forms\nversion: 1.2.0\nrequires:\n  bins: [qpdf]\n---\n# PDF\n"
+	claude, err := FromHub([]byte(hub), "pdf")
+	if err != nil {
+		t.Fatalf("FromHub: %v", err)
+	}
+	got := string(claude)
+	if !strings.Contains(got, "metadata:\n  version: 1.2.0\n") || strings.Contains(got, "\nversion:") {
+		t.Errorf("FromHub should nest version under metadata:\n%s", got)
+	}
+	if !strings.HasSuffix(got, "---\n# PDF\n") {
+		t.Errorf("FromHub should keep the body:\n%s", got)
+	}
+
+	back, err := ToHub(claude, "pdf")
+	if err != nil {
+		t.Fatalf("ToHub: %v", err)
+	}
+	if got := string(back); !strings.Contains(got, "\nversion: 1.2.0\n") || strings.Contains(got, "metadata") {
+		t.Errorf("ToHub should lift metadata to the top level:\n%s", got)
+	}
+
+	if _, err := FromHub([]byte("---\nname: pdf\n---\n"), "pdf"); err == nil {
+		t.Error("FromHub should require a description")
+	}
+}
+
+func TestToHub_KeepsTopLevel(t *testing.T) {
+	in := "---\ndescription: x\nversion: 2.0.0\nmetadata:\n  version: 1.0.0\n  owner: me\n---\n"
+	out, err := ToHub([]byte(in), "tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(out)
+	for _, want := range []string{"\nversion: 2.0.0\n", "\nowner: me\n", "metadata:\n  version: 1.0.0\n", "name: tool\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ToHub output lacks %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package system
+
+import (
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FaultFS wraps an FS and fails the operations Fault picks. Fault gets the
+// operation name (the FS method, such as "Symlink") and the path it works
+// on (the new path for Symlink and Rename); a nil error lets the call
+// through to FS.
+type FaultFS struct {
+	FS
+	Fault func(op, path string) error
+}
+
+func (f FaultFS) fail(op, path string) error {
+	if f.Fault == nil {
+		return nil
+	}
+	return f.Fault(op, path)
+}
+
+func (f FaultFS) Stat(name string) (fs.FileInfo, error) {
+	if err := f.fail("Stat", name); err != nil {
+		return nil, err
+	}
+	return f.FS.Stat(name)
+}
+
+func (f FaultFS) Lstat(name string) (fs.FileInfo, error) {
+	if err := f.fail("Lstat", name); err != nil {
+		return nil, err
+	}
+	return f.FS.Lstat(name)
+}
+
+func (f FaultFS) Readlink(name string) (string, error) {
+	if err := f.fail("Readlink", name); err != nil {
+		return "", err
+	}
+	return f.FS.Readlink(name)
+}
+
+func (f FaultFS) Symlink(oldname, newname string) error {
+	if err := f.fail("Symlink", newname); err != nil {
+		return err
+	}
+	return f.FS.Symlink(oldname, newname)
+}
+
+func (f FaultFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := f.fail("ReadDir", name); err != nil {
+		return nil, err
+	}
+	return f.FS.ReadDir(name)
+}
+
+func (f FaultFS) ReadFile(name string) ([]byte, error) {
+	if err := f.fail("ReadFile", name); err != nil {
+		return nil, err
+	}
+	return f.FS.ReadFile(name)
+}
+
+func (f FaultFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if err := f.fail("WriteFile", name); err != nil {
+		return err
+	}
+	return f.FS.WriteFile(name, data, perm)
+}
+
+func (f FaultFS) MkdirAll(path string, perm fs.FileMode) error {
+	if err := f.fail("MkdirAll", path); err != nil {
+		return err
+	}
+	return f.FS.MkdirAll(path, perm)
+}
+
+func (f FaultFS) Rename(oldpath, newpath string) error {
+	if err := f.fail("Rename", newpath); err != nil {
+		return err
+	}
+	return f.FS.Rename(oldpath, newpath)
+}
+
+func (f FaultFS) Remove(name string) error {
+	if err := f.fail("Remove", name); err != nil {
+		return err
+	}
+	return f.FS.Remove(name)
+}
+
+func (f FaultFS) RemoveAll(path string) error {
+	if err := f.fail("RemoveAll", path); err != nil {
+		return err
+	}
+	return f.FS.RemoveAll(path)
+}
+
+// FakeClock is a Clock that only moves when told to.
+type FakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+// NewFakeClock returns a FakeClock stopped at t.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{t: t}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// FakeRunner records the commands it is given and answers them with
+// Handle instead of running them. Handle gets the command line (program
+// first) and returns what the command writes to stdout and its error; a
+// nil Handle makes every command succeed silently.
+type FakeRunner struct {
+	Handle func(args []string) (string, error)
+
+	mu    sync.Mutex
+	calls [][]string
+}
+
+func (r *FakeRunner) Run(c *exec.Cmd) error {
+	args := append([]string(nil), c.Args...)
+	r.mu.Lock()
+	r.calls = append(r.calls, args)
+	r.mu.Unlock()
+	if r.Handle == nil {
+		return nil
+	}
+	out, err := r.Handle(args)
+	if out != "" && c.Stdout != nil {
+		if _, werr := fmt.Fprint(c.Stdout, out); werr != nil {
+			return werr
+		}
+	}
+	return err
+}
+
+// Calls returns the command lines run so far, each joined with spaces.
+func (r *FakeRunner) Calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]string, len(r.calls))
+	for i, c := range r.calls {
+		out[i] = strings.Join(c, " ")
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package system
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFaultFS(t *testing.T) {
+	dir := t.TempDir()
+	errDenied := errors.New("denied")
+	f := FaultFS{FS: OSFS{}, Fault: func(op, path string) error {
+		if op == "WriteFile" && filepath.Base(path) == "blocked" {
+			return errDenied
+		}
+		return nil
+	}}
+	if err := f.WriteFile(filepath.Join(dir, "blocked"), []byte("x"), 0o644); !errors.Is(err, errDenied) {
+		t.Errorf("WriteFile(blocked) = %v, want the injected error", err)
+	}
+	if err := f.WriteFile(filepath.Join(dir, "ok"), []byte("x"), 0o644); err != nil {
+		t.Errorf("WriteFile(ok) = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "blocked")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("a failed write should not reach the disk, stat = %v", err)
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := NewFakeClock(start)
+	c.Advance(90 * time.Second)
+	if got := c.Now(); !got.Equal(start.Add(90 * time.Second)) {
+		t.Errorf("Now = %v", got)
+	}
+}
+
+func TestFakeRunner(t *testing.T) {
+	r := &FakeRunner{Handle: func(args []string) (string, error) {
+		return "main\n", nil
+	}}
+	var out bytes.Buffer
+	c := exec.Command("git", "branch", "--show-current")
+	c.Stdout = &out
+	if err := r.Run(c); err != nil || out.String() != "main\n" {
+		t.Errorf("output = %q, %v", out.String(), err)
+	}
+	if calls := r.Calls(); len(calls) != 1 || calls[0] != "git branch --show-current" {
+		t.Errorf("Calls = %q", calls)
+	}
+}
@@ -0,0 +1,67 @@
+// Package system puts the filesystem, the clock, and external commands
+// behind small interfaces. The link, unlink, and sync code in cmd uses them
+// instead of os, time, and os/exec directly, so its tests can swap in the
+// fakes of this package: inject a Windows symlink error on Linux, freeze the
+// backup timestamp, or answer git without running it.
+package system
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// FS is the subset of package os that linking and syncing needs.
+type FS interface {
+	Stat(name string) (fs.FileInfo, error)
+	Lstat(name string) (fs.FileInfo, error)
+	Readlink(name string) (string, error)
+	Symlink(oldname, newname string) error
+	ReadDir(name string) ([]fs.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+	Rename(oldpath, newpath string) error
+	Remove(name string) error
+	RemoveAll(path string) error
+}
+
+// OSFS is the real filesystem.
+type OSFS struct{}
+
+func (OSFS) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
+func (OSFS) Lstat(name string) (fs.FileInfo, error)     { return os.Lstat(name) }
+func (OSFS) Readlink(name string) (string, error)       { return os.Readlink(name) }
+func (OSFS) Symlink(oldname, newname string) error      { return os.Symlink(oldname, newname) }
+func (OSFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (OSFS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (OSFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+func (OSFS) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+func (OSFS) Rename(oldpath, newpath string) error         { return os.Rename(oldpath, newpath) }
+func (OSFS) Remove(name string) error                     { return os.Remove(name) }
+func (OSFS) RemoveAll(path string) error                  { return os.RemoveAll(path) }
+
+// Clock tells the time.
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock is the system clock.
+type RealClock struct{}
+
+func (RealClock) Now() time.Time { return time.Now() }
+
+// Runner runs external commands. Callers build the *exec.Cmd as usual,
+// with its Dir, Env, and output writers, and hand it over instead of
+// calling c.Run.
+type Runner interface {
+	Run(c *exec.Cmd) error
+}
+
+// ExecRunner runs commands for real.
+type ExecRunner struct{}
+
+func (ExecRunner) Run(c *exec.Cmd) error { return c.Run() }
@@ -0,0 +1,312 @@
+// Package termmd renders Markdown for a terminal: headings, paragraphs
+// wrapped to the terminal width, lists, block quotes, code blocks, and
+// inline emphasis, code, and links. It covers what skills and workflows
+// use, not all of CommonMark; anything it does not know is shown as written.
+package termmd
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// Options controls rendering.
+type Options struct {
+	// Width is the column paragraphs are wrapped at; 0 means 80.
+	Width int
+	// Color styles the output with ANSI escape sequences. Without it,
+	// emphasis markers are dropped and the text is left plain.
+	Color bool
+}
+
+// ANSI SGR codes.
+const (
+	sgrBold      = "1"
+	sgrDim       = "2"
+	sgrItalic    = "3"
+	sgrUnderline = "4"
+	sgrYellow    = "33"
+	sgrCyan      = "36"
+)
+
+var (
+	headingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	bulletRe   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	orderedRe  = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	taskRe     = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	setextRe   = regexp.MustCompile(`^(=+|-+)$`)
+	ruleRe     = regexp.MustCompile(`^\s*([-*_])(\s*([-*_]))\s*([-*_]\s*)+$`)
+	fenceRe    = regexp.MustCompile("^\\s*(```+|~~~+)\\s*([^`\\s]*)")
+	linkRe     = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	codeSpanRe = regexp.MustCompile("`([^`]+)`")
+	strongRe   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	emRe       = regexp.MustCompile(`(^|[^\w*])\*([^*\s][^*]*)\*|(^|[^\w_])_([^_\s][^_]*)_`)
+	ansiRe     = regexp.MustCompile("\x1b\\[[0-9;]*m")
+)
+
+// Render returns src rendered for a terminal, ending in a newline; an
+// empty document renders as "".
+func Render(src []byte, opts Options) string {
+	if opts.Width <= 0 {
+		opts.Width = 80
+	}
+	r := &renderer{opts: opts}
+	r.render(strings.Split(strings.ReplaceAll(string(src), "\r\n", "\n"), "\n"))
+	out := strings.Trim(r.out.String(), "\n")
+	if out == "" {
+		return ""
+	}
+	return out + "\n"
+}
+
+type renderer struct {
+	opts Options
+	out  strings.Builder
+	para []string // lines of the paragraph being collected
+}
+
+func (r *renderer) paint(code, s string) string {
+	if !r.opts.Color || s == "" {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
+
+// blank ends the current block with one empty line, never more.
+func (r *renderer) blank() {
+	s := r.out.String()
+	if s != "" && !strings.HasSuffix(s, "\n\n") {
+		r.out.WriteString("\n")
+	}
+}
+
+func (r *renderer) flush() {
+	if len(r.para) == 0 {
+		return
+	}
+	text := strings.Join(r.para, " ")
+	r.para = nil
+	r.wrap(r.inline(text), "", "")
+	r.blank()
+}
+
+func (r *renderer) render(lines []string) {
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			r.flush()
+
+		case strings.HasPrefix(trimmed, "<!--"):
+			// HTML comments, such as vendor provenance, are not shown.
+			r.flush()
+			for !strings.Contains(lines[i], "-->") && i+1 < len(lines) {
+				i++
+			}
+
+		case fenceRe.MatchString(line):
+			r.flush()
+			m := fenceRe.FindStringSubmatch(line)
+			if m[2] != "" {
+				r.out.WriteString("    " + r.paint(sgrDim, m[2]) + "\n")
+			}
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1][:3]) {
+					break
+				}
+				r.out.WriteString("    " + r.paint(sgrYellow, strings.TrimRight(lines[i], " \t")) + "\n")
+			}
+			r.blank()
+
+		case headingRe.MatchString(trimmed):
+			r.flush()
+			m := headingRe.FindStringSubmatch(trimmed)
+			r.heading(len(m[1]), m[2])
+
+		case len(r.para) > 0 && setextRe.MatchString(trimmed):
+			// "Title" underlined with === or --- is a level 1 or 2 heading.
+			text := strings.Join(r.para, " ")
+			r.para = nil
+			level := 1
+			if trimmed[0] == '-' {
+				level = 2
+			}
+			r.heading(level, text)
+
+		case ruleRe.MatchString(trimmed) && len(r.para) == 0:
+			r.out.WriteString(r.paint(sgrDim, strings.Repeat("─", r.opts.Width)) + "\n")
+			r.blank()
+
+		case strings.HasPrefix(trimmed, ">"):
+			r.flush()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote = append(quote, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")))
+			}
+			i--
+			bar := r.paint(sgrDim, "│ ")
+			r.wrap(r.paint(sgrItalic, r.inline(strings.Join(quote, " "))), bar, bar)
+			r.blank()
+
+		case strings.HasPrefix(trimmed, "|"):
+			// Tables are kept as written, which lines up in a monospace font.
+			r.flush()
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				r.out.WriteString("  " + r.inline(strings.TrimSpace(lines[i])) + "\n")
+			}
+			i--
+			r.blank()
+
+		case bulletRe.MatchString(line) || orderedRe.MatchString(line):
+			r.flush()
+			i = r.list(lines, i) - 1
+			r.blank()
+
+		default:
+			r.para = append(r.para, trimmed)
+		}
+	}
+	r.flush()
+}
+
+// heading writes a heading: levels 1 and 2 are underlined, the others only
+// bold.
+func (r *renderer) heading(level int, text string) {
+	plain := stripANSI(r.inline(text))
+	switch level {
+	case 1:
+		r.out.WriteString(r.paint(sgrBold+";"+sgrCyan, plain) + "\n")
+		r.out.WriteString(r.paint(sgrCyan, strings.Repeat("═", min(visibleWidth(plain), r.opts.Width))) + "\n")
+	case 2:
+		r.out.WriteString(r.paint(sgrBold+";"+sgrCyan, plain) + "\n")
+		r.out.WriteString(r.paint(sgrCyan, strings.Repeat("─", min(visibleWidth(plain), r.opts.Width))) + "\n")
+	default:
+		r.out.WriteString(r.paint(sgrBold, plain) + "\n")
+	}
+	r.blank()
+}
+
+// list renders the list starting at lines[i] and returns the index of the
+// first line after it. Continuation lines are joined to their item.
+func (r *renderer) list(lines []string, i int) int {
+	for i < len(lines) {
+		line := lines[i]
+		var indent, marker, text string
+		if m := bulletRe.FindStringSubmatch(line); m != nil && !ruleRe.MatchString(strings.TrimSpace(line)) {
+			indent, marker, text = m[1], "•", m[2]
+		} else if m := orderedRe.FindStringSubmatch(line); m != nil {
+			indent, marker, text = m[1], m[2], m[3]
+		} else {
+			return i
+		}
+		if m := taskRe.FindStringSubmatch(text); m != nil {
+			marker = "☐"
+			if m[1] != " " {
+				marker = "☑"
+			}
+			text = text[len(m[0]):]
+		}
+		for i++; i < len(lines); i++ {
+			next := strings.TrimSpace(lines[i])
+			if next == "" || bulletRe.MatchString(lines[i]) || orderedRe.MatchString(lines[i]) ||
+				headingRe.MatchString(next) || fenceRe.MatchString(lines[i]) || !strings.HasPrefix(lines[i], " ") && strings.HasPrefix(next, ">") {
+				break
+			}
+			text += " " + next
+		}
+		depth := len(strings.ReplaceAll(indent, "\t", "    ")) / 2
+		lead := strings.Repeat("  ", depth+1)
+		first := lead + r.paint(sgrCyan, marker) + " "
+		rest := lead + strings.Repeat(" ", visibleWidth(marker)+1)
+		r.wrap(r.inline(text), first, rest)
+		if i < len(lines) && strings.TrimSpace(lines[i]) == "" &&
+			i+1 < len(lines) && (bulletRe.MatchString(lines[i+1]) || orderedRe.MatchString(lines[i+1])) {
+			i++ // a loose list continues after one blank line
+		}
+	}
+	return i
+}
+
+// inline renders links, code spans, and emphasis in text. Code spans are
+// set aside first so their content is left alone.
+func (r *renderer) inline(text string) string {
+	var spans []string
+	text = codeSpanRe.ReplaceAllStringFunc(text, func(s string) string {
+		code := codeSpanRe.FindStringSubmatch(s)[1]
+		if r.opts.Color {
+			spans = append(spans, r.paint(sgrCyan, code))
+		} else {
+			spans = append(spans, s)
+		}
+		return "\x00" + string(rune('0'+len(spans)-1)) + "\x00"
+	})
+	text = linkRe.ReplaceAllStringFunc(text, func(s string) string {
+		m := linkRe.FindStringSubmatch(s)
+		label, url := m[1], m[2]
+		if label == "" || label == url {
+			return r.paint(sgrUnderline, url)
+		}
+		return r.paint(sgrUnderline, label) + " " + r.paint(sgrDim, "("+url+")")
+	})
+	text = strongRe.ReplaceAllStringFunc(text, func(s string) string {
+		m := strongRe.FindStringSubmatch(s)
+		return r.paint(sgrBold, m[1]+m[2])
+	})
+	text = emRe.ReplaceAllStringFunc(text, func(s string) string {
+		m := emRe.FindStringSubmatch(s)
+		return m[1] + m[3] + r.paint(sgrItalic, m[2]+m[4])
+	})
+	for n, s := range spans {
+		text = strings.Replace(text, "\x00"+string(rune('0'+n))+"\x00", s, 1)
+	}
+	return text
+}
+
+// wrap writes text word-wrapped to the width, the first line after first
+// and the others after rest.
+func (r *renderer) wrap(text, first, rest string) {
+	prefix := first
+	line, lineWidth := "", 0
+	for _, word := range strings.Fields(text) {
+		w := visibleWidth(word)
+		limit := r.opts.Width - visibleWidth(prefix)
+		if line != "" && lineWidth+1+w > limit {
+			r.out.WriteString(prefix + line + "\n")
+			prefix, line, lineWidth = rest, "", 0
+		}
+		if line != "" {
+			line += " "
+			lineWidth++
+		}
+		line += word
+		lineWidth += w
+	}
+	if line != "" || prefix == first {
+		r.out.WriteString(prefix + line + "\n")
+	}
+}
+
+func stripANSI(s string) string {
+	return ansiRe.ReplaceAllString(s, "")
+}
+
+// visibleWidth returns the number of terminal columns s takes, not counting
+// escape sequences; East Asian wide characters take two.
+func visibleWidth(s string) int {
+	n := 0
+	for _, c := range stripANSI(s) {
+		switch width.LookupRune(c).Kind() {
+		case width.EastAsianWide, width.EastAsianFullwidth:
+			n += 2
+		default:
+			if utf8.RuneLen(c) > 0 {
+				n++
+			}
+		}
+	}
+	return n
+}
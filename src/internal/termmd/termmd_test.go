@@ -0,0 +1,79 @@
+package termmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender_Plain(t *testing.T) {
+	src := `<!-- vendored from acme/skills -->
+# Release Notes
+
+Write **release notes** from the _merged_ PRs, see [the guide](https://example.com/guide).
+
+## Steps
+
+1. Run ` + "`git log`" + `
+2. Group changes
+   by area
+- [x] done
+- [ ] todo
+
+> Keep it short.
+
+` + "```bash" + `
+git log --oneline
+` + "```" + `
+`
+	got := Render([]byte(src), Options{Width: 80})
+	want := `Release Notes
+═════════════
+
+Write release notes from the merged PRs, see the guide
+(https://example.com/guide).
+
+Steps
+─────
+
+  1. Run ` + "`git log`" + `
+  2. Group changes by area
+  ☑ done
+  ☐ todo
+
+│ Keep it short.
+
+    bash
+    git log --oneline
+`
+	if got != want {
+		t.Errorf("Render =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRender_Wraps(t *testing.T) {
+	got := Render([]byte("- one two three four five six\n"), Options{Width: 16})
+	want := "  • one two\n    three four\n    five six\n"
+	if got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
+
+func TestRender_Color(t *testing.T) {
+	got := Render([]byte("Use `pnpm`, **never** npm.\n"), Options{Width: 80, Color: true})
+	for _, want := range []string{"\x1b[36mpnpm\x1b[0m", "\x1b[1mnever\x1b[0m"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render = %q, missing %q", got, want)
+		}
+	}
+	if strings.Contains(got, "**") || strings.Contains(got, "`") {
+		t.Errorf("Render = %q, markers left in colored output", got)
+	}
+}
+
+func TestVisibleWidth(t *testing.T) {
+	for s, want := range map[string]int{"abc": 3, "\x1b[1mabc\x1b[0m": 3, "技能": 4} {
+		if got := visibleWidth(s); got != want {
+			t.Errorf("visibleWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
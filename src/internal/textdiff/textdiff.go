@@ -0,0 +1,147 @@
+// Package textdiff renders line-based unified diffs, so axon can show how a
+// file changed without shelling out to an external diff tool.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxCells bounds the size of the table used to align two texts (lines of a
+// times lines of b). Larger inputs are shown as a whole-file replacement.
+const MaxCells = 16 << 20
+
+type op struct {
+	kind   byte // ' ', '-', or '+'
+	text   string
+	ai, bi int // index of the next line of a and b before this op
+}
+
+// Unified returns a unified diff of a and b labelled aName and bName, with
+// context lines of unchanged text around each change. It returns "" when the
+// texts have the same lines. Line endings (\n or \r\n) are ignored.
+func Unified(aName, bName string, a, b []byte, context int) string {
+	ops := diffLines(splitLines(a), splitLines(b))
+	var out strings.Builder
+	for i := 0; i < len(ops); {
+		for i < len(ops) && ops[i].kind == ' ' {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+		start := max(i-context, 0)
+		end := i
+		for {
+			for end < len(ops) && ops[end].kind != ' ' {
+				end++
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next < len(ops) && next-end <= 2*context {
+				end = next
+				continue
+			}
+			end = min(end+context, len(ops))
+			break
+		}
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+		}
+		writeHunk(&out, ops[start:end])
+		i = end
+	}
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, ops []op) {
+	var aLen, bLen int
+	for _, o := range ops {
+		if o.kind != '+' {
+			aLen++
+		}
+		if o.kind != '-' {
+			bLen++
+		}
+	}
+	// An empty range names the line before it, as in diff(1).
+	aStart, bStart := ops[0].ai, ops[0].bi
+	if aLen > 0 {
+		aStart++
+	}
+	if bLen > 0 {
+		bStart++
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+	for _, o := range ops {
+		out.WriteByte(o.kind)
+		out.WriteString(o.text)
+		out.WriteByte('\n')
+	}
+}
+
+func hunkRange(start, n int) string {
+	if n == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, n)
+}
+
+// diffLines aligns a and b on their longest common subsequence, listing
+// removals before additions within a change.
+func diffLines(a, b []string) []op {
+	n, m := len(a), len(b)
+	var ops []op
+	if n*m > MaxCells {
+		for i, s := range a {
+			ops = append(ops, op{'-', s, i, 0})
+		}
+		for j, s := range b {
+			ops = append(ops, op{'+', s, n, j})
+		}
+		return ops
+	}
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && a[i] == b[j]:
+			ops = append(ops, op{' ', a[i], i, j})
+			i++
+			j++
+		case i < n && (j == m || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{'-', a[i], i, j})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j], i, j})
+			j++
+		}
+	}
+	return ops
+}
+
+func splitLines(data []byte) []string {
+	s := strings.TrimSuffix(string(data), "\n")
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSuffix(l, "\r")
+	}
+	return lines
+}
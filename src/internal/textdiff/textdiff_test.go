@@ -0,0 +1,40 @@
+package textdiff
+
+import "testing"
+
+func TestUnified(t *testing.T) {
+	a := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"
+	b := "one\nTWO\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\neleven\n"
+	want := `--- a
++++ b
+@@ -1,3 +1,3 @@
+ one
+-two
++TWO
+ three
+@@ -10 +10,2 @@
+ ten
++eleven
+`
+	if got := Unified("a", "b", []byte(a), []byte(b), 1); got != want {
+		t.Errorf("Unified =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnifiedMergesCloseHunks(t *testing.T) {
+	got := Unified("a", "b", []byte("1\n2\n3\n4\n"), []byte("x\n2\n3\ny\n"), 1)
+	want := "--- a\n+++ b\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n-4\n+y\n"
+	if got != want {
+		t.Errorf("Unified =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnifiedEdges(t *testing.T) {
+	if got := Unified("a", "b", []byte("x\r\ny\n"), []byte("x\ny"), 3); got != "" {
+		t.Errorf("line endings should not differ, got\n%s", got)
+	}
+	want := "--- /dev/null\n+++ b\n@@ -0,0 +1,2 @@\n+x\n+y\n"
+	if got := Unified("/dev/null", "b", nil, []byte("x\ny\n"), 3); got != want {
+		t.Errorf("new file =\n%s\nwant\n%s", got, want)
+	}
+}
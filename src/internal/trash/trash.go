@@ -0,0 +1,262 @@
+// Package trash keeps what axon removes on the user's behalf, such as
+// conflict files deleted by 'axon doctor --fix' and backups pruned by
+// 'axon gc', in ~/.axon/trash for a retention period instead of deleting it
+// outright, so a removal can be undone with 'axon trash restore'.
+//
+// Every trashed item gets its own directory named by its ID, holding the
+// item under its original base name and a meta.json describing it.
+package trash
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+	"github.com/kamusis/axon-cli/internal/portable"
+)
+
+// metaFile describes the item next to it in an entry directory.
+const metaFile = "meta.json"
+
+// Entry is one trashed item.
+type Entry struct {
+	ID     string    `json:"-"`
+	Path   string    `json:"path"`   // where the item was
+	Reason string    `json:"reason"` // what removed it, e.g. "gc: backups"
+	Time   time.Time `json:"time"`
+	Size   int64     `json:"size"`
+	IsDir  bool      `json:"dir,omitempty"`
+
+	dir string // the entry directory
+}
+
+// Item returns the path of the trashed item inside the trash.
+func (e Entry) Item() string {
+	return filepath.Join(e.dir, filepath.Base(e.Path))
+}
+
+// Dir returns the trash directory (~/.axon/trash).
+func Dir() (string, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(axonDir, "trash"), nil
+}
+
+// Move moves path into the trash at root, recording reason. Items on
+// another filesystem are copied, then removed.
+func Move(root, path, reason string) (Entry, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return Entry{}, err
+	}
+	info, err := os.Lstat(abs)
+	if err != nil {
+		return Entry{}, err
+	}
+	now := time.Now()
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	e := Entry{
+		ID:     now.Format("20060102-150405") + "-" + hex.EncodeToString(suffix),
+		Path:   abs,
+		Reason: reason,
+		Time:   now,
+		Size:   treeSize(abs),
+		IsDir:  info.IsDir(),
+	}
+	e.dir = filepath.Join(root, e.ID)
+	if err := os.MkdirAll(e.dir, 0o700); err != nil {
+		return Entry{}, fmt.Errorf("cannot create trash entry: %w", err)
+	}
+	data, _ := json.MarshalIndent(e, "", "  ")
+	if err := os.WriteFile(filepath.Join(e.dir, metaFile), data, 0o600); err != nil {
+		_ = os.RemoveAll(e.dir)
+		return Entry{}, fmt.Errorf("cannot write trash entry: %w", err)
+	}
+	if err := move(abs, e.Item()); err != nil {
+		_ = os.RemoveAll(e.dir)
+		return Entry{}, fmt.Errorf("cannot move %s to the trash: %w", abs, err)
+	}
+	return e, nil
+}
+
+// List returns the entries in the trash at root, oldest first. Directories
+// without a readable meta.json are skipped.
+func List(root string) ([]Entry, error) {
+	dirs, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []Entry
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		dir := filepath.Join(root, d.Name())
+		data, err := os.ReadFile(filepath.Join(dir, metaFile))
+		if err != nil {
+			continue
+		}
+		var e Entry
+		if json.Unmarshal(data, &e) != nil || e.Path == "" {
+			continue
+		}
+		e.ID, e.dir = d.Name(), dir
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Time.Equal(out[j].Time) {
+			return out[i].Time.Before(out[j].Time)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// Find returns the entry whose ID is ref or starts with it.
+func Find(entries []Entry, ref string) (Entry, error) {
+	var matches []Entry
+	for _, e := range entries {
+		if e.ID == ref {
+			return e, nil
+		}
+		if ref != "" && strings.HasPrefix(e.ID, ref) {
+			matches = append(matches, e)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Entry{}, fmt.Errorf("no trash entry %q (see 'axon trash list')", ref)
+	case 1:
+		return matches[0], nil
+	}
+	return Entry{}, fmt.Errorf("trash entry %q is ambiguous: %d entries start with it", ref, len(matches))
+}
+
+// Restore moves the item of e back to to, or to where it was when to is
+// empty, and removes the entry. It refuses to overwrite anything.
+func Restore(e Entry, to string) (string, error) {
+	if to == "" {
+		to = e.Path
+	}
+	if _, err := os.Lstat(to); err == nil {
+		return "", fmt.Errorf("%s already exists; restore to another path with --to", to)
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return "", err
+	}
+	if err := move(e.Item(), to); err != nil {
+		return "", fmt.Errorf("cannot restore %s: %w", to, err)
+	}
+	return to, os.RemoveAll(e.dir)
+}
+
+// Delete removes e from the trash for good.
+func Delete(e Entry) error {
+	return os.RemoveAll(e.dir)
+}
+
+// Expired returns the entries trashed longer than retention before now. A
+// zero retention keeps everything.
+func Expired(entries []Entry, retention time.Duration, now time.Time) []Entry {
+	if retention <= 0 {
+		return nil
+	}
+	var out []Entry
+	for _, e := range entries {
+		if now.Sub(e.Time) > retention {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// move renames src to dst, falling back to copy and remove when they are on
+// different filesystems.
+func move(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	if err := copyTree(src, dst); err != nil {
+		_ = os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+// copyTree copies src, a file, directory, or symlink, to dst.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := portable.LongPath(filepath.Join(dst, rel))
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyFile(p, target, info.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(portable.LongPath(src))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(portable.LongPath(dst), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// treeSize returns the total size of the regular files under path.
+func treeSize(path string) int64 {
+	var n int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.Mode().IsRegular() {
+			n += info.Size()
+		}
+		return nil
+	})
+	return n
+}
@@ -0,0 +1,85 @@
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMoveListRestore(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "trash")
+	work := t.TempDir()
+	file := filepath.Join(work, "notes.conflict-laptop.md")
+	dir := filepath.Join(work, "backup_20260101000000")
+	if err := os.WriteFile(file, []byte("mine"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "a.md"), []byte("12345"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fe, err := Move(root, file, "doctor: conflicts")
+	if err != nil {
+		t.Fatalf("Move file: %v", err)
+	}
+	de, err := Move(root, dir, "gc: backups")
+	if err != nil {
+		t.Fatalf("Move dir: %v", err)
+	}
+	if _, err := os.Lstat(file); !os.IsNotExist(err) {
+		t.Fatalf("file still in place: %v", err)
+	}
+	if de.Size != 5 || !de.IsDir || fe.IsDir {
+		t.Errorf("entries = %+v, %+v", fe, de)
+	}
+
+	entries, err := List(root)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("List = %v, %v", entries, err)
+	}
+	got, err := Find(entries, fe.ID[:len(fe.ID)-1])
+	if err != nil || got.Path != file || got.Reason != "doctor: conflicts" {
+		t.Fatalf("Find = %+v, %v", got, err)
+	}
+
+	if _, err := Restore(got, ""); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if data, err := os.ReadFile(file); err != nil || string(data) != "mine" {
+		t.Fatalf("restored file = %q, %v", data, err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Restore(de, ""); err == nil {
+		t.Fatal("Restore overwrote an existing directory")
+	}
+	other := filepath.Join(work, "restored")
+	if _, err := Restore(de, other); err != nil {
+		t.Fatalf("Restore --to: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(other, "sub", "a.md")); err != nil {
+		t.Fatalf("restored dir: %v", err)
+	}
+	if entries, _ := List(root); len(entries) != 0 {
+		t.Fatalf("entries left after restore: %v", entries)
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Now()
+	entries := []Entry{
+		{ID: "old", Time: now.Add(-48 * time.Hour)},
+		{ID: "new", Time: now.Add(-time.Hour)},
+	}
+	if got := Expired(entries, 24*time.Hour, now); len(got) != 1 || got[0].ID != "old" {
+		t.Errorf("Expired = %v", got)
+	}
+	if got := Expired(entries, 0, now); len(got) != 0 {
+		t.Errorf("zero retention expired %v", got)
+	}
+}
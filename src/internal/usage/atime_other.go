@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !windows
+
+package usage
+
+import (
+	"os"
+	"time"
+)
+
+// accessTime is not available here; skills are only tracked through
+// 'axon serve'.
+func accessTime(os.FileInfo) (time.Time, bool) {
+	return time.Time{}, false
+}
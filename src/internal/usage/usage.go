@@ -0,0 +1,185 @@
+// Package usage estimates which skills tools actually read. Tools read
+// skills through symlinks into the Hub, so the access times of Hub files
+// show when a skill was last opened; 'axon serve' also records the skills
+// it hands out. The last read of each skill is kept in ~/.axon/usage.json.
+//
+// Access times are an estimate: filesystems mounted noatime never update
+// them, and relatime updates them at most once a day. Files are only
+// counted as read when their access time is after their modification
+// time, so writing or checking out a file is not a read, and reads that
+// hit most skills within the same minute are taken for a bulk scan (an
+// index build, a backup) and ignored.
+package usage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/kamusis/axon-cli/internal/config"
+)
+
+// Sources of a recorded read.
+const (
+	SourceAtime = "atime"
+	SourceServe = "serve"
+)
+
+// bulkScanMin is the fewest skills read within one minute that can count
+// as a bulk scan; below it, half of the Hub must be read at once.
+const bulkScanMin = 5
+
+// Read is the last recorded read of one skill.
+type Read struct {
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"`
+}
+
+// Store is the persisted usage record.
+type Store struct {
+	Since   time.Time       `json:"since"`             // when tracking started
+	Sampled time.Time       `json:"sampled,omitempty"` // last access-time sample
+	Skills  map[string]Read `json:"skills"`
+
+	path string
+}
+
+// Path returns the usage file (~/.axon/usage.json).
+func Path() (string, error) {
+	axonDir, err := config.AxonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(axonDir, "usage.json"), nil
+}
+
+// Load reads the store at path. A missing file starts tracking at now.
+func Load(path string, now time.Time) (*Store, error) {
+	s := &Store{Since: now, Skills: map[string]Read{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	if s.Skills == nil {
+		s.Skills = map[string]Read{}
+	}
+	return s, nil
+}
+
+// Save writes the store back to the path it was loaded from.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Record notes a read of skill at t, unless a later one is known.
+func (s *Store) Record(skill string, t time.Time, source string) {
+	if prev, ok := s.Skills[skill]; ok && !t.After(prev.Time) {
+		return
+	}
+	s.Skills[skill] = Read{Time: t, Source: source}
+}
+
+// Sample records the latest access time of each skill's files; skills maps
+// names to directories. Access times after now are ignored, so the caller
+// can pass a time taken before it read the skills itself. It returns how
+// many skills had a new read.
+func (s *Store) Sample(skills map[string]string, now time.Time) int {
+	reads := make(map[string]time.Time)
+	for name, dir := range skills {
+		t, ok := lastRead(dir, now)
+		if !ok {
+			continue
+		}
+		if prev, known := s.Skills[name]; known && !t.After(prev.Time) {
+			continue
+		}
+		reads[name] = t
+	}
+
+	// Most of the Hub read in the same minute is a scan, not tool use.
+	perMinute := make(map[time.Time]int)
+	for _, t := range reads {
+		perMinute[t.Truncate(time.Minute)]++
+	}
+	threshold := max(bulkScanMin, (len(skills)+1)/2)
+	n := 0
+	for name, t := range reads {
+		if perMinute[t.Truncate(time.Minute)] >= threshold {
+			continue
+		}
+		s.Record(name, t, SourceAtime)
+		n++
+	}
+	s.Sampled = now
+	return n
+}
+
+// lastRead returns the latest access time up to until among dir's files
+// that were read after they were last written. .git directories are
+// skipped.
+func lastRead(dir string, until time.Time) (time.Time, bool) {
+	var latest time.Time
+	_ = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		at, ok := accessTime(info)
+		if !ok || at.After(until) || !at.After(info.ModTime().Add(time.Second)) {
+			return nil
+		}
+		if at.After(latest) {
+			latest = at
+		}
+		return nil
+	})
+	return latest, !latest.IsZero()
+}
+
+// Unused returns, sorted, the skills with no recorded read since cutoff.
+// It returns nil while tracking has run for less than that, since a skill
+// could not have shown a read yet.
+func (s *Store) Unused(skills []string, cutoff time.Time) []string {
+	if s.Since.After(cutoff) {
+		return nil
+	}
+	var out []string
+	for _, name := range skills {
+		if r, ok := s.Skills[name]; !ok || r.Time.Before(cutoff) {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,115 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// makeSkill creates dir/name/SKILL.md with the given access and
+// modification times and returns the skill directory.
+func makeSkill(t *testing.T, dir, name string, atime, mtime time.Time) string {
+	t.Helper()
+	skill := filepath.Join(dir, name)
+	if err := os.MkdirAll(skill, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(skill, "SKILL.md")
+	if err := os.WriteFile(file, []byte("# "+name), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, atime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	return skill
+}
+
+func TestSample(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	written := now.Add(-30 * 24 * time.Hour)
+	read := now.Add(-2 * 24 * time.Hour)
+
+	skills := map[string]string{
+		"read":      makeSkill(t, dir, "read", read, written),
+		"unread":    makeSkill(t, dir, "unread", written, written),
+		"by-us":     makeSkill(t, dir, "by-us", now.Add(time.Minute), written),
+		"no-access": makeSkill(t, dir, "no-access", written.Add(-time.Hour), written),
+	}
+	if _, ok := accessTime(mustStat(t, filepath.Join(skills["read"], "SKILL.md"))); !ok {
+		t.Skip("access times are not available on this platform")
+	}
+
+	s, err := Load(filepath.Join(dir, "usage.json"), now.Add(-100*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := s.Sample(skills, now); n != 1 {
+		t.Fatalf("Sample = %d new read(s), want 1 (%+v)", n, s.Skills)
+	}
+	if r := s.Skills["read"]; !r.Time.Equal(read) || r.Source != SourceAtime {
+		t.Errorf("read = %+v", r)
+	}
+
+	got := s.Unused([]string{"by-us", "no-access", "read", "unread"}, now.Add(-90*24*time.Hour))
+	if want := []string{"by-us", "no-access", "unread"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unused = %v, want %v", got, want)
+	}
+	if got := s.Unused([]string{"unread"}, s.Since.Add(-time.Hour)); got != nil {
+		t.Errorf("Unused before tracking started = %v, want nil", got)
+	}
+
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(filepath.Join(dir, "usage.json"), now)
+	if err != nil || !loaded.Since.Equal(s.Since) || !loaded.Skills["read"].Time.Equal(read) {
+		t.Errorf("Load after Save = %+v, %v", loaded, err)
+	}
+}
+
+func TestSample_IgnoresBulkScan(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	written := now.Add(-30 * 24 * time.Hour)
+	scan := now.Add(-time.Hour)
+
+	skills := map[string]string{
+		"used": makeSkill(t, dir, "used", now.Add(-3*time.Hour), written),
+	}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		skills[name] = makeSkill(t, dir, name, scan.Add(time.Second), written)
+	}
+	if _, ok := accessTime(mustStat(t, filepath.Join(skills["used"], "SKILL.md"))); !ok {
+		t.Skip("access times are not available on this platform")
+	}
+
+	s, _ := Load(filepath.Join(dir, "usage.json"), now)
+	if n := s.Sample(skills, now); n != 1 {
+		t.Fatalf("Sample = %d, want only the skill outside the scan (%+v)", n, s.Skills)
+	}
+	if _, ok := s.Skills["used"]; !ok {
+		t.Errorf("skills = %+v, want used", s.Skills)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	s, _ := Load(filepath.Join(t.TempDir(), "usage.json"), time.Now())
+	t1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.Record("pdf", t1.Add(time.Hour), SourceServe)
+	s.Record("pdf", t1, SourceAtime)
+	if r := s.Skills["pdf"]; !r.Time.Equal(t1.Add(time.Hour)) || r.Source != SourceServe {
+		t.Errorf("an older read replaced a newer one: %+v", r)
+	}
+}
+
+func mustStat(t *testing.T, p string) os.FileInfo {
+	t.Helper()
+	info, err := os.Stat(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info
+}